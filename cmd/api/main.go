@@ -10,25 +10,30 @@ import (
 	"syscall"
 	"time"
 
-	"github.com/go-chi/chi/v5"
-	"github.com/go-chi/chi/v5/middleware"
-	"github.com/go-chi/cors"
 	_ "github.com/joho/godotenv/autoload"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	"pemira-api/internal/adminuser"
 	"pemira-api/internal/analytics"
+	"pemira-api/internal/archive"
 	"pemira-api/internal/auth"
 	"pemira-api/internal/candidate"
 	"pemira-api/internal/config"
 	"pemira-api/internal/dpt"
 	"pemira-api/internal/election"
+	"pemira-api/internal/electionkey"
 	"pemira-api/internal/electionvoter"
-	httpMiddleware "pemira-api/internal/http/middleware"
-	"pemira-api/internal/http/response"
+	"pemira-api/internal/embargo"
+	"pemira-api/internal/health"
+	"pemira-api/internal/mail"
 	"pemira-api/internal/master"
 	"pemira-api/internal/monitoring"
+	"pemira-api/internal/rbac"
+	"pemira-api/internal/ratelimit"
+	"pemira-api/internal/recount"
+	"pemira-api/internal/results"
 	"pemira-api/internal/settings"
+	"pemira-api/internal/shared/constants"
+	"pemira-api/internal/telemetry"
 	"pemira-api/internal/tps"
 	"pemira-api/internal/voter"
 	"pemira-api/internal/voting"
@@ -37,7 +42,7 @@ import (
 )
 
 func main() {
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	logger := slog.New(telemetry.NewLogHandler(slog.NewJSONHandler(os.Stdout, nil)))
 	slog.SetDefault(logger)
 
 	cfg, err := config.Load()
@@ -47,12 +52,27 @@ func main() {
 	}
 
 	ctx := context.Background()
-	pool, err := database.NewPostgresPool(ctx, cfg.DatabaseURL)
+	shutdownTracing, err := telemetry.SetupTracing(ctx, telemetry.TracingConfig{
+		Endpoint:    cfg.OTelEndpoint,
+		ServiceName: cfg.OTelServiceName,
+		Environment: cfg.AppEnv,
+		SampleRatio: cfg.OTelTraceSampleRate,
+	})
+	if err != nil {
+		logger.Error("failed to set up tracing", "error", err)
+		os.Exit(1)
+	}
+	if cfg.OTelEndpoint != "" {
+		logger.Info("tracing enabled", "endpoint", cfg.OTelEndpoint)
+	}
+
+	pool, err := database.NewPostgresPool(ctx, cfg.DatabaseURL, database.WithTracer(telemetry.QueryTracer{}))
 	if err != nil {
 		logger.Error("failed to connect to database", "error", err)
 		os.Exit(1)
 	}
 	defer pool.Close()
+	telemetry.RegisterPool(pool)
 
 	logger.Info("connected to database")
 
@@ -66,11 +86,12 @@ func main() {
 	candidateStatsProvider := candidate.NewPgStatsProvider(pool)
 	monitoringRepo := monitoring.NewPgRepository(pool)
 	tpsRepo := tps.NewPostgresRepositoryFromPool(pool)
+	tpsAllocationRepo := tps.NewPgAllocationRepository(pool)
+	tpsCheckinLifecycleRepo := tps.NewPgCheckinLifecycleRepository(pool)
 
 	voterRepo := voting.NewVoterRepository()
 	candidateRepo := voting.NewCandidateRepository()
 	voteRepo := voting.NewVoteRepository()
-	statsRepo := voting.NewVoteStatsRepository()
 	auditSvc := voting.NewAuditService()
 
 	// Voter profile repositories
@@ -96,12 +117,95 @@ func main() {
 	masterAdapter := auth.NewMasterRepositoryAdapter(masterRepo)
 	authService.SetMasterRepository(masterAdapter)
 
+	// Two-factor authentication
+	authService.SetMFAConfig(auth.MFAConfig{
+		Issuer:        cfg.TOTPIssuer,
+		RequiredRoles: parseRoles(cfg.TOTPRequiredRoles),
+		EncryptionKey: cfg.TOTPEncryptionKey,
+	})
+	if err := authService.CheckMFAKey(ctx); err != nil {
+		logger.Error("2FA is enrolled without TOTP_ENCRYPTION_KEY; set it and run pemiractl user rekey-2fa", "error", err)
+		os.Exit(1)
+	}
+
+	// Institutional SSO (OIDC)
+	authService.SetSSOConfig(auth.SSOConfig{
+		Issuer:              cfg.OIDCIssuer,
+		ClientID:            cfg.OIDCClientID,
+		ClientSecret:        cfg.OIDCClientSecret,
+		RedirectURL:         cfg.OIDCRedirectURL,
+		FrontendRedirectURL: cfg.OIDCFrontendRedirectURL,
+		Scopes:              strings.Fields(strings.ReplaceAll(cfg.OIDCScopes, ",", " ")),
+		NIMClaim:            cfg.OIDCNIMClaim,
+		NIDNClaim:           cfg.OIDCNIDNClaim,
+		NIPClaim:            cfg.OIDCNIPClaim,
+		FacultyClaim:        cfg.OIDCFacultyClaim,
+	})
+	authService.DisablePasswordLogin(parseRoles(cfg.PasswordLoginDisabledRoles)...)
+	if authService.SSOEnabled() {
+		logger.Info("sso enabled", "issuer", cfg.OIDCIssuer)
+	}
+
+	// Email verification on self-registration
+	if !cfg.EmailVerificationRequired {
+		logger.Warn("EMAIL_VERIFICATION_REQUIRED is off; self-registered accounts are active without proving they own the email, so anyone who knows a NIM can register it")
+	}
+	var mailSender mail.Sender = mail.LogSender{}
+	if cfg.SMTPHost != "" {
+		mailSender = mail.NewSMTPSender(mail.SMTPConfig{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			From:     cfg.SMTPFrom,
+		})
+	} else if cfg.EmailVerificationRequired {
+		logger.Warn("EMAIL_VERIFICATION_REQUIRED is set but SMTP_HOST is empty; verification emails are only logged")
+	}
+	authService.SetEmailVerification(auth.EmailVerificationConfig{
+		Required: cfg.EmailVerificationRequired,
+		AllowedDomains: map[string][]string{
+			"STUDENT":  parseList(cfg.EmailDomainsStudent),
+			"LECTURER": parseList(cfg.EmailDomainsLecturer),
+			"STAFF":    parseList(cfg.EmailDomainsStaff),
+		},
+		RosterEmail: masterAdapter.RosterEmail,
+		LinkURL:     cfg.EmailVerificationURL,
+		Sender:      mailSender,
+	})
+
+	// Progressive lockout after failed password logins
+	authService.SetLockout(auth.LockoutConfig{
+		Threshold:    cfg.LoginLockoutThreshold,
+		BaseDuration: cfg.LoginLockoutBase,
+		MaxDuration:  cfg.LoginLockoutMax,
+		Window:       cfg.LoginLockoutWindow,
+	})
+
 	electionService := election.NewService(electionRepo, electionAdminRepo)
 	electionAdminService := election.NewAdminService(electionAdminRepo)
 	dptService := dpt.NewService(dptRepo)
 	tpsAdminService := tps.NewAdminService(tpsAdminRepo)
 	tpsService := tps.NewService(tpsRepo)
 	tpsPanelService := tps.NewPanelService(tpsRepo)
+	tpsAllocationService := tps.NewAllocationService(tpsAllocationRepo)
+	tpsAllocationService.SetMailer(mailSender)
+	tpsAdminService.SetAllocation(tpsAllocationService)
+	tpsService.SetAllocation(tpsAllocationService)
+	tpsPanelService.SetAllocation(tpsAllocationService)
+	tpsCheckinLifecycleService := tps.NewCheckinLifecycleService(tpsCheckinLifecycleRepo, tps.CheckinTTL{
+		Pending:  cfg.CheckinPendingTTL,
+		Approved: cfg.CheckinApprovedTTL,
+	})
+	tpsService.SetCheckinLifecycle(tpsCheckinLifecycleService)
+	tpsQueueService := tps.NewQueueService(tps.NewPgQueueRepository(pool))
+	tpsQueueService.SetCheckinLifecycle(tpsCheckinLifecycleService)
+	tpsService.SetQueue(tpsQueueService)
+	tpsPanelService.SetQueue(tpsQueueService)
+	tpsScheduleService := tps.NewScheduleService(tps.NewPgScheduleRepository(pool))
+	tpsService.SetSchedule(tpsScheduleService)
+	tpsPanelService.SetSchedule(tpsScheduleService)
+	tpsIncidentService := tps.NewIncidentService(tps.NewPgIncidentRepository(pool))
 	candidateService := candidate.NewService(candidatePgRepo, candidateStatsProvider)
 	candidateHandler := candidate.NewHandler(candidateService)
 	monitoringService := monitoring.NewService(monitoringRepo)
@@ -112,7 +216,6 @@ func main() {
 		voterRepo,
 		candidateRepo,
 		voteRepo,
-		statsRepo,
 		auditSvc,
 	)
 
@@ -123,22 +226,73 @@ func main() {
 	adminUserRepo := adminuser.NewPgRepository(pool)
 	adminUserService := adminuser.NewService(adminUserRepo)
 	masterService := master.NewService(masterRepo)
+	masterService.SetRosterSource(master.RosterSourceConfig{
+		URL:   cfg.RosterSourceURL,
+		Token: cfg.RosterSourceToken,
+	})
+	electionVoterService.SetRoster(masterService)
 
 	// Analytics
 	analyticsRepo := analytics.NewAnalyticsRepo(pool)
 	analyticsService := analytics.NewService(analyticsRepo)
 
+	// Election export/import bundles
+	archiveService := archive.NewService(archive.NewPgRepository(pool))
+
+	// Permissions and scoped role assignments
+	rbacService := rbac.NewService(rbac.NewPgRepository(pool))
+
+	// Threshold election keys and end-to-end encrypted ballots
+	electionKeyService := electionkey.NewService(electionkey.NewPgRepository(pool))
+	votingService.SetBallotVerifier(electionKeyService)
+	votingService.SetTPSSchedule(tpsScheduleService)
+
+	// Tally consistency checks against the ballots
+	recountService := recount.NewService(recount.NewPgRepository(pool))
+
+	// Results embargo: candidate tallies stay hidden from every role until
+	// the election's embargo lifts
+	embargoService := embargo.NewService(embargo.NewPgRepository(pool))
+	embargoService.SetMailer(mailSender)
+	monitoringService.SetEmbargo(embargoService)
+	votingService.SetEmbargo(embargoService)
+	analyticsService.SetEmbargo(embargoService)
+	recountService.SetEmbargo(embargoService)
+	candidateService.SetEmbargo(embargoService)
+	archiveService.SetEmbargo(embargoService)
+
+	// Public results and turnout, served from precomputed snapshots
+	resultsService := results.NewService(results.NewPgRepository(pool))
+
 	// Initialize handlers
 	authHandler := auth.NewAuthHandler(authService)
 	electionHandler := election.NewHandler(electionService)
 	electionAdminHandler := election.NewAdminHandler(electionAdminService)
+	archiveHandler := archive.NewHandler(archiveService)
+	rbacHandler := rbac.NewHandler(rbacService)
+	electionKeyHandler := electionkey.NewHandler(electionKeyService)
+	recountHandler := recount.NewHandler(recountService)
+	embargoHandler := embargo.NewHandler(embargoService)
+	resultsHandler := results.NewHandler(resultsService)
 	votingHandler := voting.NewVotingHandler(votingService)
 	dptHandler := dpt.NewHandler(dptService)
 	tpsAdminHandler := tps.NewAdminHandler(tpsAdminService)
 	tpsHandler := tps.NewTPSHandler(tpsService)
 	tpsPanelHandler := tps.NewPanelHandler(tpsPanelService)
+	tpsAllocationHandler := tps.NewAllocationHandler(tpsAllocationService)
+	tpsCheckinLifecycleHandler := tps.NewCheckinLifecycleHandler(tpsCheckinLifecycleService)
+	tpsQueueHandler := tps.NewQueueHandler(tpsPanelService, tpsQueueService)
+	tpsScheduleHandler := tps.NewScheduleHandler(tpsPanelService, tpsScheduleService)
+	tpsIncidentHandler := tps.NewIncidentHandler(tpsPanelService, tpsIncidentService)
 	tpsPanelAuthHandler := tps.NewPanelAuthHandler(authService, tpsRepo)
 	candidateAdminHandler := candidate.NewAdminHandler(candidateService)
+	candidacyRepo := candidate.NewPgCandidacyRepository(pool)
+	candidacyService := candidate.NewCandidacyService(candidacyRepo, candidatePgRepo)
+	candidacyHandler := candidate.NewCandidacyHandler(candidacyService)
+	ballotDrawRepo := candidate.NewPgBallotDrawRepository(pool)
+	ballotDrawHandler := candidate.NewBallotDrawHandler(candidate.NewBallotDrawService(ballotDrawRepo, candidacyRepo))
+	candidateService.SetNumberLock(ballotDrawRepo)
+	candidacyService.SetNumberLock(ballotDrawRepo)
 	monitoringHandler := monitoring.NewHandler(monitoringService)
 	voterProfileHandler := voter.NewProfileHandler(voterProfileService)
 	settingsHandler := settings.NewHandler(settingsService)
@@ -154,293 +308,114 @@ func main() {
 	allowedOrigins := parseOrigins(cfg.CORSAllowedOrigins)
 	hub := ws.NewHub()
 	go hub.Run(ctx)
-
-	r := chi.NewRouter()
-
-	// CORS middleware
-	r.Use(cors.Handler(cors.Options{
-		AllowedOrigins:   allowedOrigins,
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS", "PATCH"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
-		ExposedHeaders:   []string{"Link"},
-		AllowCredentials: true,
-		MaxAge:           300,
-	}))
-
-	r.Use(middleware.RequestID)
-	r.Use(middleware.RealIP)
-	r.Use(middleware.Logger)
-	r.Use(middleware.Recoverer)
-	r.Use(middleware.Timeout(60 * time.Second))
-
-	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
-		response.Success(w, http.StatusOK, map[string]string{
-			"status": "ok",
-		})
-	})
-
-	r.Handle("/metrics", promhttp.Handler())
-
+	go recountService.Run(ctx, cfg.RecountInterval)
+	go resultsService.Run(ctx, cfg.ResultsSnapshotInterval)
+	go masterService.RunRosterSync(ctx, cfg.RosterSyncInterval)
+
+	// TPS panels follow their queue over /ws/tps/{tpsID}/queue
+	tpsWSHub := tps.NewWSHub()
+	go tpsWSHub.Run()
+	tpsWSHandler := tps.NewWSHandler(tpsWSHub, tpsService)
+	tpsCheckinLifecycleService.SetNotifier(tpsWSHub)
+	tpsQueueService.SetNotifier(tpsWSHub)
+	go tpsCheckinLifecycleService.Run(ctx, cfg.CheckinSweepInterval)
+
+	// Rate limits, shared through Redis when REDIS_URL is set
+	var limitStore ratelimit.Store
+	if cfg.RateLimitEnabled {
+		limitStore = ratelimit.NewMemoryStore()
+		if cfg.RedisURL != "" {
+			redisStore, err := ratelimit.NewRedisStore(cfg.RedisURL)
+			if err != nil {
+				logger.Error("invalid REDIS_URL", "error", err)
+				os.Exit(1)
+			}
+			if err := redisStore.Ping(ctx); err != nil {
+				logger.Warn("redis unreachable; requests are not limited until it recovers", "error", err)
+			}
+			defer redisStore.Close()
+			limitStore = redisStore
+		}
+	}
+	limiter := ratelimit.NewLimiter(limitStore)
+	limitPolicy := func(name, spec string, key ratelimit.KeyFunc) ratelimit.Policy {
+		p, err := ratelimit.NewPolicy(name, spec, key)
+		if err != nil {
+			logger.Error("invalid rate limit", "error", err)
+			os.Exit(1)
+		}
+		return p
+	}
+	loginPolicy := limitPolicy("login", cfg.RateLimitLogin, ratelimit.ByIP)
+	limitLogin := limiter.Middleware(loginPolicy,
+		limitPolicy("login_username", cfg.RateLimitLoginUsername, ratelimit.ByJSONField("username")))
+	limitLoginStep := limiter.Middleware(loginPolicy)
+	limitRegister := limiter.Middleware(limitPolicy("register", cfg.RateLimitRegister, ratelimit.ByIP))
+	limitReset := limiter.Middleware(limitPolicy("reset", cfg.RateLimitReset, ratelimit.ByIP))
+	limitVote := limiter.Middleware(limitPolicy("vote", cfg.RateLimitVote, ratelimit.ByUserID))
+	limitQRScan := limiter.Middleware(limitPolicy("qr_scan", cfg.RateLimitQRScan, ratelimit.ByUserID))
+
+	healthChecks := []health.Check{
+		health.Database(pool),
+		health.PoolSaturation(pool, cfg.HealthPoolSaturation),
+	}
+	if url, key := os.Getenv("SUPABASE_URL"), os.Getenv("SUPABASE_SECRET_KEY"); url != "" && key != "" {
+		healthChecks = append(healthChecks, health.Storage(&http.Client{Timeout: 5 * time.Second}, url, key))
+	}
+	healthHandler := health.NewHandler(healthChecks...)
+	healthHandler.SetMaintenance(settingsService)
 	wsHandler := ws.NewHandler(hub)
-	wsHandler.RegisterRoutes(r)
 
-	r.Route("/api/v1", func(r chi.Router) {
-		r.Get("/", func(w http.ResponseWriter, r *http.Request) {
-			response.Success(w, http.StatusOK, map[string]string{
-				"message": "PEMIRA API v1",
-			})
-		})
-
-		// Metadata for dropdowns
-		r.Get("/meta/faculties-programs", masterHandler.GetFacultyPrograms)
-		r.Get("/master/faculties", masterHandler.GetFaculties)
-		r.Get("/master/study-programs", masterHandler.GetStudyPrograms)
-		r.Get("/master/lecturer-units", masterHandler.GetLecturerUnits)
-		r.Get("/master/lecturer-positions", masterHandler.GetLecturerPositions)
-		r.Get("/master/staff-units", masterHandler.GetStaffUnits)
-		r.Get("/master/staff-positions", masterHandler.GetStaffPositions)
-
-		// Auth routes (public)
-		r.Post("/auth/register/student", authHandler.RegisterStudent)
-		r.Post("/auth/register/lecturer-staff", authHandler.RegisterLecturerStaff)
-		r.Post("/auth/login", authHandler.Login)
-		r.Post("/auth/refresh", authHandler.RefreshToken)
-		r.Get("/auth/logout-page", authHandler.LogoutPage)
-		r.Post("/auth/reset-password", authHandler.ResetPassword)
-		r.Post("/tps-panel/auth/login", tpsPanelAuthHandler.PanelLogin)
-
-		// Public election routes
-		r.Get("/elections/current", electionHandler.GetCurrent)
-		r.Get("/elections/current-for-registration", electionHandler.GetCurrentForRegistration)
-		r.Get("/elections", electionHandler.ListPublic)
-		r.Get("/elections/{electionID}/phases", electionHandler.GetPublicPhases)
-		r.Get("/elections/{electionID}/timeline", electionHandler.GetPublicPhases)
-		r.Get("/elections/{electionID}/qr-codes", candidateHandler.ListWithQR)
-		r.Get("/elections/{electionID}/candidates/{candidateID}", candidateHandler.DetailPublic)
-		r.Get("/elections/{electionID}/candidates/{candidateID}/media/profile", candidateHandler.GetPublicProfileMedia)
-		r.Get("/elections/{electionID}/candidates", candidateHandler.ListPublic)
-
-		// Protected routes
-		r.Group(func(r chi.Router) {
-			r.Use(httpMiddleware.JWTAuth(jwtManager))
-
-			// Auth protected
-			r.Get("/auth/me", authHandler.Me)
-			r.Post("/auth/logout", authHandler.Logout)
-
-			// Voter profile routes (authenticated - voter only)
-			voterProfileHandler.RegisterRoutes(r)
-
-			// Election routes (authenticated)
-			r.Get("/elections/{electionID}/me/status", electionHandler.GetMeStatus)
-			r.Get("/elections/{electionID}/me/history", electionHandler.GetMeHistory)
-
-			// Election-specific voter enrollment (self-service)
-			r.Post("/voters/me/elections/{electionID}/register", electionVoterHandler.VoterSelfRegister)
-			r.Get("/voters/me/elections/{electionID}/status", electionVoterHandler.VoterStatus)
-
-			// Voter TPS QR (student/admin)
-			r.Get("/voters/{voterID}/tps/qr", votingHandler.GetVoterTPSQR)
-			r.Post("/voters/{voterID}/tps/qr", votingHandler.GenerateVoterTPSQR)
-
-			// TPS student check-in
-			r.Post("/tps/checkin/scan", tpsHandler.ScanQR)
-			r.Get("/tps/checkin/status", tpsHandler.StudentCheckinStatus)
-
-			// Voting routes (student, lecturer, staff)
-			r.Group(func(r chi.Router) {
-				r.Use(httpMiddleware.JWTAuth(jwtManager))
-				r.Post("/voting/online/cast", votingHandler.CastOnlineVote)
-				r.Post("/voting/online/signature", votingHandler.SubmitDigitalSignature)
-				r.Post("/voting/tps/cast", votingHandler.CastTPSVote)
-				r.Post("/voting/tps/ballots/parse-qr", votingHandler.ParseBallotQR)
-				r.Post("/voting/tps/ballots/cast-from-qr", votingHandler.CastBallotFromQR)
-				r.Get("/voting/tps/status", votingHandler.GetTPSVotingStatus)
-				r.Get("/voting/receipt", votingHandler.GetVotingReceipt)
-			})
-
-			// Admin routes
-			r.Group(func(r chi.Router) {
-				r.Use(httpMiddleware.AuthAdminOnly(jwtManager))
-
-				// Election management
-				r.Route("/admin/elections", func(r chi.Router) {
-					r.Get("/", electionAdminHandler.List)
-					r.Post("/", electionAdminHandler.Create)
-					r.Get("/{electionID}", electionAdminHandler.Get)
-					r.Put("/{electionID}", electionAdminHandler.Update)
-					r.Patch("/{electionID}", electionAdminHandler.PatchGeneralInfo)
-					r.Post("/{electionID}/open-voting", electionAdminHandler.OpenVoting)
-					r.Post("/{electionID}/close-voting", electionAdminHandler.CloseVoting)
-					r.Route("/{electionID}/actions", func(r chi.Router) {
-						r.Post("/open-voting", electionAdminHandler.OpenVoting)
-						r.Post("/close-voting", electionAdminHandler.CloseVoting)
-						r.Post("/archive", electionAdminHandler.Archive)
-					})
-					r.Route("/{electionID}/phases", func(r chi.Router) {
-						r.Get("/", electionAdminHandler.GetPhases)
-						r.Put("/", electionAdminHandler.UpdatePhases)
-					})
-					r.Route("/{electionID}/settings", func(r chi.Router) {
-						r.Get("/", electionAdminHandler.GetAllSettings)
-						r.Get("/mode", electionAdminHandler.GetModeSettings)
-						r.Put("/mode", electionAdminHandler.UpdateModeSettings)
-					})
-					r.Get("/{electionID}/summary", electionAdminHandler.GetSummary)
-					r.Route("/{electionID}/branding", func(r chi.Router) {
-						r.Get("/", electionAdminHandler.GetBranding)
-						r.Get("/logo/{slot}", electionAdminHandler.GetBrandingLogo)
-						r.Post("/logo/{slot}", electionAdminHandler.UploadBrandingLogo)
-						r.Delete("/logo/{slot}", electionAdminHandler.DeleteBrandingLogo)
-					})
-
-					// TPS election-scoped management
-					r.Route("/{electionID}/tps", func(r chi.Router) {
-						r.Get("/", tpsHandler.AdminListTPSElection)
-						r.Post("/", tpsHandler.AdminCreateTPSElection)
-						r.Get("/{tpsID}", tpsHandler.AdminGetTPSElection)
-						r.Put("/{tpsID}", tpsHandler.AdminUpdateTPSElection)
-						r.Delete("/{tpsID}", tpsHandler.AdminDeleteTPSElection)
-						r.Get("/{tpsID}/qr", tpsHandler.AdminGetQRMetadata)
-						r.Post("/{tpsID}/qr/rotate", tpsHandler.AdminRotateQR)
-						r.Get("/{tpsID}/qr/print", tpsHandler.AdminGetQRPrint)
-						r.Get("/{tpsID}/operators", tpsHandler.AdminListOperators)
-						r.Post("/{tpsID}/operators", tpsHandler.AdminCreateOperator)
-						r.Delete("/{tpsID}/operators/{userID}", tpsHandler.AdminDeleteOperator)
-						r.Get("/{tpsID}/allocation", tpsAdminHandler.Allocation)
-						r.Get("/{tpsID}/activity", tpsAdminHandler.Activity)
-					})
-
-					// NOTE: Per-election TPS management moved to standalone route at line ~400
-					// to avoid nested path issue: /admin/elections/{electionID}/tps/{tpsID}
-					// (not /admin/elections/{electionID}/tps/{electionID}/tps/{tpsID})
-
-					// Candidate management
-					r.Route("/{electionID}/candidates", func(r chi.Router) {
-						r.Get("/", candidateAdminHandler.List)
-						r.Post("/", candidateAdminHandler.Create)
-						r.Get("/{candidateID}", candidateAdminHandler.Detail)
-						r.Put("/{candidateID}", candidateAdminHandler.Update)
-						r.Delete("/{candidateID}", candidateAdminHandler.Delete)
-						r.Post("/{candidateID}/publish", candidateAdminHandler.Publish)
-						r.Post("/{candidateID}/unpublish", candidateAdminHandler.Unpublish)
-						r.Post("/{candidateID}/qr/generate", candidateAdminHandler.GenerateQRCode)
-					})
-
-					// DPT management
-					r.Post("/{electionID}/voters/import", dptHandler.Import)
-					r.Route("/{electionID}/voters", func(r chi.Router) {
-						r.Get("/", electionVoterHandler.AdminList)
-						r.Post("/", electionVoterHandler.AdminUpsert)
-						r.Get("/lookup", electionVoterHandler.AdminLookup)
-						r.Patch("/{voterID}", electionVoterHandler.AdminPatch)
-						r.Post("/{voterID}/blacklist", electionVoterHandler.AdminBlacklist)
-						r.Post("/{voterID}/unblacklist", electionVoterHandler.AdminUnblacklist)
-						r.Get("/export", electionVoterHandler.ExportToExcel)
-						r.Get("/{voterID}", dptHandler.Get)
-						r.Put("/{voterID}", dptHandler.Update)
-						r.Delete("/{voterID}", dptHandler.Delete)
-					})
-
-					// Analytics endpoints
-					r.Route("/{electionID}/analytics", func(r chi.Router) {
-						analyticsHandler.Mount(r)
-					})
-
-					// TPS monitoring per election
-					r.Get("/{electionID}/tps/monitor", tpsAdminHandler.Monitor)
-				})
-
-				// Candidate media management (global by candidate ID)
-				r.Route("/admin/candidates", func(r chi.Router) {
-					r.Post("/{candidateID}/media/profile", candidateAdminHandler.UploadProfileMedia)
-					r.Get("/{candidateID}/media/profile", candidateAdminHandler.GetProfileMedia)
-					r.Delete("/{candidateID}/media/profile", candidateAdminHandler.DeleteProfileMedia)
-					r.Post("/{candidateID}/media", candidateAdminHandler.UploadMedia)
-					r.Get("/{candidateID}/media/{mediaID}", candidateAdminHandler.GetMedia)
-					r.Delete("/{candidateID}/media/{mediaID}", candidateAdminHandler.DeleteMedia)
-				})
-
-				// Global voters endpoint
-				r.Route("/admin/voters", func(r chi.Router) {
-					r.Get("/", dptHandler.ListAll)
-				})
-
-				// Admin user management
-				r.Route("/admin/users", func(r chi.Router) {
-					r.Get("/", adminUserHandler.List)
-					r.Post("/", adminUserHandler.Create)
-					r.Get("/{userID}", adminUserHandler.Detail)
-					r.Patch("/{userID}", adminUserHandler.Update)
-					r.Post("/{userID}/reset-password", adminUserHandler.ResetPassword)
-					r.Post("/{userID}/activate", adminUserHandler.Activate)
-					r.Post("/{userID}/deactivate", adminUserHandler.Deactivate)
-					r.Delete("/{userID}", adminUserHandler.Delete)
-				})
-
-				// App Settings
-				r.Route("/admin/settings", func(r chi.Router) {
-					r.Get("/", settingsHandler.GetSettings)
-					r.Get("/active-election", settingsHandler.GetActiveElection)
-					r.Put("/active-election", settingsHandler.UpdateActiveElection)
-				})
-
-				// Monitoring (counts/participation)
-				monitoringHandler.RegisterRoutes(r)
-
-				// TPS management
-				r.Route("/admin/tps", func(r chi.Router) {
-					r.Get("/", tpsAdminHandler.List)
-					r.Post("/", tpsAdminHandler.Create)
-					r.Get("/{tpsID}", tpsAdminHandler.Get)
-					r.Put("/{tpsID}", tpsAdminHandler.Update)
-					r.Delete("/{tpsID}", tpsAdminHandler.Delete)
-
-					// QR management
-					r.Get("/{tpsID}/qr", tpsAdminHandler.GetQRMetadata)
-					r.Post("/{tpsID}/qr/rotate", tpsAdminHandler.RotateQR)
-					r.Get("/{tpsID}/qr/print", tpsAdminHandler.GetQRForPrint)
-
-					// Operator management
-					r.Get("/{tpsID}/operators", tpsAdminHandler.ListOperators)
-					r.Post("/{tpsID}/operators", tpsAdminHandler.CreateOperator)
-					r.Delete("/{tpsID}/operators/{userID}", tpsAdminHandler.RemoveOperator)
-
-					// Allocation & activity
-					r.Get("/{tpsID}/allocation", tpsAdminHandler.Allocation)
-					r.Get("/{tpsID}/activity", tpsAdminHandler.Activity)
-				})
-
-			})
-
-			// TPS panel endpoints under admin namespace (admin + TPS operator scoped)
-			r.Route("/admin/elections/{electionID}/tps/{tpsID}", func(r chi.Router) {
-				r.Use(httpMiddleware.AuthAdminOrTPSOperator(jwtManager))
-				r.Get("/dashboard", tpsPanelHandler.Dashboard)
-				r.Get("/stats", tpsPanelHandler.Stats)
-				r.Get("/status", tpsPanelHandler.Status)
-				r.Get("/checkins", tpsPanelHandler.ListCheckins)
-				r.Get("/checkins/{checkinId}", tpsPanelHandler.GetCheckin)
-				r.Post("/checkin/scan", tpsPanelHandler.ScanCheckin)
-				r.Post("/checkin/manual", tpsPanelHandler.ManualCheckin)
-				r.Get("/stats/timeline", tpsPanelHandler.Timeline)
-				r.Get("/logs", tpsPanelHandler.Logs)
-
-				// Admin-only TPS management endpoints
-				r.With(httpMiddleware.AuthAdminOnly(jwtManager)).Get("/operators", tpsHandler.AdminListOperators)
-				r.With(httpMiddleware.AuthAdminOnly(jwtManager)).Post("/operators", tpsHandler.AdminCreateOperator)
-				r.With(httpMiddleware.AuthAdminOnly(jwtManager)).Delete("/operators/{userID}", tpsHandler.AdminDeleteOperator)
-				r.With(httpMiddleware.AuthAdminOnly(jwtManager)).Get("/allocation", tpsAdminHandler.Allocation)
-				r.With(httpMiddleware.AuthAdminOnly(jwtManager)).Get("/activity", tpsAdminHandler.Activity)
-			})
-
-			r.Group(func(r chi.Router) {
-				r.Use(httpMiddleware.AuthTPSOperatorOnly(jwtManager))
-				r.Post("/tps/{tpsID}/checkins/{checkinID}/scan-candidate", votingHandler.ScanTPSCandidate)
-				r.Post("/tps/{tpsID}/checkins", tpsPanelHandler.CreateCheckinSimple)
-			})
-		})
+	r, err := newRouter(&server{
+		allowedOrigins:             allowedOrigins,
+		settingsService:            settingsService,
+		jwtManager:                 jwtManager,
+		rbacService:                rbacService,
+		limitLogin:                 limitLogin,
+		limitLoginStep:             limitLoginStep,
+		limitRegister:              limitRegister,
+		limitReset:                 limitReset,
+		limitVote:                  limitVote,
+		limitQRScan:                limitQRScan,
+		healthHandler:              healthHandler,
+		wsHandler:                  wsHandler,
+		authHandler:                authHandler,
+		masterHandler:              masterHandler,
+		settingsHandler:            settingsHandler,
+		electionHandler:            electionHandler,
+		electionAdminHandler:       electionAdminHandler,
+		electionVoterHandler:       electionVoterHandler,
+		electionKeyHandler:         electionKeyHandler,
+		archiveHandler:             archiveHandler,
+		rbacHandler:                rbacHandler,
+		adminUserHandler:           adminUserHandler,
+		candidateHandler:           candidateHandler,
+		candidateAdminHandler:      candidateAdminHandler,
+		candidacyHandler:           candidacyHandler,
+		ballotDrawHandler:          ballotDrawHandler,
+		voterProfileHandler:        voterProfileHandler,
+		votingHandler:              votingHandler,
+		dptHandler:                 dptHandler,
+		monitoringHandler:          monitoringHandler,
+		analyticsHandler:           analyticsHandler,
+		recountHandler:             recountHandler,
+		embargoHandler:             embargoHandler,
+		resultsHandler:             resultsHandler,
+		tpsHandler:                 tpsHandler,
+		tpsAdminHandler:            tpsAdminHandler,
+		tpsPanelAuthHandler:        tpsPanelAuthHandler,
+		tpsPanelHandler:            tpsPanelHandler,
+		tpsAllocationHandler:       tpsAllocationHandler,
+		tpsCheckinLifecycleHandler: tpsCheckinLifecycleHandler,
+		tpsQueueHandler:            tpsQueueHandler,
+		tpsScheduleHandler:         tpsScheduleHandler,
+		tpsIncidentHandler:         tpsIncidentHandler,
+		tpsWSHandler:               tpsWSHandler,
 	})
+	if err != nil {
+		logger.Error("failed to build routes", "error", err)
+		os.Exit(1)
+	}
 
 	srv := &http.Server{
 		Addr:         ":" + cfg.HTTPPort,
@@ -471,10 +446,34 @@ func main() {
 		logger.Error("server forced to shutdown", "error", err)
 		os.Exit(1)
 	}
+	if err := shutdownTracing(ctx); err != nil {
+		logger.Error("failed to flush traces", "error", err)
+	}
 
 	logger.Info("server stopped")
 }
 
+func parseRoles(raw string) []constants.Role {
+	var roles []constants.Role
+	for _, p := range strings.Split(raw, ",") {
+		p = strings.ToUpper(strings.TrimSpace(p))
+		if p != "" {
+			roles = append(roles, constants.Role(p))
+		}
+	}
+	return roles
+}
+
+func parseList(raw string) []string {
+	var out []string
+	for _, p := range strings.Split(raw, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
 func parseOrigins(raw string) []string {
 	parts := strings.Split(raw, ",")
 	out := make([]string, 0, len(parts))
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"net/http"
+
+	"pemira-api/internal/adminuser"
+	"pemira-api/internal/auth"
+	"pemira-api/internal/candidate"
+	"pemira-api/internal/crypto"
+	"pemira-api/internal/dpt"
+	"pemira-api/internal/election"
+	"pemira-api/internal/electionkey"
+	"pemira-api/internal/electionvoter"
+	"pemira-api/internal/embargo"
+	"pemira-api/internal/openapi"
+	"pemira-api/internal/rbac"
+	"pemira-api/internal/settings"
+	"pemira-api/internal/tps"
+	"pemira-api/internal/voter"
+	"pemira-api/internal/voting"
+)
+
+var apiInfo = openapi.Info{
+	Title:       "PEMIRA API",
+	Version:     "1.0.0",
+	Description: "Generated from the route table in cmd/api/routes.go; see docs/OPENAPI.md.",
+}
+
+// apiOperations adds to the route table what it cannot tell: summaries,
+// request bodies and public routes. Every route is in the document without
+// an entry here; an entry without a route fails startup and the routes test.
+// Request bodies are validated against the DTO's validate tags.
+var apiOperations = []openapi.Operation{
+	// Health and tooling
+	{Method: http.MethodGet, Pattern: "/health", Summary: "Readiness check (alias of /health/ready)", Public: true},
+	{Method: http.MethodGet, Pattern: "/health/live", Summary: "Liveness check", Public: true},
+	{Method: http.MethodGet, Pattern: "/health/ready", Summary: "Readiness check", Public: true},
+	{Method: http.MethodGet, Pattern: "/metrics", Summary: "Prometheus metrics", Public: true},
+	{Method: http.MethodGet, Pattern: "/openapi.json", Summary: "This document", Public: true},
+	{Method: http.MethodGet, Pattern: "/ws/{channel}", Summary: "Realtime channel (WebSocket)", Public: true},
+	{Method: http.MethodGet, Pattern: "/api/v1/", Summary: "API version", Public: true},
+
+	// Master data
+	{Method: http.MethodGet, Pattern: "/api/v1/meta/faculties-programs", Summary: "Faculties with their study programs", Public: true},
+	{Method: http.MethodGet, Pattern: "/api/v1/master/faculties", Summary: "List faculties", Public: true},
+	{Method: http.MethodGet, Pattern: "/api/v1/master/study-programs", Summary: "List study programs", Public: true},
+	{Method: http.MethodGet, Pattern: "/api/v1/master/lecturer-units", Summary: "List lecturer units", Public: true},
+	{Method: http.MethodGet, Pattern: "/api/v1/master/lecturer-positions", Summary: "List lecturer positions", Public: true},
+	{Method: http.MethodGet, Pattern: "/api/v1/master/staff-units", Summary: "List staff units", Public: true},
+	{Method: http.MethodGet, Pattern: "/api/v1/master/staff-positions", Summary: "List staff positions", Public: true},
+
+	// Auth
+	{Method: http.MethodPost, Pattern: "/api/v1/auth/register/student", Summary: "Register a student account", Request: auth.RegisterStudentRequest{}, Public: true},
+	{Method: http.MethodPost, Pattern: "/api/v1/auth/register/lecturer-staff", Summary: "Register a lecturer or staff account", Request: auth.RegisterLecturerStaffRequest{}, Public: true},
+	{Method: http.MethodPost, Pattern: "/api/v1/auth/login", Summary: "Log in with username and password", Request: auth.LoginRequest{}, Public: true},
+	{Method: http.MethodPost, Pattern: "/api/v1/auth/login/2fa", Summary: "Complete a login with a TOTP or recovery code", Request: auth.LoginMFARequest{}, Public: true},
+	{Method: http.MethodPost, Pattern: "/api/v1/auth/login/2fa/setup", Summary: "Enroll TOTP during a login that requires it", Request: auth.MFATokenRequest{}, Public: true},
+	{Method: http.MethodPost, Pattern: "/api/v1/auth/refresh", Summary: "Exchange a refresh token", Request: auth.RefreshRequest{}, Public: true},
+	{Method: http.MethodGet, Pattern: "/api/v1/auth/logout-page", Summary: "SSO logout redirect page", Public: true},
+	{Method: http.MethodPost, Pattern: "/api/v1/auth/reset-password", Summary: "Reset a password", Request: auth.ResetPasswordRequest{}, Public: true},
+	{Method: http.MethodGet, Pattern: "/api/v1/auth/sso", Summary: "SSO configuration", Public: true},
+	{Method: http.MethodGet, Pattern: "/api/v1/auth/sso/login", Summary: "Start an SSO login", Public: true},
+	{Method: http.MethodGet, Pattern: "/api/v1/auth/sso/callback", Summary: "SSO callback", Public: true},
+	{Method: http.MethodPost, Pattern: "/api/v1/auth/sso/token", Summary: "Exchange an SSO code for tokens", Request: auth.SSOTokenRequest{}, Public: true},
+	{Method: http.MethodPost, Pattern: "/api/v1/auth/email/verify", Summary: "Verify an email address", Request: auth.VerifyEmailRequest{}, Public: true},
+	{Method: http.MethodPost, Pattern: "/api/v1/auth/email/resend", Summary: "Resend the verification email", Request: auth.ResendEmailVerificationRequest{}, Public: true},
+	{Method: http.MethodPost, Pattern: "/api/v1/tps-panel/auth/login", Summary: "Log in to the TPS panel", Request: auth.LoginRequest{}, Public: true},
+	{Method: http.MethodPost, Pattern: "/api/v1/tps-panel/auth/login/2fa", Summary: "Complete a TPS panel login with a TOTP code", Request: auth.LoginMFARequest{}, Public: true},
+	{Method: http.MethodPost, Pattern: "/api/v1/auth/logout", Summary: "Revoke a refresh token", Request: auth.LogoutRequest{}},
+	{Method: http.MethodPost, Pattern: "/api/v1/auth/2fa/enable", Summary: "Enable TOTP", Request: auth.TOTPCodeRequest{}},
+	{Method: http.MethodPost, Pattern: "/api/v1/auth/2fa/disable", Summary: "Disable TOTP", Request: auth.TOTPCodeRequest{}},
+	{Method: http.MethodPost, Pattern: "/api/v1/auth/2fa/recovery-codes", Summary: "Regenerate recovery codes", Request: auth.TOTPCodeRequest{}},
+
+	{Method: http.MethodGet, Pattern: "/api/v1/maintenance", Summary: "Maintenance mode status", Public: true},
+
+	// Public election data
+	{Method: http.MethodGet, Pattern: "/api/v1/elections/current", Summary: "Current election", Public: true},
+	{Method: http.MethodGet, Pattern: "/api/v1/elections/current-for-registration", Summary: "Election open for registration", Public: true},
+	{Method: http.MethodGet, Pattern: "/api/v1/elections", Summary: "List public elections", Public: true},
+	{Method: http.MethodGet, Pattern: "/api/v1/elections/{electionID}/phases", Summary: "Election phases", Public: true},
+	{Method: http.MethodGet, Pattern: "/api/v1/elections/{electionID}/timeline", Summary: "Election phases (alias)", Public: true},
+	{Method: http.MethodGet, Pattern: "/api/v1/elections/{electionID}/qr-codes", Summary: "Candidates with their ballot QR codes", Public: true},
+	{Method: http.MethodGet, Pattern: "/api/v1/elections/{electionID}/candidates/{candidateID}", Summary: "Published candidate", Public: true},
+	{Method: http.MethodGet, Pattern: "/api/v1/elections/{electionID}/candidates/{candidateID}/media/profile", Summary: "Candidate profile photo", Public: true},
+	{Method: http.MethodGet, Pattern: "/api/v1/elections/{electionID}/candidates", Summary: "List published candidates", Public: true},
+	{Method: http.MethodGet, Pattern: "/api/v1/elections/{electionID}/ballot-draw", Summary: "Ballot number draw transcript", Public: true},
+	{Method: http.MethodGet, Pattern: "/api/v1/elections/{electionID}/encryption-key", Summary: "Election public key", Public: true},
+	{Method: http.MethodGet, Pattern: "/api/v1/elections/{electionID}/bulletin-board", Summary: "Encrypted ballot bulletin board", Public: true},
+	{Method: http.MethodGet, Pattern: "/api/v1/elections/{electionID}/bulletin-board/{tracker}", Summary: "Find a ballot by tracker", Public: true},
+	{Method: http.MethodGet, Pattern: "/api/v1/elections/{electionID}/verifiable-tally", Summary: "Decrypted tally with proofs", Public: true},
+	{Method: http.MethodGet, Pattern: "/api/v1/elections/{electionID}/results", Summary: "Published results (cached snapshot)", Public: true},
+	{Method: http.MethodGet, Pattern: "/api/v1/elections/{electionID}/turnout", Summary: "Turnout per faculty (cached snapshot)", Public: true},
+
+	// Voter
+	{Method: http.MethodPut, Pattern: "/api/v1/voters/me/profile", Summary: "Update the voter's profile", Request: voter.UpdateProfileRequest{}},
+	{Method: http.MethodPut, Pattern: "/api/v1/voters/me/voting-method", Summary: "Choose online or TPS voting", Request: voter.UpdateVotingMethodRequest{}},
+	{Method: http.MethodPost, Pattern: "/api/v1/voters/me/change-password", Summary: "Change the voter's password", Request: voter.ChangePasswordRequest{}},
+	{Method: http.MethodPost, Pattern: "/api/v1/voters/me/elections/{electionID}/register", Summary: "Register for an election", Request: electionvoter.SelfRegisterInput{}},
+	{Method: http.MethodPost, Pattern: "/api/v1/voters/{voterID}/tps/qr", Summary: "Generate the voter's TPS registration QR", Request: voting.VoterTPSQRRequest{}},
+	{Method: http.MethodPost, Pattern: "/api/v1/tps/checkin/scan", Summary: "Check in at a TPS by scanning its QR", Request: tps.ScanQRRequest{}},
+
+	// Candidacy
+	{Method: http.MethodPost, Pattern: "/api/v1/elections/{electionID}/candidacy", Summary: "Apply as a candidate", Request: candidate.CandidacyRequest{}},
+	{Method: http.MethodPut, Pattern: "/api/v1/elections/{electionID}/candidacy", Summary: "Update the own candidacy", Request: candidate.CandidacyRequest{}},
+	{Method: http.MethodPost, Pattern: "/api/v1/elections/{electionID}/candidacy/draw-seed", Summary: "Submit the ballot draw seed", Request: candidate.BallotDrawSeedRequest{}},
+
+	// Voting
+	{Method: http.MethodPost, Pattern: "/api/v1/voting/online/cast", Summary: "Cast an online vote", Request: voting.CastOnlineVoteRequest{}},
+	{Method: http.MethodPost, Pattern: "/api/v1/voting/online/signature", Summary: "Submit the voter's digital signature", Request: voting.SubmitSignatureRequest{}},
+	{Method: http.MethodPost, Pattern: "/api/v1/voting/tps/cast", Summary: "Cast a vote after TPS check-in", Request: voting.CastTPSVoteRequest{}},
+	{Method: http.MethodPost, Pattern: "/api/v1/voting/tps/ballots/parse-qr", Summary: "Parse a ballot QR", Request: voting.ParseBallotQRRequest{}},
+	{Method: http.MethodPost, Pattern: "/api/v1/voting/tps/ballots/cast-from-qr", Summary: "Cast a vote from a ballot QR", Request: voting.CastFromBallotQRRequest{}},
+	{Method: http.MethodPost, Pattern: "/api/v1/voting/receipt/verify", Summary: "Verify a vote receipt", Request: voting.VerifyReceiptRequest{}},
+	{Method: http.MethodPost, Pattern: "/api/v1/voting/encrypted/cast", Summary: "Cast a ballot encrypted under the election key", Request: voting.CastEncryptedVoteRequest{}},
+
+	// Key ceremony
+	{Method: http.MethodPost, Pattern: "/api/v1/admin/elections/{electionID}/key-ceremony", Summary: "Start the key ceremony", Request: electionkey.CreateCeremonyRequest{}},
+	{Method: http.MethodPost, Pattern: "/api/v1/trustee/elections/{electionID}/transport-key", Summary: "Publish the trustee's transport key", Request: electionkey.TransportKeyRequest{}},
+	{Method: http.MethodPost, Pattern: "/api/v1/trustee/elections/{electionID}/dealing", Summary: "Publish the trustee's dealing", Request: crypto.Dealing{}},
+	{Method: http.MethodPost, Pattern: "/api/v1/trustee/elections/{electionID}/confirm", Summary: "Confirm the received shares", Request: electionkey.ConfirmRequest{}},
+	{Method: http.MethodPost, Pattern: "/api/v1/trustee/elections/{electionID}/partial-decryption", Summary: "Submit partial decryptions of the tally", Request: electionkey.PartialDecryptionRequest{}},
+
+	// Election management
+	{Method: http.MethodPost, Pattern: "/api/v1/admin/elections", Summary: "Create an election", Request: election.AdminElectionCreateRequest{}},
+	{Method: http.MethodPut, Pattern: "/api/v1/admin/elections/{electionID}", Summary: "Update an election", Request: election.AdminElectionUpdateRequest{}},
+	{Method: http.MethodPatch, Pattern: "/api/v1/admin/elections/{electionID}", Summary: "Update an election's general information", Request: election.AdminElectionGeneralUpdateRequest{}},
+	{Method: http.MethodPost, Pattern: "/api/v1/admin/elections/{electionID}/clone", Summary: "Clone an election", Request: election.ElectionCloneRequest{}},
+	{Method: http.MethodPut, Pattern: "/api/v1/admin/elections/{electionID}/phases", Summary: "Set the phase schedule", Request: election.UpdateElectionPhasesRequest{}},
+	{Method: http.MethodPut, Pattern: "/api/v1/admin/elections/{electionID}/settings/mode", Summary: "Set the voting modes", Request: election.ModeSettingsRequest{}},
+	{Method: http.MethodPost, Pattern: "/api/v1/admin/election-templates", Summary: "Save an election as a template", Request: election.ElectionTemplateCreateRequest{}},
+	{Method: http.MethodPost, Pattern: "/api/v1/admin/election-templates/{templateID}/apply", Summary: "Create an election from a template", Request: election.ElectionTemplateApplyRequest{}},
+
+	// Candidates
+	{Method: http.MethodPost, Pattern: "/api/v1/admin/elections/{electionID}/candidates", Summary: "Create a candidate", Request: candidate.AdminCreateCandidateRequest{}},
+	{Method: http.MethodPut, Pattern: "/api/v1/admin/elections/{electionID}/candidates/{candidateID}", Summary: "Update a candidate", Request: candidate.AdminUpdateCandidateRequest{}},
+	{Method: http.MethodPost, Pattern: "/api/v1/admin/elections/{electionID}/candidacies/{candidateID}/reviews", Summary: "Review a candidacy", Request: candidate.CandidacyReviewRequest{}},
+
+	// Voter roll
+	{Method: http.MethodPost, Pattern: "/api/v1/admin/elections/{electionID}/voters", Summary: "Add or update a voter and enroll them", Request: electionvoter.UpsertAndEnrollInput{}},
+	{Method: http.MethodPut, Pattern: "/api/v1/admin/elections/{electionID}/voters/eligibility/rules", Summary: "Set the eligibility rules", Request: electionvoter.EligibilityRules{}},
+	{Method: http.MethodPost, Pattern: "/api/v1/admin/elections/{electionID}/voters/eligibility/apply", Summary: "Apply the eligibility rules", Request: electionvoter.EligibilityApplyRequest{}},
+	{Method: http.MethodPatch, Pattern: "/api/v1/admin/elections/{electionID}/voters/{voterID}", Summary: "Update an enrollment", Request: electionvoter.UpdateInput{}},
+	{Method: http.MethodPut, Pattern: "/api/v1/admin/elections/{electionID}/voters/{voterID}", Summary: "Update a voter in the DPT", Request: dpt.VoterUpdateDTO{}},
+	{Method: http.MethodPost, Pattern: "/api/v1/admin/elections/{electionID}/voters/{voterID}/blacklist", Summary: "Blacklist a voter", Request: electionvoter.BlacklistInput{}},
+
+	// Users, roles and settings
+	{Method: http.MethodPost, Pattern: "/api/v1/admin/users", Summary: "Create an admin user", Request: adminuser.CreateInput{}},
+	{Method: http.MethodPatch, Pattern: "/api/v1/admin/users/{userID}", Summary: "Update an admin user", Request: adminuser.UpdateInput{}},
+	{Method: http.MethodPost, Pattern: "/api/v1/admin/users/{userID}/reset-password", Summary: "Reset an admin user's password", Request: adminuser.ResetPasswordInput{}},
+	{Method: http.MethodPost, Pattern: "/api/v1/admin/users/{userID}/roles", Summary: "Assign a role", Request: rbac.AssignRequest{}},
+	{Method: http.MethodPut, Pattern: "/api/v1/admin/settings/active-election", Summary: "Set the active election", Request: settings.UpdateActiveElectionRequest{}},
+	{Method: http.MethodPut, Pattern: "/api/v1/admin/settings/maintenance", Summary: "Set maintenance mode", Request: settings.UpdateMaintenanceRequest{}},
+
+	// Results embargo
+	{Method: http.MethodPut, Pattern: "/api/v1/admin/elections/{electionID}/settings/results-embargo", Summary: "Set the results embargo", Request: embargo.UpdateRequest{}},
+	{Method: http.MethodPost, Pattern: "/api/v1/admin/elections/{electionID}/results/break-glass", Summary: "Read sealed results (super admin, audited)", Request: embargo.BreakGlassRequest{}},
+	{Method: http.MethodPost, Pattern: "/api/v1/admin/elections/{electionID}/results/snapshot", Summary: "Regenerate public result snapshots"},
+
+	// TPS management
+	{Method: http.MethodPost, Pattern: "/api/v1/admin/tps", Summary: "Create a TPS", Request: tps.TPSCreateRequest{}},
+	{Method: http.MethodPut, Pattern: "/api/v1/admin/tps/{tpsID}", Summary: "Update a TPS", Request: tps.TPSUpdateRequest{}},
+	{Method: http.MethodPost, Pattern: "/api/v1/admin/tps/{tpsID}/operators", Summary: "Create a TPS operator account", Request: tps.CreateOperatorRequest{}},
+	{Method: http.MethodPut, Pattern: "/api/v1/admin/elections/{electionID}/tps/{tpsID}", Summary: "Update a TPS of an election", Request: tps.UpdateTPSRequest{}},
+	{Method: http.MethodPost, Pattern: "/api/v1/admin/elections/{electionID}/tps/{tpsID}/operators", Summary: "Create a TPS operator account", Request: tps.OperatorCreate{}},
+	{Method: http.MethodPut, Pattern: "/api/v1/admin/elections/{electionID}/tps/allocation/settings", Summary: "Set the TPS allocation settings", Request: tps.UpdateAllocationSettingsRequest{}},
+	{Method: http.MethodPost, Pattern: "/api/v1/admin/elections/{electionID}/tps/allocation/apply", Summary: "Allocate voters to TPS", Request: tps.ApplyAllocationRequest{}},
+	{Method: http.MethodPut, Pattern: "/api/v1/admin/elections/{electionID}/tps/allocation/voters/{voterID}", Summary: "Assign a voter to a TPS", Request: tps.AssignVoterTPSRequest{}},
+	{Method: http.MethodPut, Pattern: "/api/v1/admin/elections/{electionID}/tps/checkin-settings", Summary: "Set the check-in lifetime", Request: tps.UpdateCheckinTTLRequest{}},
+	{Method: http.MethodPut, Pattern: "/api/v1/admin/elections/{electionID}/tps/schedule-settings", Summary: "Set the TPS schedule settings", Request: tps.UpdateScheduleSettingsRequest{}},
+	{Method: http.MethodPut, Pattern: "/api/v1/admin/elections/{electionID}/tps/incidents/{incidentID}", Summary: "Triage an incident", Request: tps.TriageIncidentRequest{}},
+	{Method: http.MethodPut, Pattern: "/api/v1/admin/elections/{electionID}/tps/incidents/{incidentID}/assignee", Summary: "Assign an incident", Request: tps.AssignIncidentRequest{}},
+	{Method: http.MethodPost, Pattern: "/api/v1/admin/elections/{electionID}/tps/incidents/{incidentID}/resolve", Summary: "Resolve an incident", Request: tps.ResolveIncidentRequest{}},
+	{Method: http.MethodPost, Pattern: "/api/v1/admin/elections/{electionID}/tps/incidents/{incidentID}/comments", Summary: "Comment on an incident", Request: tps.IncidentCommentRequest{}},
+
+	// TPS panel
+	{Method: http.MethodPost, Pattern: "/api/v1/admin/elections/{electionID}/tps/{tpsID}/checkin/scan", Summary: "Check a voter in by registration QR", Request: tps.PanelCheckinRequest{}},
+	{Method: http.MethodPost, Pattern: "/api/v1/admin/elections/{electionID}/tps/{tpsID}/checkin/manual", Summary: "Check a voter in by registration code or NIM", Request: tps.PanelCheckinRequest{}},
+	{Method: http.MethodPut, Pattern: "/api/v1/admin/elections/{electionID}/tps/{tpsID}/queue/settings", Summary: "Set the queue settings", Request: tps.UpdateQueueSettingsRequest{}},
+	{Method: http.MethodPut, Pattern: "/api/v1/admin/elections/{electionID}/tps/{tpsID}/schedule", Summary: "Set the TPS schedule", Request: tps.UpdateScheduleRequest{}},
+	{Method: http.MethodPost, Pattern: "/api/v1/admin/elections/{electionID}/tps/{tpsID}/schedule/extend", Summary: "Extend the current session", Request: tps.ExtendSessionRequest{}},
+	{Method: http.MethodPost, Pattern: "/api/v1/admin/elections/{electionID}/tps/{tpsID}/incidents", Summary: "Report an incident", Request: tps.ReportIncidentRequest{}},
+	{Method: http.MethodPost, Pattern: "/api/v1/admin/elections/{electionID}/tps/{tpsID}/incidents/{incidentID}/comments", Summary: "Comment on an incident", Request: tps.IncidentCommentRequest{}},
+	{Method: http.MethodPost, Pattern: "/api/v1/tps/{tpsID}/checkins", Summary: "Check a voter in by registration QR", Request: tps.CreateCheckinRequest{}},
+	{Method: http.MethodPost, Pattern: "/api/v1/tps/{tpsID}/checkins/{checkinID}/scan-candidate", Summary: "Record a vote from a scanned ballot QR", Request: voting.ScanTPSCandidateRequest{}},
+}
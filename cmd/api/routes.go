@@ -0,0 +1,555 @@
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/cors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"pemira-api/internal/adminuser"
+	"pemira-api/internal/analytics"
+	"pemira-api/internal/archive"
+	"pemira-api/internal/auth"
+	"pemira-api/internal/candidate"
+	"pemira-api/internal/dpt"
+	"pemira-api/internal/election"
+	"pemira-api/internal/electionkey"
+	"pemira-api/internal/electionvoter"
+	"pemira-api/internal/embargo"
+	"pemira-api/internal/health"
+	httpMiddleware "pemira-api/internal/http/middleware"
+	"pemira-api/internal/http/response"
+	"pemira-api/internal/master"
+	"pemira-api/internal/monitoring"
+	"pemira-api/internal/openapi"
+	"pemira-api/internal/rbac"
+	"pemira-api/internal/recount"
+	"pemira-api/internal/results"
+	"pemira-api/internal/settings"
+	"pemira-api/internal/shared/constants"
+	"pemira-api/internal/telemetry"
+	"pemira-api/internal/tps"
+	"pemira-api/internal/voter"
+	"pemira-api/internal/voting"
+	"pemira-api/internal/ws"
+)
+
+// server is everything the route table needs
+type server struct {
+	allowedOrigins  []string
+	settingsService *settings.Service
+	jwtManager      *auth.JWTManager
+	rbacService     *rbac.Service
+
+	// rate limits
+	limitLogin     func(http.Handler) http.Handler
+	limitLoginStep func(http.Handler) http.Handler
+	limitRegister  func(http.Handler) http.Handler
+	limitReset     func(http.Handler) http.Handler
+	limitVote      func(http.Handler) http.Handler
+	limitQRScan    func(http.Handler) http.Handler
+
+	healthHandler              *health.Handler
+	wsHandler                  *ws.Handler
+	authHandler                *auth.AuthHandler
+	masterHandler              *master.Handler
+	settingsHandler            *settings.Handler
+	electionHandler            *election.Handler
+	electionAdminHandler       *election.AdminHandler
+	electionVoterHandler       *electionvoter.Handler
+	electionKeyHandler         *electionkey.Handler
+	archiveHandler             *archive.Handler
+	rbacHandler                *rbac.Handler
+	adminUserHandler           *adminuser.Handler
+	candidateHandler           *candidate.Handler
+	candidateAdminHandler      *candidate.AdminHandler
+	candidacyHandler           *candidate.CandidacyHandler
+	ballotDrawHandler          *candidate.BallotDrawHandler
+	voterProfileHandler        *voter.ProfileHandler
+	votingHandler              *voting.Handler
+	dptHandler                 *dpt.Handler
+	monitoringHandler          *monitoring.Handler
+	analyticsHandler           *analytics.Handler
+	recountHandler             *recount.Handler
+	embargoHandler             *embargo.Handler
+	resultsHandler             *results.Handler
+	tpsHandler                 *tps.Handler
+	tpsAdminHandler            *tps.AdminHandler
+	tpsPanelAuthHandler        *tps.PanelAuthHandler
+	tpsPanelHandler            *tps.PanelHandler
+	tpsAllocationHandler       *tps.AllocationHandler
+	tpsCheckinLifecycleHandler *tps.CheckinLifecycleHandler
+	tpsQueueHandler            *tps.QueueHandler
+	tpsScheduleHandler         *tps.ScheduleHandler
+	tpsIncidentHandler         *tps.IncidentHandler
+	tpsWSHandler               *tps.WSHandler
+}
+
+// newRouter registers every route and generates the OpenAPI document from
+// the result.
+func newRouter(s *server) (*chi.Mux, error) {
+	spec := openapi.New(apiInfo, apiOperations...)
+	r := chi.NewRouter()
+
+	// CORS middleware
+	r.Use(cors.Handler(cors.Options{
+		AllowedOrigins:   s.allowedOrigins,
+		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS", "PATCH"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
+		ExposedHeaders:   []string{"Link", "Retry-After", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy"},
+		AllowCredentials: true,
+		MaxAge:           300,
+	}))
+
+	r.Use(middleware.RequestID)
+	r.Use(httpMiddleware.Language)
+	r.Use(telemetry.Middleware)
+	r.Use(middleware.RealIP)
+	r.Use(httpMiddleware.RequestLogger)
+	r.Use(middleware.Recoverer)
+	r.Use(middleware.Timeout(60 * time.Second))
+	// Logins and the switch itself stay open so admins can leave the mode
+	r.Use(httpMiddleware.ReadOnlyMode(s.settingsService,
+		"/api/v1/auth/login",
+		"/api/v1/auth/login/2fa",
+		"/api/v1/auth/login/2fa/setup",
+		"/api/v1/auth/refresh",
+		"/api/v1/auth/logout",
+		"/api/v1/auth/sso/token",
+		"/api/v1/tps-panel/auth/login",
+		"/api/v1/tps-panel/auth/login/2fa",
+		"/api/v1/admin/settings/maintenance",
+	))
+	// Bodies of routes with a bound DTO are checked against it (see openapi.go)
+	r.Use(spec.ValidateRequests)
+
+	r.Get("/health", s.healthHandler.Ready)
+	r.Get("/health/live", s.healthHandler.Live)
+	r.Get("/health/ready", s.healthHandler.Ready)
+
+	r.Method(http.MethodGet, "/metrics", promhttp.Handler())
+	r.Get("/openapi.json", spec.ServeHTTP)
+
+	s.wsHandler.RegisterRoutes(r)
+
+	r.Route("/api/v1", func(r chi.Router) {
+		r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+			response.Success(w, http.StatusOK, map[string]string{
+				"message": "PEMIRA API v1",
+			})
+		})
+
+		// Metadata for dropdowns
+		r.Get("/meta/faculties-programs", s.masterHandler.GetFacultyPrograms)
+		r.Get("/master/faculties", s.masterHandler.GetFaculties)
+		r.Get("/master/study-programs", s.masterHandler.GetStudyPrograms)
+		r.Get("/master/lecturer-units", s.masterHandler.GetLecturerUnits)
+		r.Get("/master/lecturer-positions", s.masterHandler.GetLecturerPositions)
+		r.Get("/master/staff-units", s.masterHandler.GetStaffUnits)
+		r.Get("/master/staff-positions", s.masterHandler.GetStaffPositions)
+
+		// Auth routes (public)
+		r.With(s.limitRegister).Post("/auth/register/student", s.authHandler.RegisterStudent)
+		r.With(s.limitRegister).Post("/auth/register/lecturer-staff", s.authHandler.RegisterLecturerStaff)
+		r.With(s.limitLogin).Post("/auth/login", s.authHandler.Login)
+		r.With(s.limitLoginStep).Post("/auth/login/2fa", s.authHandler.LoginMFA)
+		r.With(s.limitLoginStep).Post("/auth/login/2fa/setup", s.authHandler.LoginTOTPSetup)
+		r.Post("/auth/refresh", s.authHandler.RefreshToken)
+		r.Get("/auth/logout-page", s.authHandler.LogoutPage)
+		r.With(s.limitReset).Post("/auth/reset-password", s.authHandler.ResetPassword)
+		r.Get("/auth/sso", s.authHandler.SSOInfo)
+		r.Get("/auth/sso/login", s.authHandler.SSOLogin)
+		r.Get("/auth/sso/callback", s.authHandler.SSOCallback)
+		r.With(s.limitLoginStep).Post("/auth/sso/token", s.authHandler.SSOToken)
+		r.With(s.limitReset).Post("/auth/email/verify", s.authHandler.VerifyEmail)
+		r.With(s.limitReset).Post("/auth/email/resend", s.authHandler.ResendEmailVerification)
+		r.With(s.limitLogin).Post("/tps-panel/auth/login", s.tpsPanelAuthHandler.PanelLogin)
+		r.With(s.limitLoginStep).Post("/tps-panel/auth/login/2fa", s.tpsPanelAuthHandler.PanelLoginMFA)
+
+		r.Get("/maintenance", s.settingsHandler.PublicMaintenance)
+
+		// Public election routes
+		r.Get("/elections/current", s.electionHandler.GetCurrent)
+		r.Get("/elections/current-for-registration", s.electionHandler.GetCurrentForRegistration)
+		r.Get("/elections", s.electionHandler.ListPublic)
+		r.Get("/elections/{electionID}/phases", s.electionHandler.GetPublicPhases)
+		r.Get("/elections/{electionID}/timeline", s.electionHandler.GetPublicPhases)
+		r.Get("/elections/{electionID}/qr-codes", s.candidateHandler.ListWithQR)
+		r.Get("/elections/{electionID}/candidates/{candidateID}", s.candidateHandler.DetailPublic)
+		r.Get("/elections/{electionID}/candidates/{candidateID}/media/profile", s.candidateHandler.GetPublicProfileMedia)
+		r.Get("/elections/{electionID}/candidates", s.candidateHandler.ListPublic)
+		r.Get("/elections/{electionID}/ballot-draw", s.ballotDrawHandler.GetPublic)
+		r.Get("/elections/{electionID}/encryption-key", s.electionKeyHandler.ElectionKey)
+		r.Get("/elections/{electionID}/bulletin-board", s.electionKeyHandler.BulletinBoard)
+		r.Get("/elections/{electionID}/bulletin-board/{tracker}", s.electionKeyHandler.FindBallot)
+		r.Get("/elections/{electionID}/verifiable-tally", s.electionKeyHandler.VerifiableTally)
+		r.Get("/elections/{electionID}/results", s.resultsHandler.Results)
+		r.Get("/elections/{electionID}/turnout", s.resultsHandler.Turnout)
+
+		// Protected routes
+		r.Group(func(r chi.Router) {
+			r.Use(httpMiddleware.JWTAuth(s.jwtManager))
+
+			// Auth protected
+			r.Get("/auth/me", s.authHandler.Me)
+			r.Post("/auth/logout", s.authHandler.Logout)
+			r.Get("/auth/2fa", s.authHandler.TOTPStatus)
+			r.Post("/auth/2fa/setup", s.authHandler.SetupTOTP)
+			r.Post("/auth/2fa/enable", s.authHandler.EnableTOTP)
+			r.Post("/auth/2fa/disable", s.authHandler.DisableTOTP)
+			r.Post("/auth/2fa/recovery-codes", s.authHandler.RegenerateRecoveryCodes)
+
+			// Voter profile routes (authenticated - voter only)
+			s.voterProfileHandler.RegisterRoutes(r)
+
+			// Election routes (authenticated)
+			r.Get("/elections/{electionID}/me/status", s.electionHandler.GetMeStatus)
+			r.Get("/elections/{electionID}/me/history", s.electionHandler.GetMeHistory)
+
+			// Election-specific voter enrollment (self-service)
+			r.Post("/voters/me/elections/{electionID}/register", s.electionVoterHandler.VoterSelfRegister)
+			r.Get("/voters/me/elections/{electionID}/status", s.electionVoterHandler.VoterStatus)
+
+			// Voter TPS QR (student/admin)
+			r.Get("/voters/{voterID}/tps/qr", s.votingHandler.GetVoterTPSQR)
+			r.Post("/voters/{voterID}/tps/qr", s.votingHandler.GenerateVoterTPSQR)
+
+			// TPS student check-in
+			r.With(s.limitQRScan).Post("/tps/checkin/scan", s.tpsHandler.ScanQR)
+			r.Get("/tps/checkin/status", s.tpsHandler.StudentCheckinStatus)
+			r.Get("/tps/assignment", s.tpsAllocationHandler.MyAssignment)
+
+			// Candidate self-registration (student only)
+			r.Route("/elections/{electionID}/candidacy", func(r chi.Router) {
+				r.Use(httpMiddleware.AuthStudentOnly(s.jwtManager))
+				r.Get("/", s.candidacyHandler.GetMine)
+				r.Post("/", s.candidacyHandler.Apply)
+				r.Put("/", s.candidacyHandler.UpdateMine)
+				r.Post("/documents", s.candidacyHandler.UploadDocument)
+				r.Post("/submit", s.candidacyHandler.Submit)
+				r.Get("/notifications", s.candidacyHandler.Notifications)
+				r.Post("/draw-seed", s.ballotDrawHandler.SubmitOwnSeed)
+			})
+
+			// Voting routes (student, lecturer, staff)
+			r.Group(func(r chi.Router) {
+				r.Use(httpMiddleware.JWTAuth(s.jwtManager))
+				r.With(s.limitVote).Post("/voting/online/cast", s.votingHandler.CastOnlineVote)
+				r.With(s.limitVote).Post("/voting/online/signature", s.votingHandler.SubmitDigitalSignature)
+				r.With(s.limitVote).Post("/voting/tps/cast", s.votingHandler.CastTPSVote)
+				r.With(s.limitQRScan).Post("/voting/tps/ballots/parse-qr", s.votingHandler.ParseBallotQR)
+				r.With(s.limitVote).Post("/voting/tps/ballots/cast-from-qr", s.votingHandler.CastBallotFromQR)
+				r.Get("/voting/tps/status", s.votingHandler.GetTPSVotingStatus)
+				r.Get("/voting/receipt", s.votingHandler.GetVotingReceipt)
+				r.Post("/voting/receipt/verify", s.votingHandler.VerifyReceipt)
+				r.With(s.limitVote).Post("/voting/encrypted/cast", s.votingHandler.CastEncryptedVote)
+			})
+
+			// Key ceremony and tally decryption (trustee user accounts)
+			r.Route("/trustee/elections/{electionID}", func(r chi.Router) {
+				r.Get("/key-ceremony", s.electionKeyHandler.GetCeremony)
+				r.Post("/transport-key", s.electionKeyHandler.SubmitTransportKey)
+				r.Post("/dealing", s.electionKeyHandler.SubmitDealing)
+				r.Get("/shares", s.electionKeyHandler.MyShares)
+				r.Post("/confirm", s.electionKeyHandler.Confirm)
+				r.Get("/tally", s.electionKeyHandler.GetTally)
+				r.Post("/partial-decryption", s.electionKeyHandler.SubmitPartialDecryption)
+			})
+
+			// Admin routes (permission-based, see internal/rbac)
+			r.Group(func(r chi.Router) {
+				r.Use(httpMiddleware.Authorize(s.jwtManager, s.rbacService))
+				can := httpMiddleware.RequirePermission
+
+				r.Get("/me/permissions", s.rbacHandler.MyPermissions)
+
+				// Election templates
+				r.Route("/admin/election-templates", func(r chi.Router) {
+					r.With(can(rbac.PermElectionView)).Get("/", s.electionAdminHandler.ListTemplates)
+					r.With(can(rbac.PermElectionManage)).Post("/", s.electionAdminHandler.CreateTemplate)
+					r.With(can(rbac.PermElectionView)).Get("/{templateID}", s.electionAdminHandler.GetTemplate)
+					r.With(can(rbac.PermElectionManage)).Delete("/{templateID}", s.electionAdminHandler.DeleteTemplate)
+					r.With(can(rbac.PermElectionManage)).Post("/{templateID}/apply", s.electionAdminHandler.ApplyTemplate)
+				})
+
+				// Election management
+				r.Route("/admin/elections", func(r chi.Router) {
+					r.With(can(rbac.PermElectionView)).Get("/", s.electionAdminHandler.List)
+					r.With(can(rbac.PermElectionManage)).Post("/", s.electionAdminHandler.Create)
+					r.With(can(rbac.PermElectionManage)).Post("/import", s.archiveHandler.Import)
+					r.With(can(rbac.PermElectionView)).Get("/{electionID}", s.electionAdminHandler.Get)
+					r.With(can(rbac.PermElectionManage)).Put("/{electionID}", s.electionAdminHandler.Update)
+					r.With(can(rbac.PermElectionManage)).Patch("/{electionID}", s.electionAdminHandler.PatchGeneralInfo)
+					r.With(can(rbac.PermElectionManage)).Post("/{electionID}/open-voting", s.electionAdminHandler.OpenVoting)
+					r.With(can(rbac.PermElectionManage)).Post("/{electionID}/close-voting", s.electionAdminHandler.CloseVoting)
+					r.With(can(rbac.PermElectionManage)).Post("/{electionID}/clone", s.electionAdminHandler.Clone)
+					r.With(can(rbac.PermElectionManage)).Get("/{electionID}/export", s.archiveHandler.Export)
+					r.With(can(rbac.PermElectionView)).Get("/{electionID}/key-ceremony", s.electionKeyHandler.GetCeremony)
+					r.With(can(rbac.PermElectionManage)).Post("/{electionID}/key-ceremony", s.electionKeyHandler.CreateCeremony)
+					r.With(can(rbac.PermElectionManage)).Delete("/{electionID}/key-ceremony", s.electionKeyHandler.DeleteCeremony)
+					r.With(can(rbac.PermResultsView)).Get("/{electionID}/encrypted-tally", s.electionKeyHandler.GetTally)
+					r.With(can(rbac.PermElectionManage)).Post("/{electionID}/encrypted-tally", s.electionKeyHandler.StartTally)
+					r.With(can(rbac.PermResultsView)).Get("/{electionID}/recount", s.recountHandler.Check)
+					r.With(can(rbac.PermElectionManage)).Post("/{electionID}/recount/rebuild-stats", s.recountHandler.RebuildStats)
+					r.With(httpMiddleware.RequireRole(constants.RoleSuperAdmin)).Post("/{electionID}/results/break-glass", s.embargoHandler.BreakGlass)
+					r.With(can(rbac.PermElectionManage)).Post("/{electionID}/results/snapshot", s.resultsHandler.Regenerate)
+					r.Route("/{electionID}/actions", func(r chi.Router) {
+						r.Use(can(rbac.PermElectionManage))
+						r.Post("/open-voting", s.electionAdminHandler.OpenVoting)
+						r.Post("/close-voting", s.electionAdminHandler.CloseVoting)
+						r.Post("/archive", s.electionAdminHandler.Archive)
+					})
+					r.Route("/{electionID}/phases", func(r chi.Router) {
+						r.With(can(rbac.PermElectionView)).Get("/", s.electionAdminHandler.GetPhases)
+						r.With(can(rbac.PermElectionManage)).Put("/", s.electionAdminHandler.UpdatePhases)
+					})
+					r.Route("/{electionID}/settings", func(r chi.Router) {
+						r.With(can(rbac.PermElectionView)).Get("/", s.electionAdminHandler.GetAllSettings)
+						r.With(can(rbac.PermElectionView)).Get("/mode", s.electionAdminHandler.GetModeSettings)
+						r.With(can(rbac.PermElectionManage)).Put("/mode", s.electionAdminHandler.UpdateModeSettings)
+						r.With(can(rbac.PermElectionView)).Get("/results-embargo", s.embargoHandler.Get)
+						r.With(can(rbac.PermElectionManage)).Put("/results-embargo", s.embargoHandler.Update)
+					})
+					r.With(can(rbac.PermElectionView)).Get("/{electionID}/summary", s.electionAdminHandler.GetSummary)
+					r.Route("/{electionID}/branding", func(r chi.Router) {
+						r.With(can(rbac.PermElectionView)).Get("/", s.electionAdminHandler.GetBranding)
+						r.With(can(rbac.PermElectionView)).Get("/logo/{slot}", s.electionAdminHandler.GetBrandingLogo)
+						r.With(can(rbac.PermElectionManage)).Post("/logo/{slot}", s.electionAdminHandler.UploadBrandingLogo)
+						r.With(can(rbac.PermElectionManage)).Delete("/logo/{slot}", s.electionAdminHandler.DeleteBrandingLogo)
+					})
+
+					// TPS election-scoped management
+					r.Route("/{electionID}/tps", func(r chi.Router) {
+						r.With(can(rbac.PermTPSView)).Get("/", s.tpsHandler.AdminListTPSElection)
+						r.With(can(rbac.PermTPSManage)).Post("/", s.tpsHandler.AdminCreateTPSElection)
+						r.With(can(rbac.PermTPSView)).Get("/{tpsID}", s.tpsHandler.AdminGetTPSElection)
+						r.With(can(rbac.PermTPSManage)).Put("/{tpsID}", s.tpsHandler.AdminUpdateTPSElection)
+						r.With(can(rbac.PermTPSManage)).Delete("/{tpsID}", s.tpsHandler.AdminDeleteTPSElection)
+						r.With(can(rbac.PermTPSManage)).Get("/{tpsID}/qr", s.tpsHandler.AdminGetQRMetadata)
+						r.With(can(rbac.PermTPSManage)).Post("/{tpsID}/qr/rotate", s.tpsHandler.AdminRotateQR)
+						r.With(can(rbac.PermTPSManage)).Get("/{tpsID}/qr/print", s.tpsHandler.AdminGetQRPrint)
+						r.With(can(rbac.PermTPSManage)).Get("/{tpsID}/operators", s.tpsHandler.AdminListOperators)
+						r.With(can(rbac.PermTPSManage)).Post("/{tpsID}/operators", s.tpsHandler.AdminCreateOperator)
+						r.With(can(rbac.PermTPSManage)).Delete("/{tpsID}/operators/{userID}", s.tpsHandler.AdminDeleteOperator)
+						r.With(can(rbac.PermTPSView)).Get("/{tpsID}/allocation", s.tpsAdminHandler.Allocation)
+						r.With(can(rbac.PermTPSView)).Get("/{tpsID}/activity", s.tpsAdminHandler.Activity)
+
+						// Voter allocation across the election's TPS
+						r.With(can(rbac.PermTPSView)).Get("/allocation", s.tpsAllocationHandler.Overview)
+						r.With(can(rbac.PermTPSView)).Get("/allocation/preview", s.tpsAllocationHandler.Preview)
+						r.With(can(rbac.PermTPSManage)).Put("/allocation/settings", s.tpsAllocationHandler.UpdateSettings)
+						r.With(can(rbac.PermTPSManage)).Post("/allocation/apply", s.tpsAllocationHandler.Apply)
+						r.With(can(rbac.PermTPSManage)).Put("/allocation/voters/{voterID}", s.tpsAllocationHandler.AssignVoter)
+						r.With(can(rbac.PermTPSManage)).Delete("/allocation/voters/{voterID}", s.tpsAllocationHandler.ReleaseVoter)
+
+						// Check-in expiry
+						r.With(can(rbac.PermTPSView)).Get("/checkin-settings", s.tpsCheckinLifecycleHandler.GetSettings)
+						r.With(can(rbac.PermTPSManage)).Put("/checkin-settings", s.tpsCheckinLifecycleHandler.UpdateSettings)
+
+						// Timezone of TPS schedules
+						r.With(can(rbac.PermTPSView)).Get("/schedule-settings", s.tpsScheduleHandler.GetSettings)
+						r.With(can(rbac.PermTPSManage)).Put("/schedule-settings", s.tpsScheduleHandler.UpdateSettings)
+
+						// Incident triage
+						r.With(can(rbac.PermTPSView)).Get("/incidents", s.tpsIncidentHandler.List)
+						r.With(can(rbac.PermTPSView)).Get("/incidents/{incidentID}", s.tpsIncidentHandler.Get)
+						r.With(can(rbac.PermTPSManage)).Put("/incidents/{incidentID}", s.tpsIncidentHandler.Triage)
+						r.With(can(rbac.PermTPSManage)).Put("/incidents/{incidentID}/assignee", s.tpsIncidentHandler.Assign)
+						r.With(can(rbac.PermTPSManage)).Post("/incidents/{incidentID}/resolve", s.tpsIncidentHandler.Resolve)
+						r.With(can(rbac.PermTPSManage)).Post("/incidents/{incidentID}/comments", s.tpsIncidentHandler.Comment)
+						r.With(can(rbac.PermTPSView)).Get("/incidents/{incidentID}/photo", s.tpsIncidentHandler.Photo)
+					})
+
+					// NOTE: Per-election TPS management moved to standalone route at line ~400
+					// to avoid nested path issue: /admin/elections/{electionID}/tps/{tpsID}
+					// (not /admin/elections/{electionID}/tps/{electionID}/tps/{tpsID})
+
+					// Candidate management
+					r.Route("/{electionID}/candidates", func(r chi.Router) {
+						r.With(can(rbac.PermCandidateView)).Get("/", s.candidateAdminHandler.List)
+						r.With(can(rbac.PermCandidateManage)).Post("/", s.candidateAdminHandler.Create)
+						r.With(can(rbac.PermCandidateView)).Get("/{candidateID}", s.candidateAdminHandler.Detail)
+						r.With(can(rbac.PermCandidateManage)).Put("/{candidateID}", s.candidateAdminHandler.Update)
+						r.With(can(rbac.PermCandidateManage)).Delete("/{candidateID}", s.candidateAdminHandler.Delete)
+						r.With(can(rbac.PermCandidateManage)).Post("/{candidateID}/publish", s.candidateAdminHandler.Publish)
+						r.With(can(rbac.PermCandidateManage)).Post("/{candidateID}/unpublish", s.candidateAdminHandler.Unpublish)
+						r.With(can(rbac.PermCandidateManage)).Post("/{candidateID}/qr/generate", s.candidateAdminHandler.GenerateQRCode)
+					})
+
+					// Candidacy verification
+					r.Route("/{electionID}/candidacies", func(r chi.Router) {
+						r.With(can(rbac.PermCandidateView)).Get("/", s.candidacyHandler.AdminList)
+						r.With(can(rbac.PermCandidateView)).Get("/{candidateID}", s.candidacyHandler.AdminDetail)
+						r.With(can(rbac.PermCandidacyReview)).Post("/{candidateID}/reviews", s.candidacyHandler.Review)
+					})
+
+					// Ballot number draw (commit-reveal)
+					r.Route("/{electionID}/ballot-draw", func(r chi.Router) {
+						r.With(can(rbac.PermCandidateView)).Get("/", s.ballotDrawHandler.Get)
+						r.With(can(rbac.PermCandidateManage)).Post("/commit", s.ballotDrawHandler.Commit)
+						r.With(can(rbac.PermCandidateManage)).Post("/reveal", s.ballotDrawHandler.Reveal)
+					})
+
+					// DPT management
+					r.With(can(rbac.PermDPTEdit)).Post("/{electionID}/voters/import", s.dptHandler.Import)
+					r.Route("/{electionID}/voters", func(r chi.Router) {
+						r.With(can(rbac.PermDPTView)).Get("/", s.electionVoterHandler.AdminList)
+						r.With(can(rbac.PermDPTEdit)).Post("/", s.electionVoterHandler.AdminUpsert)
+						r.With(can(rbac.PermDPTView)).Get("/lookup", s.electionVoterHandler.AdminLookup)
+						r.With(can(rbac.PermDPTView)).Get("/eligibility/rules", s.electionVoterHandler.AdminGetEligibilityRules)
+						r.With(can(rbac.PermDPTEdit)).Put("/eligibility/rules", s.electionVoterHandler.AdminUpdateEligibilityRules)
+						r.With(can(rbac.PermDPTView)).Get("/eligibility/diff", s.electionVoterHandler.AdminPreviewEligibility)
+						r.With(can(rbac.PermDPTEdit)).Post("/eligibility/apply", s.electionVoterHandler.AdminApplyEligibility)
+						r.With(can(rbac.PermDPTEdit)).Patch("/{voterID}", s.electionVoterHandler.AdminPatch)
+						r.With(can(rbac.PermDPTEdit)).Post("/{voterID}/blacklist", s.electionVoterHandler.AdminBlacklist)
+						r.With(can(rbac.PermDPTEdit)).Post("/{voterID}/unblacklist", s.electionVoterHandler.AdminUnblacklist)
+						r.With(can(rbac.PermDPTView)).Get("/export", s.electionVoterHandler.ExportToExcel)
+						r.With(can(rbac.PermDPTView)).Get("/{voterID}", s.dptHandler.Get)
+						r.With(can(rbac.PermDPTEdit)).Put("/{voterID}", s.dptHandler.Update)
+						r.With(can(rbac.PermDPTEdit)).Delete("/{voterID}", s.dptHandler.Delete)
+					})
+
+					// Analytics endpoints
+					r.Route("/{electionID}/analytics", func(r chi.Router) {
+						r.Use(can(rbac.PermResultsView))
+						s.analyticsHandler.Mount(r)
+					})
+
+					// TPS monitoring per election
+					r.With(can(rbac.PermTPSView)).Get("/{electionID}/tps/monitor", s.tpsAdminHandler.Monitor)
+				})
+
+				// Candidate media management (global by candidate ID)
+				r.Route("/admin/candidates", func(r chi.Router) {
+					r.With(can(rbac.PermCandidateManage)).Post("/{candidateID}/media/profile", s.candidateAdminHandler.UploadProfileMedia)
+					r.With(can(rbac.PermCandidateView)).Get("/{candidateID}/media/profile", s.candidateAdminHandler.GetProfileMedia)
+					r.With(can(rbac.PermCandidateManage)).Delete("/{candidateID}/media/profile", s.candidateAdminHandler.DeleteProfileMedia)
+					r.With(can(rbac.PermCandidateManage)).Post("/{candidateID}/media", s.candidateAdminHandler.UploadMedia)
+					r.With(can(rbac.PermCandidateView)).Get("/{candidateID}/media/{mediaID}", s.candidateAdminHandler.GetMedia)
+					r.With(can(rbac.PermCandidateManage)).Delete("/{candidateID}/media/{mediaID}", s.candidateAdminHandler.DeleteMedia)
+				})
+
+				// Global voters endpoint
+				r.Route("/admin/voters", func(r chi.Router) {
+					r.With(can(rbac.PermDPTView)).Get("/", s.dptHandler.ListAll)
+				})
+
+				// Academic roster feeding rule-based DPT eligibility
+				r.Route("/admin/roster", func(r chi.Router) {
+					r.With(can(rbac.PermDPTView)).Get("/", s.masterHandler.ListRoster)
+					r.With(can(rbac.PermDPTEdit)).Post("/sync", s.masterHandler.SyncRoster)
+					r.With(can(rbac.PermDPTView)).Get("/syncs", s.masterHandler.ListRosterSyncRuns)
+				})
+
+				// Admin user management
+				r.Route("/admin/users", func(r chi.Router) {
+					r.Use(can(rbac.PermUsersManage))
+					r.Get("/", s.adminUserHandler.List)
+					r.Post("/", s.adminUserHandler.Create)
+					r.Get("/{userID}", s.adminUserHandler.Detail)
+					r.Patch("/{userID}", s.adminUserHandler.Update)
+					r.Post("/{userID}/reset-password", s.adminUserHandler.ResetPassword)
+					r.Post("/{userID}/activate", s.adminUserHandler.Activate)
+					r.Post("/{userID}/deactivate", s.adminUserHandler.Deactivate)
+					r.Delete("/{userID}", s.adminUserHandler.Delete)
+					r.Delete("/{userID}/2fa", s.authHandler.AdminResetTOTP)
+
+					// Role assignments (optionally scoped to an election or TPS)
+					r.Get("/{userID}/roles", s.rbacHandler.ListAssignments)
+					r.Post("/{userID}/roles", s.rbacHandler.Assign)
+					r.Delete("/{userID}/roles/{assignmentID}", s.rbacHandler.Revoke)
+				})
+
+				// App Settings
+				r.Route("/admin/settings", func(r chi.Router) {
+					r.With(can(rbac.PermElectionView)).Get("/", s.settingsHandler.GetSettings)
+					r.With(can(rbac.PermElectionView)).Get("/active-election", s.settingsHandler.GetActiveElection)
+					r.With(can(rbac.PermSettingsManage)).Put("/active-election", s.settingsHandler.UpdateActiveElection)
+					r.With(can(rbac.PermElectionView)).Get("/maintenance", s.settingsHandler.GetMaintenance)
+					r.With(can(rbac.PermSettingsManage)).Put("/maintenance", s.settingsHandler.UpdateMaintenance)
+				})
+
+				// Monitoring (counts/participation)
+				r.Group(func(r chi.Router) {
+					r.Use(can(rbac.PermResultsView))
+					s.monitoringHandler.RegisterRoutes(r)
+				})
+
+				// TPS management
+				r.Route("/admin/tps", func(r chi.Router) {
+					r.With(can(rbac.PermTPSView)).Get("/", s.tpsAdminHandler.List)
+					r.With(can(rbac.PermTPSManage)).Post("/", s.tpsAdminHandler.Create)
+					r.With(can(rbac.PermTPSView)).Get("/{tpsID}", s.tpsAdminHandler.Get)
+					r.With(can(rbac.PermTPSManage)).Put("/{tpsID}", s.tpsAdminHandler.Update)
+					r.With(can(rbac.PermTPSManage)).Delete("/{tpsID}", s.tpsAdminHandler.Delete)
+
+					// QR management
+					r.With(can(rbac.PermTPSManage)).Get("/{tpsID}/qr", s.tpsAdminHandler.GetQRMetadata)
+					r.With(can(rbac.PermTPSManage)).Post("/{tpsID}/qr/rotate", s.tpsAdminHandler.RotateQR)
+					r.With(can(rbac.PermTPSManage)).Get("/{tpsID}/qr/print", s.tpsAdminHandler.GetQRForPrint)
+
+					// Operator management
+					r.With(can(rbac.PermTPSManage)).Get("/{tpsID}/operators", s.tpsAdminHandler.ListOperators)
+					r.With(can(rbac.PermTPSManage)).Post("/{tpsID}/operators", s.tpsAdminHandler.CreateOperator)
+					r.With(can(rbac.PermTPSManage)).Delete("/{tpsID}/operators/{userID}", s.tpsAdminHandler.RemoveOperator)
+
+					// Allocation & activity
+					r.With(can(rbac.PermTPSView)).Get("/{tpsID}/allocation", s.tpsAdminHandler.Allocation)
+					r.With(can(rbac.PermTPSView)).Get("/{tpsID}/activity", s.tpsAdminHandler.Activity)
+				})
+
+			})
+
+			// TPS panel endpoints under admin namespace (scoped to the election and TPS in the path)
+			r.Route("/admin/elections/{electionID}/tps/{tpsID}", func(r chi.Router) {
+				r.Use(httpMiddleware.Authorize(s.jwtManager, s.rbacService))
+				can := httpMiddleware.RequirePermission
+				r.With(can(rbac.PermTPSView)).Get("/dashboard", s.tpsPanelHandler.Dashboard)
+				r.With(can(rbac.PermTPSView)).Get("/stats", s.tpsPanelHandler.Stats)
+				r.With(can(rbac.PermTPSView)).Get("/status", s.tpsPanelHandler.Status)
+				r.With(can(rbac.PermTPSView)).Get("/checkins", s.tpsPanelHandler.ListCheckins)
+				r.With(can(rbac.PermTPSView)).Get("/checkins/{checkinId}", s.tpsPanelHandler.GetCheckin)
+				r.With(can(rbac.PermTPSApproveCheckin), s.limitQRScan).Post("/checkin/scan", s.tpsPanelHandler.ScanCheckin)
+				r.With(can(rbac.PermTPSApproveCheckin)).Post("/checkin/manual", s.tpsPanelHandler.ManualCheckin)
+				r.With(can(rbac.PermTPSView)).Get("/stats/timeline", s.tpsPanelHandler.Timeline)
+				r.With(can(rbac.PermTPSView)).Get("/logs", s.tpsPanelHandler.Logs)
+				r.With(can(rbac.PermTPSView)).Get("/queue", s.tpsQueueHandler.Queue)
+				r.With(can(rbac.PermTPSApproveCheckin)).Post("/queue/call-next", s.tpsQueueHandler.CallNext)
+				r.With(can(rbac.PermTPSView)).Get("/queue/settings", s.tpsQueueHandler.GetSettings)
+				r.With(can(rbac.PermTPSApproveCheckin)).Put("/queue/settings", s.tpsQueueHandler.UpdateSettings)
+				r.With(can(rbac.PermTPSView)).Get("/schedule", s.tpsScheduleHandler.Get)
+				r.With(can(rbac.PermTPSManage)).Put("/schedule", s.tpsScheduleHandler.Update)
+				r.With(can(rbac.PermTPSApproveCheckin)).Post("/schedule/extend", s.tpsScheduleHandler.Extend)
+				r.With(can(rbac.PermTPSView)).Get("/incidents", s.tpsIncidentHandler.PanelList)
+				r.With(can(rbac.PermTPSApproveCheckin)).Post("/incidents", s.tpsIncidentHandler.Report)
+				r.With(can(rbac.PermTPSView)).Get("/incidents/{incidentID}", s.tpsIncidentHandler.PanelGet)
+				r.With(can(rbac.PermTPSApproveCheckin)).Post("/incidents/{incidentID}/comments", s.tpsIncidentHandler.PanelComment)
+				r.With(can(rbac.PermTPSApproveCheckin)).Put("/incidents/{incidentID}/photo", s.tpsIncidentHandler.UploadPhoto)
+				r.With(can(rbac.PermTPSView)).Get("/incidents/{incidentID}/photo", s.tpsIncidentHandler.PanelPhoto)
+
+				// TPS management endpoints
+				r.With(can(rbac.PermTPSManage)).Get("/operators", s.tpsHandler.AdminListOperators)
+				r.With(can(rbac.PermTPSManage)).Post("/operators", s.tpsHandler.AdminCreateOperator)
+				r.With(can(rbac.PermTPSManage)).Delete("/operators/{userID}", s.tpsHandler.AdminDeleteOperator)
+				r.With(can(rbac.PermTPSView)).Get("/allocation", s.tpsAdminHandler.Allocation)
+				r.With(can(rbac.PermTPSView)).Get("/activity", s.tpsAdminHandler.Activity)
+			})
+
+			r.Group(func(r chi.Router) {
+				r.Use(httpMiddleware.Authorize(s.jwtManager, s.rbacService))
+				can := httpMiddleware.RequirePermission
+				r.With(can(rbac.PermTPSApproveCheckin), s.limitQRScan).Post("/tps/{tpsID}/checkins/{checkinID}/scan-candidate", s.votingHandler.ScanTPSCandidate)
+				r.With(can(rbac.PermTPSApproveCheckin)).Post("/tps/{tpsID}/checkins", s.tpsPanelHandler.CreateCheckinSimple)
+				r.With(can(rbac.PermTPSView)).Get("/ws/tps/{tpsID}/queue", s.tpsWSHandler.HandleTPSQueue)
+			})
+		})
+	})
+
+	if err := spec.Build(r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
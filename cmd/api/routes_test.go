@@ -0,0 +1,172 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func testRouter(t *testing.T) *chi.Mux {
+	t.Helper()
+	pass := func(next http.Handler) http.Handler { return next }
+	r, err := newRouter(&server{
+		limitLogin:     pass,
+		limitLoginStep: pass,
+		limitRegister:  pass,
+		limitReset:     pass,
+		limitVote:      pass,
+		limitQRScan:    pass,
+	})
+	if err != nil {
+		// an operation in apiOperations no longer matches a route
+		t.Fatal(err)
+	}
+	return r
+}
+
+// TestSpecCoversRoutes fails when a registered route is missing from the
+// served document or a POST, PUT or PATCH route has no request body bound in
+// apiOperations without being listed in bodyless.
+func TestSpecCoversRoutes(t *testing.T) {
+	r := testRouter(t)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /openapi.json: code = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	var doc struct {
+		OpenAPI string `json:"openapi"`
+		Paths   map[string]map[string]struct {
+			RequestBody json.RawMessage `json:"requestBody"`
+		} `json:"paths"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.OpenAPI != "3.1.0" {
+		t.Fatalf("openapi = %q", doc.OpenAPI)
+	}
+
+	param := regexp.MustCompile(`\{([^}:]+)(:[^}]*)?\}`)
+	routes := 0
+	err := chi.Walk(r, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		routes++
+		path := strings.TrimSuffix(route, "/*")
+		if len(path) > 1 {
+			path = strings.TrimSuffix(path, "/")
+		}
+		path = param.ReplaceAllString(path, "{$1}")
+		op, ok := doc.Paths[path][strings.ToLower(method)]
+		if !ok {
+			t.Errorf("%s %s is not in /openapi.json", method, route)
+			return nil
+		}
+		switch method {
+		case http.MethodPost, http.MethodPut, http.MethodPatch:
+			key := method + " " + path
+			if op.RequestBody == nil && !bodyless[key] {
+				t.Errorf("%s has no Request in apiOperations and is not in bodyless", key)
+			}
+			if op.RequestBody != nil && bodyless[key] {
+				t.Errorf("%s binds a Request but is listed in bodyless", key)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if routes < 100 {
+		t.Fatalf("walked %d routes, the route table looks incomplete", routes)
+	}
+}
+
+func TestSpecBindsRequestBodies(t *testing.T) {
+	r := testRouter(t)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	var doc struct {
+		Paths map[string]map[string]struct {
+			RequestBody *struct {
+				Content map[string]struct {
+					Schema struct {
+						Ref string `json:"$ref"`
+					} `json:"schema"`
+				} `json:"content"`
+			} `json:"requestBody"`
+		} `json:"paths"`
+		Components struct {
+			Schemas map[string]struct {
+				Required []string `json:"required"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct{ method, path, schema, required string }{
+		{"post", "/api/v1/voting/online/cast", "voting.CastOnlineVoteRequest", "candidate_id"},
+		{"post", "/api/v1/admin/tps", "tps.TPSCreateRequest", "code"},
+	} {
+		body := doc.Paths[tt.path][tt.method].RequestBody
+		if body == nil {
+			t.Errorf("%s %s has no request body", tt.method, tt.path)
+			continue
+		}
+		if ref := body.Content["application/json"].Schema.Ref; ref != "#/components/schemas/"+tt.schema {
+			t.Errorf("%s %s: schema = %q", tt.method, tt.path, ref)
+		}
+		if !strings.Contains(strings.Join(doc.Components.Schemas[tt.schema].Required, ","), tt.required) {
+			t.Errorf("%s: required = %v, want %s", tt.schema, doc.Components.Schemas[tt.schema].Required, tt.required)
+		}
+	}
+}
+
+// bodyless lists the POST, PUT and PATCH routes that bind no Request,
+// grouped by why. Routes that read a JSON body bind its DTO instead.
+var bodyless = map[string]bool{
+	// actions on the resource in the path
+	"POST /api/v1/auth/2fa/setup":                                                    true,
+	"POST /api/v1/elections/{electionID}/candidacy/submit":                           true,
+	"POST /api/v1/admin/elections/{electionID}/open-voting":                          true,
+	"POST /api/v1/admin/elections/{electionID}/close-voting":                         true,
+	"POST /api/v1/admin/elections/{electionID}/actions/open-voting":                  true,
+	"POST /api/v1/admin/elections/{electionID}/actions/close-voting":                 true,
+	"POST /api/v1/admin/elections/{electionID}/actions/archive":                      true,
+	"POST /api/v1/admin/elections/{electionID}/encrypted-tally":                      true,
+	"POST /api/v1/admin/elections/{electionID}/recount/rebuild-stats":                true,
+	"POST /api/v1/admin/elections/{electionID}/results/snapshot":                     true,
+	"POST /api/v1/admin/elections/{electionID}/ballot-draw/commit":                   true,
+	"POST /api/v1/admin/elections/{electionID}/ballot-draw/reveal":                   true,
+	"POST /api/v1/admin/elections/{electionID}/candidates/{candidateID}/publish":     true,
+	"POST /api/v1/admin/elections/{electionID}/candidates/{candidateID}/unpublish":   true,
+	"POST /api/v1/admin/elections/{electionID}/candidates/{candidateID}/qr/generate": true,
+	"POST /api/v1/admin/elections/{electionID}/voters/{voterID}/unblacklist":         true,
+	"POST /api/v1/admin/elections/{electionID}/tps/{tpsID}/qr/rotate":                true,
+	"POST /api/v1/admin/elections/{electionID}/tps/{tpsID}/queue/call-next":          true,
+	"POST /api/v1/admin/tps/{tpsID}/qr/rotate":                                       true,
+	"POST /api/v1/admin/users/{userID}/activate":                                     true,
+	"POST /api/v1/admin/users/{userID}/deactivate":                                   true,
+
+	// multipart uploads
+	"POST /api/v1/elections/{electionID}/candidacy/documents":                           true,
+	"POST /api/v1/admin/elections/import":                                               true,
+	"POST /api/v1/admin/elections/{electionID}/branding/logo/{slot}":                    true,
+	"POST /api/v1/admin/elections/{electionID}/voters/import":                           true,
+	"POST /api/v1/admin/candidates/{candidateID}/media":                                 true,
+	"POST /api/v1/admin/candidates/{candidateID}/media/profile":                         true,
+	"POST /api/v1/admin/roster/sync":                                                    true,
+	"PUT /api/v1/admin/elections/{electionID}/tps/{tpsID}/incidents/{incidentID}/photo": true,
+
+	// tps.CreateTPSRequest requires election_id, which this route takes from
+	// the path after decoding; binding it would reject bodies without it
+	"POST /api/v1/admin/elections/{electionID}/tps": true,
+}
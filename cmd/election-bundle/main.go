@@ -0,0 +1,137 @@
+// cmd/election-bundle/main.go
+// Export an election as a versioned bundle (zip of JSON/CSV, media and a
+// checksum manifest), or restore a bundle into this instance.
+//
+// Usage:
+//   DATABASE_URL=postgres://... go run ./cmd/election-bundle -export 3 -out pemira-2025.zip
+//   DATABASE_URL=postgres://... go run ./cmd/election-bundle -import pemira-2025.zip
+//   go run ./cmd/election-bundle -verify pemira-2025.zip
+//
+// Flags:
+//   -export ID    Election to export
+//   -out FILE     Output file for -export (default: pemira-election-<id>-<time>.zip)
+//   -import FILE  Bundle to restore as a new election
+//   -verify FILE  Check bundle checksums without touching the database
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"pemira-api/internal/archive"
+)
+
+func main() {
+	exportID := flag.Int64("export", 0, "Election ID to export")
+	out := flag.String("out", "", "Output file for -export")
+	importPath := flag.String("import", "", "Bundle file to import")
+	verifyPath := flag.String("verify", "", "Bundle file to verify")
+	flag.Parse()
+
+	if *verifyPath != "" {
+		verify(*verifyPath)
+		return
+	}
+	if (*exportID > 0) == (*importPath != "") {
+		log.Fatal("exactly one of -export, -import or -verify is required")
+	}
+
+	dbURL := os.Getenv("DATABASE_URL")
+	if dbURL == "" {
+		log.Fatal("DATABASE_URL is required")
+	}
+
+	ctx := context.Background()
+
+	db, err := pgxpool.New(ctx, dbURL)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	svc := archive.NewService(archive.NewPgRepository(db))
+
+	if *exportID > 0 {
+		path := *out
+		if path == "" {
+			path = archive.BundleFileName(*exportID, time.Now())
+		}
+		exportBundle(ctx, svc, *exportID, path)
+		return
+	}
+	importBundle(ctx, svc, *importPath)
+}
+
+func exportBundle(ctx context.Context, svc *archive.Service, electionID int64, path string) {
+	f, err := os.Create(path)
+	if err != nil {
+		log.Fatalf("Failed to create %s: %v", path, err)
+	}
+
+	manifest, err := svc.Export(ctx, electionID, f)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(path)
+		log.Fatalf("Export failed: %v", err)
+	}
+
+	fmt.Printf("Exported election %d (%s) to %s\n", electionID, manifest.ElectionCode, path)
+	for _, file := range manifest.Files {
+		if file.Rows != nil {
+			fmt.Printf("  %-22s %6d rows  %s\n", file.Name, *file.Rows, file.SHA256)
+		}
+	}
+	if len(manifest.MissingMedia) > 0 {
+		fmt.Printf("Warning: %d media files could not be downloaded and are listed in the manifest\n", len(manifest.MissingMedia))
+	}
+}
+
+func importBundle(ctx context.Context, svc *archive.Service, path string) {
+	f, info := openBundle(path)
+	defer f.Close()
+
+	result, err := svc.Import(ctx, f, info.Size(), nil)
+	if err != nil {
+		log.Fatalf("Import failed: %v", err)
+	}
+
+	fmt.Printf("Imported bundle %s as election %d (archive %d)\n", path, result.ElectionID, result.ArchiveID)
+	fmt.Printf("  candidates=%d media=%d tps=%d votes=%d vote_tokens=%d audit=%d\n",
+		result.Candidates, result.CandidateMedia, result.TPS, result.Votes, result.VoteTokens, result.AuditEntries)
+}
+
+func verify(path string) {
+	f, info := openBundle(path)
+	defer f.Close()
+
+	manifest, snap, media, err := archive.ReadBundle(f, info.Size())
+	if err != nil {
+		log.Fatalf("Bundle is not valid: %v", err)
+	}
+
+	fmt.Printf("Bundle OK: %s v%d, election %d (%s), exported %s\n",
+		manifest.Format, manifest.Version, manifest.SourceElectionID, manifest.ElectionCode, manifest.ExportedAt.Format(time.RFC3339))
+	fmt.Printf("  candidates=%d media=%d tps=%d votes=%d vote_tokens=%d files=%d\n",
+		len(snap.Candidates), len(media), len(snap.TPS), len(snap.Votes), len(snap.VoteTokens), len(manifest.Files))
+}
+
+func openBundle(path string) (*os.File, os.FileInfo) {
+	f, err := os.Open(path)
+	if err != nil {
+		log.Fatalf("Failed to open %s: %v", path, err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		log.Fatalf("Failed to stat %s: %v", path, err)
+	}
+	return f, info
+}
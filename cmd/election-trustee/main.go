@@ -0,0 +1,335 @@
+// cmd/election-trustee/main.go
+// Client for committee trustees of an end-to-end verifiable election. All
+// secrets (transport key, key share) stay in the local state file; the API
+// only ever receives public values and proofs.
+//
+// Usage (in ceremony order, each step once all trustees finished the last):
+//   TOKEN=... go run ./cmd/election-trustee -api https://host/api/v1 -election 3 -index 2 -step transport
+//   TOKEN=... go run ./cmd/election-trustee -api ... -election 3 -index 2 -step deal
+//   TOKEN=... go run ./cmd/election-trustee -api ... -election 3 -index 2 -step confirm
+//   TOKEN=... go run ./cmd/election-trustee -api ... -election 3 -index 2 -step decrypt
+//   go run ./cmd/election-trustee -api ... -election 3 -step audit
+//
+// Flags:
+//   -api URL      API base URL including /api/v1
+//   -election ID  Election ID
+//   -index N      Trustee index assigned in the ceremony
+//   -step NAME    transport | deal | confirm | decrypt | audit
+//   -state FILE   Secret state file (default: trustee-<election>-<index>.json)
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"pemira-api/internal/crypto"
+	"pemira-api/internal/electionkey"
+)
+
+type state struct {
+	ElectionID      int64        `json:"election_id"`
+	Index           int          `json:"index"`
+	TransportSecret *crypto.Elem `json:"transport_secret"`
+	Share           *crypto.Elem `json:"share,omitempty"`
+}
+
+type client struct {
+	api   string
+	token string
+	http  *http.Client
+}
+
+func main() {
+	api := flag.String("api", "", "API base URL including /api/v1")
+	electionID := flag.Int64("election", 0, "Election ID")
+	index := flag.Int("index", 0, "Trustee index")
+	step := flag.String("step", "", "transport | deal | confirm | decrypt | audit")
+	statePath := flag.String("state", "", "Secret state file")
+	flag.Parse()
+
+	if *api == "" || *electionID <= 0 {
+		log.Fatal("-api and -election are required")
+	}
+	c := &client{api: strings.TrimRight(*api, "/"), token: os.Getenv("TOKEN"), http: &http.Client{Timeout: 2 * time.Minute}}
+	g := crypto.DefaultGroup()
+
+	if *step == "audit" {
+		audit(c, g, *electionID)
+		return
+	}
+
+	if *index <= 0 {
+		log.Fatal("-index is required")
+	}
+	if c.token == "" {
+		log.Fatal("TOKEN is required")
+	}
+	path := *statePath
+	if path == "" {
+		path = fmt.Sprintf("trustee-%d-%d.json", *electionID, *index)
+	}
+
+	switch *step {
+	case "transport":
+		transport(c, g, path, *electionID, *index)
+	case "deal":
+		deal(c, g, loadState(path), *electionID)
+	case "confirm":
+		confirm(c, g, path, loadState(path))
+	case "decrypt":
+		decrypt(c, g, loadState(path))
+	default:
+		log.Fatalf("unknown -step %q", *step)
+	}
+}
+
+func transport(c *client, g *crypto.Group, path string, electionID int64, index int) {
+	if _, err := os.Stat(path); err == nil {
+		log.Fatalf("%s already exists; refusing to overwrite a transport secret", path)
+	}
+	secret, err := g.RandomScalar()
+	if err != nil {
+		log.Fatal(err)
+	}
+	proof, err := g.ProveKnowledge(electionkey.TransportContext(electionID, index), secret)
+	if err != nil {
+		log.Fatal(err)
+	}
+	saveState(path, &state{ElectionID: electionID, Index: index, TransportSecret: crypto.E(secret)})
+
+	var out electionkey.Ceremony
+	c.do("POST", fmt.Sprintf("/trustee/elections/%d/transport-key", electionID),
+		electionkey.TransportKeyRequest{PublicKey: crypto.E(g.GExp(secret)), Proof: proof}, &out)
+	fmt.Printf("Transport key submitted (ceremony status %s). Keep %s private.\n", out.Status, path)
+}
+
+func deal(c *client, g *crypto.Group, st *state, electionID int64) {
+	var cer electionkey.Ceremony
+	c.do("GET", fmt.Sprintf("/trustee/elections/%d/key-ceremony", electionID), nil, &cer)
+	if cer.Status != electionkey.StatusDealing {
+		log.Fatalf("ceremony is in step %s, not %s", cer.Status, electionkey.StatusDealing)
+	}
+
+	keys := make(map[int]*big.Int, len(cer.Trustees))
+	for _, t := range cer.Trustees {
+		if t.TransportKey == nil || t.TransportProof == nil {
+			log.Fatalf("trustee %d has no transport key", t.Index)
+		}
+		if err := g.VerifyKnowledge(electionkey.TransportContext(electionID, t.Index), t.TransportKey.Int(), *t.TransportProof); err != nil {
+			log.Fatalf("transport key of trustee %d does not verify: %v", t.Index, err)
+		}
+		keys[t.Index] = t.TransportKey.Int()
+	}
+
+	poly, err := g.NewPolynomial(cer.Threshold)
+	if err != nil {
+		log.Fatal(err)
+	}
+	d, err := g.Deal(electionkey.CeremonyContext(electionID), st.Index, poly, keys)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var out electionkey.Ceremony
+	c.do("POST", fmt.Sprintf("/trustee/elections/%d/dealing", electionID), d, &out)
+	fmt.Printf("Dealing submitted to %d trustees (ceremony status %s).\n", len(d.Shares), out.Status)
+}
+
+func confirm(c *client, g *crypto.Group, path string, st *state) {
+	var shares electionkey.TrusteeShares
+	c.do("GET", fmt.Sprintf("/trustee/elections/%d/shares", st.ElectionID), nil, &shares)
+
+	sum := new(big.Int)
+	complaints := []int{}
+	for _, rs := range shares.Shares {
+		s, err := g.DecryptShare(electionkey.CeremonyContext(st.ElectionID), rs.Dealer, st.TransportSecret.Int(), rs.Share)
+		if err == nil {
+			err = g.VerifyShare(rs.Commitments, st.Index, s)
+		}
+		if err != nil {
+			fmt.Printf("Share from trustee %d rejected: %v\n", rs.Dealer, err)
+			complaints = append(complaints, rs.Dealer)
+			continue
+		}
+		sum.Add(sum, s)
+	}
+
+	if len(complaints) == 0 {
+		st.Share = crypto.E(sum.Mod(sum, g.Q))
+		saveState(path, st)
+	}
+
+	var out electionkey.Ceremony
+	c.do("POST", fmt.Sprintf("/trustee/elections/%d/confirm", st.ElectionID), electionkey.ConfirmRequest{Complaints: complaints}, &out)
+	if len(complaints) > 0 {
+		fmt.Printf("Complaint filed against %v; the ceremony must be restarted.\n", complaints)
+		return
+	}
+	fmt.Printf("Key share stored in %s (ceremony status %s).\n", path, out.Status)
+}
+
+func decrypt(c *client, g *crypto.Group, st *state) {
+	if st.Share == nil {
+		log.Fatal("state file has no key share; run -step confirm first")
+	}
+	var tally electionkey.Tally
+	c.do("GET", fmt.Sprintf("/trustee/elections/%d/tally", st.ElectionID), nil, &tally)
+
+	partials, err := g.PartialDecrypt(electionkey.TallyContext(st.ElectionID), st.Index, st.Share.Int(), tally.Tally)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var out electionkey.Tally
+	c.do("POST", fmt.Sprintf("/trustee/elections/%d/partial-decryption", st.ElectionID),
+		electionkey.PartialDecryptionRequest{Partials: partials}, &out)
+	fmt.Printf("Partial decryption submitted (%d received, tally %s).\n", len(out.Partials), out.Status)
+}
+
+// audit rechecks a published tally from public data only: every ballot on
+// the bulletin board, the homomorphic aggregate, every partial decryption
+// and the decoded counts.
+func audit(c *client, g *crypto.Group, electionID int64) {
+	var vt electionkey.VerifiableTally
+	c.do("GET", fmt.Sprintf("/elections/%d/verifiable-tally", electionID), nil, &vt)
+	key, tally := vt.Key, vt.Tally
+
+	commitments := make([][]*crypto.Elem, len(vt.Trustees))
+	for i, t := range vt.Trustees {
+		if err := g.VerifyCommitments(electionkey.CeremonyContext(electionID), t.Index, key.Threshold, t.Commitments, *t.CommitmentProof); err != nil {
+			log.Fatalf("FAIL: commitments of trustee %d: %v", t.Index, err)
+		}
+		commitments[i] = t.Commitments
+	}
+	pk := g.JointPublicKey(commitments)
+	if pk.Cmp(key.PublicKey.Int()) != 0 {
+		log.Fatal("FAIL: election key is not the product of the trustees' commitments")
+	}
+
+	var ballots []*crypto.EncryptedBallot
+	var afterID int64
+	for {
+		var page struct {
+			Items []electionkey.BallotRecord `json:"items"`
+		}
+		c.do("GET", fmt.Sprintf("/elections/%d/bulletin-board?after_id=%d", electionID, afterID), nil, &page)
+		if len(page.Items) == 0 {
+			break
+		}
+		for _, rec := range page.Items {
+			if err := g.VerifyBallot(pk, electionID, key.CandidateIDs, rec.Ballot); err != nil {
+				log.Fatalf("FAIL: ballot %s: %v", rec.Tracker, err)
+			}
+			if rec.Ballot.Tracker() != rec.Tracker {
+				log.Fatalf("FAIL: ballot %s does not match its tracker", rec.Tracker)
+			}
+			ballots = append(ballots, rec.Ballot)
+		}
+		afterID = page.Items[len(page.Items)-1].ID
+	}
+	if len(ballots) != tally.BallotCount {
+		log.Fatalf("FAIL: bulletin board has %d ballots, tally counts %d", len(ballots), tally.BallotCount)
+	}
+
+	aggregate := g.Aggregate(key.CandidateIDs, ballots)
+	agg, _ := json.Marshal(aggregate)
+	published, _ := json.Marshal(tally.Tally)
+	if !bytes.Equal(agg, published) {
+		log.Fatal("FAIL: published encrypted tally is not the product of the ballots")
+	}
+
+	partials := make(map[int][]crypto.PartialDecryption, len(tally.Partials))
+	for _, p := range tally.Partials {
+		vk := g.VerificationKey(commitments, p.Index)
+		if err := g.VerifyPartial(electionkey.TallyContext(electionID), p.Index, vk, aggregate, p.Partials); err != nil {
+			log.Fatalf("FAIL: partial decryption of trustee %d: %v", p.Index, err)
+		}
+		partials[p.Index] = p.Partials
+	}
+	counts, err := g.DecryptTally(key.Threshold, aggregate, partials, int64(len(ballots)))
+	if err != nil {
+		log.Fatalf("FAIL: %v", err)
+	}
+	for _, r := range tally.Result {
+		if counts[r.CandidateID] != r.Votes {
+			log.Fatalf("FAIL: candidate %d published %d votes, decrypts to %d", r.CandidateID, r.Votes, counts[r.CandidateID])
+		}
+	}
+
+	fmt.Printf("OK: %d ballots, %d partial decryptions, result verified\n", len(ballots), len(partials))
+	for _, r := range tally.Result {
+		fmt.Printf("  candidate %-6d %d\n", r.CandidateID, r.Votes)
+	}
+}
+
+func (c *client) do(method, path string, body, out any) {
+	var rd io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			log.Fatal(err)
+		}
+		rd = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, c.api+path, rd)
+	if err != nil {
+		log.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		log.Fatalf("%s %s: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode >= 300 {
+		log.Fatalf("%s %s: %s %s", method, path, resp.Status, data)
+	}
+
+	envelope := struct {
+		Data any `json:"data"`
+	}{Data: out}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		log.Fatalf("%s %s: %v", method, path, err)
+	}
+}
+
+func loadState(path string) *state {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		log.Fatalf("%s not found; run -step transport first", path)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	var st state
+	if err := json.Unmarshal(data, &st); err != nil {
+		log.Fatalf("%s: %v", path, err)
+	}
+	return &st
+}
+
+func saveState(path string, st *state) {
+	data, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"pemira-api/internal/election"
+	"pemira-api/internal/tps"
+)
+
+const (
+	electionUsage = "pemiractl election open|close ID"
+	tpsUsage      = "pemiractl tps rotate-qr -election ID"
+)
+
+func runElection(ctx context.Context, db *pgxpool.Pool, args []string) error {
+	sub, args, err := subcommand(args, electionUsage)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		return fmt.Errorf("usage: %s", electionUsage)
+	}
+	id, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil || id <= 0 {
+		return fmt.Errorf("invalid election ID %q", args[0])
+	}
+
+	svc := election.NewAdminService(election.NewPgAdminRepository(db))
+
+	var dto *election.AdminElectionDTO
+	switch sub {
+	case "open":
+		dto, err = svc.OpenVoting(ctx, id)
+	case "close":
+		dto, err = svc.CloseVoting(ctx, id)
+	default:
+		return fmt.Errorf("usage: %s", electionUsage)
+	}
+	if err != nil {
+		return fmt.Errorf("%s voting: %w", sub, err)
+	}
+
+	fmt.Printf("Election %d (%s) is now %s.\n", dto.ID, dto.Name, dto.Status)
+	return nil
+}
+
+func runTPS(ctx context.Context, db *pgxpool.Pool, args []string) error {
+	sub, args, err := subcommand(args, tpsUsage)
+	if err != nil {
+		return err
+	}
+	if sub != "rotate-qr" {
+		return fmt.Errorf("usage: %s", tpsUsage)
+	}
+
+	fs := flag.NewFlagSet("tps rotate-qr", flag.ExitOnError)
+	electionID := fs.Int64("election", 0, "Election ID")
+	fs.Parse(args)
+	if *electionID <= 0 {
+		return fmt.Errorf("usage: %s", tpsUsage)
+	}
+
+	svc := tps.NewAdminService(tps.NewPgAdminRepository(db))
+	list, err := svc.List(ctx, *electionID)
+	if err != nil {
+		return err
+	}
+	if len(list) == 0 {
+		fmt.Println("Election has no TPS.")
+		return nil
+	}
+
+	failed := 0
+	for _, t := range list {
+		if _, err := svc.RotateQR(ctx, t.ID); err != nil {
+			fmt.Printf("  %-10s %s: FAILED: %v\n", t.Code, t.Name, err)
+			failed++
+			continue
+		}
+		fmt.Printf("  %-10s %s: rotated\n", t.Code, t.Name)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d TPS QR codes failed to rotate", failed, len(list))
+	}
+	fmt.Printf("Rotated %d TPS QR codes.\n", len(list))
+	return nil
+}
@@ -0,0 +1,99 @@
+// cmd/pemiractl/main.go
+// Operations CLI: schema migrations (embedded in the binary) and the admin
+// tasks that would otherwise need psql.
+//
+// Usage:
+//   DATABASE_URL=postgres://... pemiractl migrate status
+//   DATABASE_URL=postgres://... pemiractl migrate up
+//   DATABASE_URL=postgres://... pemiractl migrate down [-steps N]
+//   DATABASE_URL=postgres://... pemiractl migrate to VERSION
+//   DATABASE_URL=postgres://... pemiractl migrate baseline VERSION
+//   DATABASE_URL=postgres://... pemiractl user create-superadmin -username U -email E -name N [-password P]
+//   DATABASE_URL=postgres://... pemiractl user reset-password -username U [-password P]
+//   DATABASE_URL=postgres://... pemiractl user reset-2fa -username U
+//   DATABASE_URL=postgres://... TOTP_ENCRYPTION_KEY=... pemiractl user rekey-2fa -old-key K
+//   DATABASE_URL=postgres://... pemiractl election open|close ID
+//   DATABASE_URL=postgres://... pemiractl tps rotate-qr -election ID
+//   DATABASE_URL=postgres://... pemiractl recount -election ID [-rebuild-stats] [-json]
+//   DATABASE_URL=postgres://... pemiractl results export -election ID [-format csv|json] [-out FILE]
+//   DATABASE_URL=postgres://... pemiractl roster sync [-file FILE [-type T] [-full]]
+//
+// Passwords left empty are generated and printed once.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	_ "github.com/joho/godotenv/autoload"
+)
+
+const usage = `pemiractl <command> [arguments]
+
+Commands:
+  migrate status|up|down|to|baseline   Schema migrations
+  user create-superadmin|reset-password|reset-2fa|rekey-2fa
+  election open|close ID
+  tps rotate-qr -election ID           Rotate the QR code of every TPS
+  recount -election ID                 Cross-check vote counts
+  results export -election ID          Export per-candidate results
+  roster sync [-file FILE]             Sync the academic roster
+
+Set DATABASE_URL (a .env file is read if present).`
+
+type command func(ctx context.Context, db *pgxpool.Pool, args []string) error
+
+var commands = map[string]command{
+	"migrate":  runMigrate,
+	"user":     runUser,
+	"election": runElection,
+	"tps":      runTPS,
+	"recount":  runRecount,
+	"results":  runResults,
+	"roster":   runRoster,
+}
+
+func main() {
+	log.SetFlags(0)
+
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s\n", os.Args[1], usage)
+		os.Exit(2)
+	}
+
+	dbURL := os.Getenv("DATABASE_URL")
+	if dbURL == "" {
+		log.Fatal("DATABASE_URL is required")
+	}
+
+	ctx := context.Background()
+
+	db, err := pgxpool.New(ctx, dbURL)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	if err := cmd(ctx, db, os.Args[2:]); err != nil {
+		db.Close()
+		log.Fatal(err)
+	}
+}
+
+// subcommand splits "name rest..." and reports a usage error when name is
+// missing.
+func subcommand(args []string, usage string) (string, []string, error) {
+	if len(args) == 0 {
+		return "", nil, fmt.Errorf("usage: %s", usage)
+	}
+	return args[0], args[1:], nil
+}
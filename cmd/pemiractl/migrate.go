@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"pemira-api/internal/migrate"
+	"pemira-api/migrations"
+)
+
+const migrateUsage = "pemiractl migrate status | up | down [-steps N] | to VERSION | baseline VERSION"
+
+func runMigrate(ctx context.Context, db *pgxpool.Pool, args []string) error {
+	sub, args, err := subcommand(args, migrateUsage)
+	if err != nil {
+		return err
+	}
+
+	migs, err := migrate.Load(migrations.FS)
+	if err != nil {
+		return err
+	}
+	m := migrate.New(db, migs)
+	m.Logf = func(format string, a ...any) { fmt.Printf(format+"\n", a...) }
+
+	switch sub {
+	case "status":
+		return migrateStatus(ctx, m)
+	case "up":
+		return report(m.Up(ctx))
+	case "down":
+		fs := flag.NewFlagSet("migrate down", flag.ExitOnError)
+		steps := fs.Int("steps", 1, "Number of migrations to roll back")
+		fs.Parse(args)
+		if *steps < 1 {
+			return fmt.Errorf("-steps must be at least 1")
+		}
+		return report(m.Down(ctx, *steps))
+	case "to":
+		version, err := versionArg(args)
+		if err != nil {
+			return err
+		}
+		return report(m.To(ctx, version))
+	case "baseline":
+		version, err := versionArg(args)
+		if err != nil {
+			return err
+		}
+		n, err := m.Baseline(ctx, version)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Marked %d migrations up to %03d as applied.\n", n, version)
+		return nil
+	}
+	return fmt.Errorf("usage: %s", migrateUsage)
+}
+
+func versionArg(args []string) (int64, error) {
+	if len(args) != 1 {
+		return 0, fmt.Errorf("usage: %s", migrateUsage)
+	}
+	v, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("invalid version %q", args[0])
+	}
+	return v, nil
+}
+
+func report(done []migrate.Migration, err error) error {
+	if err != nil {
+		if len(done) > 0 {
+			fmt.Printf("%d migrations completed before the failure.\n", len(done))
+		}
+		return err
+	}
+	if len(done) == 0 {
+		fmt.Println("Nothing to do.")
+		return nil
+	}
+	fmt.Printf("%d migrations completed.\n", len(done))
+	return nil
+}
+
+func migrateStatus(ctx context.Context, m *migrate.Migrator) error {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+	pending := 0
+	for _, s := range statuses {
+		applied := "pending"
+		switch {
+		case s.Missing:
+			applied = s.AppliedAt.Format("2006-01-02 15:04:05") + " (not in this binary)"
+		case s.Applied:
+			applied = s.AppliedAt.Format("2006-01-02 15:04:05")
+		default:
+			pending++
+		}
+		fmt.Fprintf(w, "%03d\t%s\t%s\n", s.Version, s.Name, applied)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Printf("\n%d pending.\n", pending)
+	return nil
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"pemira-api/internal/candidate"
+	"pemira-api/internal/recount"
+)
+
+const (
+	recountUsage = "pemiractl recount -election ID [-rebuild-stats] [-json]"
+	resultsUsage = "pemiractl results export -election ID [-format csv|json] [-out FILE]"
+)
+
+func runRecount(ctx context.Context, db *pgxpool.Pool, args []string) error {
+	fs := flag.NewFlagSet("recount", flag.ExitOnError)
+	electionID := fs.Int64("election", 0, "Election ID")
+	rebuild := fs.Bool("rebuild-stats", false, "Rebuild vote_stats when it drifted")
+	asJSON := fs.Bool("json", false, "Print the report as JSON")
+	fs.Parse(args)
+	if *electionID <= 0 {
+		return fmt.Errorf("usage: %s", recountUsage)
+	}
+
+	report, err := recount.NewService(recount.NewPgRepository(db)).Check(ctx, *electionID, *rebuild)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		if err := writeJSON(os.Stdout, report); err != nil {
+			return err
+		}
+	} else {
+		report.WriteText(os.Stdout)
+	}
+	if !report.Consistent {
+		return errors.New("recount found mismatches")
+	}
+	return nil
+}
+
+// resultRow is one candidate's line in a results export.
+type resultRow struct {
+	CandidateID int64  `json:"candidate_id"`
+	Number      int    `json:"number"`
+	Name        string `json:"name"`
+	Votes       int64  `json:"votes"`
+	Online      int64  `json:"online"`
+	TPS         int64  `json:"tps"`
+}
+
+type resultsExport struct {
+	ElectionID int64          `json:"election_id"`
+	Totals     recount.Totals `json:"totals"`
+	Consistent bool           `json:"consistent"`
+	Candidates []resultRow    `json:"candidates"`
+}
+
+func runResults(ctx context.Context, db *pgxpool.Pool, args []string) error {
+	sub, args, err := subcommand(args, resultsUsage)
+	if err != nil {
+		return err
+	}
+	if sub != "export" {
+		return fmt.Errorf("usage: %s", resultsUsage)
+	}
+
+	fs := flag.NewFlagSet("results export", flag.ExitOnError)
+	electionID := fs.Int64("election", 0, "Election ID")
+	format := fs.String("format", "csv", "csv or json")
+	out := fs.String("out", "", "Output file (default: stdout)")
+	fs.Parse(args)
+	if *electionID <= 0 || (*format != "csv" && *format != "json") {
+		return fmt.Errorf("usage: %s", resultsUsage)
+	}
+
+	// Counts come from the ballots themselves, not vote_stats.
+	report, err := recount.NewService(recount.NewPgRepository(db)).Check(ctx, *electionID, false)
+	if err != nil {
+		return err
+	}
+	candidates, _, err := candidate.NewPgCandidateRepository(db).ListByElection(ctx, *electionID, candidate.Filter{Limit: 1000})
+	if err != nil {
+		return err
+	}
+
+	totals := map[int64]recount.CandidateTotal{}
+	for _, c := range report.Candidates {
+		totals[c.CandidateID] = c
+	}
+	export := resultsExport{
+		ElectionID: *electionID,
+		Totals:     report.Totals,
+		Consistent: report.Consistent,
+		Candidates: make([]resultRow, 0, len(candidates)),
+	}
+	for _, c := range candidates {
+		t := totals[c.ID]
+		export.Candidates = append(export.Candidates, resultRow{
+			CandidateID: c.ID,
+			Number:      c.Number,
+			Name:        c.Name,
+			Votes:       t.Votes,
+			Online:      t.Online,
+			TPS:         t.TPS,
+		})
+	}
+
+	w := io.Writer(os.Stdout)
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if *format == "json" {
+		err = writeJSON(w, export)
+	} else {
+		err = writeResultsCSV(w, export.Candidates)
+	}
+	if err != nil {
+		return err
+	}
+
+	if *out != "" {
+		fmt.Printf("Exported %d candidates to %s.\n", len(export.Candidates), *out)
+	}
+	if !report.Consistent {
+		fmt.Fprintln(os.Stderr, "warning: recount found mismatches; run pemiractl recount for details")
+	}
+	return nil
+}
+
+func writeResultsCSV(w io.Writer, rows []resultRow) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"candidate_id", "number", "name", "votes", "online", "tps"})
+	for _, r := range rows {
+		cw.Write([]string{
+			strconv.FormatInt(r.CandidateID, 10),
+			strconv.Itoa(r.Number),
+			r.Name,
+			strconv.FormatInt(r.Votes, 10),
+			strconv.FormatInt(r.Online, 10),
+			strconv.FormatInt(r.TPS, 10),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
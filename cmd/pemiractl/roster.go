@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"pemira-api/internal/master"
+)
+
+const rosterUsage = "pemiractl roster sync [-file FILE [-type STUDENT|LECTURER|STAFF] [-full]]"
+
+func runRoster(ctx context.Context, db *pgxpool.Pool, args []string) error {
+	sub, args, err := subcommand(args, rosterUsage)
+	if err != nil {
+		return err
+	}
+	if sub != "sync" {
+		return fmt.Errorf("usage: %s", rosterUsage)
+	}
+
+	fs := flag.NewFlagSet("roster sync", flag.ExitOnError)
+	file := fs.String("file", "", "Roster file (.csv or .json); default: ROSTER_SOURCE_URL")
+	voterType := fs.String("type", "", "Voter type for rows without one")
+	full := fs.Bool("full", false, "Mark entries of the file's voter types missing from it as removed")
+	fs.Parse(args)
+
+	svc := master.NewService(master.NewPgxRepository(db))
+
+	var run *master.RosterSyncRun
+	if *file == "" {
+		svc.SetRosterSource(master.RosterSourceConfig{
+			URL:   os.Getenv("ROSTER_SOURCE_URL"),
+			Token: os.Getenv("ROSTER_SOURCE_TOKEN"),
+		})
+		run, err = svc.SyncRosterFromSource(ctx, nil)
+	} else {
+		run, err = syncRosterFile(ctx, svc, *file, *voterType, *full)
+	}
+	if run != nil {
+		fmt.Printf("Roster sync (%s): %d inserted, %d updated, %d unchanged, %d removed, %d rejected.\n",
+			run.Source, run.Inserted, run.Updated, run.Unchanged, run.Removed, run.Rejected)
+		for _, e := range run.Errors {
+			fmt.Fprintf(os.Stderr, "  row %d %s: %s\n", e.Row, e.Identifier, e.Message)
+		}
+	}
+	if errors.Is(err, master.ErrRosterSourceDisabled) {
+		return fmt.Errorf("ROSTER_SOURCE_URL is not set; pass -file instead")
+	}
+	return err
+}
+
+func syncRosterFile(ctx context.Context, svc *master.Service, path, voterType string, full bool) (*master.RosterSyncRun, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	format := strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	records, rejected, err := master.ParseRoster(f, format, voterType)
+	if err != nil {
+		return nil, err
+	}
+	return svc.SyncRoster(ctx, master.RosterSyncRequest{
+		Source:   "file:" + filepath.Base(path),
+		Records:  records,
+		Rejected: rejected,
+		Full:     full,
+	})
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"pemira-api/internal/adminuser"
+	"pemira-api/internal/auth"
+	"pemira-api/internal/shared/constants"
+)
+
+const userUsage = "pemiractl user create-superadmin -username U -email E -name N [-password P] | reset-password -username U [-password P] | reset-2fa -username U | rekey-2fa -old-key K"
+
+func runUser(ctx context.Context, db *pgxpool.Pool, args []string) error {
+	sub, args, err := subcommand(args, userUsage)
+	if err != nil {
+		return err
+	}
+	svc := adminuser.NewService(adminuser.NewPgRepository(db))
+
+	switch sub {
+	case "create-superadmin":
+		fs := flag.NewFlagSet("user create-superadmin", flag.ExitOnError)
+		username := fs.String("username", "", "Username")
+		email := fs.String("email", "", "Email")
+		name := fs.String("name", "", "Full name")
+		password := fs.String("password", "", "Password (generated when empty)")
+		fs.Parse(args)
+		if *username == "" || *email == "" || *name == "" {
+			return fmt.Errorf("usage: %s", userUsage)
+		}
+
+		pw, generated, err := passwordOrGenerate(*password)
+		if err != nil {
+			return err
+		}
+		user, err := svc.Create(ctx, adminuser.CreateInput{
+			Username: *username,
+			Email:    *email,
+			FullName: *name,
+			Role:     constants.RoleSuperAdmin,
+			Password: pw,
+		})
+		if err != nil {
+			return fmt.Errorf("create user: %w", err)
+		}
+		fmt.Printf("Created SUPER_ADMIN %s (id %d).\n", user.Username, user.ID)
+		printPassword(pw, generated)
+		return nil
+
+	case "reset-password":
+		fs := flag.NewFlagSet("user reset-password", flag.ExitOnError)
+		username := fs.String("username", "", "Username")
+		password := fs.String("password", "", "New password (generated when empty)")
+		fs.Parse(args)
+		if *username == "" {
+			return fmt.Errorf("usage: %s", userUsage)
+		}
+
+		user, err := findUser(ctx, svc, *username)
+		if err != nil {
+			return err
+		}
+		pw, generated, err := passwordOrGenerate(*password)
+		if err != nil {
+			return err
+		}
+		if err := svc.ResetPassword(ctx, user.ID, pw); err != nil {
+			return fmt.Errorf("reset password: %w", err)
+		}
+		fmt.Printf("Password reset for %s (id %d).\n", user.Username, user.ID)
+		printPassword(pw, generated)
+		return nil
+
+	case "reset-2fa":
+		fs := flag.NewFlagSet("user reset-2fa", flag.ExitOnError)
+		username := fs.String("username", "", "Username")
+		fs.Parse(args)
+		if *username == "" {
+			return fmt.Errorf("usage: %s", userUsage)
+		}
+
+		user, err := findUser(ctx, svc, *username)
+		if err != nil {
+			return err
+		}
+		if err := auth.NewPgRepository(db).DeleteUserTOTP(ctx, user.ID); err != nil {
+			return fmt.Errorf("reset 2fa: %w", err)
+		}
+		fmt.Printf("2FA reset for %s (id %d). The user must enroll again if the role requires 2FA.\n", user.Username, user.ID)
+		return nil
+
+	case "rekey-2fa":
+		fs := flag.NewFlagSet("user rekey-2fa", flag.ExitOnError)
+		oldKey := fs.String("old-key", "", "Key the secrets are encrypted with now (JWT_SECRET for enrollments made before TOTP_ENCRYPTION_KEY was required)")
+		fs.Parse(args)
+		newKey := os.Getenv("TOTP_ENCRYPTION_KEY")
+		if *oldKey == "" || newKey == "" {
+			return fmt.Errorf("usage: TOTP_ENCRYPTION_KEY=... %s", userUsage)
+		}
+
+		n, err := auth.RekeyTOTPSecrets(ctx, auth.NewPgRepository(db), *oldKey, newKey)
+		if err != nil {
+			return fmt.Errorf("rekey 2fa: %w", err)
+		}
+		fmt.Printf("Re-encrypted %d TOTP secrets with TOTP_ENCRYPTION_KEY.\n", n)
+		return nil
+	}
+	return fmt.Errorf("usage: %s", userUsage)
+}
+
+// findUser looks a username up through the admin search, which matches
+// substrings, and keeps the exact match.
+func findUser(ctx context.Context, svc *adminuser.Service, username string) (*adminuser.User, error) {
+	users, _, err := svc.List(ctx, adminuser.ListFilter{Search: username}, 1, 100)
+	if err != nil {
+		return nil, err
+	}
+	for i := range users {
+		if strings.EqualFold(users[i].Username, username) {
+			return &users[i], nil
+		}
+	}
+	return nil, errors.New("user " + username + " not found")
+}
+
+func passwordOrGenerate(pw string) (string, bool, error) {
+	if pw != "" {
+		return pw, false, nil
+	}
+	buf := make([]byte, 12)
+	if _, err := rand.Read(buf); err != nil {
+		return "", false, err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), true, nil
+}
+
+func printPassword(pw string, generated bool) {
+	if generated {
+		fmt.Printf("Generated password: %s\n", pw)
+	}
+}
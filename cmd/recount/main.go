@@ -0,0 +1,93 @@
+// cmd/recount/main.go
+// Recount an election from its ballots and cross-check vote_stats,
+// vote_tokens and voter_status.has_voted. Exits with status 1 when any
+// mismatch remains.
+//
+// Usage:
+//   DATABASE_URL=postgres://... go run ./cmd/recount -election 3
+//   DATABASE_URL=postgres://... go run ./cmd/recount -election 3 -rebuild-stats
+//   DATABASE_URL=postgres://... go run ./cmd/recount -open -json
+//
+// Flags:
+//   -election ID     Election to check
+//   -open            Check every election with voting open
+//   -rebuild-stats   Rebuild vote_stats from the ballots when it drifted
+//   -json            Print the full reports as JSON
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"pemira-api/internal/recount"
+)
+
+func main() {
+	electionID := flag.Int64("election", 0, "Election ID to check")
+	open := flag.Bool("open", false, "Check every election with voting open")
+	rebuild := flag.Bool("rebuild-stats", false, "Rebuild vote_stats when it drifted")
+	asJSON := flag.Bool("json", false, "Print reports as JSON")
+	flag.Parse()
+
+	if (*electionID > 0) == *open {
+		log.Fatal("exactly one of -election or -open is required")
+	}
+
+	dbURL := os.Getenv("DATABASE_URL")
+	if dbURL == "" {
+		log.Fatal("DATABASE_URL is required")
+	}
+
+	ctx := context.Background()
+
+	db, err := pgxpool.New(ctx, dbURL)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	svc := recount.NewService(recount.NewPgRepository(db))
+
+	var reports []*recount.Report
+	if *open {
+		if *rebuild {
+			log.Fatal("-rebuild-stats needs -election")
+		}
+		if reports, err = svc.CheckOpenElections(ctx); err != nil {
+			log.Fatalf("Recount failed: %v", err)
+		}
+	} else {
+		report, err := svc.Check(ctx, *electionID, *rebuild)
+		if err != nil {
+			log.Fatalf("Recount failed: %v", err)
+		}
+		reports = append(reports, report)
+	}
+
+	consistent := true
+	for _, r := range reports {
+		consistent = consistent && r.Consistent
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(reports); err != nil {
+			log.Fatal(err)
+		}
+	} else {
+		for _, r := range reports {
+			r.WriteText(os.Stdout)
+		}
+	}
+
+	if !consistent {
+		os.Exit(1)
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/go-chi/chi/v5"
 
+	"pemira-api/internal/errcatalog"
 	"pemira-api/internal/http/response"
 	"pemira-api/internal/shared"
 	"pemira-api/internal/shared/constants"
@@ -38,7 +39,7 @@ func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
 
 	items, meta, err := h.svc.List(r.Context(), filter, page, limit)
 	if err != nil {
-		response.InternalServerError(w, "INTERNAL_ERROR", "Gagal mengambil daftar admin")
+		response.Catalog(w, adminListFailed)
 		return
 	}
 
@@ -55,7 +56,7 @@ func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
 	var req CreateInput
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.BadRequest(w, "VALIDATION_ERROR", "Body tidak valid")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
 		return
 	}
 
@@ -63,13 +64,13 @@ func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		switch err {
 		case shared.ErrBadRequest:
-			response.BadRequest(w, "VALIDATION_ERROR", "Data tidak valid atau role tidak diperbolehkan")
+			response.Catalog(w, adminInvalid)
 			return
 		case shared.ErrDuplicateEntry:
-			response.Conflict(w, "DUPLICATE", "Username atau email sudah digunakan")
+			response.Catalog(w, adminDuplicate)
 			return
 		default:
-			response.InternalServerError(w, "INTERNAL_ERROR", "Gagal membuat admin")
+			response.Catalog(w, adminCreateFailed)
 			return
 		}
 	}
@@ -87,10 +88,10 @@ func (h *Handler) Detail(w http.ResponseWriter, r *http.Request) {
 	user, err := h.svc.GetByID(r.Context(), id)
 	if err != nil {
 		if err == shared.ErrNotFound {
-			response.NotFound(w, "NOT_FOUND", "User tidak ditemukan")
+			response.NotFound(w, "NOT_FOUND", errcatalog.UserNotFound)
 			return
 		}
-		response.InternalServerError(w, "INTERNAL_ERROR", "Gagal mengambil user")
+		response.Catalog(w, adminFetchFailed)
 		return
 	}
 
@@ -106,7 +107,7 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 
 	var req UpdateInput
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.BadRequest(w, "VALIDATION_ERROR", "Body tidak valid")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
 		return
 	}
 
@@ -120,16 +121,16 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		switch err {
 		case shared.ErrBadRequest:
-			response.BadRequest(w, "VALIDATION_ERROR", "Role tidak valid")
+			response.Catalog(w, adminRoleInvalid)
 			return
 		case shared.ErrDuplicateEntry:
-			response.Conflict(w, "DUPLICATE", "Username atau email sudah digunakan")
+			response.Catalog(w, adminDuplicate)
 			return
 		case shared.ErrNotFound:
-			response.NotFound(w, "NOT_FOUND", "User tidak ditemukan")
+			response.NotFound(w, "NOT_FOUND", errcatalog.UserNotFound)
 			return
 		default:
-			response.InternalServerError(w, "INTERNAL_ERROR", "Gagal memperbarui user")
+			response.Catalog(w, adminUpdateFailed)
 			return
 		}
 	}
@@ -146,20 +147,20 @@ func (h *Handler) ResetPassword(w http.ResponseWriter, r *http.Request) {
 
 	var req ResetPasswordInput
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.BadRequest(w, "VALIDATION_ERROR", "Body tidak valid")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
 		return
 	}
 
 	if err := h.svc.ResetPassword(r.Context(), id, req.NewPassword); err != nil {
 		switch err {
 		case shared.ErrBadRequest:
-			response.BadRequest(w, "VALIDATION_ERROR", "Password baru tidak valid")
+			response.Catalog(w, adminPasswordInvalid)
 			return
 		case shared.ErrNotFound:
-			response.NotFound(w, "NOT_FOUND", "User tidak ditemukan")
+			response.NotFound(w, "NOT_FOUND", errcatalog.UserNotFound)
 			return
 		default:
-			response.InternalServerError(w, "INTERNAL_ERROR", "Gagal reset password")
+			response.Catalog(w, adminPasswordResetFailed)
 			return
 		}
 	}
@@ -184,10 +185,10 @@ func (h *Handler) setActive(w http.ResponseWriter, r *http.Request, active bool)
 	user, err := h.svc.SetActive(r.Context(), id, active)
 	if err != nil {
 		if err == shared.ErrNotFound {
-			response.NotFound(w, "NOT_FOUND", "User tidak ditemukan")
+			response.NotFound(w, "NOT_FOUND", errcatalog.UserNotFound)
 			return
 		}
-		response.InternalServerError(w, "INTERNAL_ERROR", "Gagal memperbarui status user")
+		response.Catalog(w, adminStatusUpdateFailed)
 		return
 	}
 	response.Success(w, http.StatusOK, user)
@@ -202,10 +203,10 @@ func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 	err := h.svc.Delete(r.Context(), id)
 	if err != nil {
 		if err == shared.ErrNotFound {
-			response.NotFound(w, "NOT_FOUND", "User tidak ditemukan")
+			response.NotFound(w, "NOT_FOUND", errcatalog.UserNotFound)
 			return
 		}
-		response.InternalServerError(w, "INTERNAL_ERROR", "Gagal menghapus user")
+		response.Catalog(w, adminDeleteFailed)
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
@@ -214,7 +215,7 @@ func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 func parseID(w http.ResponseWriter, raw string) (int64, bool) {
 	id, err := strconv.ParseInt(raw, 10, 64)
 	if err != nil || id <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "ID tidak valid")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidID)
 		return 0, false
 	}
 	return id, true
@@ -0,0 +1,23 @@
+package adminuser
+
+import (
+	"net/http"
+
+	"pemira-api/internal/errcatalog"
+)
+
+// Messages the admin user handlers answer with, each under its own code in the
+// error catalog
+var (
+	adminListFailed          = errcatalog.Define("ADMIN_LIST_FAILED", http.StatusInternalServerError, "Gagal mengambil daftar admin", "Failed to fetch admins")
+	adminInvalid             = errcatalog.Define("ADMIN_INVALID", http.StatusBadRequest, "Data tidak valid atau role tidak diperbolehkan", "Invalid data or role not allowed")
+	adminDuplicate           = errcatalog.Define("ADMIN_DUPLICATE", http.StatusConflict, "Username atau email sudah digunakan", "The username or email is already used")
+	adminCreateFailed        = errcatalog.Define("ADMIN_CREATE_FAILED", http.StatusInternalServerError, "Gagal membuat admin", "Failed to create the admin")
+	adminFetchFailed         = errcatalog.Define("ADMIN_FETCH_FAILED", http.StatusInternalServerError, "Gagal mengambil user", "Failed to fetch the user")
+	adminRoleInvalid         = errcatalog.Define("ADMIN_ROLE_INVALID", http.StatusBadRequest, "Role tidak valid", "Invalid role")
+	adminUpdateFailed        = errcatalog.Define("ADMIN_UPDATE_FAILED", http.StatusInternalServerError, "Gagal memperbarui user", "Failed to update the user")
+	adminPasswordInvalid     = errcatalog.Define("ADMIN_PASSWORD_INVALID", http.StatusBadRequest, "Password baru tidak valid", "Invalid new password")
+	adminPasswordResetFailed = errcatalog.Define("ADMIN_PASSWORD_RESET_FAILED", http.StatusInternalServerError, "Gagal reset password", "Failed to reset the password")
+	adminStatusUpdateFailed  = errcatalog.Define("ADMIN_STATUS_UPDATE_FAILED", http.StatusInternalServerError, "Gagal memperbarui status user", "Failed to update the user status")
+	adminDeleteFailed        = errcatalog.Define("ADMIN_DELETE_FAILED", http.StatusInternalServerError, "Gagal menghapus user", "Failed to delete the user")
+)
@@ -201,7 +201,11 @@ func (r *pgRepository) Update(ctx context.Context, id int64, in UpdateInput) (*U
 }
 
 func (r *pgRepository) ResetPassword(ctx context.Context, id int64, passwordHash string) error {
-	query := `UPDATE user_accounts SET password_hash = $2, updated_at = NOW() WHERE id = $1`
+	query := `
+		UPDATE user_accounts
+		SET password_hash = $2, failed_login_count = 0, last_failed_login_at = NULL, locked_until = NULL, updated_at = NOW()
+		WHERE id = $1
+	`
 	tag, err := r.db.Exec(ctx, query, id, passwordHash)
 	if err != nil {
 		return fmt.Errorf("reset password: %w", err)
@@ -215,7 +219,10 @@ func (r *pgRepository) ResetPassword(ctx context.Context, id int64, passwordHash
 func (r *pgRepository) SetActive(ctx context.Context, id int64, active bool) (*User, error) {
 	query := `
 		UPDATE user_accounts
-		SET is_active = $2, updated_at = NOW()
+		SET is_active = $2,
+		    failed_login_count = CASE WHEN $2 THEN 0 ELSE failed_login_count END,
+		    locked_until = CASE WHEN $2 THEN NULL ELSE locked_until END,
+		    updated_at = NOW()
 		WHERE id = $1
 		RETURNING id, username, email, full_name, role, voter_id, tps_id, lecturer_id, staff_id, is_active,
 		          last_login_at, login_count, created_at, updated_at
@@ -10,16 +10,16 @@ import (
 )
 
 var allowedRoles = map[constants.Role]struct{}{
-	constants.RoleAdmin:              {},
-	constants.RoleSuperAdmin:         {},
-	constants.RoleTPSOperator:        {},
-	constants.RoleStudent:            {},
-	constants.RoleLecturer:           {},
-	constants.RoleStaff:              {},
-	constants.Role("PANITIA"):        {},
-	constants.Role("KETUA_TPS"):      {},
-	constants.Role("OPERATOR_PANEL"): {},
-	constants.Role("VIEWER"):         {},
+	constants.RoleAdmin:         {},
+	constants.RoleSuperAdmin:    {},
+	constants.RoleTPSOperator:   {},
+	constants.RoleStudent:       {},
+	constants.RoleLecturer:      {},
+	constants.RoleStaff:         {},
+	constants.RolePanitia:       {},
+	constants.RoleKetuaTPS:      {},
+	constants.RoleOperatorPanel: {},
+	constants.RoleViewer:        {},
 }
 
 type Service struct {
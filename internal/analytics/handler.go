@@ -7,14 +7,17 @@ import (
 	"strconv"
 
 	"github.com/go-chi/chi/v5"
+
+	"pemira-api/internal/embargo"
+	"pemira-api/internal/errcatalog"
 )
 
 // Response helper interface (compatible with internal/http/response)
 type ResponseWriter interface {
 	Success(w http.ResponseWriter, statusCode int, data interface{})
-	BadRequest(w http.ResponseWriter, message string, details interface{})
-	InternalServerError(w http.ResponseWriter, message string)
-	NotFound(w http.ResponseWriter, message string)
+	BadRequest(w http.ResponseWriter, message errcatalog.Message, details interface{})
+	InternalServerError(w http.ResponseWriter, message errcatalog.Message)
+	NotFound(w http.ResponseWriter, message errcatalog.Message)
 }
 
 // AnalyticsService defines the interface for analytics operations
@@ -86,7 +89,7 @@ ctx := r.Context()
 
 electionID, err := parseElectionID(r)
 if err != nil || electionID <= 0 {
-h.res.BadRequest(w, "electionID tidak valid.", nil)
+h.res.BadRequest(w, errcatalog.Invalid("electionID"), nil)
 return
 }
 
@@ -105,7 +108,7 @@ ctx := r.Context()
 
 electionID, err := parseElectionID(r)
 if err != nil || electionID <= 0 {
-h.res.BadRequest(w, "electionID tidak valid.", nil)
+h.res.BadRequest(w, errcatalog.Invalid("electionID"), nil)
 return
 }
 
@@ -124,7 +127,7 @@ ctx := r.Context()
 
 electionID, err := parseElectionID(r)
 if err != nil || electionID <= 0 {
-h.res.BadRequest(w, "electionID tidak valid.", nil)
+h.res.BadRequest(w, errcatalog.Invalid("electionID"), nil)
 return
 }
 
@@ -143,7 +146,7 @@ ctx := r.Context()
 
 electionID, err := parseElectionID(r)
 if err != nil || electionID <= 0 {
-h.res.BadRequest(w, "electionID tidak valid.", nil)
+h.res.BadRequest(w, errcatalog.Invalid("electionID"), nil)
 return
 }
 
@@ -162,7 +165,7 @@ ctx := r.Context()
 
 electionID, err := parseElectionID(r)
 if err != nil || electionID <= 0 {
-h.res.BadRequest(w, "electionID tidak valid.", nil)
+h.res.BadRequest(w, errcatalog.Invalid("electionID"), nil)
 return
 }
 
@@ -181,7 +184,7 @@ ctx := r.Context()
 
 electionID, err := parseElectionID(r)
 if err != nil || electionID <= 0 {
-h.res.BadRequest(w, "electionID tidak valid.", nil)
+h.res.BadRequest(w, errcatalog.Invalid("electionID"), nil)
 return
 }
 
@@ -200,7 +203,7 @@ ctx := r.Context()
 
 electionID, err := parseElectionID(r)
 if err != nil || electionID <= 0 {
-h.res.BadRequest(w, "electionID tidak valid.", nil)
+h.res.BadRequest(w, errcatalog.Invalid("electionID"), nil)
 return
 }
 
@@ -219,7 +222,7 @@ ctx := r.Context()
 
 electionID, err := parseElectionID(r)
 if err != nil || electionID <= 0 {
-h.res.BadRequest(w, "electionID tidak valid.", nil)
+h.res.BadRequest(w, errcatalog.Invalid("electionID"), nil)
 return
 }
 
@@ -239,11 +242,14 @@ var notFoundErr interface{ NotFound() bool }
 
 switch {
 case errors.As(err, &notFoundErr):
-h.res.NotFound(w, "Pemilu tidak ditemukan.")
+h.res.NotFound(w, errcatalog.ElectionNotFound)
+
+case errors.Is(err, embargo.ErrResultsEmbargoed):
+embargo.RespondSealed(w)
 
 default:
 // Log internal error here if needed
-h.res.InternalServerError(w, "Terjadi kesalahan pada sistem.")
+h.res.InternalServerError(w, errcatalog.InternalError)
 }
 }
 
@@ -253,7 +259,7 @@ ctx := r.Context()
 
 electionID, err := parseElectionID(r)
 if err != nil || electionID <= 0 {
-h.res.BadRequest(w, "electionID tidak valid.", nil)
+h.res.BadRequest(w, errcatalog.Invalid("electionID"), nil)
 return
 }
 
@@ -5,11 +5,14 @@ import (
 "fmt"
 
 "golang.org/x/sync/errgroup"
+
+"pemira-api/internal/embargo"
 )
 
 // Service handles analytics business logic
 type Service struct {
-repo AnalyticsRepository
+repo    AnalyticsRepository
+embargo embargo.Policy
 }
 
 // NewService creates a new analytics service
@@ -17,6 +20,17 @@ func NewService(repo AnalyticsRepository) *Service {
 return &Service{repo: repo}
 }
 
+// SetEmbargo withholds the candidate breakdowns while an election's results
+// are under embargo. Turnout, timeline and participation charts stay.
+func (s *Service) SetEmbargo(p embargo.Policy) {
+s.embargo = p
+}
+
+// sealed reports whether the candidate breakdowns of the election are hidden
+func (s *Service) sealed(ctx context.Context, electionID int64) bool {
+return s.embargo != nil && s.embargo.Sealed(ctx, electionID)
+}
+
 // DashboardCharts contains all chart data for analytics dashboard
 type DashboardCharts struct {
 HourlyVotes           []HourlyVotes                 `json:"hourly_votes"`
@@ -26,11 +40,14 @@ TurnoutTimeline       []TurnoutPoint                `json:"turnout_timeline"`
 CohortBreakdown       []CohortCandidateVotes        `json:"cohort_breakdown"`
 PeakHours             []PeakHour                    `json:"peak_hours"`
 VotingVelocity        *VotingVelocity               `json:"voting_velocity"`
+// ResultsEmbargoed means the candidate breakdowns are withheld
+ResultsEmbargoed      bool                          `json:"results_embargoed"`
 }
 
 // GetDashboardCharts fetches all analytics data in parallel
 func (s *Service) GetDashboardCharts(ctx context.Context, electionID int64) (*DashboardCharts, error) {
 var result DashboardCharts
+result.ResultsEmbargoed = s.sealed(ctx, electionID)
 g, ctx := errgroup.WithContext(ctx)
 
 // Fetch hourly votes by channel
@@ -45,6 +62,9 @@ return nil
 
 // Fetch hourly votes by candidate
 g.Go(func() error {
+if result.ResultsEmbargoed {
+return nil
+}
 data, err := s.repo.GetHourlyVotesByCandidate(ctx, electionID)
 if err != nil {
 return fmt.Errorf("hourly by candidate: %w", err)
@@ -55,6 +75,9 @@ return nil
 
 // Fetch faculty heatmap
 g.Go(func() error {
+if result.ResultsEmbargoed {
+return nil
+}
 data, err := s.repo.GetFacultyCandidateHeatmap(ctx, electionID)
 if err != nil {
 return fmt.Errorf("faculty heatmap: %w", err)
@@ -75,6 +98,9 @@ return nil
 
 // Fetch cohort breakdown
 g.Go(func() error {
+if result.ResultsEmbargoed {
+return nil
+}
 data, err := s.repo.GetCohortCandidateVotes(ctx, electionID)
 if err != nil {
 return fmt.Errorf("cohort breakdown: %w", err)
@@ -117,11 +143,17 @@ return s.repo.GetHourlyVotesByChannel(ctx, electionID)
 
 // GetHourlyVotesByCandidate wraps repository method
 func (s *Service) GetHourlyVotesByCandidate(ctx context.Context, electionID int64) ([]HourlyCandidateVotes, error) {
+if s.sealed(ctx, electionID) {
+return nil, embargo.ErrResultsEmbargoed
+}
 return s.repo.GetHourlyVotesByCandidate(ctx, electionID)
 }
 
 // GetFacultyCandidateHeatmap wraps repository method
 func (s *Service) GetFacultyCandidateHeatmap(ctx context.Context, electionID int64) ([]FacultyCandidateHeatmapRow, error) {
+if s.sealed(ctx, electionID) {
+return nil, embargo.ErrResultsEmbargoed
+}
 return s.repo.GetFacultyCandidateHeatmap(ctx, electionID)
 }
 
@@ -132,6 +164,9 @@ return s.repo.GetTurnoutTimeline(ctx, electionID)
 
 // GetCohortCandidateVotes wraps repository method
 func (s *Service) GetCohortCandidateVotes(ctx context.Context, electionID int64) ([]CohortCandidateVotes, error) {
+if s.sealed(ctx, electionID) {
+return nil, embargo.ErrResultsEmbargoed
+}
 return s.repo.GetCohortCandidateVotes(ctx, electionID)
 }
 
@@ -8,6 +8,7 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-playground/validator/v10"
 	
+	"pemira-api/internal/errcatalog"
 	"pemira-api/internal/http/response"
 	"pemira-api/internal/shared"
 	"pemira-api/internal/shared/ctxkeys"
@@ -45,7 +46,7 @@ func (h *Handler) ListPublished(w http.ResponseWriter, r *http.Request) {
 	params := shared.NewPaginationParams(page, perPage)
 	announcements, total, err := h.service.ListPublished(r.Context(), electionID, params)
 	if err != nil {
-		response.InternalServerError(w, "INTERNAL_ERROR", "Failed to fetch announcements")
+		response.Catalog(w, announcementListFailed)
 		return
 	}
 
@@ -55,13 +56,13 @@ func (h *Handler) ListPublished(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
 	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
 	if err != nil {
-		response.BadRequest(w, "INVALID_REQUEST", "Invalid announcement ID")
+		response.Catalog(w, announcementIDInvalid)
 		return
 	}
 
 	announcement, err := h.service.GetByID(r.Context(), id)
 	if err != nil {
-		response.NotFound(w, "NOT_FOUND", "Announcement not found")
+		response.Catalog(w, announcementNotFound)
 		return
 	}
 
@@ -71,12 +72,12 @@ func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
 	var req CreateAnnouncementRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.BadRequest(w, "INVALID_REQUEST", "Invalid request body")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.InvalidBody)
 		return
 	}
 
 	if err := h.validate.Struct(req); err != nil {
-		response.BadRequest(w, "Validation failed", err.Error())
+		response.Error(w, http.StatusBadRequest, "VALIDATION_ERROR", errcatalog.ValidationFailed, err.Error())
 		return
 	}
 
@@ -93,7 +94,7 @@ func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.service.Create(r.Context(), announcement); err != nil {
-		response.InternalServerError(w, "INTERNAL_ERROR", "Failed to create announcement")
+		response.Catalog(w, announcementCreateFailed)
 		return
 	}
 
@@ -103,24 +104,24 @@ func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
 	if err != nil {
-		response.BadRequest(w, "INVALID_REQUEST", "Invalid announcement ID")
+		response.Catalog(w, announcementIDInvalid)
 		return
 	}
 
 	var req UpdateAnnouncementRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.BadRequest(w, "INVALID_REQUEST", "Invalid request body")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.InvalidBody)
 		return
 	}
 
 	if err := h.validate.Struct(req); err != nil {
-		response.BadRequest(w, "Validation failed", err.Error())
+		response.Error(w, http.StatusBadRequest, "VALIDATION_ERROR", errcatalog.ValidationFailed, err.Error())
 		return
 	}
 
 	announcement, err := h.service.GetByID(r.Context(), id)
 	if err != nil {
-		response.NotFound(w, "NOT_FOUND", "Announcement not found")
+		response.Catalog(w, announcementNotFound)
 		return
 	}
 
@@ -131,7 +132,7 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 	announcement.IsPublished = req.IsPublished
 
 	if err := h.service.Update(r.Context(), announcement); err != nil {
-		response.InternalServerError(w, "INTERNAL_ERROR", "Failed to update announcement")
+		response.Catalog(w, announcementUpdateFailed)
 		return
 	}
 
@@ -141,12 +142,12 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
 	if err != nil {
-		response.BadRequest(w, "INVALID_REQUEST", "Invalid announcement ID")
+		response.Catalog(w, announcementIDInvalid)
 		return
 	}
 
 	if err := h.service.repo.Delete(r.Context(), id); err != nil {
-		response.InternalServerError(w, "INTERNAL_ERROR", "Failed to delete announcement")
+		response.Catalog(w, announcementDeleteFailed)
 		return
 	}
 
@@ -156,12 +157,12 @@ func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) Publish(w http.ResponseWriter, r *http.Request) {
 	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
 	if err != nil {
-		response.BadRequest(w, "INVALID_REQUEST", "Invalid announcement ID")
+		response.Catalog(w, announcementIDInvalid)
 		return
 	}
 
 	if err := h.service.Publish(r.Context(), id); err != nil {
-		response.InternalServerError(w, "INTERNAL_ERROR", "Failed to publish announcement")
+		response.Catalog(w, announcementPublishFailed)
 		return
 	}
 
@@ -0,0 +1,19 @@
+package announcement
+
+import (
+	"net/http"
+
+	"pemira-api/internal/errcatalog"
+)
+
+// Messages the announcement handlers answer with, each under its own code in the
+// error catalog
+var (
+	announcementListFailed    = errcatalog.Define("ANNOUNCEMENT_LIST_FAILED", http.StatusInternalServerError, "Gagal mengambil daftar pengumuman.", "Failed to fetch announcements.")
+	announcementIDInvalid     = errcatalog.Define("ANNOUNCEMENT_ID_INVALID", http.StatusBadRequest, "ID pengumuman tidak valid.", "Invalid announcement ID.")
+	announcementNotFound      = errcatalog.Define("ANNOUNCEMENT_NOT_FOUND", http.StatusNotFound, "Pengumuman tidak ditemukan.", "Announcement not found.")
+	announcementCreateFailed  = errcatalog.Define("ANNOUNCEMENT_CREATE_FAILED", http.StatusInternalServerError, "Gagal membuat pengumuman.", "Failed to create the announcement.")
+	announcementUpdateFailed  = errcatalog.Define("ANNOUNCEMENT_UPDATE_FAILED", http.StatusInternalServerError, "Gagal mengubah pengumuman.", "Failed to update the announcement.")
+	announcementDeleteFailed  = errcatalog.Define("ANNOUNCEMENT_DELETE_FAILED", http.StatusInternalServerError, "Gagal menghapus pengumuman.", "Failed to delete the announcement.")
+	announcementPublishFailed = errcatalog.Define("ANNOUNCEMENT_PUBLISH_FAILED", http.StatusInternalServerError, "Gagal mempublikasikan pengumuman.", "Failed to publish the announcement.")
+)
@@ -0,0 +1,393 @@
+package archive
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxBundleFileSize guards against oversized or compressed-bomb entries.
+const maxBundleFileSize = 256 << 20
+
+// WriteBundle encodes a snapshot and its media files as a zip bundle. The
+// manifest is written last, after every checksum is known.
+func WriteBundle(w io.Writer, manifest *Manifest, snap *Snapshot, media map[string][]byte) error {
+	bw := &bundleWriter{zw: zip.NewWriter(w)}
+
+	if err := bw.writeJSON(FileElection, snap.Election, nil); err != nil {
+		return err
+	}
+	if err := bw.writeJSON(FileCandidates, nonNil(snap.Candidates), rowCount(len(snap.Candidates))); err != nil {
+		return err
+	}
+	if err := bw.writeJSON(FileCandidateMedia, nonNil(snap.CandidateMedia), rowCount(len(snap.CandidateMedia))); err != nil {
+		return err
+	}
+	if err := bw.writeJSON(FileTPS, nonNil(snap.TPS), rowCount(len(snap.TPS))); err != nil {
+		return err
+	}
+	if err := bw.writeCSV(FileVotes, voteHeader, encodeVotes(snap.Votes)); err != nil {
+		return err
+	}
+	if err := bw.writeCSV(FileVoteTokens, voteTokenHeader, encodeVoteTokens(snap.VoteTokens)); err != nil {
+		return err
+	}
+	if err := bw.writeJSON(FileTallies, nonNil(snap.Tallies), rowCount(len(snap.Tallies))); err != nil {
+		return err
+	}
+	if err := bw.writeJSON(FileAuditLog, nonNil(snap.AuditLog), rowCount(len(snap.AuditLog))); err != nil {
+		return err
+	}
+	if err := bw.writeJSON(FileTPSIncidents, nonNil(snap.TPSIncidents), rowCount(len(snap.TPSIncidents))); err != nil {
+		return err
+	}
+	if len(snap.BallotDraw) > 0 {
+		if err := bw.writeJSON(FileBallotDraw, snap.BallotDraw, nil); err != nil {
+			return err
+		}
+	}
+
+	for _, m := range snap.CandidateMedia {
+		data, ok := media[m.ID]
+		if !ok {
+			manifest.MissingMedia = append(manifest.MissingMedia, m.ID)
+			continue
+		}
+		if err := bw.writeFile(MediaDir+m.ID, data, nil); err != nil {
+			return err
+		}
+	}
+
+	manifest.Format = BundleFormat
+	manifest.Version = BundleVersion
+	manifest.Files = bw.files
+
+	raw, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	f, err := bw.zw.Create(FileManifest)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(raw); err != nil {
+		return err
+	}
+	return bw.zw.Close()
+}
+
+// ReadBundle opens a bundle, verifies the manifest and every checksum, and
+// decodes the snapshot. The media files it carries are returned by media ID.
+func ReadBundle(r io.ReaderAt, size int64) (*Manifest, *Snapshot, map[string][]byte, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("%w: %v", ErrInvalidBundle, err)
+	}
+
+	entries := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		entries[f.Name] = f
+	}
+
+	mf, ok := entries[FileManifest]
+	if !ok {
+		return nil, nil, nil, fmt.Errorf("%w: missing %s", ErrInvalidBundle, FileManifest)
+	}
+	raw, err := readEntry(mf, maxBundleFileSize)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	var manifest Manifest
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		return nil, nil, nil, fmt.Errorf("%w: manifest: %v", ErrInvalidBundle, err)
+	}
+	if manifest.Format != BundleFormat {
+		return nil, nil, nil, fmt.Errorf("%w: unknown format %q", ErrInvalidBundle, manifest.Format)
+	}
+	if manifest.Version < 1 || manifest.Version > BundleVersion {
+		return nil, nil, nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, manifest.Version)
+	}
+
+	files := make(map[string][]byte, len(manifest.Files))
+	for _, mfile := range manifest.Files {
+		entry, ok := entries[mfile.Name]
+		if !ok {
+			return nil, nil, nil, fmt.Errorf("%w: missing %s", ErrInvalidBundle, mfile.Name)
+		}
+		if mfile.Size > maxBundleFileSize {
+			return nil, nil, nil, fmt.Errorf("%w: %s is too large", ErrInvalidBundle, mfile.Name)
+		}
+		data, err := readEntry(entry, mfile.Size)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if int64(len(data)) != mfile.Size || checksum(data) != mfile.SHA256 {
+			return nil, nil, nil, fmt.Errorf("%w: %s", ErrChecksumMismatch, mfile.Name)
+		}
+		files[mfile.Name] = data
+	}
+
+	snap, err := decodeSnapshot(files)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	media := make(map[string][]byte)
+	for name, data := range files {
+		if id, ok := strings.CutPrefix(name, MediaDir); ok {
+			media[id] = data
+		}
+	}
+	return &manifest, snap, media, nil
+}
+
+func decodeSnapshot(files map[string][]byte) (*Snapshot, error) {
+	snap := &Snapshot{}
+
+	jsonFiles := []struct {
+		name     string
+		dst      any
+		required bool
+	}{
+		{FileElection, &snap.Election, true},
+		{FileCandidates, &snap.Candidates, true},
+		{FileCandidateMedia, &snap.CandidateMedia, true},
+		{FileTPS, &snap.TPS, true},
+		{FileTallies, &snap.Tallies, true},
+		{FileAuditLog, &snap.AuditLog, false},
+		{FileBallotDraw, &snap.BallotDraw, false},
+		{FileTPSIncidents, &snap.TPSIncidents, false},
+	}
+	for _, jf := range jsonFiles {
+		data, ok := files[jf.name]
+		if !ok {
+			if jf.required {
+				return nil, fmt.Errorf("%w: missing %s", ErrInvalidBundle, jf.name)
+			}
+			continue
+		}
+		if err := json.Unmarshal(data, jf.dst); err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidBundle, jf.name, err)
+		}
+	}
+
+	var err error
+	if snap.Votes, err = decodeVotes(files[FileVotes]); err != nil {
+		return nil, err
+	}
+	if snap.VoteTokens, err = decodeVoteTokens(files[FileVoteTokens]); err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+type bundleWriter struct {
+	zw    *zip.Writer
+	files []ManifestFile
+}
+
+func (b *bundleWriter) writeJSON(name string, v any, rows *int) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return b.writeFile(name, data, rows)
+}
+
+func (b *bundleWriter) writeCSV(name string, header []string, records [][]string) error {
+	var buf bytes.Buffer
+	cw := csv.NewWriter(&buf)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(records); err != nil {
+		return err
+	}
+	return b.writeFile(name, buf.Bytes(), rowCount(len(records)))
+}
+
+func (b *bundleWriter) writeFile(name string, data []byte, rows *int) error {
+	f, err := b.zw.Create(name)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		return err
+	}
+	b.files = append(b.files, ManifestFile{
+		Name:   name,
+		SHA256: checksum(data),
+		Size:   int64(len(data)),
+		Rows:   rows,
+	})
+	return nil
+}
+
+func readEntry(f *zip.File, limit int64) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidBundle, f.Name, err)
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(io.LimitReader(rc, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidBundle, f.Name, err)
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("%w: %s", ErrChecksumMismatch, f.Name)
+	}
+	return data, nil
+}
+
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func rowCount(n int) *int {
+	return &n
+}
+
+func nonNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
+
+var (
+	voteHeader      = []string{"candidate_id", "channel", "tps_id", "cast_hour"}
+	voteTokenHeader = []string{"token_hash", "method", "tps_id", "issued_at", "used_at"}
+)
+
+func encodeVotes(votes []VoteRecord) [][]string {
+	out := make([][]string, 0, len(votes))
+	for _, v := range votes {
+		out = append(out, []string{
+			strconv.FormatInt(v.CandidateID, 10),
+			v.Channel,
+			formatOptionalID(v.TPSID),
+			v.CastHour.UTC().Format(time.RFC3339),
+		})
+	}
+	return out
+}
+
+func encodeVoteTokens(tokens []VoteTokenRecord) [][]string {
+	out := make([][]string, 0, len(tokens))
+	for _, t := range tokens {
+		usedAt := ""
+		if t.UsedAt != nil {
+			usedAt = t.UsedAt.UTC().Format(time.RFC3339Nano)
+		}
+		out = append(out, []string{
+			t.TokenHash,
+			t.Method,
+			formatOptionalID(t.TPSID),
+			t.IssuedAt.UTC().Format(time.RFC3339Nano),
+			usedAt,
+		})
+	}
+	return out
+}
+
+func decodeVotes(data []byte) ([]VoteRecord, error) {
+	records, err := readCSV(FileVotes, data, voteHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]VoteRecord, 0, len(records))
+	for i, rec := range records {
+		candidateID, err := strconv.ParseInt(rec[0], 10, 64)
+		if err != nil {
+			return nil, csvRowError(FileVotes, i, err)
+		}
+		tpsID, err := parseOptionalID(rec[2])
+		if err != nil {
+			return nil, csvRowError(FileVotes, i, err)
+		}
+		castHour, err := time.Parse(time.RFC3339, rec[3])
+		if err != nil {
+			return nil, csvRowError(FileVotes, i, err)
+		}
+		out = append(out, VoteRecord{CandidateID: candidateID, Channel: rec[1], TPSID: tpsID, CastHour: castHour})
+	}
+	return out, nil
+}
+
+func decodeVoteTokens(data []byte) ([]VoteTokenRecord, error) {
+	records, err := readCSV(FileVoteTokens, data, voteTokenHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]VoteTokenRecord, 0, len(records))
+	for i, rec := range records {
+		tpsID, err := parseOptionalID(rec[2])
+		if err != nil {
+			return nil, csvRowError(FileVoteTokens, i, err)
+		}
+		issuedAt, err := time.Parse(time.RFC3339Nano, rec[3])
+		if err != nil {
+			return nil, csvRowError(FileVoteTokens, i, err)
+		}
+		t := VoteTokenRecord{TokenHash: rec[0], Method: rec[1], TPSID: tpsID, IssuedAt: issuedAt}
+		if rec[4] != "" {
+			usedAt, err := time.Parse(time.RFC3339Nano, rec[4])
+			if err != nil {
+				return nil, csvRowError(FileVoteTokens, i, err)
+			}
+			t.UsedAt = &usedAt
+		}
+		out = append(out, t)
+	}
+	return out, nil
+}
+
+func readCSV(name string, data []byte, header []string) ([][]string, error) {
+	if data == nil {
+		return nil, fmt.Errorf("%w: missing %s", ErrInvalidBundle, name)
+	}
+	cr := csv.NewReader(bytes.NewReader(data))
+	cr.FieldsPerRecord = len(header)
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidBundle, name, err)
+	}
+	if len(records) == 0 || strings.Join(records[0], ",") != strings.Join(header, ",") {
+		return nil, fmt.Errorf("%w: %s: unexpected header", ErrInvalidBundle, name)
+	}
+	return records[1:], nil
+}
+
+func csvRowError(name string, row int, err error) error {
+	return fmt.Errorf("%w: %s row %d: %v", ErrInvalidBundle, name, row+1, err)
+}
+
+func formatOptionalID(id *int64) string {
+	if id == nil {
+		return ""
+	}
+	return strconv.FormatInt(*id, 10)
+}
+
+func parseOptionalID(s string) (*int64, error) {
+	if s == "" {
+		return nil, nil
+	}
+	id, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
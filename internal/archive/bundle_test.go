@@ -0,0 +1,131 @@
+package archive
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+func bundleFixture() (*Snapshot, map[string][]byte) {
+	tpsID := int64(4)
+	usedAt := time.Date(2025, 2, 15, 9, 12, 0, 0, time.UTC)
+	snap := &Snapshot{
+		Election:   json.RawMessage(`{"id":3,"code":"pemira-2025","name":"Pemira 2025","status":"ARCHIVED"}`),
+		Candidates: []json.RawMessage{json.RawMessage(`{"id":10,"election_id":3,"number":1,"name":"A"}`)},
+		CandidateMedia: []MediaRecord{
+			{ID: "m-1", CandidateID: 10, Slot: "profile", FileName: "a.png", ContentType: "image/png", SizeBytes: 3, StoragePath: "https://cdn/a.png"},
+			{ID: "m-2", CandidateID: 10, Slot: "poster", FileName: "b.png", ContentType: "image/png", SizeBytes: 3, StoragePath: "https://cdn/b.png"},
+		},
+		TPS: []json.RawMessage{json.RawMessage(`{"id":4,"election_id":3,"code":"TPS-01"}`)},
+		Votes: []VoteRecord{
+			{CandidateID: 10, Channel: "ONLINE", CastHour: time.Date(2025, 2, 15, 8, 0, 0, 0, time.UTC)},
+			{CandidateID: 10, Channel: "TPS", TPSID: &tpsID, CastHour: time.Date(2025, 2, 15, 9, 0, 0, 0, time.UTC)},
+		},
+		VoteTokens: []VoteTokenRecord{
+			{TokenHash: "abc", Method: "TPS", TPSID: &tpsID, IssuedAt: time.Date(2025, 2, 15, 9, 0, 0, 0, time.UTC), UsedAt: &usedAt},
+		},
+		Tallies:  []TallyRecord{{CandidateID: 10, Channel: "ONLINE", Votes: 1}, {CandidateID: 10, Channel: "TPS", Votes: 1}},
+		AuditLog: []json.RawMessage{json.RawMessage(`{"id":1,"action":"VOTE_CAST"}`)},
+		TPSIncidents: []json.RawMessage{
+			json.RawMessage(`{"id":7,"tps_id":4,"category":"POWER_OUTAGE","status":"OPEN"}`),
+			json.RawMessage(`{"id":8,"tps_id":4,"category":"EQUIPMENT","status":"RESOLVED"}`),
+		},
+	}
+	return snap, map[string][]byte{"m-1": []byte("png")}
+}
+
+func TestBundle_RoundTrip(t *testing.T) {
+	snap, media := bundleFixture()
+
+	var buf bytes.Buffer
+	manifest := &Manifest{SourceElectionID: 3, ElectionCode: "pemira-2025"}
+	if err := WriteBundle(&buf, manifest, snap, media); err != nil {
+		t.Fatalf("write bundle: %v", err)
+	}
+	if len(manifest.MissingMedia) != 1 || manifest.MissingMedia[0] != "m-2" {
+		t.Fatalf("expected m-2 to be reported missing, got %v", manifest.MissingMedia)
+	}
+
+	got, decoded, gotMedia, err := ReadBundle(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("read bundle: %v", err)
+	}
+	if got.Format != BundleFormat || got.Version != BundleVersion || got.SourceElectionID != 3 {
+		t.Fatalf("unexpected manifest %+v", got)
+	}
+	if len(decoded.Candidates) != 1 || len(decoded.TPS) != 1 || len(decoded.CandidateMedia) != 2 {
+		t.Fatalf("unexpected snapshot %+v", decoded)
+	}
+	if len(gotMedia) != 1 || string(gotMedia["m-1"]) != "png" {
+		t.Fatalf("unexpected media %v", gotMedia)
+	}
+	if len(decoded.Votes) != 2 || decoded.Votes[0].TPSID != nil || *decoded.Votes[1].TPSID != 4 {
+		t.Fatalf("unexpected votes %+v", decoded.Votes)
+	}
+	if !decoded.Votes[1].CastHour.Equal(snap.Votes[1].CastHour) {
+		t.Fatalf("cast hour not preserved: %v", decoded.Votes[1].CastHour)
+	}
+	if len(decoded.VoteTokens) != 1 || decoded.VoteTokens[0].UsedAt == nil || !decoded.VoteTokens[0].UsedAt.Equal(*snap.VoteTokens[0].UsedAt) {
+		t.Fatalf("unexpected vote tokens %+v", decoded.VoteTokens)
+	}
+	if len(decoded.BallotDraw) != 0 {
+		t.Fatal("ballot draw should be absent")
+	}
+	if len(decoded.TPSIncidents) != 2 || countOpenIncidents(decoded.TPSIncidents) != 1 {
+		t.Fatalf("unexpected incidents %s", decoded.TPSIncidents)
+	}
+}
+
+func TestReadBundle_DetectsTampering(t *testing.T) {
+	snap, media := bundleFixture()
+
+	var buf bytes.Buffer
+	if err := WriteBundle(&buf, &Manifest{SourceElectionID: 3}, snap, media); err != nil {
+		t.Fatalf("write bundle: %v", err)
+	}
+
+	tampered := rewriteEntry(t, buf.Bytes(), FileVotes, func(data []byte) []byte {
+		return bytes.Replace(data, []byte("ONLINE"), []byte("TPS"), 1)
+	})
+	if _, _, _, err := ReadBundle(bytes.NewReader(tampered), int64(len(tampered))); !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("expected ErrChecksumMismatch, got %v", err)
+	}
+
+	future := rewriteEntry(t, buf.Bytes(), FileManifest, func(data []byte) []byte {
+		return bytes.Replace(data, []byte(`"version": 1`), []byte(`"version": 99`), 1)
+	})
+	if _, _, _, err := ReadBundle(bytes.NewReader(future), int64(len(future))); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Fatalf("expected ErrUnsupportedVersion, got %v", err)
+	}
+}
+
+func rewriteEntry(t *testing.T, bundle []byte, name string, edit func([]byte) []byte) []byte {
+	t.Helper()
+
+	zr, err := zip.NewReader(bytes.NewReader(bundle), int64(len(bundle)))
+	if err != nil {
+		t.Fatalf("open bundle: %v", err)
+	}
+
+	var out bytes.Buffer
+	zw := zip.NewWriter(&out)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", f.Name, err)
+		}
+		data, _ := io.ReadAll(rc)
+		rc.Close()
+		if f.Name == name {
+			data = edit(data)
+		}
+		w, _ := zw.Create(f.Name)
+		w.Write(data)
+	}
+	zw.Close()
+	return out.Bytes()
+}
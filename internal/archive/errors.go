@@ -0,0 +1,20 @@
+package archive
+
+import (
+	"net/http"
+
+	"pemira-api/internal/errcatalog"
+)
+
+// The API codes and messages of the export and import errors; handleError
+// writes them
+func init() {
+	errcatalog.Register(
+		errcatalog.Entry{Err: ErrElectionNotFound, Code: "ELECTION_NOT_FOUND", Status: http.StatusNotFound, ID: "Pemilu tidak ditemukan.", EN: "Election not found."},
+		errcatalog.Entry{Err: ErrElectionVotingOpen, Code: "VOTING_OPEN", Status: http.StatusConflict, ID: "Pemilu tidak dapat diekspor selama voting berlangsung.", EN: "The election cannot be exported while voting is open."},
+		errcatalog.Entry{Err: ErrElectionExists, Code: "ELECTION_EXISTS", Status: http.StatusConflict, ID: "Pemilu dengan kode atau slug yang sama sudah ada.", EN: "An election with the same code or slug already exists."},
+		errcatalog.Entry{Err: ErrUnsupportedVersion, Code: "UNSUPPORTED_BUNDLE_VERSION", Status: http.StatusUnprocessableEntity, ID: "Versi bundle tidak didukung.", EN: "Unsupported bundle version."},
+		errcatalog.Entry{Err: ErrChecksumMismatch, Code: "CHECKSUM_MISMATCH", Status: http.StatusUnprocessableEntity, ID: "Checksum bundle tidak cocok. File mungkin rusak atau telah diubah.", EN: "The bundle checksum does not match. The file may be corrupted or modified."},
+		errcatalog.Entry{Err: ErrInvalidBundle, Code: "INVALID_BUNDLE", Status: http.StatusUnprocessableEntity, ID: "File bundle pemilu tidak valid.", EN: "Invalid election bundle file."},
+	)
+}
@@ -0,0 +1,86 @@
+package archive
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"pemira-api/internal/embargo"
+	"pemira-api/internal/errcatalog"
+	"pemira-api/internal/http/response"
+	"pemira-api/internal/shared/ctxkeys"
+)
+
+// maxImportSize caps an uploaded bundle.
+const maxImportSize = 512 << 20
+
+type Handler struct {
+	svc *Service
+}
+
+func NewHandler(svc *Service) *Handler {
+	return &Handler{svc: svc}
+}
+
+// Export menangani GET /admin/elections/{electionID}/export
+func (h *Handler) Export(w http.ResponseWriter, r *http.Request) {
+	electionID, err := strconv.ParseInt(chi.URLParam(r, "electionID"), 10, 64)
+	if err != nil || electionID <= 0 {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("electionID"))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+BundleFileName(electionID, h.svc.now())+`"`)
+
+	if _, err := h.svc.Export(r.Context(), electionID, w); err != nil {
+		w.Header().Del("Content-Disposition")
+		h.handleError(w, err)
+	}
+}
+
+// Import menangani POST /admin/elections/import (multipart, field "file")
+func (h *Handler) Import(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.UploadFormError)
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.FileRequired)
+		return
+	}
+	defer file.Close()
+
+	var adminID *int64
+	if id, ok := ctxkeys.GetUserID(r.Context()); ok {
+		adminID = &id
+	}
+
+	result, err := h.svc.Import(r.Context(), file, header.Size, adminID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, result)
+}
+
+// handleError maps service errors to HTTP responses through the error
+// catalog (see errors.go)
+func (h *Handler) handleError(w http.ResponseWriter, err error) {
+	if errors.Is(err, embargo.ErrResultsEmbargoed) {
+		embargo.RespondSealed(w)
+		return
+	}
+	if response.DomainError(w, err) {
+		return
+	}
+	slog.Error("election archive handler error", "err", err)
+	response.InternalServerError(w, "INTERNAL_ERROR", errcatalog.InternalError)
+}
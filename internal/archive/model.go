@@ -0,0 +1,122 @@
+package archive
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+const (
+	BundleFormat  = "pemira-election-bundle"
+	BundleVersion = 1
+
+	FileManifest       = "manifest.json"
+	FileElection       = "election.json"
+	FileCandidates     = "candidates.json"
+	FileCandidateMedia = "candidate_media.json"
+	FileTPS            = "tps.json"
+	FileVotes          = "votes.csv"
+	FileVoteTokens     = "vote_tokens.csv"
+	FileTallies        = "tallies.json"
+	FileAuditLog       = "audit_log.json"
+	FileBallotDraw     = "ballot_draw.json"
+	FileTPSIncidents   = "tps_incidents.json"
+	MediaDir           = "media/"
+)
+
+var (
+	ErrElectionNotFound   = errors.New("election not found")
+	ErrElectionVotingOpen = errors.New("election voting is still open")
+	ErrElectionExists     = errors.New("election code or slug already exists")
+	ErrInvalidBundle      = errors.New("invalid election bundle")
+	ErrUnsupportedVersion = errors.New("unsupported bundle version")
+	ErrChecksumMismatch   = errors.New("bundle checksum mismatch")
+)
+
+// Manifest describes a bundle and carries a SHA-256 checksum for every file in it.
+type Manifest struct {
+	Format           string         `json:"format"`
+	Version          int            `json:"version"`
+	ExportedAt       time.Time      `json:"exported_at"`
+	SourceElectionID int64          `json:"source_election_id"`
+	ElectionCode     string         `json:"election_code"`
+	ElectionName     string         `json:"election_name"`
+	Files            []ManifestFile `json:"files"`
+	MissingMedia     []string       `json:"missing_media,omitempty"`
+	// OpenIncidents counts the TPS incidents still unresolved at export
+	OpenIncidents int `json:"open_incidents"`
+}
+
+type ManifestFile struct {
+	Name   string `json:"name"`
+	SHA256 string `json:"sha256"`
+	Size   int64  `json:"size"`
+	Rows   *int   `json:"rows,omitempty"`
+}
+
+// Snapshot is the full content of an election as stored in a bundle. Tables
+// that are restored generically are kept as raw row JSON so the bundle keeps
+// every column of the source instance.
+type Snapshot struct {
+	Election       json.RawMessage   `json:"election"`
+	Candidates     []json.RawMessage `json:"candidates"`
+	CandidateMedia []MediaRecord     `json:"candidate_media"`
+	TPS            []json.RawMessage `json:"tps"`
+	Votes          []VoteRecord      `json:"-"`
+	VoteTokens     []VoteTokenRecord `json:"-"`
+	Tallies        []TallyRecord     `json:"tallies"`
+	AuditLog       []json.RawMessage `json:"audit_log"`
+	BallotDraw     json.RawMessage   `json:"ballot_draw,omitempty"`
+	// TPSIncidents are the incidents reported at the TPS of the election,
+	// unresolved ones first, without their voter or check-in
+	TPSIncidents []json.RawMessage `json:"tps_incidents"`
+}
+
+type MediaRecord struct {
+	ID          string    `json:"id"`
+	CandidateID int64     `json:"candidate_id"`
+	Slot        string    `json:"slot"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	SizeBytes   int64     `json:"size_bytes"`
+	StoragePath string    `json:"storage_path"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// VoteRecord is an anonymised vote: no token hash, no row id and the cast
+// time truncated to the hour, so it cannot be joined back to a voter.
+type VoteRecord struct {
+	CandidateID int64
+	Channel     string
+	TPSID       *int64
+	CastHour    time.Time
+}
+
+// VoteTokenRecord is a vote token without its voter.
+type VoteTokenRecord struct {
+	TokenHash string     `json:"token_hash"`
+	Method    string     `json:"method"`
+	TPSID     *int64     `json:"tps_id,omitempty"`
+	IssuedAt  time.Time  `json:"issued_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+}
+
+type TallyRecord struct {
+	CandidateID int64  `json:"candidate_id"`
+	Channel     string `json:"channel"`
+	Votes       int64  `json:"votes"`
+}
+
+type ImportResult struct {
+	ArchiveID        int64  `json:"archive_id"`
+	ElectionID       int64  `json:"election_id"`
+	SourceElectionID int64  `json:"source_election_id"`
+	BundleSHA256     string `json:"bundle_sha256"`
+	Candidates       int    `json:"candidates"`
+	CandidateMedia   int    `json:"candidate_media"`
+	TPS              int    `json:"tps"`
+	Votes            int    `json:"votes"`
+	VoteTokens       int    `json:"vote_tokens"`
+	AuditEntries     int    `json:"audit_entries"`
+	TPSIncidents     int    `json:"tps_incidents"`
+}
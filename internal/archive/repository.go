@@ -0,0 +1,10 @@
+package archive
+
+import "context"
+
+type Repository interface {
+	// LoadSnapshot reads every exported table of an election.
+	LoadSnapshot(ctx context.Context, electionID int64) (*Snapshot, error)
+	// Restore inserts a snapshot as a new election and records the archive.
+	Restore(ctx context.Context, snap *Snapshot, manifest Manifest, bundleSHA256 string, adminID *int64) (*ImportResult, error)
+}
@@ -0,0 +1,524 @@
+package archive
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PgRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPgRepository(db *pgxpool.Pool) *PgRepository {
+	return &PgRepository{db: db}
+}
+
+func (r *PgRepository) LoadSnapshot(ctx context.Context, electionID int64) (*Snapshot, error) {
+	// Ballots still waiting for their shuffled batch belong in the export once
+	// voting is over.
+	if _, err := r.db.Exec(ctx, `
+SELECT flush_ballot_queue(id, 0) FROM elections WHERE id = $1 AND status <> 'VOTING_OPEN'`, electionID); err != nil {
+		return nil, err
+	}
+
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	snap := &Snapshot{}
+
+	err = tx.QueryRow(ctx, `SELECT row_to_json(e) FROM elections e WHERE e.id = $1`, electionID).Scan(&snap.Election)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrElectionNotFound
+		}
+		return nil, err
+	}
+
+	if snap.Candidates, err = queryJSONRows(ctx, tx, `
+SELECT row_to_json(c) FROM candidates c WHERE c.election_id = $1 ORDER BY c.id
+`, electionID); err != nil {
+		return nil, err
+	}
+
+	if snap.TPS, err = queryJSONRows(ctx, tx, `
+SELECT row_to_json(t) FROM tps t WHERE t.election_id = $1 ORDER BY t.id
+`, electionID); err != nil {
+		return nil, err
+	}
+
+	if snap.CandidateMedia, err = loadMedia(ctx, tx, electionID); err != nil {
+		return nil, err
+	}
+	if snap.Votes, err = loadVotes(ctx, tx, electionID); err != nil {
+		return nil, err
+	}
+	if snap.VoteTokens, err = loadVoteTokens(ctx, tx, electionID); err != nil {
+		return nil, err
+	}
+	if snap.Tallies, err = loadTallies(ctx, tx, electionID); err != nil {
+		return nil, err
+	}
+
+	if snap.AuditLog, err = queryJSONRows(ctx, tx, `
+SELECT row_to_json(a)
+FROM audit_logs a
+WHERE (a.metadata->>'election_id') = $1::text
+   OR (a.entity_type = 'ELECTION' AND a.entity_id = $1)
+ORDER BY a.id
+`, electionID); err != nil {
+		return nil, err
+	}
+
+	// Incidents leave their voter and check-in behind, like the votes do.
+	if snap.TPSIncidents, err = queryJSONRows(ctx, tx, `
+SELECT row_to_json(x)
+FROM (
+    SELECT i.id, i.tps_id, t.code AS tps_code, i.category, i.severity, i.status, i.description,
+           i.voter_id IS NOT NULL OR i.checkin_id IS NOT NULL AS linked_voter,
+           i.photo_data IS NOT NULL AS has_photo,
+           i.reported_by, i.assigned_to, i.resolution, i.resolved_by, i.resolved_at,
+           i.created_at, i.updated_at,
+           COALESCE((
+               SELECT json_agg(json_build_object('author_id', c.author_id, 'body', c.body, 'created_at', c.created_at) ORDER BY c.id)
+               FROM tps_incident_comments c
+               WHERE c.incident_id = i.id
+           ), '[]'::json) AS comments
+    FROM tps_incidents i
+    JOIN tps t ON t.id = i.tps_id
+    WHERE i.election_id = $1
+) x
+ORDER BY x.status = 'RESOLVED', x.id
+`, electionID); err != nil {
+		return nil, err
+	}
+
+	err = tx.QueryRow(ctx, `SELECT row_to_json(d) FROM candidate_ballot_draws d WHERE d.election_id = $1`, electionID).Scan(&snap.BallotDraw)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+
+	return snap, nil
+}
+
+func queryJSONRows(ctx context.Context, tx pgx.Tx, q string, args ...any) ([]json.RawMessage, error) {
+	rows, err := tx.Query(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []json.RawMessage{}
+	for rows.Next() {
+		var raw json.RawMessage
+		if err := rows.Scan(&raw); err != nil {
+			return nil, err
+		}
+		out = append(out, raw)
+	}
+	return out, rows.Err()
+}
+
+func loadMedia(ctx context.Context, tx pgx.Tx, electionID int64) ([]MediaRecord, error) {
+	const q = `
+SELECT m.id::text, m.candidate_id, m.slot, m.file_name, m.content_type, m.size_bytes, m.storage_path, m.created_at
+FROM candidate_media m
+JOIN candidates c ON c.id = m.candidate_id
+WHERE c.election_id = $1
+ORDER BY m.candidate_id, m.created_at
+`
+	rows, err := tx.Query(ctx, q, electionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []MediaRecord{}
+	for rows.Next() {
+		var m MediaRecord
+		if err := rows.Scan(&m.ID, &m.CandidateID, &m.Slot, &m.FileName, &m.ContentType, &m.SizeBytes, &m.StoragePath, &m.CreatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	return out, rows.Err()
+}
+
+// loadVotes drops the token hash and row order and truncates the cast time,
+// which is what keeps exported votes unlinkable from vote tokens.
+func loadVotes(ctx context.Context, tx pgx.Tx, electionID int64) ([]VoteRecord, error) {
+	const q = `
+SELECT candidate_id, channel::text, tps_id, date_trunc('hour', cast_at)
+FROM votes
+WHERE election_id = $1
+ORDER BY candidate_id, channel, tps_id NULLS FIRST, 4
+`
+	rows, err := tx.Query(ctx, q, electionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []VoteRecord{}
+	for rows.Next() {
+		var v VoteRecord
+		if err := rows.Scan(&v.CandidateID, &v.Channel, &v.TPSID, &v.CastHour); err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, rows.Err()
+}
+
+func loadVoteTokens(ctx context.Context, tx pgx.Tx, electionID int64) ([]VoteTokenRecord, error) {
+	const q = `
+SELECT token_hash, method::text, tps_id, issued_at, used_at
+FROM vote_tokens
+WHERE election_id = $1
+ORDER BY token_hash
+`
+	rows, err := tx.Query(ctx, q, electionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []VoteTokenRecord{}
+	for rows.Next() {
+		var t VoteTokenRecord
+		if err := rows.Scan(&t.TokenHash, &t.Method, &t.TPSID, &t.IssuedAt, &t.UsedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, t)
+	}
+	return out, rows.Err()
+}
+
+func loadTallies(ctx context.Context, tx pgx.Tx, electionID int64) ([]TallyRecord, error) {
+	const q = `
+SELECT candidate_id, channel::text, COUNT(*)
+FROM votes
+WHERE election_id = $1
+GROUP BY candidate_id, channel
+ORDER BY candidate_id, channel
+`
+	rows, err := tx.Query(ctx, q, electionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []TallyRecord{}
+	for rows.Next() {
+		var t TallyRecord
+		if err := rows.Scan(&t.CandidateID, &t.Channel, &t.Votes); err != nil {
+			return nil, err
+		}
+		out = append(out, t)
+	}
+	return out, rows.Err()
+}
+
+// Restore inserts the snapshot under fresh ids. Rows are written through
+// jsonb_populate_record so columns are matched by name; columns unknown to
+// this instance and references to accounts or voters of the source instance
+// are dropped.
+func (r *PgRepository) Restore(ctx context.Context, snap *Snapshot, manifest Manifest, bundleSHA256 string, adminID *int64) (*ImportResult, error) {
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	res := &ImportResult{SourceElectionID: manifest.SourceElectionID, BundleSHA256: bundleSHA256}
+
+	electionRow, err := decodeRow(snap.Election)
+	if err != nil {
+		return nil, err
+	}
+	columns := map[string]map[string]bool{}
+	for _, table := range []string{"elections", "candidates", "tps"} {
+		if columns[table], err = restorableColumns(ctx, tx, table); err != nil {
+			return nil, err
+		}
+	}
+
+	res.ElectionID, err = insertRow(ctx, tx, "elections", columns["elections"], electionRow)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, ErrElectionExists
+		}
+		return nil, err
+	}
+
+	manifestJSON, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, err
+	}
+	tokensJSON, err := json.Marshal(nonNil(snap.VoteTokens))
+	if err != nil {
+		return nil, err
+	}
+	auditJSON, err := json.Marshal(nonNil(snap.AuditLog))
+	if err != nil {
+		return nil, err
+	}
+	incidentsJSON, err := json.Marshal(nonNil(snap.TPSIncidents))
+	if err != nil {
+		return nil, err
+	}
+	var ballotDraw any
+	if len(snap.BallotDraw) > 0 {
+		ballotDraw = []byte(snap.BallotDraw)
+	}
+	err = tx.QueryRow(ctx, `
+INSERT INTO election_archives (
+    election_id, format_version, source_election_id, bundle_sha256,
+    manifest, vote_tokens, audit_log, ballot_draw, tps_incidents, imported_by
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+RETURNING id
+`, res.ElectionID, manifest.Version, manifest.SourceElectionID, bundleSHA256,
+		manifestJSON, tokensJSON, auditJSON, ballotDraw, incidentsJSON, adminID).Scan(&res.ArchiveID)
+	if err != nil {
+		return nil, err
+	}
+	res.VoteTokens = len(snap.VoteTokens)
+	res.AuditEntries = len(snap.AuditLog)
+	res.TPSIncidents = len(snap.TPSIncidents)
+
+	candidateIDs := map[int64]int64{}
+	photoMedia := map[int64]string{}
+	for _, raw := range snap.Candidates {
+		row, err := decodeRow(raw)
+		if err != nil {
+			return nil, err
+		}
+		oldID := rowID(row)
+		if photo, ok := row["photo_media_id"].(string); ok {
+			photoMedia[oldID] = photo
+		}
+		delete(row, "photo_media_id")
+		row["election_id"] = res.ElectionID
+
+		newID, err := insertRow(ctx, tx, "candidates", columns["candidates"], row)
+		if err != nil {
+			return nil, fmt.Errorf("restore candidate %d: %w", oldID, err)
+		}
+		candidateIDs[oldID] = newID
+	}
+	res.Candidates = len(candidateIDs)
+
+	mediaIDs := map[string]string{}
+	for _, m := range snap.CandidateMedia {
+		candidateID, ok := candidateIDs[m.CandidateID]
+		if !ok {
+			return nil, fmt.Errorf("%w: media %s references unknown candidate %d", ErrInvalidBundle, m.ID, m.CandidateID)
+		}
+		newID, err := newUUID()
+		if err != nil {
+			return nil, err
+		}
+		_, err = tx.Exec(ctx, `
+INSERT INTO candidate_media (id, candidate_id, slot, file_name, content_type, size_bytes, storage_path, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+`, newID, candidateID, m.Slot, m.FileName, m.ContentType, m.SizeBytes, m.StoragePath, m.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("restore media %s: %w", m.ID, err)
+		}
+		mediaIDs[m.ID] = newID
+	}
+	res.CandidateMedia = len(mediaIDs)
+
+	for oldCandidate, oldMedia := range photoMedia {
+		newMedia, ok := mediaIDs[oldMedia]
+		if !ok {
+			continue
+		}
+		if _, err := tx.Exec(ctx, `UPDATE candidates SET photo_media_id = $1 WHERE id = $2`, newMedia, candidateIDs[oldCandidate]); err != nil {
+			return nil, err
+		}
+	}
+
+	tpsIDs := map[int64]int64{}
+	for _, raw := range snap.TPS {
+		row, err := decodeRow(raw)
+		if err != nil {
+			return nil, err
+		}
+		oldID := rowID(row)
+		row["election_id"] = res.ElectionID
+
+		newID, err := insertRow(ctx, tx, "tps", columns["tps"], row)
+		if err != nil {
+			return nil, fmt.Errorf("restore tps %d: %w", oldID, err)
+		}
+		tpsIDs[oldID] = newID
+	}
+	res.TPS = len(tpsIDs)
+
+	batch := &pgx.Batch{}
+	for i, v := range snap.Votes {
+		candidateID, ok := candidateIDs[v.CandidateID]
+		if !ok {
+			return nil, fmt.Errorf("%w: vote references unknown candidate %d", ErrInvalidBundle, v.CandidateID)
+		}
+		var tpsID *int64
+		if v.TPSID != nil {
+			if id, ok := tpsIDs[*v.TPSID]; ok {
+				tpsID = &id
+			}
+		}
+		// Restored votes get a synthetic token hash; the real ones are not exported.
+		tokenHash := fmt.Sprintf("archive:%d:%d", res.ArchiveID, i+1)
+		batch.Queue(`
+INSERT INTO votes (election_id, candidate_id, token_hash, channel, tps_id, cast_at)
+VALUES ($1, $2, $3, $4::vote_channel, $5, $6)
+`, res.ElectionID, candidateID, tokenHash, v.Channel, tpsID, v.CastHour)
+	}
+	if batch.Len() > 0 {
+		if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+			return nil, fmt.Errorf("restore votes: %w", err)
+		}
+	}
+	res.Votes = len(snap.Votes)
+
+	totals := map[int64]int64{}
+	for _, t := range snap.Tallies {
+		if id, ok := candidateIDs[t.CandidateID]; ok {
+			totals[id] += t.Votes
+		}
+	}
+	for candidateID, total := range totals {
+		_, err := tx.Exec(ctx, `
+INSERT INTO vote_stats (election_id, candidate_id, total_votes, updated_at)
+VALUES ($1, $2, $3, NOW())
+ON CONFLICT (election_id, candidate_id) DO UPDATE SET total_votes = EXCLUDED.total_votes, updated_at = NOW()
+`, res.ElectionID, candidateID, total)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func decodeRow(raw json.RawMessage) (map[string]any, error) {
+	dec := json.NewDecoder(strings.NewReader(string(raw)))
+	dec.UseNumber()
+	var row map[string]any
+	if err := dec.Decode(&row); err != nil || row == nil {
+		return nil, fmt.Errorf("%w: malformed row", ErrInvalidBundle)
+	}
+	return row, nil
+}
+
+func rowID(row map[string]any) int64 {
+	if n, ok := row["id"].(json.Number); ok {
+		id, _ := n.Int64()
+		return id
+	}
+	return 0
+}
+
+// insertRow inserts a bundle row into table, letting the database assign a new id.
+func insertRow(ctx context.Context, tx pgx.Tx, table string, cols map[string]bool, row map[string]any) (int64, error) {
+	names := make([]string, 0, len(row))
+	filtered := make(map[string]any, len(row))
+	for k, v := range row {
+		if k == "id" || !cols[k] {
+			continue
+		}
+		names = append(names, k)
+		filtered[k] = v
+	}
+	sort.Strings(names)
+
+	quoted := make([]string, len(names))
+	for i, n := range names {
+		quoted[i] = pgx.Identifier{n}.Sanitize()
+	}
+	payload, err := json.Marshal(filtered)
+	if err != nil {
+		return 0, err
+	}
+
+	tableIdent := pgx.Identifier{table}.Sanitize()
+	q := fmt.Sprintf(`
+INSERT INTO %s (%s)
+SELECT %s FROM jsonb_populate_record(NULL::%s, $1::jsonb)
+RETURNING id
+`, tableIdent, strings.Join(quoted, ", "), strings.Join(quoted, ", "), tableIdent)
+
+	var id int64
+	if err := tx.QueryRow(ctx, q, payload).Scan(&id); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// restorableColumns lists the columns of table, minus foreign keys to
+// accounts and voters which only make sense on the source instance.
+func restorableColumns(ctx context.Context, tx pgx.Tx, table string) (map[string]bool, error) {
+	const q = `
+SELECT c.column_name
+FROM information_schema.columns c
+WHERE c.table_schema = current_schema()
+  AND c.table_name = $1
+  AND c.is_generated = 'NEVER'
+  AND NOT EXISTS (
+      SELECT 1
+      FROM information_schema.key_column_usage kcu
+      JOIN information_schema.referential_constraints rc
+        ON rc.constraint_name = kcu.constraint_name AND rc.constraint_schema = kcu.constraint_schema
+      JOIN information_schema.constraint_column_usage ccu
+        ON ccu.constraint_name = rc.unique_constraint_name AND ccu.constraint_schema = rc.unique_constraint_schema
+      WHERE kcu.table_schema = c.table_schema
+        AND kcu.table_name = c.table_name
+        AND kcu.column_name = c.column_name
+        AND ccu.table_name IN ('user_accounts', 'voters')
+  )
+`
+	rows, err := tx.Query(ctx, q, table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cols := map[string]bool{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		cols[name] = true
+	}
+	return cols, rows.Err()
+}
+
+func newUUID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+
+	// UUID v4 layout
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
@@ -0,0 +1,208 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	storage_go "github.com/supabase-community/storage-go"
+
+	"pemira-api/internal/embargo"
+)
+
+// MediaFetcher returns the bytes of a stored media file.
+type MediaFetcher func(ctx context.Context, storagePath string) ([]byte, error)
+
+// MediaUploader stores a media file at path and returns its new storage path.
+type MediaUploader func(ctx context.Context, path string, data []byte, contentType string) (string, error)
+
+type Service struct {
+	repo        Repository
+	fetchMedia  MediaFetcher
+	uploadMedia MediaUploader
+	now         func() time.Time
+	embargo     embargo.Policy
+}
+
+func NewService(repo Repository) *Service {
+	return &Service{
+		repo:        repo,
+		fetchMedia:  httpMediaFetcher(&http.Client{Timeout: 30 * time.Second}),
+		uploadMedia: supabaseMediaUploader,
+		now:         time.Now,
+	}
+}
+
+// SetMediaFetcher replaces how media files are downloaded during export.
+func (s *Service) SetMediaFetcher(f MediaFetcher) {
+	s.fetchMedia = f
+}
+
+// SetMediaUploader replaces how bundled media files are stored during import.
+func (s *Service) SetMediaUploader(u MediaUploader) {
+	s.uploadMedia = u
+}
+
+// SetEmbargo rejects exports while the election's results are under
+// embargo: the bundle holds every ballot.
+func (s *Service) SetEmbargo(p embargo.Policy) {
+	s.embargo = p
+}
+
+// Export writes an election bundle to w. Elections with voting still open are
+// rejected so the bundle never holds a partial tally.
+func (s *Service) Export(ctx context.Context, electionID int64, w io.Writer) (*Manifest, error) {
+	snap, err := s.repo.LoadSnapshot(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var head struct {
+		Code   string `json:"code"`
+		Name   string `json:"name"`
+		Status string `json:"status"`
+	}
+	if err := json.Unmarshal(snap.Election, &head); err != nil {
+		return nil, err
+	}
+	if head.Status == "VOTING_OPEN" {
+		return nil, ErrElectionVotingOpen
+	}
+	if s.embargo != nil && s.embargo.Sealed(ctx, electionID) {
+		return nil, embargo.ErrResultsEmbargoed
+	}
+
+	media := make(map[string][]byte, len(snap.CandidateMedia))
+	for _, m := range snap.CandidateMedia {
+		data, err := s.fetchMedia(ctx, m.StoragePath)
+		if err != nil {
+			slog.Warn("election export: media not available", "media_id", m.ID, "err", err)
+			continue
+		}
+		media[m.ID] = data
+	}
+
+	manifest := &Manifest{
+		ExportedAt:       s.now().UTC(),
+		SourceElectionID: electionID,
+		ElectionCode:     head.Code,
+		ElectionName:     head.Name,
+		OpenIncidents:    countOpenIncidents(snap.TPSIncidents),
+	}
+	if err := WriteBundle(w, manifest, snap, media); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// Import verifies a bundle and restores it as a new election.
+func (s *Service) Import(ctx context.Context, r io.ReaderAt, size int64, adminID *int64) (*ImportResult, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, io.NewSectionReader(r, 0, size)); err != nil {
+		return nil, err
+	}
+	bundleSHA := hex.EncodeToString(h.Sum(nil))
+
+	manifest, snap, media, err := ReadBundle(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	// The bundle's copy of each file is uploaded under a path of its own so
+	// the restored election never points at the source instance's storage.
+	// Media the export could not include is left out.
+	restored := make([]MediaRecord, 0, len(snap.CandidateMedia))
+	for _, m := range snap.CandidateMedia {
+		data, ok := media[m.ID]
+		if !ok {
+			slog.Warn("election import: media missing from bundle", "media_id", m.ID)
+			continue
+		}
+		p := fmt.Sprintf("archives/%s/%s%s", bundleSHA[:16], m.ID, path.Ext(m.FileName))
+		storagePath, err := s.uploadMedia(ctx, p, data, m.ContentType)
+		if err != nil {
+			return nil, fmt.Errorf("upload media %s: %w", m.ID, err)
+		}
+		m.StoragePath = storagePath
+		m.SizeBytes = int64(len(data))
+		restored = append(restored, m)
+	}
+	snap.CandidateMedia = restored
+
+	return s.repo.Restore(ctx, snap, *manifest, bundleSHA, adminID)
+}
+
+// BundleFileName is the suggested download name for an election bundle.
+func BundleFileName(electionID int64, at time.Time) string {
+	return fmt.Sprintf("pemira-election-%d-%s.zip", electionID, at.UTC().Format("20060102T150405Z"))
+}
+
+func httpMediaFetcher(client *http.Client) MediaFetcher {
+	return func(ctx context.Context, storagePath string) ([]byte, error) {
+		if !strings.HasPrefix(storagePath, "http://") && !strings.HasPrefix(storagePath, "https://") {
+			return nil, fmt.Errorf("storage path %q is not a URL", storagePath)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, storagePath, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+		}
+		return io.ReadAll(io.LimitReader(resp.Body, maxBundleFileSize))
+	}
+}
+
+// supabaseMediaUploader stores media in the candidate media bucket
+func supabaseMediaUploader(ctx context.Context, path string, data []byte, contentType string) (string, error) {
+	url := os.Getenv("SUPABASE_URL")
+	key := os.Getenv("SUPABASE_SECRET_KEY")
+	if url == "" || key == "" {
+		return "", fmt.Errorf("SUPABASE_URL and SUPABASE_SECRET_KEY required")
+	}
+	bucket := os.Getenv("SUPABASE_MEDIA_BUCKET")
+	if bucket == "" {
+		bucket = "pemira"
+	}
+
+	client := storage_go.NewClient(url+"/storage/v1", key, map[string]string{"apikey": key})
+	upsert := true
+	if _, err := client.UploadFile(bucket, path, bytes.NewReader(data), storage_go.FileOptions{
+		ContentType: &contentType,
+		Upsert:      &upsert,
+	}); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/storage/v1/object/public/%s/%s", url, bucket, path), nil
+}
+
+// countOpenIncidents counts the incident rows that are not resolved
+func countOpenIncidents(rows []json.RawMessage) int {
+	open := 0
+	for _, raw := range rows {
+		var row struct {
+			Status string `json:"status"`
+		}
+		if err := json.Unmarshal(raw, &row); err == nil && row.Status != "RESOLVED" {
+			open++
+		}
+	}
+	return open
+}
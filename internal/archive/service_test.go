@@ -0,0 +1,56 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+type restoreRepo struct {
+	restored *Snapshot
+}
+
+func (r *restoreRepo) LoadSnapshot(ctx context.Context, electionID int64) (*Snapshot, error) {
+	return nil, ErrElectionNotFound
+}
+
+func (r *restoreRepo) Restore(ctx context.Context, snap *Snapshot, manifest Manifest, bundleSHA256 string, adminID *int64) (*ImportResult, error) {
+	r.restored = snap
+	return &ImportResult{CandidateMedia: len(snap.CandidateMedia)}, nil
+}
+
+func TestImport_UploadsBundledMedia(t *testing.T) {
+	snap, media := bundleFixture()
+	var buf bytes.Buffer
+	if err := WriteBundle(&buf, &Manifest{SourceElectionID: 3}, snap, media); err != nil {
+		t.Fatalf("write bundle: %v", err)
+	}
+
+	repo := &restoreRepo{}
+	svc := NewService(repo)
+	uploaded := map[string]string{}
+	svc.SetMediaUploader(func(ctx context.Context, path string, data []byte, contentType string) (string, error) {
+		uploaded[path] = string(data)
+		return "https://new/" + path, nil
+	})
+
+	if _, err := svc.Import(context.Background(), bytes.NewReader(buf.Bytes()), int64(buf.Len()), nil); err != nil {
+		t.Fatalf("import: %v", err)
+	}
+
+	if len(uploaded) != 1 {
+		t.Fatalf("expected one upload, got %v", uploaded)
+	}
+	// m-2 was not in the bundle
+	if len(repo.restored.CandidateMedia) != 1 {
+		t.Fatalf("restored media = %+v", repo.restored.CandidateMedia)
+	}
+	m1 := repo.restored.CandidateMedia[0]
+	if !strings.HasPrefix(m1.StoragePath, "https://new/archives/") || !strings.HasSuffix(m1.StoragePath, "/m-1.png") {
+		t.Fatalf("m-1 storage path = %q", m1.StoragePath)
+	}
+	if uploaded[strings.TrimPrefix(m1.StoragePath, "https://new/")] != "png" {
+		t.Fatalf("m-1 uploaded with wrong bytes: %v", uploaded)
+	}
+}
@@ -0,0 +1,15 @@
+package audit
+
+import (
+	"net/http"
+
+	"pemira-api/internal/errcatalog"
+)
+
+// Messages the audit log handlers answer with, each under its own code in the
+// error catalog
+var (
+	auditListFailed = errcatalog.Define("AUDIT_LIST_FAILED", http.StatusInternalServerError, "Gagal mengambil log audit.", "Failed to fetch audit logs.")
+	auditIDInvalid  = errcatalog.Define("AUDIT_ID_INVALID", http.StatusBadRequest, "ID log audit tidak valid.", "Invalid audit log ID.")
+	auditNotFound   = errcatalog.Define("AUDIT_LOG_NOT_FOUND", http.StatusNotFound, "Log audit tidak ditemukan.", "Audit log not found.")
+)
@@ -0,0 +1,63 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Execer is satisfied by pgx.Tx, *pgxpool.Pool and *pgx.Conn.
+type Execer interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+// Entry is one audit_logs row.
+type Entry struct {
+	ActorVoterID *int64
+	ActorUserID  *int64
+	Action       string
+	EntityType   string
+	EntityID     int64
+	Metadata     map[string]any
+}
+
+// Write inserts entries into audit_logs. Pass the transaction of the change
+// being audited: a failed write is returned, so the change rolls back instead
+// of going unaudited.
+func Write(ctx context.Context, db Execer, entries ...Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	voters := make([]*int64, len(entries))
+	users := make([]*int64, len(entries))
+	actions := make([]string, len(entries))
+	types := make([]string, len(entries))
+	ids := make([]int64, len(entries))
+	metadata := make([]string, len(entries))
+	for i, e := range entries {
+		voters[i], users[i] = e.ActorVoterID, e.ActorUserID
+		actions[i], types[i], ids[i] = e.Action, e.EntityType, e.EntityID
+		m := e.Metadata
+		if m == nil {
+			m = map[string]any{}
+		}
+		b, err := json.Marshal(m)
+		if err != nil {
+			return fmt.Errorf("encode audit metadata: %w", err)
+		}
+		metadata[i] = string(b)
+	}
+
+	if _, err := db.Exec(ctx, `
+		INSERT INTO audit_logs (actor_voter_id, actor_user_id, action, entity_type, entity_id, metadata, created_at)
+		SELECT e.actor_voter_id, e.actor_user_id, e.action, e.entity_type, e.entity_id, e.metadata::jsonb, NOW()
+		FROM unnest($1::bigint[], $2::bigint[], $3::text[], $4::text[], $5::bigint[], $6::text[])
+			AS e(actor_voter_id, actor_user_id, action, entity_type, entity_id, metadata)
+	`, voters, users, actions, types, ids, metadata); err != nil {
+		return fmt.Errorf("write audit log: %w", err)
+	}
+	return nil
+}
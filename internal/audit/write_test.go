@@ -0,0 +1,63 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type fakeExecer struct {
+	calls int
+	args  []any
+	err   error
+}
+
+func (f *fakeExecer) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	f.calls++
+	f.args = args
+	return pgconn.CommandTag{}, f.err
+}
+
+func TestWrite_OneStatementForAllEntries(t *testing.T) {
+	db := &fakeExecer{}
+	actor := int64(3)
+
+	err := Write(context.Background(), db,
+		Entry{ActorUserID: &actor, Action: "A", EntityType: "ELECTION", EntityID: 1, Metadata: map[string]any{"x": 1}},
+		Entry{Action: "B", EntityType: "TPS_CHECKIN", EntityID: 2},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db.calls != 1 {
+		t.Fatalf("expected one insert, got %d", db.calls)
+	}
+	users := db.args[1].([]*int64)
+	if users[0] == nil || *users[0] != 3 || users[1] != nil {
+		t.Fatalf("unexpected actors %v", users)
+	}
+	metadata := db.args[5].([]string)
+	var m map[string]any
+	if err := json.Unmarshal([]byte(metadata[1]), &m); err != nil || len(m) != 0 {
+		t.Fatalf("nil metadata should be an empty object, got %q", metadata[1])
+	}
+}
+
+func TestWrite_ReturnsInsertError(t *testing.T) {
+	errMissing := errors.New(`relation "audit_logs" does not exist`)
+	db := &fakeExecer{err: errMissing}
+
+	if err := Write(context.Background(), db, Entry{Action: "A", EntityType: "ELECTION", EntityID: 1}); !errors.Is(err, errMissing) {
+		t.Fatalf("expected the insert error, got %v", err)
+	}
+}
+
+func TestWrite_NothingToWrite(t *testing.T) {
+	db := &fakeExecer{}
+	if err := Write(context.Background(), db); err != nil || db.calls != 0 {
+		t.Fatalf("expected no insert, got %d calls, err %v", db.calls, err)
+	}
+}
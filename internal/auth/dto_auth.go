@@ -1,5 +1,7 @@
 package auth
 
+import "time"
+
 // LoginRequest represents login request payload
 type LoginRequest struct {
 	Username string `json:"username"`
@@ -13,6 +15,55 @@ type LoginResponse struct {
 	TokenType    string    `json:"token_type"`
 	ExpiresIn    int64     `json:"expires_in"`
 	User         *AuthUser `json:"user"`
+	// RecoveryCodes is set once, when 2FA enrollment completes during login.
+	RecoveryCodes []string `json:"recovery_codes,omitempty"`
+}
+
+// MFAChallengeResponse is returned by login instead of tokens when the
+// account needs a second factor
+type MFAChallengeResponse struct {
+	MFARequired      bool   `json:"mfa_required"`
+	MFASetupRequired bool   `json:"mfa_setup_required"`
+	MFAToken         string `json:"mfa_token"`
+	ExpiresIn        int64  `json:"expires_in"`
+}
+
+// LoginMFARequest completes a login with a TOTP or recovery code
+type LoginMFARequest struct {
+	MFAToken string `json:"mfa_token"`
+	Code     string `json:"code"`
+}
+
+// MFATokenRequest starts 2FA enrollment during login
+type MFATokenRequest struct {
+	MFAToken string `json:"mfa_token"`
+}
+
+// TOTPCodeRequest carries a TOTP code, or a recovery code where accepted
+type TOTPCodeRequest struct {
+	Code string `json:"code"`
+}
+
+// TOTPSetupResponse carries the provisioning data for an authenticator app
+type TOTPSetupResponse struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+	Issuer     string `json:"issuer"`
+	Account    string `json:"account"`
+}
+
+// TOTPStatusResponse describes the 2FA state of the current user
+type TOTPStatusResponse struct {
+	Enabled                bool       `json:"enabled"`
+	Pending                bool       `json:"pending"`
+	Required               bool       `json:"required"`
+	ConfirmedAt            *time.Time `json:"confirmed_at,omitempty"`
+	RecoveryCodesRemaining int        `json:"recovery_codes_remaining"`
+}
+
+// RecoveryCodesResponse returns freshly generated recovery codes
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
 }
 
 // RefreshRequest represents refresh token request
@@ -105,3 +156,24 @@ type ResetPasswordRequest struct {
 	Identifier  string `json:"identifier"`   // NIM/NIDN/NIP
 	NewPassword string `json:"new_password"` // New password
 }
+
+// VerifyEmailRequest verifies an email with the username and 6-digit code,
+// or with the token from the email link
+type VerifyEmailRequest struct {
+	Username string `json:"username,omitempty"`
+	Code     string `json:"code,omitempty"`
+	Token    string `json:"token,omitempty"`
+}
+
+// ResendEmailVerificationRequest asks for a new verification email,
+// optionally to a corrected address
+type ResendEmailVerificationRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Email    string `json:"email,omitempty"`
+}
+
+// SSOTokenRequest exchanges the one-time code from the SSO callback
+type SSOTokenRequest struct {
+	Code string `json:"code"`
+}
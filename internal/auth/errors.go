@@ -0,0 +1,50 @@
+package auth
+
+import (
+	"net/http"
+
+	"pemira-api/internal/errcatalog"
+)
+
+// The API codes and messages of the service errors; handleError writes them
+func init() {
+	errcatalog.Register(
+		errcatalog.Entry{Err: ErrInvalidCredentials, Code: "INVALID_CREDENTIALS", Status: http.StatusUnauthorized, ID: "Username atau password salah.", EN: "Incorrect username or password."},
+		errcatalog.Entry{Err: ErrInactiveUser, Code: "USER_INACTIVE", Status: http.StatusForbidden, ID: "Akun tidak aktif.", EN: "The account is inactive."},
+		errcatalog.Entry{Err: ErrInvalidRefreshToken, Code: "INVALID_REFRESH_TOKEN", Status: http.StatusUnauthorized, ID: "Refresh token tidak valid atau sudah kadaluarsa.", EN: "The refresh token is invalid or has expired."},
+		errcatalog.Entry{Err: ErrUserNotFound, Code: "USER_NOT_FOUND", Status: http.StatusNotFound, ID: "Pengguna tidak ditemukan.", EN: "User not found."},
+		errcatalog.Entry{Err: ErrVoterNotRegistered, Code: "VOTER_NOT_REGISTERED", Status: http.StatusNotFound, ID: "NIM/NIDN/NIP tidak terdaftar atau belum memiliki akun.", EN: "The NIM/NIDN/NIP is not registered or has no account."},
+		errcatalog.Entry{Err: ErrUsernameExists, Code: "USERNAME_EXISTS", Status: http.StatusConflict, ID: "Username sudah terdaftar.", EN: "The username is already registered."},
+		errcatalog.Entry{Err: ErrNIMExists, Code: "NIM_EXISTS", Status: http.StatusConflict, ID: "NIM sudah terdaftar.", EN: "The NIM is already registered."},
+		errcatalog.Entry{Err: ErrNIDNExists, Code: "NIDN_EXISTS", Status: http.StatusConflict, ID: "NIDN sudah terdaftar.", EN: "The NIDN is already registered."},
+		errcatalog.Entry{Err: ErrNIPExists, Code: "NIP_EXISTS", Status: http.StatusConflict, ID: "NIP sudah terdaftar.", EN: "The NIP is already registered."},
+		errcatalog.Entry{Err: ErrInvalidRegisterType, Code: "INVALID_REQUEST", Status: http.StatusBadRequest, ID: "Tipe registrasi tidak valid.", EN: "Invalid registration type."},
+		errcatalog.Entry{Err: ErrInvalidRegistration, Code: "VALIDATION_ERROR", Status: http.StatusUnprocessableEntity, ID: "Data registrasi tidak lengkap atau tidak valid.", EN: "The registration data is incomplete or invalid."},
+		errcatalog.Entry{Err: ErrModeNotAvailable, Code: "MODE_NOT_AVAILABLE", Status: http.StatusUnprocessableEntity, ID: "Mode tidak tersedia untuk pemilu ini.", EN: "This mode is not available in this election."},
+		errcatalog.Entry{Err: ErrMFAChallengeInvalid, Code: "MFA_CHALLENGE_INVALID", Status: http.StatusUnauthorized, ID: "Sesi verifikasi 2FA tidak valid atau sudah kedaluwarsa. Silakan login ulang.", EN: "The 2FA session is invalid or has expired. Please log in again."},
+		errcatalog.Entry{Err: ErrInvalidMFACode, Code: "INVALID_MFA_CODE", Status: http.StatusUnauthorized, ID: "Kode 2FA salah.", EN: "Incorrect 2FA code."},
+		errcatalog.Entry{Err: ErrTOTPNotEnabled, Code: "TOTP_NOT_ENABLED", Status: http.StatusUnprocessableEntity, ID: "2FA belum diaktifkan.", EN: "2FA is not enabled."},
+		errcatalog.Entry{Err: ErrTOTPSetupRequired, Code: "TOTP_SETUP_REQUIRED", Status: http.StatusUnprocessableEntity, ID: "Mulai setup 2FA terlebih dahulu.", EN: "Start the 2FA setup first."},
+		errcatalog.Entry{Err: ErrTOTPAlreadyEnabled, Code: "TOTP_ALREADY_ENABLED", Status: http.StatusConflict, ID: "2FA sudah aktif.", EN: "2FA is already enabled."},
+		errcatalog.Entry{Err: ErrTOTPUnavailable, Code: "TOTP_UNAVAILABLE", Status: http.StatusServiceUnavailable, ID: "2FA belum dikonfigurasi di server ini.", EN: "2FA is not configured on this server."},
+		errcatalog.Entry{Err: ErrTOTPRequired, Code: "TOTP_REQUIRED", Status: http.StatusForbidden, ID: "2FA wajib untuk peran akun ini.", EN: "2FA is required for this account's role."},
+		errcatalog.Entry{Err: ErrPasswordLoginDisabled, Code: "PASSWORD_LOGIN_DISABLED", Status: http.StatusForbidden, ID: "Login dengan password dinonaktifkan untuk akun ini. Gunakan SSO kampus.", EN: "Password login is disabled for this account. Use campus SSO."},
+		errcatalog.Entry{Err: ErrSSODisabled, Code: "SSO_DISABLED", Status: http.StatusNotFound, ID: "SSO belum dikonfigurasi.", EN: "SSO is not configured."},
+		errcatalog.Entry{Err: ErrSSOStateInvalid, Code: "SSO_STATE_INVALID", Status: http.StatusUnauthorized, ID: "Sesi login SSO tidak valid atau sudah kedaluwarsa. Silakan ulangi login.", EN: "The SSO login session is invalid or has expired. Please log in again."},
+		errcatalog.Entry{Err: ErrInvalidIDToken, Code: "INVALID_ID_TOKEN", Status: http.StatusUnauthorized, ID: "Token dari penyedia SSO tidak valid.", EN: "The token from the SSO provider is invalid."},
+		errcatalog.Entry{Err: errSSOProviderUnavailable, Code: "SSO_PROVIDER_UNAVAILABLE", Status: http.StatusBadGateway, ID: "Penyedia SSO tidak dapat dihubungi.", EN: "The SSO provider cannot be reached."},
+		errcatalog.Entry{Err: ErrSSOIdentifierMissing, Code: "SSO_IDENTIFIER_MISSING", Status: http.StatusUnprocessableEntity, ID: "Akun SSO tidak memiliki NIM/NIDN/NIP.", EN: "The SSO account has no NIM/NIDN/NIP."},
+		errcatalog.Entry{Err: ErrVoterNotInDPT, Code: "NOT_IN_DPT", Status: http.StatusForbidden, ID: "NIM/NIDN/NIP tidak terdaftar di DPT.", EN: "The NIM/NIDN/NIP is not in the DPT."},
+		errcatalog.Entry{Err: ErrIdentityConflict, Code: "SSO_ACCOUNT_CONFLICT", Status: http.StatusConflict, ID: "Akun sudah terhubung dengan identitas SSO lain atau tidak dapat dihubungkan.", EN: "The account is linked to another SSO identity or cannot be linked."},
+		errcatalog.Entry{Err: ErrSSOAccountNotLinkable, Code: "SSO_ACCOUNT_CONFLICT", Status: http.StatusConflict, ID: "Akun sudah terhubung dengan identitas SSO lain atau tidak dapat dihubungkan.", EN: "The account is linked to another SSO identity or cannot be linked."},
+		errcatalog.Entry{Err: ErrEmailRequired, Code: "EMAIL_REQUIRED", Status: http.StatusUnprocessableEntity, ID: "Email institusi wajib diisi.", EN: "An institutional email is required."},
+		errcatalog.Entry{Err: ErrEmailNotOwned, Code: "EMAIL_IDENTITY_MISMATCH", Status: http.StatusUnprocessableEntity, ID: "Email tidak sesuai dengan data akademik NIM/NIDN/NIP ini.", EN: "The email does not match the academic record of this NIM/NIDN/NIP."},
+		errcatalog.Entry{Err: ErrEmailDomainNotAllowed, Code: "EMAIL_DOMAIN_NOT_ALLOWED", Status: http.StatusUnprocessableEntity, ID: "Gunakan email institusi yang diizinkan.", EN: "Use an allowed institutional email."},
+		errcatalog.Entry{Err: ErrEmailNotVerified, Code: "EMAIL_NOT_VERIFIED", Status: http.StatusForbidden, ID: "Email belum diverifikasi. Masukkan kode yang dikirim ke email Anda.", EN: "The email is not verified. Enter the code sent to your email."},
+		errcatalog.Entry{Err: ErrEmailVerificationInvalid, Code: "EMAIL_VERIFICATION_INVALID", Status: http.StatusUnauthorized, ID: "Kode atau tautan verifikasi tidak valid atau sudah kedaluwarsa. Minta kode baru.", EN: "The verification code or link is invalid or has expired. Request a new code."},
+		errcatalog.Entry{Err: ErrInvalidVerificationCode, Code: "INVALID_VERIFICATION_CODE", Status: http.StatusUnauthorized, ID: "Kode verifikasi salah.", EN: "Incorrect verification code."},
+		errcatalog.Entry{Err: ErrVerificationResendLimit, Code: "VERIFICATION_RESEND_LIMIT", Status: http.StatusTooManyRequests, ID: "Terlalu banyak permintaan email verifikasi. Coba lagi nanti.", EN: "Too many verification email requests. Try again later."},
+		errcatalog.Entry{Err: ErrEmailAlreadyVerified, Code: "EMAIL_ALREADY_VERIFIED", Status: http.StatusConflict, ID: "Email sudah diverifikasi.", EN: "The email is already verified."},
+		errcatalog.Entry{Err: ErrEmailInUse, Code: "EMAIL_IN_USE", Status: http.StatusConflict, ID: "Email sudah digunakan akun lain.", EN: "The email is used by another account."},
+	)
+}
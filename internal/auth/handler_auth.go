@@ -4,9 +4,12 @@ import (
 	"encoding/json"
 	"errors"
 	"log/slog"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
 
+	"pemira-api/internal/errcatalog"
 	"pemira-api/internal/http/response"
 	"pemira-api/internal/shared/ctxkeys"
 )
@@ -23,19 +26,37 @@ func NewAuthHandler(service *AuthService) *AuthHandler {
 func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	var req LoginRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.BadRequest(w, "VALIDATION_ERROR", "Body tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
 		return
 	}
 
 	// Validate input
 	if req.Username == "" || req.Password == "" {
-		response.UnprocessableEntity(w, "VALIDATION_ERROR", "username dan password wajib diisi.")
+		response.UnprocessableEntity(w, "VALIDATION_ERROR", errcatalog.Required("username", "password"))
 		return
 	}
 
-	// Extract user agent and IP
-	userAgent := r.Header.Get("User-Agent")
-	ipAddress := r.Header.Get("X-Real-IP")
+	userAgent, ipAddress := ClientInfo(r)
+
+	loginResp, err := h.service.Login(r.Context(), req, userAgent, ipAddress)
+	if err != nil {
+		var mfa *MFARequiredError
+		if errors.As(err, &mfa) {
+			response.JSON(w, http.StatusOK, mfa.Challenge)
+			return
+		}
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, loginResp)
+}
+
+// ClientInfo extracts the user agent and client IP (without port) recorded
+// on login sessions
+func ClientInfo(r *http.Request) (userAgent, ipAddress string) {
+	userAgent = r.Header.Get("User-Agent")
+	ipAddress = r.Header.Get("X-Real-IP")
 	if ipAddress == "" {
 		ipAddress = r.Header.Get("X-Forwarded-For")
 		if ipAddress != "" {
@@ -56,20 +77,14 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	loginResp, err := h.service.Login(r.Context(), req, userAgent, ipAddress)
-	if err != nil {
-		h.handleError(w, err)
-		return
-	}
-
-	response.JSON(w, http.StatusOK, loginResp)
+	return userAgent, ipAddress
 }
 
 // RegisterStudent handles POST /auth/register/student
 func (h *AuthHandler) RegisterStudent(w http.ResponseWriter, r *http.Request) {
 	var req RegisterStudentRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.BadRequest(w, "VALIDATION_ERROR", "Body tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
 		return
 	}
 
@@ -79,9 +94,13 @@ func (h *AuthHandler) RegisterStudent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	message := "Registrasi mahasiswa berhasil."
+	if user.EmailVerificationPending {
+		message = emailVerificationPendingMessage
+	}
 	response.JSON(w, http.StatusCreated, map[string]interface{}{
 		"user":        user,
-		"message":     "Registrasi mahasiswa berhasil.",
+		"message":     message,
 		"voting_mode": normalizeVotingMode(req.VotingMode),
 	})
 }
@@ -90,7 +109,7 @@ func (h *AuthHandler) RegisterStudent(w http.ResponseWriter, r *http.Request) {
 func (h *AuthHandler) RegisterLecturerStaff(w http.ResponseWriter, r *http.Request) {
 	var req RegisterLecturerStaffRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.BadRequest(w, "VALIDATION_ERROR", "Body tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
 		return
 	}
 
@@ -100,9 +119,13 @@ func (h *AuthHandler) RegisterLecturerStaff(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	message := "Registrasi berhasil."
+	if user.EmailVerificationPending {
+		message = emailVerificationPendingMessage
+	}
 	response.JSON(w, http.StatusCreated, map[string]interface{}{
 		"user":        user,
-		"message":     "Registrasi berhasil.",
+		"message":     message,
 		"voting_mode": normalizeVotingMode(req.VotingMode),
 	})
 }
@@ -111,12 +134,12 @@ func (h *AuthHandler) RegisterLecturerStaff(w http.ResponseWriter, r *http.Reque
 func (h *AuthHandler) RefreshToken(w http.ResponseWriter, r *http.Request) {
 	var req RefreshRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.BadRequest(w, "VALIDATION_ERROR", "Body tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
 		return
 	}
 
 	if req.RefreshToken == "" {
-		response.UnprocessableEntity(w, "VALIDATION_ERROR", "Refresh token wajib diisi.")
+		response.Catalog(w, refreshTokenRequired)
 		return
 	}
 
@@ -133,12 +156,12 @@ func (h *AuthHandler) RefreshToken(w http.ResponseWriter, r *http.Request) {
 func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
 	var req LogoutRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.BadRequest(w, "VALIDATION_ERROR", "Body tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
 		return
 	}
 
 	if req.RefreshToken == "" {
-		response.UnprocessableEntity(w, "VALIDATION_ERROR", "Refresh token wajib diisi.")
+		response.Catalog(w, refreshTokenRequired)
 		return
 	}
 
@@ -157,7 +180,7 @@ func (h *AuthHandler) Me(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from context (set by auth middleware)
 	userID, ok := ctxkeys.GetUserID(r.Context())
 	if !ok {
-		response.Unauthorized(w, "UNAUTHORIZED", "Token tidak valid atau tidak ditemukan.")
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.TokenMissing)
 		return
 	}
 
@@ -175,21 +198,21 @@ func (h *AuthHandler) Me(w http.ResponseWriter, r *http.Request) {
 func (h *AuthHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
 	var req ResetPasswordRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.BadRequest(w, "VALIDATION_ERROR", "Body tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
 		return
 	}
 
 	// Validate input
 	if req.Identifier == "" {
-		response.UnprocessableEntity(w, "VALIDATION_ERROR", "NIM/NIDN/NIP wajib diisi.")
+		response.Catalog(w, identifierRequired)
 		return
 	}
 	if req.NewPassword == "" {
-		response.UnprocessableEntity(w, "VALIDATION_ERROR", "Password baru wajib diisi.")
+		response.Catalog(w, newPasswordRequired)
 		return
 	}
 	if len(req.NewPassword) < 6 {
-		response.UnprocessableEntity(w, "VALIDATION_ERROR", "Password minimal 6 karakter.")
+		response.Catalog(w, newPasswordTooShort)
 		return
 	}
 
@@ -204,50 +227,36 @@ func (h *AuthHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-// handleError maps service errors to HTTP responses
-func (h *AuthHandler) handleError(w http.ResponseWriter, err error) {
-	switch {
-	case errors.Is(err, ErrInvalidCredentials):
-		response.Unauthorized(w, "INVALID_CREDENTIALS", "Username atau password salah.")
-
-	case errors.Is(err, ErrInactiveUser):
-		response.Forbidden(w, "USER_INACTIVE", "Akun tidak aktif.")
-
-	case errors.Is(err, ErrInvalidRefreshToken):
-		response.Unauthorized(w, "INVALID_REFRESH_TOKEN", "Refresh token tidak valid atau sudah kadaluarsa.")
-
-	case errors.Is(err, ErrUserNotFound):
-		response.NotFound(w, "USER_NOT_FOUND", "Pengguna tidak ditemukan.")
-
-	case errors.Is(err, ErrVoterNotRegistered):
-		response.NotFound(w, "VOTER_NOT_REGISTERED", "NIM/NIDN/NIP tidak terdaftar atau belum memiliki akun.")
-
-	case errors.Is(err, ErrUsernameExists):
-		response.Conflict(w, "USERNAME_EXISTS", "Username sudah terdaftar.")
-
-	case errors.Is(err, ErrNIMExists):
-		response.Conflict(w, "NIM_EXISTS", "NIM sudah terdaftar.")
-
-	case errors.Is(err, ErrNIDNExists):
-		response.Conflict(w, "NIDN_EXISTS", "NIDN sudah terdaftar.")
-
-	case errors.Is(err, ErrNIPExists):
-		response.Conflict(w, "NIP_EXISTS", "NIP sudah terdaftar.")
-
-	case errors.Is(err, ErrInvalidRegisterType):
-		response.BadRequest(w, "INVALID_REQUEST", "Tipe registrasi tidak valid.")
-
-	case errors.Is(err, ErrInvalidRegistration):
-		response.UnprocessableEntity(w, "VALIDATION_ERROR", "Data registrasi tidak lengkap atau tidak valid.")
-
-	case errors.Is(err, ErrModeNotAvailable):
-		response.UnprocessableEntity(w, "MODE_NOT_AVAILABLE", "Mode tidak tersedia untuk pemilu ini.")
+// WriteAccountLocked answers 429 with Retry-After when err is an
+// *AccountLockedError and reports whether it did. Shared with the TPS panel
+// login.
+func WriteAccountLocked(w http.ResponseWriter, err error) bool {
+	var locked *AccountLockedError
+	if !errors.As(err, &locked) {
+		return false
+	}
+	retry := int(math.Ceil(locked.RetryAfter().Seconds()))
+	if retry < 1 {
+		retry = 1
+	}
+	w.Header().Set("Retry-After", strconv.Itoa(retry))
+	response.Error(w, accountLocked.Status, accountLocked.Code, accountLocked.Message(),
+		map[string]interface{}{"locked_until": locked.Until, "retry_after": retry})
+	return true
+}
 
-	default:
-		// Log internal error
-		slog.Error("auth handler error", "error", err)
-		response.InternalServerError(w, "INTERNAL_ERROR", "Terjadi kesalahan pada sistem.")
+// handleError maps service errors to HTTP responses through the error
+// catalog (see errors.go)
+func (h *AuthHandler) handleError(w http.ResponseWriter, err error) {
+	if WriteAccountLocked(w, err) {
+		return
+	}
+	if response.DomainError(w, err) {
+		return
 	}
+	// Log internal error
+	slog.Error("auth handler error", "error", err)
+	response.InternalServerError(w, "INTERNAL_ERROR", errcatalog.InternalError)
 }
 
 // LogoutPage handles GET /auth/logout-page - simple HTML page to clear tokens
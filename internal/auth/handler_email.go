@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"pemira-api/internal/errcatalog"
+	"pemira-api/internal/http/response"
+)
+
+const emailVerificationPendingMessage = "Registrasi berhasil. Masukkan kode verifikasi yang dikirim ke email Anda sebelum login."
+
+// VerifyEmail handles POST /auth/email/verify with either
+// {username, code} or {token} from the email link
+func (h *AuthHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	var req VerifyEmailRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
+		return
+	}
+
+	var err error
+	switch {
+	case strings.TrimSpace(req.Token) != "":
+		err = h.service.VerifyEmailLink(r.Context(), req.Token)
+	case strings.TrimSpace(req.Username) != "" && strings.TrimSpace(req.Code) != "":
+		err = h.service.VerifyEmailCode(r.Context(), req.Username, req.Code)
+	default:
+		response.Catalog(w, verificationInputRequired)
+		return
+	}
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]string{
+		"message": "Email berhasil diverifikasi. Silakan login.",
+	})
+}
+
+// ResendEmailVerification handles POST /auth/email/resend
+func (h *AuthHandler) ResendEmailVerification(w http.ResponseWriter, r *http.Request) {
+	var req ResendEmailVerificationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
+		return
+	}
+	if strings.TrimSpace(req.Username) == "" || req.Password == "" {
+		response.UnprocessableEntity(w, "VALIDATION_ERROR", errcatalog.Required("username", "password"))
+		return
+	}
+
+	if err := h.service.ResendEmailVerification(r.Context(), req); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]string{
+		"message": "Kode verifikasi baru telah dikirim.",
+	})
+}
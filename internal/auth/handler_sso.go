@@ -0,0 +1,128 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+
+	"pemira-api/internal/errcatalog"
+	"pemira-api/internal/http/response"
+)
+
+// SSOInfo handles GET /auth/sso so the frontend knows which login options
+// to show
+func (h *AuthHandler) SSOInfo(w http.ResponseWriter, r *http.Request) {
+	response.JSON(w, http.StatusOK, map[string]interface{}{
+		"enabled":                       h.service.SSOEnabled(),
+		"password_login_disabled_roles": h.service.PasswordLoginDisabledRoles(),
+	})
+}
+
+// SSOLogin handles GET /auth/sso/login and redirects the browser to the IdP
+func (h *AuthHandler) SSOLogin(w http.ResponseWriter, r *http.Request) {
+	authURL, err := h.service.StartSSO(r.Context())
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+// SSOCallback handles GET /auth/sso/callback, the IdP redirect. The browser
+// is sent on to the frontend with a one-time ?code= to exchange at
+// POST /auth/sso/token, or with ?error=. Without a frontend URL configured
+// the code is returned as JSON.
+func (h *AuthHandler) SSOCallback(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	var (
+		loginCode string
+		err       error
+	)
+	switch {
+	case q.Get("error") != "":
+		slog.Warn("sso login refused by provider", "error", q.Get("error"), "description", q.Get("error_description"))
+		err = ErrSSOStateInvalid
+	case q.Get("state") == "" || q.Get("code") == "":
+		err = ErrSSOStateInvalid
+	default:
+		loginCode, err = h.service.CompleteSSO(r.Context(), q.Get("state"), q.Get("code"))
+	}
+
+	frontend := h.service.ssoConfig.FrontendRedirectURL
+	if frontend == "" {
+		if err != nil {
+			h.handleError(w, err)
+			return
+		}
+		response.JSON(w, http.StatusOK, map[string]string{"code": loginCode})
+		return
+	}
+
+	target, perr := url.Parse(frontend)
+	if perr != nil {
+		response.InternalServerError(w, "INTERNAL_ERROR", errcatalog.InternalError)
+		return
+	}
+	params := target.Query()
+	if err != nil {
+		params.Set("error", ssoErrorCode(err))
+	} else {
+		params.Set("code", loginCode)
+	}
+	target.RawQuery = params.Encode()
+	http.Redirect(w, r, target.String(), http.StatusFound)
+}
+
+// SSOToken handles POST /auth/sso/token
+func (h *AuthHandler) SSOToken(w http.ResponseWriter, r *http.Request) {
+	var req SSOTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
+		return
+	}
+	if req.Code == "" {
+		response.UnprocessableEntity(w, "VALIDATION_ERROR", errcatalog.Required("code"))
+		return
+	}
+
+	userAgent, ipAddress := ClientInfo(r)
+	loginResp, err := h.service.ExchangeSSOCode(r.Context(), req.Code, userAgent, ipAddress)
+	if err != nil {
+		var mfa *MFARequiredError
+		if errors.As(err, &mfa) {
+			response.JSON(w, http.StatusOK, mfa.Challenge)
+			return
+		}
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, loginResp)
+}
+
+// ssoErrorCode is the ?error= value sent to the frontend
+func ssoErrorCode(err error) string {
+	switch {
+	case errors.Is(err, ErrSSOStateInvalid):
+		return "SSO_STATE_INVALID"
+	case errors.Is(err, ErrInvalidIDToken):
+		return "INVALID_ID_TOKEN"
+	case errors.Is(err, errSSOProviderUnavailable):
+		return "SSO_PROVIDER_UNAVAILABLE"
+	case errors.Is(err, ErrSSOIdentifierMissing):
+		return "SSO_IDENTIFIER_MISSING"
+	case errors.Is(err, ErrVoterNotInDPT):
+		return "NOT_IN_DPT"
+	case errors.Is(err, ErrIdentityConflict), errors.Is(err, ErrSSOAccountNotLinkable):
+		return "SSO_ACCOUNT_CONFLICT"
+	case errors.Is(err, ErrInactiveUser):
+		return "USER_INACTIVE"
+	case errors.Is(err, ErrSSODisabled):
+		return "SSO_DISABLED"
+	}
+	slog.Error("sso callback error", "error", err)
+	return "INTERNAL_ERROR"
+}
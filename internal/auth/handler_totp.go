@@ -0,0 +1,195 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"pemira-api/internal/errcatalog"
+	"pemira-api/internal/http/response"
+	"pemira-api/internal/shared/constants"
+	"pemira-api/internal/shared/ctxkeys"
+)
+
+// LoginMFA handles POST /auth/login/2fa
+func (h *AuthHandler) LoginMFA(w http.ResponseWriter, r *http.Request) {
+	req, ok := DecodeLoginMFA(w, r)
+	if !ok {
+		return
+	}
+
+	userAgent, ipAddress := ClientInfo(r)
+	loginResp, err := h.service.CompleteLoginMFA(r.Context(), req, userAgent, ipAddress)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, loginResp)
+}
+
+// DecodeLoginMFA reads and validates a LoginMFARequest body, writing the
+// error response itself. Shared with the TPS panel login.
+func DecodeLoginMFA(w http.ResponseWriter, r *http.Request) (LoginMFARequest, bool) {
+	var req LoginMFARequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
+		return req, false
+	}
+	req.Code = strings.TrimSpace(req.Code)
+	if req.MFAToken == "" || req.Code == "" {
+		response.UnprocessableEntity(w, "VALIDATION_ERROR", errcatalog.Required("mfa_token", "code"))
+		return req, false
+	}
+	return req, true
+}
+
+// LoginTOTPSetup handles POST /auth/login/2fa/setup, the enrollment step for
+// accounts whose role requires 2FA
+func (h *AuthHandler) LoginTOTPSetup(w http.ResponseWriter, r *http.Request) {
+	var req MFATokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
+		return
+	}
+	if req.MFAToken == "" {
+		response.UnprocessableEntity(w, "VALIDATION_ERROR", errcatalog.Required("mfa_token"))
+		return
+	}
+
+	setup, err := h.service.BeginLoginTOTPSetup(r.Context(), req.MFAToken)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, setup)
+}
+
+// TOTPStatus handles GET /auth/2fa
+func (h *AuthHandler) TOTPStatus(w http.ResponseWriter, r *http.Request) {
+	userID, ok := ctxkeys.GetUserID(r.Context())
+	if !ok {
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.TokenMissing)
+		return
+	}
+
+	status, err := h.service.TOTPStatus(r.Context(), userID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, status)
+}
+
+// SetupTOTP handles POST /auth/2fa/setup
+func (h *AuthHandler) SetupTOTP(w http.ResponseWriter, r *http.Request) {
+	userID, ok := ctxkeys.GetUserID(r.Context())
+	if !ok {
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.TokenMissing)
+		return
+	}
+
+	setup, err := h.service.BeginTOTPSetup(r.Context(), userID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, setup)
+}
+
+// EnableTOTP handles POST /auth/2fa/enable
+func (h *AuthHandler) EnableTOTP(w http.ResponseWriter, r *http.Request) {
+	userID, code, ok := h.decodeCode(w, r)
+	if !ok {
+		return
+	}
+
+	codes, err := h.service.EnableTOTP(r.Context(), userID, code)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// DisableTOTP handles POST /auth/2fa/disable
+func (h *AuthHandler) DisableTOTP(w http.ResponseWriter, r *http.Request) {
+	userID, code, ok := h.decodeCode(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DisableTOTP(r.Context(), userID, code); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]string{
+		"message": "2FA dinonaktifkan.",
+	})
+}
+
+// RegenerateRecoveryCodes handles POST /auth/2fa/recovery-codes
+func (h *AuthHandler) RegenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	userID, code, ok := h.decodeCode(w, r)
+	if !ok {
+		return
+	}
+
+	codes, err := h.service.RegenerateRecoveryCodes(r.Context(), userID, code)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// AdminResetTOTP handles DELETE /admin/users/{userID}/2fa (super admin only)
+func (h *AuthHandler) AdminResetTOTP(w http.ResponseWriter, r *http.Request) {
+	role, _ := ctxkeys.GetUserRole(r.Context())
+	if role != string(constants.RoleSuperAdmin) {
+		response.Catalog(w, totpResetForbidden)
+		return
+	}
+
+	userID, err := strconv.ParseInt(chi.URLParam(r, "userID"), 10, 64)
+	if err != nil || userID <= 0 {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidID)
+		return
+	}
+
+	if err := h.service.ResetTOTP(r.Context(), userID); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.Success(w, http.StatusOK, map[string]bool{"success": true})
+}
+
+func (h *AuthHandler) decodeCode(w http.ResponseWriter, r *http.Request) (int64, string, bool) {
+	userID, ok := ctxkeys.GetUserID(r.Context())
+	if !ok {
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.TokenMissing)
+		return 0, "", false
+	}
+
+	var req TOTPCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
+		return 0, "", false
+	}
+	req.Code = strings.TrimSpace(req.Code)
+	if req.Code == "" {
+		response.UnprocessableEntity(w, "VALIDATION_ERROR", errcatalog.Required("code"))
+		return 0, "", false
+	}
+	return userID, req.Code, true
+}
@@ -2,6 +2,9 @@ package auth
 
 import (
 	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
 
 	"pemira-api/internal/master"
 )
@@ -79,3 +82,19 @@ func (a *MasterRepositoryAdapter) GetStaffPositionByName(ctx context.Context, na
 		Name: position.Name,
 	}, nil
 }
+
+// RosterEmail returns the roster email of an identifier, "" when the roster
+// has no entry or no email for it
+func (a *MasterRepositoryAdapter) RosterEmail(ctx context.Context, voterType, identifier string) (string, error) {
+	entry, err := a.repo.GetRosterEntry(ctx, voterType, identifier)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if entry.Email == nil {
+		return "", nil
+	}
+	return *entry.Email, nil
+}
@@ -0,0 +1,19 @@
+package auth
+
+import (
+	"net/http"
+
+	"pemira-api/internal/errcatalog"
+)
+
+// Messages the auth handlers answer with, each under its own code in the
+// error catalog
+var (
+	refreshTokenRequired      = errcatalog.Define("REFRESH_TOKEN_REQUIRED", http.StatusUnprocessableEntity, "Refresh token wajib diisi.", "The refresh token is required.")
+	identifierRequired        = errcatalog.Define("IDENTIFIER_REQUIRED", http.StatusUnprocessableEntity, "NIM/NIDN/NIP wajib diisi.", "NIM/NIDN/NIP is required.")
+	newPasswordRequired       = errcatalog.Define("NEW_PASSWORD_REQUIRED", http.StatusUnprocessableEntity, "Password baru wajib diisi.", "The new password is required.")
+	newPasswordTooShort       = errcatalog.Define("NEW_PASSWORD_TOO_SHORT", http.StatusUnprocessableEntity, "Password minimal 6 karakter.", "Passwords need at least 6 characters.")
+	verificationInputRequired = errcatalog.Define("VERIFICATION_INPUT_REQUIRED", http.StatusUnprocessableEntity, "username dan code, atau token, wajib diisi.", "username and code, or token, are required.")
+	totpResetForbidden        = errcatalog.Define("TOTP_RESET_FORBIDDEN", http.StatusForbidden, "Hanya super admin yang dapat mereset 2FA.", "Only super admins can reset 2FA.")
+	accountLocked             = errcatalog.Define("ACCOUNT_LOCKED", http.StatusTooManyRequests, "Akun dikunci sementara karena terlalu banyak percobaan login gagal. Coba lagi nanti.", "The account is temporarily locked after too many failed logins. Try again later.")
+)
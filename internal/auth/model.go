@@ -19,10 +19,16 @@ type UserAccount struct {
 	LecturerID   *int64         `json:"lecturer_id,omitempty"`
 	StaffID      *int64         `json:"staff_id,omitempty"`
 	IsActive     bool           `json:"is_active"`
-	LastLoginAt  *time.Time     `json:"last_login_at,omitempty"`
-	LoginCount   int            `json:"login_count"`
-	CreatedAt    time.Time      `json:"created_at"`
-	UpdatedAt    time.Time      `json:"updated_at"`
+	// EmailVerificationPending blocks login until the email is verified.
+	EmailVerificationPending bool       `json:"email_verification_pending"`
+	LastLoginAt              *time.Time `json:"last_login_at,omitempty"`
+	LoginCount               int        `json:"login_count"`
+	CreatedAt                time.Time  `json:"created_at"`
+	UpdatedAt                time.Time  `json:"updated_at"`
+
+	// Progressive lockout after failed password logins
+	FailedLoginCount int        `json:"-"`
+	LockedUntil      *time.Time `json:"locked_until,omitempty"`
 }
 
 // UserSession stores refresh token metadata.
@@ -72,6 +78,9 @@ type AuthUser struct {
 	LecturerID *int64         `json:"lecturer_id,omitempty"`
 	StaffID    *int64         `json:"staff_id,omitempty"`
 	Profile    *UserProfile   `json:"profile,omitempty"`
+	// EmailVerificationPending is set on registration when the account
+	// must verify its email before logging in.
+	EmailVerificationPending bool `json:"email_verification_pending,omitempty"`
 }
 
 // JWTClaims is used by JWT middleware and tokens.
@@ -85,3 +94,64 @@ type JWTClaims struct {
 	Exp        int64          `json:"exp"`
 	Iat        int64          `json:"iat"`
 }
+
+// UserTOTP is a user's TOTP enrollment. ConfirmedAt stays nil until the first
+// code from the authenticator app has been verified.
+type UserTOTP struct {
+	UserID       int64      `json:"user_id"`
+	SecretEnc    string     `json:"-"`
+	ConfirmedAt  *time.Time `json:"confirmed_at,omitempty"`
+	LastUsedStep int64      `json:"-"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// MFA challenge purposes.
+const (
+	MFAPurposeVerify = "VERIFY"
+	MFAPurposeEnroll = "ENROLL"
+)
+
+// MFAChallenge is the pending second step of a password login.
+type MFAChallenge struct {
+	ID         int64
+	UserID     int64
+	Purpose    string
+	Attempts   int
+	ExpiresAt  time.Time
+	ConsumedAt *time.Time
+}
+
+// SSOLoginState is one OIDC authorization-code round trip.
+type SSOLoginState struct {
+	ID           int64
+	Nonce        string
+	CodeVerifier string
+	ExpiresAt    time.Time
+}
+
+// DPTVoter is the voter row an SSO identity is matched against by NIM,
+// NIDN or NIP.
+type DPTVoter struct {
+	ID          int64
+	NIM         string
+	Name        string
+	Email       string
+	FacultyName string
+	VoterType   string
+	LecturerID  *int64
+	StaffID     *int64
+}
+
+// EmailVerification is one verification email: a 6-digit code and a link
+// token, both stored hashed.
+type EmailVerification struct {
+	ID         int64
+	UserID     int64
+	Email      string
+	CodeHash   string
+	Attempts   int
+	ExpiresAt  time.Time
+	VerifiedAt *time.Time
+	CreatedAt  time.Time
+}
@@ -3,6 +3,7 @@ package auth
 import (
 	"context"
 	"errors"
+	"time"
 )
 
 var (
@@ -14,6 +15,14 @@ var (
 	ErrNIPExists           = errors.New("nip already exists")
 	ErrElectionUnavailable = errors.New("no active election for registration")
 	ErrVoterNotRegistered  = errors.New("voter not registered or has no account")
+	ErrTOTPNotFound        = errors.New("totp enrollment not found")
+	ErrMFAChallengeInvalid = errors.New("invalid or expired 2fa challenge")
+	ErrSSOStateInvalid     = errors.New("invalid or expired sso login state")
+	ErrIdentityConflict    = errors.New("account already linked to another identity")
+	ErrVoterNotInDPT       = errors.New("identifier not found in DPT")
+
+	ErrEmailVerificationInvalid = errors.New("invalid or expired email verification")
+	ErrEmailInUse               = errors.New("email already used by another account")
 )
 
 type Repository interface {
@@ -27,6 +36,11 @@ type Repository interface {
 	DeactivateUser(ctx context.Context, userID int64) error
 	UpdateLoginTracking(ctx context.Context, userID int64) error
 
+	// Login lockout operations
+	RecordLoginFailure(ctx context.Context, userID int64, since time.Time) (int, error)
+	LockUser(ctx context.Context, userID int64, until time.Time) error
+	ClearLoginFailures(ctx context.Context, userID int64) error
+
 	// Session operations
 	CreateSession(ctx context.Context, session *UserSession) (*UserSession, error)
 	GetSessionByTokenHash(ctx context.Context, tokenHash string) (*UserSession, error)
@@ -35,6 +49,41 @@ type Repository interface {
 	RevokeAllUserSessions(ctx context.Context, userID int64) error
 	CleanupExpiredSessions(ctx context.Context) error
 
+	// Two-factor operations
+	GetUserTOTP(ctx context.Context, userID int64) (*UserTOTP, error)
+	UpsertPendingTOTP(ctx context.Context, userID int64, secretEnc string) error
+	ConfirmTOTP(ctx context.Context, userID, step int64, recoveryCodeHashes []string) error
+	AdvanceTOTPStep(ctx context.Context, userID, step int64) (bool, error)
+	DeleteUserTOTP(ctx context.Context, userID int64) error
+	CountTOTPEnrollments(ctx context.Context) (int, error)
+	RekeyTOTPSecrets(ctx context.Context, rekey func(secretEnc string) (string, bool, error)) (int, error)
+	ReplaceRecoveryCodes(ctx context.Context, userID int64, codeHashes []string) error
+	UseRecoveryCode(ctx context.Context, userID int64, codeHash string) (bool, error)
+	CountRecoveryCodes(ctx context.Context, userID int64) (int, error)
+	CreateMFAChallenge(ctx context.Context, challenge *MFAChallenge, tokenHash string) error
+	GetMFAChallenge(ctx context.Context, tokenHash string) (*MFAChallenge, error)
+	ClaimMFAChallengeAttempt(ctx context.Context, tokenHash string, maxAttempts int) (*MFAChallenge, error)
+	ConsumeMFAChallenge(ctx context.Context, challengeID int64) (bool, error)
+
+	// SSO operations
+	CreateSSOState(ctx context.Context, state *SSOLoginState, stateHash string) error
+	ClaimSSOState(ctx context.Context, stateHash string) (*SSOLoginState, error)
+	CompleteSSOState(ctx context.Context, stateID, userID int64, loginCodeHash string, codeExpiresAt time.Time) error
+	ClaimSSOLoginCode(ctx context.Context, loginCodeHash string) (int64, error)
+	GetUserByIdentity(ctx context.Context, issuer, subject string) (*UserAccount, error)
+	LinkUserIdentity(ctx context.Context, userID int64, issuer, subject, email string) error
+	TouchUserIdentity(ctx context.Context, issuer, subject string) error
+	GetDPTVoterByNumber(ctx context.Context, number string) (*DPTVoter, error)
+	FillVoterFaculty(ctx context.Context, voterID int64, faculty string) error
+
+	// Email verification operations
+	CreateEmailVerification(ctx context.Context, v *EmailVerification, linkTokenHash string) error
+	EmailVerificationSends(ctx context.Context, userID int64, since time.Time) (int, *time.Time, error)
+	ClaimEmailVerificationAttempt(ctx context.Context, userID int64, maxAttempts int) (*EmailVerification, error)
+	GetEmailVerificationByLinkToken(ctx context.Context, linkTokenHash string) (*EmailVerification, error)
+	CompleteEmailVerification(ctx context.Context, v *EmailVerification) error
+	MarkEmailVerified(ctx context.Context, userID int64, email string) error
+
 	// Registration helpers
 	CreateVoter(ctx context.Context, voter VoterRegistration) (int64, error)
 	DeleteVoter(ctx context.Context, voterID int64) error
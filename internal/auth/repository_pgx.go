@@ -25,9 +25,9 @@ func NewPgRepository(db *pgxpool.Pool) *PgRepository {
 // CreateUserAccount creates a new user account
 func (r *PgRepository) CreateUserAccount(ctx context.Context, user *UserAccount) (*UserAccount, error) {
 	query := `
-		INSERT INTO user_accounts (username, email, password_hash, full_name, role, voter_id, tps_id, lecturer_id, staff_id, is_active)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-		RETURNING id, username, email, password_hash, full_name, role, voter_id, tps_id, lecturer_id, staff_id, is_active, created_at, updated_at
+		INSERT INTO user_accounts (username, email, password_hash, full_name, role, voter_id, tps_id, lecturer_id, staff_id, is_active, email_verification_pending)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id, username, email, password_hash, full_name, role, voter_id, tps_id, lecturer_id, staff_id, is_active, email_verification_pending, created_at, updated_at
 	`
 
 	var created UserAccount
@@ -50,6 +50,7 @@ func (r *PgRepository) CreateUserAccount(ctx context.Context, user *UserAccount)
 		user.LecturerID,
 		user.StaffID,
 		user.IsActive,
+		user.EmailVerificationPending,
 	).Scan(
 		&created.ID,
 		&created.Username,
@@ -62,6 +63,7 @@ func (r *PgRepository) CreateUserAccount(ctx context.Context, user *UserAccount)
 		&created.LecturerID,
 		&created.StaffID,
 		&created.IsActive,
+		&created.EmailVerificationPending,
 		&created.CreatedAt,
 		&created.UpdatedAt,
 	)
@@ -80,7 +82,8 @@ func (r *PgRepository) CreateUserAccount(ctx context.Context, user *UserAccount)
 // GetUserByUsername retrieves a user by username
 func (r *PgRepository) GetUserByUsername(ctx context.Context, username string) (*UserAccount, error) {
 	query := `
-		SELECT id, username, email, password_hash, full_name, role, voter_id, tps_id, lecturer_id, staff_id, is_active, last_login_at, login_count, created_at, updated_at
+		SELECT id, username, email, password_hash, full_name, role, voter_id, tps_id, lecturer_id, staff_id, is_active, email_verification_pending, last_login_at, login_count, created_at, updated_at,
+		       failed_login_count, locked_until
 		FROM user_accounts
 		WHERE username = $1
 	`
@@ -98,10 +101,13 @@ func (r *PgRepository) GetUserByUsername(ctx context.Context, username string) (
 		&user.LecturerID,
 		&user.StaffID,
 		&user.IsActive,
+		&user.EmailVerificationPending,
 		&user.LastLoginAt,
 		&user.LoginCount,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.FailedLoginCount,
+		&user.LockedUntil,
 	)
 
 	if err != nil {
@@ -117,7 +123,8 @@ func (r *PgRepository) GetUserByUsername(ctx context.Context, username string) (
 // GetUserByID retrieves a user by ID
 func (r *PgRepository) GetUserByID(ctx context.Context, userID int64) (*UserAccount, error) {
 	query := `
-		SELECT id, username, email, password_hash, full_name, role, voter_id, tps_id, lecturer_id, staff_id, is_active, last_login_at, login_count, created_at, updated_at
+		SELECT id, username, email, password_hash, full_name, role, voter_id, tps_id, lecturer_id, staff_id, is_active, email_verification_pending, last_login_at, login_count, created_at, updated_at,
+		       failed_login_count, locked_until
 		FROM user_accounts
 		WHERE id = $1
 	`
@@ -135,10 +142,13 @@ func (r *PgRepository) GetUserByID(ctx context.Context, userID int64) (*UserAcco
 		&user.LecturerID,
 		&user.StaffID,
 		&user.IsActive,
+		&user.EmailVerificationPending,
 		&user.LastLoginAt,
 		&user.LoginCount,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.FailedLoginCount,
+		&user.LockedUntil,
 	)
 
 	if err != nil {
@@ -185,7 +195,7 @@ func (r *PgRepository) UpdateUserAccount(ctx context.Context, user *UserAccount)
 func (r *PgRepository) GetUserByVoterNIM(ctx context.Context, nim string) (*UserAccount, error) {
 	query := `
 		SELECT ua.id, ua.username, ua.password_hash, ua.role, ua.voter_id, ua.tps_id, 
-		       ua.lecturer_id, ua.staff_id, ua.is_active, ua.created_at, ua.updated_at, ua.last_login_at
+		       ua.lecturer_id, ua.staff_id, ua.is_active, ua.email_verification_pending, ua.created_at, ua.updated_at, ua.last_login_at
 		FROM user_accounts ua
 		JOIN voters v ON v.id = ua.voter_id
 		WHERE v.nim = $1 AND ua.is_active = true
@@ -205,6 +215,7 @@ func (r *PgRepository) GetUserByVoterNIM(ctx context.Context, nim string) (*User
 		&user.LecturerID,
 		&user.StaffID,
 		&user.IsActive,
+		&user.EmailVerificationPending,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 		&lastLoginAt,
@@ -0,0 +1,158 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// CreateEmailVerification stores a new verification and closes the user's
+// earlier open ones, so only the latest code or link works
+func (r *PgRepository) CreateEmailVerification(ctx context.Context, v *EmailVerification, linkTokenHash string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE email_verifications
+		SET expires_at = NOW()
+		WHERE user_id = $1 AND verified_at IS NULL AND expires_at > NOW()
+	`, v.UserID); err != nil {
+		return err
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO email_verifications (user_id, email, code_hash, link_token_hash, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at
+	`, v.UserID, v.Email, v.CodeHash, linkTokenHash, v.ExpiresAt).Scan(&v.ID, &v.CreatedAt)
+	if err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// EmailVerificationSends counts verification emails sent to a user since a
+// time and returns when the latest one was sent
+func (r *PgRepository) EmailVerificationSends(ctx context.Context, userID int64, since time.Time) (int, *time.Time, error) {
+	var (
+		count int
+		last  *time.Time
+	)
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*) FILTER (WHERE created_at >= $2), MAX(created_at)
+		FROM email_verifications
+		WHERE user_id = $1
+	`, userID, since).Scan(&count, &last)
+	return count, last, err
+}
+
+// ClaimEmailVerificationAttempt counts a code attempt against the user's
+// open verification and returns it, or ErrEmailVerificationInvalid when
+// there is none left to try
+func (r *PgRepository) ClaimEmailVerificationAttempt(ctx context.Context, userID int64, maxAttempts int) (*EmailVerification, error) {
+	query := `
+		UPDATE email_verifications
+		SET attempts = attempts + 1
+		WHERE id = (
+			SELECT id FROM email_verifications
+			WHERE user_id = $1 AND verified_at IS NULL AND expires_at > NOW()
+			ORDER BY created_at DESC
+			LIMIT 1
+		) AND attempts < $2
+		RETURNING id, user_id, email, code_hash, attempts, expires_at, verified_at, created_at
+	`
+
+	return scanEmailVerification(r.db.QueryRow(ctx, query, userID, maxAttempts))
+}
+
+// GetEmailVerificationByLinkToken finds an open verification by its link token
+func (r *PgRepository) GetEmailVerificationByLinkToken(ctx context.Context, linkTokenHash string) (*EmailVerification, error) {
+	query := `
+		SELECT id, user_id, email, code_hash, attempts, expires_at, verified_at, created_at
+		FROM email_verifications
+		WHERE link_token_hash = $1 AND verified_at IS NULL AND expires_at > NOW()
+	`
+
+	return scanEmailVerification(r.db.QueryRow(ctx, query, linkTokenHash))
+}
+
+// CompleteEmailVerification marks the verification used and the account's
+// email verified. A verification completes once.
+func (r *PgRepository) CompleteEmailVerification(ctx context.Context, v *EmailVerification) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE email_verifications SET verified_at = NOW()
+		WHERE id = $1 AND verified_at IS NULL
+	`, v.ID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrEmailVerificationInvalid
+	}
+
+	if err := markEmailVerified(ctx, tx, v.UserID, v.Email); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// MarkEmailVerified records an email verified by other means (SSO)
+func (r *PgRepository) MarkEmailVerified(ctx context.Context, userID int64, email string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := markEmailVerified(ctx, tx, userID, email); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func markEmailVerified(ctx context.Context, tx pgx.Tx, userID int64, email string) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE user_accounts
+		SET email = $2, email_verification_pending = FALSE, updated_at = NOW()
+		WHERE id = $1
+	`, userID, email)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return ErrEmailInUse
+		}
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE voters v
+		SET email = $2, email_verified_at = NOW(), updated_at = NOW()
+		FROM user_accounts ua
+		WHERE ua.id = $1 AND v.id = ua.voter_id
+	`, userID, email)
+	return err
+}
+
+func scanEmailVerification(row pgx.Row) (*EmailVerification, error) {
+	var v EmailVerification
+	err := row.Scan(&v.ID, &v.UserID, &v.Email, &v.CodeHash, &v.Attempts, &v.ExpiresAt, &v.VerifiedAt, &v.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrEmailVerificationInvalid
+		}
+		return nil, err
+	}
+	return &v, nil
+}
@@ -0,0 +1,47 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// RecordLoginFailure counts a failed password login and returns the number
+// of failures in a row. Failures before since no longer count.
+func (r *PgRepository) RecordLoginFailure(ctx context.Context, userID int64, since time.Time) (int, error) {
+	var failures int
+	err := r.db.QueryRow(ctx, `
+		UPDATE user_accounts
+		SET failed_login_count = CASE
+		        WHEN last_failed_login_at IS NULL OR last_failed_login_at < $2 THEN 1
+		        ELSE failed_login_count + 1
+		    END,
+		    last_failed_login_at = NOW()
+		WHERE id = $1
+		RETURNING failed_login_count
+	`, userID, since).Scan(&failures)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, ErrUserNotFound
+	}
+	return failures, err
+}
+
+func (r *PgRepository) LockUser(ctx context.Context, userID int64, until time.Time) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE user_accounts
+		SET locked_until = GREATEST(COALESCE(locked_until, $2), $2)
+		WHERE id = $1
+	`, userID, until)
+	return err
+}
+
+func (r *PgRepository) ClearLoginFailures(ctx context.Context, userID int64) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE user_accounts
+		SET failed_login_count = 0, last_failed_login_at = NULL, locked_until = NULL
+		WHERE id = $1
+	`, userID)
+	return err
+}
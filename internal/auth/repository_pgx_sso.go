@@ -0,0 +1,179 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// CreateSSOState stores a pending authorization request under the hash of
+// its state parameter
+func (r *PgRepository) CreateSSOState(ctx context.Context, state *SSOLoginState, stateHash string) error {
+	query := `
+		INSERT INTO sso_login_states (state_hash, nonce, code_verifier, expires_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`
+
+	return r.db.QueryRow(ctx, query, stateHash, state.Nonce, state.CodeVerifier, state.ExpiresAt).Scan(&state.ID)
+}
+
+// ClaimSSOState marks a state as used and returns it. A state can be claimed
+// once, before it expires.
+func (r *PgRepository) ClaimSSOState(ctx context.Context, stateHash string) (*SSOLoginState, error) {
+	query := `
+		UPDATE sso_login_states
+		SET state_used_at = NOW()
+		WHERE state_hash = $1 AND state_used_at IS NULL AND expires_at > NOW()
+		RETURNING id, nonce, code_verifier, expires_at
+	`
+
+	var st SSOLoginState
+	err := r.db.QueryRow(ctx, query, stateHash).Scan(&st.ID, &st.Nonce, &st.CodeVerifier, &st.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSSOStateInvalid
+		}
+		return nil, err
+	}
+	return &st, nil
+}
+
+// CompleteSSOState attaches the authenticated user and the one-time login
+// code handed to the frontend
+func (r *PgRepository) CompleteSSOState(ctx context.Context, stateID, userID int64, loginCodeHash string, codeExpiresAt time.Time) error {
+	query := `
+		UPDATE sso_login_states
+		SET user_id = $2, login_code_hash = $3, code_expires_at = $4
+		WHERE id = $1
+	`
+
+	_, err := r.db.Exec(ctx, query, stateID, userID, loginCodeHash, codeExpiresAt)
+	return err
+}
+
+// ClaimSSOLoginCode redeems a login code once and returns its user ID
+func (r *PgRepository) ClaimSSOLoginCode(ctx context.Context, loginCodeHash string) (int64, error) {
+	query := `
+		UPDATE sso_login_states
+		SET code_used_at = NOW()
+		WHERE login_code_hash = $1 AND code_used_at IS NULL AND code_expires_at > NOW()
+		RETURNING user_id
+	`
+
+	var userID int64
+	if err := r.db.QueryRow(ctx, query, loginCodeHash).Scan(&userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, ErrSSOStateInvalid
+		}
+		return 0, err
+	}
+	return userID, nil
+}
+
+// GetUserByIdentity retrieves the account linked to an IdP subject
+func (r *PgRepository) GetUserByIdentity(ctx context.Context, issuer, subject string) (*UserAccount, error) {
+	query := `
+		SELECT ua.id, ua.username, ua.email, ua.password_hash, ua.full_name, ua.role, ua.voter_id, ua.tps_id,
+		       ua.lecturer_id, ua.staff_id, ua.is_active, ua.email_verification_pending, ua.last_login_at, ua.login_count, ua.created_at, ua.updated_at
+		FROM user_identities ui
+		JOIN user_accounts ua ON ua.id = ui.user_id
+		WHERE ui.issuer = $1 AND ui.subject = $2
+	`
+
+	var user UserAccount
+	err := r.db.QueryRow(ctx, query, issuer, subject).Scan(
+		&user.ID,
+		&user.Username,
+		&user.Email,
+		&user.PasswordHash,
+		&user.FullName,
+		&user.Role,
+		&user.VoterID,
+		&user.TPSID,
+		&user.LecturerID,
+		&user.StaffID,
+		&user.IsActive,
+		&user.EmailVerificationPending,
+		&user.LastLoginAt,
+		&user.LoginCount,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// LinkUserIdentity ties an IdP subject to a user account
+func (r *PgRepository) LinkUserIdentity(ctx context.Context, userID int64, issuer, subject, email string) error {
+	query := `
+		INSERT INTO user_identities (user_id, issuer, subject, email, last_login_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), NOW())
+	`
+
+	_, err := r.db.Exec(ctx, query, userID, issuer, subject, email)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return ErrIdentityConflict
+		}
+		return err
+	}
+	return nil
+}
+
+// TouchUserIdentity records a login through an identity
+func (r *PgRepository) TouchUserIdentity(ctx context.Context, issuer, subject string) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE user_identities SET last_login_at = NOW() WHERE issuer = $1 AND subject = $2
+	`, issuer, subject)
+	return err
+}
+
+// GetDPTVoterByNumber finds a voter by NIM (students) or the NIDN/NIP stored
+// in the same column for lecturers and staff
+func (r *PgRepository) GetDPTVoterByNumber(ctx context.Context, number string) (*DPTVoter, error) {
+	query := `
+		SELECT id, nim, name, COALESCE(email, ''), COALESCE(faculty_name, ''),
+		       COALESCE(voter_type::text, 'STUDENT'), lecturer_id, staff_id
+		FROM voters
+		WHERE nim = $1
+	`
+
+	var v DPTVoter
+	err := r.db.QueryRow(ctx, query, number).Scan(
+		&v.ID,
+		&v.NIM,
+		&v.Name,
+		&v.Email,
+		&v.FacultyName,
+		&v.VoterType,
+		&v.LecturerID,
+		&v.StaffID,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrVoterNotInDPT
+		}
+		return nil, err
+	}
+	return &v, nil
+}
+
+// FillVoterFaculty sets the voter's faculty from the IdP when the DPT row
+// has none
+func (r *PgRepository) FillVoterFaculty(ctx context.Context, voterID int64, faculty string) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE voters SET faculty_name = $2, updated_at = NOW()
+		WHERE id = $1 AND COALESCE(faculty_name, '') = ''
+	`, voterID, faculty)
+	return err
+}
@@ -0,0 +1,279 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// GetUserTOTP retrieves a user's TOTP enrollment, confirmed or pending
+func (r *PgRepository) GetUserTOTP(ctx context.Context, userID int64) (*UserTOTP, error) {
+	query := `
+		SELECT user_id, secret_enc, confirmed_at, last_used_step, created_at, updated_at
+		FROM user_totp
+		WHERE user_id = $1
+	`
+
+	var t UserTOTP
+	err := r.db.QueryRow(ctx, query, userID).Scan(
+		&t.UserID,
+		&t.SecretEnc,
+		&t.ConfirmedAt,
+		&t.LastUsedStep,
+		&t.CreatedAt,
+		&t.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrTOTPNotFound
+		}
+		return nil, err
+	}
+	return &t, nil
+}
+
+// UpsertPendingTOTP stores a new, unconfirmed secret. A confirmed enrollment
+// is left untouched.
+func (r *PgRepository) UpsertPendingTOTP(ctx context.Context, userID int64, secretEnc string) error {
+	query := `
+		INSERT INTO user_totp (user_id, secret_enc)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET secret_enc = EXCLUDED.secret_enc, last_used_step = 0, updated_at = NOW()
+		WHERE user_totp.confirmed_at IS NULL
+	`
+
+	_, err := r.db.Exec(ctx, query, userID, secretEnc)
+	return err
+}
+
+// ConfirmTOTP marks the pending enrollment as confirmed and stores a fresh
+// set of recovery codes in the same transaction
+func (r *PgRepository) ConfirmTOTP(ctx context.Context, userID, step int64, recoveryCodeHashes []string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE user_totp
+		SET confirmed_at = NOW(), last_used_step = $2, updated_at = NOW()
+		WHERE user_id = $1 AND confirmed_at IS NULL
+	`, userID, step)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrTOTPNotFound
+	}
+
+	if err := replaceRecoveryCodes(ctx, tx, userID, recoveryCodeHashes); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// AdvanceTOTPStep records step as the last accepted code. It reports false
+// when an equal or later step was already used, i.e. the code is a replay.
+func (r *PgRepository) AdvanceTOTPStep(ctx context.Context, userID, step int64) (bool, error) {
+	query := `
+		UPDATE user_totp
+		SET last_used_step = $2, updated_at = NOW()
+		WHERE user_id = $1 AND confirmed_at IS NOT NULL AND last_used_step < $2
+	`
+
+	tag, err := r.db.Exec(ctx, query, userID, step)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// DeleteUserTOTP removes the enrollment, recovery codes and pending login
+// challenges of a user
+func (r *PgRepository) DeleteUserTOTP(ctx context.Context, userID int64) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	for _, q := range []string{
+		`DELETE FROM user_totp WHERE user_id = $1`,
+		`DELETE FROM user_recovery_codes WHERE user_id = $1`,
+		`DELETE FROM auth_mfa_challenges WHERE user_id = $1`,
+	} {
+		if _, err := tx.Exec(ctx, q, userID); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// ReplaceRecoveryCodes drops all recovery codes of a user and stores new ones
+func (r *PgRepository) ReplaceRecoveryCodes(ctx context.Context, userID int64, codeHashes []string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := replaceRecoveryCodes(ctx, tx, userID, codeHashes); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func replaceRecoveryCodes(ctx context.Context, tx pgx.Tx, userID int64, codeHashes []string) error {
+	if _, err := tx.Exec(ctx, `DELETE FROM user_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+	_, err := tx.Exec(ctx, `
+		INSERT INTO user_recovery_codes (user_id, code_hash)
+		SELECT $1, UNNEST($2::text[])
+	`, userID, codeHashes)
+	return err
+}
+
+// UseRecoveryCode burns an unused recovery code. It reports false when the
+// code does not exist or was already used.
+func (r *PgRepository) UseRecoveryCode(ctx context.Context, userID int64, codeHash string) (bool, error) {
+	query := `
+		UPDATE user_recovery_codes
+		SET used_at = NOW()
+		WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+	`
+
+	tag, err := r.db.Exec(ctx, query, userID, codeHash)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// CountRecoveryCodes returns the number of unused recovery codes
+func (r *PgRepository) CountRecoveryCodes(ctx context.Context, userID int64) (int, error) {
+	var n int
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*) FROM user_recovery_codes WHERE user_id = $1 AND used_at IS NULL
+	`, userID).Scan(&n)
+	return n, err
+}
+
+// CreateMFAChallenge stores a login challenge under the hash of its token
+func (r *PgRepository) CreateMFAChallenge(ctx context.Context, challenge *MFAChallenge, tokenHash string) error {
+	query := `
+		INSERT INTO auth_mfa_challenges (token_hash, user_id, purpose, expires_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`
+
+	return r.db.QueryRow(ctx, query, tokenHash, challenge.UserID, challenge.Purpose, challenge.ExpiresAt).Scan(&challenge.ID)
+}
+
+// GetMFAChallenge retrieves an unexpired, unconsumed challenge
+func (r *PgRepository) GetMFAChallenge(ctx context.Context, tokenHash string) (*MFAChallenge, error) {
+	query := `
+		SELECT id, user_id, purpose, attempts, expires_at, consumed_at
+		FROM auth_mfa_challenges
+		WHERE token_hash = $1 AND consumed_at IS NULL AND expires_at > NOW()
+	`
+
+	return scanMFAChallenge(r.db.QueryRow(ctx, query, tokenHash))
+}
+
+// ClaimMFAChallengeAttempt counts one verification attempt against an active
+// challenge. Once maxAttempts is reached the challenge stops matching.
+func (r *PgRepository) ClaimMFAChallengeAttempt(ctx context.Context, tokenHash string, maxAttempts int) (*MFAChallenge, error) {
+	query := `
+		UPDATE auth_mfa_challenges
+		SET attempts = attempts + 1
+		WHERE token_hash = $1 AND consumed_at IS NULL AND expires_at > NOW() AND attempts < $2
+		RETURNING id, user_id, purpose, attempts, expires_at, consumed_at
+	`
+
+	return scanMFAChallenge(r.db.QueryRow(ctx, query, tokenHash, maxAttempts))
+}
+
+// ConsumeMFAChallenge marks a challenge as used. It reports false when it was
+// already consumed by a concurrent request.
+func (r *PgRepository) ConsumeMFAChallenge(ctx context.Context, challengeID int64) (bool, error) {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE auth_mfa_challenges SET consumed_at = NOW()
+		WHERE id = $1 AND consumed_at IS NULL
+	`, challengeID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func scanMFAChallenge(row pgx.Row) (*MFAChallenge, error) {
+	var c MFAChallenge
+	err := row.Scan(&c.ID, &c.UserID, &c.Purpose, &c.Attempts, &c.ExpiresAt, &c.ConsumedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrMFAChallengeInvalid
+		}
+		return nil, err
+	}
+	return &c, nil
+}
+
+// CountTOTPEnrollments counts the users with confirmed 2FA
+func (r *PgRepository) CountTOTPEnrollments(ctx context.Context) (int, error) {
+	var n int
+	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM user_totp WHERE confirmed_at IS NOT NULL`).Scan(&n)
+	return n, err
+}
+
+// RekeyTOTPSecrets passes every stored secret through rekey and saves the
+// ones it changed, all in one transaction
+func (r *PgRepository) RekeyTOTPSecrets(ctx context.Context, rekey func(secretEnc string) (string, bool, error)) (int, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `SELECT user_id, secret_enc FROM user_totp ORDER BY user_id FOR UPDATE`)
+	if err != nil {
+		return 0, err
+	}
+	type secret struct {
+		userID int64
+		sealed string
+	}
+	var secrets []secret
+	for rows.Next() {
+		var s secret
+		if err := rows.Scan(&s.userID, &s.sealed); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		secrets = append(secrets, s)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	changed := 0
+	for _, s := range secrets {
+		sealed, ok, err := rekey(s.sealed)
+		if err != nil {
+			return 0, fmt.Errorf("user %d: %w", s.userID, err)
+		}
+		if !ok {
+			continue
+		}
+		if _, err := tx.Exec(ctx, `UPDATE user_totp SET secret_enc = $2, updated_at = NOW() WHERE user_id = $1`, s.userID, sealed); err != nil {
+			return 0, err
+		}
+		changed++
+	}
+	return changed, tx.Commit(ctx)
+}
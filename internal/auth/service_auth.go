@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"pemira-api/internal/shared/constants"
+	"pemira-api/internal/telemetry"
 )
 
 var (
@@ -63,14 +64,28 @@ type AuthService struct {
 	masterRepo MasterRepository
 	jwtManager *JWTManager
 	config     JWTConfig
+	mfa        MFAConfig
+	secrets    *secretBox
+
+	sso              *oidcProvider
+	ssoConfig        SSOConfig
+	passwordDisabled map[constants.Role]bool
+
+	emailConfig EmailVerificationConfig
+
+	lockout LockoutConfig
 }
 
 func NewAuthService(repo Repository, jwtManager *JWTManager, config JWTConfig) *AuthService {
-	return &AuthService{
+	s := &AuthService{
 		repo:       repo,
 		jwtManager: jwtManager,
 		config:     config,
 	}
+	// 2FA stays unavailable until SetMFAConfig provides an encryption key
+	s.SetMFAConfig(MFAConfig{})
+	s.SetEmailVerification(EmailVerificationConfig{})
+	return s
 }
 
 func (s *AuthService) SetMasterRepository(masterRepo MasterRepository) {
@@ -79,6 +94,9 @@ func (s *AuthService) SetMasterRepository(masterRepo MasterRepository) {
 
 // RegisterStudent registers a new student account and linked voter profile.
 func (s *AuthService) RegisterStudent(ctx context.Context, req RegisterStudentRequest) (*AuthUser, error) {
+	if !s.passwordLoginAllowed(constants.RoleStudent) {
+		return nil, ErrPasswordLoginDisabled
+	}
 	nim := strings.TrimSpace(req.NIM)
 	name := strings.TrimSpace(req.Name)
 	if nim == "" || name == "" || strings.TrimSpace(req.Password) == "" {
@@ -92,9 +110,9 @@ func (s *AuthService) RegisterStudent(ctx context.Context, req RegisterStudentRe
 		return nil, ErrInvalidRegistration
 	}
 
-	email := strings.TrimSpace(req.Email)
-	if email == "" {
-		email = fmt.Sprintf("%s@pemira.ac.id", nim)
+	email, err := s.registrationEmail(ctx, "STUDENT", req.Email, nim)
+	if err != nil {
+		return nil, err
 	}
 
 	passwordHash, err := HashPassword(req.Password)
@@ -136,6 +154,8 @@ func (s *AuthService) RegisterStudent(ctx context.Context, req RegisterStudentRe
 		Role:         constants.RoleStudent,
 		VoterID:      &voterID,
 		IsActive:     true,
+
+		EmailVerificationPending: s.emailConfig.Required,
 	})
 	if err != nil {
 		_ = s.repo.DeleteVoter(ctx, voterID)
@@ -150,6 +170,10 @@ func (s *AuthService) RegisterStudent(ctx context.Context, req RegisterStudentRe
 	// Auto-enroll voter to the registration election
 	_ = s.repo.EnrollVoterToElection(ctx, regElection.ID, voterID, nim, mode)
 
+	if user.EmailVerificationPending {
+		s.startEmailVerification(ctx, user)
+	}
+
 	profile := &UserProfile{
 		Name:             name,
 		FacultyName:      req.FacultyName,
@@ -164,12 +188,18 @@ func (s *AuthService) RegisterStudent(ctx context.Context, req RegisterStudentRe
 		VoterID:  user.VoterID,
 		Profile:  profile,
 		// voting_mode echoed for client convenience
+
+		EmailVerificationPending: user.EmailVerificationPending,
 	}, nil
 }
 
 // RegisterLecturerStaff registers a lecturer or staff account.
 func (s *AuthService) RegisterLecturerStaff(ctx context.Context, req RegisterLecturerStaffRequest) (*AuthUser, error) {
 	roleType := strings.ToUpper(strings.TrimSpace(req.Type))
+	if (roleType == "LECTURER" && !s.passwordLoginAllowed(constants.RoleLecturer)) ||
+		(roleType == "STAFF" && !s.passwordLoginAllowed(constants.RoleStaff)) {
+		return nil, ErrPasswordLoginDisabled
+	}
 	if strings.TrimSpace(req.Name) == "" || strings.TrimSpace(req.Password) == "" {
 		return nil, ErrInvalidRegistration
 	}
@@ -177,8 +207,6 @@ func (s *AuthService) RegisterLecturerStaff(ctx context.Context, req RegisterLec
 		return nil, ErrInvalidRegistration
 	}
 
-	email := strings.TrimSpace(req.Email)
-
 	passwordHash, err := HashPassword(req.Password)
 	if err != nil {
 		return nil, err
@@ -202,8 +230,9 @@ func (s *AuthService) RegisterLecturerStaff(ctx context.Context, req RegisterLec
 		if nidn == "" {
 			return nil, ErrInvalidRegistration
 		}
-		if email == "" {
-			email = fmt.Sprintf("%s@pemira.ac.id", nidn)
+		email, err := s.registrationEmail(ctx, "LECTURER", req.Email, nidn)
+		if err != nil {
+			return nil, err
 		}
 
 		// Lookup unit and position IDs from master tables
@@ -260,6 +289,8 @@ func (s *AuthService) RegisterLecturerStaff(ctx context.Context, req RegisterLec
 			LecturerID:   &lecturerID,
 			VoterID:      &voterID,
 			IsActive:     true,
+
+			EmailVerificationPending: s.emailConfig.Required,
 		})
 		if err != nil {
 			_ = s.repo.DeleteLecturer(ctx, lecturerID)
@@ -274,6 +305,10 @@ func (s *AuthService) RegisterLecturerStaff(ctx context.Context, req RegisterLec
 		// Auto-enroll voter to the registration election
 		_ = s.repo.EnrollVoterToElection(ctx, regElection.ID, voterID, nidn, mode)
 
+		if user.EmailVerificationPending {
+			s.startEmailVerification(ctx, user)
+		}
+
 		profile := &UserProfile{
 			Name:           req.Name,
 			FacultyName:    req.FacultyName,
@@ -288,6 +323,8 @@ func (s *AuthService) RegisterLecturerStaff(ctx context.Context, req RegisterLec
 			VoterID:    user.VoterID,
 			LecturerID: user.LecturerID,
 			Profile:    profile,
+
+			EmailVerificationPending: user.EmailVerificationPending,
 		}, nil
 
 	case "STAFF":
@@ -295,8 +332,9 @@ func (s *AuthService) RegisterLecturerStaff(ctx context.Context, req RegisterLec
 		if nip == "" {
 			return nil, ErrInvalidRegistration
 		}
-		if email == "" {
-			email = fmt.Sprintf("%s@pemira.ac.id", nip)
+		email, err := s.registrationEmail(ctx, "STAFF", req.Email, nip)
+		if err != nil {
+			return nil, err
 		}
 
 		// Lookup unit and position IDs from master tables
@@ -352,6 +390,8 @@ func (s *AuthService) RegisterLecturerStaff(ctx context.Context, req RegisterLec
 			StaffID:      &staffID,
 			VoterID:      &voterID,
 			IsActive:     true,
+
+			EmailVerificationPending: s.emailConfig.Required,
 		})
 		if err != nil {
 			_ = s.repo.DeleteStaff(ctx, staffID)
@@ -366,6 +406,10 @@ func (s *AuthService) RegisterLecturerStaff(ctx context.Context, req RegisterLec
 		// Auto-enroll voter to the registration election
 		_ = s.repo.EnrollVoterToElection(ctx, regElection.ID, voterID, nip, mode)
 
+		if user.EmailVerificationPending {
+			s.startEmailVerification(ctx, user)
+		}
+
 		profile := &UserProfile{
 			Name:     req.Name,
 			UnitName: req.UnitName,
@@ -379,6 +423,8 @@ func (s *AuthService) RegisterLecturerStaff(ctx context.Context, req RegisterLec
 			VoterID:  user.VoterID,
 			StaffID:  user.StaffID,
 			Profile:  profile,
+
+			EmailVerificationPending: user.EmailVerificationPending,
 		}, nil
 
 	default:
@@ -386,8 +432,24 @@ func (s *AuthService) RegisterLecturerStaff(ctx context.Context, req RegisterLec
 	}
 }
 
+// recordLogin counts a login attempt through method; a 2FA challenge is
+// neither a success nor a failure yet.
+func recordLogin(method string, err error) {
+	var mfa *MFARequiredError
+	switch {
+	case err == nil:
+		telemetry.LoginAttempt(method, telemetry.LoginSuccess)
+	case errors.As(err, &mfa):
+		telemetry.LoginAttempt(method, telemetry.LoginMFARequired)
+	default:
+		telemetry.LoginAttempt(method, telemetry.LoginFailure)
+	}
+}
+
 // Login authenticates a user and returns tokens
-func (s *AuthService) Login(ctx context.Context, req LoginRequest, userAgent, ipAddress string) (*LoginResponse, error) {
+func (s *AuthService) Login(ctx context.Context, req LoginRequest, userAgent, ipAddress string) (resp *LoginResponse, err error) {
+	defer func() { recordLogin("password", err) }()
+
 	// Get user by username
 	user, err := s.repo.GetUserByUsername(ctx, req.Username)
 	if err != nil {
@@ -401,12 +463,34 @@ func (s *AuthService) Login(ctx context.Context, req LoginRequest, userAgent, ip
 	if !user.IsActive {
 		return nil, ErrInactiveUser
 	}
+	if err := s.checkLockout(user); err != nil {
+		return nil, err
+	}
 
 	// Verify password
 	if err := VerifyPassword(user.PasswordHash, req.Password); err != nil {
-		return nil, ErrInvalidCredentials
+		return nil, s.loginFailed(ctx, user)
+	}
+	s.loginSucceeded(ctx, user)
+
+	if !s.passwordLoginAllowed(user.Role) {
+		return nil, ErrPasswordLoginDisabled
+	}
+	if user.EmailVerificationPending {
+		return nil, ErrEmailNotVerified
+	}
+
+	// Enrolled users, and users whose role requires 2FA, get a challenge
+	// instead of tokens
+	if err := s.challengeSecondFactor(ctx, user); err != nil {
+		return nil, err
 	}
 
+	return s.issueLogin(ctx, user, userAgent, ipAddress)
+}
+
+// issueLogin creates a session for an authenticated user and returns its tokens
+func (s *AuthService) issueLogin(ctx context.Context, user *UserAccount, userAgent, ipAddress string) (*LoginResponse, error) {
 	// Generate access token
 	accessToken, err := s.jwtManager.GenerateAccessToken(user)
 	if err != nil {
@@ -621,6 +705,9 @@ func (s *AuthService) ResetPasswordByIdentifier(ctx context.Context, req ResetPa
 	if err != nil {
 		return err // Will return ErrVoterNotRegistered if not found
 	}
+	if !s.passwordLoginAllowed(user.Role) {
+		return ErrPasswordLoginDisabled
+	}
 
 	// Hash new password
 	hashedPassword, err := HashPassword(req.NewPassword)
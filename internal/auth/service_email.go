@@ -0,0 +1,276 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"net/url"
+	"strings"
+	"time"
+
+	"pemira-api/internal/mail"
+)
+
+var (
+	ErrEmailRequired           = errors.New("email is required")
+	ErrEmailDomainNotAllowed   = errors.New("email domain not allowed for this voter type")
+	ErrEmailNotOwned           = errors.New("email does not belong to the identifier")
+	ErrEmailNotVerified        = errors.New("email not verified")
+	ErrInvalidVerificationCode = errors.New("invalid email verification code")
+	ErrVerificationResendLimit = errors.New("too many verification emails")
+	ErrEmailAlreadyVerified    = errors.New("email already verified")
+)
+
+const (
+	emailCodeTTL         = 15 * time.Minute
+	maxEmailCodeAttempts = 5
+	emailResendCooldown  = time.Minute
+	maxEmailSendsPerHour = 5
+	// placeholderEmailDomain is where addresses were once made up for
+	// registrations without an email; nobody can receive mail there
+	placeholderEmailDomain = "pemira.ac.id"
+)
+
+// EmailVerificationConfig controls email ownership checks on
+// self-registration.
+type EmailVerificationConfig struct {
+	// Required keeps new accounts pending until their email is verified.
+	Required bool
+	// AllowedDomains per voter type (STUDENT, LECTURER, STAFF). A domain
+	// also admits its subdomains. An empty list admits any domain only while
+	// verification is not required.
+	AllowedDomains map[string][]string
+	// RosterEmail returns the academic roster's email of an identifier, ""
+	// when the roster has none. Registration emails must match it; without
+	// one their local part must be the identifier.
+	RosterEmail func(ctx context.Context, voterType, identifier string) (string, error)
+	// LinkURL is the frontend page that receives ?token= from the email.
+	// Without it the email carries only the code.
+	LinkURL string
+	Sender  mail.Sender
+}
+
+// SetEmailVerification configures email verification. A nil Sender logs
+// messages instead of sending them.
+func (s *AuthService) SetEmailVerification(cfg EmailVerificationConfig) {
+	if cfg.Sender == nil {
+		cfg.Sender = mail.LogSender{}
+	}
+	domains := make(map[string][]string, len(cfg.AllowedDomains))
+	for voterType, list := range cfg.AllowedDomains {
+		for _, d := range list {
+			if d = strings.ToLower(strings.Trim(strings.TrimSpace(d), "@.")); d != "" {
+				domains[strings.ToUpper(voterType)] = append(domains[strings.ToUpper(voterType)], d)
+			}
+		}
+	}
+	cfg.AllowedDomains = domains
+	s.emailConfig = cfg
+}
+
+// registrationEmail validates the email given at registration or resend:
+// it is required, in an allowed domain and must belong to identifier (see
+// emailOwnedBy).
+func (s *AuthService) registrationEmail(ctx context.Context, voterType, raw, identifier string) (string, error) {
+	email := strings.TrimSpace(raw)
+	if email == "" {
+		return "", ErrEmailRequired
+	}
+
+	at := strings.LastIndex(email, "@")
+	if at <= 0 || at == len(email)-1 || strings.ContainsAny(email, " \t\r\n<>") {
+		return "", ErrInvalidRegistration
+	}
+	domain := strings.ToLower(email[at+1:])
+	if inDomain(domain, placeholderEmailDomain) || !s.emailDomainAllowed(voterType, domain) {
+		return "", ErrEmailDomainNotAllowed
+	}
+
+	owned, err := s.emailOwnedBy(ctx, voterType, identifier, email)
+	if err != nil {
+		return "", err
+	}
+	if !owned {
+		return "", ErrEmailNotOwned
+	}
+	return email, nil
+}
+
+func (s *AuthService) emailDomainAllowed(voterType, domain string) bool {
+	allowed := s.emailConfig.AllowedDomains[strings.ToUpper(voterType)]
+	if len(allowed) == 0 {
+		return !s.emailConfig.Required
+	}
+	for _, d := range allowed {
+		if inDomain(domain, d) {
+			return true
+		}
+	}
+	return false
+}
+
+// inDomain reports whether domain is d or one of its subdomains
+func inDomain(domain, d string) bool {
+	return domain == d || strings.HasSuffix(domain, "."+d)
+}
+
+// emailOwnedBy reports whether email is the roster's email of identifier or,
+// when the roster has none, whether its local part is the identifier. Either
+// way a verified email proves control of the identifier, not just of some
+// address in an allowed domain.
+func (s *AuthService) emailOwnedBy(ctx context.Context, voterType, identifier, email string) (bool, error) {
+	if s.emailConfig.RosterEmail != nil {
+		rosterEmail, err := s.emailConfig.RosterEmail(ctx, strings.ToUpper(voterType), identifier)
+		if err != nil {
+			return false, err
+		}
+		if rosterEmail = strings.TrimSpace(rosterEmail); rosterEmail != "" {
+			return strings.EqualFold(email, rosterEmail), nil
+		}
+	}
+	local := email[:strings.LastIndex(email, "@")]
+	return strings.EqualFold(local, identifier), nil
+}
+
+// startEmailVerification sends the first verification email after
+// registration. A failed send is logged only: the account exists and the
+// voter can ask for another email.
+func (s *AuthService) startEmailVerification(ctx context.Context, user *UserAccount) {
+	if err := s.sendEmailVerification(ctx, user, user.Email); err != nil {
+		slog.Error("failed to send verification email", "user_id", user.ID, "error", err)
+	}
+}
+
+// ResendEmailVerification sends a new code to a pending account, optionally
+// to a corrected address. The password is required so nobody else can
+// redirect the verification.
+func (s *AuthService) ResendEmailVerification(ctx context.Context, req ResendEmailVerificationRequest) error {
+	user, err := s.repo.GetUserByUsername(ctx, strings.TrimSpace(req.Username))
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return ErrInvalidCredentials
+		}
+		return err
+	}
+	if err := s.checkLockout(user); err != nil {
+		return err
+	}
+	if err := VerifyPassword(user.PasswordHash, req.Password); err != nil {
+		return s.loginFailed(ctx, user)
+	}
+	s.loginSucceeded(ctx, user)
+	if !user.EmailVerificationPending {
+		return ErrEmailAlreadyVerified
+	}
+
+	email := user.Email
+	if strings.TrimSpace(req.Email) != "" {
+		if email, err = s.registrationEmail(ctx, string(user.Role), req.Email, user.Username); err != nil {
+			return err
+		}
+	}
+	return s.sendEmailVerification(ctx, user, email)
+}
+
+// VerifyEmailCode completes verification with the 6-digit code
+func (s *AuthService) VerifyEmailCode(ctx context.Context, username, code string) error {
+	user, err := s.repo.GetUserByUsername(ctx, strings.TrimSpace(username))
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return ErrEmailVerificationInvalid
+		}
+		return err
+	}
+	if !user.EmailVerificationPending {
+		return ErrEmailAlreadyVerified
+	}
+
+	v, err := s.repo.ClaimEmailVerificationAttempt(ctx, user.ID, maxEmailCodeAttempts)
+	if err != nil {
+		return err
+	}
+	got := emailCodeHash(user.ID, strings.TrimSpace(code))
+	if subtle.ConstantTimeCompare([]byte(got), []byte(v.CodeHash)) != 1 {
+		return ErrInvalidVerificationCode
+	}
+	return s.repo.CompleteEmailVerification(ctx, v)
+}
+
+// VerifyEmailLink completes verification with the token from the email link
+func (s *AuthService) VerifyEmailLink(ctx context.Context, token string) error {
+	v, err := s.repo.GetEmailVerificationByLinkToken(ctx, sha256Hex(strings.TrimSpace(token)))
+	if err != nil {
+		return err
+	}
+	return s.repo.CompleteEmailVerification(ctx, v)
+}
+
+func (s *AuthService) sendEmailVerification(ctx context.Context, user *UserAccount, email string) error {
+	sends, last, err := s.repo.EmailVerificationSends(ctx, user.ID, time.Now().Add(-time.Hour))
+	if err != nil {
+		return err
+	}
+	if sends >= maxEmailSendsPerHour || (last != nil && time.Since(*last) < emailResendCooldown) {
+		return ErrVerificationResendLimit
+	}
+
+	code, err := generateEmailCode()
+	if err != nil {
+		return err
+	}
+	token, err := randomURLToken(32)
+	if err != nil {
+		return err
+	}
+
+	v := &EmailVerification{
+		UserID:    user.ID,
+		Email:     email,
+		CodeHash:  emailCodeHash(user.ID, code),
+		ExpiresAt: time.Now().Add(emailCodeTTL),
+	}
+	if err := s.repo.CreateEmailVerification(ctx, v, sha256Hex(token)); err != nil {
+		return err
+	}
+
+	return s.emailConfig.Sender.Send(ctx, mail.Message{
+		To:      email,
+		Subject: "Kode verifikasi email PEMIRA",
+		Body:    s.verificationEmailBody(user, code, token),
+	})
+}
+
+func (s *AuthService) verificationEmailBody(user *UserAccount, code, token string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Halo %s,\n\n", user.FullName)
+	fmt.Fprintf(&b, "Kode verifikasi email akun PEMIRA %s: %s\n", user.Username, code)
+	fmt.Fprintf(&b, "Kode berlaku %d menit.\n", int(emailCodeTTL.Minutes()))
+	if s.emailConfig.LinkURL != "" {
+		if link, err := url.Parse(s.emailConfig.LinkURL); err == nil {
+			q := link.Query()
+			q.Set("token", token)
+			link.RawQuery = q.Encode()
+			fmt.Fprintf(&b, "\nAtau buka tautan berikut:\n%s\n", link.String())
+		}
+	}
+	b.WriteString("\nAbaikan email ini jika Anda tidak mendaftar di PEMIRA.\n")
+	return b.String()
+}
+
+// generateEmailCode returns a uniformly random 6-digit code
+func generateEmailCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
+
+// emailCodeHash binds a code to its user so equal codes hash differently
+func emailCodeHash(userID int64, code string) string {
+	return sha256Hex(fmt.Sprintf("%d:%s", userID, code))
+}
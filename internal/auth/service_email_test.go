@@ -0,0 +1,364 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"pemira-api/internal/mail"
+)
+
+// emailRepo adds registration and email verification storage to ssoRepo.
+type emailRepo struct {
+	*ssoRepo
+	verifications []*storedVerification
+}
+
+type storedVerification struct {
+	v         EmailVerification
+	tokenHash string
+}
+
+func newEmailRepo() *emailRepo {
+	return &emailRepo{ssoRepo: newSSORepo()}
+}
+
+func (r *emailRepo) CreateVoter(ctx context.Context, v VoterRegistration) (int64, error) {
+	id := int64(len(r.voters) + 100)
+	r.voters[v.NIM] = &DPTVoter{ID: id, NIM: v.NIM, Name: v.Name, Email: v.Email, VoterType: v.VoterType}
+	return id, nil
+}
+
+func (r *emailRepo) FindOrCreateRegistrationElection(ctx context.Context) (*RegistrationElection, error) {
+	return &RegistrationElection{ID: 1, OnlineEnabled: true, TPSEnabled: true}, nil
+}
+
+func (r *emailRepo) EnsureVoterStatus(ctx context.Context, electionID, voterID int64, mode string, online, tps bool) error {
+	return nil
+}
+
+func (r *emailRepo) EnrollVoterToElection(ctx context.Context, electionID, voterID int64, nim, mode string) error {
+	return nil
+}
+
+func (r *emailRepo) CreateEmailVerification(ctx context.Context, v *EmailVerification, tokenHash string) error {
+	now := time.Now()
+	for _, sv := range r.verifications {
+		if sv.v.UserID == v.UserID && sv.v.VerifiedAt == nil && sv.v.ExpiresAt.After(now) {
+			sv.v.ExpiresAt = now
+		}
+	}
+	v.ID = int64(len(r.verifications) + 1)
+	v.CreatedAt = now
+	r.verifications = append(r.verifications, &storedVerification{v: *v, tokenHash: tokenHash})
+	return nil
+}
+
+func (r *emailRepo) EmailVerificationSends(ctx context.Context, userID int64, since time.Time) (int, *time.Time, error) {
+	var (
+		count int
+		last  *time.Time
+	)
+	for _, sv := range r.verifications {
+		if sv.v.UserID != userID {
+			continue
+		}
+		if !sv.v.CreatedAt.Before(since) {
+			count++
+		}
+		created := sv.v.CreatedAt
+		last = &created
+	}
+	return count, last, nil
+}
+
+func (r *emailRepo) open(match func(*storedVerification) bool) *storedVerification {
+	for i := len(r.verifications) - 1; i >= 0; i-- {
+		sv := r.verifications[i]
+		if match(sv) && sv.v.VerifiedAt == nil && sv.v.ExpiresAt.After(time.Now()) {
+			return sv
+		}
+	}
+	return nil
+}
+
+func (r *emailRepo) ClaimEmailVerificationAttempt(ctx context.Context, userID int64, maxAttempts int) (*EmailVerification, error) {
+	sv := r.open(func(sv *storedVerification) bool { return sv.v.UserID == userID })
+	if sv == nil || sv.v.Attempts >= maxAttempts {
+		return nil, ErrEmailVerificationInvalid
+	}
+	sv.v.Attempts++
+	v := sv.v
+	return &v, nil
+}
+
+func (r *emailRepo) GetEmailVerificationByLinkToken(ctx context.Context, tokenHash string) (*EmailVerification, error) {
+	sv := r.open(func(sv *storedVerification) bool { return sv.tokenHash == tokenHash })
+	if sv == nil {
+		return nil, ErrEmailVerificationInvalid
+	}
+	v := sv.v
+	return &v, nil
+}
+
+func (r *emailRepo) CompleteEmailVerification(ctx context.Context, v *EmailVerification) error {
+	for _, sv := range r.verifications {
+		if sv.v.ID == v.ID {
+			if sv.v.VerifiedAt != nil {
+				return ErrEmailVerificationInvalid
+			}
+			now := time.Now()
+			sv.v.VerifiedAt = &now
+			return r.MarkEmailVerified(ctx, v.UserID, v.Email)
+		}
+	}
+	return ErrEmailVerificationInvalid
+}
+
+// outbox records sent messages
+type outbox struct{ sent []mail.Message }
+
+func (o *outbox) Send(ctx context.Context, msg mail.Message) error {
+	o.sent = append(o.sent, msg)
+	return nil
+}
+
+var (
+	codePattern  = regexp.MustCompile(`: (\d{6})\n`)
+	tokenPattern = regexp.MustCompile(`token=([A-Za-z0-9_-]+)`)
+)
+
+func (o *outbox) last(t *testing.T) (to, code, token string) {
+	t.Helper()
+	if len(o.sent) == 0 {
+		t.Fatal("no email sent")
+	}
+	msg := o.sent[len(o.sent)-1]
+	m := codePattern.FindStringSubmatch(msg.Body)
+	if m == nil {
+		t.Fatalf("no code in email:\n%s", msg.Body)
+	}
+	if tm := tokenPattern.FindStringSubmatch(msg.Body); tm != nil {
+		token = tm[1]
+	}
+	return msg.To, m[1], token
+}
+
+func newEmailService(t *testing.T, required bool) (*AuthService, *emailRepo, *outbox) {
+	t.Helper()
+	repo := newEmailRepo()
+	box := &outbox{}
+	cfg := JWTConfig{Secret: "test-secret", AccessTokenTTL: time.Hour, RefreshTokenTTL: time.Hour}
+	svc := NewAuthService(repo, NewJWTManager(cfg), cfg)
+	svc.SetEmailVerification(EmailVerificationConfig{
+		Required:       required,
+		AllowedDomains: map[string][]string{"student": {"@Kampus.ac.id"}},
+		RosterEmail:    rosterEmails{"STUDENT/2021003": "budi@kampus.ac.id"}.lookup,
+		LinkURL:        "https://pemira.example.test/verify-email",
+		Sender:         box,
+	})
+	return svc, repo, box
+}
+
+// rosterEmails maps "TYPE/identifier" to the roster's email
+type rosterEmails map[string]string
+
+func (r rosterEmails) lookup(ctx context.Context, voterType, identifier string) (string, error) {
+	return r[voterType+"/"+identifier], nil
+}
+
+func studentRequest(email string) RegisterStudentRequest {
+	return RegisterStudentRequest{NIM: "2021001", Name: "Ani", Email: email, Semester: "5", Password: "secret123"}
+}
+
+func TestRegisterStudent_EmailVerification(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, box := newEmailService(t, true)
+
+	if _, err := svc.RegisterStudent(ctx, studentRequest("")); !errors.Is(err, ErrEmailRequired) {
+		t.Fatalf("missing email: err = %v", err)
+	}
+	if _, err := svc.RegisterStudent(ctx, studentRequest("2021001@gmail.com")); !errors.Is(err, ErrEmailDomainNotAllowed) {
+		t.Fatalf("foreign domain: err = %v", err)
+	}
+
+	user, err := svc.RegisterStudent(ctx, studentRequest("2021001@student.kampus.ac.id"))
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	if !user.EmailVerificationPending {
+		t.Fatal("new account is not pending")
+	}
+	to, code, _ := box.last(t)
+	if to != "2021001@student.kampus.ac.id" {
+		t.Fatalf("email sent to %q", to)
+	}
+
+	login := LoginRequest{Username: "2021001", Password: "secret123"}
+	if _, err := svc.Login(ctx, login, "", ""); !errors.Is(err, ErrEmailNotVerified) {
+		t.Fatalf("login while pending: err = %v", err)
+	}
+
+	wrong := "000000"
+	if code == wrong {
+		wrong = "111111"
+	}
+	if err := svc.VerifyEmailCode(ctx, "2021001", wrong); !errors.Is(err, ErrInvalidVerificationCode) {
+		t.Fatalf("wrong code: err = %v", err)
+	}
+	if err := svc.VerifyEmailCode(ctx, "2021001", code); err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if err := svc.VerifyEmailCode(ctx, "2021001", code); !errors.Is(err, ErrEmailAlreadyVerified) {
+		t.Fatalf("second verify: err = %v", err)
+	}
+	if _, err := svc.Login(ctx, login, "", ""); err != nil {
+		t.Fatalf("login after verification: %v", err)
+	}
+	if got := repo.users[user.ID].Email; got != "2021001@student.kampus.ac.id" {
+		t.Errorf("account email = %q", got)
+	}
+}
+
+func TestVerifyEmailCode_AttemptLimit(t *testing.T) {
+	ctx := context.Background()
+	svc, _, box := newEmailService(t, true)
+	if _, err := svc.RegisterStudent(ctx, studentRequest("2021001@kampus.ac.id")); err != nil {
+		t.Fatal(err)
+	}
+	_, code, _ := box.last(t)
+
+	wrong := "000000"
+	if code == wrong {
+		wrong = "111111"
+	}
+	for i := 0; i < maxEmailCodeAttempts; i++ {
+		if err := svc.VerifyEmailCode(ctx, "2021001", wrong); !errors.Is(err, ErrInvalidVerificationCode) {
+			t.Fatalf("attempt %d: err = %v", i+1, err)
+		}
+	}
+	if err := svc.VerifyEmailCode(ctx, "2021001", code); !errors.Is(err, ErrEmailVerificationInvalid) {
+		t.Fatalf("correct code after the limit: err = %v", err)
+	}
+}
+
+func TestVerifyEmailLink(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, box := newEmailService(t, true)
+	user, err := svc.RegisterStudent(ctx, studentRequest("2021001@kampus.ac.id"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, token := box.last(t)
+	if token == "" {
+		t.Fatal("email has no verification link")
+	}
+
+	if err := svc.VerifyEmailLink(ctx, token); err != nil {
+		t.Fatalf("verify link: %v", err)
+	}
+	if repo.users[user.ID].EmailVerificationPending {
+		t.Fatal("account still pending")
+	}
+	if err := svc.VerifyEmailLink(ctx, token); !errors.Is(err, ErrEmailVerificationInvalid) {
+		t.Fatalf("reused link: err = %v", err)
+	}
+}
+
+func TestResendEmailVerification(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, box := newEmailService(t, true)
+	user, err := svc.RegisterStudent(ctx, studentRequest("2021001@kampus.ac.id"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, firstCode, _ := box.last(t)
+
+	req := ResendEmailVerificationRequest{Username: "2021001", Password: "secret123", Email: "2021001@student.kampus.ac.id"}
+	if err := svc.ResendEmailVerification(ctx, req); !errors.Is(err, ErrVerificationResendLimit) {
+		t.Fatalf("resend within cooldown: err = %v", err)
+	}
+
+	// Move the first send out of the cooldown window
+	repo.verifications[0].v.CreatedAt = time.Now().Add(-2 * emailResendCooldown)
+
+	bad := req
+	bad.Password = "wrong"
+	if err := svc.ResendEmailVerification(ctx, bad); !errors.Is(err, ErrInvalidCredentials) {
+		t.Fatalf("wrong password: err = %v", err)
+	}
+	if err := svc.ResendEmailVerification(ctx, req); err != nil {
+		t.Fatalf("resend: %v", err)
+	}
+	to, code, _ := box.last(t)
+	if to != "2021001@student.kampus.ac.id" {
+		t.Fatalf("resent to %q", to)
+	}
+
+	// The earlier code no longer works
+	if firstCode != code {
+		if err := svc.VerifyEmailCode(ctx, "2021001", firstCode); !errors.Is(err, ErrInvalidVerificationCode) {
+			t.Fatalf("superseded code: err = %v", err)
+		}
+	}
+	if err := svc.VerifyEmailCode(ctx, "2021001", code); err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if got := repo.users[user.ID].Email; got != "2021001@student.kampus.ac.id" {
+		t.Errorf("account email = %q", got)
+	}
+}
+
+func TestRegisterStudent_VerificationOff(t *testing.T) {
+	ctx := context.Background()
+	svc, _, box := newEmailService(t, false)
+	if _, err := svc.RegisterStudent(ctx, studentRequest("")); !errors.Is(err, ErrEmailRequired) {
+		t.Fatalf("missing email: err = %v", err)
+	}
+	user, err := svc.RegisterStudent(ctx, studentRequest("2021001@kampus.ac.id"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.EmailVerificationPending || len(box.sent) != 0 {
+		t.Fatal("verification started while disabled")
+	}
+}
+
+func TestRegistrationEmail_Ownership(t *testing.T) {
+	ctx := context.Background()
+	svc, _, _ := newEmailService(t, true)
+
+	for _, tt := range []struct {
+		voterType, email, identifier string
+		want                         error
+	}{
+		{"STUDENT", "2021001@kampus.ac.id", "2021001", nil},
+		{"STUDENT", "2021001@ft.kampus.ac.id", "2021001", nil},
+		// someone else's NIM
+		{"STUDENT", "2021002@kampus.ac.id", "2021001", ErrEmailNotOwned},
+		{"STUDENT", "ani@kampus.ac.id", "2021001", ErrEmailNotOwned},
+		// the roster's email wins over the identifier
+		{"STUDENT", "Budi@Kampus.ac.id", "2021003", nil},
+		{"STUDENT", "2021003@kampus.ac.id", "2021003", ErrEmailNotOwned},
+		// the address once made up for empty emails
+		{"STUDENT", "2021001@pemira.ac.id", "2021001", ErrEmailDomainNotAllowed},
+		// no allowlist for lecturers: nothing is allowed while required
+		{"LECTURER", "0011223344@kampus.ac.id", "0011223344", ErrEmailDomainNotAllowed},
+	} {
+		_, err := svc.registrationEmail(ctx, tt.voterType, tt.email, tt.identifier)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("%s %s for %s: err = %v, want %v", tt.voterType, tt.email, tt.identifier, err, tt.want)
+		}
+	}
+
+	// the placeholder domain stays refused when allowlisted by mistake
+	svc.SetEmailVerification(EmailVerificationConfig{
+		Required:       true,
+		AllowedDomains: map[string][]string{"STUDENT": {"pemira.ac.id"}},
+	})
+	if _, err := svc.registrationEmail(ctx, "STUDENT", "2021001@pemira.ac.id", "2021001"); !errors.Is(err, ErrEmailDomainNotAllowed) {
+		t.Errorf("allowlisted placeholder domain: err = %v", err)
+	}
+}
@@ -0,0 +1,115 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+)
+
+var ErrAccountLocked = errors.New("account temporarily locked")
+
+// LockoutConfig controls progressive lockout after failed password logins.
+// Once Threshold failures happen in a row, each further failure locks the
+// account for BaseDuration, doubling per failure up to MaxDuration. Failures
+// older than Window are forgotten. A zero Threshold disables lockout.
+type LockoutConfig struct {
+	Threshold    int
+	BaseDuration time.Duration
+	MaxDuration  time.Duration
+	Window       time.Duration
+}
+
+// AccountLockedError is returned by password checks while the account is
+// locked. It matches ErrAccountLocked.
+type AccountLockedError struct {
+	Until time.Time
+}
+
+func (e *AccountLockedError) Error() string {
+	return ErrAccountLocked.Error()
+}
+
+func (e *AccountLockedError) Unwrap() error {
+	return ErrAccountLocked
+}
+
+// RetryAfter is how long until the lock lifts
+func (e *AccountLockedError) RetryAfter() time.Duration {
+	return time.Until(e.Until)
+}
+
+// SetLockout enables account lockout, filling in defaults
+func (s *AuthService) SetLockout(cfg LockoutConfig) {
+	if cfg.BaseDuration <= 0 {
+		cfg.BaseDuration = time.Minute
+	}
+	if cfg.MaxDuration < cfg.BaseDuration {
+		cfg.MaxDuration = time.Hour
+		if cfg.MaxDuration < cfg.BaseDuration {
+			cfg.MaxDuration = cfg.BaseDuration
+		}
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = 24 * time.Hour
+	}
+	s.lockout = cfg
+}
+
+// lockoutDuration is how long the account locks after its nth failure in a row
+func (c LockoutConfig) lockoutDuration(failures int) time.Duration {
+	if c.Threshold <= 0 || failures < c.Threshold {
+		return 0
+	}
+	d := c.BaseDuration
+	for i := c.Threshold; i < failures; i++ {
+		d *= 2
+		if d >= c.MaxDuration {
+			return c.MaxDuration
+		}
+	}
+	return d
+}
+
+// checkLockout refuses password checks for a locked account
+func (s *AuthService) checkLockout(user *UserAccount) error {
+	if s.lockout.Threshold <= 0 || user.LockedUntil == nil || !time.Now().Before(*user.LockedUntil) {
+		return nil
+	}
+	return &AccountLockedError{Until: *user.LockedUntil}
+}
+
+// loginFailed records a wrong password and returns the error for the
+// caller: ErrInvalidCredentials, or an *AccountLockedError when this
+// failure locked the account.
+func (s *AuthService) loginFailed(ctx context.Context, user *UserAccount) error {
+	if s.lockout.Threshold <= 0 {
+		return ErrInvalidCredentials
+	}
+	failures, err := s.repo.RecordLoginFailure(ctx, user.ID, time.Now().Add(-s.lockout.Window))
+	if err != nil {
+		slog.Error("failed to record login failure", "user_id", user.ID, "error", err)
+		return ErrInvalidCredentials
+	}
+	d := s.lockout.lockoutDuration(failures)
+	if d == 0 {
+		return ErrInvalidCredentials
+	}
+	until := time.Now().Add(d)
+	if err := s.repo.LockUser(ctx, user.ID, until); err != nil {
+		slog.Error("failed to lock account", "user_id", user.ID, "error", err)
+		return ErrInvalidCredentials
+	}
+	slog.Warn("account locked after failed logins", "user_id", user.ID, "failures", failures, "until", until)
+	return &AccountLockedError{Until: until}
+}
+
+// loginSucceeded resets the failure counter after a correct password
+func (s *AuthService) loginSucceeded(ctx context.Context, user *UserAccount) {
+	if user.FailedLoginCount == 0 && user.LockedUntil == nil {
+		return
+	}
+	if err := s.repo.ClearLoginFailures(ctx, user.ID); err != nil {
+		slog.Error("failed to clear login failures", "user_id", user.ID, "error", err)
+	}
+}
@@ -0,0 +1,155 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"pemira-api/internal/shared/constants"
+)
+
+// lockoutRepo adds failed login tracking to ssoRepo.
+type lockoutRepo struct {
+	*ssoRepo
+	lastFailure map[int64]time.Time
+}
+
+func (r *lockoutRepo) RecordLoginFailure(ctx context.Context, userID int64, since time.Time) (int, error) {
+	u, ok := r.users[userID]
+	if !ok {
+		return 0, ErrUserNotFound
+	}
+	if last, ok := r.lastFailure[userID]; !ok || last.Before(since) {
+		u.FailedLoginCount = 0
+	}
+	u.FailedLoginCount++
+	r.lastFailure[userID] = time.Now()
+	return u.FailedLoginCount, nil
+}
+
+func (r *lockoutRepo) LockUser(ctx context.Context, userID int64, until time.Time) error {
+	u := r.users[userID]
+	if u.LockedUntil == nil || until.After(*u.LockedUntil) {
+		u.LockedUntil = &until
+	}
+	return nil
+}
+
+func (r *lockoutRepo) ClearLoginFailures(ctx context.Context, userID int64) error {
+	u := r.users[userID]
+	u.FailedLoginCount = 0
+	u.LockedUntil = nil
+	delete(r.lastFailure, userID)
+	return nil
+}
+
+func newLockoutService(t *testing.T) (*AuthService, *lockoutRepo) {
+	t.Helper()
+	repo := &lockoutRepo{ssoRepo: newSSORepo(), lastFailure: map[int64]time.Time{}}
+	hash, err := HashPassword("benar123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo.users[1] = &UserAccount{ID: 1, Username: "ani", PasswordHash: hash, Role: constants.RoleStudent, IsActive: true}
+
+	cfg := JWTConfig{Secret: "test-secret", AccessTokenTTL: time.Hour, RefreshTokenTTL: time.Hour}
+	svc := NewAuthService(repo, NewJWTManager(cfg), cfg)
+	svc.SetLockout(LockoutConfig{Threshold: 3, BaseDuration: time.Minute, MaxDuration: 10 * time.Minute})
+	return svc, repo
+}
+
+func TestLockoutDuration(t *testing.T) {
+	cfg := LockoutConfig{Threshold: 3, BaseDuration: time.Minute, MaxDuration: 10 * time.Minute}
+	want := map[int]time.Duration{
+		1:  0,
+		2:  0,
+		3:  time.Minute,
+		4:  2 * time.Minute,
+		5:  4 * time.Minute,
+		6:  8 * time.Minute,
+		7:  10 * time.Minute,
+		50: 10 * time.Minute,
+	}
+	for failures, d := range want {
+		if got := cfg.lockoutDuration(failures); got != d {
+			t.Errorf("lockoutDuration(%d) = %v, want %v", failures, got, d)
+		}
+	}
+	if got := (LockoutConfig{}).lockoutDuration(100); got != 0 {
+		t.Errorf("disabled lockout returned %v", got)
+	}
+}
+
+func TestLogin_LockoutAfterFailures(t *testing.T) {
+	ctx := context.Background()
+	svc, repo := newLockoutService(t)
+	wrong := LoginRequest{Username: "ani", Password: "salah"}
+
+	for i := 0; i < 2; i++ {
+		if _, err := svc.Login(ctx, wrong, "", ""); !errors.Is(err, ErrInvalidCredentials) {
+			t.Fatalf("failure %d: err = %v", i+1, err)
+		}
+	}
+
+	_, err := svc.Login(ctx, wrong, "", "")
+	var locked *AccountLockedError
+	if !errors.As(err, &locked) || !errors.Is(err, ErrAccountLocked) {
+		t.Fatalf("third failure: err = %v", err)
+	}
+	if ra := locked.RetryAfter(); ra <= 0 || ra > time.Minute {
+		t.Fatalf("retry after = %v", ra)
+	}
+
+	// The correct password is refused while the lock holds
+	if _, err := svc.Login(ctx, LoginRequest{Username: "ani", Password: "benar123"}, "", ""); !errors.Is(err, ErrAccountLocked) {
+		t.Fatalf("locked login: err = %v", err)
+	}
+	if repo.users[1].FailedLoginCount != 3 {
+		t.Fatalf("refused attempts should not count, got %d", repo.users[1].FailedLoginCount)
+	}
+}
+
+func TestLogin_SuccessClearsFailures(t *testing.T) {
+	ctx := context.Background()
+	svc, repo := newLockoutService(t)
+
+	if _, err := svc.Login(ctx, LoginRequest{Username: "ani", Password: "salah"}, "", ""); !errors.Is(err, ErrInvalidCredentials) {
+		t.Fatalf("err = %v", err)
+	}
+	if repo.users[1].FailedLoginCount != 1 {
+		t.Fatalf("failed count = %d", repo.users[1].FailedLoginCount)
+	}
+
+	if _, err := svc.Login(ctx, LoginRequest{Username: "ani", Password: "benar123"}, "", ""); err != nil {
+		t.Fatalf("login: %v", err)
+	}
+	if repo.users[1].FailedLoginCount != 0 {
+		t.Fatalf("failed count not cleared: %d", repo.users[1].FailedLoginCount)
+	}
+
+	// An expired lock no longer refuses the password
+	past := time.Now().Add(-time.Second)
+	repo.users[1].LockedUntil = &past
+	if _, err := svc.Login(ctx, LoginRequest{Username: "ani", Password: "benar123"}, "", ""); err != nil {
+		t.Fatalf("login after lock expired: %v", err)
+	}
+	if repo.users[1].LockedUntil != nil {
+		t.Fatal("expired lock not cleared")
+	}
+}
+
+func TestLogin_LockoutDisabledByDefault(t *testing.T) {
+	ctx := context.Background()
+	svc, repo := newLockoutService(t)
+	svc.lockout = LockoutConfig{}
+
+	for i := 0; i < 10; i++ {
+		if _, err := svc.Login(ctx, LoginRequest{Username: "ani", Password: "salah"}, "", ""); !errors.Is(err, ErrInvalidCredentials) {
+			t.Fatalf("attempt %d: err = %v", i+1, err)
+		}
+	}
+	if repo.users[1].FailedLoginCount != 0 || repo.users[1].LockedUntil != nil {
+		t.Fatal("disabled lockout should not track failures")
+	}
+}
@@ -0,0 +1,371 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"pemira-api/internal/shared/constants"
+)
+
+var (
+	ErrSSODisabled            = errors.New("sso is not configured")
+	ErrSSOIdentifierMissing   = errors.New("id token carries no NIM, NIDN or NIP")
+	ErrPasswordLoginDisabled  = errors.New("password login is disabled for this role")
+	ErrSSOAccountNotLinkable  = errors.New("account cannot be linked to sso")
+	errSSOProviderUnavailable = errors.New("sso provider unavailable")
+)
+
+const (
+	ssoStateTTL     = 10 * time.Minute
+	ssoLoginCodeTTL = time.Minute
+)
+
+// SSOConfig configures institutional login through an OpenID Connect
+// provider. SSO is enabled when Issuer and ClientID are set.
+type SSOConfig struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	// RedirectURL is this API's callback, registered at the IdP.
+	RedirectURL string
+	// FrontendRedirectURL receives ?code= (or ?error=) after the callback.
+	FrontendRedirectURL string
+	Scopes              []string
+
+	// ID token claims holding the identifiers matched against the DPT.
+	NIMClaim     string
+	NIDNClaim    string
+	NIPClaim     string
+	FacultyClaim string
+
+	HTTPClient *http.Client
+}
+
+// ssoIdentity is what the login flow takes from a verified ID token.
+type ssoIdentity struct {
+	Subject string
+	Email   string
+	Name    string
+	Faculty string
+	Number  string
+	// EmailVerified is false only when the IdP says so explicitly.
+	EmailVerified bool
+}
+
+// SetSSOConfig enables OIDC login. An empty Issuer leaves SSO disabled.
+func (s *AuthService) SetSSOConfig(cfg SSOConfig) {
+	if cfg.Issuer == "" || cfg.ClientID == "" {
+		s.sso = nil
+		return
+	}
+	if cfg.NIMClaim == "" {
+		cfg.NIMClaim = "nim"
+	}
+	if cfg.NIDNClaim == "" {
+		cfg.NIDNClaim = "nidn"
+	}
+	if cfg.NIPClaim == "" {
+		cfg.NIPClaim = "nip"
+	}
+	if cfg.FacultyClaim == "" {
+		cfg.FacultyClaim = "faculty"
+	}
+	s.ssoConfig = cfg
+	s.sso = newOIDCProvider(cfg)
+}
+
+// SSOEnabled reports whether OIDC login is configured
+func (s *AuthService) SSOEnabled() bool {
+	return s.sso != nil
+}
+
+// DisablePasswordLogin turns off password login, self-registration and
+// NIM-based password reset for roles, leaving SSO as their only way in
+func (s *AuthService) DisablePasswordLogin(roles ...constants.Role) {
+	s.passwordDisabled = make(map[constants.Role]bool, len(roles))
+	for _, r := range roles {
+		s.passwordDisabled[r] = true
+	}
+}
+
+// PasswordLoginDisabledRoles lists roles that must use SSO
+func (s *AuthService) PasswordLoginDisabledRoles() []constants.Role {
+	roles := make([]constants.Role, 0, len(s.passwordDisabled))
+	for r := range s.passwordDisabled {
+		roles = append(roles, r)
+	}
+	return roles
+}
+
+func (s *AuthService) passwordLoginAllowed(role constants.Role) bool {
+	return !s.passwordDisabled[role]
+}
+
+// StartSSO creates a login state and returns the IdP authorization URL
+func (s *AuthService) StartSSO(ctx context.Context) (string, error) {
+	if s.sso == nil {
+		return "", ErrSSODisabled
+	}
+
+	state, err := randomURLToken(32)
+	if err != nil {
+		return "", err
+	}
+	nonce, err := randomURLToken(32)
+	if err != nil {
+		return "", err
+	}
+	verifier, err := randomURLToken(48)
+	if err != nil {
+		return "", err
+	}
+
+	authURL, err := s.sso.authCodeURL(ctx, state, nonce, verifier)
+	if err != nil {
+		slog.Error("sso discovery failed", "error", err)
+		return "", errSSOProviderUnavailable
+	}
+
+	st := &SSOLoginState{
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		ExpiresAt:    time.Now().Add(ssoStateTTL),
+	}
+	if err := s.repo.CreateSSOState(ctx, st, sha256Hex(state)); err != nil {
+		return "", err
+	}
+	return authURL, nil
+}
+
+// CompleteSSO handles the IdP callback: it redeems the authorization code,
+// verifies the ID token, resolves the account and returns a one-time login
+// code for the frontend to exchange with ExchangeSSOCode.
+func (s *AuthService) CompleteSSO(ctx context.Context, state, code string) (_ string, err error) {
+	// Successful SSO logins are counted when the login code is exchanged
+	defer func() {
+		if err != nil {
+			recordLogin("sso", err)
+		}
+	}()
+
+	if s.sso == nil {
+		return "", ErrSSODisabled
+	}
+
+	st, err := s.repo.ClaimSSOState(ctx, sha256Hex(state))
+	if err != nil {
+		return "", err
+	}
+
+	rawIDToken, err := s.sso.exchange(ctx, code, st.CodeVerifier)
+	if err != nil {
+		slog.Warn("sso code exchange failed", "error", err)
+		return "", errSSOProviderUnavailable
+	}
+	claims, err := s.sso.verifyIDToken(ctx, rawIDToken, st.Nonce)
+	if err != nil {
+		slog.Warn("sso id token rejected", "error", err)
+		return "", ErrInvalidIDToken
+	}
+
+	user, err := s.resolveSSOUser(ctx, s.ssoIdentity(claims))
+	if err != nil {
+		return "", err
+	}
+	if !user.IsActive {
+		return "", ErrInactiveUser
+	}
+
+	loginCode, err := randomURLToken(32)
+	if err != nil {
+		return "", err
+	}
+	if err := s.repo.CompleteSSOState(ctx, st.ID, user.ID, sha256Hex(loginCode), time.Now().Add(ssoLoginCodeTTL)); err != nil {
+		return "", err
+	}
+	return loginCode, nil
+}
+
+// ExchangeSSOCode trades the one-time login code for the normal token pair.
+// Accounts with 2FA get an *MFARequiredError, as with password login.
+func (s *AuthService) ExchangeSSOCode(ctx context.Context, loginCode, userAgent, ipAddress string) (resp *LoginResponse, err error) {
+	defer func() { recordLogin("sso", err) }()
+
+	userID, err := s.repo.ClaimSSOLoginCode(ctx, sha256Hex(loginCode))
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsActive {
+		return nil, ErrInactiveUser
+	}
+
+	if err := s.challengeSecondFactor(ctx, user); err != nil {
+		return nil, err
+	}
+	return s.issueLogin(ctx, user, userAgent, ipAddress)
+}
+
+// ssoIdentity extracts the configured claims from an ID token
+func (s *AuthService) ssoIdentity(claims jwt.MapClaims) ssoIdentity {
+	id := ssoIdentity{
+		Subject: claimString(claims, "sub"),
+		Email:   claimString(claims, "email"),
+		Name:    claimString(claims, "name"),
+		Faculty: claimString(claims, s.ssoConfig.FacultyClaim),
+
+		EmailVerified: claimString(claims, "email_verified") != "false",
+	}
+	// NIM, NIDN and NIP share the voters.nim column; the DPT voter type
+	// decides the role
+	for _, name := range []string{s.ssoConfig.NIMClaim, s.ssoConfig.NIDNClaim, s.ssoConfig.NIPClaim} {
+		if v := claimString(claims, name); v != "" {
+			id.Number = v
+			break
+		}
+	}
+	return id
+}
+
+// resolveSSOUser finds the account for an identity: an existing link first,
+// then the voter with the same NIM/NIDN/NIP and its account, which is
+// created on first login when missing
+func (s *AuthService) resolveSSOUser(ctx context.Context, id ssoIdentity) (*UserAccount, error) {
+	issuer := s.sso.issuer
+
+	user, err := s.repo.GetUserByIdentity(ctx, issuer, id.Subject)
+	if err == nil {
+		_ = s.repo.TouchUserIdentity(ctx, issuer, id.Subject)
+		return user, nil
+	}
+	if !errors.Is(err, ErrUserNotFound) {
+		return nil, err
+	}
+
+	if id.Number == "" {
+		return nil, ErrSSOIdentifierMissing
+	}
+	voter, err := s.repo.GetDPTVoterByNumber(ctx, id.Number)
+	if err != nil {
+		return nil, err
+	}
+	if id.Faculty != "" && voter.FacultyName == "" {
+		_ = s.repo.FillVoterFaculty(ctx, voter.ID, id.Faculty)
+	}
+
+	user, err = s.repo.GetUserByVoterNIM(ctx, voter.NIM)
+	switch {
+	case err == nil:
+		if !isVoterRole(user.Role) {
+			// Never link staff/admin accounts through a voter identifier
+			return nil, ErrSSOAccountNotLinkable
+		}
+		// The IdP proved ownership of this NIM; end sessions opened
+		// with a password before the link
+		_ = s.repo.RevokeAllUserSessions(ctx, user.ID)
+	case errors.Is(err, ErrVoterNotRegistered):
+		if user, err = s.createSSOAccount(ctx, voter, id); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+
+	if err := s.repo.LinkUserIdentity(ctx, user.ID, issuer, id.Subject, id.Email); err != nil {
+		return nil, err
+	}
+
+	// The institution vouches for the email it returns, which settles a
+	// pending email verification
+	if id.Email != "" && id.EmailVerified {
+		if err := s.repo.MarkEmailVerified(ctx, user.ID, id.Email); err != nil {
+			slog.Warn("sso: could not mark email verified", "user_id", user.ID, "error", err)
+		} else {
+			user.EmailVerificationPending = false
+		}
+	}
+	return user, nil
+}
+
+// createSSOAccount creates the user account of a DPT voter. The role follows
+// the DPT voter type; the password is random and unusable.
+func (s *AuthService) createSSOAccount(ctx context.Context, voter *DPTVoter, id ssoIdentity) (*UserAccount, error) {
+	role := constants.RoleStudent
+	switch strings.ToUpper(voter.VoterType) {
+	case "LECTURER":
+		role = constants.RoleLecturer
+	case "STAFF":
+		role = constants.RoleStaff
+	}
+
+	randomPassword, err := GenerateRandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	passwordHash, err := HashPassword(randomPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	email := id.Email
+	if email == "" {
+		email = voter.Email
+	}
+	if email == "" {
+		email = fmt.Sprintf("%s@pemira.ac.id", voter.NIM)
+	}
+	name := voter.Name
+	if name == "" {
+		name = id.Name
+	}
+
+	user, err := s.repo.CreateUserAccount(ctx, &UserAccount{
+		Username:     voter.NIM,
+		Email:        email,
+		FullName:     name,
+		PasswordHash: passwordHash,
+		Role:         role,
+		VoterID:      &voter.ID,
+		LecturerID:   voter.LecturerID,
+		StaffID:      voter.StaffID,
+		IsActive:     true,
+	})
+	if err != nil {
+		if errors.Is(err, ErrUsernameExists) {
+			return nil, ErrSSOAccountNotLinkable
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+func isVoterRole(role constants.Role) bool {
+	return role == constants.RoleStudent || role == constants.RoleLecturer || role == constants.RoleStaff
+}
+
+// claimString reads a string, boolean or numeric claim
+func claimString(claims jwt.MapClaims, name string) string {
+	switch v := claims[name].(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case bool:
+		return fmt.Sprint(v)
+	case float64:
+		return fmt.Sprintf("%.0f", v)
+	case json.Number:
+		return v.String()
+	}
+	return ""
+}
@@ -0,0 +1,506 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"pemira-api/internal/shared/constants"
+)
+
+// mockIdP is an OpenID provider with a single signing key. /authorize logs
+// the configured user in immediately and redirects back with a code.
+type mockIdP struct {
+	t      *testing.T
+	srv    *httptest.Server
+	key    *rsa.PrivateKey
+	claims jwt.MapClaims // extra ID token claims of the logged-in user
+
+	mu    sync.Mutex
+	codes map[string]mockAuthRequest
+}
+
+type mockAuthRequest struct {
+	clientID, redirectURI, nonce, challenge string
+}
+
+func newMockIdP(t *testing.T, claims jwt.MapClaims) *mockIdP {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	idp := &mockIdP{t: t, key: key, claims: claims, codes: map[string]mockAuthRequest{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 idp.srv.URL,
+			"authorization_endpoint": idp.srv.URL + "/authorize",
+			"token_endpoint":         idp.srv.URL + "/token",
+			"jwks_uri":               idp.srv.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"keys": []map[string]string{{
+			"kty": "RSA", "kid": "k1", "use": "sig", "alg": "RS256",
+			"n": base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e": base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/authorize", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("response_type") != "code" || q.Get("code_challenge_method") != "S256" || q.Get("code_challenge") == "" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		code, _ := randomURLToken(16)
+		idp.mu.Lock()
+		idp.codes[code] = mockAuthRequest{
+			clientID:    q.Get("client_id"),
+			redirectURI: q.Get("redirect_uri"),
+			nonce:       q.Get("nonce"),
+			challenge:   q.Get("code_challenge"),
+		}
+		idp.mu.Unlock()
+		http.Redirect(w, r, q.Get("redirect_uri")+"?code="+code+"&state="+url.QueryEscape(q.Get("state")), http.StatusFound)
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		idp.mu.Lock()
+		req, ok := idp.codes[r.PostForm.Get("code")]
+		delete(idp.codes, r.PostForm.Get("code"))
+		idp.mu.Unlock()
+
+		clientID, _, _ := r.BasicAuth()
+		switch {
+		case !ok, r.PostForm.Get("grant_type") != "authorization_code":
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		case pkceChallenge(r.PostForm.Get("code_verifier")) != req.challenge:
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant", "error_description": "pkce"})
+			return
+		case clientID != req.clientID, r.PostForm.Get("redirect_uri") != req.redirectURI:
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_client"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{
+			"access_token": "at",
+			"token_type":   "Bearer",
+			"id_token":     idp.idToken(req.clientID, req.nonce),
+		})
+	})
+	idp.srv = httptest.NewServer(mux)
+	t.Cleanup(idp.srv.Close)
+	return idp
+}
+
+func (idp *mockIdP) idToken(aud, nonce string) string {
+	claims := jwt.MapClaims{
+		"iss":   idp.srv.URL,
+		"aud":   aud,
+		"iat":   time.Now().Unix(),
+		"exp":   time.Now().Add(5 * time.Minute).Unix(),
+		"nonce": nonce,
+	}
+	for k, v := range idp.claims {
+		claims[k] = v
+	}
+	tok := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	tok.Header["kid"] = "k1"
+	signed, err := tok.SignedString(idp.key)
+	if err != nil {
+		idp.t.Fatal(err)
+	}
+	return signed
+}
+
+// ssoRepo keeps just enough state in memory for the SSO and login paths.
+type ssoRepo struct {
+	Repository
+
+	voters     map[string]*DPTVoter
+	users      map[int64]*UserAccount
+	identities map[string]int64
+	states     map[string]*SSOLoginState
+	codes      map[string]int64
+	revoked    []int64
+}
+
+func newSSORepo() *ssoRepo {
+	return &ssoRepo{
+		voters:     map[string]*DPTVoter{},
+		users:      map[int64]*UserAccount{},
+		identities: map[string]int64{},
+		states:     map[string]*SSOLoginState{},
+		codes:      map[string]int64{},
+	}
+}
+
+func (r *ssoRepo) CreateSSOState(ctx context.Context, st *SSOLoginState, hash string) error {
+	st.ID = int64(len(r.states) + 1)
+	r.states[hash] = st
+	return nil
+}
+
+func (r *ssoRepo) ClaimSSOState(ctx context.Context, hash string) (*SSOLoginState, error) {
+	st, ok := r.states[hash]
+	if !ok || time.Now().After(st.ExpiresAt) {
+		return nil, ErrSSOStateInvalid
+	}
+	delete(r.states, hash)
+	return st, nil
+}
+
+func (r *ssoRepo) CompleteSSOState(ctx context.Context, stateID, userID int64, codeHash string, exp time.Time) error {
+	r.codes[codeHash] = userID
+	return nil
+}
+
+func (r *ssoRepo) ClaimSSOLoginCode(ctx context.Context, codeHash string) (int64, error) {
+	id, ok := r.codes[codeHash]
+	if !ok {
+		return 0, ErrSSOStateInvalid
+	}
+	delete(r.codes, codeHash)
+	return id, nil
+}
+
+func (r *ssoRepo) GetUserByIdentity(ctx context.Context, issuer, subject string) (*UserAccount, error) {
+	if id, ok := r.identities[issuer+"|"+subject]; ok {
+		return r.users[id], nil
+	}
+	return nil, ErrUserNotFound
+}
+
+func (r *ssoRepo) LinkUserIdentity(ctx context.Context, userID int64, issuer, subject, email string) error {
+	for k, id := range r.identities {
+		if k == issuer+"|"+subject || id == userID {
+			return ErrIdentityConflict
+		}
+	}
+	r.identities[issuer+"|"+subject] = userID
+	return nil
+}
+
+func (r *ssoRepo) TouchUserIdentity(ctx context.Context, issuer, subject string) error { return nil }
+
+func (r *ssoRepo) MarkEmailVerified(ctx context.Context, userID int64, email string) error {
+	if u, ok := r.users[userID]; ok {
+		u.Email = email
+		u.EmailVerificationPending = false
+	}
+	return nil
+}
+
+func (r *ssoRepo) GetDPTVoterByNumber(ctx context.Context, number string) (*DPTVoter, error) {
+	if v, ok := r.voters[number]; ok {
+		return v, nil
+	}
+	return nil, ErrVoterNotInDPT
+}
+
+func (r *ssoRepo) FillVoterFaculty(ctx context.Context, voterID int64, faculty string) error {
+	for _, v := range r.voters {
+		if v.ID == voterID && v.FacultyName == "" {
+			v.FacultyName = faculty
+		}
+	}
+	return nil
+}
+
+func (r *ssoRepo) GetUserByVoterNIM(ctx context.Context, nim string) (*UserAccount, error) {
+	v, ok := r.voters[nim]
+	if ok {
+		for _, u := range r.users {
+			if u.VoterID != nil && *u.VoterID == v.ID {
+				return u, nil
+			}
+		}
+	}
+	return nil, ErrVoterNotRegistered
+}
+
+func (r *ssoRepo) CreateUserAccount(ctx context.Context, u *UserAccount) (*UserAccount, error) {
+	created := *u
+	created.ID = int64(len(r.users) + 1)
+	r.users[created.ID] = &created
+	return &created, nil
+}
+
+func (r *ssoRepo) GetUserByID(ctx context.Context, id int64) (*UserAccount, error) {
+	if u, ok := r.users[id]; ok {
+		return u, nil
+	}
+	return nil, ErrUserNotFound
+}
+
+func (r *ssoRepo) GetUserByUsername(ctx context.Context, username string) (*UserAccount, error) {
+	for _, u := range r.users {
+		if u.Username == username {
+			return u, nil
+		}
+	}
+	return nil, ErrUserNotFound
+}
+
+func (r *ssoRepo) RevokeAllUserSessions(ctx context.Context, userID int64) error {
+	r.revoked = append(r.revoked, userID)
+	return nil
+}
+
+func (r *ssoRepo) GetUserTOTP(ctx context.Context, userID int64) (*UserTOTP, error) {
+	return nil, ErrTOTPNotFound
+}
+
+func (r *ssoRepo) CreateSession(ctx context.Context, s *UserSession) (*UserSession, error) {
+	return s, nil
+}
+
+func (r *ssoRepo) UpdateLoginTracking(ctx context.Context, userID int64) error { return nil }
+
+const testRedirectURL = "https://api.example.test/auth/sso/callback"
+
+func newSSOService(t *testing.T, idp *mockIdP, repo *ssoRepo) *AuthService {
+	t.Helper()
+	cfg := JWTConfig{Secret: "test-secret", AccessTokenTTL: time.Hour, RefreshTokenTTL: time.Hour}
+	svc := NewAuthService(repo, NewJWTManager(cfg), cfg)
+	svc.SetSSOConfig(SSOConfig{
+		Issuer:       idp.srv.URL,
+		ClientID:     "pemira",
+		ClientSecret: "s3cret",
+		RedirectURL:  testRedirectURL,
+		HTTPClient:   idp.srv.Client(),
+	})
+	return svc
+}
+
+// ssoRoundTrip runs the browser leg: follows the authorization URL to the
+// mock IdP and returns the state and code it redirects back with.
+func ssoRoundTrip(t *testing.T, svc *AuthService, idp *mockIdP) (string, string) {
+	t.Helper()
+	authURL, err := svc.StartSSO(context.Background())
+	if err != nil {
+		t.Fatalf("StartSSO: %v", err)
+	}
+
+	client := idp.srv.Client()
+	client.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+	resp, err := client.Get(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		t.Fatalf("authorize returned %d", resp.StatusCode)
+	}
+	back, _ := url.Parse(resp.Header.Get("Location"))
+	return back.Query().Get("state"), back.Query().Get("code")
+}
+
+func TestSSO_FirstLoginCreatesAccountForDPTVoter(t *testing.T) {
+	idp := newMockIdP(t, jwt.MapClaims{"sub": "idp-user-1", "nim": "2021001", "faculty": "Teknik", "email": "a@kampus.ac.id"})
+	repo := newSSORepo()
+	repo.voters["2021001"] = &DPTVoter{ID: 7, NIM: "2021001", Name: "Ani", VoterType: "STUDENT"}
+	svc := newSSOService(t, idp, repo)
+
+	state, code := ssoRoundTrip(t, svc, idp)
+	loginCode, err := svc.CompleteSSO(context.Background(), state, code)
+	if err != nil {
+		t.Fatalf("CompleteSSO: %v", err)
+	}
+	resp, err := svc.ExchangeSSOCode(context.Background(), loginCode, "ua", "127.0.0.1")
+	if err != nil {
+		t.Fatalf("ExchangeSSOCode: %v", err)
+	}
+	if resp.AccessToken == "" || resp.RefreshToken == "" {
+		t.Fatal("no tokens issued")
+	}
+	if resp.User.Role != constants.RoleStudent || resp.User.VoterID == nil || *resp.User.VoterID != 7 {
+		t.Fatalf("unexpected user %+v", resp.User)
+	}
+	claims, err := svc.jwtManager.ValidateAccessToken(resp.AccessToken)
+	if err != nil || claims.UserID != resp.User.ID {
+		t.Fatalf("access token invalid: %v", err)
+	}
+	if repo.voters["2021001"].FacultyName != "Teknik" {
+		t.Error("faculty not filled from claims")
+	}
+
+	// The login code is single use
+	if _, err := svc.ExchangeSSOCode(context.Background(), loginCode, "ua", "127.0.0.1"); !errors.Is(err, ErrSSOStateInvalid) {
+		t.Fatalf("reused login code: err = %v", err)
+	}
+
+	// A second login resolves the linked identity without a new account
+	state, code = ssoRoundTrip(t, svc, idp)
+	if _, err := svc.CompleteSSO(context.Background(), state, code); err != nil {
+		t.Fatalf("second CompleteSSO: %v", err)
+	}
+	if len(repo.users) != 1 {
+		t.Fatalf("accounts = %d, want 1", len(repo.users))
+	}
+}
+
+func TestSSO_LinksExistingPasswordAccount(t *testing.T) {
+	idp := newMockIdP(t, jwt.MapClaims{"sub": "idp-user-2", "nidn": "0011223344"})
+	repo := newSSORepo()
+	voterID := int64(9)
+	repo.voters["0011223344"] = &DPTVoter{ID: voterID, NIM: "0011223344", VoterType: "LECTURER"}
+	repo.users[1] = &UserAccount{ID: 1, Username: "0011223344", Role: constants.RoleLecturer, VoterID: &voterID, IsActive: true}
+	svc := newSSOService(t, idp, repo)
+
+	state, code := ssoRoundTrip(t, svc, idp)
+	if _, err := svc.CompleteSSO(context.Background(), state, code); err != nil {
+		t.Fatalf("CompleteSSO: %v", err)
+	}
+	if repo.identities[idp.srv.URL+"|idp-user-2"] != 1 {
+		t.Fatal("identity not linked to the existing account")
+	}
+	if len(repo.revoked) != 1 || repo.revoked[0] != 1 {
+		t.Error("password sessions were not revoked on link")
+	}
+}
+
+func TestSSO_Rejections(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("not in DPT", func(t *testing.T) {
+		idp := newMockIdP(t, jwt.MapClaims{"sub": "x", "nim": "999"})
+		svc := newSSOService(t, idp, newSSORepo())
+		state, code := ssoRoundTrip(t, svc, idp)
+		if _, err := svc.CompleteSSO(ctx, state, code); !errors.Is(err, ErrVoterNotInDPT) {
+			t.Fatalf("err = %v", err)
+		}
+	})
+
+	t.Run("no identifier claim", func(t *testing.T) {
+		idp := newMockIdP(t, jwt.MapClaims{"sub": "x"})
+		svc := newSSOService(t, idp, newSSORepo())
+		state, code := ssoRoundTrip(t, svc, idp)
+		if _, err := svc.CompleteSSO(ctx, state, code); !errors.Is(err, ErrSSOIdentifierMissing) {
+			t.Fatalf("err = %v", err)
+		}
+	})
+
+	t.Run("state is single use", func(t *testing.T) {
+		idp := newMockIdP(t, jwt.MapClaims{"sub": "x", "nim": "1"})
+		repo := newSSORepo()
+		repo.voters["1"] = &DPTVoter{ID: 1, NIM: "1", VoterType: "STUDENT"}
+		svc := newSSOService(t, idp, repo)
+		state, code := ssoRoundTrip(t, svc, idp)
+		if _, err := svc.CompleteSSO(ctx, state, code); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := svc.CompleteSSO(ctx, state, code); !errors.Is(err, ErrSSOStateInvalid) {
+			t.Fatalf("replayed state: err = %v", err)
+		}
+	})
+
+	t.Run("unknown state", func(t *testing.T) {
+		idp := newMockIdP(t, jwt.MapClaims{"sub": "x", "nim": "1"})
+		svc := newSSOService(t, idp, newSSORepo())
+		_, code := ssoRoundTrip(t, svc, idp)
+		if _, err := svc.CompleteSSO(ctx, "forged", code); !errors.Is(err, ErrSSOStateInvalid) {
+			t.Fatalf("err = %v", err)
+		}
+	})
+
+	t.Run("admin account is never linked", func(t *testing.T) {
+		idp := newMockIdP(t, jwt.MapClaims{"sub": "x", "nip": "123"})
+		repo := newSSORepo()
+		voterID := int64(3)
+		repo.voters["123"] = &DPTVoter{ID: voterID, NIM: "123", VoterType: "STAFF"}
+		repo.users[1] = &UserAccount{ID: 1, Username: "123", Role: constants.RoleAdmin, VoterID: &voterID, IsActive: true}
+		svc := newSSOService(t, idp, repo)
+		state, code := ssoRoundTrip(t, svc, idp)
+		if _, err := svc.CompleteSSO(ctx, state, code); !errors.Is(err, ErrSSOAccountNotLinkable) {
+			t.Fatalf("err = %v", err)
+		}
+	})
+}
+
+func TestOIDC_VerifyIDToken(t *testing.T) {
+	ctx := context.Background()
+	idp := newMockIdP(t, jwt.MapClaims{"sub": "s"})
+	p := newOIDCProvider(SSOConfig{Issuer: idp.srv.URL, ClientID: "pemira", HTTPClient: idp.srv.Client()})
+
+	if _, err := p.verifyIDToken(ctx, idp.idToken("pemira", "n1"), "n1"); err != nil {
+		t.Fatalf("valid token rejected: %v", err)
+	}
+	if _, err := p.verifyIDToken(ctx, idp.idToken("pemira", "n1"), "n2"); !errors.Is(err, ErrInvalidIDToken) {
+		t.Error("nonce mismatch accepted")
+	}
+	if _, err := p.verifyIDToken(ctx, idp.idToken("other-client", "n1"), "n1"); !errors.Is(err, ErrInvalidIDToken) {
+		t.Error("foreign audience accepted")
+	}
+
+	other := newMockIdP(t, jwt.MapClaims{"sub": "s"})
+	other.srv.URL = idp.srv.URL // same issuer, different signing key
+	if _, err := p.verifyIDToken(ctx, other.idToken("pemira", "n1"), "n1"); !errors.Is(err, ErrInvalidIDToken) {
+		t.Error("token signed by an unknown key accepted")
+	}
+}
+
+func TestOIDC_ExchangeRequiresPKCEVerifier(t *testing.T) {
+	ctx := context.Background()
+	idp := newMockIdP(t, jwt.MapClaims{"sub": "s"})
+	p := newOIDCProvider(SSOConfig{Issuer: idp.srv.URL, ClientID: "pemira", RedirectURL: testRedirectURL, HTTPClient: idp.srv.Client()})
+
+	authURL, err := p.authCodeURL(ctx, "st", "n", "right-verifier")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := idp.srv.Client()
+	client.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+	resp, err := client.Get(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	back, _ := url.Parse(resp.Header.Get("Location"))
+
+	if _, err := p.exchange(ctx, back.Query().Get("code"), "wrong-verifier"); err == nil {
+		t.Fatal("exchange succeeded with the wrong code verifier")
+	}
+}
+
+func TestLogin_PasswordDisabledForRole(t *testing.T) {
+	hash, err := HashPassword("secret123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := newSSORepo()
+	repo.users[1] = &UserAccount{ID: 1, Username: "2021001", PasswordHash: hash, Role: constants.RoleStudent, IsActive: true}
+	repo.users[2] = &UserAccount{ID: 2, Username: "panitia", PasswordHash: hash, Role: constants.RolePanitia, IsActive: true}
+
+	cfg := JWTConfig{Secret: "test-secret", AccessTokenTTL: time.Hour, RefreshTokenTTL: time.Hour}
+	svc := NewAuthService(repo, NewJWTManager(cfg), cfg)
+	svc.DisablePasswordLogin(constants.RoleStudent)
+
+	ctx := context.Background()
+	if _, err := svc.Login(ctx, LoginRequest{Username: "2021001", Password: "secret123"}, "", ""); !errors.Is(err, ErrPasswordLoginDisabled) {
+		t.Fatalf("student password login: err = %v", err)
+	}
+	if _, err := svc.Login(ctx, LoginRequest{Username: "panitia", Password: "secret123"}, "", ""); err != nil {
+		t.Fatalf("panitia password login: %v", err)
+	}
+	if _, err := svc.RegisterStudent(ctx, RegisterStudentRequest{NIM: "1", Name: "x", Password: "secret123", Semester: "1"}); !errors.Is(err, ErrPasswordLoginDisabled) {
+		t.Fatalf("student registration: err = %v", err)
+	}
+}
@@ -0,0 +1,414 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"pemira-api/internal/shared/constants"
+)
+
+var (
+	ErrInvalidMFACode     = errors.New("invalid 2fa code")
+	ErrTOTPNotEnabled     = errors.New("2fa is not enabled")
+	ErrTOTPSetupRequired  = errors.New("2fa setup has not been started")
+	ErrTOTPAlreadyEnabled = errors.New("2fa is already enabled")
+	ErrTOTPRequired       = errors.New("2fa is required for this role")
+	ErrTOTPUnavailable    = errors.New("2fa has no encryption key configured")
+)
+
+const (
+	defaultMFAIssuer       = "PEMIRA"
+	defaultMFAChallengeTTL = 5 * time.Minute
+	maxMFAAttempts         = 5
+)
+
+// MFAConfig configures TOTP two-factor authentication.
+type MFAConfig struct {
+	// Issuer is the name authenticator apps show next to the account.
+	Issuer string
+	// RequiredRoles must enroll before their first login completes.
+	RequiredRoles []constants.Role
+	// EncryptionKey encrypts TOTP secrets at rest. It is never derived from
+	// the JWT secret; without it 2FA is unavailable.
+	EncryptionKey string
+	// ChallengeTTL bounds the time between password and second factor.
+	ChallengeTTL time.Duration
+}
+
+// MFARequiredError is returned by Login when the password was correct but
+// the account still needs a second factor. Callers that do not handle it
+// fail closed.
+type MFARequiredError struct {
+	Challenge MFAChallengeResponse
+}
+
+func (e *MFARequiredError) Error() string {
+	return "second factor required"
+}
+
+// SetMFAConfig applies 2FA settings, filling in defaults
+func (s *AuthService) SetMFAConfig(cfg MFAConfig) {
+	if cfg.Issuer == "" {
+		cfg.Issuer = defaultMFAIssuer
+	}
+	if cfg.ChallengeTTL <= 0 {
+		cfg.ChallengeTTL = defaultMFAChallengeTTL
+	}
+	s.mfa = cfg
+	s.secrets = newSecretBox(cfg.EncryptionKey)
+}
+
+// CheckMFAKey fails when users have enrolled 2FA but no encryption key is
+// configured, since none of them could complete a login
+func (s *AuthService) CheckMFAKey(ctx context.Context) error {
+	if s.secrets != nil {
+		return nil
+	}
+	n, err := s.repo.CountTOTPEnrollments(ctx)
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return fmt.Errorf("%d users have 2FA enrolled but no TOTP encryption key is configured", n)
+	}
+	return nil
+}
+
+// totpRequired reports whether role must use 2FA
+func (s *AuthService) totpRequired(role constants.Role) bool {
+	for _, r := range s.mfa.RequiredRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// challengeSecondFactor returns an *MFARequiredError when user needs a
+// second factor, nil when the password alone is enough
+func (s *AuthService) challengeSecondFactor(ctx context.Context, user *UserAccount) error {
+	totp, err := s.repo.GetUserTOTP(ctx, user.ID)
+	if err != nil && !errors.Is(err, ErrTOTPNotFound) {
+		return err
+	}
+
+	var purpose string
+	switch {
+	case totp != nil && totp.ConfirmedAt != nil:
+		purpose = MFAPurposeVerify
+	case s.totpRequired(user.Role):
+		purpose = MFAPurposeEnroll
+	default:
+		return nil
+	}
+
+	token, err := GenerateRandomToken(32)
+	if err != nil {
+		return err
+	}
+	challenge := &MFAChallenge{
+		UserID:    user.ID,
+		Purpose:   purpose,
+		ExpiresAt: time.Now().Add(s.mfa.ChallengeTTL),
+	}
+	if err := s.repo.CreateMFAChallenge(ctx, challenge, sha256Hex(token)); err != nil {
+		return err
+	}
+
+	return &MFARequiredError{Challenge: MFAChallengeResponse{
+		MFARequired:      true,
+		MFASetupRequired: purpose == MFAPurposeEnroll,
+		MFAToken:         token,
+		ExpiresIn:        int64(s.mfa.ChallengeTTL.Seconds()),
+	}}
+}
+
+// CompleteLoginMFA finishes a login started with a challenge. For a VERIFY
+// challenge code is a TOTP or recovery code; for an ENROLL challenge it is
+// the first TOTP code after BeginLoginTOTPSetup, and the response carries
+// the new recovery codes.
+func (s *AuthService) CompleteLoginMFA(ctx context.Context, req LoginMFARequest, userAgent, ipAddress string) (resp *LoginResponse, err error) {
+	defer func() { recordLogin("totp", err) }()
+
+	challenge, err := s.repo.ClaimMFAChallengeAttempt(ctx, sha256Hex(req.MFAToken), maxMFAAttempts)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.repo.GetUserByID(ctx, challenge.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsActive {
+		return nil, ErrInactiveUser
+	}
+
+	var recoveryCodes []string
+	switch challenge.Purpose {
+	case MFAPurposeVerify:
+		err = s.verifySecondFactor(ctx, user.ID, req.Code)
+	case MFAPurposeEnroll:
+		recoveryCodes, err = s.confirmTOTP(ctx, user.ID, req.Code)
+	default:
+		err = ErrMFAChallengeInvalid
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	consumed, err := s.repo.ConsumeMFAChallenge(ctx, challenge.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !consumed {
+		return nil, ErrMFAChallengeInvalid
+	}
+
+	resp, err = s.issueLogin(ctx, user, userAgent, ipAddress)
+	if err != nil {
+		return nil, err
+	}
+	resp.RecoveryCodes = recoveryCodes
+	return resp, nil
+}
+
+// BeginLoginTOTPSetup returns provisioning data for a user who must enroll
+// before the login can complete
+func (s *AuthService) BeginLoginTOTPSetup(ctx context.Context, mfaToken string) (*TOTPSetupResponse, error) {
+	challenge, err := s.repo.GetMFAChallenge(ctx, sha256Hex(mfaToken))
+	if err != nil {
+		return nil, err
+	}
+	if challenge.Purpose != MFAPurposeEnroll {
+		return nil, ErrTOTPAlreadyEnabled
+	}
+
+	user, err := s.repo.GetUserByID(ctx, challenge.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return s.beginSetup(ctx, user)
+}
+
+// TOTPStatus reports the 2FA state of a user
+func (s *AuthService) TOTPStatus(ctx context.Context, userID int64) (*TOTPStatusResponse, error) {
+	user, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &TOTPStatusResponse{Required: s.totpRequired(user.Role)}
+	totp, err := s.repo.GetUserTOTP(ctx, userID)
+	if errors.Is(err, ErrTOTPNotFound) {
+		return status, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	status.Enabled = totp.ConfirmedAt != nil
+	status.Pending = totp.ConfirmedAt == nil
+	status.ConfirmedAt = totp.ConfirmedAt
+	if status.Enabled {
+		if status.RecoveryCodesRemaining, err = s.repo.CountRecoveryCodes(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+	return status, nil
+}
+
+// BeginTOTPSetup starts (or restarts) enrollment for a signed-in user
+func (s *AuthService) BeginTOTPSetup(ctx context.Context, userID int64) (*TOTPSetupResponse, error) {
+	user, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.beginSetup(ctx, user)
+}
+
+// EnableTOTP confirms enrollment with the first code and returns the
+// recovery codes, which are shown only once
+func (s *AuthService) EnableTOTP(ctx context.Context, userID int64, code string) ([]string, error) {
+	return s.confirmTOTP(ctx, userID, code)
+}
+
+// DisableTOTP removes 2FA after checking a current code. Roles that require
+// 2FA cannot disable it; a super admin can reset it instead.
+func (s *AuthService) DisableTOTP(ctx context.Context, userID int64, code string) error {
+	user, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if s.totpRequired(user.Role) {
+		return ErrTOTPRequired
+	}
+	if err := s.verifySecondFactor(ctx, userID, code); err != nil {
+		return err
+	}
+	return s.repo.DeleteUserTOTP(ctx, userID)
+}
+
+// RegenerateRecoveryCodes replaces all recovery codes after checking a
+// current TOTP code
+func (s *AuthService) RegenerateRecoveryCodes(ctx context.Context, userID int64, code string) ([]string, error) {
+	if !isTOTPCode(code) {
+		return nil, ErrInvalidMFACode
+	}
+	if err := s.verifySecondFactor(ctx, userID, code); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// ResetTOTP removes a user's 2FA enrollment so they can enroll again, e.g.
+// after losing their device and recovery codes
+func (s *AuthService) ResetTOTP(ctx context.Context, userID int64) error {
+	if _, err := s.repo.GetUserByID(ctx, userID); err != nil {
+		return err
+	}
+	return s.repo.DeleteUserTOTP(ctx, userID)
+}
+
+func (s *AuthService) beginSetup(ctx context.Context, user *UserAccount) (*TOTPSetupResponse, error) {
+	existing, err := s.repo.GetUserTOTP(ctx, user.ID)
+	if err != nil && !errors.Is(err, ErrTOTPNotFound) {
+		return nil, err
+	}
+	if existing != nil && existing.ConfirmedAt != nil {
+		return nil, ErrTOTPAlreadyEnabled
+	}
+
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := s.secrets.seal(secret)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpsertPendingTOTP(ctx, user.ID, sealed); err != nil {
+		return nil, err
+	}
+
+	return &TOTPSetupResponse{
+		Secret:     secret,
+		OTPAuthURI: TOTPProvisioningURI(s.mfa.Issuer, user.Username, secret),
+		Issuer:     s.mfa.Issuer,
+		Account:    user.Username,
+	}, nil
+}
+
+// confirmTOTP turns a pending enrollment into an active one
+func (s *AuthService) confirmTOTP(ctx context.Context, userID int64, code string) ([]string, error) {
+	totp, err := s.repo.GetUserTOTP(ctx, userID)
+	if errors.Is(err, ErrTOTPNotFound) {
+		return nil, ErrTOTPSetupRequired
+	}
+	if err != nil {
+		return nil, err
+	}
+	if totp.ConfirmedAt != nil {
+		return nil, ErrTOTPAlreadyEnabled
+	}
+
+	secret, err := s.secrets.open(totp.SecretEnc)
+	if err != nil {
+		return nil, err
+	}
+	step, ok := ValidateTOTP(secret, code, time.Now(), totp.LastUsedStep)
+	if !ok {
+		return nil, ErrInvalidMFACode
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.ConfirmTOTP(ctx, userID, step, hashes); err != nil {
+		if errors.Is(err, ErrTOTPNotFound) {
+			return nil, ErrTOTPAlreadyEnabled
+		}
+		return nil, err
+	}
+	return codes, nil
+}
+
+// verifySecondFactor accepts a current TOTP code or an unused recovery code
+func (s *AuthService) verifySecondFactor(ctx context.Context, userID int64, code string) error {
+	totp, err := s.repo.GetUserTOTP(ctx, userID)
+	if errors.Is(err, ErrTOTPNotFound) {
+		return ErrTOTPNotEnabled
+	}
+	if err != nil {
+		return err
+	}
+	if totp.ConfirmedAt == nil {
+		return ErrTOTPNotEnabled
+	}
+
+	if !isTOTPCode(code) {
+		used, err := s.repo.UseRecoveryCode(ctx, userID, hashRecoveryCode(code))
+		if err != nil {
+			return err
+		}
+		if !used {
+			return ErrInvalidMFACode
+		}
+		return nil
+	}
+
+	secret, err := s.secrets.open(totp.SecretEnc)
+	if err != nil {
+		return err
+	}
+	step, ok := ValidateTOTP(secret, code, time.Now(), totp.LastUsedStep)
+	if !ok {
+		return ErrInvalidMFACode
+	}
+	// Advancing the step atomically rejects a code replayed concurrently
+	advanced, err := s.repo.AdvanceTOTPStep(ctx, userID, step)
+	if err != nil {
+		return err
+	}
+	if !advanced {
+		return ErrInvalidMFACode
+	}
+	return nil
+}
+
+// isTOTPCode tells a 6-digit TOTP code apart from a recovery code
+func isTOTPCode(code string) bool {
+	code = strings.ReplaceAll(strings.TrimSpace(code), " ", "")
+	if len(code) != totpDigits {
+		return false
+	}
+	for _, c := range code {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func newRecoveryCodes() ([]string, []string, error) {
+	codes, err := GenerateRecoveryCodes()
+	if err != nil {
+		return nil, nil, err
+	}
+	hashes := make([]string, len(codes))
+	for i, c := range codes {
+		hashes[i] = hashRecoveryCode(c)
+	}
+	return codes, hashes, nil
+}
@@ -0,0 +1,203 @@
+package auth
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// TOTP parameters (RFC 6238 defaults understood by every authenticator app).
+const (
+	totpPeriod    = 30
+	totpDigits    = 6
+	totpSkew      = 1 // steps accepted either side of now
+	totpSecretLen = 20
+
+	recoveryCodeCount = 10
+	recoveryCodeLen   = 10
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateTOTPSecret returns a new random secret, base32 encoded.
+func GenerateTOTPSecret() (string, error) {
+	buf := make([]byte, totpSecretLen)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate totp secret: %w", err)
+	}
+	return totpEncoding.EncodeToString(buf), nil
+}
+
+// TOTPProvisioningURI builds the otpauth:// URI that authenticator apps
+// import, usually rendered as a QR code by the client.
+func TOTPProvisioningURI(issuer, account, secret string) string {
+	v := url.Values{}
+	v.Set("secret", secret)
+	v.Set("issuer", issuer)
+	v.Set("algorithm", "SHA1")
+	v.Set("digits", fmt.Sprint(totpDigits))
+	v.Set("period", fmt.Sprint(totpPeriod))
+	label := url.PathEscape(issuer + ":" + account)
+	return "otpauth://totp/" + label + "?" + v.Encode()
+}
+
+// totpCode computes the code for one time step (RFC 4226 truncation).
+func totpCode(key []byte, step int64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	off := sum[len(sum)-1] & 0x0f
+	bin := binary.BigEndian.Uint32(sum[off:off+4]) & 0x7fffffff
+	mod := uint32(1)
+	for i := 0; i < totpDigits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", totpDigits, bin%mod)
+}
+
+// ValidateTOTP checks code against secret at now and returns the matched
+// time step. Steps at or before lastStep are rejected so a code cannot be
+// replayed.
+func ValidateTOTP(secret, code string, now time.Time, lastStep int64) (int64, bool) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return 0, false
+	}
+	code = strings.ReplaceAll(strings.TrimSpace(code), " ", "")
+	if len(code) != totpDigits {
+		return 0, false
+	}
+
+	current := now.Unix() / totpPeriod
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		if step <= lastStep {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(totpCode(key, step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// GenerateRecoveryCodes returns a fresh set of single-use recovery codes,
+// formatted as xxxxx-xxxxx.
+func GenerateRecoveryCodes() ([]string, error) {
+	const alphabet = "abcdefghijklmnopqrstuvwxyz234567"
+	codes := make([]string, recoveryCodeCount)
+	buf := make([]byte, recoveryCodeLen)
+	for i := range codes {
+		if _, err := rand.Read(buf); err != nil {
+			return nil, fmt.Errorf("failed to generate recovery code: %w", err)
+		}
+		var sb strings.Builder
+		for j, b := range buf {
+			if j == recoveryCodeLen/2 {
+				sb.WriteByte('-')
+			}
+			sb.WriteByte(alphabet[b&31])
+		}
+		codes[i] = sb.String()
+	}
+	return codes, nil
+}
+
+// hashRecoveryCode normalizes a recovery code as typed by the user and
+// hashes it for lookup. Codes are random, so an unsalted hash is enough.
+func hashRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	code = strings.NewReplacer("-", "", " ", "").Replace(code)
+	return sha256Hex(code)
+}
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+var errSecretCorrupt = errors.New("totp secret cannot be decrypted")
+
+// secretBox encrypts TOTP secrets at rest with AES-256-GCM. A nil box has no
+// key: 2FA is unavailable and every seal and open fails.
+type secretBox struct {
+	aead cipher.AEAD
+}
+
+// newSecretBox derives the AES key from key, nil when key is empty. AES-256
+// and GCM construction cannot fail for a 32-byte key, so errors are not
+// returned.
+func newSecretBox(key string) *secretBox {
+	if key == "" {
+		return nil
+	}
+	sum := sha256.Sum256([]byte("pemira-totp:" + key))
+	block, _ := aes.NewCipher(sum[:])
+	aead, _ := cipher.NewGCM(block)
+	return &secretBox{aead: aead}
+}
+
+func (b *secretBox) seal(plain string) (string, error) {
+	if b == nil {
+		return "", ErrTOTPUnavailable
+	}
+	nonce := make([]byte, b.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	out := b.aead.Seal(nonce, nonce, []byte(plain), nil)
+	return base64.StdEncoding.EncodeToString(out), nil
+}
+
+func (b *secretBox) open(sealed string) (string, error) {
+	if b == nil {
+		return "", ErrTOTPUnavailable
+	}
+	raw, err := base64.StdEncoding.DecodeString(sealed)
+	if err != nil || len(raw) < b.aead.NonceSize() {
+		return "", errSecretCorrupt
+	}
+	n := b.aead.NonceSize()
+	plain, err := b.aead.Open(nil, raw[:n], raw[n:], nil)
+	if err != nil {
+		return "", errSecretCorrupt
+	}
+	return string(plain), nil
+}
+
+// RekeyTOTPSecrets re-encrypts the stored TOTP secrets sealed with oldKey
+// under newKey and returns how many it changed. Secrets newKey already opens
+// are left alone, so an interrupted run can be repeated.
+func RekeyTOTPSecrets(ctx context.Context, repo Repository, oldKey, newKey string) (int, error) {
+	from, to := newSecretBox(oldKey), newSecretBox(newKey)
+	if from == nil || to == nil {
+		return 0, errors.New("rekey totp secrets: both keys are required")
+	}
+	return repo.RekeyTOTPSecrets(ctx, func(sealed string) (string, bool, error) {
+		if _, err := to.open(sealed); err == nil {
+			return sealed, false, nil
+		}
+		plain, err := from.open(sealed)
+		if err != nil {
+			return "", false, err
+		}
+		resealed, err := to.seal(plain)
+		return resealed, err == nil, err
+	})
+}
@@ -0,0 +1,196 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// RFC 6238 appendix B, SHA-1, truncated to 6 digits.
+var rfcSecret = totpEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestValidateTOTP_RFCVectors(t *testing.T) {
+	cases := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+	for _, c := range cases {
+		step, ok := ValidateTOTP(rfcSecret, c.code, time.Unix(c.unix, 0), 0)
+		if !ok {
+			t.Errorf("t=%d: code %s rejected", c.unix, c.code)
+			continue
+		}
+		if step != c.unix/totpPeriod {
+			t.Errorf("t=%d: step = %d, want %d", c.unix, step, c.unix/totpPeriod)
+		}
+	}
+}
+
+func TestValidateTOTP_SkewAndReplay(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+
+	// Code of the previous step is still accepted.
+	if _, ok := ValidateTOTP(rfcSecret, "005924", now.Add(totpPeriod*time.Second), 0); !ok {
+		t.Error("code one step old was rejected")
+	}
+	if _, ok := ValidateTOTP(rfcSecret, "005924", now.Add(3*totpPeriod*time.Second), 0); ok {
+		t.Error("code three steps old was accepted")
+	}
+
+	step, _ := ValidateTOTP(rfcSecret, "005924", now, 0)
+	if _, ok := ValidateTOTP(rfcSecret, "005924", now, step); ok {
+		t.Error("replayed code was accepted")
+	}
+	if _, ok := ValidateTOTP(rfcSecret, "005 924", now, 0); !ok {
+		t.Error("code with a space was rejected")
+	}
+	if _, ok := ValidateTOTP(rfcSecret, "00592", now, 0); ok {
+		t.Error("short code was accepted")
+	}
+}
+
+func TestTOTPProvisioningURI(t *testing.T) {
+	uri := TOTPProvisioningURI("PEMIRA UNIWA", "panitia01", "ABCDEF")
+	if !strings.HasPrefix(uri, "otpauth://totp/PEMIRA%20UNIWA:panitia01?") {
+		t.Fatalf("unexpected label: %s", uri)
+	}
+	for _, want := range []string{"secret=ABCDEF", "issuer=PEMIRA+UNIWA", "digits=6", "period=30"} {
+		if !strings.Contains(uri, want) {
+			t.Errorf("uri %s lacks %s", uri, want)
+		}
+	}
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(codes) != recoveryCodeCount {
+		t.Fatalf("got %d codes", len(codes))
+	}
+	seen := map[string]bool{}
+	for _, c := range codes {
+		if len(c) != recoveryCodeLen+1 || c[recoveryCodeLen/2] != '-' {
+			t.Errorf("malformed code %q", c)
+		}
+		if isTOTPCode(c) {
+			t.Errorf("recovery code %q looks like a TOTP code", c)
+		}
+		seen[c] = true
+	}
+	if len(seen) != len(codes) {
+		t.Error("duplicate recovery codes")
+	}
+
+	typed := strings.ToUpper(strings.ReplaceAll(codes[0], "-", " "))
+	if hashRecoveryCode(typed) != hashRecoveryCode(codes[0]) {
+		t.Error("recovery code hash is not normalized")
+	}
+}
+
+func TestSecretBox(t *testing.T) {
+	box := newSecretBox("key-one")
+	sealed, err := box.seal("JBSWY3DPEHPK3PXP")
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain, err := box.open(sealed)
+	if err != nil || plain != "JBSWY3DPEHPK3PXP" {
+		t.Fatalf("open = %q, %v", plain, err)
+	}
+	if _, err := newSecretBox("key-two").open(sealed); err == nil {
+		t.Error("secret opened with the wrong key")
+	}
+}
+
+func TestSecretBoxWithoutKey(t *testing.T) {
+	box := newSecretBox("")
+	if _, err := box.seal("JBSWY3DPEHPK3PXP"); !errors.Is(err, ErrTOTPUnavailable) {
+		t.Fatalf("seal without key: %v", err)
+	}
+	if _, err := box.open("c2VhbGVk"); !errors.Is(err, ErrTOTPUnavailable) {
+		t.Fatalf("open without key: %v", err)
+	}
+}
+
+type totpSecretRepo struct {
+	Repository
+	secrets  map[int64]string
+	enrolled int
+}
+
+func (r *totpSecretRepo) CountTOTPEnrollments(ctx context.Context) (int, error) {
+	return r.enrolled, nil
+}
+
+func (r *totpSecretRepo) RekeyTOTPSecrets(ctx context.Context, rekey func(string) (string, bool, error)) (int, error) {
+	changed := 0
+	for id, sealed := range r.secrets {
+		resealed, ok, err := rekey(sealed)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			r.secrets[id] = resealed
+			changed++
+		}
+	}
+	return changed, nil
+}
+
+func TestRekeyTOTPSecrets(t *testing.T) {
+	const oldKey, newKey = "jwt-secret", "dedicated-totp-key"
+	legacy, _ := newSecretBox(oldKey).seal("JBSWY3DPEHPK3PXP")
+	current, _ := newSecretBox(newKey).seal("KRSXG5CTMVRXEZLU")
+	repo := &totpSecretRepo{secrets: map[int64]string{1: legacy, 2: current}}
+
+	n, err := RekeyTOTPSecrets(context.Background(), repo, oldKey, newKey)
+	if err != nil || n != 1 {
+		t.Fatalf("rekey = %d, %v", n, err)
+	}
+	for id, want := range map[int64]string{1: "JBSWY3DPEHPK3PXP", 2: "KRSXG5CTMVRXEZLU"} {
+		if plain, err := newSecretBox(newKey).open(repo.secrets[id]); err != nil || plain != want {
+			t.Errorf("user %d: open = %q, %v", id, plain, err)
+		}
+	}
+
+	// a second run finds nothing left to do
+	if n, err := RekeyTOTPSecrets(context.Background(), repo, oldKey, newKey); err != nil || n != 0 {
+		t.Fatalf("second rekey = %d, %v", n, err)
+	}
+
+	repo.secrets[3], _ = newSecretBox("another-key").seal("JBSWY3DPEHPK3PXP")
+	if _, err := RekeyTOTPSecrets(context.Background(), repo, oldKey, newKey); !errors.Is(err, errSecretCorrupt) {
+		t.Fatalf("secret under an unknown key: %v", err)
+	}
+}
+
+func TestCheckMFAKey(t *testing.T) {
+	repo := &totpSecretRepo{enrolled: 2}
+	s := NewAuthService(repo, nil, JWTConfig{Secret: "jwt-secret"})
+	if err := s.CheckMFAKey(context.Background()); err == nil {
+		t.Fatal("enrollments without a key were accepted")
+	}
+	if _, err := s.secrets.seal("JBSWY3DPEHPK3PXP"); !errors.Is(err, ErrTOTPUnavailable) {
+		t.Fatalf("the JWT secret was used as TOTP key: %v", err)
+	}
+
+	s.SetMFAConfig(MFAConfig{EncryptionKey: "dedicated-totp-key"})
+	if err := s.CheckMFAKey(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	repo.enrolled = 0
+	s.SetMFAConfig(MFAConfig{})
+	if err := s.CheckMFAKey(context.Background()); err != nil {
+		t.Fatalf("no enrollments, no key: %v", err)
+	}
+}
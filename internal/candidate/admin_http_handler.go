@@ -15,6 +15,7 @@ import (
 	"github.com/gabriel-vasile/mimetype"
 	"github.com/go-chi/chi/v5"
 
+	"pemira-api/internal/errcatalog"
 	"pemira-api/internal/http/response"
 	"pemira-api/internal/shared/ctxkeys"
 )
@@ -96,7 +97,7 @@ func (h *AdminHandler) List(w http.ResponseWriter, r *http.Request) {
 
 	electionID, err := parseInt64Param(r, "electionID")
 	if err != nil || electionID <= 0 {
-		response.BadRequest(w, "INVALID_REQUEST", "electionID tidak valid.")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.Invalid("electionID"))
 		return
 	}
 
@@ -135,19 +136,19 @@ func (h *AdminHandler) Create(w http.ResponseWriter, r *http.Request) {
 
 	electionID, err := parseInt64Param(r, "electionID")
 	if err != nil || electionID <= 0 {
-		response.BadRequest(w, "INVALID_REQUEST", "electionID tidak valid.")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.Invalid("electionID"))
 		return
 	}
 
 	var req AdminCreateCandidateRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.BadRequest(w, "INVALID_REQUEST", "Body tidak valid.")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.InvalidBody)
 		return
 	}
 
 	// Validasi minimal
 	if req.Number <= 0 || req.Name == "" {
-		response.UnprocessableEntity(w, "VALIDATION_ERROR", "number dan name wajib diisi.")
+		response.UnprocessableEntity(w, "VALIDATION_ERROR", errcatalog.Required("number", "name"))
 		return
 	}
 
@@ -175,13 +176,13 @@ func (h *AdminHandler) Detail(w http.ResponseWriter, r *http.Request) {
 	// Get electionID from URL path
 	electionID, err := parseInt64Param(r, "electionID")
 	if err != nil || electionID <= 0 {
-		response.BadRequest(w, "INVALID_REQUEST", "electionID tidak valid.")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.Invalid("electionID"))
 		return
 	}
 
 	candidateID, err := parseInt64Param(r, "candidateID")
 	if err != nil || candidateID <= 0 {
-		response.BadRequest(w, "INVALID_REQUEST", "candidateID tidak valid.")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.Invalid("candidateID"))
 		return
 	}
 
@@ -200,19 +201,19 @@ func (h *AdminHandler) Update(w http.ResponseWriter, r *http.Request) {
 
 	electionID, err := parseInt64Param(r, "electionID")
 	if err != nil || electionID <= 0 {
-		response.BadRequest(w, "INVALID_REQUEST", "electionID tidak valid.")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.Invalid("electionID"))
 		return
 	}
 
 	candidateID, err := parseInt64Param(r, "candidateID")
 	if err != nil || candidateID <= 0 {
-		response.BadRequest(w, "INVALID_REQUEST", "candidateID tidak valid.")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.Invalid("candidateID"))
 		return
 	}
 
 	var req AdminUpdateCandidateRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.BadRequest(w, "INVALID_REQUEST", "Body tidak valid.")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.InvalidBody)
 		return
 	}
 
@@ -231,20 +232,20 @@ func (h *AdminHandler) Delete(w http.ResponseWriter, r *http.Request) {
 
 	electionID, err := parseInt64Param(r, "electionID")
 	if err != nil || electionID <= 0 {
-		response.BadRequest(w, "INVALID_REQUEST", "electionID tidak valid.")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.Invalid("electionID"))
 		return
 	}
 
 	candidateID, err := parseInt64Param(r, "candidateID")
 	if err != nil || candidateID <= 0 {
-		response.BadRequest(w, "INVALID_REQUEST", "candidateID tidak valid.")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.Invalid("candidateID"))
 		return
 	}
 
 	// Get admin ID from context
 	adminID, ok := ctxkeys.GetUserID(ctx)
 	if !ok {
-		response.Unauthorized(w, "UNAUTHORIZED", "User ID tidak ditemukan.")
+		response.Catalog(w, userIDMissing)
 		return
 	}
 
@@ -264,13 +265,13 @@ func (h *AdminHandler) Publish(w http.ResponseWriter, r *http.Request) {
 
 	electionID, err := parseInt64Param(r, "electionID")
 	if err != nil || electionID <= 0 {
-		response.BadRequest(w, "INVALID_REQUEST", "electionID tidak valid.")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.Invalid("electionID"))
 		return
 	}
 
 	candidateID, err := parseInt64Param(r, "candidateID")
 	if err != nil || candidateID <= 0 {
-		response.BadRequest(w, "INVALID_REQUEST", "candidateID tidak valid.")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.Invalid("candidateID"))
 		return
 	}
 
@@ -289,13 +290,13 @@ func (h *AdminHandler) Unpublish(w http.ResponseWriter, r *http.Request) {
 
 	electionID, err := parseInt64Param(r, "electionID")
 	if err != nil || electionID <= 0 {
-		response.BadRequest(w, "INVALID_REQUEST", "electionID tidak valid.")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.Invalid("electionID"))
 		return
 	}
 
 	candidateID, err := parseInt64Param(r, "candidateID")
 	if err != nil || candidateID <= 0 {
-		response.BadRequest(w, "INVALID_REQUEST", "candidateID tidak valid.")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.Invalid("candidateID"))
 		return
 	}
 
@@ -314,13 +315,13 @@ func (h *AdminHandler) GenerateQRCode(w http.ResponseWriter, r *http.Request) {
 
 	electionID, err := parseInt64Param(r, "electionID")
 	if err != nil || electionID <= 0 {
-		response.BadRequest(w, "INVALID_REQUEST", "electionID tidak valid.")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.Invalid("electionID"))
 		return
 	}
 
 	candidateID, err := parseInt64Param(r, "candidateID")
 	if err != nil || candidateID <= 0 {
-		response.BadRequest(w, "INVALID_REQUEST", "candidateID tidak valid.")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.Invalid("candidateID"))
 		return
 	}
 
@@ -339,47 +340,47 @@ func (h *AdminHandler) UploadProfileMedia(w http.ResponseWriter, r *http.Request
 
 	candidateID, err := parseInt64Param(r, "candidateID")
 	if err != nil || candidateID <= 0 {
-		response.BadRequest(w, "INVALID_REQUEST", "candidateID tidak valid.")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.Invalid("candidateID"))
 		return
 	}
 
 	adminID, ok := ctxkeys.GetUserID(ctx)
 	if !ok {
-		response.Unauthorized(w, "UNAUTHORIZED", "User tidak valid.")
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.InvalidUser)
 		return
 	}
 
 	if err := r.ParseMultipartForm(maxCandidateMediaSize + (512 << 10)); err != nil {
-		response.BadRequest(w, "INVALID_REQUEST", "Gagal membaca form upload.")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.UploadFormError)
 		return
 	}
 
 	filePart, header, err := r.FormFile("file")
 	if err != nil {
-		response.BadRequest(w, "INVALID_REQUEST", "Field file wajib diisi.")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.FileRequired)
 		return
 	}
 	defer filePart.Close()
 
 	data, tooLarge, err := readCandidateMedia(filePart, maxCandidateMediaSize)
 	if err != nil {
-		response.BadRequest(w, "INVALID_REQUEST", "Gagal membaca file upload.")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.UploadFileError)
 		return
 	}
 	if tooLarge {
-		response.UnprocessableEntity(w, "FILE_TOO_LARGE", "Ukuran file maksimal 3MB.")
+		response.UnprocessableEntity(w, "FILE_TOO_LARGE", errcatalog.FileTooLarge)
 		return
 	}
 
 	mime := mimetype.Detect(data)
 	if !isAllowedCandidateMedia(CandidateMediaSlotProfile, mime) {
-		response.UnprocessableEntity(w, "INVALID_FILE_TYPE", "Foto profil harus berupa PNG atau JPEG.")
+		response.Catalog(w, profilePhotoTypeInvalid)
 		return
 	}
 
 	mediaID, err := newCandidateMediaID()
 	if err != nil {
-		response.InternalServerError(w, "INTERNAL_ERROR", "Gagal menyiapkan penyimpanan media.")
+		response.Catalog(w, mediaStorageUnavailable)
 		return
 	}
 
@@ -415,7 +416,7 @@ func (h *AdminHandler) GetProfileMedia(w http.ResponseWriter, r *http.Request) {
 
 	candidateID, err := parseInt64Param(r, "candidateID")
 	if err != nil || candidateID <= 0 {
-		response.BadRequest(w, "INVALID_REQUEST", "candidateID tidak valid.")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.Invalid("candidateID"))
 		return
 	}
 
@@ -430,7 +431,7 @@ func (h *AdminHandler) GetProfileMedia(w http.ResponseWriter, r *http.Request) {
 		// Fetch blob from Supabase public URL
 		resp, err := http.Get(media.URL)
 		if err != nil {
-			response.InternalServerError(w, "INTERNAL_ERROR", "Gagal mengambil foto profil.")
+			response.Catalog(w, profilePhotoFetchFailed)
 			return
 		}
 		defer resp.Body.Close()
@@ -448,7 +449,7 @@ func (h *AdminHandler) GetProfileMedia(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Fallback: return 404 if no URL
-	response.NotFound(w, "MEDIA_NOT_FOUND", "Foto profil tidak ditemukan.")
+	response.Catalog(w, profilePhotoNotFound)
 }
 
 // DeleteProfileMedia handles DELETE /admin/candidates/{candidateID}/media/profile
@@ -457,13 +458,13 @@ func (h *AdminHandler) DeleteProfileMedia(w http.ResponseWriter, r *http.Request
 
 	candidateID, err := parseInt64Param(r, "candidateID")
 	if err != nil || candidateID <= 0 {
-		response.BadRequest(w, "INVALID_REQUEST", "candidateID tidak valid.")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.Invalid("candidateID"))
 		return
 	}
 
 	adminID, ok := ctxkeys.GetUserID(ctx)
 	if !ok {
-		response.Unauthorized(w, "UNAUTHORIZED", "User tidak valid.")
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.InvalidUser)
 		return
 	}
 
@@ -481,18 +482,18 @@ func (h *AdminHandler) UploadMedia(w http.ResponseWriter, r *http.Request) {
 
 	candidateID, err := parseInt64Param(r, "candidateID")
 	if err != nil || candidateID <= 0 {
-		response.BadRequest(w, "INVALID_REQUEST", "candidateID tidak valid.")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.Invalid("candidateID"))
 		return
 	}
 
 	adminID, ok := ctxkeys.GetUserID(ctx)
 	if !ok {
-		response.Unauthorized(w, "UNAUTHORIZED", "User tidak valid.")
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.InvalidUser)
 		return
 	}
 
 	if err := r.ParseMultipartForm(maxCandidateMediaSize + (512 << 10)); err != nil {
-		response.BadRequest(w, "INVALID_REQUEST", "Gagal membaca form upload.")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.UploadFormError)
 		return
 	}
 
@@ -501,11 +502,11 @@ func (h *AdminHandler) UploadMedia(w http.ResponseWriter, r *http.Request) {
 	if slotRaw != "" {
 		slotParsed, err := ParseCandidateMediaSlot(slotRaw)
 		if err != nil {
-			response.BadRequest(w, "INVALID_REQUEST", "slot tidak valid.")
+			response.BadRequest(w, "INVALID_REQUEST", errcatalog.Invalid("slot"))
 			return
 		}
 		if slotParsed == CandidateMediaSlotProfile {
-			response.BadRequest(w, "INVALID_REQUEST", "Gunakan endpoint /media/profile untuk foto profil.")
+			response.Catalog(w, profileMediaEndpoint)
 			return
 		}
 		slot = slotParsed
@@ -513,30 +514,30 @@ func (h *AdminHandler) UploadMedia(w http.ResponseWriter, r *http.Request) {
 
 	filePart, header, err := r.FormFile("file")
 	if err != nil {
-		response.BadRequest(w, "INVALID_REQUEST", "Field file wajib diisi.")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.FileRequired)
 		return
 	}
 	defer filePart.Close()
 
 	data, tooLarge, err := readCandidateMedia(filePart, maxCandidateMediaSize)
 	if err != nil {
-		response.BadRequest(w, "INVALID_REQUEST", "Gagal membaca file upload.")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.UploadFileError)
 		return
 	}
 	if tooLarge {
-		response.UnprocessableEntity(w, "FILE_TOO_LARGE", "Ukuran file maksimal 3MB.")
+		response.UnprocessableEntity(w, "FILE_TOO_LARGE", errcatalog.FileTooLarge)
 		return
 	}
 
 	mime := mimetype.Detect(data)
 	if !isAllowedCandidateMedia(slot, mime) {
-		response.UnprocessableEntity(w, "INVALID_FILE_TYPE", invalidMediaMessage(slot))
+		response.Catalog(w, invalidMediaType(slot))
 		return
 	}
 
 	mediaID, err := newCandidateMediaID()
 	if err != nil {
-		response.InternalServerError(w, "INTERNAL_ERROR", "Gagal menyiapkan penyimpanan media.")
+		response.Catalog(w, mediaStorageUnavailable)
 		return
 	}
 
@@ -571,13 +572,13 @@ func (h *AdminHandler) GetMedia(w http.ResponseWriter, r *http.Request) {
 
 	candidateID, err := parseInt64Param(r, "candidateID")
 	if err != nil || candidateID <= 0 {
-		response.BadRequest(w, "INVALID_REQUEST", "candidateID tidak valid.")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.Invalid("candidateID"))
 		return
 	}
 
 	mediaID := chi.URLParam(r, "mediaID")
 	if mediaID == "" {
-		response.BadRequest(w, "INVALID_REQUEST", "mediaID tidak valid.")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.Invalid("mediaID"))
 		return
 	}
 
@@ -599,13 +600,13 @@ func (h *AdminHandler) DeleteMedia(w http.ResponseWriter, r *http.Request) {
 
 	candidateID, err := parseInt64Param(r, "candidateID")
 	if err != nil || candidateID <= 0 {
-		response.BadRequest(w, "INVALID_REQUEST", "candidateID tidak valid.")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.Invalid("candidateID"))
 		return
 	}
 
 	mediaID := chi.URLParam(r, "mediaID")
 	if mediaID == "" {
-		response.BadRequest(w, "INVALID_REQUEST", "mediaID tidak valid.")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.Invalid("mediaID"))
 		return
 	}
 
@@ -638,17 +639,21 @@ func isAllowedCandidateMedia(slot CandidateMediaSlot, mime *mimetype.MIME) bool
 		return mime.Is("image/png") || mime.Is("image/jpeg")
 	case CandidateMediaSlotPDFProgram, CandidateMediaSlotPDFVisimisi:
 		return mime.Is("application/pdf")
+	case CandidateMediaSlotDocKTM, CandidateMediaSlotDocTranscript, CandidateMediaSlotDocStatement, CandidateMediaSlotDocRecommendation:
+		return mime.Is("application/pdf") || mime.Is("image/png") || mime.Is("image/jpeg")
 	default:
 		return false
 	}
 }
 
-func invalidMediaMessage(slot CandidateMediaSlot) string {
+func invalidMediaType(slot CandidateMediaSlot) errcatalog.Entry {
 	switch slot {
 	case CandidateMediaSlotPDFProgram, CandidateMediaSlotPDFVisimisi:
-		return "File harus berupa PDF."
+		return mediaPDFRequired
+	case CandidateMediaSlotDocKTM, CandidateMediaSlotDocTranscript, CandidateMediaSlotDocStatement, CandidateMediaSlotDocRecommendation:
+		return documentTypeInvalid
 	default:
-		return "File harus berupa PNG atau JPEG."
+		return mediaImageRequired
 	}
 }
 
@@ -670,30 +675,13 @@ func newCandidateMediaID() (string, error) {
 
 const maxCandidateMediaSize = int64(3 * 1024 * 1024) // ~3MB
 
-// handleError maps service errors to HTTP responses
+// handleError maps service errors to HTTP responses through the error
+// catalog (see errors.go)
 func (h *AdminHandler) handleError(w http.ResponseWriter, err error) {
-	switch {
-	case errors.Is(err, ErrElectionNotFound):
-		response.NotFound(w, "NOT_FOUND", "Pemilu tidak ditemukan.")
-
-	case errors.Is(err, ErrCandidateNotFound):
-		response.NotFound(w, "NOT_FOUND", "Kandidat tidak ditemukan.")
-
-	case errors.Is(err, ErrCandidateNumberTaken):
-		response.Conflict(w, "CANDIDATE_NUMBER_TAKEN", "Nomor kandidat sudah digunakan di pemilu ini.")
-
-	case errors.Is(err, ErrCandidateStatusInvalid):
-		response.BadRequest(w, "INVALID_REQUEST", "Perubahan status kandidat tidak diizinkan.")
-
-	case errors.Is(err, ErrCandidateMediaNotFound):
-		response.NotFound(w, "MEDIA_NOT_FOUND", "Media kandidat tidak ditemukan.")
-
-	case errors.Is(err, ErrInvalidCandidateMediaSlot):
-		response.BadRequest(w, "INVALID_REQUEST", "Slot media tidak valid.")
-
-	default:
-		slog.Error("candidate admin handler error", "err", err)
-		log.Printf("INTERNAL_ERROR in candidate handler: %v", err)
-		response.InternalServerError(w, "INTERNAL_ERROR", "Terjadi kesalahan pada sistem.")
+	if response.DomainError(w, err) {
+		return
 	}
+	slog.Error("candidate admin handler error", "err", err)
+	log.Printf("INTERNAL_ERROR in candidate handler: %v", err)
+	response.InternalServerError(w, "INTERNAL_ERROR", errcatalog.InternalError)
 }
@@ -0,0 +1,298 @@
+package candidate
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BallotDrawAlgorithm identifies the derivation below; it is stored in every
+// transcript so old draws stay verifiable if the scheme ever changes.
+//
+//	server_seed_hash = hex(SHA-256(server_seed))
+//	combined_seed    = hex(SHA-256("pemira-ballot-draw/v1\n" + election_id + "\n" + server_seed + "\n" +
+//	                       for each seed ordered by candidate_id: candidate_id + ":" + seed + "\n"))
+//	shuffle          = Fisher-Yates over candidate IDs sorted ascending, i from n-1 down to 1,
+//	                   j = uniform(0..i) from HMAC-SHA256(key=combined_seed bytes, msg=uint64 big-endian counter)
+//	                   taking the first 8 bytes, rejecting values >= the largest multiple of i+1
+//	number           = position in the shuffled list + 1
+//
+// Seeds are accepted until verification closes, the earliest the draw can be
+// revealed. A candidate without a seed by then gets a substitute the server
+// committed to along with its seed, so a missing seed can neither block the
+// draw nor be chosen after the fact:
+//
+//	substitute_seed  = "substitute:" + hex(HMAC-SHA256(key=server_seed, msg="missing-seed\n" + election_id + "\n" + candidate_id))
+const BallotDrawAlgorithm = "pemira-ballot-draw/v1"
+
+const maxBallotDrawSeedLength = 128
+
+// BallotDrawStatus is the lifecycle state of a ballot number draw.
+type BallotDrawStatus string
+
+const (
+	BallotDrawStatusCommitted BallotDrawStatus = "COMMITTED"
+	BallotDrawStatusRevealed  BallotDrawStatus = "REVEALED"
+)
+
+// BallotDrawSeed is one seed contributed by a candidate pair.
+type BallotDrawSeed struct {
+	CandidateID int64     `json:"candidate_id"`
+	Seed        string    `json:"seed"`
+	SubmittedBy *int64    `json:"submitted_by,omitempty"`
+	SubmittedAt time.Time `json:"submitted_at"`
+}
+
+// BallotDrawAssignment is a resulting ballot number.
+type BallotDrawAssignment struct {
+	CandidateID int64  `json:"candidate_id"`
+	Name        string `json:"name"`
+	Number      int    `json:"number"`
+}
+
+// BallotDrawSeedEntry is a seed as it enters the derivation. Substituted
+// marks a seed derived by SubstituteBallotDrawSeed for a candidate who did
+// not submit one.
+type BallotDrawSeedEntry struct {
+	CandidateID int64  `json:"candidate_id"`
+	Seed        string `json:"seed"`
+	Substituted bool   `json:"substituted,omitempty"`
+}
+
+// BallotDrawTranscript contains everything needed to recompute a draw.
+type BallotDrawTranscript struct {
+	Algorithm      string                 `json:"algorithm"`
+	ElectionID     int64                  `json:"election_id"`
+	ServerSeed     string                 `json:"server_seed"`
+	ServerSeedHash string                 `json:"server_seed_hash"`
+	Seeds          []BallotDrawSeedEntry  `json:"seeds"`
+	CombinedSeed   string                 `json:"combined_seed"`
+	Assignments    []BallotDrawAssignment `json:"assignments"`
+}
+
+// BallotDraw is the stored state of an election's ballot number draw. The
+// server seed is never read back before reveal; afterwards it is in the
+// transcript.
+type BallotDraw struct {
+	ID             int64                 `json:"id"`
+	ElectionID     int64                 `json:"election_id"`
+	Status         BallotDrawStatus      `json:"status"`
+	ServerSeedHash string                `json:"server_seed_hash"`
+	CombinedSeed   *string               `json:"combined_seed,omitempty"`
+	Transcript     *BallotDrawTranscript `json:"transcript,omitempty"`
+	CommittedBy    *int64                `json:"committed_by,omitempty"`
+	CommittedAt    time.Time             `json:"committed_at"`
+	RevealedBy     *int64                `json:"revealed_by,omitempty"`
+	RevealedAt     *time.Time            `json:"revealed_at,omitempty"`
+}
+
+// BallotDrawCandidate is a candidate taking part in the draw.
+type BallotDrawCandidate struct {
+	ID   int64
+	Name string
+}
+
+// BallotDrawRepository persists ballot number draws.
+type BallotDrawRepository interface {
+	GetDraw(ctx context.Context, electionID int64) (*BallotDraw, error)
+	CreateDraw(ctx context.Context, draw *BallotDraw, serverSeed string) error
+	// InsertSeed returns ErrBallotDrawSeedSubmitted when the candidate already
+	// has a seed; seeds cannot be replaced.
+	InsertSeed(ctx context.Context, electionID int64, seed BallotDrawSeed) error
+	ListSeeds(ctx context.Context, electionID int64) ([]BallotDrawSeed, error)
+	ListDrawCandidates(ctx context.Context, electionID int64) ([]BallotDrawCandidate, error)
+
+	// Reveal passes the committed server seed to compute, then stores the
+	// transcript and applies the numbers atomically. It is the only read of
+	// the server seed.
+	Reveal(ctx context.Context, electionID int64, revealedBy int64, compute func(serverSeed string) (*BallotDrawTranscript, error)) (*BallotDraw, error)
+
+	NumberLock
+}
+
+// NumberLock reports whether ballot numbers for an election are final.
+type NumberLock interface {
+	IsNumberLocked(ctx context.Context, electionID int64) (bool, error)
+}
+
+var (
+	ErrBallotDrawNotFound         = errors.New("ballot draw not found")
+	ErrBallotDrawAlreadyCommitted = errors.New("ballot draw already committed")
+	ErrBallotDrawAlreadyRevealed  = errors.New("ballot draw already revealed")
+	ErrBallotDrawNotReady         = errors.New("ballot draw cannot run before verification closes")
+	ErrBallotDrawSeedInvalid      = errors.New("ballot draw seed invalid")
+	ErrBallotDrawSeedSubmitted    = errors.New("ballot draw seed already submitted")
+	ErrBallotDrawSeedClosed       = errors.New("ballot draw seed deadline has passed")
+	ErrBallotDrawNoCandidates     = errors.New("no approved candidates to draw")
+	ErrBallotDrawNotParticipant   = errors.New("candidate is not part of the ballot draw")
+	ErrBallotDrawMismatch         = errors.New("ballot draw transcript does not match")
+	ErrCandidateNumberLocked      = errors.New("candidate numbers are locked after the ballot draw")
+)
+
+// HashServerSeed returns the commitment published before the draw.
+func HashServerSeed(serverSeed string) string {
+	sum := sha256.Sum256([]byte(serverSeed))
+	return hex.EncodeToString(sum[:])
+}
+
+// ValidateBallotDrawSeed checks a contributed seed.
+func ValidateBallotDrawSeed(seed string) error {
+	if seed == "" || len(seed) > maxBallotDrawSeedLength || strings.ContainsAny(seed, "\r\n") {
+		return ErrBallotDrawSeedInvalid
+	}
+	return nil
+}
+
+// SubstituteBallotDrawSeed returns the seed used for a candidate who did not
+// submit one before the deadline.
+func SubstituteBallotDrawSeed(electionID int64, serverSeed string, candidateID int64) string {
+	mac := hmac.New(sha256.New, []byte(serverSeed))
+	mac.Write([]byte("missing-seed\n" + strconv.FormatInt(electionID, 10) + "\n" + strconv.FormatInt(candidateID, 10)))
+	return "substitute:" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// CombineBallotDrawSeeds derives the shuffle key from the server seed and the candidate seeds.
+func CombineBallotDrawSeeds(electionID int64, serverSeed string, seeds []BallotDrawSeedEntry) string {
+	sorted := append([]BallotDrawSeedEntry(nil), seeds...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].CandidateID < sorted[j].CandidateID })
+
+	h := sha256.New()
+	h.Write([]byte(BallotDrawAlgorithm + "\n"))
+	h.Write([]byte(strconv.FormatInt(electionID, 10) + "\n"))
+	h.Write([]byte(serverSeed + "\n"))
+	for _, s := range sorted {
+		h.Write([]byte(strconv.FormatInt(s.CandidateID, 10) + ":" + s.Seed + "\n"))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// ShuffleBallotOrder returns candidate IDs in ballot order for the given combined seed.
+func ShuffleBallotOrder(combinedSeed string, candidateIDs []int64) ([]int64, error) {
+	key, err := hex.DecodeString(combinedSeed)
+	if err != nil {
+		return nil, fmt.Errorf("decode combined seed: %w", err)
+	}
+
+	order := append([]int64(nil), candidateIDs...)
+	sort.Slice(order, func(i, j int) bool { return order[i] < order[j] })
+
+	var counter uint64
+	next := func() uint64 {
+		mac := hmac.New(sha256.New, key)
+		var msg [8]byte
+		binary.BigEndian.PutUint64(msg[:], counter)
+		counter++
+		mac.Write(msg[:])
+		return binary.BigEndian.Uint64(mac.Sum(nil)[:8])
+	}
+
+	for i := len(order) - 1; i > 0; i-- {
+		n := uint64(i + 1)
+		limit := math.MaxUint64 - math.MaxUint64%n
+		v := next()
+		for v >= limit {
+			v = next()
+		}
+		j := int(v % n)
+		order[i], order[j] = order[j], order[i]
+	}
+	return order, nil
+}
+
+// ComputeBallotDraw runs the full derivation and builds a transcript. Seeds
+// marked Substituted are ignored and derived again.
+func ComputeBallotDraw(electionID int64, serverSeed string, candidates []BallotDrawCandidate, seeds []BallotDrawSeedEntry) (*BallotDrawTranscript, error) {
+	if len(candidates) == 0 {
+		return nil, ErrBallotDrawNoCandidates
+	}
+
+	names := make(map[int64]string, len(candidates))
+	ids := make([]int64, 0, len(candidates))
+	for _, c := range candidates {
+		names[c.ID] = c.Name
+		ids = append(ids, c.ID)
+	}
+
+	seedByCandidate := make(map[int64]string, len(seeds))
+	for _, s := range seeds {
+		if !s.Substituted {
+			seedByCandidate[s.CandidateID] = s.Seed
+		}
+	}
+	entries := make([]BallotDrawSeedEntry, 0, len(ids))
+	for _, id := range ids {
+		seed, ok := seedByCandidate[id]
+		if !ok {
+			entries = append(entries, BallotDrawSeedEntry{CandidateID: id, Seed: SubstituteBallotDrawSeed(electionID, serverSeed, id), Substituted: true})
+			continue
+		}
+		entries = append(entries, BallotDrawSeedEntry{CandidateID: id, Seed: seed})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].CandidateID < entries[j].CandidateID })
+
+	combined := CombineBallotDrawSeeds(electionID, serverSeed, entries)
+	order, err := ShuffleBallotOrder(combined, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	assignments := make([]BallotDrawAssignment, 0, len(order))
+	for i, id := range order {
+		assignments = append(assignments, BallotDrawAssignment{CandidateID: id, Name: names[id], Number: i + 1})
+	}
+
+	return &BallotDrawTranscript{
+		Algorithm:      BallotDrawAlgorithm,
+		ElectionID:     electionID,
+		ServerSeed:     serverSeed,
+		ServerSeedHash: HashServerSeed(serverSeed),
+		Seeds:          entries,
+		CombinedSeed:   combined,
+		Assignments:    assignments,
+	}, nil
+}
+
+// VerifyBallotDrawTranscript recomputes a transcript and reports any mismatch.
+func VerifyBallotDrawTranscript(t *BallotDrawTranscript) error {
+	if t.Algorithm != BallotDrawAlgorithm {
+		return fmt.Errorf("%w: unsupported algorithm %q", ErrBallotDrawMismatch, t.Algorithm)
+	}
+	if HashServerSeed(t.ServerSeed) != t.ServerSeedHash {
+		return fmt.Errorf("%w: server seed does not match commitment", ErrBallotDrawMismatch)
+	}
+
+	candidates := make([]BallotDrawCandidate, 0, len(t.Assignments))
+	for _, a := range t.Assignments {
+		candidates = append(candidates, BallotDrawCandidate{ID: a.CandidateID, Name: a.Name})
+	}
+	got, err := ComputeBallotDraw(t.ElectionID, t.ServerSeed, candidates, t.Seeds)
+	if err != nil {
+		return err
+	}
+	if got.CombinedSeed != t.CombinedSeed {
+		return fmt.Errorf("%w: combined seed", ErrBallotDrawMismatch)
+	}
+	if len(got.Seeds) != len(t.Seeds) {
+		return fmt.Errorf("%w: seeds", ErrBallotDrawMismatch)
+	}
+	for i := range got.Seeds {
+		if got.Seeds[i] != t.Seeds[i] {
+			return fmt.Errorf("%w: seed of candidate %d", ErrBallotDrawMismatch, t.Seeds[i].CandidateID)
+		}
+	}
+	for i := range got.Assignments {
+		if got.Assignments[i].CandidateID != t.Assignments[i].CandidateID || got.Assignments[i].Number != t.Assignments[i].Number {
+			return fmt.Errorf("%w: assignment %d", ErrBallotDrawMismatch, i+1)
+		}
+	}
+	return nil
+}
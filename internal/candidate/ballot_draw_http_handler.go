@@ -0,0 +1,139 @@
+package candidate
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"pemira-api/internal/errcatalog"
+	"pemira-api/internal/http/response"
+	"pemira-api/internal/shared/ctxkeys"
+)
+
+// BallotDrawHandler serves the ballot number draw (pengundian nomor urut).
+type BallotDrawHandler struct {
+	svc *BallotDrawService
+}
+
+func NewBallotDrawHandler(svc *BallotDrawService) *BallotDrawHandler {
+	return &BallotDrawHandler{svc: svc}
+}
+
+type BallotDrawSeedRequest struct {
+	Seed string `json:"seed"`
+}
+
+// GetPublic menangani GET /elections/{electionID}/ballot-draw
+func (h *BallotDrawHandler) GetPublic(w http.ResponseWriter, r *http.Request) {
+	electionID, err := parseInt64Param(r, "electionID")
+	if err != nil || electionID <= 0 {
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.Invalid("electionID"))
+		return
+	}
+
+	view, err := h.svc.Get(r.Context(), electionID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	response.Success(w, http.StatusOK, view)
+}
+
+// SubmitOwnSeed menangani POST /elections/{electionID}/candidacy/draw-seed
+func (h *BallotDrawHandler) SubmitOwnSeed(w http.ResponseWriter, r *http.Request) {
+	electionID, err := parseInt64Param(r, "electionID")
+	if err != nil || electionID <= 0 {
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.Invalid("electionID"))
+		return
+	}
+	userID, ok := ctxkeys.GetUserID(r.Context())
+	if !ok {
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.InvalidUser)
+		return
+	}
+
+	var req BallotDrawSeedRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.InvalidBody)
+		return
+	}
+
+	view, err := h.svc.SubmitOwnSeed(r.Context(), electionID, userID, req.Seed)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	response.Success(w, http.StatusOK, view)
+}
+
+// Get menangani GET /admin/elections/{electionID}/ballot-draw
+func (h *BallotDrawHandler) Get(w http.ResponseWriter, r *http.Request) {
+	electionID, err := parseInt64Param(r, "electionID")
+	if err != nil || electionID <= 0 {
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.Invalid("electionID"))
+		return
+	}
+
+	view, err := h.svc.Get(r.Context(), electionID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, view)
+}
+
+// Commit menangani POST /admin/elections/{electionID}/ballot-draw/commit
+func (h *BallotDrawHandler) Commit(w http.ResponseWriter, r *http.Request) {
+	electionID, adminID, ok := h.adminScope(w, r)
+	if !ok {
+		return
+	}
+
+	view, err := h.svc.Commit(r.Context(), electionID, adminID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, view)
+}
+
+// Reveal menangani POST /admin/elections/{electionID}/ballot-draw/reveal
+func (h *BallotDrawHandler) Reveal(w http.ResponseWriter, r *http.Request) {
+	electionID, adminID, ok := h.adminScope(w, r)
+	if !ok {
+		return
+	}
+
+	view, err := h.svc.Reveal(r.Context(), electionID, adminID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, view)
+}
+
+func (h *BallotDrawHandler) adminScope(w http.ResponseWriter, r *http.Request) (int64, int64, bool) {
+	electionID, err := parseInt64Param(r, "electionID")
+	if err != nil || electionID <= 0 {
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.Invalid("electionID"))
+		return 0, 0, false
+	}
+	adminID, ok := ctxkeys.GetUserID(r.Context())
+	if !ok {
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.InvalidUser)
+		return 0, 0, false
+	}
+	return electionID, adminID, true
+}
+
+func (h *BallotDrawHandler) handleError(w http.ResponseWriter, err error) {
+	if errors.Is(err, ErrCandidacyNotFound) {
+		err = ErrCandidateNotFound
+	}
+	if response.DomainError(w, err) {
+		return
+	}
+	slog.Error("ballot draw handler error", "err", err)
+	response.InternalServerError(w, "INTERNAL_ERROR", errcatalog.InternalError)
+}
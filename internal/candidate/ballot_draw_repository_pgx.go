@@ -0,0 +1,218 @@
+package candidate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PgBallotDrawRepository implements BallotDrawRepository using pgxpool
+type PgBallotDrawRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewPgBallotDrawRepository creates a new PostgreSQL ballot draw repository
+func NewPgBallotDrawRepository(db *pgxpool.Pool) *PgBallotDrawRepository {
+	return &PgBallotDrawRepository{db: db}
+}
+
+// server_seed is deliberately not selected; only Reveal reads it.
+const ballotDrawColumns = `
+id, election_id, status, server_seed_hash, combined_seed, transcript,
+committed_by, committed_at, revealed_by, revealed_at
+`
+
+func scanBallotDraw(row pgx.Row) (*BallotDraw, error) {
+	var d BallotDraw
+	var transcriptRaw []byte
+	if err := row.Scan(
+		&d.ID,
+		&d.ElectionID,
+		&d.Status,
+		&d.ServerSeedHash,
+		&d.CombinedSeed,
+		&transcriptRaw,
+		&d.CommittedBy,
+		&d.CommittedAt,
+		&d.RevealedBy,
+		&d.RevealedAt,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrBallotDrawNotFound
+		}
+		return nil, err
+	}
+	if len(transcriptRaw) > 0 {
+		var t BallotDrawTranscript
+		if err := json.Unmarshal(transcriptRaw, &t); err != nil {
+			return nil, fmt.Errorf("unmarshal transcript: %w", err)
+		}
+		d.Transcript = &t
+	}
+	return &d, nil
+}
+
+func (r *PgBallotDrawRepository) GetDraw(ctx context.Context, electionID int64) (*BallotDraw, error) {
+	row := r.db.QueryRow(ctx, `SELECT `+ballotDrawColumns+` FROM candidate_ballot_draws WHERE election_id = $1`, electionID)
+	return scanBallotDraw(row)
+}
+
+func (r *PgBallotDrawRepository) CreateDraw(ctx context.Context, draw *BallotDraw, serverSeed string) error {
+	const q = `
+INSERT INTO candidate_ballot_draws (election_id, status, server_seed_hash, server_seed, committed_by)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, committed_at
+`
+	err := r.db.QueryRow(ctx, q, draw.ElectionID, draw.Status, draw.ServerSeedHash, serverSeed, draw.CommittedBy).
+		Scan(&draw.ID, &draw.CommittedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return ErrBallotDrawAlreadyCommitted
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *PgBallotDrawRepository) InsertSeed(ctx context.Context, electionID int64, seed BallotDrawSeed) error {
+	const q = `
+INSERT INTO candidate_ballot_draw_seeds (election_id, candidate_id, seed, submitted_by)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (election_id, candidate_id) DO NOTHING
+`
+	tag, err := r.db.Exec(ctx, q, electionID, seed.CandidateID, seed.Seed, seed.SubmittedBy)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrBallotDrawSeedSubmitted
+	}
+	return nil
+}
+
+func (r *PgBallotDrawRepository) ListSeeds(ctx context.Context, electionID int64) ([]BallotDrawSeed, error) {
+	const q = `
+SELECT candidate_id, seed, submitted_by, submitted_at
+FROM candidate_ballot_draw_seeds
+WHERE election_id = $1
+ORDER BY candidate_id
+`
+	rows, err := r.db.Query(ctx, q, electionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []BallotDrawSeed{}
+	for rows.Next() {
+		var s BallotDrawSeed
+		if err := rows.Scan(&s.CandidateID, &s.Seed, &s.SubmittedBy, &s.SubmittedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, s)
+	}
+	return items, rows.Err()
+}
+
+func (r *PgBallotDrawRepository) ListDrawCandidates(ctx context.Context, electionID int64) ([]BallotDrawCandidate, error) {
+	const q = `
+SELECT id, name
+FROM candidates
+WHERE election_id = $1
+  AND deleted_at IS NULL
+  AND status IN ('APPROVED', 'PUBLISHED')
+ORDER BY id
+`
+	rows, err := r.db.Query(ctx, q, electionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []BallotDrawCandidate{}
+	for rows.Next() {
+		var c BallotDrawCandidate
+		if err := rows.Scan(&c.ID, &c.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, c)
+	}
+	return items, rows.Err()
+}
+
+func (r *PgBallotDrawRepository) Reveal(ctx context.Context, electionID int64, revealedBy int64, compute func(serverSeed string) (*BallotDrawTranscript, error)) (*BallotDraw, error) {
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var status BallotDrawStatus
+	var serverSeed string
+	err = tx.QueryRow(ctx, `SELECT status, server_seed FROM candidate_ballot_draws WHERE election_id = $1 FOR UPDATE`, electionID).Scan(&status, &serverSeed)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrBallotDrawNotFound
+		}
+		return nil, err
+	}
+	if status == BallotDrawStatusRevealed {
+		return nil, ErrBallotDrawAlreadyRevealed
+	}
+
+	transcript, err := compute(serverSeed)
+	if err != nil {
+		return nil, err
+	}
+	transcriptJSON, err := json.Marshal(transcript)
+	if err != nil {
+		return nil, fmt.Errorf("marshal transcript: %w", err)
+	}
+
+	// Numbers are unique per election, so clear every existing number first
+	// (including soft-deleted rows) before writing the drawn ones.
+	if _, err := tx.Exec(ctx, `UPDATE candidates SET number = NULL WHERE election_id = $1 AND number IS NOT NULL`, electionID); err != nil {
+		return nil, err
+	}
+	for _, a := range transcript.Assignments {
+		tag, err := tx.Exec(ctx, `
+UPDATE candidates SET number = $3, updated_at = NOW()
+WHERE election_id = $1 AND id = $2 AND deleted_at IS NULL
+`, electionID, a.CandidateID, a.Number)
+		if err != nil {
+			return nil, err
+		}
+		if tag.RowsAffected() == 0 {
+			return nil, fmt.Errorf("%w: %d", ErrCandidateNotFound, a.CandidateID)
+		}
+	}
+
+	row := tx.QueryRow(ctx, `
+UPDATE candidate_ballot_draws
+SET status = $2, combined_seed = $3, transcript = $4, revealed_by = $5, revealed_at = NOW()
+WHERE election_id = $1
+RETURNING `+ballotDrawColumns, electionID, BallotDrawStatusRevealed, transcript.CombinedSeed, string(transcriptJSON), revealedBy)
+	draw, err := scanBallotDraw(row)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return draw, nil
+}
+
+func (r *PgBallotDrawRepository) IsNumberLocked(ctx context.Context, electionID int64) (bool, error) {
+	var locked bool
+	err := r.db.QueryRow(ctx, `
+SELECT EXISTS(SELECT 1 FROM candidate_ballot_draws WHERE election_id = $1 AND status = 'REVEALED')
+`, electionID).Scan(&locked)
+	return locked, err
+}
@@ -0,0 +1,234 @@
+package candidate
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+)
+
+// BallotDrawView is the public state of a draw. Before reveal it carries only
+// the commitment and which candidates have submitted a seed: a visible seed
+// would let the last candidate to submit pick one that favours them. Seeds
+// are shown once revealed, from the transcript.
+type BallotDrawView struct {
+	ElectionID     int64                 `json:"election_id"`
+	Status         BallotDrawStatus      `json:"status"`
+	Algorithm      string                `json:"algorithm"`
+	ServerSeedHash string                `json:"server_seed_hash"`
+	CommittedAt    time.Time             `json:"committed_at"`
+	RevealedAt     *time.Time            `json:"revealed_at,omitempty"`
+	SeedDeadline   *time.Time            `json:"seed_deadline,omitempty"`
+	Submitted      []BallotDrawSubmitted `json:"submitted"`
+	Seeds          []BallotDrawSeedEntry `json:"seeds"`
+	Transcript     *BallotDrawTranscript `json:"transcript,omitempty"`
+}
+
+// BallotDrawSubmitted records that a candidate's seed is in, without the seed.
+type BallotDrawSubmitted struct {
+	CandidateID int64     `json:"candidate_id"`
+	SubmittedAt time.Time `json:"submitted_at"`
+}
+
+// BallotDrawService runs the commit-reveal ballot number draw
+type BallotDrawService struct {
+	repo      BallotDrawRepository
+	candidacy CandidacyRepository
+	now       func() time.Time
+}
+
+// NewBallotDrawService creates a new ballot draw service
+func NewBallotDrawService(repo BallotDrawRepository, candidacy CandidacyRepository) *BallotDrawService {
+	return &BallotDrawService{
+		repo:      repo,
+		candidacy: candidacy,
+		now:       time.Now,
+	}
+}
+
+// Get returns the public view of an election's draw.
+func (s *BallotDrawService) Get(ctx context.Context, electionID int64) (*BallotDrawView, error) {
+	draw, err := s.repo.GetDraw(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+	seeds, err := s.repo.ListSeeds(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+	w, err := s.candidacy.GetWindow(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+
+	view := &BallotDrawView{
+		ElectionID:     draw.ElectionID,
+		Status:         draw.Status,
+		Algorithm:      BallotDrawAlgorithm,
+		ServerSeedHash: draw.ServerSeedHash,
+		CommittedAt:    draw.CommittedAt,
+		RevealedAt:     draw.RevealedAt,
+		SeedDeadline:   w.VerificationEndAt,
+		Submitted:      make([]BallotDrawSubmitted, 0, len(seeds)),
+		Seeds:          []BallotDrawSeedEntry{},
+		Transcript:     draw.Transcript,
+	}
+	for _, sd := range seeds {
+		view.Submitted = append(view.Submitted, BallotDrawSubmitted{CandidateID: sd.CandidateID, SubmittedAt: sd.SubmittedAt})
+	}
+	if draw.Transcript != nil {
+		view.Seeds = draw.Transcript.Seeds
+	}
+	return view, nil
+}
+
+// Commit generates the secret server seed and publishes its hash. It can only
+// happen once per election so the seed cannot be re-rolled after candidates
+// have contributed theirs.
+func (s *BallotDrawService) Commit(ctx context.Context, electionID, adminID int64) (*BallotDrawView, error) {
+	if _, err := s.candidacy.GetWindow(ctx, electionID); err != nil {
+		return nil, err
+	}
+
+	var buf [32]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return nil, err
+	}
+	serverSeed := hex.EncodeToString(buf[:])
+
+	draw := &BallotDraw{
+		ElectionID:     electionID,
+		Status:         BallotDrawStatusCommitted,
+		ServerSeedHash: HashServerSeed(serverSeed),
+		CommittedBy:    &adminID,
+	}
+	if err := s.repo.CreateDraw(ctx, draw, serverSeed); err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, electionID)
+}
+
+// SubmitOwnSeed records the seed for the candidacy owned by the applicant.
+// A seed is written once: neither the candidate nor an admin can replace it.
+// Seeds close with verification; a candidate without one by then gets the
+// substitute described at BallotDrawAlgorithm.
+func (s *BallotDrawService) SubmitOwnSeed(ctx context.Context, electionID, userID int64, seed string) (*BallotDrawView, error) {
+	app, err := s.candidacy.GetApplicationByApplicant(ctx, electionID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.submitSeed(ctx, electionID, app.CandidateID, userID, seed)
+}
+
+func (s *BallotDrawService) submitSeed(ctx context.Context, electionID, candidateID, submittedBy int64, seed string) (*BallotDrawView, error) {
+	if err := ValidateBallotDrawSeed(seed); err != nil {
+		return nil, err
+	}
+
+	draw, err := s.repo.GetDraw(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+	if draw.Status == BallotDrawStatusRevealed {
+		return nil, ErrBallotDrawAlreadyRevealed
+	}
+	w, err := s.candidacy.GetWindow(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+	if verificationClosed(w, s.now()) {
+		return nil, ErrBallotDrawSeedClosed
+	}
+
+	candidates, err := s.repo.ListDrawCandidates(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+	participant := false
+	for _, c := range candidates {
+		if c.ID == candidateID {
+			participant = true
+			break
+		}
+	}
+	if !participant {
+		return nil, ErrBallotDrawNotParticipant
+	}
+
+	if err := s.repo.InsertSeed(ctx, electionID, BallotDrawSeed{
+		CandidateID: candidateID,
+		Seed:        seed,
+		SubmittedBy: &submittedBy,
+	}); err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, electionID)
+}
+
+// Reveal runs the draw once verification has closed and locks the numbers.
+func (s *BallotDrawService) Reveal(ctx context.Context, electionID, adminID int64) (*BallotDrawView, error) {
+	w, err := s.candidacy.GetWindow(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+	if !verificationClosed(w, s.now()) {
+		return nil, ErrBallotDrawNotReady
+	}
+
+	draw, err := s.repo.GetDraw(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+	if draw.Status == BallotDrawStatusRevealed {
+		return nil, ErrBallotDrawAlreadyRevealed
+	}
+
+	candidates, err := s.repo.ListDrawCandidates(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+	seeds, err := s.repo.ListSeeds(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]BallotDrawSeedEntry, 0, len(seeds))
+	for _, sd := range seeds {
+		entries = append(entries, BallotDrawSeedEntry{CandidateID: sd.CandidateID, Seed: sd.Seed})
+	}
+
+	_, err = s.repo.Reveal(ctx, electionID, adminID, func(serverSeed string) (*BallotDrawTranscript, error) {
+		return ComputeBallotDraw(electionID, serverSeed, candidates, entries)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, electionID)
+}
+
+// verificationClosed uses the verification end date when configured, and the
+// election status otherwise.
+func verificationClosed(w *CandidacyWindow, now time.Time) bool {
+	if w.VerificationEndAt != nil {
+		return now.After(*w.VerificationEndAt)
+	}
+	switch w.Status {
+	case "DRAFT", "REGISTRATION", "REGISTRATION_OPEN", "VERIFICATION":
+		return false
+	}
+	return true
+}
+
+// ensureNumbersUnlocked returns ErrCandidateNumberLocked once the draw has been revealed.
+func ensureNumbersUnlocked(ctx context.Context, lock NumberLock, electionID int64) error {
+	if lock == nil {
+		return nil
+	}
+	locked, err := lock.IsNumberLocked(ctx, electionID)
+	if err != nil {
+		return err
+	}
+	if locked {
+		return ErrCandidateNumberLocked
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+package candidate
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func drawFixture() ([]BallotDrawCandidate, []BallotDrawSeedEntry) {
+	candidates := []BallotDrawCandidate{{ID: 12, Name: "A"}, {ID: 7, Name: "B"}, {ID: 31, Name: "C"}, {ID: 19, Name: "D"}}
+	seeds := []BallotDrawSeedEntry{
+		{CandidateID: 31, Seed: "garuda"},
+		{CandidateID: 7, Seed: "merdeka"},
+		{CandidateID: 19, Seed: "1945"},
+		{CandidateID: 12, Seed: "pemira"},
+	}
+	return candidates, seeds
+}
+
+func Test_ComputeBallotDraw_DeterministicAndVerifiable(t *testing.T) {
+	candidates, seeds := drawFixture()
+
+	first, err := ComputeBallotDraw(3, "server-seed", candidates, seeds)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := ComputeBallotDraw(3, "server-seed", candidates, seeds)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first.CombinedSeed != second.CombinedSeed {
+		t.Fatal("combined seed must be deterministic")
+	}
+
+	seen := map[int]bool{}
+	for i, a := range first.Assignments {
+		if a.Number != i+1 || seen[a.Number] {
+			t.Fatalf("unexpected numbering %+v", first.Assignments)
+		}
+		seen[a.Number] = true
+		if second.Assignments[i].CandidateID != a.CandidateID {
+			t.Fatal("shuffle must be deterministic")
+		}
+	}
+
+	if err := VerifyBallotDrawTranscript(first); err != nil {
+		t.Fatalf("expected transcript to verify, got %v", err)
+	}
+}
+
+func Test_VerifyBallotDrawTranscript_DetectsTampering(t *testing.T) {
+	candidates, seeds := drawFixture()
+	tr, err := ComputeBallotDraw(3, "server-seed", candidates, seeds)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tr.Assignments[0].CandidateID, tr.Assignments[1].CandidateID = tr.Assignments[1].CandidateID, tr.Assignments[0].CandidateID
+	if err := VerifyBallotDrawTranscript(tr); !errors.Is(err, ErrBallotDrawMismatch) {
+		t.Fatalf("expected ErrBallotDrawMismatch, got %v", err)
+	}
+
+	tr, _ = ComputeBallotDraw(3, "server-seed", candidates, seeds)
+	tr.ServerSeed = "other-seed"
+	if err := VerifyBallotDrawTranscript(tr); !errors.Is(err, ErrBallotDrawMismatch) {
+		t.Fatalf("expected ErrBallotDrawMismatch, got %v", err)
+	}
+}
+
+func Test_ComputeBallotDraw_SeedChangesOrderInputs(t *testing.T) {
+	candidates, seeds := drawFixture()
+	a, _ := ComputeBallotDraw(3, "server-seed", candidates, seeds)
+	seeds[0].Seed = "garuda!"
+	b, _ := ComputeBallotDraw(3, "server-seed", candidates, seeds)
+	if a.CombinedSeed == b.CombinedSeed {
+		t.Fatal("every candidate seed must influence the combined seed")
+	}
+}
+
+func Test_ComputeBallotDraw_SubstitutesMissingSeed(t *testing.T) {
+	candidates, seeds := drawFixture()
+	tr, err := ComputeBallotDraw(3, "server-seed", candidates, seeds[1:]) // candidate 31 never submitted
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var missing BallotDrawSeedEntry
+	for _, sd := range tr.Seeds {
+		if sd.CandidateID == 31 {
+			missing = sd
+		} else if sd.Substituted {
+			t.Fatalf("submitted seed marked substituted: %+v", sd)
+		}
+	}
+	if !missing.Substituted || missing.Seed != SubstituteBallotDrawSeed(3, "server-seed", 31) {
+		t.Fatalf("expected a substitute derived from the server seed, got %+v", missing)
+	}
+	if SubstituteBallotDrawSeed(3, "other-seed", 31) == missing.Seed {
+		t.Fatal("substitute must depend on the server seed")
+	}
+	if err := VerifyBallotDrawTranscript(tr); err != nil {
+		t.Fatalf("expected transcript to verify, got %v", err)
+	}
+
+	forged, _ := ComputeBallotDraw(3, "server-seed", candidates, seeds[1:])
+	for i := range forged.Seeds {
+		if forged.Seeds[i].Substituted {
+			forged.Seeds[i].Seed = "substitute:chosen"
+		}
+	}
+	if err := VerifyBallotDrawTranscript(forged); !errors.Is(err, ErrBallotDrawMismatch) {
+		t.Fatalf("expected a forged substitute to fail verification, got %v", err)
+	}
+}
+
+type drawRepo struct {
+	BallotDrawRepository
+	draw  BallotDraw
+	seeds []BallotDrawSeed
+}
+
+func (r *drawRepo) GetDraw(ctx context.Context, electionID int64) (*BallotDraw, error) {
+	d := r.draw
+	return &d, nil
+}
+
+func (r *drawRepo) ListSeeds(ctx context.Context, electionID int64) ([]BallotDrawSeed, error) {
+	return r.seeds, nil
+}
+
+func (r *drawRepo) ListDrawCandidates(ctx context.Context, electionID int64) ([]BallotDrawCandidate, error) {
+	candidates, _ := drawFixture()
+	return candidates, nil
+}
+
+func (r *drawRepo) InsertSeed(ctx context.Context, electionID int64, seed BallotDrawSeed) error {
+	r.seeds = append(r.seeds, seed)
+	return nil
+}
+
+type drawWindow struct {
+	CandidacyRepository
+	window CandidacyWindow
+}
+
+func (c drawWindow) GetWindow(ctx context.Context, electionID int64) (*CandidacyWindow, error) {
+	w := c.window
+	return &w, nil
+}
+
+func (c drawWindow) GetApplicationByApplicant(ctx context.Context, electionID, applicantUserID int64) (*CandidacyApplication, error) {
+	return &CandidacyApplication{CandidateID: 7, ElectionID: electionID, ApplicantUserID: applicantUserID}, nil
+}
+
+func Test_BallotDrawService_HidesSeedsUntilReveal(t *testing.T) {
+	deadline := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	repo := &drawRepo{draw: BallotDraw{ElectionID: 3, Status: BallotDrawStatusCommitted}}
+	svc := NewBallotDrawService(repo, drawWindow{window: CandidacyWindow{ElectionID: 3, VerificationEndAt: &deadline}})
+	svc.now = func() time.Time { return deadline.Add(-time.Hour) }
+
+	view, err := svc.SubmitOwnSeed(context.Background(), 3, 50, "merdeka")
+	if err != nil {
+		t.Fatalf("submit seed: %v", err)
+	}
+	if len(view.Seeds) != 0 {
+		t.Fatalf("seeds visible before reveal: %+v", view.Seeds)
+	}
+	if len(view.Submitted) != 1 || view.Submitted[0].CandidateID != 7 {
+		t.Fatalf("expected candidate 7 listed as submitted, got %+v", view.Submitted)
+	}
+	if view.SeedDeadline == nil || !view.SeedDeadline.Equal(deadline) {
+		t.Fatalf("seed deadline not shown: %v", view.SeedDeadline)
+	}
+
+	repo.draw.Status = BallotDrawStatusRevealed
+	repo.draw.Transcript = &BallotDrawTranscript{Seeds: []BallotDrawSeedEntry{{CandidateID: 7, Seed: "merdeka"}}}
+	view, err = svc.Get(context.Background(), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(view.Seeds) != 1 || view.Seeds[0].Seed != "merdeka" {
+		t.Fatalf("seeds should be public after reveal, got %+v", view.Seeds)
+	}
+}
+
+func Test_BallotDrawService_RejectsSeedAfterDeadline(t *testing.T) {
+	deadline := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	repo := &drawRepo{draw: BallotDraw{ElectionID: 3, Status: BallotDrawStatusCommitted}}
+	svc := NewBallotDrawService(repo, drawWindow{window: CandidacyWindow{ElectionID: 3, VerificationEndAt: &deadline}})
+	svc.now = func() time.Time { return deadline.Add(time.Minute) }
+
+	if _, err := svc.SubmitOwnSeed(context.Background(), 3, 50, "late"); !errors.Is(err, ErrBallotDrawSeedClosed) {
+		t.Fatalf("expected ErrBallotDrawSeedClosed, got %v", err)
+	}
+	if len(repo.seeds) != 0 {
+		t.Fatalf("late seed stored: %+v", repo.seeds)
+	}
+}
+
+func Test_ValidateBallotDrawSeed(t *testing.T) {
+	if err := ValidateBallotDrawSeed("ok"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, bad := range []string{"", "a\nb"} {
+		if err := ValidateBallotDrawSeed(bad); !errors.Is(err, ErrBallotDrawSeedInvalid) {
+			t.Fatalf("expected ErrBallotDrawSeedInvalid for %q", bad)
+		}
+	}
+}
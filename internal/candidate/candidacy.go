@@ -0,0 +1,130 @@
+package candidate
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// CandidacyRequirement describes a document an applicant must upload before
+// submitting a candidacy, and which the committee checks during verification.
+type CandidacyRequirement struct {
+	Key   string             `json:"key"`
+	Label string             `json:"label"`
+	Slot  CandidateMediaSlot `json:"slot"`
+}
+
+// CandidacyRequirements is the checklist used for every self-registered candidacy.
+var CandidacyRequirements = []CandidacyRequirement{
+	{Key: "ktm", Label: "Kartu Tanda Mahasiswa", Slot: CandidateMediaSlotDocKTM},
+	{Key: "transcript", Label: "Transkrip nilai terakhir", Slot: CandidateMediaSlotDocTranscript},
+	{Key: "statement", Label: "Surat pernyataan kesediaan", Slot: CandidateMediaSlotDocStatement},
+	{Key: "recommendation", Label: "Surat rekomendasi organisasi", Slot: CandidateMediaSlotDocRecommendation},
+	{Key: "vision_mission", Label: "Dokumen visi dan misi", Slot: CandidateMediaSlotPDFVisimisi},
+}
+
+// ReviewDecision is the outcome of a committee verification.
+type ReviewDecision string
+
+const (
+	ReviewDecisionApprove         ReviewDecision = "APPROVE"
+	ReviewDecisionRequestRevision ReviewDecision = "REQUEST_REVISION"
+	ReviewDecisionReject          ReviewDecision = "REJECT"
+)
+
+// ChecklistItem records the committee verdict for one requirement.
+type ChecklistItem struct {
+	Key     string `json:"key"`
+	Passed  bool   `json:"passed"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// CandidacyApplication links a candidate row to the student who submitted it.
+type CandidacyApplication struct {
+	CandidateID     int64      `json:"candidate_id"`
+	ElectionID      int64      `json:"election_id"`
+	ApplicantUserID int64      `json:"applicant_user_id"`
+	SubmittedAt     *time.Time `json:"submitted_at,omitempty"`
+	DecidedAt       *time.Time `json:"decided_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// CandidacyApplicationSummary is a list row for committee review queues.
+type CandidacyApplicationSummary struct {
+	CandidacyApplication
+	Name             string          `json:"name"`
+	FacultyName      string          `json:"faculty_name"`
+	StudyProgramName string          `json:"study_program_name"`
+	Status           CandidateStatus `json:"status"`
+	ApplicantName    string          `json:"applicant_name"`
+}
+
+// CandidacyReview is a single verification decision made by a committee member.
+type CandidacyReview struct {
+	ID          int64           `json:"id"`
+	CandidateID int64           `json:"candidate_id"`
+	ElectionID  int64           `json:"election_id"`
+	ReviewerID  *int64          `json:"reviewer_id,omitempty"`
+	Decision    ReviewDecision  `json:"decision"`
+	Checklist   []ChecklistItem `json:"checklist"`
+	Comment     string          `json:"comment"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+// CandidacyNotification is a status message delivered to an applicant.
+type CandidacyNotification struct {
+	ID          int64      `json:"id"`
+	CandidateID int64      `json:"candidate_id"`
+	UserID      int64      `json:"user_id"`
+	Type        string     `json:"type"`
+	Title       string     `json:"title"`
+	Message     string     `json:"message"`
+	ReadAt      *time.Time `json:"read_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// CandidacyWindow holds the election fields that gate self-registration.
+type CandidacyWindow struct {
+	ElectionID          int64
+	Status              string
+	RegistrationStartAt *time.Time
+	RegistrationEndAt   *time.Time
+	VerificationStartAt *time.Time
+	VerificationEndAt   *time.Time
+}
+
+// CandidacyRepository defines persistence for the self-registration workflow.
+type CandidacyRepository interface {
+	GetWindow(ctx context.Context, electionID int64) (*CandidacyWindow, error)
+
+	// CreateApplication inserts a DRAFT candidate without ballot number and links it to the applicant.
+	CreateApplication(ctx context.Context, applicantUserID int64, c *Candidate) (*CandidacyApplication, error)
+	GetApplicationByApplicant(ctx context.Context, electionID, applicantUserID int64) (*CandidacyApplication, error)
+	GetApplication(ctx context.Context, electionID, candidateID int64) (*CandidacyApplication, error)
+	ListApplications(ctx context.Context, electionID int64, status *CandidateStatus) ([]CandidacyApplicationSummary, error)
+
+	// MarkSubmitted moves the candidate to PENDING and stamps submitted_at.
+	MarkSubmitted(ctx context.Context, electionID, candidateID int64, at time.Time) error
+
+	// SaveReview stores the review and applies the resulting status atomically.
+	// On approval a ballot number is assigned when the candidate has none; it is returned.
+	SaveReview(ctx context.Context, review *CandidacyReview, status CandidateStatus) (int, error)
+	ListReviews(ctx context.Context, candidateID int64) ([]CandidacyReview, error)
+
+	CreateNotification(ctx context.Context, n *CandidacyNotification) error
+	ListNotifications(ctx context.Context, candidateID, userID int64) ([]CandidacyNotification, error)
+}
+
+var (
+	ErrCandidacyNotFound        = errors.New("candidacy application not found")
+	ErrCandidacyAlreadyExists   = errors.New("candidacy already submitted for this election")
+	ErrCandidacyWindowClosed    = errors.New("candidacy registration window closed")
+	ErrCandidacyNotEditable     = errors.New("candidacy cannot be edited in its current status")
+	ErrCandidacyNotReviewable   = errors.New("candidacy is not awaiting review")
+	ErrCandidacyDocumentMissing = errors.New("candidacy requirement document missing")
+	ErrInvalidReviewDecision    = errors.New("invalid review decision")
+	ErrChecklistIncomplete      = errors.New("review checklist incomplete")
+	ErrReviewCommentRequired    = errors.New("review comment required")
+	ErrCandidacyNameRequired    = errors.New("candidate name required")
+)
@@ -0,0 +1,285 @@
+package candidate
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/gabriel-vasile/mimetype"
+
+	"pemira-api/internal/errcatalog"
+	"pemira-api/internal/http/response"
+	"pemira-api/internal/shared/ctxkeys"
+)
+
+// CandidacyHandler serves the candidate self-registration endpoints for
+// students and the verification endpoints for the committee.
+type CandidacyHandler struct {
+	svc *CandidacyService
+}
+
+func NewCandidacyHandler(svc *CandidacyService) *CandidacyHandler {
+	return &CandidacyHandler{svc: svc}
+}
+
+// Apply menangani POST /elections/{electionID}/candidacy
+func (h *CandidacyHandler) Apply(w http.ResponseWriter, r *http.Request) {
+	electionID, userID, ok := h.studentScope(w, r)
+	if !ok {
+		return
+	}
+
+	var req CandidacyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.InvalidBody)
+		return
+	}
+
+	dto, err := h.svc.Apply(r.Context(), electionID, userID, req)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	response.Success(w, http.StatusCreated, dto)
+}
+
+// GetMine menangani GET /elections/{electionID}/candidacy
+func (h *CandidacyHandler) GetMine(w http.ResponseWriter, r *http.Request) {
+	electionID, userID, ok := h.studentScope(w, r)
+	if !ok {
+		return
+	}
+
+	dto, err := h.svc.GetMine(r.Context(), electionID, userID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	response.Success(w, http.StatusOK, dto)
+}
+
+// UpdateMine menangani PUT /elections/{electionID}/candidacy
+func (h *CandidacyHandler) UpdateMine(w http.ResponseWriter, r *http.Request) {
+	electionID, userID, ok := h.studentScope(w, r)
+	if !ok {
+		return
+	}
+
+	var req CandidacyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.InvalidBody)
+		return
+	}
+
+	dto, err := h.svc.UpdateMine(r.Context(), electionID, userID, req)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	response.Success(w, http.StatusOK, dto)
+}
+
+// UploadDocument menangani POST /elections/{electionID}/candidacy/documents
+func (h *CandidacyHandler) UploadDocument(w http.ResponseWriter, r *http.Request) {
+	electionID, userID, ok := h.studentScope(w, r)
+	if !ok {
+		return
+	}
+
+	if err := r.ParseMultipartForm(maxCandidateMediaSize + (512 << 10)); err != nil {
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.UploadFormError)
+		return
+	}
+
+	slot, err := ParseCandidateMediaSlot(r.FormValue("slot"))
+	if err != nil || requirementForSlot(slot) == nil {
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.Invalid("slot"))
+		return
+	}
+
+	filePart, header, err := r.FormFile("file")
+	if err != nil {
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.FileRequired)
+		return
+	}
+	defer filePart.Close()
+
+	data, tooLarge, err := readCandidateMedia(filePart, maxCandidateMediaSize)
+	if err != nil {
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.UploadFileError)
+		return
+	}
+	if tooLarge {
+		response.UnprocessableEntity(w, "FILE_TOO_LARGE", errcatalog.FileTooLarge)
+		return
+	}
+
+	mime := mimetype.Detect(data)
+	if !isAllowedCandidateMedia(slot, mime) {
+		response.Catalog(w, invalidMediaType(slot))
+		return
+	}
+
+	mediaID, err := newCandidateMediaID()
+	if err != nil {
+		response.Catalog(w, mediaStorageUnavailable)
+		return
+	}
+
+	saved, err := h.svc.UploadDocument(r.Context(), electionID, userID, CandidateMediaCreate{
+		ID:          mediaID,
+		Slot:        slot,
+		FileName:    header.Filename,
+		ContentType: mime.String(),
+		SizeBytes:   int64(len(data)),
+		Data:        data,
+		CreatedByID: userID,
+	})
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.Success(w, http.StatusOK, map[string]interface{}{
+		"id":           saved.ID,
+		"slot":         saved.Slot,
+		"content_type": saved.ContentType,
+		"size":         saved.SizeBytes,
+	})
+}
+
+// Submit menangani POST /elections/{electionID}/candidacy/submit
+func (h *CandidacyHandler) Submit(w http.ResponseWriter, r *http.Request) {
+	electionID, userID, ok := h.studentScope(w, r)
+	if !ok {
+		return
+	}
+
+	dto, err := h.svc.Submit(r.Context(), electionID, userID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	response.Success(w, http.StatusOK, dto)
+}
+
+// Notifications menangani GET /elections/{electionID}/candidacy/notifications
+func (h *CandidacyHandler) Notifications(w http.ResponseWriter, r *http.Request) {
+	electionID, userID, ok := h.studentScope(w, r)
+	if !ok {
+		return
+	}
+
+	items, err := h.svc.ListNotifications(r.Context(), electionID, userID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	response.Success(w, http.StatusOK, map[string]interface{}{"items": items})
+}
+
+// AdminList menangani GET /admin/elections/{electionID}/candidacies
+func (h *CandidacyHandler) AdminList(w http.ResponseWriter, r *http.Request) {
+	electionID, err := parseInt64Param(r, "electionID")
+	if err != nil || electionID <= 0 {
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.Invalid("electionID"))
+		return
+	}
+
+	var status *CandidateStatus
+	if raw := r.URL.Query().Get("status"); raw != "" {
+		cs := CandidateStatus(strings.ToUpper(raw))
+		status = &cs
+	}
+
+	items, err := h.svc.AdminList(r.Context(), electionID, status)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]interface{}{"items": items})
+}
+
+// AdminDetail menangani GET /admin/elections/{electionID}/candidacies/{candidateID}
+func (h *CandidacyHandler) AdminDetail(w http.ResponseWriter, r *http.Request) {
+	electionID, candidateID, ok := h.adminScope(w, r)
+	if !ok {
+		return
+	}
+
+	dto, err := h.svc.AdminGet(r.Context(), electionID, candidateID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, dto)
+}
+
+// Review menangani POST /admin/elections/{electionID}/candidacies/{candidateID}/reviews
+func (h *CandidacyHandler) Review(w http.ResponseWriter, r *http.Request) {
+	electionID, candidateID, ok := h.adminScope(w, r)
+	if !ok {
+		return
+	}
+
+	reviewerID, ok := ctxkeys.GetUserID(r.Context())
+	if !ok {
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.InvalidUser)
+		return
+	}
+
+	var req CandidacyReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.InvalidBody)
+		return
+	}
+	req.Decision = ReviewDecision(strings.ToUpper(string(req.Decision)))
+
+	dto, err := h.svc.Review(r.Context(), electionID, candidateID, reviewerID, req)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, dto)
+}
+
+func (h *CandidacyHandler) studentScope(w http.ResponseWriter, r *http.Request) (int64, int64, bool) {
+	electionID, err := parseInt64Param(r, "electionID")
+	if err != nil || electionID <= 0 {
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.Invalid("electionID"))
+		return 0, 0, false
+	}
+	userID, ok := ctxkeys.GetUserID(r.Context())
+	if !ok {
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.InvalidUser)
+		return 0, 0, false
+	}
+	return electionID, userID, true
+}
+
+func (h *CandidacyHandler) adminScope(w http.ResponseWriter, r *http.Request) (int64, int64, bool) {
+	electionID, err := parseInt64Param(r, "electionID")
+	if err != nil || electionID <= 0 {
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.Invalid("electionID"))
+		return 0, 0, false
+	}
+	candidateID, err := parseInt64Param(r, "candidateID")
+	if err != nil || candidateID <= 0 {
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.Invalid("candidateID"))
+		return 0, 0, false
+	}
+	return electionID, candidateID, true
+}
+
+func (h *CandidacyHandler) handleError(w http.ResponseWriter, err error) {
+	if errors.Is(err, ErrCandidateNotFound) {
+		err = ErrCandidacyNotFound
+	}
+	if response.DomainError(w, err) {
+		return
+	}
+	slog.Error("candidacy handler error", "err", err)
+	response.InternalServerError(w, "INTERNAL_ERROR", errcatalog.InternalError)
+}
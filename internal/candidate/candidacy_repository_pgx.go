@@ -0,0 +1,412 @@
+package candidate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PgCandidacyRepository implements CandidacyRepository using pgxpool
+type PgCandidacyRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewPgCandidacyRepository creates a new PostgreSQL candidacy repository
+func NewPgCandidacyRepository(db *pgxpool.Pool) *PgCandidacyRepository {
+	return &PgCandidacyRepository{db: db}
+}
+
+func (r *PgCandidacyRepository) GetWindow(ctx context.Context, electionID int64) (*CandidacyWindow, error) {
+	const q = `
+SELECT id, status::text, registration_start_at, registration_end_at, verification_start_at, verification_end_at
+FROM elections
+WHERE id = $1
+`
+	var w CandidacyWindow
+	err := r.db.QueryRow(ctx, q, electionID).Scan(
+		&w.ElectionID,
+		&w.Status,
+		&w.RegistrationStartAt,
+		&w.RegistrationEndAt,
+		&w.VerificationStartAt,
+		&w.VerificationEndAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrElectionNotFound
+		}
+		return nil, err
+	}
+	return &w, nil
+}
+
+const candidacyApplicationColumns = `
+candidate_id, election_id, applicant_user_id, submitted_at, decided_at, created_at, updated_at
+`
+
+func scanCandidacyApplication(row pgx.Row) (*CandidacyApplication, error) {
+	var a CandidacyApplication
+	if err := row.Scan(
+		&a.CandidateID,
+		&a.ElectionID,
+		&a.ApplicantUserID,
+		&a.SubmittedAt,
+		&a.DecidedAt,
+		&a.CreatedAt,
+		&a.UpdatedAt,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCandidacyNotFound
+		}
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (r *PgCandidacyRepository) CreateApplication(ctx context.Context, applicantUserID int64, c *Candidate) (*CandidacyApplication, error) {
+	if c.Missions == nil {
+		c.Missions = []string{}
+	}
+	if c.MainPrograms == nil {
+		c.MainPrograms = []MainProgram{}
+	}
+	if c.SocialLinks == nil {
+		c.SocialLinks = []SocialLink{}
+	}
+
+	missionsJSON, err := json.Marshal(c.Missions)
+	if err != nil {
+		return nil, fmt.Errorf("marshal missions: %w", err)
+	}
+	mainProgramsJSON, err := json.Marshal(c.MainPrograms)
+	if err != nil {
+		return nil, fmt.Errorf("marshal main_programs: %w", err)
+	}
+	mediaJSON, err := json.Marshal(c.Media)
+	if err != nil {
+		return nil, fmt.Errorf("marshal media: %w", err)
+	}
+	socialLinksJSON, err := json.Marshal(c.SocialLinks)
+	if err != nil {
+		return nil, fmt.Errorf("marshal social_links: %w", err)
+	}
+
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var exists bool
+	if err := tx.QueryRow(ctx, `
+SELECT EXISTS(SELECT 1 FROM candidate_applications WHERE election_id = $1 AND applicant_user_id = $2)
+`, c.ElectionID, applicantUserID).Scan(&exists); err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrCandidacyAlreadyExists
+	}
+
+	var candidateID int64
+	err = tx.QueryRow(ctx, `
+INSERT INTO candidates (
+election_id, number, name, photo_url, short_bio, long_bio, tagline,
+faculty_name, study_program_name, cohort_year, vision, missions,
+main_programs, media, social_links, status
+) VALUES (
+$1, NULL, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
+)
+RETURNING id
+`,
+		c.ElectionID,
+		c.Name,
+		c.PhotoURL,
+		c.ShortBio,
+		c.LongBio,
+		c.Tagline,
+		c.FacultyName,
+		c.StudyProgramName,
+		c.CohortYear,
+		c.Vision,
+		string(missionsJSON),
+		string(mainProgramsJSON),
+		string(mediaJSON),
+		string(socialLinksJSON),
+		CandidateStatusDraft,
+	).Scan(&candidateID)
+	if err != nil {
+		return nil, err
+	}
+
+	row := tx.QueryRow(ctx, `
+INSERT INTO candidate_applications (candidate_id, election_id, applicant_user_id)
+VALUES ($1, $2, $3)
+RETURNING `+candidacyApplicationColumns, candidateID, c.ElectionID, applicantUserID)
+	app, err := scanCandidacyApplication(row)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, ErrCandidacyAlreadyExists
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return app, nil
+}
+
+func (r *PgCandidacyRepository) GetApplicationByApplicant(ctx context.Context, electionID, applicantUserID int64) (*CandidacyApplication, error) {
+	row := r.db.QueryRow(ctx, `
+SELECT `+candidacyApplicationColumns+`
+FROM candidate_applications
+WHERE election_id = $1 AND applicant_user_id = $2
+`, electionID, applicantUserID)
+	return scanCandidacyApplication(row)
+}
+
+func (r *PgCandidacyRepository) GetApplication(ctx context.Context, electionID, candidateID int64) (*CandidacyApplication, error) {
+	row := r.db.QueryRow(ctx, `
+SELECT `+candidacyApplicationColumns+`
+FROM candidate_applications
+WHERE election_id = $1 AND candidate_id = $2
+`, electionID, candidateID)
+	return scanCandidacyApplication(row)
+}
+
+func (r *PgCandidacyRepository) ListApplications(ctx context.Context, electionID int64, status *CandidateStatus) ([]CandidacyApplicationSummary, error) {
+	rows, err := r.db.Query(ctx, `
+SELECT
+    a.candidate_id,
+    a.election_id,
+    a.applicant_user_id,
+    a.submitted_at,
+    a.decided_at,
+    a.created_at,
+    a.updated_at,
+    c.name,
+    COALESCE(c.faculty_name, ''),
+    COALESCE(c.study_program_name, ''),
+    c.status,
+    COALESCE(u.full_name, u.username, '')
+FROM candidate_applications a
+JOIN candidates c ON c.id = a.candidate_id AND c.deleted_at IS NULL
+LEFT JOIN user_accounts u ON u.id = a.applicant_user_id
+WHERE a.election_id = $1
+  AND ($2::text IS NULL OR c.status::text = $2::text)
+ORDER BY a.submitted_at ASC NULLS LAST, a.created_at ASC
+`, electionID, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []CandidacyApplicationSummary{}
+	for rows.Next() {
+		var it CandidacyApplicationSummary
+		if err := rows.Scan(
+			&it.CandidateID,
+			&it.ElectionID,
+			&it.ApplicantUserID,
+			&it.SubmittedAt,
+			&it.DecidedAt,
+			&it.CreatedAt,
+			&it.UpdatedAt,
+			&it.Name,
+			&it.FacultyName,
+			&it.StudyProgramName,
+			&it.Status,
+			&it.ApplicantName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, it)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *PgCandidacyRepository) MarkSubmitted(ctx context.Context, electionID, candidateID int64, at time.Time) error {
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `
+UPDATE candidates SET status = $3, updated_at = NOW()
+WHERE election_id = $1 AND id = $2 AND deleted_at IS NULL
+`, electionID, candidateID, CandidateStatusPending)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrCandidateNotFound
+	}
+
+	if _, err := tx.Exec(ctx, `
+UPDATE candidate_applications SET submitted_at = $2, updated_at = NOW()
+WHERE candidate_id = $1
+`, candidateID, at); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *PgCandidacyRepository) SaveReview(ctx context.Context, review *CandidacyReview, status CandidateStatus) (int, error) {
+	checklistJSON, err := json.Marshal(review.Checklist)
+	if err != nil {
+		return 0, fmt.Errorf("marshal checklist: %w", err)
+	}
+
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	var number *int
+	err = tx.QueryRow(ctx, `
+SELECT number FROM candidates
+WHERE election_id = $1 AND id = $2 AND deleted_at IS NULL
+FOR UPDATE
+`, review.ElectionID, review.CandidateID).Scan(&number)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, ErrCandidateNotFound
+		}
+		return 0, err
+	}
+
+	if status == CandidateStatusApproved && number == nil {
+		// Serialize number assignment per election so concurrent approvals never collide.
+		if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('candidate_number'), $1::int)`, review.ElectionID); err != nil {
+			return 0, err
+		}
+		var next int
+		if err := tx.QueryRow(ctx, `
+SELECT COALESCE(MAX(number), 0) + 1 FROM candidates
+WHERE election_id = $1 AND deleted_at IS NULL
+`, review.ElectionID).Scan(&next); err != nil {
+			return 0, err
+		}
+		number = &next
+	}
+
+	if _, err := tx.Exec(ctx, `
+UPDATE candidates SET status = $3, number = $4, updated_at = NOW()
+WHERE election_id = $1 AND id = $2
+`, review.ElectionID, review.CandidateID, status, number); err != nil {
+		return 0, err
+	}
+
+	if err := tx.QueryRow(ctx, `
+INSERT INTO candidate_reviews (candidate_id, election_id, reviewer_id, decision, checklist, comment)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, created_at
+`, review.CandidateID, review.ElectionID, review.ReviewerID, review.Decision, string(checklistJSON), review.Comment,
+	).Scan(&review.ID, &review.CreatedAt); err != nil {
+		return 0, err
+	}
+
+	if review.Decision != ReviewDecisionRequestRevision {
+		if _, err := tx.Exec(ctx, `
+UPDATE candidate_applications SET decided_at = NOW(), updated_at = NOW()
+WHERE candidate_id = $1
+`, review.CandidateID); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+
+	if number == nil {
+		return 0, nil
+	}
+	return *number, nil
+}
+
+func (r *PgCandidacyRepository) ListReviews(ctx context.Context, candidateID int64) ([]CandidacyReview, error) {
+	rows, err := r.db.Query(ctx, `
+SELECT id, candidate_id, election_id, reviewer_id, decision, checklist, comment, created_at
+FROM candidate_reviews
+WHERE candidate_id = $1
+ORDER BY created_at DESC
+`, candidateID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []CandidacyReview{}
+	for rows.Next() {
+		var rv CandidacyReview
+		var checklistRaw any
+		if err := rows.Scan(
+			&rv.ID,
+			&rv.CandidateID,
+			&rv.ElectionID,
+			&rv.ReviewerID,
+			&rv.Decision,
+			&checklistRaw,
+			&rv.Comment,
+			&rv.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		if err := scanJSON(checklistRaw, &rv.Checklist); err != nil {
+			return nil, err
+		}
+		items = append(items, rv)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *PgCandidacyRepository) CreateNotification(ctx context.Context, n *CandidacyNotification) error {
+	return r.db.QueryRow(ctx, `
+INSERT INTO candidate_notifications (candidate_id, user_id, type, title, message)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, created_at
+`, n.CandidateID, n.UserID, n.Type, n.Title, n.Message).Scan(&n.ID, &n.CreatedAt)
+}
+
+func (r *PgCandidacyRepository) ListNotifications(ctx context.Context, candidateID, userID int64) ([]CandidacyNotification, error) {
+	rows, err := r.db.Query(ctx, `
+SELECT id, candidate_id, user_id, type, title, message, read_at, created_at
+FROM candidate_notifications
+WHERE candidate_id = $1 AND user_id = $2
+ORDER BY created_at DESC
+`, candidateID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []CandidacyNotification{}
+	for rows.Next() {
+		var n CandidacyNotification
+		if err := rows.Scan(&n.ID, &n.CandidateID, &n.UserID, &n.Type, &n.Title, &n.Message, &n.ReadAt, &n.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
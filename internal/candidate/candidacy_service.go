@@ -0,0 +1,475 @@
+package candidate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// CandidacyRequest is the profile an applicant fills in when submitting a candidacy.
+type CandidacyRequest struct {
+	Name             string        `json:"name"`
+	PhotoURL         string        `json:"photo_url"`
+	ShortBio         string        `json:"short_bio"`
+	LongBio          string        `json:"long_bio"`
+	Tagline          string        `json:"tagline"`
+	FacultyName      string        `json:"faculty_name"`
+	StudyProgramName string        `json:"study_program_name"`
+	CohortYear       *int          `json:"cohort_year"`
+	Vision           string        `json:"vision"`
+	Missions         []string      `json:"missions"`
+	MainPrograms     []MainProgram `json:"main_programs"`
+	SocialLinks      []SocialLink  `json:"social_links"`
+}
+
+// CandidacyReviewRequest is the committee verdict payload.
+type CandidacyReviewRequest struct {
+	Decision  ReviewDecision  `json:"decision"`
+	Checklist []ChecklistItem `json:"checklist"`
+	Comment   string          `json:"comment"`
+}
+
+// CandidacyRequirementStatus reports whether a requirement document has been uploaded.
+type CandidacyRequirementStatus struct {
+	CandidacyRequirement
+	Uploaded bool    `json:"uploaded"`
+	MediaID  *string `json:"media_id,omitempty"`
+}
+
+// CandidacyDTO is the full view of a candidacy for the applicant and the committee.
+type CandidacyDTO struct {
+	Application  CandidacyApplication         `json:"application"`
+	Candidate    *Candidate                   `json:"candidate"`
+	Requirements []CandidacyRequirementStatus `json:"requirements"`
+	Reviews      []CandidacyReview            `json:"reviews"`
+}
+
+// CandidacyService implements the candidate self-registration and verification workflow
+type CandidacyService struct {
+	repo       CandidacyRepository
+	candidates CandidateRepository
+	numberLock NumberLock
+	now        func() time.Time
+}
+
+// NewCandidacyService creates a new candidacy service
+func NewCandidacyService(repo CandidacyRepository, candidates CandidateRepository) *CandidacyService {
+	return &CandidacyService{
+		repo:       repo,
+		candidates: candidates,
+		now:        time.Now,
+	}
+}
+
+// SetNumberLock blocks approvals once the ballot draw has fixed the numbers
+func (s *CandidacyService) SetNumberLock(lock NumberLock) {
+	s.numberLock = lock
+}
+
+// Apply creates a DRAFT candidacy for the applicant during the registration window.
+func (s *CandidacyService) Apply(ctx context.Context, electionID, userID int64, req CandidacyRequest) (*CandidacyDTO, error) {
+	if err := s.ensureRegistrationOpen(ctx, electionID); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, ErrCandidacyNameRequired
+	}
+
+	c := &Candidate{
+		ElectionID:       electionID,
+		Name:             strings.TrimSpace(req.Name),
+		PhotoURL:         req.PhotoURL,
+		ShortBio:         req.ShortBio,
+		LongBio:          req.LongBio,
+		Tagline:          req.Tagline,
+		FacultyName:      req.FacultyName,
+		StudyProgramName: req.StudyProgramName,
+		CohortYear:       req.CohortYear,
+		Vision:           req.Vision,
+		Missions:         req.Missions,
+		MainPrograms:     req.MainPrograms,
+		SocialLinks:      req.SocialLinks,
+		Status:           CandidateStatusDraft,
+	}
+
+	app, err := s.repo.CreateApplication(ctx, userID, c)
+	if err != nil {
+		return nil, err
+	}
+	return s.buildDTO(ctx, app)
+}
+
+// GetMine returns the applicant's candidacy for an election.
+func (s *CandidacyService) GetMine(ctx context.Context, electionID, userID int64) (*CandidacyDTO, error) {
+	app, err := s.repo.GetApplicationByApplicant(ctx, electionID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.buildDTO(ctx, app)
+}
+
+// UpdateMine edits the applicant's profile while it is still a draft or under revision.
+func (s *CandidacyService) UpdateMine(ctx context.Context, electionID, userID int64, req CandidacyRequest) (*CandidacyDTO, error) {
+	app, c, err := s.loadEditable(ctx, electionID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, ErrCandidacyNameRequired
+	}
+
+	c.Name = strings.TrimSpace(req.Name)
+	c.PhotoURL = req.PhotoURL
+	c.ShortBio = req.ShortBio
+	c.LongBio = req.LongBio
+	c.Tagline = req.Tagline
+	c.FacultyName = req.FacultyName
+	c.StudyProgramName = req.StudyProgramName
+	c.CohortYear = req.CohortYear
+	c.Vision = req.Vision
+	c.Missions = req.Missions
+	c.MainPrograms = req.MainPrograms
+	c.SocialLinks = req.SocialLinks
+
+	if _, err := s.candidates.Update(ctx, electionID, c.ID, c); err != nil {
+		return nil, err
+	}
+	return s.buildDTO(ctx, app)
+}
+
+// UploadDocument stores a requirement document, replacing any earlier upload for the same slot.
+func (s *CandidacyService) UploadDocument(ctx context.Context, electionID, userID int64, media CandidateMediaCreate) (*CandidateMedia, error) {
+	if requirementForSlot(media.Slot) == nil {
+		return nil, ErrInvalidCandidateMediaSlot
+	}
+
+	_, c, err := s.loadEditable(ctx, electionID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.candidates.ListMediaMeta(ctx, c.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range existing {
+		if m.Slot == media.Slot {
+			if err := s.candidates.DeleteMedia(ctx, c.ID, m.ID); err != nil && !errors.Is(err, ErrCandidateMediaNotFound) {
+				return nil, err
+			}
+		}
+	}
+
+	return s.candidates.AddMedia(ctx, c.ID, media)
+}
+
+// Submit sends the candidacy to the committee once every requirement document is uploaded.
+func (s *CandidacyService) Submit(ctx context.Context, electionID, userID int64) (*CandidacyDTO, error) {
+	app, c, err := s.loadEditable(ctx, electionID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	media, err := s.candidates.ListMediaMeta(ctx, c.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, req := range requirementStatuses(media) {
+		if !req.Uploaded {
+			return nil, fmt.Errorf("%w: %s", ErrCandidacyDocumentMissing, req.Label)
+		}
+	}
+
+	now := s.now()
+	if err := s.repo.MarkSubmitted(ctx, electionID, c.ID, now); err != nil {
+		return nil, err
+	}
+	app.SubmittedAt = &now
+
+	s.notify(ctx, app, "SUBMITTED", "Pendaftaran diterima",
+		"Berkas pendaftaran calon Anda telah dikirim dan menunggu verifikasi panitia.")
+
+	return s.buildDTO(ctx, app)
+}
+
+// ListNotifications returns status messages for the applicant's candidacy.
+func (s *CandidacyService) ListNotifications(ctx context.Context, electionID, userID int64) ([]CandidacyNotification, error) {
+	app, err := s.repo.GetApplicationByApplicant(ctx, electionID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.ListNotifications(ctx, app.CandidateID, userID)
+}
+
+// AdminList returns the committee review queue for an election.
+func (s *CandidacyService) AdminList(ctx context.Context, electionID int64, status *CandidateStatus) ([]CandidacyApplicationSummary, error) {
+	if status != nil && !status.IsValid() {
+		return nil, ErrCandidateStatusInvalid
+	}
+	return s.repo.ListApplications(ctx, electionID, status)
+}
+
+// AdminGet returns a candidacy with its documents and review history.
+func (s *CandidacyService) AdminGet(ctx context.Context, electionID, candidateID int64) (*CandidacyDTO, error) {
+	app, err := s.repo.GetApplication(ctx, electionID, candidateID)
+	if err != nil {
+		return nil, err
+	}
+	return s.buildDTO(ctx, app)
+}
+
+// Review records a committee decision. Approval publishes the candidate and assigns a
+// provisional ballot number; the ballot draw later fixes the final order.
+func (s *CandidacyService) Review(ctx context.Context, electionID, candidateID, reviewerID int64, req CandidacyReviewRequest) (*CandidacyDTO, error) {
+	app, err := s.repo.GetApplication(ctx, electionID, candidateID)
+	if err != nil {
+		return nil, err
+	}
+	c, err := s.candidates.GetByID(ctx, electionID, candidateID)
+	if err != nil {
+		return nil, err
+	}
+	if c.Status != CandidateStatusPending {
+		return nil, ErrCandidacyNotReviewable
+	}
+
+	status, err := validateReviewDecision(req)
+	if err != nil {
+		return nil, err
+	}
+	if status == CandidateStatusApproved {
+		if err := ensureNumbersUnlocked(ctx, s.numberLock, electionID); err != nil {
+			return nil, err
+		}
+	}
+
+	review := &CandidacyReview{
+		CandidateID: candidateID,
+		ElectionID:  electionID,
+		ReviewerID:  &reviewerID,
+		Decision:    req.Decision,
+		Checklist:   req.Checklist,
+		Comment:     strings.TrimSpace(req.Comment),
+	}
+	number, err := s.repo.SaveReview(ctx, review, status)
+	if err != nil {
+		return nil, err
+	}
+
+	switch status {
+	case CandidateStatusApproved:
+		if existingQR, _ := s.candidates.GetActiveQRCode(ctx, candidateID); existingQR == nil {
+			if _, err := s.candidates.CreateQRCode(ctx, electionID, candidateID); err != nil {
+				slog.Warn("Failed to auto-generate QR code on candidacy approval", "candidate_id", candidateID, "error", err)
+			}
+		}
+		s.notify(ctx, app, "APPROVED", "Pendaftaran disetujui",
+			fmt.Sprintf("Selamat, pencalonan Anda disetujui dengan nomor urut %d.", number))
+	case CandidateStatusNeedsRevision:
+		s.notify(ctx, app, "NEEDS_REVISION", "Berkas perlu diperbaiki",
+			revisionMessage(review))
+	case CandidateStatusRejected:
+		s.notify(ctx, app, "REJECTED", "Pendaftaran ditolak", review.Comment)
+	}
+
+	return s.buildDTO(ctx, app)
+}
+
+// validateReviewDecision checks the checklist against the requirements and
+// returns the candidate status the decision leads to.
+func validateReviewDecision(req CandidacyReviewRequest) (CandidateStatus, error) {
+	items := make(map[string]ChecklistItem, len(req.Checklist))
+	for _, it := range req.Checklist {
+		items[it.Key] = it
+	}
+
+	allPassed := true
+	for _, r := range CandidacyRequirements {
+		it, ok := items[r.Key]
+		if !ok {
+			return "", fmt.Errorf("%w: %s", ErrChecklistIncomplete, r.Key)
+		}
+		if !it.Passed {
+			allPassed = false
+		}
+	}
+
+	comment := strings.TrimSpace(req.Comment)
+	switch req.Decision {
+	case ReviewDecisionApprove:
+		if !allPassed {
+			return "", ErrChecklistIncomplete
+		}
+		return CandidateStatusApproved, nil
+	case ReviewDecisionRequestRevision:
+		if allPassed && comment == "" {
+			return "", ErrReviewCommentRequired
+		}
+		return CandidateStatusNeedsRevision, nil
+	case ReviewDecisionReject:
+		if comment == "" {
+			return "", ErrReviewCommentRequired
+		}
+		return CandidateStatusRejected, nil
+	default:
+		return "", ErrInvalidReviewDecision
+	}
+}
+
+func revisionMessage(review *CandidacyReview) string {
+	var b strings.Builder
+	b.WriteString("Panitia meminta perbaikan berkas pendaftaran.")
+	for _, it := range review.Checklist {
+		if it.Passed {
+			continue
+		}
+		b.WriteString("\n- ")
+		if r := requirementForKey(it.Key); r != nil {
+			b.WriteString(r.Label)
+		} else {
+			b.WriteString(it.Key)
+		}
+		if it.Comment != "" {
+			b.WriteString(": ")
+			b.WriteString(it.Comment)
+		}
+	}
+	if review.Comment != "" {
+		b.WriteString("\n")
+		b.WriteString(review.Comment)
+	}
+	return b.String()
+}
+
+// loadEditable returns the applicant's candidacy when it may still be changed.
+// Drafts follow the registration window; revisions stay open until verification ends.
+func (s *CandidacyService) loadEditable(ctx context.Context, electionID, userID int64) (*CandidacyApplication, *Candidate, error) {
+	app, err := s.repo.GetApplicationByApplicant(ctx, electionID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	c, err := s.candidates.GetByID(ctx, electionID, app.CandidateID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch c.Status {
+	case CandidateStatusDraft:
+		if err := s.ensureRegistrationOpen(ctx, electionID); err != nil {
+			return nil, nil, err
+		}
+	case CandidateStatusNeedsRevision:
+		w, err := s.repo.GetWindow(ctx, electionID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if w.VerificationEndAt != nil && s.now().After(*w.VerificationEndAt) {
+			return nil, nil, ErrCandidacyWindowClosed
+		}
+	default:
+		return nil, nil, ErrCandidacyNotEditable
+	}
+
+	return app, c, nil
+}
+
+func (s *CandidacyService) ensureRegistrationOpen(ctx context.Context, electionID int64) error {
+	w, err := s.repo.GetWindow(ctx, electionID)
+	if err != nil {
+		return err
+	}
+	if !registrationOpen(w, s.now()) {
+		return ErrCandidacyWindowClosed
+	}
+	return nil
+}
+
+// registrationOpen uses the configured registration dates; when none are set
+// the election status decides.
+func registrationOpen(w *CandidacyWindow, now time.Time) bool {
+	if w.RegistrationStartAt == nil && w.RegistrationEndAt == nil {
+		return w.Status == "REGISTRATION" || w.Status == "REGISTRATION_OPEN"
+	}
+	if w.RegistrationStartAt != nil && now.Before(*w.RegistrationStartAt) {
+		return false
+	}
+	if w.RegistrationEndAt != nil && now.After(*w.RegistrationEndAt) {
+		return false
+	}
+	return true
+}
+
+func (s *CandidacyService) notify(ctx context.Context, app *CandidacyApplication, typ, title, message string) {
+	n := &CandidacyNotification{
+		CandidateID: app.CandidateID,
+		UserID:      app.ApplicantUserID,
+		Type:        typ,
+		Title:       title,
+		Message:     message,
+	}
+	if err := s.repo.CreateNotification(ctx, n); err != nil {
+		slog.Warn("failed to create candidacy notification", "candidate_id", app.CandidateID, "type", typ, "error", err)
+	}
+}
+
+func (s *CandidacyService) buildDTO(ctx context.Context, app *CandidacyApplication) (*CandidacyDTO, error) {
+	c, err := s.candidates.GetByID(ctx, app.ElectionID, app.CandidateID)
+	if err != nil {
+		return nil, err
+	}
+	media, err := s.candidates.ListMediaMeta(ctx, c.ID)
+	if err != nil {
+		return nil, err
+	}
+	c.MediaFiles = media
+
+	reviews, err := s.repo.ListReviews(ctx, c.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CandidacyDTO{
+		Application:  *app,
+		Candidate:    c,
+		Requirements: requirementStatuses(media),
+		Reviews:      reviews,
+	}, nil
+}
+
+func requirementStatuses(media []CandidateMediaMeta) []CandidacyRequirementStatus {
+	out := make([]CandidacyRequirementStatus, 0, len(CandidacyRequirements))
+	for _, r := range CandidacyRequirements {
+		st := CandidacyRequirementStatus{CandidacyRequirement: r}
+		for i := range media {
+			if media[i].Slot == r.Slot {
+				id := media[i].ID
+				st.Uploaded = true
+				st.MediaID = &id
+				break
+			}
+		}
+		out = append(out, st)
+	}
+	return out
+}
+
+func requirementForSlot(slot CandidateMediaSlot) *CandidacyRequirement {
+	for i := range CandidacyRequirements {
+		if CandidacyRequirements[i].Slot == slot {
+			return &CandidacyRequirements[i]
+		}
+	}
+	return nil
+}
+
+func requirementForKey(key string) *CandidacyRequirement {
+	for i := range CandidacyRequirements {
+		if CandidacyRequirements[i].Key == key {
+			return &CandidacyRequirements[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package candidate
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func fullChecklist(passed bool) []ChecklistItem {
+	items := make([]ChecklistItem, 0, len(CandidacyRequirements))
+	for _, r := range CandidacyRequirements {
+		items = append(items, ChecklistItem{Key: r.Key, Passed: passed})
+	}
+	return items
+}
+
+func Test_validateReviewDecision_ApproveRequiresAllPassed(t *testing.T) {
+	status, err := validateReviewDecision(CandidacyReviewRequest{Decision: ReviewDecisionApprove, Checklist: fullChecklist(true)})
+	if err != nil || status != CandidateStatusApproved {
+		t.Fatalf("expected APPROVED, got %q err=%v", status, err)
+	}
+
+	checklist := fullChecklist(true)
+	checklist[0].Passed = false
+	if _, err := validateReviewDecision(CandidacyReviewRequest{Decision: ReviewDecisionApprove, Checklist: checklist}); !errors.Is(err, ErrChecklistIncomplete) {
+		t.Fatalf("expected ErrChecklistIncomplete, got %v", err)
+	}
+}
+
+func Test_validateReviewDecision_MissingItem(t *testing.T) {
+	checklist := fullChecklist(true)[1:]
+	if _, err := validateReviewDecision(CandidacyReviewRequest{Decision: ReviewDecisionApprove, Checklist: checklist}); !errors.Is(err, ErrChecklistIncomplete) {
+		t.Fatalf("expected ErrChecklistIncomplete, got %v", err)
+	}
+}
+
+func Test_validateReviewDecision_RevisionAndReject(t *testing.T) {
+	if _, err := validateReviewDecision(CandidacyReviewRequest{Decision: ReviewDecisionRequestRevision, Checklist: fullChecklist(true)}); !errors.Is(err, ErrReviewCommentRequired) {
+		t.Fatalf("expected ErrReviewCommentRequired, got %v", err)
+	}
+	status, err := validateReviewDecision(CandidacyReviewRequest{Decision: ReviewDecisionRequestRevision, Checklist: fullChecklist(false)})
+	if err != nil || status != CandidateStatusNeedsRevision {
+		t.Fatalf("expected NEEDS_REVISION, got %q err=%v", status, err)
+	}
+	if _, err := validateReviewDecision(CandidacyReviewRequest{Decision: ReviewDecisionReject, Checklist: fullChecklist(false)}); !errors.Is(err, ErrReviewCommentRequired) {
+		t.Fatalf("expected ErrReviewCommentRequired, got %v", err)
+	}
+	if _, err := validateReviewDecision(CandidacyReviewRequest{Decision: "MAYBE", Checklist: fullChecklist(true)}); !errors.Is(err, ErrInvalidReviewDecision) {
+		t.Fatalf("expected ErrInvalidReviewDecision, got %v", err)
+	}
+}
+
+func Test_registrationOpen(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	start := now.Add(-time.Hour)
+	end := now.Add(time.Hour)
+	past := now.Add(-2 * time.Hour)
+
+	if !registrationOpen(&CandidacyWindow{RegistrationStartAt: &start, RegistrationEndAt: &end}, now) {
+		t.Fatal("expected open inside window")
+	}
+	if registrationOpen(&CandidacyWindow{RegistrationStartAt: &past, RegistrationEndAt: &start}, now) {
+		t.Fatal("expected closed after window")
+	}
+	if !registrationOpen(&CandidacyWindow{Status: "REGISTRATION"}, now) {
+		t.Fatal("expected open by status when no dates")
+	}
+	if registrationOpen(&CandidacyWindow{Status: "VOTING_OPEN"}, now) {
+		t.Fatal("expected closed by status when no dates")
+	}
+}
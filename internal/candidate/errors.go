@@ -0,0 +1,44 @@
+package candidate
+
+import (
+	"net/http"
+
+	"pemira-api/internal/errcatalog"
+)
+
+// The API codes and messages of the candidate, candidacy and ballot draw
+// errors; the handlers' handleError writes them
+func init() {
+	errcatalog.Register(
+		errcatalog.Entry{Err: ErrElectionNotFound, Code: "NOT_FOUND", Status: http.StatusNotFound, ID: "Pemilu tidak ditemukan.", EN: "Election not found."},
+		errcatalog.Entry{Err: ErrCandidateNotFound, Code: "NOT_FOUND", Status: http.StatusNotFound, ID: "Kandidat tidak ditemukan.", EN: "Candidate not found."},
+		// Unpublished candidates are hidden from students
+		errcatalog.Entry{Err: ErrCandidateNotPublished, Code: "NOT_FOUND", Status: http.StatusNotFound, ID: "Kandidat tidak ditemukan.", EN: "Candidate not found."},
+		errcatalog.Entry{Err: ErrCandidateNumberTaken, Code: "CANDIDATE_NUMBER_TAKEN", Status: http.StatusConflict, ID: "Nomor kandidat sudah digunakan di pemilu ini.", EN: "The candidate number is already used in this election."},
+		errcatalog.Entry{Err: ErrCandidateStatusInvalid, Code: "INVALID_REQUEST", Status: http.StatusBadRequest, ID: "Perubahan status kandidat tidak diizinkan.", EN: "This candidate status change is not allowed."},
+		errcatalog.Entry{Err: ErrCandidateNumberLocked, Code: "CANDIDATE_NUMBER_LOCKED", Status: http.StatusConflict, ID: "Nomor urut sudah dikunci oleh hasil pengundian.", EN: "Ballot numbers are locked by the ballot draw."},
+		errcatalog.Entry{Err: ErrCandidateMediaNotFound, Code: "MEDIA_NOT_FOUND", Status: http.StatusNotFound, ID: "Media kandidat tidak ditemukan.", EN: "Candidate media not found."},
+		errcatalog.Entry{Err: ErrInvalidCandidateMediaSlot, Code: "INVALID_REQUEST", Status: http.StatusBadRequest, ID: "Slot media tidak valid.", EN: "Invalid media slot."},
+
+		errcatalog.Entry{Err: ErrCandidacyNotFound, Code: "CANDIDACY_NOT_FOUND", Status: http.StatusNotFound, ID: "Pendaftaran calon tidak ditemukan.", EN: "Candidacy application not found."},
+		errcatalog.Entry{Err: ErrCandidacyAlreadyExists, Code: "CANDIDACY_EXISTS", Status: http.StatusConflict, ID: "Anda sudah mendaftar sebagai calon pada pemilu ini.", EN: "You have already applied as a candidate in this election."},
+		errcatalog.Entry{Err: ErrCandidacyWindowClosed, Code: "REGISTRATION_CLOSED", Status: http.StatusUnprocessableEntity, ID: "Masa pendaftaran calon tidak sedang berlangsung.", EN: "Candidate registration is not open."},
+		errcatalog.Entry{Err: ErrCandidacyNotEditable, Code: "CANDIDACY_LOCKED", Status: http.StatusConflict, ID: "Pendaftaran tidak dapat diubah pada status saat ini.", EN: "The application cannot be changed in its current status."},
+		errcatalog.Entry{Err: ErrCandidacyNotReviewable, Code: "CANDIDACY_NOT_REVIEWABLE", Status: http.StatusConflict, ID: "Pendaftaran tidak sedang menunggu verifikasi.", EN: "The application is not awaiting review."},
+		errcatalog.Entry{Err: ErrCandidacyDocumentMissing, Code: "DOCUMENT_MISSING", Status: http.StatusUnprocessableEntity, ID: "Dokumen persyaratan belum lengkap.", EN: "Required documents are missing."},
+		errcatalog.Entry{Err: ErrCandidacyNameRequired, Code: "VALIDATION_ERROR", Status: http.StatusUnprocessableEntity, ID: "Nama calon wajib diisi.", EN: "The candidate name is required."},
+		errcatalog.Entry{Err: ErrInvalidReviewDecision, Code: "VALIDATION_ERROR", Status: http.StatusBadRequest, ID: "Keputusan verifikasi tidak valid.", EN: "Invalid review decision."},
+		errcatalog.Entry{Err: ErrChecklistIncomplete, Code: "CHECKLIST_INCOMPLETE", Status: http.StatusUnprocessableEntity, ID: "Checklist persyaratan belum lengkap atau belum terpenuhi.", EN: "The requirement checklist is incomplete or not met."},
+		errcatalog.Entry{Err: ErrReviewCommentRequired, Code: "VALIDATION_ERROR", Status: http.StatusUnprocessableEntity, ID: "Catatan verifikasi wajib diisi.", EN: "A review comment is required."},
+
+		errcatalog.Entry{Err: ErrBallotDrawNotFound, Code: "BALLOT_DRAW_NOT_FOUND", Status: http.StatusNotFound, ID: "Pengundian nomor urut belum dimulai.", EN: "The ballot draw has not started."},
+		errcatalog.Entry{Err: ErrBallotDrawAlreadyCommitted, Code: "BALLOT_DRAW_COMMITTED", Status: http.StatusConflict, ID: "Komitmen pengundian sudah dipublikasikan.", EN: "The ballot draw commitment is already published."},
+		errcatalog.Entry{Err: ErrBallotDrawAlreadyRevealed, Code: "CANDIDATE_NUMBER_LOCKED", Status: http.StatusConflict, ID: "Pengundian sudah dilakukan dan nomor urut telah dikunci.", EN: "The ballot draw is done and ballot numbers are locked."},
+		errcatalog.Entry{Err: ErrBallotDrawNotReady, Code: "VERIFICATION_NOT_CLOSED", Status: http.StatusUnprocessableEntity, ID: "Pengundian hanya dapat dilakukan setelah masa verifikasi berakhir.", EN: "The ballot draw can only run after verification closes."},
+		errcatalog.Entry{Err: ErrBallotDrawSeedInvalid, Code: "VALIDATION_ERROR", Status: http.StatusUnprocessableEntity, ID: "Seed wajib diisi, maksimal 128 karakter, dan satu baris.", EN: "The seed is required, at most 128 characters, on one line."},
+		errcatalog.Entry{Err: ErrBallotDrawSeedSubmitted, Code: "SEED_ALREADY_SUBMITTED", Status: http.StatusConflict, ID: "Seed sudah diserahkan dan tidak dapat diubah.", EN: "The seed is already submitted and cannot be changed."},
+		errcatalog.Entry{Err: ErrBallotDrawSeedClosed, Code: "SEED_DEADLINE_PASSED", Status: http.StatusUnprocessableEntity, ID: "Batas waktu penyerahan seed sudah lewat (masa verifikasi telah berakhir).", EN: "The seed deadline has passed (verification has closed)."},
+		errcatalog.Entry{Err: ErrBallotDrawNoCandidates, Code: "NO_CANDIDATES", Status: http.StatusUnprocessableEntity, ID: "Belum ada kandidat yang disetujui.", EN: "There are no approved candidates yet."},
+		errcatalog.Entry{Err: ErrBallotDrawNotParticipant, Code: "NOT_PARTICIPANT", Status: http.StatusUnprocessableEntity, ID: "Kandidat belum disetujui sehingga tidak ikut pengundian.", EN: "The candidate is not approved and is not part of the ballot draw."},
+	)
+}
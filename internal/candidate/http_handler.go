@@ -1,7 +1,6 @@
 package candidate
 
 import (
-	"errors"
 	"io"
 	"log/slog"
 	"net/http"
@@ -10,6 +9,7 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 
+	"pemira-api/internal/errcatalog"
 	"pemira-api/internal/http/response"
 )
 
@@ -35,7 +35,7 @@ func (h *Handler) ListPublic(w http.ResponseWriter, r *http.Request) {
 
 	electionID, err := parseIDParam(r, "electionID")
 	if err != nil || electionID <= 0 {
-		response.BadRequest(w, "INVALID_REQUEST", "electionID tidak valid.")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.Invalid("electionID"))
 		return
 	}
 
@@ -67,13 +67,13 @@ func (h *Handler) DetailPublic(w http.ResponseWriter, r *http.Request) {
 
 	electionID, err := parseIDParam(r, "electionID")
 	if err != nil || electionID <= 0 {
-		response.BadRequest(w, "INVALID_REQUEST", "electionID tidak valid.")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.Invalid("electionID"))
 		return
 	}
 
 	candidateID, err := parseIDParam(r, "candidateID")
 	if err != nil || candidateID <= 0 {
-		response.BadRequest(w, "INVALID_REQUEST", "candidateID tidak valid.")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.Invalid("candidateID"))
 		return
 	}
 
@@ -93,7 +93,7 @@ func (h *Handler) ListWithQR(w http.ResponseWriter, r *http.Request) {
 
 	electionID, err := parseIDParam(r, "electionID")
 	if err != nil || electionID <= 0 {
-		response.BadRequest(w, "INVALID_REQUEST", "electionID tidak valid.")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.Invalid("electionID"))
 		return
 	}
 
@@ -123,7 +123,7 @@ func (h *Handler) GetPublicProfileMedia(w http.ResponseWriter, r *http.Request)
 
 	candidateID, err := parseIDParam(r, "candidateID")
 	if err != nil || candidateID <= 0 {
-		response.BadRequest(w, "INVALID_REQUEST", "candidateID tidak valid.")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.Invalid("candidateID"))
 		return
 	}
 
@@ -138,7 +138,7 @@ func (h *Handler) GetPublicProfileMedia(w http.ResponseWriter, r *http.Request)
 		// Fetch blob from Supabase public URL
 		resp, err := http.Get(media.URL)
 		if err != nil {
-			response.InternalServerError(w, "INTERNAL_ERROR", "Gagal mengambil foto profil.")
+			response.Catalog(w, profilePhotoFetchFailed)
 			return
 		}
 		defer resp.Body.Close()
@@ -156,7 +156,7 @@ func (h *Handler) GetPublicProfileMedia(w http.ResponseWriter, r *http.Request)
 	}
 
 	// Fallback: return 404 if no URL
-	response.NotFound(w, "MEDIA_NOT_FOUND", "Foto profil tidak ditemukan.")
+	response.Catalog(w, profilePhotoNotFound)
 }
 
 // parseIDParam parses URL parameter as int64
@@ -178,27 +178,18 @@ func parseIntQuery(r *http.Request, key string, def int) int {
 	return n
 }
 
-// handleError maps service errors to HTTP responses
+// handleError maps service errors to HTTP responses through the error
+// catalog (see errors.go)
 func (h *Handler) handleError(w http.ResponseWriter, r *http.Request, err error) {
-	switch {
-	case errors.Is(err, ErrCandidateNotFound):
-		response.NotFound(w, "NOT_FOUND", "Kandidat tidak ditemukan untuk pemilu ini.")
-
-	case errors.Is(err, ErrCandidateNotPublished):
-		// Dari sisi mahasiswa, diperlakukan sama seperti not found
-		response.NotFound(w, "NOT_FOUND", "Kandidat tidak ditemukan untuk pemilu ini.")
-
-	case errors.Is(err, ErrCandidateMediaNotFound):
-		response.NotFound(w, "MEDIA_NOT_FOUND", "Media kandidat tidak ditemukan.")
-
-	default:
-		slog.Error(
-			"candidate handler error",
-			"request_id", middleware.GetReqID(r.Context()),
-			"method", r.Method,
-			"path", r.URL.Path,
-			"err", err,
-		)
-		response.InternalServerError(w, "INTERNAL_ERROR", "Terjadi kesalahan pada sistem.")
+	if response.DomainError(w, err) {
+		return
 	}
+	slog.Error(
+		"candidate handler error",
+		"request_id", middleware.GetReqID(r.Context()),
+		"method", r.Method,
+		"path", r.URL.Path,
+		"err", err,
+	)
+	response.InternalServerError(w, "INTERNAL_ERROR", errcatalog.InternalError)
 }
@@ -14,6 +14,12 @@ const (
 	CandidateMediaSlotPhotoExtra CandidateMediaSlot = "photo_extra"
 	CandidateMediaSlotPDFProgram CandidateMediaSlot = "pdf_program"
 	CandidateMediaSlotPDFVisimisi CandidateMediaSlot = "pdf_visimisi"
+
+	// Candidacy requirement documents uploaded by applicants during self-registration.
+	CandidateMediaSlotDocKTM            CandidateMediaSlot = "doc_ktm"
+	CandidateMediaSlotDocTranscript     CandidateMediaSlot = "doc_transcript"
+	CandidateMediaSlotDocStatement      CandidateMediaSlot = "doc_statement"
+	CandidateMediaSlotDocRecommendation CandidateMediaSlot = "doc_recommendation"
 )
 
 var (
@@ -33,6 +39,14 @@ func ParseCandidateMediaSlot(raw string) (CandidateMediaSlot, error) {
 		return CandidateMediaSlotPDFProgram, nil
 	case string(CandidateMediaSlotPDFVisimisi):
 		return CandidateMediaSlotPDFVisimisi, nil
+	case string(CandidateMediaSlotDocKTM):
+		return CandidateMediaSlotDocKTM, nil
+	case string(CandidateMediaSlotDocTranscript):
+		return CandidateMediaSlotDocTranscript, nil
+	case string(CandidateMediaSlotDocStatement):
+		return CandidateMediaSlotDocStatement, nil
+	case string(CandidateMediaSlotDocRecommendation):
+		return CandidateMediaSlotDocRecommendation, nil
 	default:
 		return "", ErrInvalidCandidateMediaSlot
 	}
@@ -0,0 +1,21 @@
+package candidate
+
+import (
+	"net/http"
+
+	"pemira-api/internal/errcatalog"
+)
+
+// Messages the candidate handlers answer with, each under its own code in the
+// error catalog
+var (
+	userIDMissing           = errcatalog.Define("USER_ID_MISSING", http.StatusUnauthorized, "User ID tidak ditemukan.", "User ID not found.")
+	profilePhotoTypeInvalid = errcatalog.Define("PROFILE_PHOTO_TYPE_INVALID", http.StatusUnprocessableEntity, "Foto profil harus berupa PNG atau JPEG.", "The profile photo must be PNG or JPEG.")
+	mediaStorageUnavailable = errcatalog.Define("MEDIA_STORAGE_UNAVAILABLE", http.StatusInternalServerError, "Gagal menyiapkan penyimpanan media.", "Failed to prepare media storage.")
+	profilePhotoFetchFailed = errcatalog.Define("PROFILE_PHOTO_FETCH_FAILED", http.StatusInternalServerError, "Gagal mengambil foto profil.", "Failed to fetch the profile photo.")
+	profilePhotoNotFound    = errcatalog.Define("PROFILE_PHOTO_NOT_FOUND", http.StatusNotFound, "Foto profil tidak ditemukan.", "Profile photo not found.")
+	profileMediaEndpoint    = errcatalog.Define("PROFILE_MEDIA_ENDPOINT", http.StatusBadRequest, "Gunakan endpoint /media/profile untuk foto profil.", "Use the /media/profile endpoint for profile photos.")
+	mediaPDFRequired        = errcatalog.Define("MEDIA_PDF_REQUIRED", http.StatusUnprocessableEntity, "File harus berupa PDF.", "The file must be a PDF.")
+	documentTypeInvalid     = errcatalog.Define("DOCUMENT_TYPE_INVALID", http.StatusUnprocessableEntity, "Dokumen harus berupa PDF, PNG, atau JPEG.", "Documents must be PDF, PNG, or JPEG.")
+	mediaImageRequired      = errcatalog.Define("MEDIA_IMAGE_REQUIRED", http.StatusUnprocessableEntity, "File harus berupa PNG atau JPEG.", "The file must be PNG or JPEG.")
+)
@@ -34,7 +34,7 @@ const qListCandidatesBase = `
 SELECT
 id,
 election_id,
-number,
+COALESCE(number, 0) AS number,
 name,
 photo_url,
 photo_media_id::text AS photo_media_id,
@@ -61,7 +61,7 @@ const qListCandidatesBaseNoPhotoMedia = `
 SELECT
 id,
 election_id,
-number,
+COALESCE(number, 0) AS number,
 name,
 photo_url,
 NULL::text AS photo_media_id,
@@ -179,7 +179,7 @@ const qGetCandidateByID = `
 SELECT
 id,
 election_id,
-number,
+COALESCE(number, 0) AS number,
 name,
 photo_url,
 photo_media_id::text AS photo_media_id,
@@ -205,7 +205,7 @@ const qGetCandidateByIDNoPhotoMedia = `
 SELECT
 id,
 election_id,
-number,
+COALESCE(number, 0) AS number,
 name,
 photo_url,
 NULL::text AS photo_media_id,
@@ -231,7 +231,7 @@ const qGetCandidateByCandidateID = `
 SELECT
 id,
 election_id,
-number,
+COALESCE(number, 0) AS number,
 name,
 photo_url,
 photo_media_id::text AS photo_media_id,
@@ -257,7 +257,7 @@ const qGetCandidateByCandidateIDNoPhotoMedia = `
 SELECT
 id,
 election_id,
-number,
+COALESCE(number, 0) AS number,
 name,
 photo_url,
 NULL::text AS photo_media_id,
@@ -530,9 +530,9 @@ election_id, number, name, photo_url, short_bio, long_bio, tagline,
 faculty_name, study_program_name, cohort_year, vision, missions,
 main_programs, media, social_links, status
 ) VALUES (
-$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16
+$1, NULLIF($2, 0), $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16
 )
-RETURNING id, election_id, number, name, photo_url, photo_media_id::text AS photo_media_id, short_bio, long_bio, tagline,
+RETURNING id, election_id, COALESCE(number, 0) AS number, name, photo_url, photo_media_id::text AS photo_media_id, short_bio, long_bio, tagline,
 faculty_name, study_program_name, cohort_year, vision, missions, main_programs,
 media, social_links, status, created_at, updated_at
 `
@@ -603,7 +603,7 @@ func (r *PgCandidateRepository) Create(ctx context.Context, candidate *Candidate
 
 const qUpdateCandidate = `
 UPDATE candidates SET
-number = $3,
+number = NULLIF($3, 0),
 name = $4,
 photo_url = $5,
 short_bio = $6,
@@ -620,7 +620,7 @@ social_links = $16,
 status = $17,
 updated_at = NOW()
 WHERE election_id = $1 AND id = $2
-RETURNING id, election_id, number, name, photo_url, photo_media_id::text AS photo_media_id, short_bio, long_bio, tagline,
+RETURNING id, election_id, COALESCE(number, 0) AS number, name, photo_url, photo_media_id::text AS photo_media_id, short_bio, long_bio, tagline,
 faculty_name, study_program_name, cohort_year, vision, missions, main_programs,
 media, social_links, status, created_at, updated_at
 `
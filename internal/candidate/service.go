@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"log/slog"
 	"math"
+
+	"pemira-api/internal/embargo"
 )
 
 // CandidateStatsMap maps candidate ID to their voting statistics
@@ -17,10 +19,25 @@ type StatsProvider interface {
 	GetCandidateStats(ctx context.Context, electionID int64) (CandidateStatsMap, error)
 }
 
+// embargoedStats returns no stats while the election's results are under
+// embargo
+type embargoedStats struct {
+	StatsProvider
+	policy embargo.Policy
+}
+
+func (e embargoedStats) GetCandidateStats(ctx context.Context, electionID int64) (CandidateStatsMap, error) {
+	if e.policy.Sealed(ctx, electionID) {
+		return CandidateStatsMap{}, nil
+	}
+	return e.StatsProvider.GetCandidateStats(ctx, electionID)
+}
+
 // Service provides business logic for candidate operations
 type Service struct {
-	repo  CandidateRepository
-	stats StatsProvider
+	repo       CandidateRepository
+	stats      StatsProvider
+	numberLock NumberLock
 }
 
 // NewService creates a new candidate service
@@ -31,6 +48,19 @@ func NewService(repo CandidateRepository, stats StatsProvider) *Service {
 	}
 }
 
+// SetEmbargo leaves candidate stats empty while the election's results are
+// under embargo
+func (s *Service) SetEmbargo(p embargo.Policy) {
+	if s.stats != nil && p != nil {
+		s.stats = embargoedStats{StatsProvider: s.stats, policy: p}
+	}
+}
+
+// SetNumberLock makes ballot numbers read-only once the ballot draw is revealed
+func (s *Service) SetNumberLock(lock NumberLock) {
+	s.numberLock = lock
+}
+
 // CandidateListItemDTO represents a candidate in list view
 type CandidateListItemDTO struct {
 	ID               int64          `json:"id"`
@@ -373,6 +403,10 @@ func (s *Service) AdminCreateCandidate(
 		return nil, ErrCandidateStatusInvalid
 	}
 
+	if err := ensureNumbersUnlocked(ctx, s.numberLock, electionID); err != nil {
+		return nil, err
+	}
+
 	// Check if number is already taken
 	exists, err := s.repo.CheckNumberExists(ctx, electionID, req.Number, nil)
 	if err != nil {
@@ -504,6 +538,9 @@ func (s *Service) AdminUpdateCandidate(
 
 	// Check if number is being changed and if it's already taken
 	if req.Number != nil && *req.Number != existing.Number {
+		if err := ensureNumbersUnlocked(ctx, s.numberLock, electionID); err != nil {
+			return nil, err
+		}
 		exists, err := s.repo.CheckNumberExists(ctx, electionID, *req.Number, &candidateID)
 		if err != nil {
 			return nil, err
@@ -1,6 +1,11 @@
 package config
 
 import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/kelseyhightower/envconfig"
 )
 
@@ -13,11 +18,96 @@ type Config struct {
 	JWTSecret     string `envconfig:"JWT_SECRET" required:"true"`
 	JWTExpiration string `envconfig:"JWT_EXPIRATION" default:"24h"`
 
+	// RedisURL points the rate limiter at a Redis-compatible server shared
+	// by all instances; without it limits are kept per process.
 	RedisURL string `envconfig:"REDIS_URL"`
 
+	// Rate limits as LIMIT/WINDOW (e.g. 10/1m); "off" disables one.
+	RateLimitEnabled       bool   `envconfig:"RATE_LIMIT_ENABLED" default:"true"`
+	RateLimitLogin         string `envconfig:"RATE_LIMIT_LOGIN" default:"20/1m"`
+	RateLimitLoginUsername string `envconfig:"RATE_LIMIT_LOGIN_USERNAME" default:"10/15m"`
+	RateLimitRegister      string `envconfig:"RATE_LIMIT_REGISTER" default:"10/10m"`
+	RateLimitReset         string `envconfig:"RATE_LIMIT_RESET" default:"5/15m"`
+	RateLimitVote          string `envconfig:"RATE_LIMIT_VOTE" default:"10/1m"`
+	RateLimitQRScan        string `envconfig:"RATE_LIMIT_QR_SCAN" default:"120/1m"`
+
+	// Progressive lockout after failed password logins; 0 disables.
+	LoginLockoutThreshold int           `envconfig:"LOGIN_LOCKOUT_THRESHOLD" default:"5"`
+	LoginLockoutBase      time.Duration `envconfig:"LOGIN_LOCKOUT_BASE" default:"1m"`
+	LoginLockoutMax       time.Duration `envconfig:"LOGIN_LOCKOUT_MAX" default:"1h"`
+	LoginLockoutWindow    time.Duration `envconfig:"LOGIN_LOCKOUT_WINDOW" default:"24h"`
+
 	LogLevel string `envconfig:"LOG_LEVEL" default:"info"`
 
+	// OpenTelemetry tracing over OTLP/HTTP; disabled without an endpoint.
+	OTelEndpoint        string  `envconfig:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+	OTelServiceName     string  `envconfig:"OTEL_SERVICE_NAME" default:"pemira-api"`
+	OTelTraceSampleRate float64 `envconfig:"OTEL_TRACE_SAMPLE_RATE" default:"1"`
+
+	// HealthPoolSaturation is the share of pool connections in use at which
+	// /health/ready reports the pool as saturated.
+	HealthPoolSaturation float64 `envconfig:"HEALTH_POOL_SATURATION" default:"0.9"`
+
 	CORSAllowedOrigins string `envconfig:"CORS_ALLOWED_ORIGINS" default:"http://localhost:5173,http://localhost:3000"`
+
+	// RecountInterval is how often open elections are recounted; 0 disables.
+	RecountInterval time.Duration `envconfig:"RECOUNT_INTERVAL" default:"5m"`
+
+	// ResultsSnapshotInterval is how often public results/turnout snapshots
+	// are refreshed; 0 disables the job.
+	ResultsSnapshotInterval time.Duration `envconfig:"RESULTS_SNAPSHOT_INTERVAL" default:"30s"`
+
+	// Default TPS check-in TTLs (elections may override them) and how often
+	// stale check-ins are expired; a 0 interval disables the sweeper.
+	CheckinPendingTTL    time.Duration `envconfig:"CHECKIN_PENDING_TTL" default:"30m"`
+	CheckinApprovedTTL   time.Duration `envconfig:"CHECKIN_APPROVED_TTL" default:"15m"`
+	CheckinSweepInterval time.Duration `envconfig:"CHECKIN_SWEEP_INTERVAL" default:"1m"`
+
+	// TOTPRequiredRoles lists roles (comma separated) that must use 2FA.
+	TOTPRequiredRoles string `envconfig:"TOTP_REQUIRED_ROLES"`
+	TOTPIssuer        string `envconfig:"TOTP_ISSUER" default:"PEMIRA"`
+	// TOTPEncryptionKey encrypts TOTP secrets. It must not be JWT_SECRET and
+	// is required while TOTPRequiredRoles is set; without it 2FA is off.
+	TOTPEncryptionKey string `envconfig:"TOTP_ENCRYPTION_KEY"`
+
+	// OIDC single sign-on; enabled when OIDCIssuer and OIDCClientID are set.
+	OIDCIssuer              string `envconfig:"OIDC_ISSUER"`
+	OIDCClientID            string `envconfig:"OIDC_CLIENT_ID"`
+	OIDCClientSecret        string `envconfig:"OIDC_CLIENT_SECRET"`
+	OIDCRedirectURL         string `envconfig:"OIDC_REDIRECT_URL"`
+	OIDCFrontendRedirectURL string `envconfig:"OIDC_FRONTEND_REDIRECT_URL"`
+	OIDCScopes              string `envconfig:"OIDC_SCOPES" default:"openid,profile,email"`
+	OIDCNIMClaim            string `envconfig:"OIDC_NIM_CLAIM" default:"nim"`
+	OIDCNIDNClaim           string `envconfig:"OIDC_NIDN_CLAIM" default:"nidn"`
+	OIDCNIPClaim            string `envconfig:"OIDC_NIP_CLAIM" default:"nip"`
+	OIDCFacultyClaim        string `envconfig:"OIDC_FACULTY_CLAIM" default:"faculty"`
+	// PasswordLoginDisabledRoles lists roles (comma separated) that may only
+	// log in through SSO.
+	PasswordLoginDisabledRoles string `envconfig:"PASSWORD_LOGIN_DISABLED_ROLES"`
+
+	// EmailVerificationRequired keeps self-registered accounts pending until
+	// their email is verified. It is off by default so deployments without
+	// SMTP and domain settings keep starting; production should turn it on.
+	EmailVerificationRequired bool   `envconfig:"EMAIL_VERIFICATION_REQUIRED" default:"false"`
+	EmailVerificationURL      string `envconfig:"EMAIL_VERIFICATION_URL"`
+	// Allowed registration email domains (comma separated) per voter type;
+	// all three are required while verification is on.
+	EmailDomainsStudent  string `envconfig:"EMAIL_DOMAINS_STUDENT"`
+	EmailDomainsLecturer string `envconfig:"EMAIL_DOMAINS_LECTURER"`
+	EmailDomainsStaff    string `envconfig:"EMAIL_DOMAINS_STAFF"`
+
+	// SMTP relay for outgoing email; without SMTPHost mail is only logged.
+	SMTPHost     string `envconfig:"SMTP_HOST"`
+	SMTPPort     int    `envconfig:"SMTP_PORT" default:"587"`
+	SMTPUsername string `envconfig:"SMTP_USERNAME"`
+	SMTPPassword string `envconfig:"SMTP_PASSWORD"`
+	SMTPFrom     string `envconfig:"SMTP_FROM" default:"PEMIRA <no-reply@pemira.ac.id>"`
+
+	// Academic roster source (CSV or JSON over HTTP); RosterSyncInterval
+	// schedules full syncs from it, 0 disables.
+	RosterSourceURL    string        `envconfig:"ROSTER_SOURCE_URL"`
+	RosterSourceToken  string        `envconfig:"ROSTER_SOURCE_TOKEN"`
+	RosterSyncInterval time.Duration `envconfig:"ROSTER_SYNC_INTERVAL" default:"0"`
 }
 
 func Load() (*Config, error) {
@@ -25,5 +115,50 @@ func Load() (*Config, error) {
 	if err := envconfig.Process("", &cfg); err != nil {
 		return nil, err
 	}
+	if err := cfg.validateEmailVerification(); err != nil {
+		return nil, err
+	}
+	if err := cfg.validateTOTP(); err != nil {
+		return nil, err
+	}
 	return &cfg, nil
 }
+
+// validateEmailVerification refuses verification without domain allowlists:
+// with an empty list any mailbox named after an identifier would pass, such
+// as 2021001@gmail.com registered by someone else.
+func (c *Config) validateEmailVerification() error {
+	if !c.EmailVerificationRequired {
+		return nil
+	}
+	var missing []string
+	for _, v := range []struct{ name, value string }{
+		{"EMAIL_DOMAINS_STUDENT", c.EmailDomainsStudent},
+		{"EMAIL_DOMAINS_LECTURER", c.EmailDomainsLecturer},
+		{"EMAIL_DOMAINS_STAFF", c.EmailDomainsStaff},
+	} {
+		if strings.Trim(v.value, " ,") == "" {
+			missing = append(missing, v.name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("EMAIL_VERIFICATION_REQUIRED needs %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// validateTOTP requires a key of its own for TOTP secrets once a role must
+// use 2FA. Reusing JWT_SECRET would let one leaked secret both forge tokens
+// and decrypt every second factor.
+func (c *Config) validateTOTP() error {
+	if c.TOTPEncryptionKey == "" {
+		if strings.Trim(c.TOTPRequiredRoles, " ,") != "" {
+			return errors.New("TOTP_REQUIRED_ROLES needs TOTP_ENCRYPTION_KEY")
+		}
+		return nil
+	}
+	if c.TOTPEncryptionKey == c.JWTSecret {
+		return errors.New("TOTP_ENCRYPTION_KEY must differ from JWT_SECRET")
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package crypto
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+var ErrBallotCandidates = errors.New("ballot does not list exactly the election's candidates")
+
+// A ballot holds one ciphertext per candidate encrypting 0 or 1, each with a
+// proof of that, plus a proof that the ciphertexts sum to exactly 1.
+var (
+	choiceValues = []int64{0, 1}
+	sumValues    = []int64{1}
+)
+
+type BallotChoice struct {
+	CandidateID int64            `json:"candidate_id"`
+	Ciphertext  Ciphertext       `json:"ciphertext"`
+	Proof       DisjunctiveProof `json:"proof"`
+}
+
+type EncryptedBallot struct {
+	Choices  []BallotChoice   `json:"choices"`
+	SumProof DisjunctiveProof `json:"sum_proof"`
+}
+
+func choiceContext(electionID, candidateID int64) string {
+	return fmt.Sprintf("pemira|ballot|%d|candidate|%d", electionID, candidateID)
+}
+
+func sumContext(electionID int64) string {
+	return fmt.Sprintf("pemira|ballot|%d|sum", electionID)
+}
+
+// EncryptBallot is the voter-client side: it encrypts a vote for selected
+// under pk. candidateIDs fixes the order of choices and must match the order
+// the election publishes.
+func (g *Group) EncryptBallot(pk *big.Int, electionID int64, candidateIDs []int64, selected int64) (*EncryptedBallot, error) {
+	ballot := &EncryptedBallot{}
+	sum := ZeroCiphertext()
+	sumR := new(big.Int)
+	found := false
+
+	for _, id := range candidateIDs {
+		var m int64
+		if id == selected {
+			m, found = 1, true
+		}
+		r, err := g.RandomScalar()
+		if err != nil {
+			return nil, err
+		}
+		ct := g.Encrypt(pk, m, r)
+		proof, err := g.ProveEncrypts(choiceContext(electionID, id), pk, ct, m, r, choiceValues)
+		if err != nil {
+			return nil, err
+		}
+		ballot.Choices = append(ballot.Choices, BallotChoice{CandidateID: id, Ciphertext: ct, Proof: proof})
+		sum = g.Add(sum, ct)
+		sumR = g.modQ(sumR.Add(sumR, r))
+	}
+	if !found {
+		return nil, ErrBallotCandidates
+	}
+
+	proof, err := g.ProveEncrypts(sumContext(electionID), pk, sum, 1, sumR, sumValues)
+	if err != nil {
+		return nil, err
+	}
+	ballot.SumProof = proof
+	return ballot, nil
+}
+
+// VerifyBallot checks a ballot is well-formed for the election: the expected
+// candidates in order, each encrypting 0 or 1, summing to 1.
+func (g *Group) VerifyBallot(pk *big.Int, electionID int64, candidateIDs []int64, b *EncryptedBallot) error {
+	if b == nil || len(b.Choices) != len(candidateIDs) {
+		return ErrBallotCandidates
+	}
+	sum := ZeroCiphertext()
+	for i, c := range b.Choices {
+		if c.CandidateID != candidateIDs[i] {
+			return ErrBallotCandidates
+		}
+		if err := g.VerifyEncrypts(choiceContext(electionID, c.CandidateID), pk, c.Ciphertext, choiceValues, c.Proof); err != nil {
+			return fmt.Errorf("candidate %d: %w", c.CandidateID, err)
+		}
+		sum = g.Add(sum, c.Ciphertext)
+	}
+	if err := g.VerifyEncrypts(sumContext(electionID), pk, sum, sumValues, b.SumProof); err != nil {
+		return fmt.Errorf("sum: %w", err)
+	}
+	return nil
+}
+
+// Tracker is the public fingerprint of a ballot. The voter keeps it and
+// finds it on the bulletin board to check the ballot was counted.
+func (b *EncryptedBallot) Tracker() string {
+	data, _ := json.Marshal(b)
+	sum := sha256.Sum256(data)
+	return "eb_" + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,167 @@
+package crypto
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+)
+
+func TestDefaultGroup(t *testing.T) {
+	g := DefaultGroup()
+	if !g.P.ProbablyPrime(20) || !g.Q.ProbablyPrime(20) {
+		t.Fatal("P and Q must be prime")
+	}
+	if !g.IsElement(g.G) {
+		t.Fatal("generator must lie in the order-Q subgroup")
+	}
+}
+
+func TestElemJSON(t *testing.T) {
+	in := E(big.NewInt(0xbeef))
+	data, _ := json.Marshal(in)
+	if string(data) != `"beef"` {
+		t.Fatalf("marshal = %s", data)
+	}
+	var out Elem
+	if err := json.Unmarshal(data, &out); err != nil || out.Int().Int64() != 0xbeef {
+		t.Fatalf("unmarshal = %v, %v", out.Int(), err)
+	}
+}
+
+type testTrustee struct {
+	index     int
+	transport *big.Int
+	poly      Polynomial
+	share     *big.Int
+}
+
+// runCeremony plays a full k-of-n key ceremony and returns the trustees with
+// their combined shares and the public dealings.
+func runCeremony(t *testing.T, g *Group, ctx string, k, n int) ([]*testTrustee, [][]*Elem) {
+	t.Helper()
+	trustees := make([]*testTrustee, n)
+	transportKeys := map[int]*big.Int{}
+	for i := range trustees {
+		secret, _ := g.RandomScalar()
+		trustees[i] = &testTrustee{index: i + 1, transport: secret, share: new(big.Int)}
+		transportKeys[i+1] = g.GExp(secret)
+	}
+
+	dealings := make([]Dealing, n)
+	for i, tr := range trustees {
+		tr.poly, _ = g.NewPolynomial(k)
+		d, err := g.Deal(ctx, tr.index, tr.poly, transportKeys)
+		if err != nil {
+			t.Fatalf("deal: %v", err)
+		}
+		if err := g.VerifyCommitments(ctx, tr.index, k, d.Commitments, d.Proof); err != nil {
+			t.Fatalf("commitments: %v", err)
+		}
+		dealings[i] = d
+	}
+
+	commitments := make([][]*Elem, n)
+	for i, d := range dealings {
+		commitments[i] = d.Commitments
+		for _, es := range d.Shares {
+			tr := trustees[es.Recipient-1]
+			s, err := g.DecryptShare(ctx, i+1, tr.transport, es)
+			if err != nil {
+				t.Fatalf("decrypt share: %v", err)
+			}
+			if err := g.VerifyShare(d.Commitments, tr.index, s); err != nil {
+				t.Fatalf("share from %d to %d: %v", i+1, tr.index, err)
+			}
+			tr.share = g.modQ(tr.share.Add(tr.share, s))
+		}
+	}
+	return trustees, commitments
+}
+
+func TestThresholdElection(t *testing.T) {
+	g := DefaultGroup()
+	const ctx = "test|election|1"
+	trustees, commitments := runCeremony(t, g, ctx, 2, 3)
+	pk := g.JointPublicKey(commitments)
+
+	for _, tr := range trustees {
+		if g.VerificationKey(commitments, tr.index).Cmp(g.GExp(tr.share)) != 0 {
+			t.Fatalf("verification key of trustee %d does not match its share", tr.index)
+		}
+	}
+
+	candidates := []int64{10, 11, 12}
+	votes := []int64{10, 12, 12, 11, 12}
+	ballots := make([]*EncryptedBallot, 0, len(votes))
+	for _, v := range votes {
+		b, err := g.EncryptBallot(pk, 1, candidates, v)
+		if err != nil {
+			t.Fatalf("encrypt: %v", err)
+		}
+		if err := g.VerifyBallot(pk, 1, candidates, b); err != nil {
+			t.Fatalf("verify ballot: %v", err)
+		}
+		ballots = append(ballots, b)
+	}
+
+	tally := g.Aggregate(candidates, ballots)
+	partials := map[int][]PartialDecryption{}
+	for _, tr := range trustees[1:] {
+		ps, err := g.PartialDecrypt(ctx, tr.index, tr.share, tally)
+		if err != nil {
+			t.Fatalf("partial: %v", err)
+		}
+		if err := g.VerifyPartial(ctx, tr.index, g.VerificationKey(commitments, tr.index), tally, ps); err != nil {
+			t.Fatalf("verify partial: %v", err)
+		}
+		partials[tr.index] = ps
+	}
+
+	counts, err := g.DecryptTally(2, tally, partials, int64(len(ballots)))
+	if err != nil {
+		t.Fatalf("decrypt tally: %v", err)
+	}
+	want := map[int64]int64{10: 1, 11: 1, 12: 3}
+	for id, n := range want {
+		if counts[id] != n {
+			t.Errorf("candidate %d = %d, want %d", id, counts[id], n)
+		}
+	}
+
+	// A partial decryption made with the wrong share must be rejected.
+	forged, _ := g.PartialDecrypt(ctx, 1, trustees[2].share, tally)
+	if err := g.VerifyPartial(ctx, 1, g.VerificationKey(commitments, 1), tally, forged); err == nil {
+		t.Error("partial decryption under the wrong share was accepted")
+	}
+}
+
+func TestVerifyBallot_RejectsInvalid(t *testing.T) {
+	g := DefaultGroup()
+	x, _ := g.RandomScalar()
+	pk := g.GExp(x)
+	candidates := []int64{1, 2}
+
+	// Two votes for one candidate: the 0/1 proof cannot be produced honestly,
+	// so reuse a valid proof on a ciphertext of 2.
+	b, _ := g.EncryptBallot(pk, 7, candidates, 1)
+	r, _ := g.RandomScalar()
+	b.Choices[0].Ciphertext = g.Encrypt(pk, 2, r)
+	if err := g.VerifyBallot(pk, 7, candidates, b); err == nil {
+		t.Error("ballot encrypting 2 was accepted")
+	}
+
+	// A valid ballot replayed into another election.
+	b, _ = g.EncryptBallot(pk, 7, candidates, 2)
+	if err := g.VerifyBallot(pk, 8, candidates, b); err == nil {
+		t.Error("ballot from another election was accepted")
+	}
+
+	// Candidates out of order.
+	if err := g.VerifyBallot(pk, 7, []int64{2, 1}, b); err == nil {
+		t.Error("ballot with the wrong candidate order was accepted")
+	}
+
+	if _, err := g.EncryptBallot(pk, 7, candidates, 3); err == nil {
+		t.Error("vote for an unknown candidate was encrypted")
+	}
+}
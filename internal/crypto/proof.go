@@ -0,0 +1,200 @@
+package crypto
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// SchnorrProof shows knowledge of x with Y = G^x.
+type SchnorrProof struct {
+	Commitment *Elem `json:"commitment"`
+	Response   *Elem `json:"response"`
+}
+
+// ProveKnowledge proves knowledge of x for y = G^x, bound to context.
+func (g *Group) ProveKnowledge(context string, x *big.Int) (SchnorrProof, error) {
+	w, err := g.RandomScalar()
+	if err != nil {
+		return SchnorrProof{}, err
+	}
+	y := g.GExp(x)
+	a := g.GExp(w)
+	c := g.challenge("schnorr|"+context, y, a)
+	z := g.modQ(new(big.Int).Add(w, new(big.Int).Mul(c, x)))
+	return SchnorrProof{Commitment: E(a), Response: E(z)}, nil
+}
+
+// VerifyKnowledge checks a SchnorrProof for y.
+func (g *Group) VerifyKnowledge(context string, y *big.Int, p SchnorrProof) error {
+	if p.Commitment == nil || p.Response == nil || !g.IsElement(y) ||
+		!g.IsElement(p.Commitment.Int()) || !g.isScalar(p.Response.Int()) {
+		return ErrInvalidProof
+	}
+	c := g.challenge("schnorr|"+context, y, p.Commitment.Int())
+	if g.GExp(p.Response.Int()).Cmp(g.Mul(p.Commitment.Int(), g.Exp(y, c))) != 0 {
+		return ErrInvalidProof
+	}
+	return nil
+}
+
+// DLEQProof is a Chaum-Pedersen proof that log_G1(H1) = log_G2(H2). One
+// DLEQProof is also one branch of a DisjunctiveProof, hence the explicit
+// challenge.
+type DLEQProof struct {
+	A         *Elem `json:"a"`
+	B         *Elem `json:"b"`
+	Challenge *Elem `json:"challenge"`
+	Response  *Elem `json:"response"`
+}
+
+type dleqStatement struct {
+	g1, h1, g2, h2 *big.Int
+}
+
+func (s dleqStatement) values() []*big.Int {
+	return []*big.Int{s.g1, s.h1, s.g2, s.h2}
+}
+
+// dleqCommit starts an honest proof, returning the nonce.
+func (g *Group) dleqCommit(s dleqStatement) (w *big.Int, a, b *big.Int, err error) {
+	w, err = g.RandomScalar()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return w, g.Exp(s.g1, w), g.Exp(s.g2, w), nil
+}
+
+// dleqSimulate builds an accepting transcript for a statement the prover cannot
+// prove, given a challenge it picked beforehand.
+func (g *Group) dleqSimulate(s dleqStatement) (DLEQProof, error) {
+	c, err := g.RandomScalar()
+	if err != nil {
+		return DLEQProof{}, err
+	}
+	z, err := g.RandomScalar()
+	if err != nil {
+		return DLEQProof{}, err
+	}
+	a := g.Div(g.Exp(s.g1, z), g.Exp(s.h1, c))
+	b := g.Div(g.Exp(s.g2, z), g.Exp(s.h2, c))
+	return DLEQProof{A: E(a), B: E(b), Challenge: E(c), Response: E(z)}, nil
+}
+
+func (g *Group) dleqCheck(s dleqStatement, p DLEQProof) bool {
+	if p.A == nil || p.B == nil || p.Challenge == nil || p.Response == nil {
+		return false
+	}
+	if !g.IsElement(p.A.Int()) || !g.IsElement(p.B.Int()) ||
+		!g.isScalar(p.Challenge.Int()) || !g.isScalar(p.Response.Int()) {
+		return false
+	}
+	c, z := p.Challenge.Int(), p.Response.Int()
+	return g.Exp(s.g1, z).Cmp(g.Mul(p.A.Int(), g.Exp(s.h1, c))) == 0 &&
+		g.Exp(s.g2, z).Cmp(g.Mul(p.B.Int(), g.Exp(s.h2, c))) == 0
+}
+
+// ProveDLEQ proves log_G(G^x) = log_base(base^x).
+func (g *Group) ProveDLEQ(context string, base, x *big.Int) (DLEQProof, error) {
+	s := dleqStatement{g.G, g.GExp(x), base, g.Exp(base, x)}
+	w, a, b, err := g.dleqCommit(s)
+	if err != nil {
+		return DLEQProof{}, err
+	}
+	c := g.challenge("dleq|"+context, append(s.values(), a, b)...)
+	z := g.modQ(new(big.Int).Add(w, new(big.Int).Mul(c, x)))
+	return DLEQProof{A: E(a), B: E(b), Challenge: E(c), Response: E(z)}, nil
+}
+
+// VerifyDLEQ checks that log_G(y) = log_base(d).
+func (g *Group) VerifyDLEQ(context string, y, base, d *big.Int, p DLEQProof) error {
+	if !g.IsElement(y) || !g.IsElement(base) || !g.IsElement(d) {
+		return ErrInvalidElement
+	}
+	s := dleqStatement{g.G, y, base, d}
+	if !g.dleqCheck(s, p) {
+		return ErrInvalidProof
+	}
+	c := g.challenge("dleq|"+context, append(s.values(), p.A.Int(), p.B.Int())...)
+	if c.Cmp(p.Challenge.Int()) != 0 {
+		return ErrInvalidProof
+	}
+	return nil
+}
+
+// DisjunctiveProof shows a ciphertext encrypts one of a public list of
+// values without revealing which: one DLEQ branch per allowed value, all but
+// the true one simulated, with challenges summing to the Fiat-Shamir hash.
+type DisjunctiveProof []DLEQProof
+
+// branch m of ciphertext (A, B) under pk: log_G(A) = log_pk(B / G^m).
+func (g *Group) encryptsStatement(pk *big.Int, ct Ciphertext, m int64) dleqStatement {
+	return dleqStatement{g.G, ct.A.Int(), pk, g.Div(ct.B.Int(), g.GExp(big.NewInt(m)))}
+}
+
+func (g *Group) disjunctiveChallenge(context string, pk *big.Int, ct Ciphertext, branches []DLEQProof) *big.Int {
+	values := []*big.Int{pk, ct.A.Int(), ct.B.Int()}
+	for _, b := range branches {
+		values = append(values, b.A.Int(), b.B.Int())
+	}
+	return g.challenge("or|"+context, values...)
+}
+
+// ProveEncrypts proves ct = Encrypt(pk, m, r) with m in allowed.
+func (g *Group) ProveEncrypts(context string, pk *big.Int, ct Ciphertext, m int64, r *big.Int, allowed []int64) (DisjunctiveProof, error) {
+	truth := -1
+	proof := make(DisjunctiveProof, len(allowed))
+	var w *big.Int
+	for i, v := range allowed {
+		s := g.encryptsStatement(pk, ct, v)
+		if v == m && truth < 0 {
+			truth = i
+			var a, b *big.Int
+			var err error
+			if w, a, b, err = g.dleqCommit(s); err != nil {
+				return nil, err
+			}
+			proof[i] = DLEQProof{A: E(a), B: E(b)}
+			continue
+		}
+		p, err := g.dleqSimulate(s)
+		if err != nil {
+			return nil, err
+		}
+		proof[i] = p
+	}
+	if truth < 0 {
+		return nil, fmt.Errorf("crypto: plaintext %d is not an allowed value", m)
+	}
+
+	c := g.disjunctiveChallenge(context, pk, ct, proof)
+	for i, p := range proof {
+		if i != truth {
+			c.Sub(c, p.Challenge.Int())
+		}
+	}
+	c = g.modQ(c)
+	proof[truth].Challenge = E(c)
+	proof[truth].Response = E(g.modQ(new(big.Int).Add(w, new(big.Int).Mul(c, r))))
+	return proof, nil
+}
+
+// VerifyEncrypts checks a DisjunctiveProof that ct encrypts a value in allowed.
+func (g *Group) VerifyEncrypts(context string, pk *big.Int, ct Ciphertext, allowed []int64, proof DisjunctiveProof) error {
+	if !g.validCiphertext(ct) {
+		return ErrInvalidElement
+	}
+	if len(proof) != len(allowed) {
+		return ErrInvalidProof
+	}
+	sum := new(big.Int)
+	for i, v := range allowed {
+		if !g.dleqCheck(g.encryptsStatement(pk, ct, v), proof[i]) {
+			return ErrInvalidProof
+		}
+		sum.Add(sum, proof[i].Challenge.Int())
+	}
+	if g.modQ(sum).Cmp(g.disjunctiveChallenge(context, pk, ct, proof)) != 0 {
+		return ErrInvalidProof
+	}
+	return nil
+}
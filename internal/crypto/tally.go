@@ -0,0 +1,102 @@
+package crypto
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+var ErrPartialMismatch = errors.New("partial decryption does not cover the encrypted tally")
+
+// CandidateTally is the homomorphic sum of every ballot's choice for one
+// candidate.
+type CandidateTally struct {
+	CandidateID int64      `json:"candidate_id"`
+	Ciphertext  Ciphertext `json:"ciphertext"`
+}
+
+// Aggregate multiplies the verified ballots into one ciphertext per
+// candidate.
+func (g *Group) Aggregate(candidateIDs []int64, ballots []*EncryptedBallot) []CandidateTally {
+	out := make([]CandidateTally, len(candidateIDs))
+	for i, id := range candidateIDs {
+		out[i] = CandidateTally{CandidateID: id, Ciphertext: ZeroCiphertext()}
+	}
+	for _, b := range ballots {
+		for i, c := range b.Choices {
+			out[i].Ciphertext = g.Add(out[i].Ciphertext, c.Ciphertext)
+		}
+	}
+	return out
+}
+
+// PartialDecryption is one trustee's factor A^{x_j} for one candidate tally,
+// with a proof it used the same x_j as its public verification key.
+type PartialDecryption struct {
+	CandidateID int64     `json:"candidate_id"`
+	Factor      *Elem     `json:"factor"`
+	Proof       DLEQProof `json:"proof"`
+}
+
+func partialContext(context string, trustee int, candidateID int64) string {
+	return fmt.Sprintf("%s|trustee|%d|candidate|%d", context, trustee, candidateID)
+}
+
+// PartialDecrypt is the trustee side: it decrypts its share of every
+// candidate tally.
+func (g *Group) PartialDecrypt(context string, trustee int, share *big.Int, tally []CandidateTally) ([]PartialDecryption, error) {
+	out := make([]PartialDecryption, len(tally))
+	for i, t := range tally {
+		a := t.Ciphertext.A.Int()
+		proof, err := g.ProveDLEQ(partialContext(context, trustee, t.CandidateID), a, share)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = PartialDecryption{CandidateID: t.CandidateID, Factor: E(g.Exp(a, share)), Proof: proof}
+	}
+	return out, nil
+}
+
+// VerifyPartial checks trustee's partial decryptions against its
+// verification key.
+func (g *Group) VerifyPartial(context string, trustee int, verificationKey *big.Int, tally []CandidateTally, partials []PartialDecryption) error {
+	if len(partials) != len(tally) {
+		return ErrPartialMismatch
+	}
+	for i, t := range tally {
+		p := partials[i]
+		if p.CandidateID != t.CandidateID || p.Factor == nil {
+			return ErrPartialMismatch
+		}
+		ctx := partialContext(context, trustee, t.CandidateID)
+		if err := g.VerifyDLEQ(ctx, verificationKey, t.Ciphertext.A.Int(), p.Factor.Int(), p.Proof); err != nil {
+			return fmt.Errorf("candidate %d: %w", t.CandidateID, err)
+		}
+	}
+	return nil
+}
+
+// DecryptTally combines verified partials, keyed by trustee index, into
+// per-candidate counts. maxVotes bounds the discrete-log search.
+func (g *Group) DecryptTally(k int, tally []CandidateTally, partials map[int][]PartialDecryption, maxVotes int64) (map[int64]int64, error) {
+	counts := make(map[int64]int64, len(tally))
+	for i, t := range tally {
+		factors := make(map[int]*big.Int, len(partials))
+		for j, ps := range partials {
+			if len(ps) != len(tally) {
+				return nil, ErrPartialMismatch
+			}
+			factors[j] = ps[i].Factor.Int()
+		}
+		factor, err := g.CombineFactors(k, factors)
+		if err != nil {
+			return nil, err
+		}
+		n, err := g.DecodeTally(t.Ciphertext, factor, maxVotes)
+		if err != nil {
+			return nil, fmt.Errorf("candidate %d: %w", t.CandidateID, err)
+		}
+		counts[t.CandidateID] = n
+	}
+	return counts, nil
+}
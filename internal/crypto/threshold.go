@@ -0,0 +1,243 @@
+package crypto
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+)
+
+var (
+	ErrInvalidShare     = errors.New("key share does not match the dealer's commitments")
+	ErrNotEnoughShares  = errors.New("not enough partial decryptions to reach the threshold")
+	ErrInvalidThreshold = errors.New("threshold must be between 1 and the number of trustees")
+)
+
+// The election key is generated jointly (Pedersen DKG with Feldman
+// commitments): every trustee i deals a random polynomial f_i of degree k-1,
+// publishes G^{coefficients} and sends f_i(j) to trustee j. Trustee j's key
+// share is x_j = sum_i f_i(j); the election secret sum_i f_i(0) never exists
+// in one place, and any k shares reconstruct A^secret via Lagrange
+// interpolation in the exponent.
+
+// Polynomial holds a dealer's secret coefficients, constant term first.
+type Polynomial []*big.Int
+
+// NewPolynomial draws a random polynomial for threshold k.
+func (g *Group) NewPolynomial(k int) (Polynomial, error) {
+	if k < 1 {
+		return nil, ErrInvalidThreshold
+	}
+	p := make(Polynomial, k)
+	for i := range p {
+		c, err := g.RandomScalar()
+		if err != nil {
+			return nil, err
+		}
+		p[i] = c
+	}
+	return p, nil
+}
+
+// Eval returns f(x) mod Q.
+func (g *Group) Eval(p Polynomial, x int) *big.Int {
+	out := new(big.Int)
+	bx := big.NewInt(int64(x))
+	for i := len(p) - 1; i >= 0; i-- {
+		out.Mul(out, bx)
+		out.Add(out, p[i])
+		out.Mod(out, g.Q)
+	}
+	return out
+}
+
+// Commit returns the Feldman commitments G^{a_k}.
+func (g *Group) Commit(p Polynomial) []*Elem {
+	out := make([]*Elem, len(p))
+	for i, c := range p {
+		out[i] = E(g.GExp(c))
+	}
+	return out
+}
+
+// commitmentAt returns G^{f(x)} computed from the public commitments.
+func (g *Group) commitmentAt(commitments []*Elem, x int) *big.Int {
+	out := big.NewInt(1)
+	pow := big.NewInt(1)
+	bx := big.NewInt(int64(x))
+	for _, c := range commitments {
+		out = g.Mul(out, g.Exp(c.Int(), pow))
+		pow = g.modQ(new(big.Int).Mul(pow, bx))
+	}
+	return out
+}
+
+// VerifyShare checks share = f(index) against the dealer's commitments.
+func (g *Group) VerifyShare(commitments []*Elem, index int, share *big.Int) error {
+	if !g.isScalar(share) || g.GExp(share).Cmp(g.commitmentAt(commitments, index)) != 0 {
+		return ErrInvalidShare
+	}
+	return nil
+}
+
+func dealerContext(context string, dealer int) string {
+	return fmt.Sprintf("%s|dealer|%d", context, dealer)
+}
+
+// VerifyCommitments checks that every commitment is a group element, there
+// are exactly k of them and the dealer knows the constant term. The proof is
+// bound to the dealer so nobody can replay another trustee's commitments.
+func (g *Group) VerifyCommitments(context string, dealer, k int, commitments []*Elem, proof SchnorrProof) error {
+	if len(commitments) != k {
+		return fmt.Errorf("crypto: expected %d commitments, got %d", k, len(commitments))
+	}
+	for _, c := range commitments {
+		if c == nil || !g.IsElement(c.Int()) {
+			return ErrInvalidElement
+		}
+	}
+	return g.VerifyKnowledge(dealerContext(context, dealer), commitments[0].Int(), proof)
+}
+
+// JointPublicKey is the election public key: the product of every dealer's
+// constant-term commitment.
+func (g *Group) JointPublicKey(dealings [][]*Elem) *big.Int {
+	out := big.NewInt(1)
+	for _, d := range dealings {
+		out = g.Mul(out, d[0].Int())
+	}
+	return out
+}
+
+// VerificationKey is G^{x_j} for trustee j, derived from public commitments
+// only. Partial decryptions by j are checked against it.
+func (g *Group) VerificationKey(dealings [][]*Elem, index int) *big.Int {
+	out := big.NewInt(1)
+	for _, d := range dealings {
+		out = g.Mul(out, g.commitmentAt(d, index))
+	}
+	return out
+}
+
+// EncryptedShare is f_dealer(recipient) encrypted to the recipient's
+// transport key with hashed ElGamal, so the server relaying it learns nothing.
+type EncryptedShare struct {
+	Recipient int    `json:"recipient"`
+	Ephemeral *Elem  `json:"ephemeral"`
+	Payload   string `json:"payload"`
+}
+
+func (g *Group) shareMask(context string, dealer, recipient int, shared *big.Int) []byte {
+	size := (g.P.BitLen() + 7) / 8
+	mask := make([]byte, 0, size+sha256.Size)
+	for counter := uint32(0); len(mask) < size; counter++ {
+		h := sha256.New()
+		writeField(h, []byte(fmt.Sprintf("share|%s|%d|%d", context, dealer, recipient)))
+		writeField(h, shared.Bytes())
+		var n [4]byte
+		binary.BigEndian.PutUint32(n[:], counter)
+		h.Write(n[:])
+		mask = h.Sum(mask)
+	}
+	return mask[:size]
+}
+
+// EncryptShare encrypts share for the holder of transportKey.
+func (g *Group) EncryptShare(context string, dealer, recipient int, transportKey, share *big.Int) (EncryptedShare, error) {
+	e, err := g.RandomScalar()
+	if err != nil {
+		return EncryptedShare{}, err
+	}
+	mask := g.shareMask(context, dealer, recipient, g.Exp(transportKey, e))
+	plain := share.FillBytes(make([]byte, len(mask)))
+	for i := range plain {
+		plain[i] ^= mask[i]
+	}
+	return EncryptedShare{Recipient: recipient, Ephemeral: E(g.GExp(e)), Payload: hex.EncodeToString(plain)}, nil
+}
+
+// DecryptShare opens an EncryptedShare with the recipient's transport secret.
+func (g *Group) DecryptShare(context string, dealer int, transportSecret *big.Int, s EncryptedShare) (*big.Int, error) {
+	if s.Ephemeral == nil || !g.IsElement(s.Ephemeral.Int()) {
+		return nil, ErrInvalidElement
+	}
+	data, err := hex.DecodeString(s.Payload)
+	mask := g.shareMask(context, dealer, s.Recipient, g.Exp(s.Ephemeral.Int(), transportSecret))
+	if err != nil || len(data) != len(mask) {
+		return nil, ErrInvalidShare
+	}
+	for i := range data {
+		data[i] ^= mask[i]
+	}
+	return new(big.Int).SetBytes(data), nil
+}
+
+// Dealing is what one trustee publishes in the ceremony.
+type Dealing struct {
+	Commitments []*Elem          `json:"commitments"`
+	Proof       SchnorrProof     `json:"proof"`
+	Shares      []EncryptedShare `json:"shares"`
+}
+
+// Deal builds trustee dealer's dealing for the given transport keys, keyed
+// by trustee index. The dealer's own share is included like any other.
+func (g *Group) Deal(context string, dealer int, p Polynomial, transportKeys map[int]*big.Int) (Dealing, error) {
+	proof, err := g.ProveKnowledge(dealerContext(context, dealer), p[0])
+	if err != nil {
+		return Dealing{}, err
+	}
+	d := Dealing{Commitments: g.Commit(p), Proof: proof}
+
+	indices := make([]int, 0, len(transportKeys))
+	for j := range transportKeys {
+		indices = append(indices, j)
+	}
+	sort.Ints(indices)
+	for _, j := range indices {
+		s, err := g.EncryptShare(context, dealer, j, transportKeys[j], g.Eval(p, j))
+		if err != nil {
+			return Dealing{}, err
+		}
+		d.Shares = append(d.Shares, s)
+	}
+	return d, nil
+}
+
+// lagrange returns the coefficient of index j when interpolating at 0 over
+// indices.
+func (g *Group) lagrange(indices []int, j int) *big.Int {
+	num, den := big.NewInt(1), big.NewInt(1)
+	for _, m := range indices {
+		if m == j {
+			continue
+		}
+		num.Mul(num, big.NewInt(int64(m)))
+		num.Mod(num, g.Q)
+		den.Mul(den, big.NewInt(int64(m-j)))
+		den.Mod(den, g.Q)
+	}
+	return g.modQ(num.Mul(num, new(big.Int).ModInverse(den, g.Q)))
+}
+
+// CombineFactors interpolates verified partial decryption factors A^{x_j},
+// keyed by trustee index, into A^secret. Exactly k factors are used.
+func (g *Group) CombineFactors(k int, factors map[int]*big.Int) (*big.Int, error) {
+	if len(factors) < k {
+		return nil, ErrNotEnoughShares
+	}
+	indices := make([]int, 0, len(factors))
+	for j := range factors {
+		indices = append(indices, j)
+	}
+	sort.Ints(indices)
+	indices = indices[:k]
+
+	out := big.NewInt(1)
+	for _, j := range indices {
+		out = g.Mul(out, g.Exp(factors[j], g.lagrange(indices, j)))
+	}
+	return out, nil
+}
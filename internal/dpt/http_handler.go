@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/go-chi/chi/v5"
+	"pemira-api/internal/errcatalog"
 	"pemira-api/internal/http/response"
 )
 
@@ -34,18 +35,18 @@ func (h *Handler) Import(w http.ResponseWriter, r *http.Request) {
 
 	electionID, err := parseElectionID(r)
 	if err != nil || electionID <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "electionID tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("electionID"))
 		return
 	}
 
 	if err := r.ParseMultipartForm(10 << 20); err != nil { // 10MB
-		response.BadRequest(w, "VALIDATION_ERROR", "Gagal membaca form upload.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.UploadFormError)
 		return
 	}
 
 	file, _, err := r.FormFile("file")
 	if err != nil {
-		response.BadRequest(w, "VALIDATION_ERROR", "Field file wajib diisi.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.FileRequired)
 		return
 	}
 	defer file.Close()
@@ -56,7 +57,7 @@ func (h *Handler) Import(w http.ResponseWriter, r *http.Request) {
 	// Read header
 	header, err := reader.Read()
 	if err != nil {
-		response.BadRequest(w, "VALIDATION_ERROR", "Gagal membaca header CSV.")
+		response.Catalog(w, csvHeaderUnreadable)
 		return
 	}
 
@@ -68,7 +69,7 @@ func (h *Handler) Import(w http.ResponseWriter, r *http.Request) {
 	requiredCols := []string{"nim", "name", "faculty", "study_program", "cohort_year"}
 	for _, col := range requiredCols {
 		if _, ok := headerMap[col]; !ok {
-			response.UnprocessableEntity(w, "VALIDATION_ERROR", fmt.Sprintf("Kolom '%s' wajib ada di CSV.", col))
+			response.Catalog(w, csvColumnMissing, col)
 			return
 		}
 	}
@@ -81,14 +82,14 @@ func (h *Handler) Import(w http.ResponseWriter, r *http.Request) {
 			break
 		}
 		if err != nil {
-			response.BadRequest(w, "VALIDATION_ERROR", "CSV tidak valid.")
+			response.Catalog(w, csvInvalid)
 			return
 		}
 
 		cohortStr := record[headerMap["cohort_year"]]
 		cohortYear, err := strconv.Atoi(cohortStr)
 		if err != nil {
-			response.UnprocessableEntity(w, "VALIDATION_ERROR", "cohort_year harus angka.")
+			response.Catalog(w, cohortYearInvalid)
 			return
 		}
 
@@ -111,13 +112,13 @@ func (h *Handler) Import(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if len(rows) == 0 {
-		response.UnprocessableEntity(w, "VALIDATION_ERROR", "CSV tidak berisi data.")
+		response.Catalog(w, csvEmpty)
 		return
 	}
 
 	result, err := h.svc.Import(ctx, electionID, rows)
 	if err != nil {
-		response.InternalServerError(w, "INTERNAL_ERROR", "Gagal mengimpor DPT.")
+		response.Catalog(w, dptImportFailed)
 		return
 	}
 
@@ -147,7 +148,7 @@ func (h *Handler) ListAll(w http.ResponseWriter, r *http.Request) {
 	items, pag, err := h.svc.ListAll(ctx, filter, page, limit)
 	if err != nil {
 		slog.Error("failed to list all voters", "error", err)
-		response.InternalServerError(w, "INTERNAL_ERROR", "Gagal mengambil daftar pemilih.")
+		response.Catalog(w, dptListFailed)
 		return
 	}
 
@@ -168,7 +169,7 @@ func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
 
 	electionID, err := parseElectionID(r)
 	if err != nil || electionID <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "electionID tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("electionID"))
 		return
 	}
 
@@ -205,7 +206,7 @@ func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
 	items, pag, err := h.svc.List(ctx, electionID, filter, page, limit)
 	if err != nil {
 		slog.Error("failed to list voters", "error", err, "election_id", electionID)
-		response.InternalServerError(w, "INTERNAL_ERROR", "Gagal mengambil daftar pemilih.")
+		response.Catalog(w, dptListFailed)
 		return
 	}
 
@@ -226,7 +227,7 @@ func (h *Handler) Export(w http.ResponseWriter, r *http.Request) {
 
 	electionID, err := parseElectionID(r)
 	if err != nil || electionID <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "electionID tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("electionID"))
 		return
 	}
 
@@ -326,21 +327,21 @@ func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
 
 	electionID, err := parseElectionID(r)
 	if err != nil || electionID <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "electionID tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("electionID"))
 		return
 	}
 
 	electionVoterIDStr := chi.URLParam(r, "voterID")
 	electionVoterID, err := strconv.ParseInt(electionVoterIDStr, 10, 64)
 	if err != nil || electionVoterID <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "voterID tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("voterID"))
 		return
 	}
 
 	voter, err := h.svc.GetVoterByID(ctx, electionID, electionVoterID)
 	if err != nil {
 		slog.Error("failed to get voter", "error", err, "election_voter_id", electionVoterID)
-		response.NotFound(w, "VOTER_NOT_FOUND", "Pemilih tidak ditemukan.")
+		response.NotFound(w, "VOTER_NOT_FOUND", errcatalog.VoterNotFound)
 		return
 	}
 
@@ -354,27 +355,27 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 
 	electionID, err := parseElectionID(r)
 	if err != nil || electionID <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "electionID tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("electionID"))
 		return
 	}
 
 	electionVoterIDStr := chi.URLParam(r, "voterID")
 	electionVoterID, err := strconv.ParseInt(electionVoterIDStr, 10, 64)
 	if err != nil || electionVoterID <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "voterID tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("voterID"))
 		return
 	}
 
 	var updates VoterUpdateDTO
 	if err := json.NewDecoder(r.Body).Decode(&updates); err != nil {
-		response.BadRequest(w, "VALIDATION_ERROR", "Request body tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
 		return
 	}
 
 	if updates.VotingMethod != nil {
 		method := strings.ToUpper(strings.TrimSpace(*updates.VotingMethod))
 		if method != "ONLINE" && method != "TPS" {
-			response.UnprocessableEntity(w, "INVALID_VOTING_METHOD", "voting_method harus ONLINE atau TPS.")
+			response.UnprocessableEntity(w, "INVALID_VOTING_METHOD", errcatalog.OneOf("voting_method", "ONLINE", "TPS"))
 			return
 		}
 		updates.VotingMethod = &method
@@ -385,22 +386,22 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 
 		errMsg := err.Error()
 		if errMsg == "voter not found in this election" {
-			response.NotFound(w, "VOTER_NOT_FOUND", "Pemilih tidak ditemukan.")
+			response.NotFound(w, "VOTER_NOT_FOUND", errcatalog.VoterNotFound)
 			return
 		}
 		if errMsg == "cannot update voter who has already voted" {
-			response.Forbidden(w, "VOTER_HAS_VOTED", "Tidak dapat mengubah data pemilih yang sudah memilih.")
+			response.Catalog(w, votedVoterUpdate)
 			return
 		}
 
-		response.InternalServerError(w, "INTERNAL_ERROR", "Gagal mengupdate pemilih.")
+		response.Catalog(w, dptVoterUpdateFailed)
 		return
 	}
 
 	// Get updated voter
 	voter, err := h.svc.GetVoterByID(ctx, electionID, electionVoterID)
 	if err != nil {
-		response.InternalServerError(w, "INTERNAL_ERROR", "Gagal mengambil data pemilih.")
+		response.Catalog(w, dptVoterFetchFailed)
 		return
 	}
 
@@ -413,7 +414,7 @@ func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 
 	electionID, err := parseElectionID(r)
 	if err != nil || electionID <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "electionID tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("electionID"))
 		return
 	}
 
@@ -421,7 +422,7 @@ func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 	electionVoterIDStr := chi.URLParam(r, "voterID")
 	electionVoterID, err := strconv.ParseInt(electionVoterIDStr, 10, 64)
 	if err != nil || electionVoterID <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "voterID tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("voterID"))
 		return
 	}
 
@@ -430,15 +431,15 @@ func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 
 		errMsg := err.Error()
 		if errMsg == "voter not found in this election" {
-			response.NotFound(w, "VOTER_NOT_FOUND", "Pemilih tidak ditemukan.")
+			response.NotFound(w, "VOTER_NOT_FOUND", errcatalog.VoterNotFound)
 			return
 		}
 		if errMsg == "cannot delete voter who has already voted" {
-			response.Forbidden(w, "VOTER_HAS_VOTED", "Tidak dapat menghapus pemilih yang sudah memilih.")
+			response.Catalog(w, votedVoterDelete)
 			return
 		}
 
-		response.InternalServerError(w, "INTERNAL_ERROR", "Gagal menghapus pemilih.")
+		response.Catalog(w, dptVoterDeleteFailed)
 		return
 	}
 
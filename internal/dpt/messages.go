@@ -0,0 +1,24 @@
+package dpt
+
+import (
+	"net/http"
+
+	"pemira-api/internal/errcatalog"
+)
+
+// Messages the DPT import handlers answer with, each under its own code in the
+// error catalog
+var (
+	csvHeaderUnreadable  = errcatalog.Define("CSV_HEADER_UNREADABLE", http.StatusBadRequest, "Gagal membaca header CSV.", "Could not read the CSV header.")
+	csvInvalid           = errcatalog.Define("CSV_INVALID", http.StatusBadRequest, "CSV tidak valid.", "Invalid CSV.")
+	cohortYearInvalid    = errcatalog.Define("COHORT_YEAR_INVALID", http.StatusUnprocessableEntity, "cohort_year harus angka.", "cohort_year must be a number.")
+	csvEmpty             = errcatalog.Define("CSV_EMPTY", http.StatusUnprocessableEntity, "CSV tidak berisi data.", "The CSV has no data.")
+	dptImportFailed      = errcatalog.Define("DPT_IMPORT_FAILED", http.StatusInternalServerError, "Gagal mengimpor DPT.", "Failed to import the DPT.")
+	dptListFailed        = errcatalog.Define("DPT_LIST_FAILED", http.StatusInternalServerError, "Gagal mengambil daftar pemilih.", "Failed to fetch voters.")
+	votedVoterUpdate     = errcatalog.Define("VOTED_VOTER_UPDATE_FORBIDDEN", http.StatusForbidden, "Tidak dapat mengubah data pemilih yang sudah memilih.", "Voters who have voted cannot be changed.")
+	dptVoterUpdateFailed = errcatalog.Define("DPT_VOTER_UPDATE_FAILED", http.StatusInternalServerError, "Gagal mengupdate pemilih.", "Failed to update the voter.")
+	dptVoterFetchFailed  = errcatalog.Define("DPT_VOTER_FETCH_FAILED", http.StatusInternalServerError, "Gagal mengambil data pemilih.", "Failed to fetch the voter.")
+	votedVoterDelete     = errcatalog.Define("VOTED_VOTER_DELETE_FORBIDDEN", http.StatusForbidden, "Tidak dapat menghapus pemilih yang sudah memilih.", "Voters who have voted cannot be deleted.")
+	dptVoterDeleteFailed = errcatalog.Define("DPT_VOTER_DELETE_FAILED", http.StatusInternalServerError, "Gagal menghapus pemilih.", "Failed to delete the voter.")
+	csvColumnMissing     = errcatalog.Define("CSV_COLUMN_MISSING", http.StatusUnprocessableEntity, "Kolom '%s' wajib ada di CSV.", "The CSV must have a '%s' column.")
+)
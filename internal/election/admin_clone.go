@@ -0,0 +1,405 @@
+package election
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	ErrElectionSlugTaken     = errors.New("election slug already used")
+	ErrTemplateNotFound      = errors.New("election template not found")
+	ErrTemplateNameTaken     = errors.New("election template name already used")
+	ErrTemplateAnchorMissing = errors.New("template has a phase schedule; starts_at is required")
+	ErrInvalidCloneRequest   = errors.New("invalid clone request")
+)
+
+// ElectionCloneComponents selects what is copied from the source election.
+type ElectionCloneComponents struct {
+	Phases       bool `json:"phases"`
+	ModeSettings bool `json:"mode_settings"`
+	TPS          bool `json:"tps"`
+	Branding     bool `json:"branding"`
+	DPT          bool `json:"dpt"`
+}
+
+// DefaultCloneComponents copies everything except the voter list.
+var DefaultCloneComponents = ElectionCloneComponents{
+	Phases:       true,
+	ModeSettings: true,
+	TPS:          true,
+	Branding:     true,
+}
+
+type ElectionCloneRequest struct {
+	Year       int                      `json:"year"`
+	Name       string                   `json:"name"`
+	Slug       string                   `json:"slug"`
+	YearOffset *int                     `json:"year_offset,omitempty"`
+	Components *ElectionCloneComponents `json:"components,omitempty"`
+	SetActive  bool                     `json:"set_active"`
+}
+
+type ElectionCloneResult struct {
+	Election            *AdminElectionDTO        `json:"election"`
+	SourceElectionID    *int64                   `json:"source_election_id,omitempty"`
+	TemplateID          *int64                   `json:"template_id,omitempty"`
+	YearOffset          int                      `json:"year_offset,omitempty"`
+	Components          *ElectionCloneComponents `json:"components,omitempty"`
+	TPSCreated          int                      `json:"tps_created"`
+	BrandingLogosCopied int                      `json:"branding_logos_copied"`
+	VotersCopied        int64                    `json:"voters_copied"`
+}
+
+// ElectionTPSDefinition is the reusable part of a TPS row. QR secrets,
+// operators and check-ins are never carried over.
+type ElectionTPSDefinition struct {
+	Code             string     `json:"code"`
+	Name             string     `json:"name"`
+	Location         string     `json:"location"`
+	Status           string     `json:"status"`
+	VotingDate       *time.Time `json:"-"`
+	OpenTime         string     `json:"open_time"`
+	CloseTime        string     `json:"close_time"`
+	CapacityEstimate *int       `json:"capacity_estimate,omitempty"`
+	AreaFacultyID    *int64     `json:"area_faculty_id,omitempty"`
+	PICName          *string    `json:"pic_name,omitempty"`
+	PICPhone         *string    `json:"pic_phone,omitempty"`
+	Notes            *string    `json:"notes,omitempty"`
+}
+
+// ElectionModeConfig captures the online/TPS mode settings of an election.
+type ElectionModeConfig struct {
+	OnlineEnabled      bool    `json:"online_enabled"`
+	TPSEnabled         bool    `json:"tps_enabled"`
+	OnlineLoginURL     *string `json:"online_login_url,omitempty"`
+	OnlineMaxSessions  *int    `json:"online_max_sessions_per_voter,omitempty"`
+	TPSRequireCheckin  *bool   `json:"tps_require_checkin,omitempty"`
+	TPSRequireBallotQR *bool   `json:"tps_require_ballot_qr,omitempty"`
+	TPSMax             *int    `json:"tps_max,omitempty"`
+
+	RequireEmailVerification bool `json:"require_email_verification,omitempty"`
+}
+
+// ElectionBlueprint is everything needed to create a new election in one
+// transaction, whether it comes from a clone or a template.
+type ElectionBlueprint struct {
+	Year           int
+	Name           string
+	Slug           string
+	Mode           ElectionModeConfig
+	Phases         []ElectionPhaseInput
+	AnnouncementAt *time.Time
+	TPS            []ElectionTPSDefinition
+	BrandingFrom   *int64
+	DPTFrom        *int64
+	SetActive      bool
+	AdminID        int64
+}
+
+type ElectionBlueprintResult struct {
+	Election            *AdminElectionDTO
+	TPSCreated          int
+	BrandingLogosCopied int
+	VotersCopied        int64
+}
+
+// ElectionTemplatePhase stores a phase relative to the template anchor (the
+// earliest phase start), so templates can be applied to any year.
+type ElectionTemplatePhase struct {
+	Key                ElectionPhaseKey `json:"key"`
+	StartOffsetMinutes int64            `json:"start_offset_minutes"`
+	DurationMinutes    int64            `json:"duration_minutes"`
+}
+
+type ElectionTemplateConfig struct {
+	Phases                    []ElectionTemplatePhase `json:"phases"`
+	AnnouncementOffsetMinutes *int64                  `json:"announcement_offset_minutes,omitempty"`
+	Mode                      ElectionModeConfig      `json:"mode"`
+	TPS                       []ElectionTPSDefinition `json:"tps"`
+}
+
+type ElectionTemplate struct {
+	ID               int64                  `json:"id"`
+	Name             string                 `json:"name"`
+	Description      *string                `json:"description,omitempty"`
+	Config           ElectionTemplateConfig `json:"config"`
+	SourceElectionID *int64                 `json:"source_election_id,omitempty"`
+	CreatedBy        *int64                 `json:"created_by,omitempty"`
+	CreatedAt        time.Time              `json:"created_at"`
+	UpdatedAt        time.Time              `json:"updated_at"`
+}
+
+type ElectionTemplateCreateRequest struct {
+	Name             string  `json:"name"`
+	Description      *string `json:"description,omitempty"`
+	SourceElectionID int64   `json:"source_election_id"`
+}
+
+type ElectionTemplateApplyRequest struct {
+	Year      int        `json:"year"`
+	Name      string     `json:"name"`
+	Slug      string     `json:"slug"`
+	StartsAt  *time.Time `json:"starts_at,omitempty"`
+	SetActive bool       `json:"set_active"`
+}
+
+// CloneElection creates a new DRAFT election from an existing one, copying the
+// selected components. Phase dates are shifted by the year offset.
+func (s *AdminService) CloneElection(ctx context.Context, sourceID, adminID int64, req ElectionCloneRequest) (*ElectionCloneResult, error) {
+	if req.Year <= 0 || strings.TrimSpace(req.Name) == "" || strings.TrimSpace(req.Slug) == "" {
+		return nil, ErrInvalidCloneRequest
+	}
+
+	src, err := s.repo.GetElectionByID(ctx, sourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	components := DefaultCloneComponents
+	if req.Components != nil {
+		components = *req.Components
+	}
+	offset := req.Year - src.Year
+	if req.YearOffset != nil {
+		offset = *req.YearOffset
+	}
+
+	bp := ElectionBlueprint{
+		Year:      req.Year,
+		Name:      strings.TrimSpace(req.Name),
+		Slug:      strings.TrimSpace(req.Slug),
+		Mode:      ElectionModeConfig{OnlineEnabled: true, TPSEnabled: true},
+		SetActive: req.SetActive,
+		AdminID:   adminID,
+	}
+	if components.ModeSettings {
+		bp.Mode = modeConfigFromElection(src)
+	}
+	if components.Phases {
+		bp.Phases = shiftPhases(phaseDTOsFromElection(src), offset)
+		bp.AnnouncementAt = shiftTime(src.AnnouncementAt, offset)
+	}
+	if components.TPS {
+		defs, err := s.repo.ListTPSDefinitions(ctx, sourceID)
+		if err != nil {
+			return nil, err
+		}
+		for i := range defs {
+			defs[i].VotingDate = shiftTime(defs[i].VotingDate, offset)
+		}
+		bp.TPS = defs
+	}
+	if components.Branding {
+		bp.BrandingFrom = &sourceID
+	}
+	if components.DPT {
+		bp.DPTFrom = &sourceID
+	}
+
+	res, err := s.repo.CreateElectionFromBlueprint(ctx, bp)
+	if err != nil {
+		return nil, err
+	}
+	s.enrichElection(res.Election)
+
+	return &ElectionCloneResult{
+		Election:            res.Election,
+		SourceElectionID:    &sourceID,
+		YearOffset:          offset,
+		Components:          &components,
+		TPSCreated:          res.TPSCreated,
+		BrandingLogosCopied: res.BrandingLogosCopied,
+		VotersCopied:        res.VotersCopied,
+	}, nil
+}
+
+func (s *AdminService) ListTemplates(ctx context.Context) ([]ElectionTemplate, error) {
+	return s.repo.ListTemplates(ctx)
+}
+
+func (s *AdminService) GetTemplate(ctx context.Context, id int64) (*ElectionTemplate, error) {
+	return s.repo.GetTemplate(ctx, id)
+}
+
+func (s *AdminService) DeleteTemplate(ctx context.Context, id int64) error {
+	return s.repo.DeleteTemplate(ctx, id)
+}
+
+// SaveTemplate captures the schedule, mode settings and TPS list of an election as a template.
+func (s *AdminService) SaveTemplate(ctx context.Context, adminID int64, req ElectionTemplateCreateRequest) (*ElectionTemplate, error) {
+	if strings.TrimSpace(req.Name) == "" || req.SourceElectionID <= 0 {
+		return nil, ErrInvalidCloneRequest
+	}
+
+	src, err := s.repo.GetElectionByID(ctx, req.SourceElectionID)
+	if err != nil {
+		return nil, err
+	}
+	defs, err := s.repo.ListTPSDefinitions(ctx, src.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	phases, anchor := templatePhases(phaseDTOsFromElection(src))
+	cfg := ElectionTemplateConfig{
+		Phases: phases,
+		Mode:   modeConfigFromElection(src),
+		TPS:    defs,
+	}
+	if anchor != nil && src.AnnouncementAt != nil {
+		off := int64(src.AnnouncementAt.Sub(*anchor) / time.Minute)
+		cfg.AnnouncementOffsetMinutes = &off
+	}
+
+	t := &ElectionTemplate{
+		Name:             strings.TrimSpace(req.Name),
+		Description:      req.Description,
+		Config:           cfg,
+		SourceElectionID: &src.ID,
+		CreatedBy:        &adminID,
+	}
+	if err := s.repo.CreateTemplate(ctx, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// ApplyTemplate creates a new DRAFT election from a template, anchoring the
+// phase schedule at StartsAt.
+func (s *AdminService) ApplyTemplate(ctx context.Context, templateID, adminID int64, req ElectionTemplateApplyRequest) (*ElectionCloneResult, error) {
+	if req.Year <= 0 || strings.TrimSpace(req.Name) == "" || strings.TrimSpace(req.Slug) == "" {
+		return nil, ErrInvalidCloneRequest
+	}
+
+	t, err := s.repo.GetTemplate(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+	if len(t.Config.Phases) > 0 && req.StartsAt == nil {
+		return nil, ErrTemplateAnchorMissing
+	}
+
+	bp := ElectionBlueprint{
+		Year:      req.Year,
+		Name:      strings.TrimSpace(req.Name),
+		Slug:      strings.TrimSpace(req.Slug),
+		Mode:      t.Config.Mode,
+		TPS:       t.Config.TPS,
+		SetActive: req.SetActive,
+		AdminID:   adminID,
+	}
+	if req.StartsAt != nil {
+		anchor := req.StartsAt.UTC()
+		bp.Phases = phasesFromTemplate(t.Config.Phases, anchor)
+		if t.Config.AnnouncementOffsetMinutes != nil {
+			at := anchor.Add(time.Duration(*t.Config.AnnouncementOffsetMinutes) * time.Minute)
+			bp.AnnouncementAt = &at
+		}
+	}
+	votingDate := votingDateFromPhases(bp.Phases)
+	for i := range bp.TPS {
+		bp.TPS[i].VotingDate = votingDate
+	}
+
+	res, err := s.repo.CreateElectionFromBlueprint(ctx, bp)
+	if err != nil {
+		return nil, err
+	}
+	s.enrichElection(res.Election)
+
+	return &ElectionCloneResult{
+		Election:            res.Election,
+		TemplateID:          &t.ID,
+		TPSCreated:          res.TPSCreated,
+		BrandingLogosCopied: res.BrandingLogosCopied,
+		VotersCopied:        res.VotersCopied,
+	}, nil
+}
+
+func modeConfigFromElection(e *AdminElectionDTO) ElectionModeConfig {
+	return ElectionModeConfig{
+		OnlineEnabled:      e.OnlineEnabled,
+		TPSEnabled:         e.TPSEnabled,
+		OnlineLoginURL:     e.OnlineLoginURL,
+		OnlineMaxSessions:  e.OnlineMaxSessions,
+		TPSRequireCheckin:  e.TPSRequireCheckin,
+		TPSRequireBallotQR: e.TPSRequireBallotQR,
+		TPSMax:             e.TPSMax,
+
+		RequireEmailVerification: e.RequireEmailVerification,
+	}
+}
+
+func shiftTime(t *time.Time, years int) *time.Time {
+	if t == nil {
+		return nil
+	}
+	shifted := t.AddDate(years, 0, 0)
+	return &shifted
+}
+
+// shiftPhases moves every scheduled phase by the given number of years; unscheduled phases are dropped.
+func shiftPhases(phases []ElectionPhaseDTO, years int) []ElectionPhaseInput {
+	out := make([]ElectionPhaseInput, 0, len(phases))
+	for _, ph := range phases {
+		if ph.StartAt == nil && ph.EndAt == nil {
+			continue
+		}
+		out = append(out, ElectionPhaseInput{
+			Key:     ph.Key,
+			StartAt: shiftTime(ph.StartAt, years),
+			EndAt:   shiftTime(ph.EndAt, years),
+		})
+	}
+	return out
+}
+
+// templatePhases converts absolute phases into offsets from the earliest start.
+func templatePhases(phases []ElectionPhaseDTO) ([]ElectionTemplatePhase, *time.Time) {
+	var anchor *time.Time
+	for _, ph := range phases {
+		if ph.StartAt != nil && ph.EndAt != nil && (anchor == nil || ph.StartAt.Before(*anchor)) {
+			anchor = ph.StartAt
+		}
+	}
+	if anchor == nil {
+		return []ElectionTemplatePhase{}, nil
+	}
+
+	out := []ElectionTemplatePhase{}
+	for _, ph := range phases {
+		if ph.StartAt == nil || ph.EndAt == nil {
+			continue
+		}
+		out = append(out, ElectionTemplatePhase{
+			Key:                ph.Key,
+			StartOffsetMinutes: int64(ph.StartAt.Sub(*anchor) / time.Minute),
+			DurationMinutes:    int64(ph.EndAt.Sub(*ph.StartAt) / time.Minute),
+		})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].StartOffsetMinutes < out[j].StartOffsetMinutes })
+	return out, anchor
+}
+
+func phasesFromTemplate(phases []ElectionTemplatePhase, anchor time.Time) []ElectionPhaseInput {
+	out := make([]ElectionPhaseInput, 0, len(phases))
+	for _, ph := range phases {
+		start := anchor.Add(time.Duration(ph.StartOffsetMinutes) * time.Minute)
+		end := start.Add(time.Duration(ph.DurationMinutes) * time.Minute)
+		out = append(out, ElectionPhaseInput{Key: ph.Key, StartAt: &start, EndAt: &end})
+	}
+	return out
+}
+
+func votingDateFromPhases(phases []ElectionPhaseInput) *time.Time {
+	for _, ph := range phases {
+		if ph.Key == PhaseKeyVoting && ph.StartAt != nil {
+			d := *ph.StartAt
+			return &d
+		}
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package election
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"pemira-api/internal/errcatalog"
+	"pemira-api/internal/http/response"
+	"pemira-api/internal/shared/ctxkeys"
+)
+
+// POST /admin/elections/{electionID}/clone
+func (h *AdminHandler) Clone(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := parseIDParam(r, "electionID")
+	if err != nil || id <= 0 {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("electionID"))
+		return
+	}
+
+	adminID, ok := ctxkeys.GetUserID(ctx)
+	if !ok {
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.InvalidUser)
+		return
+	}
+
+	var req ElectionCloneRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
+		return
+	}
+
+	result, err := h.svc.CloneElection(ctx, id, adminID, req)
+	if err != nil {
+		writeError(w, err, electionCloneFailed)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, successPayload(result))
+}
+
+// GET /admin/election-templates
+func (h *AdminHandler) ListTemplates(w http.ResponseWriter, r *http.Request) {
+	items, err := h.svc.ListTemplates(r.Context())
+	if err != nil {
+		response.Catalog(w, templateFetchFailed)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, successPayload(map[string]interface{}{"items": items}))
+}
+
+// POST /admin/election-templates
+func (h *AdminHandler) CreateTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	adminID, ok := ctxkeys.GetUserID(ctx)
+	if !ok {
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.InvalidUser)
+		return
+	}
+
+	var req ElectionTemplateCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
+		return
+	}
+
+	tmpl, err := h.svc.SaveTemplate(ctx, adminID, req)
+	if errors.Is(err, ErrInvalidCloneRequest) {
+		response.UnprocessableEntity(w, "VALIDATION_ERROR", errcatalog.Required("name", "source_election_id"))
+		return
+	}
+	if err != nil {
+		writeError(w, err, templateSaveFailed)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, successPayload(tmpl))
+}
+
+// GET /admin/election-templates/{templateID}
+func (h *AdminHandler) GetTemplate(w http.ResponseWriter, r *http.Request) {
+	id, err := parseIDParam(r, "templateID")
+	if err != nil || id <= 0 {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("templateID"))
+		return
+	}
+
+	tmpl, err := h.svc.GetTemplate(r.Context(), id)
+	if err != nil {
+		writeError(w, err, templateFetchFailed)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, successPayload(tmpl))
+}
+
+// DELETE /admin/election-templates/{templateID}
+func (h *AdminHandler) DeleteTemplate(w http.ResponseWriter, r *http.Request) {
+	id, err := parseIDParam(r, "templateID")
+	if err != nil || id <= 0 {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("templateID"))
+		return
+	}
+
+	if err := h.svc.DeleteTemplate(r.Context(), id); err != nil {
+		writeError(w, err, templateDeleteFailed)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// POST /admin/election-templates/{templateID}/apply
+func (h *AdminHandler) ApplyTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := parseIDParam(r, "templateID")
+	if err != nil || id <= 0 {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("templateID"))
+		return
+	}
+
+	adminID, ok := ctxkeys.GetUserID(ctx)
+	if !ok {
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.InvalidUser)
+		return
+	}
+
+	var req ElectionTemplateApplyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
+		return
+	}
+
+	result, err := h.svc.ApplyTemplate(ctx, id, adminID, req)
+	if err != nil {
+		writeError(w, err, templateApplyFailed)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, successPayload(result))
+}
+
+// writeError writes the catalog entry of err (see errors.go), or fallback
+// when err is not a domain error
+func writeError(w http.ResponseWriter, err error, fallback errcatalog.Entry) {
+	if response.DomainError(w, err) {
+		return
+	}
+	slog.Error("election handler error", "err", err)
+	response.Catalog(w, fallback)
+}
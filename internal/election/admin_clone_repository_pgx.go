@@ -0,0 +1,429 @@
+package election
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func (r *PgAdminRepository) ListTPSDefinitions(ctx context.Context, electionID int64) ([]ElectionTPSDefinition, error) {
+	const q = `
+SELECT
+    code,
+    name,
+    location,
+    status::text,
+    voting_date,
+    to_char(open_time, 'HH24:MI'),
+    to_char(close_time, 'HH24:MI'),
+    capacity_estimate,
+    area_faculty_id,
+    pic_name,
+    pic_phone,
+    notes
+FROM tps
+WHERE election_id = $1
+ORDER BY code
+`
+
+	rows, err := r.db.Query(ctx, q, electionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []ElectionTPSDefinition{}
+	for rows.Next() {
+		var def ElectionTPSDefinition
+		if err := rows.Scan(
+			&def.Code,
+			&def.Name,
+			&def.Location,
+			&def.Status,
+			&def.VotingDate,
+			&def.OpenTime,
+			&def.CloseTime,
+			&def.CapacityEstimate,
+			&def.AreaFacultyID,
+			&def.PICName,
+			&def.PICPhone,
+			&def.Notes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, def)
+	}
+	return items, rows.Err()
+}
+
+// CreateElectionFromBlueprint creates the election and all copied components
+// in a single transaction so a failed clone leaves nothing behind.
+func (r *PgAdminRepository) CreateElectionFromBlueprint(ctx context.Context, bp ElectionBlueprint) (*ElectionBlueprintResult, error) {
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var electionID int64
+	err = tx.QueryRow(ctx, `
+INSERT INTO elections (
+    year,
+    name,
+    code,
+    slug,
+    status,
+    online_enabled,
+    tps_enabled,
+    online_login_url,
+    online_max_sessions_per_voter,
+    tps_require_checkin,
+    tps_require_ballot_qr,
+    tps_max,
+    announcement_at,
+    require_email_verification
+) VALUES ($1, $2, $3, $3, 'DRAFT', $4, $5, $6, $7, $8, $9, $10, $11, $12)
+RETURNING id
+`,
+		bp.Year,
+		bp.Name,
+		bp.Slug,
+		bp.Mode.OnlineEnabled,
+		bp.Mode.TPSEnabled,
+		bp.Mode.OnlineLoginURL,
+		bp.Mode.OnlineMaxSessions,
+		bp.Mode.TPSRequireCheckin,
+		bp.Mode.TPSRequireBallotQR,
+		bp.Mode.TPSMax,
+		bp.AnnouncementAt,
+		bp.Mode.RequireEmailVerification,
+	).Scan(&electionID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, ErrElectionSlugTaken
+		}
+		return nil, err
+	}
+
+	if err := applyPhasesTx(ctx, tx, electionID, bp.Phases); err != nil {
+		return nil, err
+	}
+
+	res := &ElectionBlueprintResult{}
+
+	for _, def := range bp.TPS {
+		if err := insertTPSDefinitionTx(ctx, tx, electionID, def); err != nil {
+			return nil, err
+		}
+		res.TPSCreated++
+	}
+
+	if bp.BrandingFrom != nil {
+		n, err := copyBrandingTx(ctx, tx, *bp.BrandingFrom, electionID, bp.AdminID)
+		if err != nil {
+			return nil, err
+		}
+		res.BrandingLogosCopied = n
+	}
+
+	if bp.DPTFrom != nil {
+		n, err := copyVotersTx(ctx, tx, *bp.DPTFrom, electionID)
+		if err != nil {
+			return nil, err
+		}
+		res.VotersCopied = n
+	}
+
+	if bp.SetActive {
+		_, err := tx.Exec(ctx, `
+INSERT INTO app_settings (key, value, updated_at, updated_by)
+VALUES ('active_election_id', $1, NOW(), $2)
+ON CONFLICT (key) DO UPDATE
+SET value = EXCLUDED.value, updated_at = NOW(), updated_by = EXCLUDED.updated_by
+`, strconv.FormatInt(electionID, 10), bp.AdminID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	dto, err := scanAdminElection(tx.QueryRow(ctx, fmt.Sprintf(`SELECT %s FROM elections WHERE id = $1`, adminElectionColumns), electionID))
+	if err != nil {
+		return nil, err
+	}
+	res.Election = dto
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func applyPhasesTx(ctx context.Context, tx pgx.Tx, electionID int64, phases []ElectionPhaseInput) error {
+	updates := []string{}
+	args := []any{}
+	pos := 1
+
+	for _, ph := range phases {
+		cols, ok := phaseColumnMap[ph.Key]
+		if !ok {
+			continue
+		}
+		updates = append(updates, fmt.Sprintf("%s = $%d", cols.startCol, pos), fmt.Sprintf("%s = $%d", cols.endCol, pos+1))
+		args = append(args, ph.StartAt, ph.EndAt)
+		pos += 2
+	}
+	if len(updates) == 0 {
+		return nil
+	}
+
+	args = append(args, electionID)
+	_, err := tx.Exec(ctx, fmt.Sprintf(`UPDATE elections SET %s WHERE id = $%d`, strings.Join(updates, ", "), pos), args...)
+	return err
+}
+
+// insertTPSDefinitionTx creates a TPS with a fresh QR token. Closed TPS from
+// the previous election are reopened as ACTIVE; drafts stay drafts.
+func insertTPSDefinitionTx(ctx context.Context, tx pgx.Tx, electionID int64, def ElectionTPSDefinition) error {
+	status := def.Status
+	if status != "DRAFT" {
+		status = "ACTIVE"
+	}
+
+	var tpsID int64
+	err := tx.QueryRow(ctx, `
+INSERT INTO tps (
+    election_id, code, name, location, status, voting_date, open_time, close_time,
+    capacity_estimate, area_faculty_id, pic_name, pic_phone, notes
+) VALUES ($1, $2, $3, $4, $5::tps_status, COALESCE($6::date, CURRENT_DATE), $7::time, $8::time, $9, $10, $11, $12, $13)
+RETURNING id
+`,
+		electionID,
+		def.Code,
+		def.Name,
+		def.Location,
+		status,
+		def.VotingDate,
+		def.OpenTime,
+		def.CloseTime,
+		def.CapacityEstimate,
+		def.AreaFacultyID,
+		def.PICName,
+		def.PICPhone,
+		def.Notes,
+	).Scan(&tpsID)
+	if err != nil {
+		return err
+	}
+
+	token, err := generateTPSQRToken(tpsID)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+INSERT INTO tps_qr (tps_id, qr_token, is_active)
+VALUES ($1, $2, TRUE)
+`, tpsID, token)
+	return err
+}
+
+// generateTPSQRToken mirrors the token format used by the tps package.
+func generateTPSQRToken(tpsID int64) (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("tps_qr_%d_%s", tpsID, base64.URLEncoding.EncodeToString(b)[:32]), nil
+}
+
+func copyBrandingTx(ctx context.Context, tx pgx.Tx, fromElectionID, toElectionID, adminID int64) (int, error) {
+	var primaryID, secondaryID *string
+	err := tx.QueryRow(ctx, `
+SELECT primary_logo_id::text, secondary_logo_id::text
+FROM branding_settings
+WHERE election_id = $1
+`, fromElectionID).Scan(&primaryID, &secondaryID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	if err := ensureBrandingSettingsTx(ctx, tx, toElectionID); err != nil {
+		return 0, err
+	}
+
+	copied := 0
+	for _, src := range []struct {
+		id  *string
+		col string
+	}{
+		{primaryID, "primary_logo_id"},
+		{secondaryID, "secondary_logo_id"},
+	} {
+		if src.id == nil {
+			continue
+		}
+		newID, err := newBrandingFileID()
+		if err != nil {
+			return 0, err
+		}
+		tag, err := tx.Exec(ctx, `
+INSERT INTO branding_files (id, election_id, slot, content_type, size_bytes, storage_path, created_by_admin_id)
+SELECT $1, $2, slot, content_type, size_bytes, storage_path, $3
+FROM branding_files
+WHERE id = $4
+`, newID, toElectionID, adminID, *src.id)
+		if err != nil {
+			return 0, err
+		}
+		if tag.RowsAffected() == 0 {
+			continue
+		}
+		_, err = tx.Exec(ctx, fmt.Sprintf(`
+UPDATE branding_settings
+SET %s = $1, updated_by_admin_id = $2, updated_at = NOW()
+WHERE election_id = $3
+`, src.col), newID, adminID, toElectionID)
+		if err != nil {
+			return 0, err
+		}
+		copied++
+	}
+	return copied, nil
+}
+
+// copyVotersTx copies the DPT without any voting history: voters who voted are
+// reset to VERIFIED and TPS assignments are remapped by TPS code.
+func copyVotersTx(ctx context.Context, tx pgx.Tx, fromElectionID, toElectionID int64) (int64, error) {
+	tag, err := tx.Exec(ctx, `
+INSERT INTO election_voters (election_id, voter_id, nim, status, voting_method, tps_id)
+SELECT
+    $2,
+    ev.voter_id,
+    ev.nim,
+    CASE WHEN ev.status = 'VOTED' THEN 'VERIFIED'::election_voter_status ELSE ev.status END,
+    ev.voting_method,
+    nt.id
+FROM election_voters ev
+LEFT JOIN tps ot ON ot.id = ev.tps_id
+LEFT JOIN tps nt ON nt.election_id = $2 AND nt.code = ot.code
+WHERE ev.election_id = $1
+ON CONFLICT DO NOTHING
+`, fromElectionID, toElectionID)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = tx.Exec(ctx, `
+INSERT INTO voter_status (election_id, voter_id, is_eligible, has_voted)
+SELECT $2, vs.voter_id, vs.is_eligible, FALSE
+FROM voter_status vs
+WHERE vs.election_id = $1
+ON CONFLICT (election_id, voter_id) DO NOTHING
+`, fromElectionID, toElectionID)
+	if err != nil {
+		return 0, err
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+const electionTemplateColumns = `
+    id,
+    name,
+    description,
+    config,
+    source_election_id,
+    created_by,
+    created_at,
+    updated_at
+`
+
+func scanElectionTemplate(row rowScanner) (*ElectionTemplate, error) {
+	var t ElectionTemplate
+	var raw []byte
+	err := row.Scan(
+		&t.ID,
+		&t.Name,
+		&t.Description,
+		&raw,
+		&t.SourceElectionID,
+		&t.CreatedBy,
+		&t.CreatedAt,
+		&t.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrTemplateNotFound
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, &t.Config); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *PgAdminRepository) ListTemplates(ctx context.Context) ([]ElectionTemplate, error) {
+	rows, err := r.db.Query(ctx, fmt.Sprintf(`SELECT %s FROM election_templates ORDER BY name`, electionTemplateColumns))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []ElectionTemplate{}
+	for rows.Next() {
+		t, err := scanElectionTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, *t)
+	}
+	return items, rows.Err()
+}
+
+func (r *PgAdminRepository) GetTemplate(ctx context.Context, id int64) (*ElectionTemplate, error) {
+	return scanElectionTemplate(r.db.QueryRow(ctx, fmt.Sprintf(`SELECT %s FROM election_templates WHERE id = $1`, electionTemplateColumns), id))
+}
+
+func (r *PgAdminRepository) CreateTemplate(ctx context.Context, t *ElectionTemplate) error {
+	cfg, err := json.Marshal(t.Config)
+	if err != nil {
+		return err
+	}
+
+	err = r.db.QueryRow(ctx, `
+INSERT INTO election_templates (name, description, config, source_election_id, created_by)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, created_at, updated_at
+`, t.Name, t.Description, cfg, t.SourceElectionID, t.CreatedBy).Scan(&t.ID, &t.CreatedAt, &t.UpdatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return ErrTemplateNameTaken
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *PgAdminRepository) DeleteTemplate(ctx context.Context, id int64) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM election_templates WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrTemplateNotFound
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package election
+
+import (
+	"testing"
+)
+
+func TestShiftPhases_ShiftsByYearAndDropsUnscheduled(t *testing.T) {
+	phases := []ElectionPhaseDTO{
+		{Key: PhaseKeyRegistration, StartAt: mustParsePhaseTime(t, "2025-02-10T08:00:00+07:00"), EndAt: mustParsePhaseTime(t, "2025-02-12T17:00:00+07:00")},
+		{Key: PhaseKeyCampaign},
+		{Key: PhaseKeyVoting, StartAt: mustParsePhaseTime(t, "2025-02-15T08:00:00+07:00"), EndAt: mustParsePhaseTime(t, "2025-02-15T17:00:00+07:00")},
+	}
+
+	out := shiftPhases(phases, 1)
+	if len(out) != 2 {
+		t.Fatalf("expected 2 scheduled phases, got %d", len(out))
+	}
+	if !out[0].StartAt.Equal(*mustParsePhaseTime(t, "2026-02-10T08:00:00+07:00")) {
+		t.Fatalf("unexpected shifted start %v", out[0].StartAt)
+	}
+	if out[1].Key != PhaseKeyVoting || !out[1].EndAt.Equal(*mustParsePhaseTime(t, "2026-02-15T17:00:00+07:00")) {
+		t.Fatalf("unexpected voting phase %+v", out[1])
+	}
+}
+
+func TestTemplatePhases_RoundTrip(t *testing.T) {
+	phases := []ElectionPhaseDTO{
+		{Key: PhaseKeyVoting, StartAt: mustParsePhaseTime(t, "2025-02-15T08:00:00+07:00"), EndAt: mustParsePhaseTime(t, "2025-02-15T17:00:00+07:00")},
+		{Key: PhaseKeyRegistration, StartAt: mustParsePhaseTime(t, "2025-02-10T08:00:00+07:00"), EndAt: mustParsePhaseTime(t, "2025-02-12T17:00:00+07:00")},
+	}
+
+	tmpl, anchor := templatePhases(phases)
+	if anchor == nil || !anchor.Equal(*phases[1].StartAt) {
+		t.Fatalf("anchor should be the earliest phase start, got %v", anchor)
+	}
+	if tmpl[0].Key != PhaseKeyRegistration || tmpl[0].StartOffsetMinutes != 0 {
+		t.Fatalf("unexpected first template phase %+v", tmpl[0])
+	}
+
+	start := *mustParsePhaseTime(t, "2026-03-02T08:00:00+07:00")
+	applied := phasesFromTemplate(tmpl, start)
+	if !applied[1].StartAt.Equal(*mustParsePhaseTime(t, "2026-03-07T08:00:00+07:00")) ||
+		!applied[1].EndAt.Equal(*mustParsePhaseTime(t, "2026-03-07T17:00:00+07:00")) {
+		t.Fatalf("unexpected applied voting phase %+v", applied[1])
+	}
+	if vd := votingDateFromPhases(applied); vd == nil || !vd.Equal(*applied[1].StartAt) {
+		t.Fatalf("unexpected voting date %v", vd)
+	}
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/gabriel-vasile/mimetype"
 	"github.com/go-chi/chi/v5"
+	"pemira-api/internal/errcatalog"
 	"pemira-api/internal/http/response"
 	"pemira-api/internal/shared/ctxkeys"
 )
@@ -74,6 +75,8 @@ type generalInfoResponse struct {
 	Phases        []ElectionPhaseDTO `json:"phases,omitempty"`
 	CreatedAt     time.Time          `json:"created_at"`
 	UpdatedAt     time.Time          `json:"updated_at"`
+
+	RequireEmailVerification bool `json:"require_email_verification"`
 }
 
 func successPayload(data interface{}) map[string]interface{} {
@@ -113,6 +116,8 @@ func buildGeneralInfoResponse(dto *AdminElectionDTO) generalInfoResponse {
 		Phases:    phaseDTOsFromElection(dto),
 		CreatedAt: dto.CreatedAt,
 		UpdatedAt: dto.UpdatedAt,
+
+		RequireEmailVerification: dto.RequireEmailVerification,
 	}
 }
 
@@ -143,7 +148,7 @@ func (h *AdminHandler) List(w http.ResponseWriter, r *http.Request) {
 	items, pag, err := h.svc.List(ctx, filter, page, limit)
 	if err != nil {
 		fmt.Printf("ERROR List elections: %v\n", err)
-		response.InternalServerError(w, "INTERNAL_ERROR", "Gagal mengambil daftar pemilu.")
+		response.Catalog(w, electionListFailed)
 		return
 	}
 
@@ -163,18 +168,18 @@ func (h *AdminHandler) Create(w http.ResponseWriter, r *http.Request) {
 
 	var req AdminElectionCreateRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.BadRequest(w, "VALIDATION_ERROR", "Body tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
 		return
 	}
 
 	if req.Year <= 0 || req.Name == "" || req.Slug == "" {
-		response.UnprocessableEntity(w, "VALIDATION_ERROR", "year, name, dan slug wajib diisi.")
+		response.UnprocessableEntity(w, "VALIDATION_ERROR", errcatalog.Required("year", "name", "slug"))
 		return
 	}
 
 	dto, err := h.svc.Create(ctx, req)
 	if err != nil {
-		response.InternalServerError(w, "INTERNAL_ERROR", "Gagal membuat pemilu.")
+		response.Catalog(w, electionCreateFailed)
 		return
 	}
 
@@ -186,17 +191,13 @@ func (h *AdminHandler) Get(w http.ResponseWriter, r *http.Request) {
 
 	id, err := parseIDParam(r, "electionID")
 	if err != nil || id <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "electionID tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("electionID"))
 		return
 	}
 
 	dto, err := h.svc.Get(ctx, id)
 	if err != nil {
-		if errors.Is(err, ErrElectionNotFound) {
-			response.NotFound(w, "ELECTION_NOT_FOUND", "Pemilu tidak ditemukan.")
-			return
-		}
-		response.InternalServerError(w, "INTERNAL_ERROR", "Gagal mengambil detail pemilu.")
+		writeError(w, err, electionFetchFailed)
 		return
 	}
 
@@ -208,23 +209,19 @@ func (h *AdminHandler) Update(w http.ResponseWriter, r *http.Request) {
 
 	id, err := parseIDParam(r, "electionID")
 	if err != nil || id <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "electionID tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("electionID"))
 		return
 	}
 
 	var req AdminElectionUpdateRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.BadRequest(w, "VALIDATION_ERROR", "Body tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
 		return
 	}
 
 	dto, err := h.svc.Update(ctx, id, req)
 	if err != nil {
-		if errors.Is(err, ErrElectionNotFound) {
-			response.NotFound(w, "ELECTION_NOT_FOUND", "Pemilu tidak ditemukan.")
-			return
-		}
-		response.InternalServerError(w, "INTERNAL_ERROR", "Gagal mengubah pemilu.")
+		writeError(w, err, electionUpdateFailed)
 		return
 	}
 
@@ -236,34 +233,25 @@ func (h *AdminHandler) PatchGeneralInfo(w http.ResponseWriter, r *http.Request)
 
 	id, err := parseIDParam(r, "electionID")
 	if err != nil || id <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "electionID tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("electionID"))
 		return
 	}
 
 	var req AdminElectionGeneralUpdateRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.BadRequest(w, "VALIDATION_ERROR", "Body tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
 		return
 	}
 
 	if req.Year == nil && req.Code == nil && req.Slug == nil && req.Name == nil && req.Description == nil && req.AcademicYear == nil {
-		response.UnprocessableEntity(w, "INVALID_INPUT", "Minimal satu field diisi.")
+		response.Catalog(w, electionPatchEmpty)
 		return
 	}
 
 	dto, err := h.svc.PatchGeneralInfo(ctx, id, req)
 	if err != nil {
-		switch {
-		case errors.Is(err, ErrElectionNotFound):
-			response.NotFound(w, "ELECTION_NOT_FOUND", "Pemilu tidak ditemukan.")
-			return
-		case errors.Is(err, ErrElectionArchived):
-			response.BadRequest(w, "ELECTION_ARCHIVED", "Pemilu sudah diarsipkan.")
-			return
-		default:
-			response.InternalServerError(w, "INTERNAL_ERROR", "Gagal mengubah informasi pemilu.")
-			return
-		}
+		writeError(w, err, electionDetailsUpdateFailed)
+		return
 	}
 
 	resp := map[string]interface{}{
@@ -285,29 +273,14 @@ func (h *AdminHandler) OpenVoting(w http.ResponseWriter, r *http.Request) {
 
 	id, err := parseIDParam(r, "electionID")
 	if err != nil || id <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "electionID tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("electionID"))
 		return
 	}
 
 	dto, err := h.svc.OpenVoting(ctx, id)
 	if err != nil {
-		switch {
-		case errors.Is(err, ErrElectionNotFound):
-			response.NotFound(w, "ELECTION_NOT_FOUND", "Pemilu tidak ditemukan.")
-			return
-		case errors.Is(err, ErrElectionAlreadyOpened):
-			response.BadRequest(w, "ELECTION_ALREADY_OPENED", "Pemilu sudah dalam status voting terbuka.")
-			return
-		case errors.Is(err, ErrInvalidStatusChange):
-			response.BadRequest(w, "INVALID_STATUS_CHANGE", "Status pemilu tidak dapat dibuka untuk voting.")
-			return
-		case errors.Is(err, ErrElectionNotInVotingPhase):
-			response.BadRequest(w, "ELECTION_NOT_IN_VOTING_PHASE", "Pemilu belum memasuki jadwal voting.")
-			return
-		default:
-			response.InternalServerError(w, "INTERNAL_ERROR", "Gagal membuka voting.")
-			return
-		}
+		writeError(w, err, votingOpenFailed)
+		return
 	}
 
 	currentPhase := ""
@@ -332,26 +305,14 @@ func (h *AdminHandler) CloseVoting(w http.ResponseWriter, r *http.Request) {
 
 	id, err := parseIDParam(r, "electionID")
 	if err != nil || id <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "electionID tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("electionID"))
 		return
 	}
 
 	dto, err := h.svc.CloseVoting(ctx, id)
 	if err != nil {
-		switch {
-		case errors.Is(err, ErrElectionNotFound):
-			response.NotFound(w, "ELECTION_NOT_FOUND", "Pemilu tidak ditemukan.")
-			return
-		case errors.Is(err, ErrElectionNotInOpenState):
-			response.BadRequest(w, "ELECTION_NOT_OPEN", "Pemilu tidak dalam status voting terbuka.")
-			return
-		case errors.Is(err, ErrElectionAlreadyClosed):
-			response.BadRequest(w, "ELECTION_ALREADY_CLOSED", "Pemilu sudah ditutup.")
-			return
-		default:
-			response.InternalServerError(w, "INTERNAL_ERROR", "Gagal menutup voting.")
-			return
-		}
+		writeError(w, err, votingCloseFailed)
+		return
 	}
 
 	currentPhase := ""
@@ -372,26 +333,18 @@ func (h *AdminHandler) Archive(w http.ResponseWriter, r *http.Request) {
 
 	id, err := parseIDParam(r, "electionID")
 	if err != nil || id <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "electionID tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("electionID"))
 		return
 	}
 
 	dto, err := h.svc.Archive(ctx, id)
+	if errors.Is(err, ErrElectionArchived) {
+		response.Catalog(w, electionAlreadyArchived)
+		return
+	}
 	if err != nil {
-		switch {
-		case errors.Is(err, ErrElectionNotFound):
-			response.NotFound(w, "ELECTION_NOT_FOUND", "Pemilu tidak ditemukan.")
-			return
-		case errors.Is(err, ErrElectionArchived):
-			response.BadRequest(w, "ELECTION_ALREADY_ARCHIVED", "Pemilu sudah diarsipkan.")
-			return
-		case errors.Is(err, ErrElectionNotClosable):
-			response.BadRequest(w, "ELECTION_NOT_CLOSABLE", "Pemilu belum bisa diarsipkan.")
-			return
-		default:
-			response.InternalServerError(w, "INTERNAL_ERROR", "Gagal mengarsipkan pemilu.")
-			return
-		}
+		writeError(w, err, electionArchiveFailed)
+		return
 	}
 
 	resp := map[string]interface{}{
@@ -407,17 +360,13 @@ func (h *AdminHandler) GetPhases(w http.ResponseWriter, r *http.Request) {
 
 	id, err := parseIDParam(r, "electionID")
 	if err != nil || id <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "electionID tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("electionID"))
 		return
 	}
 
 	resp, err := h.svc.GetPhases(ctx, id)
 	if err != nil {
-		if errors.Is(err, ErrElectionNotFound) {
-			response.NotFound(w, "ELECTION_NOT_FOUND", "Pemilu tidak ditemukan.")
-			return
-		}
-		response.InternalServerError(w, "INTERNAL_ERROR", "Gagal mengambil jadwal tahapan.")
+		writeError(w, err, phasesFetchFailed)
 		return
 	}
 
@@ -429,35 +378,20 @@ func (h *AdminHandler) UpdatePhases(w http.ResponseWriter, r *http.Request) {
 
 	id, err := parseIDParam(r, "electionID")
 	if err != nil || id <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "electionID tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("electionID"))
 		return
 	}
 
 	var req UpdateElectionPhasesRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.BadRequest(w, "VALIDATION_ERROR", "Body tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
 		return
 	}
 
 	respData, err := h.svc.UpdatePhases(ctx, id, req)
 	if err != nil {
-		switch {
-		case errors.Is(err, ErrElectionNotFound):
-			response.NotFound(w, "ELECTION_NOT_FOUND", "Pemilu tidak ditemukan.")
-			return
-		case errors.Is(err, ErrInvalidPhaseKey):
-			response.UnprocessableEntity(w, "INVALID_PHASE_KEY", "Key tahapan tidak valid atau tidak lengkap.")
-			return
-		case errors.Is(err, ErrPhaseTimeConflict):
-			response.BadRequest(w, "PHASE_TIME_CONFLICT", "Rentang waktu tahapan bertabrakan.")
-			return
-		case errors.Is(err, ErrVotingPhaseLocked):
-			response.BadRequest(w, "VOTING_PHASE_LOCKED", "Jadwal voting tidak dapat diubah saat voting sudah dibuka.")
-			return
-		default:
-			response.InternalServerError(w, "INTERNAL_ERROR", "Gagal memperbarui jadwal tahapan.")
-			return
-		}
+		writeError(w, err, phasesUpdateFailed)
+		return
 	}
 
 	response.JSON(w, http.StatusOK, successPayload(respData))
@@ -468,17 +402,13 @@ func (h *AdminHandler) GetModeSettings(w http.ResponseWriter, r *http.Request) {
 
 	id, err := parseIDParam(r, "electionID")
 	if err != nil || id <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "electionID tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("electionID"))
 		return
 	}
 
 	dto, err := h.svc.GetModeSettings(ctx, id)
 	if err != nil {
-		if errors.Is(err, ErrElectionNotFound) {
-			response.NotFound(w, "ELECTION_NOT_FOUND", "Pemilu tidak ditemukan.")
-			return
-		}
-		response.InternalServerError(w, "INTERNAL_ERROR", "Gagal mengambil pengaturan mode.")
+		writeError(w, err, modeSettingsFetchFailed)
 		return
 	}
 
@@ -490,32 +420,20 @@ func (h *AdminHandler) UpdateModeSettings(w http.ResponseWriter, r *http.Request
 
 	id, err := parseIDParam(r, "electionID")
 	if err != nil || id <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "electionID tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("electionID"))
 		return
 	}
 
 	var req ModeSettingsRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.BadRequest(w, "VALIDATION_ERROR", "Body tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
 		return
 	}
 
 	dto, err := h.svc.UpdateModeSettings(ctx, id, req)
 	if err != nil {
-		switch {
-		case errors.Is(err, ErrElectionNotFound):
-			response.NotFound(w, "ELECTION_NOT_FOUND", "Pemilu tidak ditemukan.")
-			return
-		case errors.Is(err, ErrInvalidModeCombination):
-			response.BadRequest(w, "INVALID_MODE_COMBINATION", "online_enabled dan tps_enabled tidak boleh keduanya false.")
-			return
-		case errors.Is(err, ErrElectionAlreadyStarted):
-			response.BadRequest(w, "ELECTION_ALREADY_STARTED", "Mode tidak bisa diubah karena pemilu sudah berjalan.")
-			return
-		default:
-			response.InternalServerError(w, "INTERNAL_ERROR", "Gagal memperbarui pengaturan mode.")
-			return
-		}
+		writeError(w, err, modeSettingsUpdateFailed)
+		return
 	}
 
 	response.JSON(w, http.StatusOK, successPayload(dto))
@@ -526,17 +444,13 @@ func (h *AdminHandler) GetSummary(w http.ResponseWriter, r *http.Request) {
 
 	id, err := parseIDParam(r, "electionID")
 	if err != nil || id <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "electionID tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("electionID"))
 		return
 	}
 
 	dto, err := h.svc.GetSummary(ctx, id)
 	if err != nil {
-		if errors.Is(err, ErrElectionNotFound) {
-			response.NotFound(w, "ELECTION_NOT_FOUND", "Pemilu tidak ditemukan.")
-			return
-		}
-		response.InternalServerError(w, "INTERNAL_ERROR", "Gagal mengambil ringkasan pemilu.")
+		writeError(w, err, electionSummaryFailed)
 		return
 	}
 
@@ -548,17 +462,13 @@ func (h *AdminHandler) GetBranding(w http.ResponseWriter, r *http.Request) {
 
 	electionID, err := parseIDParam(r, "electionID")
 	if err != nil || electionID <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "electionID tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("electionID"))
 		return
 	}
 
 	branding, err := h.svc.GetBranding(ctx, electionID)
 	if err != nil {
-		if errors.Is(err, ErrElectionNotFound) {
-			response.NotFound(w, "ELECTION_NOT_FOUND", "Pemilu tidak ditemukan.")
-			return
-		}
-		response.InternalServerError(w, "INTERNAL_ERROR", "Gagal mengambil branding.")
+		writeError(w, err, brandingFetchFailed)
 		return
 	}
 
@@ -570,29 +480,20 @@ func (h *AdminHandler) GetBrandingLogo(w http.ResponseWriter, r *http.Request) {
 
 	electionID, err := parseIDParam(r, "electionID")
 	if err != nil || electionID <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "electionID tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("electionID"))
 		return
 	}
 
 	slot, err := parseBrandingSlotParam(r)
 	if err != nil {
-		response.BadRequest(w, "VALIDATION_ERROR", "slot harus primary atau secondary.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.OneOf("slot", "primary", "secondary"))
 		return
 	}
 
 	file, err := h.svc.GetBrandingLogo(ctx, electionID, slot)
 	if err != nil {
-		switch {
-		case errors.Is(err, ErrElectionNotFound):
-			response.NotFound(w, "ELECTION_NOT_FOUND", "Pemilu tidak ditemukan.")
-			return
-		case errors.Is(err, ErrBrandingFileNotFound):
-			response.NotFound(w, "BRANDING_LOGO_NOT_FOUND", "Logo belum diunggah.")
-			return
-		default:
-			response.InternalServerError(w, "INTERNAL_ERROR", "Gagal mengambil logo.")
-			return
-		}
+		writeError(w, err, logoFetchFailed)
+		return
 	}
 
 	if file.URL != nil {
@@ -611,30 +512,30 @@ func (h *AdminHandler) UploadBrandingLogo(w http.ResponseWriter, r *http.Request
 
 	electionID, err := parseIDParam(r, "electionID")
 	if err != nil || electionID <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "electionID tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("electionID"))
 		return
 	}
 
 	slot, err := parseBrandingSlotParam(r)
 	if err != nil {
-		response.BadRequest(w, "VALIDATION_ERROR", "slot harus primary atau secondary.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.OneOf("slot", "primary", "secondary"))
 		return
 	}
 
 	adminID, ok := ctxkeys.GetUserID(ctx)
 	if !ok {
-		response.Unauthorized(w, "UNAUTHORIZED", "User tidak valid.")
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.InvalidUser)
 		return
 	}
 
 	if err := r.ParseMultipartForm(maxBrandingLogoSize + (512 << 10)); err != nil {
-		response.BadRequest(w, "VALIDATION_ERROR", "Gagal membaca form upload.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.UploadFormError)
 		return
 	}
 
 	filePart, _, err := r.FormFile("file")
 	if err != nil {
-		response.BadRequest(w, "VALIDATION_ERROR", "Field file wajib diisi.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.FileRequired)
 		return
 	}
 	defer filePart.Close()
@@ -642,24 +543,24 @@ func (h *AdminHandler) UploadBrandingLogo(w http.ResponseWriter, r *http.Request
 	limitedReader := io.LimitReader(filePart, maxBrandingLogoSize+1)
 	data, err := io.ReadAll(limitedReader)
 	if err != nil {
-		response.BadRequest(w, "VALIDATION_ERROR", "Gagal membaca file upload.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.UploadFileError)
 		return
 	}
 
 	if int64(len(data)) > maxBrandingLogoSize {
-		response.UnprocessableEntity(w, "FILE_TOO_LARGE", "Ukuran logo maksimal 2MB.")
+		response.Catalog(w, logoTooLarge)
 		return
 	}
 
 	mime := mimetype.Detect(data)
 	if mime == nil || !(mime.Is("image/png") || mime.Is("image/jpeg")) {
-		response.UnprocessableEntity(w, "INVALID_FILE_TYPE", "Logo harus berupa PNG atau JPEG.")
+		response.Catalog(w, logoTypeInvalid)
 		return
 	}
 
 	fileID, err := newBrandingFileID()
 	if err != nil {
-		response.InternalServerError(w, "INTERNAL_ERROR", "Gagal menyiapkan penyimpanan logo.")
+		response.Catalog(w, logoStorageUnavailable)
 		return
 	}
 
@@ -673,17 +574,8 @@ func (h *AdminHandler) UploadBrandingLogo(w http.ResponseWriter, r *http.Request
 
 	saved, err := h.svc.UploadBrandingLogo(ctx, electionID, slot, file)
 	if err != nil {
-		switch {
-		case errors.Is(err, ErrElectionNotFound):
-			response.NotFound(w, "ELECTION_NOT_FOUND", "Pemilu tidak ditemukan.")
-			return
-		case errors.Is(err, ErrInvalidBrandingSlot):
-			response.BadRequest(w, "VALIDATION_ERROR", "slot tidak valid.")
-			return
-		default:
-			response.InternalServerError(w, "INTERNAL_ERROR", "Gagal menyimpan logo.")
-			return
-		}
+		writeError(w, err, logoSaveFailed)
+		return
 	}
 
 	resp := map[string]interface{}{
@@ -700,35 +592,26 @@ func (h *AdminHandler) DeleteBrandingLogo(w http.ResponseWriter, r *http.Request
 
 	electionID, err := parseIDParam(r, "electionID")
 	if err != nil || electionID <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "electionID tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("electionID"))
 		return
 	}
 
 	slot, err := parseBrandingSlotParam(r)
 	if err != nil {
-		response.BadRequest(w, "VALIDATION_ERROR", "slot harus primary atau secondary.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.OneOf("slot", "primary", "secondary"))
 		return
 	}
 
 	adminID, ok := ctxkeys.GetUserID(ctx)
 	if !ok {
-		response.Unauthorized(w, "UNAUTHORIZED", "User tidak valid.")
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.InvalidUser)
 		return
 	}
 
 	branding, err := h.svc.DeleteBrandingLogo(ctx, electionID, slot, adminID)
 	if err != nil {
-		switch {
-		case errors.Is(err, ErrElectionNotFound):
-			response.NotFound(w, "ELECTION_NOT_FOUND", "Pemilu tidak ditemukan.")
-			return
-		case errors.Is(err, ErrInvalidBrandingSlot):
-			response.BadRequest(w, "VALIDATION_ERROR", "slot tidak valid.")
-			return
-		default:
-			response.InternalServerError(w, "INTERNAL_ERROR", "Gagal menghapus logo.")
-			return
-		}
+		writeError(w, err, logoDeleteFailed)
+		return
 	}
 
 	response.JSON(w, http.StatusOK, branding)
@@ -740,39 +623,35 @@ func (h *AdminHandler) GetAllSettings(w http.ResponseWriter, r *http.Request) {
 
 	electionID, err := parseIDParam(r, "electionID")
 	if err != nil || electionID <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "electionID tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("electionID"))
 		return
 	}
 
 	// Get general info
 	election, err := h.svc.Get(ctx, electionID)
 	if err != nil {
-		if errors.Is(err, ErrElectionNotFound) {
-			response.NotFound(w, "ELECTION_NOT_FOUND", "Pemilu tidak ditemukan.")
-			return
-		}
-		response.InternalServerError(w, "INTERNAL_ERROR", "Gagal mengambil detail pemilu.")
+		writeError(w, err, electionFetchFailed)
 		return
 	}
 
 	// Get phases
 	phases, err := h.svc.GetPhases(ctx, electionID)
 	if err != nil {
-		response.InternalServerError(w, "INTERNAL_ERROR", "Gagal mengambil jadwal tahapan.")
+		response.Catalog(w, phasesFetchFailed)
 		return
 	}
 
 	// Get mode settings
 	modeSettings, err := h.svc.GetModeSettings(ctx, electionID)
 	if err != nil {
-		response.InternalServerError(w, "INTERNAL_ERROR", "Gagal mengambil pengaturan mode.")
+		response.Catalog(w, modeSettingsFetchFailed)
 		return
 	}
 
 	// Get branding
 	branding, err := h.svc.GetBranding(ctx, electionID)
 	if err != nil {
-		response.InternalServerError(w, "INTERNAL_ERROR", "Gagal mengambil branding.")
+		response.Catalog(w, brandingFetchFailed)
 		return
 	}
 
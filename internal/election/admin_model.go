@@ -35,6 +35,9 @@ type AdminElectionDTO struct {
 	TPSMax              *int           `json:"tps_max,omitempty"`
 	CreatedAt           time.Time      `json:"created_at"`
 	UpdatedAt           time.Time      `json:"updated_at"`
+
+	// RequireEmailVerification limits voting to voters with a verified email.
+	RequireEmailVerification bool `json:"require_email_verification"`
 }
 
 type AdminElectionListFilter struct {
@@ -73,6 +76,8 @@ type AdminElectionUpdateRequest struct {
 	RecapEndAt          *time.Time `json:"recap_end_at,omitempty"`
 	AnnouncementAt      *time.Time `json:"announcement_at,omitempty"`
 	FinishedAt          *time.Time `json:"finished_at,omitempty"`
+
+	RequireEmailVerification *bool `json:"require_email_verification,omitempty"`
 }
 
 type AdminElectionGeneralUpdateRequest struct {
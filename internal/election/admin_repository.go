@@ -21,4 +21,10 @@ type AdminRepository interface {
 	GetBrandingFile(ctx context.Context, electionID int64, slot BrandingSlot) (*BrandingFile, error)
 	SaveBrandingFile(ctx context.Context, electionID int64, slot BrandingSlot, file BrandingFileCreate) (*BrandingFile, error)
 	DeleteBrandingFile(ctx context.Context, electionID int64, slot BrandingSlot, adminID int64) (*BrandingSettings, error)
+	ListTPSDefinitions(ctx context.Context, electionID int64) ([]ElectionTPSDefinition, error)
+	CreateElectionFromBlueprint(ctx context.Context, bp ElectionBlueprint) (*ElectionBlueprintResult, error)
+	ListTemplates(ctx context.Context) ([]ElectionTemplate, error)
+	GetTemplate(ctx context.Context, id int64) (*ElectionTemplate, error)
+	CreateTemplate(ctx context.Context, t *ElectionTemplate) error
+	DeleteTemplate(ctx context.Context, id int64) error
 }
@@ -55,7 +55,8 @@ const adminElectionColumns = `
     tps_require_ballot_qr,
     tps_max,
     created_at,
-    updated_at
+    updated_at,
+    require_email_verification
 `
 
 var phaseColumnMap = map[ElectionPhaseKey]struct {
@@ -130,6 +131,7 @@ func scanAdminElection(row rowScanner) (*AdminElectionDTO, error) {
 		&dto.TPSMax,
 		&dto.CreatedAt,
 		&dto.UpdatedAt,
+		&dto.RequireEmailVerification,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -276,6 +278,12 @@ func (r *PgAdminRepository) UpdateElection(ctx context.Context, id int64, req Ad
 		argPos++
 	}
 
+	if req.RequireEmailVerification != nil {
+		updates = append(updates, fmt.Sprintf("require_email_verification = $%d", argPos))
+		args = append(args, *req.RequireEmailVerification)
+		argPos++
+	}
+
 	if req.RegistrationStartAt != nil {
 		updates = append(updates, fmt.Sprintf("registration_start_at = $%d", argPos))
 		args = append(args, *req.RegistrationStartAt)
@@ -0,0 +1,35 @@
+package election
+
+import (
+	"net/http"
+
+	"pemira-api/internal/errcatalog"
+)
+
+// The API codes and messages of the election errors; writeError writes them
+func init() {
+	errcatalog.Register(
+		errcatalog.Entry{Err: ErrElectionNotFound, Code: "ELECTION_NOT_FOUND", Status: http.StatusNotFound, ID: "Pemilu tidak ditemukan.", EN: "Election not found."},
+		errcatalog.Entry{Err: ErrVoterMappingMissing, Code: "VOTER_MAPPING_MISSING", Status: http.StatusForbidden, ID: "Akun ini belum terhubung dengan data pemilih.", EN: "This account is not linked to a voter record."},
+		errcatalog.Entry{Err: ErrElectionArchived, Code: "ELECTION_ARCHIVED", Status: http.StatusBadRequest, ID: "Pemilu sudah diarsipkan.", EN: "The election is archived."},
+		errcatalog.Entry{Err: ErrElectionAlreadyOpened, Code: "ELECTION_ALREADY_OPENED", Status: http.StatusBadRequest, ID: "Pemilu sudah dalam status voting terbuka.", EN: "Voting is already open for this election."},
+		errcatalog.Entry{Err: ErrInvalidStatusChange, Code: "INVALID_STATUS_CHANGE", Status: http.StatusBadRequest, ID: "Status pemilu tidak dapat dibuka untuk voting.", EN: "Voting cannot be opened from the election's status."},
+		errcatalog.Entry{Err: ErrElectionNotInVotingPhase, Code: "ELECTION_NOT_IN_VOTING_PHASE", Status: http.StatusBadRequest, ID: "Pemilu belum memasuki jadwal voting.", EN: "The election has not reached its voting schedule."},
+		errcatalog.Entry{Err: ErrElectionNotInOpenState, Code: "ELECTION_NOT_OPEN", Status: http.StatusBadRequest, ID: "Pemilu tidak dalam status voting terbuka.", EN: "Voting is not open for this election."},
+		errcatalog.Entry{Err: ErrElectionAlreadyClosed, Code: "ELECTION_ALREADY_CLOSED", Status: http.StatusBadRequest, ID: "Pemilu sudah ditutup.", EN: "The election is already closed."},
+		errcatalog.Entry{Err: ErrElectionNotClosable, Code: "ELECTION_NOT_CLOSABLE", Status: http.StatusBadRequest, ID: "Pemilu belum bisa diarsipkan.", EN: "The election cannot be archived yet."},
+		errcatalog.Entry{Err: ErrInvalidPhaseKey, Code: "INVALID_PHASE_KEY", Status: http.StatusUnprocessableEntity, ID: "Key tahapan tidak valid atau tidak lengkap.", EN: "Phase keys are invalid or incomplete."},
+		errcatalog.Entry{Err: ErrPhaseTimeConflict, Code: "PHASE_TIME_CONFLICT", Status: http.StatusBadRequest, ID: "Rentang waktu tahapan bertabrakan.", EN: "Phase time ranges overlap."},
+		errcatalog.Entry{Err: ErrVotingPhaseLocked, Code: "VOTING_PHASE_LOCKED", Status: http.StatusBadRequest, ID: "Jadwal voting tidak dapat diubah saat voting sudah dibuka.", EN: "The voting schedule cannot change once voting is open."},
+		errcatalog.Entry{Err: ErrInvalidModeCombination, Code: "INVALID_MODE_COMBINATION", Status: http.StatusBadRequest, ID: "online_enabled dan tps_enabled tidak boleh keduanya false.", EN: "online_enabled and tps_enabled cannot both be false."},
+		errcatalog.Entry{Err: ErrElectionAlreadyStarted, Code: "ELECTION_ALREADY_STARTED", Status: http.StatusBadRequest, ID: "Mode tidak bisa diubah karena pemilu sudah berjalan.", EN: "Modes cannot change once the election has started."},
+		errcatalog.Entry{Err: ErrBrandingFileNotFound, Code: "BRANDING_LOGO_NOT_FOUND", Status: http.StatusNotFound, ID: "Logo belum diunggah.", EN: "No logo has been uploaded."},
+		errcatalog.Entry{Err: ErrInvalidBrandingSlot, Code: "VALIDATION_ERROR", Status: http.StatusBadRequest, ID: "slot tidak valid.", EN: "slot is invalid."},
+
+		errcatalog.Entry{Err: ErrInvalidCloneRequest, Code: "VALIDATION_ERROR", Status: http.StatusUnprocessableEntity, ID: "year, name, dan slug wajib diisi.", EN: "year, name, and slug are required."},
+		errcatalog.Entry{Err: ErrTemplateNotFound, Code: "TEMPLATE_NOT_FOUND", Status: http.StatusNotFound, ID: "Template pemilu tidak ditemukan.", EN: "Election template not found."},
+		errcatalog.Entry{Err: ErrTemplateNameTaken, Code: "TEMPLATE_NAME_TAKEN", Status: http.StatusConflict, ID: "Nama template sudah digunakan.", EN: "The template name is already used."},
+		errcatalog.Entry{Err: ErrTemplateAnchorMissing, Code: "VALIDATION_ERROR", Status: http.StatusUnprocessableEntity, ID: "starts_at wajib diisi untuk template yang memiliki jadwal tahapan.", EN: "starts_at is required for templates with a phase schedule."},
+		errcatalog.Entry{Err: ErrElectionSlugTaken, Code: "SLUG_TAKEN", Status: http.StatusConflict, ID: "Slug pemilu sudah digunakan.", EN: "The election slug is already used."},
+	)
+}
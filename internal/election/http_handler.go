@@ -8,6 +8,7 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"pemira-api/internal/auth"
+	"pemira-api/internal/errcatalog"
 	"pemira-api/internal/http/response"
 )
 
@@ -50,10 +51,10 @@ func (h *Handler) GetCurrent(w http.ResponseWriter, r *http.Request) {
 	dto, err := h.svc.GetCurrentElection(ctx)
 	if err != nil {
 		if errors.Is(err, ErrElectionNotFound) {
-			response.NotFound(w, "ELECTION_NOT_FOUND", "Tidak ada pemilu yang sedang berlangsung.")
+			response.Catalog(w, noCurrentElection)
 			return
 		}
-		response.InternalServerError(w, "INTERNAL_ERROR", "Terjadi kesalahan pada sistem.")
+		response.InternalServerError(w, "INTERNAL_ERROR", errcatalog.InternalError)
 		return
 	}
 
@@ -67,10 +68,10 @@ func (h *Handler) GetCurrentForRegistration(w http.ResponseWriter, r *http.Reque
 	dto, err := h.svc.GetCurrentForRegistration(ctx)
 	if err != nil {
 		if errors.Is(err, ErrElectionNotFound) {
-			response.NotFound(w, "ELECTION_NOT_FOUND", "Tidak ada pemilu yang menerima pendaftaran.")
+			response.Catalog(w, noRegistrationElection)
 			return
 		}
-		response.InternalServerError(w, "INTERNAL_ERROR", "Terjadi kesalahan pada sistem.")
+		response.InternalServerError(w, "INTERNAL_ERROR", errcatalog.InternalError)
 		return
 	}
 
@@ -83,7 +84,7 @@ func (h *Handler) ListPublic(w http.ResponseWriter, r *http.Request) {
 
 	dtos, err := h.svc.ListPublicElections(ctx)
 	if err != nil {
-		response.InternalServerError(w, "INTERNAL_ERROR", "Terjadi kesalahan pada sistem.")
+		response.InternalServerError(w, "INTERNAL_ERROR", errcatalog.InternalError)
 		return
 	}
 
@@ -96,29 +97,20 @@ func (h *Handler) GetMeStatus(w http.ResponseWriter, r *http.Request) {
 
 	electionID, err := parseInt64Param(r, "electionID")
 	if err != nil || electionID <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "electionID tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("electionID"))
 		return
 	}
 
 	authUser, ok := auth.FromContext(ctx)
 	if !ok {
-		response.Unauthorized(w, "UNAUTHORIZED", "Token tidak valid.")
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.InvalidToken)
 		return
 	}
 
 	dto, err := h.svc.GetMeStatus(ctx, authUser, electionID)
 	if err != nil {
-		switch {
-		case errors.Is(err, ErrVoterMappingMissing):
-			response.Forbidden(w, "VOTER_MAPPING_MISSING", "Akun ini belum terhubung dengan data pemilih.")
-			return
-		case errors.Is(err, ErrElectionNotFound):
-			response.NotFound(w, "ELECTION_NOT_FOUND", "Pemilu tidak ditemukan.")
-			return
-		default:
-			response.InternalServerError(w, "INTERNAL_ERROR", "Terjadi kesalahan pada sistem.")
-			return
-		}
+		writeError(w, err, errcatalog.Internal)
+		return
 	}
 
 	response.JSON(w, http.StatusOK, dto)
@@ -130,23 +122,19 @@ func (h *Handler) GetMeHistory(w http.ResponseWriter, r *http.Request) {
 
 	electionID, err := parseInt64Param(r, "electionID")
 	if err != nil || electionID <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "electionID tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("electionID"))
 		return
 	}
 
 	authUser, ok := auth.FromContext(ctx)
 	if !ok {
-		response.Unauthorized(w, "UNAUTHORIZED", "Token tidak valid.")
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.InvalidToken)
 		return
 	}
 
 	dto, err := h.svc.GetMeHistory(ctx, authUser, electionID)
 	if err != nil {
-		if errors.Is(err, ErrElectionNotFound) {
-			response.NotFound(w, "ELECTION_NOT_FOUND", "Pemilu tidak ditemukan.")
-			return
-		}
-		response.InternalServerError(w, "INTERNAL_ERROR", "Terjadi kesalahan pada sistem.")
+		writeError(w, err, errcatalog.Internal)
 		return
 	}
 
@@ -159,17 +147,13 @@ func (h *Handler) GetPublicPhases(w http.ResponseWriter, r *http.Request) {
 
 	electionID, err := parseInt64Param(r, "electionID")
 	if err != nil || electionID <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "electionID tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("electionID"))
 		return
 	}
 
 	phases, err := h.svc.GetPublicPhases(ctx, electionID)
 	if err != nil {
-		if errors.Is(err, ErrElectionNotFound) {
-			response.NotFound(w, "ELECTION_NOT_FOUND", "Pemilu tidak ditemukan.")
-			return
-		}
-		response.InternalServerError(w, "INTERNAL_ERROR", "Terjadi kesalahan pada sistem.")
+		writeError(w, err, errcatalog.Internal)
 		return
 	}
 
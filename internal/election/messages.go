@@ -0,0 +1,41 @@
+package election
+
+import (
+	"net/http"
+
+	"pemira-api/internal/errcatalog"
+)
+
+// Messages the election admin handlers answer with, each under its own code in the
+// error catalog
+var (
+	electionCloneFailed         = errcatalog.Define("ELECTION_CLONE_FAILED", http.StatusInternalServerError, "Gagal menduplikasi pemilu.", "Failed to duplicate the election.")
+	templateFetchFailed         = errcatalog.Define("TEMPLATE_FETCH_FAILED", http.StatusInternalServerError, "Gagal mengambil template pemilu.", "Failed to fetch the election template.")
+	templateSaveFailed          = errcatalog.Define("TEMPLATE_SAVE_FAILED", http.StatusInternalServerError, "Gagal menyimpan template pemilu.", "Failed to save the election template.")
+	templateDeleteFailed        = errcatalog.Define("TEMPLATE_DELETE_FAILED", http.StatusInternalServerError, "Gagal menghapus template pemilu.", "Failed to delete the election template.")
+	templateApplyFailed         = errcatalog.Define("TEMPLATE_APPLY_FAILED", http.StatusInternalServerError, "Gagal membuat pemilu dari template.", "Failed to create an election from the template.")
+	electionListFailed          = errcatalog.Define("ELECTION_LIST_FAILED", http.StatusInternalServerError, "Gagal mengambil daftar pemilu.", "Failed to fetch elections.")
+	electionCreateFailed        = errcatalog.Define("ELECTION_CREATE_FAILED", http.StatusInternalServerError, "Gagal membuat pemilu.", "Failed to create the election.")
+	electionFetchFailed         = errcatalog.Define("ELECTION_FETCH_FAILED", http.StatusInternalServerError, "Gagal mengambil detail pemilu.", "Failed to fetch the election.")
+	electionUpdateFailed        = errcatalog.Define("ELECTION_UPDATE_FAILED", http.StatusInternalServerError, "Gagal mengubah pemilu.", "Failed to update the election.")
+	electionPatchEmpty          = errcatalog.Define("ELECTION_PATCH_EMPTY", http.StatusUnprocessableEntity, "Minimal satu field diisi.", "Fill in at least one field.")
+	electionDetailsUpdateFailed = errcatalog.Define("ELECTION_DETAILS_UPDATE_FAILED", http.StatusInternalServerError, "Gagal mengubah informasi pemilu.", "Failed to update the election details.")
+	votingOpenFailed            = errcatalog.Define("VOTING_OPEN_FAILED", http.StatusInternalServerError, "Gagal membuka voting.", "Failed to open voting.")
+	votingCloseFailed           = errcatalog.Define("VOTING_CLOSE_FAILED", http.StatusInternalServerError, "Gagal menutup voting.", "Failed to close voting.")
+	electionAlreadyArchived     = errcatalog.Define("ELECTION_ALREADY_ARCHIVED", http.StatusBadRequest, "Pemilu sudah diarsipkan.", "The election is already archived.")
+	electionArchiveFailed       = errcatalog.Define("ELECTION_ARCHIVE_FAILED", http.StatusInternalServerError, "Gagal mengarsipkan pemilu.", "Failed to archive the election.")
+	phasesFetchFailed           = errcatalog.Define("PHASES_FETCH_FAILED", http.StatusInternalServerError, "Gagal mengambil jadwal tahapan.", "Failed to fetch the phase schedule.")
+	phasesUpdateFailed          = errcatalog.Define("PHASES_UPDATE_FAILED", http.StatusInternalServerError, "Gagal memperbarui jadwal tahapan.", "Failed to update the phase schedule.")
+	modeSettingsFetchFailed     = errcatalog.Define("MODE_SETTINGS_FETCH_FAILED", http.StatusInternalServerError, "Gagal mengambil pengaturan mode.", "Failed to fetch the mode settings.")
+	modeSettingsUpdateFailed    = errcatalog.Define("MODE_SETTINGS_UPDATE_FAILED", http.StatusInternalServerError, "Gagal memperbarui pengaturan mode.", "Failed to update the mode settings.")
+	electionSummaryFailed       = errcatalog.Define("ELECTION_SUMMARY_FAILED", http.StatusInternalServerError, "Gagal mengambil ringkasan pemilu.", "Failed to fetch the election summary.")
+	brandingFetchFailed         = errcatalog.Define("BRANDING_FETCH_FAILED", http.StatusInternalServerError, "Gagal mengambil branding.", "Failed to fetch the branding.")
+	logoFetchFailed             = errcatalog.Define("LOGO_FETCH_FAILED", http.StatusInternalServerError, "Gagal mengambil logo.", "Failed to fetch the logo.")
+	logoTooLarge                = errcatalog.Define("LOGO_TOO_LARGE", http.StatusUnprocessableEntity, "Ukuran logo maksimal 2MB.", "Logos can be at most 2MB.")
+	logoTypeInvalid             = errcatalog.Define("LOGO_TYPE_INVALID", http.StatusUnprocessableEntity, "Logo harus berupa PNG atau JPEG.", "The logo must be PNG or JPEG.")
+	logoStorageUnavailable      = errcatalog.Define("LOGO_STORAGE_UNAVAILABLE", http.StatusInternalServerError, "Gagal menyiapkan penyimpanan logo.", "Failed to prepare logo storage.")
+	logoSaveFailed              = errcatalog.Define("LOGO_SAVE_FAILED", http.StatusInternalServerError, "Gagal menyimpan logo.", "Failed to save the logo.")
+	logoDeleteFailed            = errcatalog.Define("LOGO_DELETE_FAILED", http.StatusInternalServerError, "Gagal menghapus logo.", "Failed to delete the logo.")
+	noCurrentElection           = errcatalog.Define("NO_CURRENT_ELECTION", http.StatusNotFound, "Tidak ada pemilu yang sedang berlangsung.", "No election is in progress.")
+	noRegistrationElection      = errcatalog.Define("NO_REGISTRATION_ELECTION", http.StatusNotFound, "Tidak ada pemilu yang menerima pendaftaran.", "No election is accepting registrations.")
+)
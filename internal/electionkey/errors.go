@@ -0,0 +1,31 @@
+package electionkey
+
+import (
+	"net/http"
+
+	"pemira-api/internal/errcatalog"
+)
+
+// The API codes and messages of the key ceremony and tally errors;
+// handleError writes them
+func init() {
+	errcatalog.Register(
+		errcatalog.Entry{Err: ErrElectionNotFound, Code: "ELECTION_NOT_FOUND", Status: http.StatusNotFound, ID: "Pemilu tidak ditemukan.", EN: "Election not found."},
+		errcatalog.Entry{Err: ErrCeremonyNotFound, Code: "KEY_CEREMONY_NOT_FOUND", Status: http.StatusNotFound, ID: "Seremoni kunci pemilu belum dibuat.", EN: "The key ceremony has not been set up."},
+		errcatalog.Entry{Err: ErrUserNotFound, Code: "USER_NOT_FOUND", Status: http.StatusNotFound, ID: "User trustee tidak ditemukan.", EN: "Trustee user not found."},
+		errcatalog.Entry{Err: ErrBallotNotFound, Code: "BALLOT_NOT_FOUND", Status: http.StatusNotFound, ID: "Surat suara terenkripsi tidak ditemukan.", EN: "Encrypted ballot not found."},
+		errcatalog.Entry{Err: ErrTallyNotFound, Code: "TALLY_NOT_FOUND", Status: http.StatusNotFound, ID: "Rekapitulasi terenkripsi belum tersedia.", EN: "The encrypted tally is not available yet."},
+		errcatalog.Entry{Err: ErrNotTrustee, Code: "NOT_TRUSTEE", Status: http.StatusForbidden, ID: "Anda bukan trustee pemilu ini.", EN: "You are not a trustee of this election."},
+		errcatalog.Entry{Err: ErrInvalidCeremony, Code: "VALIDATION_ERROR", Status: http.StatusBadRequest, ID: "Threshold harus 1..jumlah trustee dan setiap trustee harus user berbeda dengan nama.", EN: "The threshold must be 1..trustee count and every trustee must be a distinct, named user."},
+		errcatalog.Entry{Err: ErrInvalidSubmission, Code: "INVALID_PROOF", Status: http.StatusUnprocessableEntity, ID: "Data trustee tidak lolos verifikasi.", EN: "The trustee data does not verify."},
+		errcatalog.Entry{Err: ErrCeremonyExists, Code: "KEY_CEREMONY_EXISTS", Status: http.StatusConflict, ID: "Kunci pemilu sudah siap. Hapus dulu untuk mengulang seremoni.", EN: "The election key is already ready. Delete it first to rerun the ceremony."},
+		errcatalog.Entry{Err: ErrCeremonyLocked, Code: "KEY_CEREMONY_LOCKED", Status: http.StatusConflict, ID: "Kunci pemilu tidak dapat diubah setelah voting dimulai.", EN: "The election key cannot change after voting has started."},
+		errcatalog.Entry{Err: ErrCeremonyWrongStatus, Code: "KEY_CEREMONY_WRONG_STEP", Status: http.StatusConflict, ID: "Seremoni kunci tidak berada pada tahap ini.", EN: "The key ceremony is not at this step."},
+		errcatalog.Entry{Err: ErrAlreadySubmitted, Code: "ALREADY_SUBMITTED", Status: http.StatusConflict, ID: "Trustee sudah mengirim data untuk tahap ini.", EN: "The trustee already submitted data for this step."},
+		errcatalog.Entry{Err: ErrNoCandidates, Code: "NO_CANDIDATES", Status: http.StatusConflict, ID: "Pemilu belum memiliki kandidat yang disetujui.", EN: "The election has no approved candidates."},
+		errcatalog.Entry{Err: ErrKeyNotReady, Code: "KEY_NOT_READY", Status: http.StatusConflict, ID: "Kunci pemilu belum siap.", EN: "The election key is not ready."},
+		errcatalog.Entry{Err: ErrVotingNotClosed, Code: "VOTING_NOT_CLOSED", Status: http.StatusConflict, ID: "Rekapitulasi hanya dapat dibuat setelah voting ditutup.", EN: "The tally can only be computed after voting closes."},
+		errcatalog.Entry{Err: ErrTallyExists, Code: "TALLY_EXISTS", Status: http.StatusConflict, ID: "Rekapitulasi terenkripsi sudah dibuat.", EN: "The encrypted tally is already computed."},
+		errcatalog.Entry{Err: ErrTallyPublished, Code: "TALLY_PUBLISHED", Status: http.StatusConflict, ID: "Hasil sudah dipublikasikan.", EN: "The results are already published."},
+	)
+}
@@ -0,0 +1,285 @@
+package electionkey
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"pemira-api/internal/crypto"
+	"pemira-api/internal/errcatalog"
+	"pemira-api/internal/http/response"
+	"pemira-api/internal/shared/ctxkeys"
+)
+
+// maxSubmissionSize caps trustee uploads; a dealing for maxTrustees is well
+// under it.
+const maxSubmissionSize = 1 << 20
+
+type Handler struct {
+	svc *Service
+}
+
+func NewHandler(svc *Service) *Handler {
+	return &Handler{svc: svc}
+}
+
+// GetCeremony: GET /admin/elections/{electionID}/key-ceremony
+// and GET /trustee/elections/{electionID}/key-ceremony
+func (h *Handler) GetCeremony(w http.ResponseWriter, r *http.Request) {
+	electionID, ok := parseID(w, r, "electionID")
+	if !ok {
+		return
+	}
+
+	c, err := h.svc.GetCeremony(r.Context(), electionID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.Success(w, http.StatusOK, c)
+}
+
+// CreateCeremony: POST /admin/elections/{electionID}/key-ceremony
+func (h *Handler) CreateCeremony(w http.ResponseWriter, r *http.Request) {
+	electionID, ok := parseID(w, r, "electionID")
+	if !ok {
+		return
+	}
+
+	var req CreateCeremonyRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	var adminID *int64
+	if id, ok := ctxkeys.GetUserID(r.Context()); ok {
+		adminID = &id
+	}
+
+	c, err := h.svc.CreateCeremony(r.Context(), electionID, req, adminID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.Success(w, http.StatusCreated, c)
+}
+
+// DeleteCeremony: DELETE /admin/elections/{electionID}/key-ceremony
+func (h *Handler) DeleteCeremony(w http.ResponseWriter, r *http.Request) {
+	electionID, ok := parseID(w, r, "electionID")
+	if !ok {
+		return
+	}
+
+	if err := h.svc.DeleteCeremony(r.Context(), electionID); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.Success(w, http.StatusOK, map[string]string{"message": "Kunci pemilu dihapus"})
+}
+
+// SubmitTransportKey: POST /trustee/elections/{electionID}/transport-key
+func (h *Handler) SubmitTransportKey(w http.ResponseWriter, r *http.Request) {
+	var req TransportKeyRequest
+	h.trusteeStep(w, r, &req, func(electionID, userID int64) (any, error) {
+		return h.svc.SubmitTransportKey(r.Context(), electionID, userID, req)
+	})
+}
+
+// SubmitDealing: POST /trustee/elections/{electionID}/dealing
+func (h *Handler) SubmitDealing(w http.ResponseWriter, r *http.Request) {
+	var req crypto.Dealing
+	h.trusteeStep(w, r, &req, func(electionID, userID int64) (any, error) {
+		return h.svc.SubmitDealing(r.Context(), electionID, userID, req)
+	})
+}
+
+// Confirm: POST /trustee/elections/{electionID}/confirm
+func (h *Handler) Confirm(w http.ResponseWriter, r *http.Request) {
+	var req ConfirmRequest
+	h.trusteeStep(w, r, &req, func(electionID, userID int64) (any, error) {
+		return h.svc.Confirm(r.Context(), electionID, userID, req)
+	})
+}
+
+// SubmitPartialDecryption: POST /trustee/elections/{electionID}/partial-decryption
+func (h *Handler) SubmitPartialDecryption(w http.ResponseWriter, r *http.Request) {
+	var req PartialDecryptionRequest
+	h.trusteeStep(w, r, &req, func(electionID, userID int64) (any, error) {
+		return h.svc.SubmitPartialDecryption(r.Context(), electionID, userID, req)
+	})
+}
+
+// MyShares: GET /trustee/elections/{electionID}/shares
+func (h *Handler) MyShares(w http.ResponseWriter, r *http.Request) {
+	h.trusteeStep(w, r, nil, func(electionID, userID int64) (any, error) {
+		return h.svc.MyShares(r.Context(), electionID, userID)
+	})
+}
+
+func (h *Handler) trusteeStep(w http.ResponseWriter, r *http.Request, req any, fn func(electionID, userID int64) (any, error)) {
+	electionID, ok := parseID(w, r, "electionID")
+	if !ok {
+		return
+	}
+	userID, ok := ctxkeys.GetUserID(r.Context())
+	if !ok {
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.Unauthenticated)
+		return
+	}
+	if req != nil && !decode(w, r, req) {
+		return
+	}
+
+	out, err := fn(electionID, userID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.Success(w, http.StatusOK, out)
+}
+
+// StartTally: POST /admin/elections/{electionID}/encrypted-tally
+func (h *Handler) StartTally(w http.ResponseWriter, r *http.Request) {
+	electionID, ok := parseID(w, r, "electionID")
+	if !ok {
+		return
+	}
+
+	t, err := h.svc.StartTally(r.Context(), electionID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.Success(w, http.StatusCreated, t)
+}
+
+// GetTally: GET /admin/elections/{electionID}/encrypted-tally
+// and GET /trustee/elections/{electionID}/tally
+func (h *Handler) GetTally(w http.ResponseWriter, r *http.Request) {
+	electionID, ok := parseID(w, r, "electionID")
+	if !ok {
+		return
+	}
+
+	t, err := h.svc.GetTally(r.Context(), electionID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.Success(w, http.StatusOK, t)
+}
+
+// ElectionKey: GET /elections/{electionID}/encryption-key
+func (h *Handler) ElectionKey(w http.ResponseWriter, r *http.Request) {
+	electionID, ok := parseID(w, r, "electionID")
+	if !ok {
+		return
+	}
+
+	key, err := h.svc.ElectionKey(r.Context(), electionID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.Success(w, http.StatusOK, key)
+}
+
+// BulletinBoard: GET /elections/{electionID}/bulletin-board?after_id=&limit=
+func (h *Handler) BulletinBoard(w http.ResponseWriter, r *http.Request) {
+	electionID, ok := parseID(w, r, "electionID")
+	if !ok {
+		return
+	}
+	afterID, _ := strconv.ParseInt(r.URL.Query().Get("after_id"), 10, 64)
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	items, err := h.svc.ListBallots(r.Context(), electionID, afterID, limit)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	if items == nil {
+		items = []BallotRecord{}
+	}
+
+	response.Success(w, http.StatusOK, map[string]interface{}{"items": items})
+}
+
+// FindBallot: GET /elections/{electionID}/bulletin-board/{tracker}
+func (h *Handler) FindBallot(w http.ResponseWriter, r *http.Request) {
+	electionID, ok := parseID(w, r, "electionID")
+	if !ok {
+		return
+	}
+
+	rec, err := h.svc.FindBallot(r.Context(), electionID, chi.URLParam(r, "tracker"))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.Success(w, http.StatusOK, rec)
+}
+
+// VerifiableTally: GET /elections/{electionID}/verifiable-tally
+func (h *Handler) VerifiableTally(w http.ResponseWriter, r *http.Request) {
+	electionID, ok := parseID(w, r, "electionID")
+	if !ok {
+		return
+	}
+
+	t, err := h.svc.VerifiableTally(r.Context(), electionID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.Success(w, http.StatusOK, t)
+}
+
+func parseID(w http.ResponseWriter, r *http.Request, key string) (int64, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, key), 10, 64)
+	if err != nil || id <= 0 {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid(key))
+		return 0, false
+	}
+	return id, true
+}
+
+func decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	r.Body = http.MaxBytesReader(w, r.Body, maxSubmissionSize)
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
+		return false
+	}
+	return true
+}
+
+// handleError maps service errors to HTTP responses through the error
+// catalog (see errors.go)
+func (h *Handler) handleError(w http.ResponseWriter, err error) {
+	if errors.Is(err, ErrInvalidSubmission) {
+		// The reason comes from proof verification and is kept out of the
+		// localized message
+		e, _ := errcatalog.Lookup(err)
+		response.Error(w, e.Status, e.Code, e.Message(), map[string]interface{}{"reason": err.Error()})
+		return
+	}
+	if response.DomainError(w, err) {
+		return
+	}
+	slog.Error("election key handler error", "err", err)
+	response.InternalServerError(w, "INTERNAL_ERROR", errcatalog.InternalError)
+}
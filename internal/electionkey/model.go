@@ -0,0 +1,208 @@
+package electionkey
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"pemira-api/internal/crypto"
+)
+
+type CeremonyStatus string
+
+const (
+	// StatusTransportKeys waits for every trustee's transport key, which
+	// dealers encrypt key shares to.
+	StatusTransportKeys CeremonyStatus = "TRANSPORT_KEYS"
+	// StatusDealing waits for every trustee's commitments and shares.
+	StatusDealing CeremonyStatus = "DEALING"
+	// StatusConfirming waits for every trustee to check the shares it received.
+	StatusConfirming CeremonyStatus = "CONFIRMING"
+	// StatusReady means the election key is published and takes ballots.
+	StatusReady CeremonyStatus = "READY"
+	// StatusFailed means a trustee rejected a share; the ceremony is rerun.
+	StatusFailed CeremonyStatus = "FAILED"
+)
+
+type TallyStatus string
+
+const (
+	TallyDecrypting TallyStatus = "DECRYPTING"
+	TallyPublished  TallyStatus = "PUBLISHED"
+)
+
+// maxTrustees keeps the ceremony's N^2 share exchange small.
+const maxTrustees = 15
+
+var (
+	ErrElectionNotFound    = errors.New("election not found")
+	ErrCeremonyNotFound    = errors.New("key ceremony not found")
+	ErrCeremonyExists      = errors.New("election key is already ready")
+	ErrCeremonyLocked      = errors.New("key ceremony cannot change once voting has started")
+	ErrCeremonyWrongStatus = errors.New("key ceremony is not in the right step")
+	ErrInvalidCeremony     = errors.New("invalid key ceremony setup")
+	ErrUserNotFound        = errors.New("user not found")
+	ErrNoCandidates        = errors.New("election has no candidates")
+	ErrNotTrustee          = errors.New("user is not a trustee of this election")
+	ErrAlreadySubmitted    = errors.New("trustee already submitted this step")
+	ErrInvalidSubmission   = errors.New("trustee submission does not verify")
+	ErrKeyNotReady         = errors.New("election key is not ready")
+	ErrVotingNotClosed     = errors.New("voting has not closed")
+	ErrBallotNotFound      = errors.New("encrypted ballot not found")
+	ErrTallyNotFound       = errors.New("encrypted tally not found")
+	ErrTallyExists         = errors.New("encrypted tally already computed")
+	ErrTallyPublished      = errors.New("tally already published")
+)
+
+// CeremonyContext binds ceremony proofs and share encryption to one
+// election. Trustee clients must use the same value.
+func CeremonyContext(electionID int64) string {
+	return fmt.Sprintf("pemira|election|%d|ceremony", electionID)
+}
+
+// TransportContext binds trustee index's transport-key proof.
+func TransportContext(electionID int64, index int) string {
+	return fmt.Sprintf("%s|transport|%d", CeremonyContext(electionID), index)
+}
+
+// TallyContext binds partial-decryption proofs to one election's tally.
+func TallyContext(electionID int64) string {
+	return fmt.Sprintf("pemira|election|%d|tally", electionID)
+}
+
+type Ceremony struct {
+	ElectionID   int64          `json:"election_id"`
+	Threshold    int            `json:"threshold"`
+	TrusteeCount int            `json:"trustee_count"`
+	Status       CeremonyStatus `json:"status"`
+	Failure      *string        `json:"failure,omitempty"`
+	PublicKey    *crypto.Elem   `json:"public_key,omitempty"`
+	CandidateIDs []int64        `json:"candidate_ids"`
+	Trustees     []Trustee      `json:"trustees"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	CompletedAt  *time.Time     `json:"completed_at,omitempty"`
+}
+
+// Trustee is one committee member's public record. Dealing holds the
+// encrypted shares it sent; it is served only to the recipients.
+type Trustee struct {
+	Index           int                  `json:"index"`
+	UserID          int64                `json:"user_id"`
+	Name            string               `json:"name"`
+	TransportKey    *crypto.Elem         `json:"transport_key,omitempty"`
+	TransportProof  *crypto.SchnorrProof `json:"transport_proof,omitempty"`
+	Commitments     []*crypto.Elem       `json:"commitments,omitempty"`
+	CommitmentProof *crypto.SchnorrProof `json:"commitment_proof,omitempty"`
+	ConfirmedAt     *time.Time           `json:"confirmed_at,omitempty"`
+	VerificationKey *crypto.Elem         `json:"verification_key,omitempty"`
+	Dealing         *crypto.Dealing      `json:"-"`
+}
+
+func (c *Ceremony) trusteeByUser(userID int64) *Trustee {
+	for i := range c.Trustees {
+		if c.Trustees[i].UserID == userID {
+			return &c.Trustees[i]
+		}
+	}
+	return nil
+}
+
+func (c *Ceremony) commitments() [][]*crypto.Elem {
+	out := make([][]*crypto.Elem, len(c.Trustees))
+	for i, t := range c.Trustees {
+		out[i] = t.Dealing.Commitments
+	}
+	return out
+}
+
+type TrusteeInput struct {
+	UserID int64  `json:"user_id"`
+	Name   string `json:"name"`
+}
+
+type CreateCeremonyRequest struct {
+	Threshold int            `json:"threshold"`
+	Trustees  []TrusteeInput `json:"trustees"`
+}
+
+type TransportKeyRequest struct {
+	PublicKey *crypto.Elem        `json:"public_key"`
+	Proof     crypto.SchnorrProof `json:"proof"`
+}
+
+// ConfirmRequest lists the dealers whose share failed to decrypt or verify.
+// An empty list accepts every share.
+type ConfirmRequest struct {
+	Complaints []int `json:"complaints"`
+}
+
+type PartialDecryptionRequest struct {
+	Partials []crypto.PartialDecryption `json:"partials"`
+}
+
+// ReceivedShare is a share addressed to the requesting trustee together with
+// the dealer's commitments to check it against.
+type ReceivedShare struct {
+	Dealer      int                   `json:"dealer"`
+	Commitments []*crypto.Elem        `json:"commitments"`
+	Share       crypto.EncryptedShare `json:"share"`
+}
+
+type TrusteeShares struct {
+	ElectionID int64           `json:"election_id"`
+	Index      int             `json:"index"`
+	Threshold  int             `json:"threshold"`
+	Shares     []ReceivedShare `json:"shares"`
+}
+
+// ElectionKey is what a voter client needs to encrypt a ballot.
+type ElectionKey struct {
+	ElectionID   int64              `json:"election_id"`
+	Group        crypto.GroupParams `json:"group"`
+	PublicKey    *crypto.Elem       `json:"public_key"`
+	CandidateIDs []int64            `json:"candidate_ids"`
+	Threshold    int                `json:"threshold"`
+	TrusteeCount int                `json:"trustee_count"`
+}
+
+// BallotRecord is one entry of the public bulletin board.
+type BallotRecord struct {
+	ID      int64                   `json:"id"`
+	Tracker string                  `json:"tracker"`
+	Ballot  *crypto.EncryptedBallot `json:"ballot"`
+	Channel string                  `json:"channel"`
+	CastAt  time.Time               `json:"cast_at"`
+}
+
+type TrusteePartial struct {
+	Index       int                        `json:"index"`
+	Partials    []crypto.PartialDecryption `json:"partials"`
+	SubmittedAt time.Time                  `json:"submitted_at"`
+}
+
+type CandidateResult struct {
+	CandidateID int64 `json:"candidate_id"`
+	Votes       int64 `json:"votes"`
+}
+
+type Tally struct {
+	ElectionID  int64                   `json:"election_id"`
+	Status      TallyStatus             `json:"status"`
+	BallotCount int                     `json:"ballot_count"`
+	Tally       []crypto.CandidateTally `json:"tally"`
+	Partials    []TrusteePartial        `json:"partials"`
+	Result      []CandidateResult       `json:"result,omitempty"`
+	CreatedAt   time.Time               `json:"created_at"`
+	PublishedAt *time.Time              `json:"published_at,omitempty"`
+}
+
+// VerifiableTally is everything an outside auditor needs to recheck the
+// result: the key and its ceremony transcript, the encrypted tally, every
+// partial decryption with its proof, and the decoded counts. Ballots are on
+// the bulletin board.
+type VerifiableTally struct {
+	Key      ElectionKey `json:"key"`
+	Trustees []Trustee   `json:"trustees"`
+	Tally    *Tally      `json:"tally"`
+}
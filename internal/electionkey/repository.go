@@ -0,0 +1,38 @@
+package electionkey
+
+import (
+	"context"
+
+	"pemira-api/internal/crypto"
+)
+
+type Repository interface {
+	// ElectionStatus returns the election's status.
+	ElectionStatus(ctx context.Context, electionID int64) (string, error)
+	// ListCandidateIDs returns the election's ballot candidates in ballot order.
+	ListCandidateIDs(ctx context.Context, electionID int64) ([]int64, error)
+
+	// GetCeremony returns the ceremony with its trustees ordered by index.
+	GetCeremony(ctx context.Context, electionID int64) (*Ceremony, error)
+	// CreateCeremony replaces any earlier ceremony of the election.
+	CreateCeremony(ctx context.Context, c *Ceremony, createdBy *int64) error
+	// DeleteCeremony removes the ceremony and its trustees.
+	DeleteCeremony(ctx context.Context, electionID int64) error
+	// UpdateCeremony loads the ceremony under a row lock, applies fn and
+	// saves the ceremony and its trustees if fn returns nil.
+	UpdateCeremony(ctx context.Context, electionID int64, fn func(c *Ceremony) error) error
+
+	// ListBallots pages through the bulletin board by id.
+	ListBallots(ctx context.Context, electionID, afterID int64, limit int) ([]BallotRecord, error)
+	// FindBallot looks a ballot up by tracker.
+	FindBallot(ctx context.Context, electionID int64, tracker string) (*BallotRecord, error)
+
+	// CreateTally stores the aggregated ciphertexts; ErrTallyExists if one is
+	// already stored.
+	CreateTally(ctx context.Context, electionID int64, ballotCount int, tally []crypto.CandidateTally) error
+	// GetTally returns the tally with its partial decryptions.
+	GetTally(ctx context.Context, electionID int64) (*Tally, error)
+	// UpdateTally loads the tally under a row lock, applies fn and saves its
+	// partials, status and result if fn returns nil.
+	UpdateTally(ctx context.Context, electionID int64, fn func(t *Tally) error) error
+}
@@ -0,0 +1,398 @@
+package electionkey
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"pemira-api/internal/crypto"
+)
+
+type PgRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPgRepository(db *pgxpool.Pool) *PgRepository {
+	return &PgRepository{db: db}
+}
+
+// querier is satisfied by both the pool and a transaction.
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+func (r *PgRepository) ElectionStatus(ctx context.Context, electionID int64) (string, error) {
+	var status string
+	err := r.db.QueryRow(ctx, `SELECT status::text FROM elections WHERE id = $1`, electionID).Scan(&status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", ErrElectionNotFound
+	}
+	return status, err
+}
+
+func (r *PgRepository) ListCandidateIDs(ctx context.Context, electionID int64) ([]int64, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id FROM candidates
+		WHERE election_id = $1 AND status::text IN ('APPROVED', 'PUBLISHED')
+		ORDER BY number NULLS LAST, id`, electionID)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[int64])
+}
+
+func (r *PgRepository) GetCeremony(ctx context.Context, electionID int64) (*Ceremony, error) {
+	return loadCeremony(ctx, r.db, electionID, "")
+}
+
+func loadCeremony(ctx context.Context, q querier, electionID int64, lock string) (*Ceremony, error) {
+	c := Ceremony{ElectionID: electionID}
+	var publicKey *string
+	err := q.QueryRow(ctx, `
+		SELECT threshold, trustee_count, status, failure, public_key, candidate_ids,
+		       created_at, updated_at, completed_at
+		FROM election_key_ceremonies
+		WHERE election_id = $1 `+lock, electionID).
+		Scan(&c.Threshold, &c.TrusteeCount, &c.Status, &c.Failure, &publicKey, &c.CandidateIDs,
+			&c.CreatedAt, &c.UpdatedAt, &c.CompletedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrCeremonyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if c.PublicKey, err = parseElem(publicKey); err != nil {
+		return nil, err
+	}
+
+	rows, err := q.Query(ctx, `
+		SELECT trustee_index, user_id, name, transport_key, transport_proof, dealing,
+		       confirmed_at, verification_key
+		FROM election_trustees
+		WHERE election_id = $1
+		ORDER BY trustee_index`, electionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var t Trustee
+		var transportKey, verificationKey *string
+		var transportProof, dealing []byte
+		if err := rows.Scan(&t.Index, &t.UserID, &t.Name, &transportKey, &transportProof, &dealing,
+			&t.ConfirmedAt, &verificationKey); err != nil {
+			return nil, err
+		}
+		if t.TransportKey, err = parseElem(transportKey); err != nil {
+			return nil, err
+		}
+		if t.VerificationKey, err = parseElem(verificationKey); err != nil {
+			return nil, err
+		}
+		if transportProof != nil {
+			t.TransportProof = &crypto.SchnorrProof{}
+			if err := json.Unmarshal(transportProof, t.TransportProof); err != nil {
+				return nil, err
+			}
+		}
+		if dealing != nil {
+			t.Dealing = &crypto.Dealing{}
+			if err := json.Unmarshal(dealing, t.Dealing); err != nil {
+				return nil, err
+			}
+			t.Commitments, t.CommitmentProof = t.Dealing.Commitments, &t.Dealing.Proof
+		}
+		c.Trustees = append(c.Trustees, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *PgRepository) CreateCeremony(ctx context.Context, c *Ceremony, createdBy *int64) error {
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM election_key_ceremonies WHERE election_id = $1`, c.ElectionID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO election_key_ceremonies (election_id, threshold, trustee_count, status, created_by)
+		VALUES ($1, $2, $3, $4, $5)`,
+		c.ElectionID, c.Threshold, c.TrusteeCount, c.Status, createdBy); err != nil {
+		return translateFK(err)
+	}
+	for _, t := range c.Trustees {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO election_trustees (election_id, trustee_index, user_id, name)
+			VALUES ($1, $2, $3, $4)`,
+			c.ElectionID, t.Index, t.UserID, t.Name); err != nil {
+			return translateFK(err)
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *PgRepository) DeleteCeremony(ctx context.Context, electionID int64) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM election_key_ceremonies WHERE election_id = $1`, electionID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrCeremonyNotFound
+	}
+	return nil
+}
+
+func (r *PgRepository) UpdateCeremony(ctx context.Context, electionID int64, fn func(c *Ceremony) error) error {
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	c, err := loadCeremony(ctx, tx, electionID, "FOR UPDATE")
+	if err != nil {
+		return err
+	}
+	if err := fn(c); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE election_key_ceremonies
+		SET status = $2, failure = $3, public_key = $4, candidate_ids = $5,
+		    completed_at = $6, updated_at = NOW()
+		WHERE election_id = $1`,
+		electionID, c.Status, c.Failure, elemText(c.PublicKey), c.CandidateIDs, c.CompletedAt); err != nil {
+		return err
+	}
+	for _, t := range c.Trustees {
+		transportProof, err := jsonOrNil(t.TransportProof)
+		if err != nil {
+			return err
+		}
+		dealing, err := jsonOrNil(t.Dealing)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, `
+			UPDATE election_trustees
+			SET transport_key = $3, transport_proof = $4, dealing = $5,
+			    confirmed_at = $6, verification_key = $7
+			WHERE election_id = $1 AND trustee_index = $2`,
+			electionID, t.Index, elemText(t.TransportKey), transportProof, dealing,
+			t.ConfirmedAt, elemText(t.VerificationKey)); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *PgRepository) ListBallots(ctx context.Context, electionID, afterID int64, limit int) ([]BallotRecord, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, tracker, ballot, channel::text, cast_at
+		FROM encrypted_ballots
+		WHERE election_id = $1 AND id > $2
+		ORDER BY id
+		LIMIT $3`, electionID, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []BallotRecord
+	for rows.Next() {
+		rec, err := scanBallot(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *rec)
+	}
+	return out, rows.Err()
+}
+
+func (r *PgRepository) FindBallot(ctx context.Context, electionID int64, tracker string) (*BallotRecord, error) {
+	rec, err := scanBallot(r.db.QueryRow(ctx, `
+		SELECT id, tracker, ballot, channel::text, cast_at
+		FROM encrypted_ballots
+		WHERE election_id = $1 AND tracker = $2`, electionID, tracker))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrBallotNotFound
+	}
+	return rec, err
+}
+
+func scanBallot(row pgx.Row) (*BallotRecord, error) {
+	var rec BallotRecord
+	var ballot []byte
+	if err := row.Scan(&rec.ID, &rec.Tracker, &ballot, &rec.Channel, &rec.CastAt); err != nil {
+		return nil, err
+	}
+	rec.Ballot = &crypto.EncryptedBallot{}
+	if err := json.Unmarshal(ballot, rec.Ballot); err != nil {
+		return nil, err
+	}
+	return &rec, nil
+}
+
+func (r *PgRepository) CreateTally(ctx context.Context, electionID int64, ballotCount int, tally []crypto.CandidateTally) error {
+	data, err := json.Marshal(tally)
+	if err != nil {
+		return err
+	}
+	tag, err := r.db.Exec(ctx, `
+		INSERT INTO encrypted_tallies (election_id, ballot_count, tally)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (election_id) DO NOTHING`, electionID, ballotCount, data)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrTallyExists
+	}
+	return nil
+}
+
+func (r *PgRepository) GetTally(ctx context.Context, electionID int64) (*Tally, error) {
+	return loadTally(ctx, r.db, electionID, "")
+}
+
+func loadTally(ctx context.Context, q querier, electionID int64, lock string) (*Tally, error) {
+	t := Tally{ElectionID: electionID}
+	var tally, result []byte
+	err := q.QueryRow(ctx, `
+		SELECT ballot_count, tally, status, result, created_at, published_at
+		FROM encrypted_tallies
+		WHERE election_id = $1 `+lock, electionID).
+		Scan(&t.BallotCount, &tally, &t.Status, &result, &t.CreatedAt, &t.PublishedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrTallyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(tally, &t.Tally); err != nil {
+		return nil, err
+	}
+	if result != nil {
+		if err := json.Unmarshal(result, &t.Result); err != nil {
+			return nil, err
+		}
+	}
+
+	rows, err := q.Query(ctx, `
+		SELECT trustee_index, partials, submitted_at
+		FROM trustee_partial_decryptions
+		WHERE election_id = $1
+		ORDER BY submitted_at, trustee_index`, electionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	t.Partials = []TrusteePartial{}
+	for rows.Next() {
+		var p TrusteePartial
+		var partials []byte
+		if err := rows.Scan(&p.Index, &partials, &p.SubmittedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(partials, &p.Partials); err != nil {
+			return nil, err
+		}
+		t.Partials = append(t.Partials, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *PgRepository) UpdateTally(ctx context.Context, electionID int64, fn func(t *Tally) error) error {
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	t, err := loadTally(ctx, tx, electionID, "FOR UPDATE")
+	if err != nil {
+		return err
+	}
+	if err := fn(t); err != nil {
+		return err
+	}
+
+	for _, p := range t.Partials {
+		data, err := json.Marshal(p.Partials)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO trustee_partial_decryptions (election_id, trustee_index, partials, submitted_at)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (election_id, trustee_index) DO NOTHING`,
+			electionID, p.Index, data, p.SubmittedAt); err != nil {
+			return err
+		}
+	}
+	var result []byte
+	if t.Result != nil {
+		if result, err = json.Marshal(t.Result); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE encrypted_tallies SET status = $2, result = $3, published_at = $4
+		WHERE election_id = $1`,
+		electionID, t.Status, result, t.PublishedAt); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func parseElem(s *string) (*crypto.Elem, error) {
+	if s == nil {
+		return nil, nil
+	}
+	e := new(crypto.Elem)
+	if err := e.UnmarshalText([]byte(*s)); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func elemText(e *crypto.Elem) *string {
+	if e == nil {
+		return nil
+	}
+	text, _ := e.MarshalText()
+	s := string(text)
+	return &s
+}
+
+func jsonOrNil[T any](v *T) ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	return json.Marshal(v)
+}
+
+func translateFK(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+		return ErrUserNotFound
+	}
+	return err
+}
@@ -0,0 +1,437 @@
+package electionkey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"pemira-api/internal/crypto"
+)
+
+// ballotPageSize is how many ballots are read per query when aggregating.
+const ballotPageSize = 500
+
+type Service struct {
+	repo  Repository
+	group *crypto.Group
+	now   func() time.Time
+}
+
+func NewService(repo Repository) *Service {
+	return &Service{repo: repo, group: crypto.DefaultGroup(), now: time.Now}
+}
+
+// votingStarted reports whether the key can no longer change: ballots may
+// already be encrypted under it.
+func votingStarted(status string) bool {
+	switch status {
+	case "VOTING_OPEN", "VOTING_CLOSED", "CLOSED", "ARCHIVED":
+		return true
+	}
+	return false
+}
+
+func votingOver(status string) bool {
+	return votingStarted(status) && status != "VOTING_OPEN"
+}
+
+// CreateCeremony starts a k-of-N key ceremony for an election. An earlier
+// ceremony that never completed is replaced.
+func (s *Service) CreateCeremony(ctx context.Context, electionID int64, req CreateCeremonyRequest, adminID *int64) (*Ceremony, error) {
+	status, err := s.repo.ElectionStatus(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+	if votingStarted(status) {
+		return nil, ErrCeremonyLocked
+	}
+
+	n := len(req.Trustees)
+	if n == 0 || n > maxTrustees || req.Threshold < 1 || req.Threshold > n {
+		return nil, ErrInvalidCeremony
+	}
+	seen := make(map[int64]bool, n)
+	trustees := make([]Trustee, n)
+	for i, t := range req.Trustees {
+		name := strings.TrimSpace(t.Name)
+		if t.UserID <= 0 || name == "" || seen[t.UserID] {
+			return nil, ErrInvalidCeremony
+		}
+		seen[t.UserID] = true
+		trustees[i] = Trustee{Index: i + 1, UserID: t.UserID, Name: name}
+	}
+
+	existing, err := s.repo.GetCeremony(ctx, electionID)
+	if err != nil && !errors.Is(err, ErrCeremonyNotFound) {
+		return nil, err
+	}
+	if existing != nil && existing.Status == StatusReady {
+		return nil, ErrCeremonyExists
+	}
+
+	c := &Ceremony{
+		ElectionID:   electionID,
+		Threshold:    req.Threshold,
+		TrusteeCount: n,
+		Status:       StatusTransportKeys,
+		CandidateIDs: []int64{},
+		Trustees:     trustees,
+	}
+	if err := s.repo.CreateCeremony(ctx, c, adminID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetCeremony(ctx, electionID)
+}
+
+// DeleteCeremony drops an election's key so it takes plaintext ballots again.
+func (s *Service) DeleteCeremony(ctx context.Context, electionID int64) error {
+	status, err := s.repo.ElectionStatus(ctx, electionID)
+	if err != nil {
+		return err
+	}
+	if votingStarted(status) {
+		return ErrCeremonyLocked
+	}
+	return s.repo.DeleteCeremony(ctx, electionID)
+}
+
+func (s *Service) GetCeremony(ctx context.Context, electionID int64) (*Ceremony, error) {
+	return s.repo.GetCeremony(ctx, electionID)
+}
+
+// updateStep applies a trustee's submission to a ceremony in the given step.
+func (s *Service) updateStep(ctx context.Context, electionID, userID int64, step CeremonyStatus, fn func(c *Ceremony, t *Trustee) error) (*Ceremony, error) {
+	err := s.repo.UpdateCeremony(ctx, electionID, func(c *Ceremony) error {
+		t := c.trusteeByUser(userID)
+		if t == nil {
+			return ErrNotTrustee
+		}
+		if c.Status != step {
+			return ErrCeremonyWrongStatus
+		}
+		return fn(c, t)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetCeremony(ctx, electionID)
+}
+
+// SubmitTransportKey records the key a trustee receives shares under.
+func (s *Service) SubmitTransportKey(ctx context.Context, electionID, userID int64, req TransportKeyRequest) (*Ceremony, error) {
+	return s.updateStep(ctx, electionID, userID, StatusTransportKeys, func(c *Ceremony, t *Trustee) error {
+		if t.TransportKey != nil {
+			return ErrAlreadySubmitted
+		}
+		if req.PublicKey == nil {
+			return ErrInvalidSubmission
+		}
+		if err := s.group.VerifyKnowledge(TransportContext(electionID, t.Index), req.PublicKey.Int(), req.Proof); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidSubmission, err)
+		}
+		proof := req.Proof
+		t.TransportKey, t.TransportProof = req.PublicKey, &proof
+
+		for _, other := range c.Trustees {
+			if other.TransportKey == nil {
+				return nil
+			}
+		}
+		c.Status = StatusDealing
+		return nil
+	})
+}
+
+// SubmitDealing records a trustee's commitments and the shares it encrypted
+// to every trustee.
+func (s *Service) SubmitDealing(ctx context.Context, electionID, userID int64, d crypto.Dealing) (*Ceremony, error) {
+	return s.updateStep(ctx, electionID, userID, StatusDealing, func(c *Ceremony, t *Trustee) error {
+		if t.Dealing != nil {
+			return ErrAlreadySubmitted
+		}
+		if err := s.group.VerifyCommitments(CeremonyContext(electionID), t.Index, c.Threshold, d.Commitments, d.Proof); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidSubmission, err)
+		}
+		if len(d.Shares) != c.TrusteeCount {
+			return fmt.Errorf("%w: expected %d shares", ErrInvalidSubmission, c.TrusteeCount)
+		}
+		for i, share := range d.Shares {
+			if share.Recipient != i+1 || share.Ephemeral == nil || !s.group.IsElement(share.Ephemeral.Int()) || share.Payload == "" {
+				return fmt.Errorf("%w: share %d is malformed", ErrInvalidSubmission, i+1)
+			}
+		}
+		t.Dealing = &d
+		t.Commitments, t.CommitmentProof = d.Commitments, &d.Proof
+
+		for _, other := range c.Trustees {
+			if other.Dealing == nil {
+				return nil
+			}
+		}
+		c.Status = StatusConfirming
+		return nil
+	})
+}
+
+// MyShares returns the shares every dealer encrypted to the trustee.
+func (s *Service) MyShares(ctx context.Context, electionID, userID int64) (*TrusteeShares, error) {
+	c, err := s.repo.GetCeremony(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+	t := c.trusteeByUser(userID)
+	if t == nil {
+		return nil, ErrNotTrustee
+	}
+	if c.Status != StatusConfirming && c.Status != StatusReady {
+		return nil, ErrCeremonyWrongStatus
+	}
+
+	out := &TrusteeShares{ElectionID: electionID, Index: t.Index, Threshold: c.Threshold}
+	for _, dealer := range c.Trustees {
+		out.Shares = append(out.Shares, ReceivedShare{
+			Dealer:      dealer.Index,
+			Commitments: dealer.Dealing.Commitments,
+			Share:       dealer.Dealing.Shares[t.Index-1],
+		})
+	}
+	return out, nil
+}
+
+// Confirm records a trustee's verdict on the shares it received. A complaint
+// fails the ceremony; once every trustee accepts, the election key and the
+// trustees' verification keys are derived and the candidate order is fixed.
+func (s *Service) Confirm(ctx context.Context, electionID, userID int64, req ConfirmRequest) (*Ceremony, error) {
+	candidateIDs, err := s.repo.ListCandidateIDs(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.updateStep(ctx, electionID, userID, StatusConfirming, func(c *Ceremony, t *Trustee) error {
+		if t.ConfirmedAt != nil {
+			return ErrAlreadySubmitted
+		}
+		if len(req.Complaints) > 0 {
+			failure := fmt.Sprintf("trustee %d rejected shares from dealers %v", t.Index, req.Complaints)
+			c.Status, c.Failure = StatusFailed, &failure
+			return nil
+		}
+
+		now := s.now().UTC()
+		t.ConfirmedAt = &now
+		for _, other := range c.Trustees {
+			if other.ConfirmedAt == nil {
+				return nil
+			}
+		}
+
+		if len(candidateIDs) == 0 {
+			return ErrNoCandidates
+		}
+		commitments := c.commitments()
+		for i := range c.Trustees {
+			c.Trustees[i].VerificationKey = crypto.E(s.group.VerificationKey(commitments, c.Trustees[i].Index))
+		}
+		c.PublicKey = crypto.E(s.group.JointPublicKey(commitments))
+		c.CandidateIDs = candidateIDs
+		c.Status = StatusReady
+		c.CompletedAt = &now
+		return nil
+	})
+}
+
+// ElectionKey returns the published key voter clients encrypt under.
+func (s *Service) ElectionKey(ctx context.Context, electionID int64) (*ElectionKey, error) {
+	c, err := s.repo.GetCeremony(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+	if c.Status != StatusReady {
+		return nil, ErrKeyNotReady
+	}
+	return s.electionKey(c), nil
+}
+
+func (s *Service) electionKey(c *Ceremony) *ElectionKey {
+	return &ElectionKey{
+		ElectionID:   c.ElectionID,
+		Group:        s.group.Params(),
+		PublicKey:    c.PublicKey,
+		CandidateIDs: c.CandidateIDs,
+		Threshold:    c.Threshold,
+		TrusteeCount: c.TrusteeCount,
+	}
+}
+
+// EncryptionMode implements voting.BallotVerifier. An election with a
+// ceremony under way takes no plaintext ballots, but no encrypted ones
+// either until the key is ready.
+func (s *Service) EncryptionMode(ctx context.Context, electionID int64) (required, ready bool, err error) {
+	c, err := s.repo.GetCeremony(ctx, electionID)
+	if errors.Is(err, ErrCeremonyNotFound) {
+		return false, false, nil
+	}
+	if err != nil {
+		return false, false, err
+	}
+	if c.Status == StatusFailed {
+		return false, false, nil
+	}
+	return true, c.Status == StatusReady, nil
+}
+
+// VerifyBallot implements voting.BallotVerifier.
+func (s *Service) VerifyBallot(ctx context.Context, electionID int64, ballot *crypto.EncryptedBallot) error {
+	c, err := s.repo.GetCeremony(ctx, electionID)
+	if err != nil {
+		return err
+	}
+	if c.Status != StatusReady {
+		return ErrKeyNotReady
+	}
+	return s.group.VerifyBallot(c.PublicKey.Int(), electionID, c.CandidateIDs, ballot)
+}
+
+// ListBallots returns a page of the public bulletin board.
+func (s *Service) ListBallots(ctx context.Context, electionID, afterID int64, limit int) ([]BallotRecord, error) {
+	if limit <= 0 || limit > ballotPageSize {
+		limit = ballotPageSize
+	}
+	return s.repo.ListBallots(ctx, electionID, afterID, limit)
+}
+
+// FindBallot lets a voter look up their ballot by tracker.
+func (s *Service) FindBallot(ctx context.Context, electionID int64, tracker string) (*BallotRecord, error) {
+	return s.repo.FindBallot(ctx, electionID, strings.TrimSpace(tracker))
+}
+
+// StartTally multiplies every ballot into one ciphertext per candidate once
+// voting is over. The result is fixed; trustees then decrypt it.
+func (s *Service) StartTally(ctx context.Context, electionID int64) (*Tally, error) {
+	status, err := s.repo.ElectionStatus(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+	if !votingOver(status) {
+		return nil, ErrVotingNotClosed
+	}
+	c, err := s.repo.GetCeremony(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+	if c.Status != StatusReady {
+		return nil, ErrKeyNotReady
+	}
+
+	tally := s.group.Aggregate(c.CandidateIDs, nil)
+	count := 0
+	var afterID int64
+	for {
+		page, err := s.repo.ListBallots(ctx, electionID, afterID, ballotPageSize)
+		if err != nil {
+			return nil, err
+		}
+		if len(page) == 0 {
+			break
+		}
+		ballots := make([]*crypto.EncryptedBallot, len(page))
+		for i, rec := range page {
+			if len(rec.Ballot.Choices) != len(c.CandidateIDs) {
+				return nil, fmt.Errorf("ballot %s does not match the election's candidates", rec.Tracker)
+			}
+			ballots[i] = rec.Ballot
+		}
+		for i, t := range s.group.Aggregate(c.CandidateIDs, ballots) {
+			tally[i].Ciphertext = s.group.Add(tally[i].Ciphertext, t.Ciphertext)
+		}
+		count += len(page)
+		afterID = page[len(page)-1].ID
+	}
+
+	if err := s.repo.CreateTally(ctx, electionID, count, tally); err != nil {
+		return nil, err
+	}
+	return s.repo.GetTally(ctx, electionID)
+}
+
+func (s *Service) GetTally(ctx context.Context, electionID int64) (*Tally, error) {
+	return s.repo.GetTally(ctx, electionID)
+}
+
+// SubmitPartialDecryption records a trustee's decryption share of the tally
+// after checking its proofs. The threshold-th valid share decrypts and
+// publishes the result.
+func (s *Service) SubmitPartialDecryption(ctx context.Context, electionID, userID int64, req PartialDecryptionRequest) (*Tally, error) {
+	c, err := s.repo.GetCeremony(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+	t := c.trusteeByUser(userID)
+	if t == nil {
+		return nil, ErrNotTrustee
+	}
+	if c.Status != StatusReady {
+		return nil, ErrKeyNotReady
+	}
+
+	err = s.repo.UpdateTally(ctx, electionID, func(tally *Tally) error {
+		if tally.Status == TallyPublished {
+			return ErrTallyPublished
+		}
+		for _, p := range tally.Partials {
+			if p.Index == t.Index {
+				return ErrAlreadySubmitted
+			}
+		}
+		if err := s.group.VerifyPartial(TallyContext(electionID), t.Index, t.VerificationKey.Int(), tally.Tally, req.Partials); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidSubmission, err)
+		}
+
+		now := s.now().UTC()
+		tally.Partials = append(tally.Partials, TrusteePartial{Index: t.Index, Partials: req.Partials, SubmittedAt: now})
+		if len(tally.Partials) < c.Threshold {
+			return nil
+		}
+
+		partials := make(map[int][]crypto.PartialDecryption, len(tally.Partials))
+		for _, p := range tally.Partials {
+			partials[p.Index] = p.Partials
+		}
+		counts, err := s.group.DecryptTally(c.Threshold, tally.Tally, partials, int64(tally.BallotCount))
+		if err != nil {
+			return err
+		}
+		tally.Result = make([]CandidateResult, len(tally.Tally))
+		for i, ct := range tally.Tally {
+			tally.Result[i] = CandidateResult{CandidateID: ct.CandidateID, Votes: counts[ct.CandidateID]}
+		}
+		tally.Status = TallyPublished
+		tally.PublishedAt = &now
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetTally(ctx, electionID)
+}
+
+// VerifiableTally returns the published tally with its full transcript.
+func (s *Service) VerifiableTally(ctx context.Context, electionID int64) (*VerifiableTally, error) {
+	tally, err := s.repo.GetTally(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+	if tally.Status != TallyPublished {
+		return nil, ErrTallyNotFound
+	}
+	c, err := s.repo.GetCeremony(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(tally.Partials, func(i, j int) bool { return tally.Partials[i].Index < tally.Partials[j].Index })
+	return &VerifiableTally{Key: *s.electionKey(c), Trustees: c.Trustees, Tally: tally}, nil
+}
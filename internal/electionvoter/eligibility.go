@@ -0,0 +1,454 @@
+package electionvoter
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"pemira-api/internal/master"
+	"pemira-api/internal/shared"
+)
+
+var (
+	ErrEligibilityRulesInvalid = errors.New("invalid eligibility rules")
+	ErrEligibilityDiffStale    = errors.New("eligibility diff changed since it was previewed")
+	ErrRosterNotConfigured     = errors.New("academic roster not configured")
+)
+
+// Reasons a roster entry or enrolled voter is not eligible
+const (
+	ReasonVoterType      = "VOTER_TYPE"
+	ReasonAcademicStatus = "ACADEMIC_STATUS"
+	ReasonCohortYear     = "COHORT_YEAR"
+	ReasonSemester       = "SEMESTER"
+	ReasonFaculty        = "FACULTY"
+	ReasonRosterRemoved  = "ROSTER_REMOVED"
+	ReasonNotInRoster    = "NOT_IN_ROSTER"
+	ReasonAlreadyVoted   = "ALREADY_VOTED"
+)
+
+type EligibilityAction string
+
+const (
+	EligibilityAdd    EligibilityAction = "ADD"
+	EligibilityRemove EligibilityAction = "REMOVE"
+	EligibilityFlag   EligibilityAction = "FLAG"
+)
+
+var allowedVoterTypes = map[string]struct{}{"STUDENT": {}, "LECTURER": {}, "STAFF": {}}
+
+// EligibilityRules decide who belongs in an election's DPT. Empty lists
+// match everything; cohort and semester ranges only constrain students.
+type EligibilityRules struct {
+	ElectionID       int64     `json:"election_id"`
+	VoterTypes       []string  `json:"voter_types"`
+	AcademicStatuses []string  `json:"academic_statuses"`
+	CohortYearMin    *int      `json:"cohort_year_min,omitempty"`
+	CohortYearMax    *int      `json:"cohort_year_max,omitempty"`
+	SemesterMin      *int      `json:"semester_min,omitempty"`
+	SemesterMax      *int      `json:"semester_max,omitempty"`
+	FacultyCodes     []string  `json:"faculty_codes"`
+	UpdatedBy        *int64    `json:"updated_by,omitempty"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// DefaultEligibilityRules admit every active person in the roster
+func DefaultEligibilityRules(electionID int64) *EligibilityRules {
+	return &EligibilityRules{
+		ElectionID:       electionID,
+		VoterTypes:       []string{},
+		AcademicStatuses: []string{defaultAcademicStatus},
+		FacultyCodes:     []string{},
+	}
+}
+
+// Normalize upper-cases and sorts the lists and validates every value
+func (r *EligibilityRules) Normalize() error {
+	var err error
+	if r.VoterTypes, err = normalizeSet(r.VoterTypes, allowedVoterTypes); err != nil {
+		return err
+	}
+	if r.AcademicStatuses, err = normalizeSet(r.AcademicStatuses, allowedAcademicStatus); err != nil {
+		return err
+	}
+	if r.FacultyCodes, err = normalizeSet(r.FacultyCodes, nil); err != nil {
+		return err
+	}
+	if r.CohortYearMin != nil && r.CohortYearMax != nil && *r.CohortYearMin > *r.CohortYearMax {
+		return ErrEligibilityRulesInvalid
+	}
+	for _, sem := range []*int{r.SemesterMin, r.SemesterMax} {
+		if sem != nil && (*sem < 1 || *sem > 20) {
+			return ErrEligibilityRulesInvalid
+		}
+	}
+	if r.SemesterMin != nil && r.SemesterMax != nil && *r.SemesterMin > *r.SemesterMax {
+		return ErrEligibilityRulesInvalid
+	}
+	return nil
+}
+
+// Evaluate returns the first rule the entry fails, or "" when it is eligible
+func (r *EligibilityRules) Evaluate(e master.RosterEntry) string {
+	if e.RemovedAt != nil {
+		return ReasonRosterRemoved
+	}
+	if !inSet(r.VoterTypes, e.VoterType) {
+		return ReasonVoterType
+	}
+	if !inSet(r.AcademicStatuses, e.AcademicStatus) {
+		return ReasonAcademicStatus
+	}
+	if e.VoterType == master.RosterStudent {
+		if !inRange(e.CohortYear, r.CohortYearMin, r.CohortYearMax) {
+			return ReasonCohortYear
+		}
+		if !inRange(e.Semester, r.SemesterMin, r.SemesterMax) {
+			return ReasonSemester
+		}
+	}
+	if len(r.FacultyCodes) > 0 && (e.FacultyCode == nil || !inSet(r.FacultyCodes, strings.ToUpper(*e.FacultyCode))) {
+		return ReasonFaculty
+	}
+	return ""
+}
+
+// EnrolledVoter is a current DPT row as the eligibility engine sees it
+type EnrolledVoter struct {
+	ElectionVoterID int64
+	VoterID         int64
+	NIM             string
+	VoterType       string
+	Name            string
+	Status          string
+	CheckedIn       bool
+	Voted           bool
+}
+
+// EligibilityChange is one proposed DPT change. Removals and flags carry
+// the enrollment; flags are for review only and never applied.
+type EligibilityChange struct {
+	Action          EligibilityAction `json:"action"`
+	VoterType       string            `json:"voter_type"`
+	Identifier      string            `json:"identifier"`
+	Name            string            `json:"name"`
+	Reason          string            `json:"reason,omitempty"`
+	Detail          string            `json:"detail,omitempty"`
+	ElectionVoterID *int64            `json:"election_voter_id,omitempty"`
+	VoterID         *int64            `json:"voter_id,omitempty"`
+	Status          string            `json:"status,omitempty"`
+}
+
+type EligibilitySummary struct {
+	RosterEntries int `json:"roster_entries"`
+	Eligible      int `json:"eligible"`
+	Enrolled      int `json:"enrolled"`
+	Unchanged     int `json:"unchanged"`
+	Add           int `json:"add"`
+	Remove        int `json:"remove"`
+	Flag          int `json:"flag"`
+}
+
+// EligibilityDiff is the DPT change set for review. Hash identifies the
+// exact set so apply can refuse a diff that moved after preview.
+type EligibilityDiff struct {
+	ElectionID int64               `json:"election_id"`
+	Rules      EligibilityRules    `json:"rules"`
+	Summary    EligibilitySummary  `json:"summary"`
+	Add        []EligibilityChange `json:"add"`
+	Remove     []EligibilityChange `json:"remove"`
+	Flag       []EligibilityChange `json:"flag"`
+	Hash       string              `json:"diff_hash"`
+
+	addEntries []master.RosterEntry
+}
+
+type EligibilityApplyRequest struct {
+	DiffHash string `json:"diff_hash"`
+}
+
+type EligibilityApplyRun struct {
+	ID         int64            `json:"id"`
+	ElectionID int64            `json:"election_id"`
+	DiffHash   string           `json:"diff_hash"`
+	Rules      EligibilityRules `json:"rules"`
+	Added      int              `json:"added"`
+	Removed    int              `json:"removed"`
+	Flagged    int              `json:"flagged"`
+	AppliedBy  *int64           `json:"applied_by,omitempty"`
+	AppliedAt  time.Time        `json:"applied_at"`
+}
+
+// ComputeEligibilityDiff matches the roster against the current DPT by
+// voter type and NIM/NIDN/NIP. Eligible entries not enrolled are added;
+// enrolled voters the rules or the roster exclude are removed unless they
+// already checked in or voted, in which case they are flagged, as are
+// enrolled voters the roster does not know.
+func ComputeEligibilityDiff(rules EligibilityRules, roster []master.RosterEntry, enrolled []EnrolledVoter) *EligibilityDiff {
+	diff := &EligibilityDiff{
+		ElectionID: rules.ElectionID,
+		Rules:      rules,
+		Add:        []EligibilityChange{},
+		Remove:     []EligibilityChange{},
+		Flag:       []EligibilityChange{},
+	}
+	diff.Summary.RosterEntries = len(roster)
+	diff.Summary.Enrolled = len(enrolled)
+
+	byKey := make(map[string]*EnrolledVoter, len(enrolled))
+	for i := range enrolled {
+		byKey[eligibilityKey(enrolled[i].VoterType, enrolled[i].NIM)] = &enrolled[i]
+	}
+	inRoster := make(map[string]bool, len(roster))
+
+	for _, e := range roster {
+		key := eligibilityKey(e.VoterType, e.Identifier)
+		inRoster[key] = true
+		reason := rules.Evaluate(e)
+		if reason == "" {
+			diff.Summary.Eligible++
+		}
+
+		ev, ok := byKey[key]
+		switch {
+		case !ok && reason == "":
+			diff.Add = append(diff.Add, EligibilityChange{
+				Action:     EligibilityAdd,
+				VoterType:  e.VoterType,
+				Identifier: e.Identifier,
+				Name:       e.Name,
+			})
+			diff.addEntries = append(diff.addEntries, e)
+		case ok && reason == "":
+			diff.Summary.Unchanged++
+		case ok:
+			diff.excludeEnrolled(ev, reason)
+		}
+	}
+
+	for i := range enrolled {
+		ev := &enrolled[i]
+		if inRoster[eligibilityKey(ev.VoterType, ev.NIM)] {
+			continue
+		}
+		if !inSet(rules.VoterTypes, ev.VoterType) {
+			diff.excludeEnrolled(ev, ReasonVoterType)
+			continue
+		}
+		diff.Flag = append(diff.Flag, enrolledChange(EligibilityFlag, ev, ReasonNotInRoster, ""))
+	}
+
+	sortChanges(diff.Add)
+	sortChanges(diff.Remove)
+	sortChanges(diff.Flag)
+	sort.Slice(diff.addEntries, func(i, j int) bool {
+		return eligibilityKey(diff.addEntries[i].VoterType, diff.addEntries[i].Identifier) <
+			eligibilityKey(diff.addEntries[j].VoterType, diff.addEntries[j].Identifier)
+	})
+	diff.Summary.Add = len(diff.Add)
+	diff.Summary.Remove = len(diff.Remove)
+	diff.Summary.Flag = len(diff.Flag)
+	diff.Hash = diff.hash()
+	return diff
+}
+
+// excludeEnrolled removes an ineligible enrolled voter, or flags them when
+// they already took part in the vote
+func (d *EligibilityDiff) excludeEnrolled(ev *EnrolledVoter, reason string) {
+	if ev.Voted || ev.CheckedIn || ev.Status == "VOTED" {
+		d.Flag = append(d.Flag, enrolledChange(EligibilityFlag, ev, ReasonAlreadyVoted, reason))
+		return
+	}
+	d.Remove = append(d.Remove, enrolledChange(EligibilityRemove, ev, reason, ""))
+}
+
+func (d *EligibilityDiff) removeIDs() []int64 {
+	ids := make([]int64, 0, len(d.Remove))
+	for _, c := range d.Remove {
+		ids = append(ids, *c.ElectionVoterID)
+	}
+	return ids
+}
+
+// hash covers the rules and every change, so any difference between
+// preview and apply shows up
+func (d *EligibilityDiff) hash() string {
+	h := sha256.New()
+	r := d.Rules
+	fmt.Fprintf(h, "rules|%v|%v|%v|%v|%v|%v|%v\n", r.VoterTypes, r.AcademicStatuses, r.FacultyCodes,
+		intOrNil(r.CohortYearMin), intOrNil(r.CohortYearMax), intOrNil(r.SemesterMin), intOrNil(r.SemesterMax))
+	for _, list := range [][]EligibilityChange{d.Add, d.Remove, d.Flag} {
+		for _, c := range list {
+			var evID int64
+			if c.ElectionVoterID != nil {
+				evID = *c.ElectionVoterID
+			}
+			fmt.Fprintf(h, "%s|%s|%s|%d|%s|%s\n", c.Action, c.VoterType, c.Identifier, evID, c.Reason, c.Detail)
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func enrolledChange(action EligibilityAction, ev *EnrolledVoter, reason, detail string) EligibilityChange {
+	evID, voterID := ev.ElectionVoterID, ev.VoterID
+	return EligibilityChange{
+		Action:          action,
+		VoterType:       ev.VoterType,
+		Identifier:      ev.NIM,
+		Name:            ev.Name,
+		Reason:          reason,
+		Detail:          detail,
+		ElectionVoterID: &evID,
+		VoterID:         &voterID,
+		Status:          ev.Status,
+	}
+}
+
+func sortChanges(changes []EligibilityChange) {
+	sort.Slice(changes, func(i, j int) bool {
+		return eligibilityKey(changes[i].VoterType, changes[i].Identifier) < eligibilityKey(changes[j].VoterType, changes[j].Identifier)
+	})
+}
+
+func eligibilityKey(voterType, identifier string) string {
+	return strings.ToUpper(voterType) + ":" + strings.TrimSpace(identifier)
+}
+
+// normalizeSet upper-cases, de-duplicates and sorts values; with allowed set
+// every value must be in it
+func normalizeSet(values []string, allowed map[string]struct{}) ([]string, error) {
+	seen := map[string]bool{}
+	out := []string{}
+	for _, v := range values {
+		v = strings.ToUpper(strings.TrimSpace(v))
+		if v == "" || seen[v] {
+			continue
+		}
+		if allowed != nil {
+			if _, ok := allowed[v]; !ok {
+				return nil, ErrEligibilityRulesInvalid
+			}
+		}
+		seen[v] = true
+		out = append(out, v)
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+func inSet(set []string, v string) bool {
+	if len(set) == 0 {
+		return true
+	}
+	for _, s := range set {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+// inRange fails a missing value only when the range is constrained
+func inRange(v, min, max *int) bool {
+	if min == nil && max == nil {
+		return true
+	}
+	if v == nil {
+		return false
+	}
+	return (min == nil || *v >= *min) && (max == nil || *v <= *max)
+}
+
+func intOrNil(v *int) interface{} {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
+
+// Roster supplies the academic roster eligibility rules are evaluated
+// against, removed entries included
+type Roster interface {
+	RosterEntries(ctx context.Context) ([]master.RosterEntry, error)
+}
+
+// SetRoster enables rule-based eligibility
+func (s *Service) SetRoster(r Roster) {
+	s.roster = r
+}
+
+// GetEligibilityRules returns the election's rules, or the defaults when
+// none were saved
+func (s *Service) GetEligibilityRules(ctx context.Context, electionID int64) (*EligibilityRules, error) {
+	rules, err := s.repo.GetEligibilityRules(ctx, electionID)
+	if errors.Is(err, shared.ErrNotFound) {
+		return DefaultEligibilityRules(electionID), nil
+	}
+	return rules, err
+}
+
+func (s *Service) UpdateEligibilityRules(ctx context.Context, electionID, adminID int64, in EligibilityRules) (*EligibilityRules, error) {
+	if err := in.Normalize(); err != nil {
+		return nil, err
+	}
+	in.ElectionID = electionID
+	in.UpdatedBy = &adminID
+	if err := s.repo.SaveEligibilityRules(ctx, &in); err != nil {
+		return nil, err
+	}
+	return &in, nil
+}
+
+// PreviewEligibility computes the DPT diff for review without changing it
+func (s *Service) PreviewEligibility(ctx context.Context, electionID int64) (*EligibilityDiff, error) {
+	if s.roster == nil {
+		return nil, ErrRosterNotConfigured
+	}
+	rules, err := s.GetEligibilityRules(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+	roster, err := s.roster.RosterEntries(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("load roster: %w", err)
+	}
+	enrolled, err := s.repo.ListEnrolledForEligibility(ctx, electionID)
+	if err != nil {
+		return nil, fmt.Errorf("load enrollments: %w", err)
+	}
+	return ComputeEligibilityDiff(*rules, roster, enrolled), nil
+}
+
+// ApplyEligibility recomputes the diff and applies its additions and
+// removals in one transaction, provided it still matches the previewed hash
+func (s *Service) ApplyEligibility(ctx context.Context, electionID, adminID int64, req EligibilityApplyRequest) (*EligibilityApplyRun, error) {
+	if strings.TrimSpace(req.DiffHash) == "" {
+		return nil, shared.ErrBadRequest
+	}
+	diff, err := s.PreviewEligibility(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+	if diff.Hash != strings.TrimSpace(req.DiffHash) {
+		return nil, ErrEligibilityDiffStale
+	}
+
+	run := &EligibilityApplyRun{
+		ElectionID: electionID,
+		DiffHash:   diff.Hash,
+		Rules:      diff.Rules,
+		Added:      len(diff.Add),
+		Removed:    len(diff.Remove),
+		Flagged:    len(diff.Flag),
+		AppliedBy:  &adminID,
+	}
+	if err := s.repo.ApplyEligibilityDiff(ctx, run, diff.addEntries, diff.removeIDs()); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
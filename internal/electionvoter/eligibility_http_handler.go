@@ -0,0 +1,111 @@
+package electionvoter
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"pemira-api/internal/auth"
+	"pemira-api/internal/errcatalog"
+	"pemira-api/internal/http/response"
+	"pemira-api/internal/shared"
+)
+
+// AdminGetEligibilityRules handles GET /admin/elections/{electionID}/voters/eligibility/rules
+func (h *Handler) AdminGetEligibilityRules(w http.ResponseWriter, r *http.Request) {
+	electionID, ok := parseID(w, chi.URLParam(r, "electionID"))
+	if !ok {
+		return
+	}
+
+	rules, err := h.svc.GetEligibilityRules(r.Context(), electionID)
+	if err != nil {
+		writeEligibilityError(w, err, eligibilityFetchFailed)
+		return
+	}
+	response.Success(w, http.StatusOK, rules)
+}
+
+// AdminUpdateEligibilityRules handles PUT /admin/elections/{electionID}/voters/eligibility/rules
+func (h *Handler) AdminUpdateEligibilityRules(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	electionID, ok := parseID(w, chi.URLParam(r, "electionID"))
+	if !ok {
+		return
+	}
+	authUser, ok := auth.FromContext(ctx)
+	if !ok {
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.InvalidToken)
+		return
+	}
+
+	var req EligibilityRules
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
+		return
+	}
+
+	rules, err := h.svc.UpdateEligibilityRules(ctx, electionID, authUser.ID, req)
+	if err != nil {
+		writeEligibilityError(w, err, eligibilitySaveFailed)
+		return
+	}
+	response.Success(w, http.StatusOK, rules)
+}
+
+// AdminPreviewEligibility handles GET /admin/elections/{electionID}/voters/eligibility/diff
+func (h *Handler) AdminPreviewEligibility(w http.ResponseWriter, r *http.Request) {
+	electionID, ok := parseID(w, chi.URLParam(r, "electionID"))
+	if !ok {
+		return
+	}
+
+	diff, err := h.svc.PreviewEligibility(r.Context(), electionID)
+	if err != nil {
+		writeEligibilityError(w, err, dptDiffFailed)
+		return
+	}
+	response.Success(w, http.StatusOK, diff)
+}
+
+// AdminApplyEligibility handles POST /admin/elections/{electionID}/voters/eligibility/apply
+func (h *Handler) AdminApplyEligibility(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	electionID, ok := parseID(w, chi.URLParam(r, "electionID"))
+	if !ok {
+		return
+	}
+	authUser, ok := auth.FromContext(ctx)
+	if !ok {
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.InvalidToken)
+		return
+	}
+
+	var req EligibilityApplyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
+		return
+	}
+
+	run, err := h.svc.ApplyEligibility(ctx, electionID, authUser.ID, req)
+	if err != nil {
+		writeEligibilityError(w, err, dptApplyFailed)
+		return
+	}
+	response.Success(w, http.StatusOK, run)
+}
+
+// writeEligibilityError writes the catalog entry of err (see errors.go), or
+// fallback when err is not a domain error
+func writeEligibilityError(w http.ResponseWriter, err error, fallback errcatalog.Entry) {
+	switch {
+	case errors.Is(err, shared.ErrNotFound):
+		response.NotFound(w, "ELECTION_NOT_FOUND", errcatalog.ElectionNotFound)
+	case errors.Is(err, shared.ErrBadRequest):
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Required("diff_hash"))
+	case !response.DomainError(w, err):
+		response.Catalog(w, fallback)
+	}
+}
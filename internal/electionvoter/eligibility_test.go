@@ -0,0 +1,130 @@
+package electionvoter
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"pemira-api/internal/master"
+)
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func rosterEntry(voterType, id, status string, cohort int) master.RosterEntry {
+	return master.RosterEntry{
+		VoterType:      voterType,
+		Identifier:     id,
+		Name:           "Nama " + id,
+		AcademicStatus: status,
+		CohortYear:     intPtr(cohort),
+		FacultyCode:    strPtr("FT"),
+	}
+}
+
+func TestEligibilityRulesNormalize(t *testing.T) {
+	rules := EligibilityRules{
+		VoterTypes:       []string{" student", "STUDENT", "lecturer"},
+		AcademicStatuses: []string{"active"},
+		FacultyCodes:     []string{"ft", " fmipa "},
+	}
+	if err := rules.Normalize(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rules.VoterTypes) != 2 || rules.VoterTypes[0] != "LECTURER" || rules.VoterTypes[1] != "STUDENT" {
+		t.Fatalf("unexpected voter types: %v", rules.VoterTypes)
+	}
+	if rules.FacultyCodes[0] != "FMIPA" || rules.FacultyCodes[1] != "FT" {
+		t.Fatalf("unexpected faculty codes: %v", rules.FacultyCodes)
+	}
+
+	invalid := []EligibilityRules{
+		{VoterTypes: []string{"ALUMNI"}},
+		{AcademicStatuses: []string{"PENSIUN"}},
+		{CohortYearMin: intPtr(2023), CohortYearMax: intPtr(2020)},
+		{SemesterMin: intPtr(0)},
+	}
+	for i, r := range invalid {
+		if err := r.Normalize(); !errors.Is(err, ErrEligibilityRulesInvalid) {
+			t.Fatalf("case %d: expected ErrEligibilityRulesInvalid, got %v", i, err)
+		}
+	}
+}
+
+func TestComputeEligibilityDiff(t *testing.T) {
+	removed := time.Now()
+	rules := EligibilityRules{
+		ElectionID:       1,
+		VoterTypes:       []string{"STUDENT"},
+		AcademicStatuses: []string{"ACTIVE"},
+		CohortYearMin:    intPtr(2019),
+	}
+
+	graduatedRemoved := rosterEntry("STUDENT", "104", "ACTIVE", 2020)
+	graduatedRemoved.RemovedAt = &removed
+
+	roster := []master.RosterEntry{
+		rosterEntry("STUDENT", "101", "ACTIVE", 2021),   // eligible, not enrolled -> add
+		rosterEntry("STUDENT", "102", "ACTIVE", 2021),   // eligible, enrolled -> unchanged
+		rosterEntry("STUDENT", "103", "ON_LEAVE", 2021), // ineligible, enrolled -> remove
+		graduatedRemoved, // removed from roster, voted -> flag
+		rosterEntry("STUDENT", "105", "ACTIVE", 2015), // cohort too old, not enrolled -> nothing
+		rosterEntry("LECTURER", "201", "ACTIVE", 0),   // wrong type, not enrolled -> nothing
+	}
+	enrolled := []EnrolledVoter{
+		{ElectionVoterID: 12, VoterID: 2, NIM: "102", VoterType: "STUDENT"},
+		{ElectionVoterID: 13, VoterID: 3, NIM: "103", VoterType: "STUDENT"},
+		{ElectionVoterID: 14, VoterID: 4, NIM: "104", VoterType: "STUDENT", Voted: true},
+		{ElectionVoterID: 19, VoterID: 9, NIM: "109", VoterType: "STUDENT"}, // unknown to roster -> flag
+		{ElectionVoterID: 30, VoterID: 7, NIM: "301", VoterType: "STAFF"},   // type excluded -> remove
+	}
+
+	diff := ComputeEligibilityDiff(rules, roster, enrolled)
+
+	if len(diff.Add) != 1 || diff.Add[0].Identifier != "101" {
+		t.Fatalf("unexpected add: %+v", diff.Add)
+	}
+	if len(diff.addEntries) != 1 || diff.addEntries[0].Identifier != "101" {
+		t.Fatalf("unexpected add entries: %+v", diff.addEntries)
+	}
+	if len(diff.Remove) != 2 || diff.Remove[0].Identifier != "301" || diff.Remove[0].Reason != ReasonVoterType ||
+		diff.Remove[1].Identifier != "103" || diff.Remove[1].Reason != ReasonAcademicStatus {
+		t.Fatalf("unexpected remove: %+v", diff.Remove)
+	}
+	if ids := diff.removeIDs(); len(ids) != 2 || ids[0] != 30 || ids[1] != 13 {
+		t.Fatalf("unexpected remove ids: %v", ids)
+	}
+	if len(diff.Flag) != 2 ||
+		diff.Flag[0].Identifier != "104" || diff.Flag[0].Reason != ReasonAlreadyVoted || diff.Flag[0].Detail != ReasonRosterRemoved ||
+		diff.Flag[1].Identifier != "109" || diff.Flag[1].Reason != ReasonNotInRoster {
+		t.Fatalf("unexpected flag: %+v", diff.Flag)
+	}
+	if diff.Summary.Eligible != 2 || diff.Summary.Unchanged != 1 || diff.Summary.Enrolled != 5 {
+		t.Fatalf("unexpected summary: %+v", diff.Summary)
+	}
+
+	again := ComputeEligibilityDiff(rules, roster, enrolled)
+	if again.Hash != diff.Hash {
+		t.Fatal("expected identical inputs to hash the same")
+	}
+
+	enrolled[1].CheckedIn = true
+	changed := ComputeEligibilityDiff(rules, roster, enrolled)
+	if changed.Hash == diff.Hash {
+		t.Fatal("expected hash to change when a removal becomes a flag")
+	}
+}
+
+func TestEligibilityRulesFacultyAppliesToAllTypes(t *testing.T) {
+	rules := EligibilityRules{FacultyCodes: []string{"FMIPA"}, AcademicStatuses: []string{"ACTIVE"}}
+
+	lecturer := rosterEntry("LECTURER", "201", "ACTIVE", 0)
+	if reason := rules.Evaluate(lecturer); reason != ReasonFaculty {
+		t.Fatalf("expected %s, got %q", ReasonFaculty, reason)
+	}
+	lecturer.FacultyCode = strPtr("fmipa")
+	if reason := rules.Evaluate(lecturer); reason != "" {
+		t.Fatalf("expected eligible, got %q", reason)
+	}
+}
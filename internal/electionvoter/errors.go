@@ -0,0 +1,17 @@
+package electionvoter
+
+import (
+	"net/http"
+
+	"pemira-api/internal/errcatalog"
+)
+
+// The API codes and messages of the eligibility errors;
+// writeEligibilityError writes them
+func init() {
+	errcatalog.Register(
+		errcatalog.Entry{Err: ErrEligibilityRulesInvalid, Code: "VALIDATION_ERROR", Status: http.StatusBadRequest, ID: "Aturan kelayakan DPT tidak valid.", EN: "Invalid eligibility rules."},
+		errcatalog.Entry{Err: ErrEligibilityDiffStale, Code: "ELIGIBILITY_DIFF_STALE", Status: http.StatusConflict, ID: "DPT atau roster berubah sejak pratinjau; hitung ulang perubahan sebelum menerapkan.", EN: "The DPT or roster changed since the preview; recompute the changes before applying them."},
+		errcatalog.Entry{Err: ErrRosterNotConfigured, Code: "ROSTER_NOT_CONFIGURED", Status: http.StatusNotFound, ID: "Roster akademik belum dikonfigurasi.", EN: "The academic roster is not configured."},
+	)
+}
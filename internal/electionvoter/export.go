@@ -13,6 +13,7 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/xuri/excelize/v2"
 
+	"pemira-api/internal/errcatalog"
 	"pemira-api/internal/http/response"
 )
 
@@ -23,7 +24,7 @@ func (h *Handler) ExportToExcel(w http.ResponseWriter, r *http.Request) {
 	electionIDStr := chi.URLParam(r, "electionID")
 	electionID, err := strconv.ParseInt(electionIDStr, 10, 64)
 	if err != nil || electionID <= 0 {
-		response.BadRequest(w, "INVALID_REQUEST", "electionID tidak valid.")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.Invalid("electionID"))
 		return
 	}
 
@@ -42,7 +43,7 @@ func (h *Handler) ExportToExcel(w http.ResponseWriter, r *http.Request) {
 	limit := 10000
 	voters, _, err := h.svc.List(ctx, electionID, filter, page, limit)
 	if err != nil {
-		response.InternalServerError(w, "INTERNAL_ERROR", "Gagal mengambil data DPT.")
+		response.Catalog(w, dptExportFetchFailed)
 		return
 	}
 
@@ -167,7 +168,7 @@ func (h *Handler) ExportToExcel(w http.ResponseWriter, r *http.Request) {
 
 	// Write to response
 	if err := f.Write(w); err != nil {
-		response.InternalServerError(w, "INTERNAL_ERROR", "Gagal menulis file Excel.")
+		response.Catalog(w, excelWriteFailed)
 		return
 	}
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/go-chi/chi/v5"
 
 	"pemira-api/internal/auth"
+	"pemira-api/internal/errcatalog"
 	"pemira-api/internal/http/response"
 	"pemira-api/internal/shared"
 )
@@ -30,17 +31,17 @@ func (h *Handler) AdminLookup(w http.ResponseWriter, r *http.Request) {
 	}
 	nim := strings.TrimSpace(r.URL.Query().Get("nim"))
 	if nim == "" {
-		response.BadRequest(w, "VALIDATION_ERROR", "parameter nim wajib diisi")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Required("nim"))
 		return
 	}
 
 	res, err := h.svc.LookupByNIM(ctx, electionID, nim)
 	if err != nil {
 		if err == shared.ErrNotFound {
-			response.NotFound(w, "NOT_FOUND", "Pemilih dengan NIM tersebut tidak ditemukan")
+			response.Catalog(w, nimNotFound)
 			return
 		}
-		response.InternalServerError(w, "INTERNAL_ERROR", "Gagal mengambil data pemilih")
+		response.Catalog(w, voterFetchFailed)
 		return
 	}
 
@@ -57,7 +58,7 @@ func (h *Handler) AdminUpsert(w http.ResponseWriter, r *http.Request) {
 
 	var req UpsertAndEnrollInput
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.BadRequest(w, "VALIDATION_ERROR", "Body tidak valid")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
 		return
 	}
 
@@ -65,15 +66,15 @@ func (h *Handler) AdminUpsert(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		switch err {
 		case shared.ErrBadRequest:
-			response.BadRequest(w, "VALIDATION_ERROR", "Data wajib diisi atau tidak valid")
+			response.Catalog(w, voterInvalid)
 			return
 		case shared.ErrDuplicateEntry:
-			response.Conflict(w, "DUPLICATE", "NIM sudah terdaftar di pemilu ini")
+			response.Catalog(w, nimAlreadyRegistered)
 			return
 		default:
 			// Log actual error for debugging
 			println("DEBUG AdminUpsert error:", err.Error())
-			response.InternalServerError(w, "INTERNAL_ERROR", "Gagal menyimpan data pemilih")
+			response.Catalog(w, voterSaveFailed)
 			return
 		}
 	}
@@ -109,10 +110,15 @@ func (h *Handler) AdminList(w http.ResponseWriter, r *http.Request) {
 			filter.TPSID = &v
 		}
 	}
+	if ev := q.Get("email_verified"); ev != "" {
+		if v, err := strconv.ParseBool(ev); err == nil {
+			filter.EmailVerified = &v
+		}
+	}
 
 	filter, err := ValidateFilter(filter)
 	if err != nil {
-		response.BadRequest(w, "VALIDATION_ERROR", "Filter tidak valid")
+		response.Catalog(w, voterFilterInvalid)
 		return
 	}
 
@@ -121,7 +127,7 @@ func (h *Handler) AdminList(w http.ResponseWriter, r *http.Request) {
 
 	items, meta, err := h.svc.List(ctx, electionID, filter, page, limit)
 	if err != nil {
-		response.InternalServerError(w, "INTERNAL_ERROR", "Gagal mengambil daftar pemilih")
+		response.Catalog(w, voterListFailed)
 		return
 	}
 
@@ -149,7 +155,7 @@ func (h *Handler) AdminPatch(w http.ResponseWriter, r *http.Request) {
 
 	var req UpdateInput
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.BadRequest(w, "VALIDATION_ERROR", "Body tidak valid")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
 		return
 	}
 
@@ -157,13 +163,13 @@ func (h *Handler) AdminPatch(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		switch err {
 		case shared.ErrBadRequest:
-			response.BadRequest(w, "VALIDATION_ERROR", "status / voting_method tidak valid")
+			response.Catalog(w, voterStatusInvalid)
 			return
 		case shared.ErrNotFound:
-			response.NotFound(w, "NOT_FOUND", "Data pemilih tidak ditemukan")
+			response.Catalog(w, voterNotFound)
 			return
 		default:
-			response.InternalServerError(w, "INTERNAL_ERROR", "Gagal memperbarui data")
+			response.Catalog(w, voterUpdateFailed)
 			return
 		}
 	}
@@ -176,7 +182,7 @@ func (h *Handler) VoterSelfRegister(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	authUser, ok := auth.FromContext(ctx)
 	if !ok || authUser.VoterID == nil {
-		response.Forbidden(w, "FORBIDDEN", "Akses tidak diizinkan")
+		response.Forbidden(w, "FORBIDDEN", errcatalog.AccessDenied)
 		return
 	}
 
@@ -187,7 +193,7 @@ func (h *Handler) VoterSelfRegister(w http.ResponseWriter, r *http.Request) {
 
 	var req SelfRegisterInput
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.BadRequest(w, "VALIDATION_ERROR", "Body tidak valid")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
 		return
 	}
 
@@ -195,16 +201,16 @@ func (h *Handler) VoterSelfRegister(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		switch err {
 		case shared.ErrDuplicateEntry:
-			response.Conflict(w, "DUPLICATE", "NIM sudah terdaftar di pemilu ini")
+			response.Catalog(w, nimAlreadyRegistered)
 			return
 		case shared.ErrBadRequest:
-			response.BadRequest(w, "VALIDATION_ERROR", "Metode voting tidak valid")
+			response.Catalog(w, votingMethodInvalid)
 			return
 		case shared.ErrNotFound:
-			response.NotFound(w, "NOT_FOUND", "Voter tidak ditemukan")
+			response.NotFound(w, "NOT_FOUND", errcatalog.VoterNotFound)
 			return
 		default:
-			response.InternalServerError(w, "INTERNAL_ERROR", "Gagal mendaftarkan pemilih")
+			response.Catalog(w, selfRegisterFailed)
 			return
 		}
 	}
@@ -217,7 +223,7 @@ func (h *Handler) VoterStatus(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	authUser, ok := auth.FromContext(ctx)
 	if !ok || authUser.VoterID == nil {
-		response.Forbidden(w, "FORBIDDEN", "Akses tidak diizinkan")
+		response.Forbidden(w, "FORBIDDEN", errcatalog.AccessDenied)
 		return
 	}
 
@@ -229,10 +235,10 @@ func (h *Handler) VoterStatus(w http.ResponseWriter, r *http.Request) {
 	ev, err := h.svc.GetStatus(ctx, electionID, *authUser.VoterID)
 	if err != nil {
 		if err == shared.ErrNotFound {
-			response.NotFound(w, "NOT_FOUND", "Belum terdaftar di pemilu ini")
+			response.Catalog(w, notRegistered)
 			return
 		}
-		response.InternalServerError(w, "INTERNAL_ERROR", "Gagal mengambil status pemilih")
+		response.Catalog(w, voterStatusFetchFailed)
 		return
 	}
 
@@ -253,19 +259,17 @@ func (h *Handler) AdminBlacklist(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Parse optional reason from body
-	var req struct {
-		Reason string `json:"reason"`
-	}
+	var req BlacklistInput
 	json.NewDecoder(r.Body).Decode(&req)
 
 	err := h.svc.BlacklistVoter(ctx, electionID, voterID, req.Reason)
 	if err != nil {
 		switch err {
 		case shared.ErrNotFound:
-			response.NotFound(w, "NOT_FOUND", "Pemilih tidak ditemukan")
+			response.NotFound(w, "NOT_FOUND", errcatalog.VoterNotFound)
 			return
 		default:
-			response.InternalServerError(w, "INTERNAL_ERROR", "Gagal mem-blacklist pemilih")
+			response.Catalog(w, blacklistFailed)
 			return
 		}
 	}
@@ -292,10 +296,10 @@ func (h *Handler) AdminUnblacklist(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		switch err {
 		case shared.ErrNotFound:
-			response.NotFound(w, "NOT_FOUND", "Pemilih tidak ditemukan")
+			response.NotFound(w, "NOT_FOUND", errcatalog.VoterNotFound)
 			return
 		default:
-			response.InternalServerError(w, "INTERNAL_ERROR", "Gagal menghapus blacklist pemilih")
+			response.Catalog(w, unblacklistFailed)
 			return
 		}
 	}
@@ -308,7 +312,7 @@ func (h *Handler) AdminUnblacklist(w http.ResponseWriter, r *http.Request) {
 func parseID(w http.ResponseWriter, raw string) (int64, bool) {
 	id, err := strconv.ParseInt(raw, 10, 64)
 	if err != nil || id <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "ID tidak valid")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidID)
 		return 0, false
 	}
 	return id, true
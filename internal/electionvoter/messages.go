@@ -0,0 +1,34 @@
+package electionvoter
+
+import (
+	"net/http"
+
+	"pemira-api/internal/errcatalog"
+)
+
+// Messages the election voter handlers answer with, each under its own code in the
+// error catalog
+var (
+	eligibilityFetchFailed = errcatalog.Define("ELIGIBILITY_FETCH_FAILED", http.StatusInternalServerError, "Gagal mengambil aturan kelayakan", "Failed to fetch the eligibility rules")
+	eligibilitySaveFailed  = errcatalog.Define("ELIGIBILITY_SAVE_FAILED", http.StatusInternalServerError, "Gagal menyimpan aturan kelayakan", "Failed to save the eligibility rules")
+	dptDiffFailed          = errcatalog.Define("DPT_DIFF_FAILED", http.StatusInternalServerError, "Gagal menghitung perubahan DPT", "Failed to compute the DPT changes")
+	dptApplyFailed         = errcatalog.Define("DPT_APPLY_FAILED", http.StatusInternalServerError, "Gagal menerapkan perubahan DPT", "Failed to apply the DPT changes")
+	dptExportFetchFailed   = errcatalog.Define("DPT_EXPORT_FETCH_FAILED", http.StatusInternalServerError, "Gagal mengambil data DPT.", "Failed to fetch the DPT.")
+	excelWriteFailed       = errcatalog.Define("EXCEL_WRITE_FAILED", http.StatusInternalServerError, "Gagal menulis file Excel.", "Failed to write the Excel file.")
+	nimNotFound            = errcatalog.Define("NIM_NOT_FOUND", http.StatusNotFound, "Pemilih dengan NIM tersebut tidak ditemukan", "No voter with that NIM")
+	voterFetchFailed       = errcatalog.Define("ELECTION_VOTER_FETCH_FAILED", http.StatusInternalServerError, "Gagal mengambil data pemilih", "Failed to fetch the voter")
+	voterInvalid           = errcatalog.Define("ELECTION_VOTER_INVALID", http.StatusBadRequest, "Data wajib diisi atau tidak valid", "Data is missing or invalid")
+	nimAlreadyRegistered   = errcatalog.Define("NIM_ALREADY_REGISTERED", http.StatusConflict, "NIM sudah terdaftar di pemilu ini", "The NIM is already registered in this election")
+	voterSaveFailed        = errcatalog.Define("ELECTION_VOTER_SAVE_FAILED", http.StatusInternalServerError, "Gagal menyimpan data pemilih", "Failed to save the voter")
+	voterFilterInvalid     = errcatalog.Define("VOTER_FILTER_INVALID", http.StatusBadRequest, "Filter tidak valid", "Invalid filter")
+	voterListFailed        = errcatalog.Define("ELECTION_VOTER_LIST_FAILED", http.StatusInternalServerError, "Gagal mengambil daftar pemilih", "Failed to fetch voters")
+	voterStatusInvalid     = errcatalog.Define("VOTER_STATUS_INVALID", http.StatusBadRequest, "status / voting_method tidak valid", "Invalid status / voting_method")
+	voterNotFound          = errcatalog.Define("ELECTION_VOTER_NOT_FOUND", http.StatusNotFound, "Data pemilih tidak ditemukan", "Voter data not found")
+	voterUpdateFailed      = errcatalog.Define("ELECTION_VOTER_UPDATE_FAILED", http.StatusInternalServerError, "Gagal memperbarui data", "Failed to update the data")
+	votingMethodInvalid    = errcatalog.Define("VOTING_METHOD_INVALID", http.StatusBadRequest, "Metode voting tidak valid", "Invalid voting method")
+	selfRegisterFailed     = errcatalog.Define("SELF_REGISTER_FAILED", http.StatusInternalServerError, "Gagal mendaftarkan pemilih", "Failed to register the voter")
+	notRegistered          = errcatalog.Define("NOT_REGISTERED_IN_ELECTION", http.StatusNotFound, "Belum terdaftar di pemilu ini", "Not registered in this election")
+	voterStatusFetchFailed = errcatalog.Define("VOTER_STATUS_FETCH_FAILED", http.StatusInternalServerError, "Gagal mengambil status pemilih", "Failed to fetch the voter status")
+	blacklistFailed        = errcatalog.Define("BLACKLIST_FAILED", http.StatusInternalServerError, "Gagal mem-blacklist pemilih", "Failed to blacklist the voter")
+	unblacklistFailed      = errcatalog.Define("UNBLACKLIST_FAILED", http.StatusInternalServerError, "Gagal menghapus blacklist pemilih", "Failed to remove the voter from the blacklist")
+)
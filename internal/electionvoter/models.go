@@ -16,6 +16,8 @@ type VoterSummary struct {
 	LecturerID       *int64  `json:"lecturer_id,omitempty"`
 	StaffID          *int64  `json:"staff_id,omitempty"`
 	VotingMethod     *string `json:"voting_method,omitempty"`
+
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
 }
 
 type ElectionVoter struct {
@@ -43,6 +45,12 @@ type ElectionVoter struct {
 	LastLoginAt         *time.Time `json:"last_login_at,omitempty"`
 	DigitalSignatureURL *string    `json:"digital_signature_url,omitempty"`
 	IsBlacklisted       bool       `json:"is_blacklisted"`
+
+	// Email verification state: verified when EmailVerifiedAt is set,
+	// pending while the self-registered account awaits its code
+	EmailVerified            bool       `json:"email_verified"`
+	EmailVerifiedAt          *time.Time `json:"email_verified_at,omitempty"`
+	EmailVerificationPending bool       `json:"email_verification_pending"`
 }
 
 type LookupResult struct {
@@ -90,6 +98,7 @@ type ListFilter struct {
 	StudyProgramCode string
 	CohortYear       *int
 	TPSID            *int64
+	EmailVerified    *bool
 }
 
 type UpdateInput struct {
@@ -103,3 +112,8 @@ type SelfRegisterInput struct {
 	VotingMethod string `json:"voting_method"`
 	TPSID        *int64 `json:"tps_id,omitempty"`
 }
+
+// BlacklistInput is the optional body of a blacklist request
+type BlacklistInput struct {
+	Reason string `json:"reason"`
+}
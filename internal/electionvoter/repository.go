@@ -3,6 +3,7 @@ package electionvoter
 import (
 	"context"
 
+	"pemira-api/internal/master"
 	"pemira-api/internal/shared"
 )
 
@@ -15,4 +16,13 @@ type Repository interface {
 	GetStatus(ctx context.Context, electionID int64, voterID int64) (*ElectionVoter, error)
 	BlacklistVoter(ctx context.Context, electionID, voterID int64, reason string) error
 	UnblacklistVoter(ctx context.Context, electionID, voterID int64) error
+
+	// Rule-based eligibility
+	GetEligibilityRules(ctx context.Context, electionID int64) (*EligibilityRules, error)
+	SaveEligibilityRules(ctx context.Context, rules *EligibilityRules) error
+	ListEnrolledForEligibility(ctx context.Context, electionID int64) ([]EnrolledVoter, error)
+	// ApplyEligibilityDiff enrolls the added roster entries and removes the
+	// enrollments in one transaction, returning ErrEligibilityDiffStale when
+	// any of them no longer applies.
+	ApplyEligibilityDiff(ctx context.Context, run *EligibilityApplyRun, add []master.RosterEntry, removeEnrollmentIDs []int64) error
 }
@@ -57,7 +57,7 @@ func (r *pgRepository) LookupByNIM(ctx context.Context, electionID int64, nim st
 			ev.checked_in_at,
 			ev.voted_at,
 			ev.updated_at,
-			v.name
+			v.email_verified_at
 		FROM voters v
 		LEFT JOIN user_accounts ua ON ua.voter_id = v.id
 		LEFT JOIN election_voters ev ON ev.voter_id = v.id AND ev.election_id = $2
@@ -106,6 +106,7 @@ func (r *pgRepository) LookupByNIM(ctx context.Context, electionID int64, nim st
 		&evCheckedIn,
 		&evVotedAt,
 		&evUpdatedAt,
+		&voter.EmailVerifiedAt,
 	)
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -142,6 +143,9 @@ func (r *pgRepository) LookupByNIM(ctx context.Context, electionID int64, nim st
 			FacultyCode:  voter.FacultyCode,
 			StudyProgram: voter.StudyProgramCode,
 			CohortYear:   voter.CohortYear,
+
+			EmailVerified:   voter.EmailVerifiedAt != nil,
+			EmailVerifiedAt: voter.EmailVerifiedAt,
 		}
 	}
 
@@ -527,6 +531,13 @@ func (r *pgRepository) List(ctx context.Context, electionID int64, filter ListFi
 		where = append(where, fmt.Sprintf("ev.tps_id = $%d", len(args)+1))
 		args = append(args, *filter.TPSID)
 	}
+	if filter.EmailVerified != nil {
+		if *filter.EmailVerified {
+			where = append(where, "v.email_verified_at IS NOT NULL")
+		} else {
+			where = append(where, "v.email_verified_at IS NULL")
+		}
+	}
 
 	whereClause := "WHERE " + strings.Join(where, " AND ")
 
@@ -563,7 +574,9 @@ func (r *pgRepository) List(ctx context.Context, electionID int64, filter ListFi
 			vs.has_voted,
 			ua.last_login_at,
 			vs.digital_signature_url,
-			NOT COALESCE(ua.is_active, true) AS is_blacklisted
+			NOT COALESCE(ua.is_active, true) AS is_blacklisted,
+			v.email_verified_at,
+			COALESCE(ua.email_verification_pending, false)
 		FROM election_voters ev
 		JOIN voters v ON v.id = ev.voter_id
 		LEFT JOIN voter_status vs ON vs.election_id = ev.election_id AND vs.voter_id = ev.voter_id
@@ -620,10 +633,13 @@ func (r *pgRepository) List(ctx context.Context, electionID int64, filter ListFi
 			&lastLoginAt,
 			&digitalSignatureURL,
 			&isBlacklisted,
+			&item.EmailVerifiedAt,
+			&item.EmailVerificationPending,
 		)
 		if err != nil {
 			return nil, 0, fmt.Errorf("scan election_voters: %w", err)
 		}
+		item.EmailVerified = item.EmailVerifiedAt != nil
 		item.Email = nullableStringPtr(email)
 		item.FacultyCode = nullableStringPtr(facultyCode)
 		item.FacultyName = nullableStringPtr(facultyName)
@@ -662,6 +678,8 @@ func (r *pgRepository) UpdateEnrollment(ctx context.Context, electionID int64, e
 	if in.TPSID != nil {
 		setParts = append(setParts, fmt.Sprintf("tps_id = $%d", len(args)+1))
 		args = append(args, *in.TPSID)
+		// An admin-chosen TPS is kept by TPS allocation rebalancing
+		setParts = append(setParts, "tps_assignment = 'MANUAL'", "tps_assigned_at = NOW()")
 	}
 
 	if len(setParts) == 0 {
@@ -802,7 +820,8 @@ func (r *pgRepository) GetStatus(ctx context.Context, electionID int64, voterID
 			ev.id, ev.election_id, ev.voter_id, ev.nim,
 			ev.status, ev.voting_method, ev.tps_id,
 			ev.checked_in_at, ev.voted_at, ev.updated_at,
-			v.voter_type, v.name, v.email, v.faculty_code, v.study_program_code, v.cohort_year
+			v.voter_type, v.name, v.email, v.faculty_code, v.study_program_code, v.cohort_year,
+			v.email_verified_at
 		FROM election_voters ev
 		JOIN voters v ON v.id = ev.voter_id
 		WHERE ev.election_id = $1 AND ev.voter_id = $2
@@ -832,6 +851,7 @@ func (r *pgRepository) GetStatus(ctx context.Context, electionID int64, voterID
 		&facultyCode,
 		&studyProgram,
 		&cohortYear,
+		&ev.EmailVerifiedAt,
 	)
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -839,6 +859,7 @@ func (r *pgRepository) GetStatus(ctx context.Context, electionID int64, voterID
 		}
 		return nil, fmt.Errorf("get election_voter status: %w", err)
 	}
+	ev.EmailVerified = ev.EmailVerifiedAt != nil
 
 	ev.Email = nullableStringPtr(email)
 	ev.FacultyCode = nullableStringPtr(facultyCode)
@@ -888,7 +909,7 @@ func nullableTimePtr(nt sql.NullTime) *time.Time {
 	return nil
 }
 
-// BlacklistVoter deactivates the user account for a voter, cancels their vote, and updates status
+// BlacklistVoter deactivates the user account for a voter and blocks them in the DPT
 func (r *pgRepository) BlacklistVoter(ctx context.Context, electionID, voterID int64, reason string) error {
 	// First, get the voter_id from election_voters
 	var actualVoterID int64
@@ -919,31 +940,8 @@ func (r *pgRepository) BlacklistVoter(ctx context.Context, electionID, voterID i
 		return shared.ErrNotFound
 	}
 
-	// Get vote_token_hash from voter_status to find and delete the vote
-	var tokenHash sql.NullString
-	r.db.QueryRow(ctx, `
-		SELECT vote_token_hash FROM voter_status 
-		WHERE election_id = $1 AND voter_id = $2
-	`, electionID, actualVoterID).Scan(&tokenHash)
-
-	// Delete the vote if vote_token_hash exists
-	if tokenHash.Valid && tokenHash.String != "" {
-		r.db.Exec(ctx, `
-			DELETE FROM votes 
-			WHERE election_id = $1 AND token_hash = $2
-		`, electionID, tokenHash.String)
-	}
-
-	// Reset voter_status (clear vote record)
-	r.db.Exec(ctx, `
-		UPDATE voter_status 
-		SET has_voted = false, 
-		    voted_at = NULL, 
-		    voting_method = NULL,
-		    vote_token_hash = NULL,
-		    updated_at = NOW() 
-		WHERE election_id = $1 AND voter_id = $2
-	`, electionID, actualVoterID)
+	// A cast ballot cannot be found from the voter's records, so it stays in
+	// the tally and voter_status keeps has_voted to stop a second vote.
 
 	// Update election_voters status to BLOCKED
 	_, err = r.db.Exec(ctx, `
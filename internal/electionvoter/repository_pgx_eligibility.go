@@ -0,0 +1,285 @@
+package electionvoter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"pemira-api/internal/master"
+	"pemira-api/internal/shared"
+)
+
+func (r *pgRepository) GetEligibilityRules(ctx context.Context, electionID int64) (*EligibilityRules, error) {
+	var rules EligibilityRules
+	err := r.db.QueryRow(ctx, `
+		SELECT election_id, voter_types, academic_statuses,
+		       cohort_year_min, cohort_year_max, semester_min, semester_max,
+		       faculty_codes, updated_by, updated_at
+		FROM election_eligibility_rules
+		WHERE election_id = $1
+	`, electionID).Scan(
+		&rules.ElectionID, &rules.VoterTypes, &rules.AcademicStatuses,
+		&rules.CohortYearMin, &rules.CohortYearMax, &rules.SemesterMin, &rules.SemesterMax,
+		&rules.FacultyCodes, &rules.UpdatedBy, &rules.UpdatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, shared.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get eligibility rules: %w", err)
+	}
+	return &rules, nil
+}
+
+func (r *pgRepository) SaveEligibilityRules(ctx context.Context, rules *EligibilityRules) error {
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO election_eligibility_rules (
+			election_id, voter_types, academic_statuses,
+			cohort_year_min, cohort_year_max, semester_min, semester_max,
+			faculty_codes, updated_by
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (election_id) DO UPDATE SET
+			voter_types = EXCLUDED.voter_types,
+			academic_statuses = EXCLUDED.academic_statuses,
+			cohort_year_min = EXCLUDED.cohort_year_min,
+			cohort_year_max = EXCLUDED.cohort_year_max,
+			semester_min = EXCLUDED.semester_min,
+			semester_max = EXCLUDED.semester_max,
+			faculty_codes = EXCLUDED.faculty_codes,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = NOW()
+		RETURNING updated_at
+	`, rules.ElectionID, rules.VoterTypes, rules.AcademicStatuses,
+		rules.CohortYearMin, rules.CohortYearMax, rules.SemesterMin, rules.SemesterMax,
+		rules.FacultyCodes, rules.UpdatedBy,
+	).Scan(&rules.UpdatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return shared.ErrNotFound
+		}
+		return fmt.Errorf("save eligibility rules: %w", err)
+	}
+	return nil
+}
+
+func (r *pgRepository) ListEnrolledForEligibility(ctx context.Context, electionID int64) ([]EnrolledVoter, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT ev.id, ev.voter_id, ev.nim, COALESCE(v.voter_type, 'STUDENT'), v.name, ev.status::text,
+		       ev.checked_in_at IS NOT NULL,
+		       ev.voted_at IS NOT NULL OR COALESCE(vs.has_voted, FALSE)
+		FROM election_voters ev
+		JOIN voters v ON v.id = ev.voter_id
+		LEFT JOIN voter_status vs ON vs.election_id = ev.election_id AND vs.voter_id = ev.voter_id
+		WHERE ev.election_id = $1
+	`, electionID)
+	if err != nil {
+		return nil, fmt.Errorf("list enrollments: %w", err)
+	}
+	defer rows.Close()
+
+	var out []EnrolledVoter
+	for rows.Next() {
+		var ev EnrolledVoter
+		if err := rows.Scan(&ev.ElectionVoterID, &ev.VoterID, &ev.NIM, &ev.VoterType, &ev.Name, &ev.Status, &ev.CheckedIn, &ev.Voted); err != nil {
+			return nil, err
+		}
+		out = append(out, ev)
+	}
+	return out, rows.Err()
+}
+
+func (r *pgRepository) ApplyEligibilityDiff(ctx context.Context, run *EligibilityApplyRun, add []master.RosterEntry, removeEnrollmentIDs []int64) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// One apply per election at a time
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('eligibility_apply'), $1::int)`, run.ElectionID); err != nil {
+		return fmt.Errorf("lock election: %w", err)
+	}
+
+	for _, e := range add {
+		voterID, err := r.saveRosterVoter(ctx, tx, e)
+		if err != nil {
+			return err
+		}
+
+		var enrollmentID int64
+		err = tx.QueryRow(ctx, `
+			INSERT INTO election_voters (election_id, voter_id, nim, status, voting_method)
+			VALUES ($1, $2, $3, 'VERIFIED', 'ONLINE')
+			ON CONFLICT DO NOTHING
+			RETURNING id
+		`, run.ElectionID, voterID, e.Identifier).Scan(&enrollmentID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrEligibilityDiffStale
+		}
+		if err != nil {
+			return fmt.Errorf("enroll %s: %w", e.Identifier, err)
+		}
+
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO voter_status (election_id, voter_id, is_eligible, has_voted)
+			VALUES ($1, $2, TRUE, FALSE)
+			ON CONFLICT (election_id, voter_id) DO UPDATE SET is_eligible = TRUE, updated_at = NOW()
+		`, run.ElectionID, voterID); err != nil {
+			return fmt.Errorf("voter status %s: %w", e.Identifier, err)
+		}
+	}
+
+	if len(removeEnrollmentIDs) > 0 {
+		rows, err := tx.Query(ctx, `
+			DELETE FROM election_voters ev
+			WHERE ev.election_id = $1
+			  AND ev.id = ANY($2)
+			  AND ev.status <> 'VOTED'
+			  AND ev.voted_at IS NULL
+			  AND ev.checked_in_at IS NULL
+			  AND NOT EXISTS (
+			      SELECT 1 FROM voter_status vs
+			      WHERE vs.election_id = ev.election_id AND vs.voter_id = ev.voter_id AND vs.has_voted
+			  )
+			RETURNING ev.voter_id
+		`, run.ElectionID, removeEnrollmentIDs)
+		if err != nil {
+			return fmt.Errorf("remove enrollments: %w", err)
+		}
+		voterIDs, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+		if err != nil {
+			return fmt.Errorf("remove enrollments: %w", err)
+		}
+		if len(voterIDs) != len(removeEnrollmentIDs) {
+			return ErrEligibilityDiffStale
+		}
+
+		if _, err := tx.Exec(ctx, `
+			UPDATE voter_status SET is_eligible = FALSE, updated_at = NOW()
+			WHERE election_id = $1 AND voter_id = ANY($2) AND NOT has_voted
+		`, run.ElectionID, voterIDs); err != nil {
+			return fmt.Errorf("revoke voter status: %w", err)
+		}
+	}
+
+	rules, err := json.Marshal(run.Rules)
+	if err != nil {
+		return err
+	}
+	err = tx.QueryRow(ctx, `
+		INSERT INTO eligibility_apply_runs (election_id, diff_hash, rules, added, removed, flagged, applied_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, applied_at
+	`, run.ElectionID, run.DiffHash, rules, run.Added, run.Removed, run.Flagged, run.AppliedBy).Scan(&run.ID, &run.AppliedAt)
+	if err != nil {
+		return fmt.Errorf("record eligibility apply: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+	return nil
+}
+
+// saveRosterVoter finds or creates the voter for a roster entry, creating
+// the student, lecturer or staff identity as needed. Existing contact
+// details are kept; academic fields follow the roster.
+func (r *pgRepository) saveRosterVoter(ctx context.Context, tx pgx.Tx, e master.RosterEntry) (int64, error) {
+	identity := identityRefs{}
+	switch e.VoterType {
+	case master.RosterStudent:
+		id, err := r.upsertStudentIdentity(ctx, tx, e.Identifier, UpsertAndEnrollInput{
+			Name:             e.Name,
+			FacultyCode:      e.FacultyCode,
+			StudyProgramCode: e.StudyProgramCode,
+			CohortYear:       e.CohortYear,
+		})
+		if err != nil {
+			return 0, err
+		}
+		identity.Column, identity.StudentID = identityColumnStudent, &id
+	case master.RosterLecturer:
+		var id int64
+		err := tx.QueryRow(ctx, `
+			INSERT INTO lecturers (nidn, name, email, faculty_code, faculty_name)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (nidn) DO UPDATE SET
+				name = EXCLUDED.name,
+				faculty_code = COALESCE(EXCLUDED.faculty_code, lecturers.faculty_code),
+				faculty_name = COALESCE(EXCLUDED.faculty_name, lecturers.faculty_name),
+				updated_at = NOW()
+			RETURNING id
+		`, e.Identifier, e.Name, e.Email, e.FacultyCode, e.FacultyName).Scan(&id)
+		if err != nil {
+			return 0, fmt.Errorf("upsert lecturer identity: %w", err)
+		}
+		identity.Column, identity.LecturerID = identityColumnLecturer, &id
+	case master.RosterStaff:
+		var id int64
+		err := tx.QueryRow(ctx, `
+			INSERT INTO staff_members (nip, name, email)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (nip) DO UPDATE SET name = EXCLUDED.name, updated_at = NOW()
+			RETURNING id
+		`, e.Identifier, e.Name, e.Email).Scan(&id)
+		if err != nil {
+			return 0, fmt.Errorf("upsert staff identity: %w", err)
+		}
+		identity.Column, identity.StaffID = identityColumnStaff, &id
+	default:
+		return 0, shared.ErrBadRequest
+	}
+
+	existingID, err := r.findExistingVoter(ctx, tx, identity, e.Identifier)
+	if err != nil {
+		return 0, err
+	}
+
+	if existingID != nil {
+		_, err := tx.Exec(ctx, `
+			UPDATE voters SET
+				name = $2,
+				email = COALESCE(email, $3),
+				faculty_code = COALESCE($4, faculty_code),
+				faculty_name = COALESCE($5, faculty_name),
+				study_program_code = COALESCE($6, study_program_code),
+				study_program_name = COALESCE($7, study_program_name),
+				cohort_year = COALESCE($8, cohort_year),
+				semester = COALESCE($9, semester),
+				academic_status = $10,
+				voter_type = $11,
+				updated_at = NOW()
+			WHERE id = $1
+		`, *existingID, e.Name, e.Email, e.FacultyCode, e.FacultyName,
+			e.StudyProgramCode, e.StudyProgramName, e.CohortYear, e.Semester,
+			e.AcademicStatus, e.VoterType)
+		if err != nil {
+			return 0, fmt.Errorf("update voter %s: %w", e.Identifier, err)
+		}
+		return *existingID, nil
+	}
+
+	var voterID int64
+	err = tx.QueryRow(ctx, `
+		INSERT INTO voters (
+			nim, name, email,
+			faculty_code, faculty_name, study_program_code, study_program_name,
+			cohort_year, semester, academic_status,
+			voter_type, student_id, lecturer_id, staff_id, voting_method
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, 'ONLINE')
+		RETURNING id
+	`, e.Identifier, e.Name, e.Email,
+		e.FacultyCode, e.FacultyName, e.StudyProgramCode, e.StudyProgramName,
+		e.CohortYear, e.Semester, e.AcademicStatus,
+		e.VoterType, identity.StudentID, identity.LecturerID, identity.StaffID,
+	).Scan(&voterID)
+	if err != nil {
+		return 0, fmt.Errorf("insert voter %s: %w", e.Identifier, err)
+	}
+	return voterID, nil
+}
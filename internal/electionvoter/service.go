@@ -17,7 +17,8 @@ var (
 const defaultAcademicStatus = "ACTIVE"
 
 type Service struct {
-	repo Repository
+	repo   Repository
+	roster Roster
 }
 
 func NewService(repo Repository) *Service {
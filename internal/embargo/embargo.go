@@ -0,0 +1,149 @@
+// Package embargo hides candidate-level tallies of an election from every
+// role until its results may be known. Turnout stays visible throughout.
+//
+// Services that expose per-candidate counts take a Policy (SetEmbargo) and
+// ask it before returning them, so every handler, HTTP or not, goes through
+// the same check.
+package embargo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"pemira-api/internal/election"
+	"pemira-api/internal/errcatalog"
+	"pemira-api/internal/http/response"
+)
+
+// Mode is the election setting deciding how long results stay sealed
+type Mode string
+
+const (
+	ModeOff Mode = "OFF"
+	// ModeUntilClose seals results until voting is closed
+	ModeUntilClose Mode = "UNTIL_CLOSE"
+	// ModeUntilAnnouncement seals results until voting is closed and the
+	// election's announcement_at has passed
+	ModeUntilAnnouncement Mode = "UNTIL_ANNOUNCEMENT"
+)
+
+// strictness orders the modes; a sealed election may only move up
+var strictness = map[Mode]int{
+	ModeOff:               0,
+	ModeUntilClose:        1,
+	ModeUntilAnnouncement: 2,
+}
+
+func (m Mode) Valid() bool {
+	_, ok := strictness[m]
+	return ok
+}
+
+const (
+	// MinReasonLength keeps break-glass reasons meaningful in the audit log
+	MinReasonLength = 10
+	maxReasonLength = 1000
+)
+
+var (
+	ErrResultsEmbargoed = errors.New("candidate results are under embargo")
+	ErrNotEmbargoed     = errors.New("candidate results are not under embargo")
+	ErrElectionNotFound = errors.New("election not found")
+	ErrInvalidMode      = errors.New("invalid embargo mode")
+	ErrEmbargoLocked    = errors.New("embargo cannot be loosened while it is in force")
+	ErrInvalidReason    = errors.New("break-glass reason is required")
+)
+
+// Policy reports whether an election's candidate-level tallies are hidden.
+// It fails closed: when the setting cannot be read the results are hidden.
+type Policy interface {
+	Sealed(ctx context.Context, electionID int64) bool
+}
+
+// State is what the embargo of one election depends on
+type State struct {
+	ElectionID     int64
+	ElectionName   string
+	Mode           Mode
+	Status         election.ElectionStatus
+	AnnouncementAt *time.Time
+}
+
+// Sealed reports whether candidate-level tallies are hidden at now
+func (st State) Sealed(now time.Time) bool {
+	switch st.Mode {
+	case ModeUntilClose:
+		return !votingClosed(st.Status)
+	case ModeUntilAnnouncement:
+		return !votingClosed(st.Status) || st.AnnouncementAt == nil || now.Before(*st.AnnouncementAt)
+	}
+	return false
+}
+
+func votingClosed(status election.ElectionStatus) bool {
+	switch status {
+	case election.ElectionStatusVotingClosed,
+		election.ElectionStatusClosed,
+		election.ElectionStatusRecap,
+		election.ElectionStatusArchived:
+		return true
+	}
+	return false
+}
+
+// Status is the embargo setting of an election as shown to admins
+type Status struct {
+	ElectionID int64 `json:"election_id"`
+	Mode       Mode  `json:"mode"`
+	Sealed     bool  `json:"sealed"`
+	// UnsealsAt is the announcement time for ModeUntilAnnouncement
+	UnsealsAt *time.Time `json:"unseals_at,omitempty"`
+}
+
+type UpdateRequest struct {
+	Mode Mode `json:"mode"`
+}
+
+type BreakGlassRequest struct {
+	Reason string `json:"reason"`
+}
+
+// CandidateTally is the live vote count of one candidate
+type CandidateTally struct {
+	CandidateID int64  `json:"candidate_id"`
+	Number      int    `json:"number"`
+	Name        string `json:"name"`
+	Votes       int64  `json:"votes"`
+}
+
+// BreakGlass is one audited access to sealed results
+type BreakGlass struct {
+	ID         int64     `json:"id"`
+	ElectionID int64     `json:"election_id"`
+	UserID     int64     `json:"user_id"`
+	Username   string    `json:"username"`
+	Reason     string    `json:"reason"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type BreakGlassResult struct {
+	Access         BreakGlass       `json:"access"`
+	TotalVotes     int64            `json:"total_votes"`
+	Candidates     []CandidateTally `json:"candidates"`
+	NotifiedAdmins int              `json:"notified_admins"`
+}
+
+// Recipient is an admin told about a break-glass access
+type Recipient struct {
+	UserID   int64
+	Email    string
+	FullName string
+}
+
+// RespondSealed answers a request for candidate-level results under embargo
+func RespondSealed(w http.ResponseWriter) {
+	e, _ := errcatalog.Lookup(ErrResultsEmbargoed)
+	response.Error(w, e.Status, e.Code, e.Message(), map[string]interface{}{"results_embargoed": true})
+}
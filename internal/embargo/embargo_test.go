@@ -0,0 +1,186 @@
+package embargo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"pemira-api/internal/election"
+	"pemira-api/internal/mail"
+)
+
+type fakeRepo struct {
+	state   State
+	loads   int
+	err     error
+	updated []Mode
+	breaks  []BreakGlass
+	admins  []Recipient
+}
+
+func (f *fakeRepo) GetState(ctx context.Context, electionID int64) (*State, error) {
+	f.loads++
+	if f.err != nil {
+		return nil, f.err
+	}
+	st := f.state
+	return &st, nil
+}
+
+func (f *fakeRepo) UpdateMode(ctx context.Context, electionID int64, from, to Mode, actorID int64) error {
+	f.updated = append(f.updated, to)
+	f.state.Mode = to
+	return nil
+}
+
+func (f *fakeRepo) CandidateTallies(ctx context.Context, electionID int64) ([]CandidateTally, error) {
+	return []CandidateTally{{CandidateID: 1, Number: 1, Votes: 30}, {CandidateID: 2, Number: 2, Votes: 12}}, nil
+}
+
+func (f *fakeRepo) RecordBreakGlass(ctx context.Context, b *BreakGlass) error {
+	b.ID = int64(len(f.breaks) + 1)
+	b.Username = "root"
+	b.CreatedAt = time.Now()
+	f.breaks = append(f.breaks, *b)
+	return nil
+}
+
+func (f *fakeRepo) ListAdmins(ctx context.Context, electionID, exclude int64) ([]Recipient, error) {
+	return f.admins, nil
+}
+
+type sentMail struct{ to []string }
+
+func (s *sentMail) Send(ctx context.Context, msg mail.Message) error {
+	s.to = append(s.to, msg.To)
+	return nil
+}
+
+func TestStateSealed(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	before, after := now.Add(-time.Hour), now.Add(time.Hour)
+
+	tests := []struct {
+		name  string
+		state State
+		want  bool
+	}{
+		{"off while voting", State{Mode: ModeOff, Status: election.ElectionStatusVotingOpen}, false},
+		{"until close, voting open", State{Mode: ModeUntilClose, Status: election.ElectionStatusVotingOpen}, true},
+		{"until close, closed", State{Mode: ModeUntilClose, Status: election.ElectionStatusVotingClosed}, false},
+		{"until announcement, open", State{Mode: ModeUntilAnnouncement, Status: election.ElectionStatusVotingOpen, AnnouncementAt: &before}, true},
+		{"until announcement, closed, not yet", State{Mode: ModeUntilAnnouncement, Status: election.ElectionStatusClosed, AnnouncementAt: &after}, true},
+		{"until announcement, closed, no time", State{Mode: ModeUntilAnnouncement, Status: election.ElectionStatusClosed}, true},
+		{"until announcement, announced", State{Mode: ModeUntilAnnouncement, Status: election.ElectionStatusRecap, AnnouncementAt: &before}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.state.Sealed(now); got != tt.want {
+				t.Fatalf("Sealed = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSealedCachesAndFailsClosed(t *testing.T) {
+	repo := &fakeRepo{state: State{ElectionID: 1, Mode: ModeOff, Status: election.ElectionStatusVotingOpen}}
+	svc := NewService(repo)
+	ctx := context.Background()
+
+	if svc.Sealed(ctx, 1) || svc.Sealed(ctx, 1) {
+		t.Fatal("embargo off should not seal")
+	}
+	if repo.loads != 1 {
+		t.Fatalf("loads = %d, want 1 (cached)", repo.loads)
+	}
+
+	repo.err = errors.New("db down")
+	if !svc.Sealed(ctx, 2) {
+		t.Fatal("unreadable setting should seal")
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	repo := &fakeRepo{state: State{ElectionID: 1, Mode: ModeOff, Status: election.ElectionStatusVotingOpen}}
+	svc := NewService(repo)
+	ctx := context.Background()
+
+	if _, err := svc.Update(ctx, 1, UpdateRequest{Mode: "SOMETIMES"}, 9); !errors.Is(err, ErrInvalidMode) {
+		t.Fatalf("invalid mode: err = %v", err)
+	}
+
+	// cache the unsealed state, then tighten
+	if svc.Sealed(ctx, 1) {
+		t.Fatal("should start unsealed")
+	}
+	st, err := svc.Update(ctx, 1, UpdateRequest{Mode: "until_close"}, 9)
+	if err != nil || !st.Sealed || st.Mode != ModeUntilClose {
+		t.Fatalf("tighten: st = %+v, err = %v", st, err)
+	}
+	if !svc.Sealed(ctx, 1) {
+		t.Fatal("update should drop the cached state")
+	}
+
+	if _, err := svc.Update(ctx, 1, UpdateRequest{Mode: ModeOff}, 9); !errors.Is(err, ErrEmbargoLocked) {
+		t.Fatalf("loosen while sealed: err = %v", err)
+	}
+	if _, err := svc.Update(ctx, 1, UpdateRequest{Mode: ModeUntilAnnouncement}, 9); err != nil {
+		t.Fatalf("tighten further: %v", err)
+	}
+
+	repo.state.Status = election.ElectionStatusRecap
+	past := time.Now().Add(-time.Minute)
+	repo.state.AnnouncementAt = &past
+	if _, err := svc.Update(ctx, 1, UpdateRequest{Mode: ModeOff}, 9); err != nil {
+		t.Fatalf("loosen after announcement: %v", err)
+	}
+	if len(repo.updated) != 3 {
+		t.Fatalf("updates = %v", repo.updated)
+	}
+}
+
+func TestBreakGlass(t *testing.T) {
+	repo := &fakeRepo{
+		state:  State{ElectionID: 1, ElectionName: "PEMIRA 2026", Mode: ModeUntilClose, Status: election.ElectionStatusVotingOpen},
+		admins: []Recipient{{UserID: 2, Email: "a@pemira.ac.id", FullName: "A"}, {UserID: 3, Email: "b@pemira.ac.id", FullName: "B"}},
+	}
+	mailer := &sentMail{}
+	svc := NewService(repo)
+	svc.SetMailer(mailer)
+	ctx := context.Background()
+
+	if _, err := svc.BreakGlass(ctx, 1, BreakGlassRequest{Reason: "cek"}, 1); !errors.Is(err, ErrInvalidReason) {
+		t.Fatalf("short reason: err = %v", err)
+	}
+
+	res, err := svc.BreakGlass(ctx, 1, BreakGlassRequest{Reason: "  sengketa hasil TPS 3 dilaporkan saksi  "}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.TotalVotes != 42 || len(res.Candidates) != 2 || res.NotifiedAdmins != 2 {
+		t.Fatalf("result = %+v", res)
+	}
+	if len(repo.breaks) != 1 || repo.breaks[0].Reason != "sengketa hasil TPS 3 dilaporkan saksi" {
+		t.Fatalf("recorded = %+v", repo.breaks)
+	}
+	if strings.Join(mailer.to, ",") != "a@pemira.ac.id,b@pemira.ac.id" {
+		t.Fatalf("mailed = %v", mailer.to)
+	}
+
+	repo.state.Mode = ModeOff
+	if _, err := svc.BreakGlass(ctx, 1, BreakGlassRequest{Reason: "tidak perlu dibuka"}, 1); !errors.Is(err, ErrNotEmbargoed) {
+		t.Fatalf("not embargoed: err = %v", err)
+	}
+}
+
+func TestRespondSealed(t *testing.T) {
+	rec := httptest.NewRecorder()
+	RespondSealed(rec)
+	if rec.Code != http.StatusForbidden || !strings.Contains(rec.Body.String(), `"RESULTS_EMBARGOED"`) {
+		t.Fatalf("code = %d, body = %s", rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,23 @@
+package embargo
+
+import (
+	"fmt"
+	"net/http"
+
+	"pemira-api/internal/errcatalog"
+)
+
+// The API codes and messages of the embargo errors; handleError and
+// RespondSealed write them
+func init() {
+	errcatalog.Register(
+		errcatalog.Entry{Err: ErrResultsEmbargoed, Code: "RESULTS_EMBARGOED", Status: http.StatusForbidden, ID: "Perolehan suara per kandidat disembunyikan selama embargo hasil.", EN: "Per-candidate vote counts are hidden during the results embargo."},
+		errcatalog.Entry{Err: ErrElectionNotFound, Code: "ELECTION_NOT_FOUND", Status: http.StatusNotFound, ID: "Pemilu tidak ditemukan.", EN: "Election not found."},
+		errcatalog.Entry{Err: ErrInvalidMode, Code: "VALIDATION_ERROR", Status: http.StatusUnprocessableEntity, ID: "mode harus OFF, UNTIL_CLOSE atau UNTIL_ANNOUNCEMENT.", EN: "mode must be OFF, UNTIL_CLOSE or UNTIL_ANNOUNCEMENT."},
+		errcatalog.Entry{Err: ErrEmbargoLocked, Code: "EMBARGO_LOCKED", Status: http.StatusConflict, ID: "Embargo hasil yang sedang berlaku hanya dapat diperketat.", EN: "A results embargo in force can only be tightened."},
+		errcatalog.Entry{Err: ErrInvalidReason, Code: "VALIDATION_ERROR", Status: http.StatusUnprocessableEntity,
+			ID: fmt.Sprintf("Alasan wajib diisi, %d-%d karakter.", MinReasonLength, maxReasonLength),
+			EN: fmt.Sprintf("A reason of %d-%d characters is required.", MinReasonLength, maxReasonLength)},
+		errcatalog.Entry{Err: ErrNotEmbargoed, Code: "RESULTS_NOT_EMBARGOED", Status: http.StatusConflict, ID: "Hasil pemilu ini tidak sedang diembargo.", EN: "This election's results are not under embargo."},
+	)
+}
@@ -0,0 +1,110 @@
+package embargo
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"pemira-api/internal/errcatalog"
+	"pemira-api/internal/http/response"
+	"pemira-api/internal/shared/ctxkeys"
+)
+
+type Handler struct {
+	svc *Service
+}
+
+func NewHandler(svc *Service) *Handler {
+	return &Handler{svc: svc}
+}
+
+// GET /admin/elections/{electionID}/settings/results-embargo
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
+	electionID, ok := parseElectionID(w, r)
+	if !ok {
+		return
+	}
+
+	st, err := h.svc.Get(r.Context(), electionID)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	response.Success(w, http.StatusOK, st)
+}
+
+// PUT /admin/elections/{electionID}/settings/results-embargo
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	electionID, ok := parseElectionID(w, r)
+	if !ok {
+		return
+	}
+	userID, ok := ctxkeys.GetUserID(ctx)
+	if !ok {
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.Unauthenticated)
+		return
+	}
+
+	var req UpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
+		return
+	}
+
+	st, err := h.svc.Update(ctx, electionID, req, userID)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	response.Success(w, http.StatusOK, st)
+}
+
+// POST /admin/elections/{electionID}/results/break-glass (super admin only)
+func (h *Handler) BreakGlass(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	electionID, ok := parseElectionID(w, r)
+	if !ok {
+		return
+	}
+	userID, ok := ctxkeys.GetUserID(ctx)
+	if !ok {
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.Unauthenticated)
+		return
+	}
+
+	var req BreakGlassRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
+		return
+	}
+
+	result, err := h.svc.BreakGlass(ctx, electionID, req, userID)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	response.Success(w, http.StatusOK, result)
+}
+
+func parseElectionID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "electionID"), 10, 64)
+	if err != nil || id <= 0 {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("electionID"))
+		return 0, false
+	}
+	return id, true
+}
+
+// handleError maps service errors to HTTP responses through the error
+// catalog (see errors.go)
+func (h *Handler) handleError(w http.ResponseWriter, r *http.Request, err error) {
+	if response.DomainError(w, err) {
+		return
+	}
+	slog.ErrorContext(r.Context(), "results embargo handler error", "error", err)
+	response.InternalServerError(w, "INTERNAL_ERROR", errcatalog.InternalError)
+}
@@ -0,0 +1,152 @@
+package embargo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"pemira-api/internal/audit"
+)
+
+type PgRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPgRepository(db *pgxpool.Pool) *PgRepository {
+	return &PgRepository{db: db}
+}
+
+func (r *PgRepository) GetState(ctx context.Context, electionID int64) (*State, error) {
+	st := State{ElectionID: electionID}
+	err := r.db.QueryRow(ctx, `
+		SELECT name, results_embargo, status, announcement_at
+		FROM elections
+		WHERE id = $1
+	`, electionID).Scan(&st.ElectionName, &st.Mode, &st.Status, &st.AnnouncementAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrElectionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &st, nil
+}
+
+func (r *PgRepository) UpdateMode(ctx context.Context, electionID int64, from, to Mode, actorID int64) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE elections SET results_embargo = $2, updated_at = NOW() WHERE id = $1
+	`, electionID, to)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrElectionNotFound
+	}
+
+	if err := auditElection(ctx, tx, actorID, "RESULTS_EMBARGO_UPDATED", electionID,
+		map[string]any{"from": from, "to": to}); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *PgRepository) CandidateTallies(ctx context.Context, electionID int64) ([]CandidateTally, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT c.id, c.number, c.name, COUNT(v.id)
+		FROM candidates c
+		LEFT JOIN votes v ON v.candidate_id = c.id AND v.election_id = c.election_id
+		WHERE c.election_id = $1
+		GROUP BY c.id, c.number, c.name
+		ORDER BY c.number, c.id
+	`, electionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tallies := []CandidateTally{}
+	for rows.Next() {
+		var t CandidateTally
+		if err := rows.Scan(&t.CandidateID, &t.Number, &t.Name, &t.Votes); err != nil {
+			return nil, err
+		}
+		tallies = append(tallies, t)
+	}
+	return tallies, rows.Err()
+}
+
+func (r *PgRepository) RecordBreakGlass(ctx context.Context, b *BreakGlass) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := tx.QueryRow(ctx, `
+		INSERT INTO results_embargo_breaks (election_id, user_id, reason)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at,
+			(SELECT COALESCE(username, '') FROM user_accounts WHERE id = $2)
+	`, b.ElectionID, b.UserID, b.Reason).Scan(&b.ID, &b.CreatedAt, &b.Username); err != nil {
+		return err
+	}
+
+	if err := auditElection(ctx, tx, b.UserID, "RESULTS_EMBARGO_BROKEN", b.ElectionID,
+		map[string]any{"access_id": b.ID, "reason": b.Reason}); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *PgRepository) ListAdmins(ctx context.Context, electionID, exclude int64) ([]Recipient, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT u.id, u.email, COALESCE(NULLIF(u.full_name, ''), u.username)
+		FROM user_accounts u
+		WHERE u.is_active
+		  AND u.id <> $2
+		  AND COALESCE(u.email, '') <> ''
+		  AND (
+		      u.role IN ('ADMIN', 'SUPER_ADMIN')
+		      OR EXISTS (
+		          SELECT 1 FROM role_assignments ra
+		          WHERE ra.user_id = u.id
+		            AND ra.role IN ('ADMIN', 'SUPER_ADMIN')
+		            AND (ra.election_id IS NULL OR ra.election_id = $1)
+		      )
+		  )
+		ORDER BY u.id
+	`, electionID, exclude)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []Recipient
+	for rows.Next() {
+		var rc Recipient
+		if err := rows.Scan(&rc.UserID, &rc.Email, &rc.FullName); err != nil {
+			return nil, err
+		}
+		out = append(out, rc)
+	}
+	return out, rows.Err()
+}
+
+// auditElection writes an election-level audit_logs entry inside tx
+func auditElection(ctx context.Context, tx pgx.Tx, actorID int64, action string, electionID int64, metadata map[string]any) error {
+	return audit.Write(ctx, tx, audit.Entry{
+		ActorUserID: &actorID,
+		Action:      action,
+		EntityType:  "ELECTION",
+		EntityID:    electionID,
+		Metadata:    metadata,
+	})
+}
@@ -0,0 +1,224 @@
+package embargo
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"pemira-api/internal/mail"
+)
+
+const (
+	// stateCacheTTL bounds how long another instance keeps an old setting;
+	// the instance that changes it drops its cache at once
+	stateCacheTTL = 5 * time.Second
+	// stateLoadTimeout keeps a slow database from stalling the results
+	// endpoints behind a cache refresh
+	stateLoadTimeout = 2 * time.Second
+)
+
+type Repository interface {
+	GetState(ctx context.Context, electionID int64) (*State, error)
+	// UpdateMode stores the setting and audits the change
+	UpdateMode(ctx context.Context, electionID int64, from, to Mode, actorID int64) error
+	CandidateTallies(ctx context.Context, electionID int64) ([]CandidateTally, error)
+	// RecordBreakGlass stores the access and audits it in one transaction;
+	// it fills in ID, Username and CreatedAt
+	RecordBreakGlass(ctx context.Context, b *BreakGlass) error
+	// ListAdmins returns the active admins of the election with an email,
+	// except exclude
+	ListAdmins(ctx context.Context, electionID, exclude int64) ([]Recipient, error)
+}
+
+type cachedState struct {
+	state    State
+	loadedAt time.Time
+}
+
+type Service struct {
+	repo   Repository
+	mailer mail.Sender
+	now    func() time.Time
+
+	mu    sync.Mutex
+	cache map[int64]cachedState
+}
+
+func NewService(repo Repository) *Service {
+	return &Service{
+		repo:   repo,
+		mailer: mail.LogSender{},
+		now:    time.Now,
+		cache:  make(map[int64]cachedState),
+	}
+}
+
+// SetMailer sets where break-glass notices to the other admins are sent.
+// nil disables them.
+func (s *Service) SetMailer(m mail.Sender) {
+	s.mailer = m
+}
+
+// Sealed implements Policy. A setting that cannot be loaded seals the
+// results; the failure is logged.
+func (s *Service) Sealed(ctx context.Context, electionID int64) bool {
+	st, err := s.state(ctx, electionID)
+	if err != nil {
+		slog.WarnContext(ctx, "embargo: failed to load setting; hiding results", "election_id", electionID, "error", err)
+		return true
+	}
+	return st.Sealed(s.now())
+}
+
+func (s *Service) state(ctx context.Context, electionID int64) (State, error) {
+	s.mu.Lock()
+	c, ok := s.cache[electionID]
+	s.mu.Unlock()
+	if ok && s.now().Sub(c.loadedAt) < stateCacheTTL {
+		return c.state, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, stateLoadTimeout)
+	defer cancel()
+	st, err := s.repo.GetState(ctx, electionID)
+	if err != nil {
+		return State{}, err
+	}
+
+	s.mu.Lock()
+	s.cache[electionID] = cachedState{state: *st, loadedAt: s.now()}
+	s.mu.Unlock()
+	return *st, nil
+}
+
+func (s *Service) forget(electionID int64) {
+	s.mu.Lock()
+	delete(s.cache, electionID)
+	s.mu.Unlock()
+}
+
+// Get returns the embargo setting of the election
+func (s *Service) Get(ctx context.Context, electionID int64) (*Status, error) {
+	st, err := s.repo.GetState(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+	return s.status(*st), nil
+}
+
+func (s *Service) status(st State) *Status {
+	out := &Status{ElectionID: st.ElectionID, Mode: st.Mode, Sealed: st.Sealed(s.now())}
+	if st.Mode == ModeUntilAnnouncement {
+		out.UnsealsAt = st.AnnouncementAt
+	}
+	return out
+}
+
+// Update changes the embargo setting. While the results are sealed the
+// embargo can only be made stricter; seeing them early is what BreakGlass
+// is for.
+func (s *Service) Update(ctx context.Context, electionID int64, req UpdateRequest, actorID int64) (*Status, error) {
+	mode := Mode(strings.ToUpper(strings.TrimSpace(string(req.Mode))))
+	if !mode.Valid() {
+		return nil, ErrInvalidMode
+	}
+
+	st, err := s.repo.GetState(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+	if st.Sealed(s.now()) && strictness[mode] < strictness[st.Mode] {
+		return nil, ErrEmbargoLocked
+	}
+
+	if mode != st.Mode {
+		if err := s.repo.UpdateMode(ctx, electionID, st.Mode, mode, actorID); err != nil {
+			return nil, err
+		}
+		s.forget(electionID)
+		slog.InfoContext(ctx, "results embargo updated", "election_id", electionID, "from", st.Mode, "to", mode, "updated_by", actorID)
+	}
+
+	st.Mode = mode
+	return s.status(*st), nil
+}
+
+// BreakGlass hands the sealed candidate tallies to a super admin. Every
+// access is stored with its reason, audited and mailed to the other admins
+// of the election.
+func (s *Service) BreakGlass(ctx context.Context, electionID int64, req BreakGlassRequest, actorID int64) (*BreakGlassResult, error) {
+	reason := strings.TrimSpace(req.Reason)
+	if n := utf8.RuneCountInString(reason); n < MinReasonLength || n > maxReasonLength {
+		return nil, ErrInvalidReason
+	}
+
+	st, err := s.repo.GetState(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+	if !st.Sealed(s.now()) {
+		return nil, ErrNotEmbargoed
+	}
+
+	tallies, err := s.repo.CandidateTallies(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+
+	access := BreakGlass{ElectionID: electionID, UserID: actorID, Reason: reason}
+	if err := s.repo.RecordBreakGlass(ctx, &access); err != nil {
+		return nil, err
+	}
+	slog.WarnContext(ctx, "results embargo broken", "election_id", electionID, "user_id", actorID, "access_id", access.ID)
+
+	result := &BreakGlassResult{Access: access, Candidates: tallies}
+	for _, t := range tallies {
+		result.TotalVotes += t.Votes
+	}
+	result.NotifiedAdmins = s.notify(ctx, st, access)
+	return result, nil
+}
+
+// notify mails the other admins about a break-glass access and returns how
+// many were told
+func (s *Service) notify(ctx context.Context, st *State, access BreakGlass) int {
+	if s.mailer == nil {
+		return 0
+	}
+	admins, err := s.repo.ListAdmins(ctx, access.ElectionID, access.UserID)
+	if err != nil {
+		slog.ErrorContext(ctx, "embargo: failed to load admins to notify", "election_id", access.ElectionID, "error", err)
+		return 0
+	}
+
+	sent := 0
+	for _, a := range admins {
+		if err := s.mailer.Send(ctx, breakGlassMessage(st, access, a)); err != nil {
+			slog.WarnContext(ctx, "embargo: failed to send break-glass notice", "user_id", a.UserID, "error", err)
+			continue
+		}
+		sent++
+	}
+	return sent
+}
+
+func breakGlassMessage(st *State, access BreakGlass, to Recipient) mail.Message {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Halo %s,\n\n", to.FullName)
+	fmt.Fprintf(&b, "Embargo hasil %s telah dibuka melalui akses darurat (break-glass).\n\n", st.ElectionName)
+	fmt.Fprintf(&b, "Oleh    : %s (user #%d)\n", access.Username, access.UserID)
+	fmt.Fprintf(&b, "Waktu   : %s\n", access.CreatedAt.Format("02-01-2006 15:04:05 MST"))
+	fmt.Fprintf(&b, "Alasan  : %s\n", access.Reason)
+	fmt.Fprintf(&b, "Akses ID: %d\n", access.ID)
+	b.WriteString("\nAkses ini tercatat di audit log. Hubungi ketua panitia bila akses ini tidak sah.\n")
+
+	return mail.Message{
+		To:      to.Email,
+		Subject: fmt.Sprintf("Embargo hasil dibuka - %s", st.ElectionName),
+		Body:    b.String(),
+	}
+}
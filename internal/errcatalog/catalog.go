@@ -0,0 +1,214 @@
+// Package errcatalog is the central catalog of API errors: domain errors
+// mapped to stable codes and HTTP statuses, and the messages handlers send in
+// Indonesian and English.
+//
+// Packages register their domain errors at init and handlers write them with
+// response.DomainError. Every other message a handler writes is a Message
+// value from this package or its own, so response.Error can pick the text in
+// the language negotiated from Accept-Language (see middleware.Language)
+// without ever matching on message text.
+//
+// Messages a handler writes without a domain error behind them are defined
+// with Define under a code of their own, so a code always stands for one
+// message.
+package errcatalog
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Lang is a supported message language
+type Lang string
+
+const (
+	Indonesian Lang = "id"
+	English    Lang = "en"
+)
+
+// Message is a user-facing message in every supported language
+type Message struct {
+	ID string
+	EN string
+	// Fields are the request fields a validation message is about;
+	// response.Error sends them in details.fields
+	Fields []FieldError
+}
+
+// Text returns the message in lang, Indonesian unless English is asked for
+// and known
+func (m Message) Text(lang Lang) string {
+	if lang == English && m.EN != "" {
+		return m.EN
+	}
+	return m.ID
+}
+
+// FieldError is one field that failed validation; the same shape is used for
+// DTO validation by the openapi middleware
+type FieldError struct {
+	Field string `json:"field"`
+	Rule  string `json:"rule"`
+	Param string `json:"param,omitempty"`
+}
+
+// Entry maps a domain error to the code, status and message the API answers
+// with. ID defaults to Err's text.
+type Entry struct {
+	Err    error
+	Code   string
+	Status int
+	ID     string
+	EN     string
+}
+
+// Message returns the entry's texts
+func (e Entry) Message() Message {
+	return Message{ID: e.ID, EN: e.EN}
+}
+
+// Messagef returns the entry's texts with args formatted into them
+func (e Entry) Messagef(args ...any) Message {
+	return Message{ID: fmt.Sprintf(e.ID, args...), EN: fmt.Sprintf(e.EN, args...)}
+}
+
+var (
+	mu      sync.RWMutex
+	domain  []Entry
+	defined = map[string]Entry{}
+)
+
+// Register adds domain error entries to the catalog
+func Register(entries ...Entry) {
+	mu.Lock()
+	defer mu.Unlock()
+	for _, e := range entries {
+		if e.Err == nil {
+			panic("errcatalog: entry " + e.Code + " has no error")
+		}
+		if e.ID == "" {
+			e.ID = e.Err.Error()
+		}
+		domain = append(domain, e)
+	}
+}
+
+// Define adds a handler message to the catalog under code and returns its
+// entry. Codes are unique among defined messages; defining one twice panics.
+func Define(code string, status int, id, en string) Entry {
+	mu.Lock()
+	defer mu.Unlock()
+	if _, ok := defined[code]; ok {
+		panic("errcatalog: code " + code + " is defined twice")
+	}
+	e := Entry{Code: code, Status: status, ID: id, EN: en}
+	defined[code] = e
+	return e
+}
+
+// ByCode returns the message defined under code
+func ByCode(code string) (Entry, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	e, ok := defined[code]
+	return e, ok
+}
+
+// Lookup returns the entry registered for err or an error it wraps
+func Lookup(err error) (Entry, bool) {
+	if err == nil {
+		return Entry{}, false
+	}
+	mu.RLock()
+	defer mu.RUnlock()
+	for _, e := range domain {
+		if errors.Is(err, e.Err) {
+			return e, true
+		}
+	}
+	return Entry{}, false
+}
+
+// Invalid is the message for a parameter or field with an invalid value
+func Invalid(field string) Message {
+	return Message{
+		ID:     field + " tidak valid.",
+		EN:     field + " is invalid.",
+		Fields: []FieldError{{Field: field, Rule: "invalid"}},
+	}
+}
+
+// Required is the message for missing fields
+func Required(fields ...string) Message {
+	m := Message{
+		ID: list(fields, "dan") + " wajib diisi.",
+		EN: list(fields, "and") + " are required.",
+	}
+	if len(fields) == 1 {
+		m.EN = fields[0] + " is required."
+	}
+	for _, f := range fields {
+		m.Fields = append(m.Fields, FieldError{Field: f, Rule: "required"})
+	}
+	return m
+}
+
+// OneOf is the message for a field outside its allowed values
+func OneOf(field string, values ...string) Message {
+	param := strings.Join(values, " ")
+	return Message{
+		ID:     field + " harus " + list(values, "atau") + ".",
+		EN:     field + " must be " + list(values, "or") + ".",
+		Fields: []FieldError{{Field: field, Rule: "oneof", Param: param}},
+	}
+}
+
+// list joins items as "a, b, dan c"
+func list(items []string, conj string) string {
+	switch len(items) {
+	case 0:
+		return ""
+	case 1:
+		return items[0]
+	case 2:
+		return items[0] + " " + conj + " " + items[1]
+	}
+	return strings.Join(items[:len(items)-1], ", ") + ", " + conj + " " + items[len(items)-1]
+}
+
+// Negotiate picks the supported language with the highest q-value in an
+// Accept-Language header; "" when none is supported
+func Negotiate(header string) Lang {
+	var best Lang
+	bestQ := 0.0
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			var err error
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+		lang := Parse(tag)
+		if lang != "" && q > bestQ {
+			best, bestQ = lang, q
+		}
+	}
+	return best
+}
+
+// Parse maps a language tag ("en-US", "id") to a supported language
+func Parse(tag string) Lang {
+	primary, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+	switch primary {
+	case "id", "in":
+		return Indonesian
+	case "en":
+		return English
+	}
+	return ""
+}
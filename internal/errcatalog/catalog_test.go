@@ -0,0 +1,156 @@
+package errcatalog_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"pemira-api/internal/errcatalog"
+	"pemira-api/internal/http/middleware"
+	"pemira-api/internal/http/response"
+)
+
+var (
+	errSample = errors.New("Sampel tidak ditemukan")
+
+	sampleLimit = errcatalog.Define("SAMPLE_LIMIT", http.StatusTooManyRequests, "Coba lagi dalam %d detik.", "Try again in %d seconds.")
+)
+
+func init() {
+	errcatalog.Register(errcatalog.Entry{Err: errSample, Code: "SAMPLE_NOT_FOUND", Status: http.StatusNotFound, EN: "Sample not found"})
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := map[string]errcatalog.Lang{
+		"":                           "",
+		"fr-FR":                      "",
+		"en-US,en;q=0.9":             errcatalog.English,
+		"id-ID,id;q=0.9,en;q=0.8":    errcatalog.Indonesian,
+		"fr;q=1, en;q=0.4, id;q=0.5": errcatalog.Indonesian,
+		"en;q=0":                     "",
+	}
+	for header, want := range tests {
+		if got := errcatalog.Negotiate(header); got != want {
+			t.Errorf("Negotiate(%q) = %q, want %q", header, got, want)
+		}
+	}
+}
+
+func TestMessages(t *testing.T) {
+	tests := []struct {
+		msg    errcatalog.Message
+		id, en string
+		fields []errcatalog.FieldError
+	}{
+		{errcatalog.InvalidBody, "Body tidak valid.", "Invalid request body.", nil},
+		{errcatalog.Invalid("electionID"), "electionID tidak valid.", "electionID is invalid.",
+			[]errcatalog.FieldError{{Field: "electionID", Rule: "invalid"}}},
+		{errcatalog.Required("tps_id"), "tps_id wajib diisi.", "tps_id is required.",
+			[]errcatalog.FieldError{{Field: "tps_id", Rule: "required"}}},
+		{errcatalog.Required("code", "name", "location"), "code, name, dan location wajib diisi.", "code, name, and location are required.",
+			[]errcatalog.FieldError{{Field: "code", Rule: "required"}, {Field: "name", Rule: "required"}, {Field: "location", Rule: "required"}}},
+		{errcatalog.OneOf("preferred_method", "ONLINE", "TPS"), "preferred_method harus ONLINE atau TPS.", "preferred_method must be ONLINE or TPS.",
+			[]errcatalog.FieldError{{Field: "preferred_method", Rule: "oneof", Param: "ONLINE TPS"}}},
+		{errcatalog.Message{ID: "Hanya dalam bahasa Indonesia."}, "Hanya dalam bahasa Indonesia.", "Hanya dalam bahasa Indonesia.", nil},
+	}
+	for _, tt := range tests {
+		if got := tt.msg.Text(errcatalog.Indonesian); got != tt.id {
+			t.Errorf("id text = %q, want %q", got, tt.id)
+		}
+		if got := tt.msg.Text(errcatalog.English); got != tt.en {
+			t.Errorf("en text = %q, want %q", got, tt.en)
+		}
+		if got := tt.msg.Text(""); got != tt.id {
+			t.Errorf("default text = %q, want %q", got, tt.id)
+		}
+		if fmt.Sprint(tt.msg.Fields) != fmt.Sprint(tt.fields) {
+			t.Errorf("%q fields = %+v, want %+v", tt.id, tt.msg.Fields, tt.fields)
+		}
+	}
+}
+
+func TestLookupWrapped(t *testing.T) {
+	e, ok := errcatalog.Lookup(fmt.Errorf("load: %w", errSample))
+	if !ok || e.Code != "SAMPLE_NOT_FOUND" || e.Message().ID != errSample.Error() {
+		t.Fatalf("entry = %+v, ok = %v", e, ok)
+	}
+	if _, ok := errcatalog.Lookup(errors.New("other")); ok {
+		t.Fatal("unknown error found")
+	}
+}
+
+func TestDefine(t *testing.T) {
+	e, ok := errcatalog.ByCode("SAMPLE_LIMIT")
+	if !ok || e != sampleLimit {
+		t.Fatalf("entry = %+v, ok = %v", e, ok)
+	}
+	if m := e.Messagef(3); m.ID != "Coba lagi dalam 3 detik." || m.EN != "Try again in 3 seconds." {
+		t.Fatalf("message = %+v", m)
+	}
+	if _, ok := errcatalog.ByCode("UNKNOWN_CODE"); ok {
+		t.Fatal("unknown code found")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("defining a code twice did not panic")
+		}
+	}()
+	errcatalog.Define("SAMPLE_LIMIT", http.StatusBadRequest, "Lain.", "Other.")
+}
+
+func TestResponseLocalized(t *testing.T) {
+	h := middleware.Language(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/domain":
+			response.DomainError(w, errSample)
+			return
+		case "/defined":
+			response.Catalog(w, sampleLimit, 3)
+			return
+		}
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("electionID"))
+	}))
+
+	serve := func(path, lang string) (*httptest.ResponseRecorder, response.ErrorResponse) {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if lang != "" {
+			req.Header.Set("Accept-Language", lang)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		var body response.ErrorResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatal(err)
+		}
+		return rec, body
+	}
+
+	rec, body := serve("/", "en-GB")
+	if rec.Header().Get("Content-Language") != "en" || body.Message != "electionID is invalid." {
+		t.Fatalf("en: headers = %v, body = %+v", rec.Header(), body)
+	}
+	details, _ := json.Marshal(body.Details)
+	if string(details) != `{"fields":[{"field":"electionID","rule":"invalid"}]}` {
+		t.Fatalf("details = %s", details)
+	}
+
+	// without a supported language the Indonesian text is sent
+	rec, body = serve("/", "")
+	if rec.Header().Get("Content-Language") != "" || body.Message != "electionID tidak valid." {
+		t.Fatalf("default: headers = %v, body = %+v", rec.Header(), body)
+	}
+
+	rec, body = serve("/domain", "en")
+	if rec.Code != http.StatusNotFound || body.Code != "SAMPLE_NOT_FOUND" || body.Message != "Sample not found" {
+		t.Fatalf("domain: code = %d, body = %+v", rec.Code, body)
+	}
+
+	rec, body = serve("/defined", "id")
+	if rec.Code != http.StatusTooManyRequests || body.Code != "SAMPLE_LIMIT" || body.Message != "Coba lagi dalam 3 detik." {
+		t.Fatalf("defined: code = %d, body = %+v", rec.Code, body)
+	}
+}
@@ -0,0 +1,44 @@
+package errcatalog
+
+import "net/http"
+
+// Messages shared by handlers across packages. Domain errors are registered
+// by the package that owns them; messages only one package writes live there.
+var (
+	// Request and body
+	InvalidBody       = Message{ID: "Body tidak valid.", EN: "Invalid request body."}
+	InvalidJSON       = Message{ID: "Format JSON tidak valid.", EN: "Invalid JSON."}
+	InvalidData       = Message{ID: "Data yang dikirim tidak valid.", EN: "The submitted data is invalid."}
+	ValidationFailed  = Message{ID: "Validasi gagal.", EN: "Validation failed."}
+	BodyTooLarge      = Message{ID: "Body terlalu besar.", EN: "Request body is too large."}
+	InvalidID         = Message{ID: "ID tidak valid.", EN: "Invalid ID."}
+	InvalidTPSID      = Message{ID: "ID TPS tidak valid.", EN: "Invalid TPS ID."}
+	InvalidElectionID = Message{ID: "ID pemilu tidak valid.", EN: "Invalid election ID."}
+	InvalidCheckinID  = Message{ID: "ID check-in tidak valid.", EN: "Invalid check-in ID."}
+	UploadFormError   = Message{ID: "Gagal membaca form upload.", EN: "Could not read the upload form."}
+	UploadFileError   = Message{ID: "Gagal membaca file upload.", EN: "Could not read the uploaded file."}
+	FileRequired      = Message{ID: "Field file wajib diisi.", EN: "The file field is required.", Fields: []FieldError{{Field: "file", Rule: "required"}}}
+	FileTooLarge      = Message{ID: "Ukuran file maksimal 3MB.", EN: "Files can be at most 3MB."}
+
+	// Authentication and access
+	Unauthenticated = Message{ID: "Tidak terautentikasi.", EN: "Unauthorized."}
+	InvalidToken    = Message{ID: "Token tidak valid.", EN: "Invalid token."}
+	TokenMissing    = Message{ID: "Token tidak valid atau tidak ditemukan.", EN: "The token is invalid or missing."}
+	TokenNoAccess   = Message{ID: "Token tidak valid atau tidak memiliki akses.", EN: "The token is invalid or has no access."}
+	InvalidUser     = Message{ID: "User tidak valid.", EN: "Invalid user."}
+	AccessDenied    = Message{ID: "Akses ditolak.", EN: "Access denied."}
+	VotersOnly      = Message{ID: "Hanya voter yang dapat mengakses profil.", EN: "Only voters can access the profile."}
+	VoterNotLinked  = Message{ID: "Akun ini belum terhubung dengan data pemilih.", EN: "This account is not linked to a voter record."}
+
+	// Not found
+	ElectionNotFound = Message{ID: "Pemilu tidak ditemukan.", EN: "Election not found."}
+	TPSNotFound      = Message{ID: "TPS tidak ditemukan.", EN: "TPS not found."}
+	VoterNotFound    = Message{ID: "Pemilih tidak ditemukan.", EN: "Voter not found."}
+	UserNotFound     = Message{ID: "User tidak ditemukan.", EN: "User not found."}
+
+	// Failures
+	InternalError = Message{ID: "Terjadi kesalahan pada sistem.", EN: "An internal error occurred."}
+)
+
+// Internal is the answer to errors the catalog does not know
+var Internal = Define("INTERNAL_ERROR", http.StatusInternalServerError, InternalError.ID, InternalError.EN)
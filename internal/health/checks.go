@@ -0,0 +1,80 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Errors are kept generic: the readiness probe is public. Details go to the log.
+var (
+	ErrDatabaseUnreachable = errors.New("database unreachable")
+	ErrStorageUnreachable  = errors.New("storage unreachable")
+)
+
+// Database pings Postgres through the pool
+func Database(pool *pgxpool.Pool) Check {
+	return Check{
+		Name:     "database",
+		Critical: true,
+		Run: func(ctx context.Context) error {
+			if err := pool.Ping(ctx); err != nil {
+				slog.WarnContext(ctx, "health: database ping failed", "error", err)
+				return ErrDatabaseUnreachable
+			}
+			return nil
+		},
+	}
+}
+
+// PoolSaturation fails when at least threshold (0-1) of the pool's
+// connections are in use.
+func PoolSaturation(pool *pgxpool.Pool, threshold float64) Check {
+	return Check{
+		Name: "database_pool",
+		Run: func(ctx context.Context) error {
+			stat := pool.Stat()
+			return saturation(stat.AcquiredConns(), stat.MaxConns(), threshold)
+		},
+	}
+}
+
+func saturation(acquired, max int32, threshold float64) error {
+	if max <= 0 || float64(acquired)/float64(max) < threshold {
+		return nil
+	}
+	return fmt.Errorf("pool saturated: %d of %d connections in use", acquired, max)
+}
+
+// Storage checks the Supabase storage API answers with the service key
+func Storage(client *http.Client, baseURL, key string) Check {
+	endpoint := strings.TrimRight(baseURL, "/") + "/storage/v1/bucket"
+	return Check{
+		Name: "storage",
+		Run: func(ctx context.Context) error {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+			if err != nil {
+				return err
+			}
+			req.Header.Set("Authorization", "Bearer "+key)
+			req.Header.Set("apikey", key)
+
+			resp, err := client.Do(req)
+			if err != nil {
+				slog.WarnContext(ctx, "health: storage request failed", "error", err)
+				return ErrStorageUnreachable
+			}
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				slog.WarnContext(ctx, "health: storage answered with an error", "status", resp.StatusCode)
+				return ErrStorageUnreachable
+			}
+			return nil
+		},
+	}
+}
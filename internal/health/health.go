@@ -0,0 +1,119 @@
+// Package health serves the liveness and readiness probes.
+package health
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"pemira-api/internal/http/response"
+	"pemira-api/internal/settings"
+)
+
+const (
+	StatusOK          = "ok"
+	StatusDegraded    = "degraded"
+	StatusUnavailable = "unavailable"
+
+	// checkTimeout bounds every dependency check of one readiness probe
+	checkTimeout = 3 * time.Second
+)
+
+// Check is one dependency check. A failing critical check makes the
+// instance unready; other checks only degrade it.
+type Check struct {
+	Name     string
+	Critical bool
+	Run      func(ctx context.Context) error
+}
+
+type CheckResult struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Critical   bool   `json:"critical"`
+	DurationMs int64  `json:"duration_ms"`
+	Error      string `json:"error,omitempty"`
+}
+
+type Report struct {
+	Status      string                `json:"status"`
+	Maintenance *settings.Maintenance `json:"maintenance,omitempty"`
+	Checks      []CheckResult         `json:"checks"`
+}
+
+// MaintenanceState reports the current maintenance mode
+type MaintenanceState interface {
+	MaintenanceState(ctx context.Context) settings.Maintenance
+}
+
+type Handler struct {
+	checks      []Check
+	maintenance MaintenanceState
+}
+
+func NewHandler(checks ...Check) *Handler {
+	return &Handler{checks: checks}
+}
+
+// SetMaintenance adds the maintenance mode to the readiness report
+func (h *Handler) SetMaintenance(m MaintenanceState) {
+	h.maintenance = m
+}
+
+// Run executes every check concurrently
+func (h *Handler) Run(ctx context.Context) Report {
+	report := Report{Status: StatusOK, Checks: make([]CheckResult, len(h.checks))}
+
+	var wg sync.WaitGroup
+	for i, c := range h.checks {
+		wg.Add(1)
+		go func(i int, c Check) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+			defer cancel()
+
+			start := time.Now()
+			err := c.Run(ctx)
+			res := CheckResult{Name: c.Name, Status: StatusOK, Critical: c.Critical, DurationMs: time.Since(start).Milliseconds()}
+			if err != nil {
+				res.Status = "fail"
+				res.Error = err.Error()
+			}
+			report.Checks[i] = res
+		}(i, c)
+	}
+	wg.Wait()
+
+	for _, res := range report.Checks {
+		if res.Status == StatusOK {
+			continue
+		}
+		if res.Critical {
+			report.Status = StatusUnavailable
+			break
+		}
+		report.Status = StatusDegraded
+	}
+
+	if h.maintenance != nil {
+		m := h.maintenance.MaintenanceState(ctx)
+		report.Maintenance = &m
+	}
+	return report
+}
+
+// GET /health/live
+func (h *Handler) Live(w http.ResponseWriter, r *http.Request) {
+	response.Success(w, http.StatusOK, map[string]string{"status": StatusOK})
+}
+
+// GET /health/ready
+func (h *Handler) Ready(w http.ResponseWriter, r *http.Request) {
+	report := h.Run(r.Context())
+	status := http.StatusOK
+	if report.Status == StatusUnavailable {
+		status = http.StatusServiceUnavailable
+	}
+	response.Success(w, status, report)
+}
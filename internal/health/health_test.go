@@ -0,0 +1,88 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"pemira-api/internal/settings"
+)
+
+type maintenanceState struct{ m settings.Maintenance }
+
+func (s maintenanceState) MaintenanceState(ctx context.Context) settings.Maintenance { return s.m }
+
+func check(name string, critical bool, err error) Check {
+	return Check{Name: name, Critical: critical, Run: func(ctx context.Context) error { return err }}
+}
+
+func TestReady(t *testing.T) {
+	tests := []struct {
+		name       string
+		checks     []Check
+		wantStatus string
+		wantCode   int
+	}{
+		{"all ok", []Check{check("database", true, nil), check("storage", false, nil)}, StatusOK, http.StatusOK},
+		{"storage down", []Check{check("database", true, nil), check("storage", false, ErrStorageUnreachable)}, StatusDegraded, http.StatusOK},
+		{"database down", []Check{check("storage", false, ErrStorageUnreachable), check("database", true, ErrDatabaseUnreachable)}, StatusUnavailable, http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(tt.checks...)
+			h.SetMaintenance(maintenanceState{settings.Maintenance{Enabled: true, Message: "maintenance"}})
+
+			rec := httptest.NewRecorder()
+			h.Ready(rec, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+			if rec.Code != tt.wantCode {
+				t.Fatalf("code = %d, want %d", rec.Code, tt.wantCode)
+			}
+
+			var body struct {
+				Data Report `json:"data"`
+			}
+			if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			if body.Data.Status != tt.wantStatus || len(body.Data.Checks) != len(tt.checks) {
+				t.Fatalf("report = %+v", body.Data)
+			}
+			if body.Data.Maintenance == nil || !body.Data.Maintenance.Enabled {
+				t.Fatalf("maintenance = %+v", body.Data.Maintenance)
+			}
+		})
+	}
+}
+
+func TestSaturation(t *testing.T) {
+	if err := saturation(20, 25, 0.9); err != nil {
+		t.Fatalf("20/25: %v", err)
+	}
+	if err := saturation(23, 25, 0.9); err == nil {
+		t.Fatal("23/25 should be saturated")
+	}
+	if err := saturation(0, 0, 0.9); err != nil {
+		t.Fatalf("empty pool: %v", err)
+	}
+}
+
+func TestStorage(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/storage/v1/bucket" || r.Header.Get("apikey") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte("[]"))
+	}))
+	defer srv.Close()
+
+	if err := Storage(srv.Client(), srv.URL+"/", "secret").Run(context.Background()); err != nil {
+		t.Fatalf("ok storage: %v", err)
+	}
+	if err := Storage(srv.Client(), srv.URL, "wrong").Run(context.Background()); !errors.Is(err, ErrStorageUnreachable) {
+		t.Fatalf("bad key: err = %v", err)
+	}
+}
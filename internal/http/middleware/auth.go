@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"pemira-api/internal/auth"
+	"pemira-api/internal/errcatalog"
 	"pemira-api/internal/http/response"
 	"pemira-api/internal/shared/constants"
 	"pemira-api/internal/shared/ctxkeys"
@@ -18,13 +19,13 @@ func JWTAuth(jwtManager *auth.JWTManager) func(http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			authHeader := r.Header.Get("Authorization")
 			if authHeader == "" {
-				response.Unauthorized(w, "UNAUTHORIZED", "Missing authorization header.")
+				response.Catalog(w, authHeaderMissing)
 				return
 			}
 
 			parts := strings.Split(authHeader, " ")
 			if len(parts) != 2 || parts[0] != "Bearer" {
-				response.Unauthorized(w, "UNAUTHORIZED", "Invalid authorization header format.")
+				response.Catalog(w, authHeaderInvalid)
 				return
 			}
 
@@ -32,9 +33,9 @@ func JWTAuth(jwtManager *auth.JWTManager) func(http.Handler) http.Handler {
 			claims, err := jwtManager.ValidateAccessToken(tokenString)
 			if err != nil {
 				if errors.Is(err, auth.ErrExpiredToken) {
-					response.Unauthorized(w, "TOKEN_EXPIRED", "Token sudah kadaluarsa.")
+					response.Catalog(w, tokenExpired)
 				} else {
-					response.Unauthorized(w, "INVALID_TOKEN", "Token tidak valid.")
+					response.Unauthorized(w, "INVALID_TOKEN", errcatalog.InvalidToken)
 				}
 				return
 			}
@@ -61,7 +62,7 @@ func AuthStudentOnly(jwtManager *auth.JWTManager) func(http.Handler) http.Handle
 		return JWTAuth(jwtManager)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			role, ok := ctxkeys.GetUserRole(r.Context())
 			if !ok || role != string(constants.RoleStudent) {
-				response.Forbidden(w, "FORBIDDEN", "Akses ditolak. Hanya untuk mahasiswa.")
+				response.Catalog(w, studentsOnly)
 				return
 			}
 			next.ServeHTTP(w, r)
@@ -75,12 +76,12 @@ func AuthAdminOnly(jwtManager *auth.JWTManager) func(http.Handler) http.Handler
 		return JWTAuth(jwtManager)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			role, ok := ctxkeys.GetUserRole(r.Context())
 			if !ok {
-				response.Forbidden(w, "FORBIDDEN", "Akses ditolak.")
+				response.Forbidden(w, "FORBIDDEN", errcatalog.AccessDenied)
 				return
 			}
 
 			if role != string(constants.RoleAdmin) && role != string(constants.RoleSuperAdmin) {
-				response.Forbidden(w, "FORBIDDEN", "Akses ditolak. Hanya untuk admin.")
+				response.Catalog(w, adminsOnly)
 				return
 			}
 
@@ -95,7 +96,7 @@ func AuthTPSOperatorOnly(jwtManager *auth.JWTManager) func(http.Handler) http.Ha
 		return JWTAuth(jwtManager)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			role, ok := ctxkeys.GetUserRole(r.Context())
 			if !ok || (role != string(constants.RoleTPSOperator) && role != string(constants.RoleAdmin) && role != string(constants.RoleSuperAdmin)) {
-				response.Forbidden(w, "FORBIDDEN", "Akses ditolak. Hanya untuk operator TPS atau admin.")
+				response.Catalog(w, tpsOperatorsOnly)
 				return
 			}
 			next.ServeHTTP(w, r)
@@ -109,11 +110,11 @@ func AuthAdminOrTPSOperator(jwtManager *auth.JWTManager) func(http.Handler) http
 		return JWTAuth(jwtManager)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			role, ok := ctxkeys.GetUserRole(r.Context())
 			if !ok {
-				response.Forbidden(w, "FORBIDDEN", "Akses ditolak.")
+				response.Forbidden(w, "FORBIDDEN", errcatalog.AccessDenied)
 				return
 			}
 			if role != string(constants.RoleAdmin) && role != string(constants.RoleSuperAdmin) && role != string(constants.RoleTPSOperator) {
-				response.Forbidden(w, "FORBIDDEN", "Akses ditolak.")
+				response.Forbidden(w, "FORBIDDEN", errcatalog.AccessDenied)
 				return
 			}
 			next.ServeHTTP(w, r)
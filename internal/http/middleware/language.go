@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"net/http"
+
+	"pemira-api/internal/errcatalog"
+)
+
+// Language picks the language of error messages from Accept-Language and
+// announces it in Content-Language, where response.Error reads it. Requests
+// without a supported language keep the messages as handlers wrote them.
+func Language(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if lang := errcatalog.Negotiate(r.Header.Get("Accept-Language")); lang != "" {
+			w.Header().Set("Content-Language", string(lang))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
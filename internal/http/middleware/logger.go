@@ -5,45 +5,33 @@ import (
 	"net/http"
 	"time"
 
+	chimw "github.com/go-chi/chi/v5/middleware"
+
 	"pemira-api/internal/shared/ctxkeys"
 )
 
-type responseWriter struct {
-	http.ResponseWriter
-	status int
-	size   int
-}
-
-func (rw *responseWriter) WriteHeader(status int) {
-	rw.status = status
-	rw.ResponseWriter.WriteHeader(status)
-}
-
-func (rw *responseWriter) Write(b []byte) (int, error) {
-	size, err := rw.ResponseWriter.Write(b)
-	rw.size += size
-	return size, err
-}
-
 func RequestLogger(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
 
-		wrapped := &responseWriter{
-			ResponseWriter: w,
-			status:         200,
-		}
+		// chi's wrapper keeps http.Hijacker for the websocket endpoint
+		wrapped := chimw.NewWrapResponseWriter(w, r.ProtoMajor)
 
 		next.ServeHTTP(wrapped, r)
 
+		status := wrapped.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+
 		duration := time.Since(start)
 
 		fields := []interface{}{
 			"method", r.Method,
 			"path", r.URL.Path,
-			"status", wrapped.status,
+			"status", status,
 			"duration_ms", duration.Milliseconds(),
-			"size", wrapped.size,
+			"size", wrapped.BytesWritten(),
 			"remote_addr", r.RemoteAddr,
 			"user_agent", r.UserAgent(),
 		}
@@ -56,6 +44,6 @@ func RequestLogger(next http.Handler) http.Handler {
 			fields = append(fields, "role", role)
 		}
 
-		slog.Info("http_request", fields...)
+		slog.InfoContext(r.Context(), "http_request", fields...)
 	})
 }
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"pemira-api/internal/http/response"
+	"pemira-api/internal/settings"
+)
+
+// MaintenanceState reports the current maintenance mode
+type MaintenanceState interface {
+	MaintenanceState(ctx context.Context) settings.Maintenance
+}
+
+// ReadOnlyMode answers mutating requests with 503 while maintenance mode is
+// on. GET, HEAD and OPTIONS requests pass, as do the paths in allow (logins
+// and the maintenance switch, so admins can turn the mode off again).
+func ReadOnlyMode(state MaintenanceState, allow ...string) func(http.Handler) http.Handler {
+	allowed := make(map[string]bool, len(allow))
+	for _, path := range allow {
+		allowed[path] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				next.ServeHTTP(w, r)
+				return
+			}
+			if allowed[r.URL.Path] {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			m := state.MaintenanceState(r.Context())
+			if !m.Enabled {
+				next.ServeHTTP(w, r)
+				return
+			}
+			// Written directly: response.Error would log every rejected write
+			response.JSON(w, http.StatusServiceUnavailable, response.ErrorResponse{
+				Code:    "MAINTENANCE_MODE",
+				Message: m.Message,
+				Details: map[string]interface{}{"read_only": true},
+			})
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"net/http"
+
+	"pemira-api/internal/errcatalog"
+)
+
+// Messages the auth and permission middleware handlers answer with, each under its own code in the
+// error catalog
+var (
+	authHeaderMissing     = errcatalog.Define("AUTH_HEADER_MISSING", http.StatusUnauthorized, "Header Authorization tidak ada.", "Missing authorization header.")
+	authHeaderInvalid     = errcatalog.Define("AUTH_HEADER_INVALID", http.StatusUnauthorized, "Format header Authorization tidak valid.", "Invalid authorization header format.")
+	tokenExpired          = errcatalog.Define("TOKEN_EXPIRED", http.StatusUnauthorized, "Token sudah kadaluarsa.", "The token has expired.")
+	studentsOnly          = errcatalog.Define("STUDENTS_ONLY", http.StatusForbidden, "Akses ditolak. Hanya untuk mahasiswa.", "Access denied. Students only.")
+	adminsOnly            = errcatalog.Define("ADMINS_ONLY", http.StatusForbidden, "Akses ditolak. Hanya untuk admin.", "Access denied. Admins only.")
+	tpsOperatorsOnly      = errcatalog.Define("TPS_OPERATORS_ONLY", http.StatusForbidden, "Akses ditolak. Hanya untuk operator TPS atau admin.", "Access denied. TPS operators or admins only.")
+	permissionsLoadFailed = errcatalog.Define("PERMISSIONS_LOAD_FAILED", http.StatusInternalServerError, "Gagal memuat hak akses.", "Failed to load permissions.")
+	roleMissing           = errcatalog.Define("ROLE_MISSING", http.StatusForbidden, "Role tidak ditemukan.", "No role found.")
+	permissionDenied      = errcatalog.Define("PERMISSION_DENIED", http.StatusForbidden, "Akses ditolak. Anda tidak memiliki izin %s.", "Access denied. You do not have the %s permission.")
+)
@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"pemira-api/internal/auth"
+	"pemira-api/internal/errcatalog"
+	"pemira-api/internal/http/response"
+	"pemira-api/internal/rbac"
+	"pemira-api/internal/shared/constants"
+	"pemira-api/internal/shared/ctxkeys"
+)
+
+// Authorize validates the JWT, loads the caller's role grants and rejects
+// accounts without any staff permission. Routes behind it pick the exact
+// permission with RequirePermission.
+func Authorize(jwtManager *auth.JWTManager, authz *rbac.Service) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return JWTAuth(jwtManager)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			userID, _ := ctxkeys.GetUserID(ctx)
+			role, _ := ctxkeys.GetUserRole(ctx)
+			var tpsID *int64
+			if id, ok := ctxkeys.GetTPSID(ctx); ok {
+				tpsID = &id
+			}
+
+			principal, err := authz.Principal(ctx, userID, constants.Role(role), tpsID)
+			if err != nil {
+				response.Catalog(w, permissionsLoadFailed)
+				return
+			}
+			if !principal.IsStaff() {
+				response.Forbidden(w, "FORBIDDEN", errcatalog.AccessDenied)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(rbac.WithPrincipal(ctx, principal)))
+		}))
+	}
+}
+
+// RequirePermission allows the request when one of the caller's grants holds
+// perm for the election and TPS named in the route ({electionID}, {tpsID}).
+// Use it on routes, or on subrouters whose pattern contains those params, so
+// chi has resolved them by the time it runs.
+func RequirePermission(perm rbac.Permission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, ok := rbac.PrincipalFromContext(r.Context())
+			if !ok || !principal.Can(perm, routeScope(r)) {
+				response.Catalog(w, permissionDenied, perm)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func routeScope(r *http.Request) rbac.Scope {
+	var scope rbac.Scope
+	if id, err := strconv.ParseInt(chi.URLParam(r, "electionID"), 10, 64); err == nil {
+		scope.ElectionID = &id
+	}
+	if id, err := strconv.ParseInt(chi.URLParam(r, "tpsID"), 10, 64); err == nil {
+		scope.TPSID = &id
+	}
+	return scope
+}
@@ -3,6 +3,7 @@ package middleware
 import (
 	"net/http"
 
+	"pemira-api/internal/errcatalog"
 	"pemira-api/internal/http/response"
 	"pemira-api/internal/shared/constants"
 	"pemira-api/internal/shared/ctxkeys"
@@ -11,20 +12,20 @@ import (
 func RequireRole(roles ...constants.Role) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			userRole, ok := r.Context().Value(ctxkeys.UserRoleKey).(constants.Role)
+			role, ok := ctxkeys.GetUserRole(r.Context())
 			if !ok {
-				response.Forbidden(w, "FORBIDDEN", "No role found")
+				response.Catalog(w, roleMissing)
 				return
 			}
 
-			for _, role := range roles {
-				if userRole == role {
+			for _, allowed := range roles {
+				if constants.Role(role) == allowed {
 					next.ServeHTTP(w, r)
 					return
 				}
 			}
 
-			response.Forbidden(w, "FORBIDDEN", "Insufficient permissions")
+			response.Forbidden(w, "FORBIDDEN", errcatalog.AccessDenied)
 		})
 	}
 }
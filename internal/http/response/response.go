@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"log/slog"
 	"net/http"
+
+	"pemira-api/internal/errcatalog"
 )
 
 type ErrorResponse struct {
@@ -26,7 +28,15 @@ func Success(w http.ResponseWriter, statusCode int, data interface{}) {
 	JSON(w, statusCode, SuccessResponse{Data: data})
 }
 
-func Error(w http.ResponseWriter, statusCode int, code, message string, details interface{}) {
+// Error writes an error response with msg in the language
+// middleware.Language negotiated. The fields a validation message names are
+// sent in details when the caller passes none.
+func Error(w http.ResponseWriter, statusCode int, code string, msg errcatalog.Message, details interface{}) {
+	if details == nil && len(msg.Fields) > 0 {
+		details = map[string]interface{}{"fields": msg.Fields}
+	}
+	w.Header().Add("Vary", "Accept-Language")
+	message := msg.Text(errcatalog.Parse(w.Header().Get("Content-Language")))
 	if statusCode >= 500 {
 		slog.Error("http error response", "status", statusCode, "code", code, "message", message)
 	}
@@ -37,30 +47,51 @@ func Error(w http.ResponseWriter, statusCode int, code, message string, details
 	})
 }
 
-func BadRequest(w http.ResponseWriter, code, message string) {
-	Error(w, http.StatusBadRequest, code, message, nil)
+// DomainError writes the status, code and message errcatalog has for err and
+// reports whether err is in the catalog
+func DomainError(w http.ResponseWriter, err error) bool {
+	e, ok := errcatalog.Lookup(err)
+	if !ok {
+		return false
+	}
+	Error(w, e.Status, e.Code, e.Message(), nil)
+	return true
+}
+
+// Catalog writes a message defined in errcatalog, formatting args into its
+// texts when given
+func Catalog(w http.ResponseWriter, e errcatalog.Entry, args ...any) {
+	msg := e.Message()
+	if len(args) > 0 {
+		msg = e.Messagef(args...)
+	}
+	Error(w, e.Status, e.Code, msg, nil)
+}
+
+func BadRequest(w http.ResponseWriter, code string, msg errcatalog.Message) {
+	Error(w, http.StatusBadRequest, code, msg, nil)
 }
 
-func Unauthorized(w http.ResponseWriter, code, message string) {
-	Error(w, http.StatusUnauthorized, code, message, nil)
+func Unauthorized(w http.ResponseWriter, code string, msg errcatalog.Message) {
+	Error(w, http.StatusUnauthorized, code, msg, nil)
 }
 
-func Forbidden(w http.ResponseWriter, code, message string) {
-	Error(w, http.StatusForbidden, code, message, nil)
+func Forbidden(w http.ResponseWriter, code string, msg errcatalog.Message) {
+	Error(w, http.StatusForbidden, code, msg, nil)
 }
 
-func NotFound(w http.ResponseWriter, code, message string) {
-	Error(w, http.StatusNotFound, code, message, nil)
+func NotFound(w http.ResponseWriter, code string, msg errcatalog.Message) {
+	Error(w, http.StatusNotFound, code, msg, nil)
 }
 
-func InternalServerError(w http.ResponseWriter, code, message string) {
-	Error(w, http.StatusInternalServerError, code, message, nil)
+func InternalServerError(w http.ResponseWriter, code string, msg errcatalog.Message) {
+	Error(w, http.StatusInternalServerError, code, msg, nil)
 }
 
-func Conflict(w http.ResponseWriter, code, message string) {
-	Error(w, http.StatusConflict, code, message, nil)
+func Conflict(w http.ResponseWriter, code string, msg errcatalog.Message) {
+	Error(w, http.StatusConflict, code, msg, nil)
 }
 
-func UnprocessableEntity(w http.ResponseWriter, code, message string) {
-	Error(w, http.StatusUnprocessableEntity, code, message, nil)
+func UnprocessableEntity(w http.ResponseWriter, code string, msg errcatalog.Message) {
+	Error(w, http.StatusUnprocessableEntity, code, msg, nil)
 }
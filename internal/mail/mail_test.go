@@ -0,0 +1,37 @@
+package mail
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildMessage(t *testing.T) {
+	raw, err := buildMessage("PEMIRA <no-reply@kampus.ac.id>", Message{
+		To:      "ani@student.kampus.ac.id",
+		Subject: "Kode verifikasi",
+		Body:    "Kode: 123456\nBerlaku 15 menit.",
+	}, time.Unix(0, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := string(raw)
+	for _, want := range []string{
+		"From: PEMIRA <no-reply@kampus.ac.id>\r\n",
+		"To: ani@student.kampus.ac.id\r\n",
+		"@kampus.ac.id>\r\n",
+		"\r\n\r\nKode: 123456\r\nBerlaku 15 menit.",
+	} {
+		if !strings.Contains(s, want) {
+			t.Errorf("message lacks %q:\n%s", want, s)
+		}
+	}
+}
+
+func TestBuildMessageRejectsHeaderInjection(t *testing.T) {
+	_, err := buildMessage("no-reply@x", Message{To: "a@x\r\nBcc: victim@y", Subject: "s"}, time.Now())
+	if !errors.Is(err, ErrInvalidHeader) {
+		t.Fatalf("err = %v", err)
+	}
+}
@@ -0,0 +1,19 @@
+package master
+
+import (
+	"net/http"
+
+	"pemira-api/internal/errcatalog"
+)
+
+// The API codes and messages of the roster errors; writeRosterError writes
+// them
+func init() {
+	errcatalog.Register(
+		errcatalog.Entry{Err: ErrRosterEmpty, Code: "ROSTER_EMPTY", Status: http.StatusUnprocessableEntity, ID: "Roster tidak berisi baris yang valid.", EN: "The roster contains no valid rows."},
+		errcatalog.Entry{Err: ErrRosterRejectedRows, Code: "ROSTER_ROWS_REJECTED", Status: http.StatusUnprocessableEntity, ID: "Sinkronisasi penuh ditolak karena ada baris yang ditolak; perbaiki barisnya atau gunakan mode=partial.", EN: "Full sync refused because some rows were rejected; fix them or use mode=partial."},
+		errcatalog.Entry{Err: ErrRosterSourceDisabled, Code: "ROSTER_SOURCE_DISABLED", Status: http.StatusNotFound, ID: "Sumber roster belum dikonfigurasi; unggah file sebagai gantinya.", EN: "The roster source is not configured; upload a file instead."},
+		errcatalog.Entry{Err: ErrRosterSourceUnavailable, Code: "ROSTER_SOURCE_UNAVAILABLE", Status: http.StatusBadGateway, ID: "Sumber roster tidak dapat dibaca.", EN: "The roster source could not be read."},
+		errcatalog.Entry{Err: ErrRosterFormat, Code: "VALIDATION_ERROR", Status: http.StatusBadRequest, ID: "File roster harus .csv atau .json.", EN: "The roster file must be .csv or .json."},
+	)
+}
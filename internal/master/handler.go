@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"pemira-api/internal/errcatalog"
 	"pemira-api/internal/http/response"
 )
 
@@ -18,7 +19,7 @@ func NewHandler(service *Service) *Handler {
 func (h *Handler) GetFacultyPrograms(w http.ResponseWriter, r *http.Request) {
 	options, err := h.service.GetFacultyProgramOptions(r.Context())
 	if err != nil {
-		response.InternalServerError(w, "DATABASE_ERROR", "Failed to fetch faculty programs")
+		response.Catalog(w, facultyProgramsFetchFailed)
 		return
 	}
 
@@ -30,7 +31,7 @@ func (h *Handler) GetFacultyPrograms(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) GetFaculties(w http.ResponseWriter, r *http.Request) {
 	faculties, err := h.service.GetAllFaculties(r.Context())
 	if err != nil {
-		response.InternalServerError(w, "DATABASE_ERROR", "Failed to fetch faculties")
+		response.Catalog(w, facultiesFetchFailed)
 		return
 	}
 
@@ -48,7 +49,7 @@ func (h *Handler) GetStudyPrograms(w http.ResponseWriter, r *http.Request) {
 	if facultyIDStr != "" {
 		facultyID, parseErr := strconv.ParseInt(facultyIDStr, 10, 64)
 		if parseErr != nil {
-			response.BadRequest(w, "VALIDATION_ERROR", "Invalid faculty_id")
+			response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("faculty_id"))
 			return
 		}
 		programs, err = h.service.GetStudyProgramsByFaculty(r.Context(), facultyID)
@@ -57,7 +58,7 @@ func (h *Handler) GetStudyPrograms(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err != nil {
-		response.InternalServerError(w, "DATABASE_ERROR", "Failed to fetch study programs")
+		response.Catalog(w, studyProgramsFetchFailed)
 		return
 	}
 
@@ -69,7 +70,7 @@ func (h *Handler) GetStudyPrograms(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) GetLecturerUnits(w http.ResponseWriter, r *http.Request) {
 	units, err := h.service.GetAllLecturerUnits(r.Context())
 	if err != nil {
-		response.InternalServerError(w, "DATABASE_ERROR", "Failed to fetch lecturer units")
+		response.Catalog(w, lecturerUnitsFetchFailed)
 		return
 	}
 
@@ -91,7 +92,7 @@ func (h *Handler) GetLecturerPositions(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err != nil {
-		response.InternalServerError(w, "DATABASE_ERROR", "Failed to fetch lecturer positions")
+		response.Catalog(w, lecturerPositionsFetchFailed)
 		return
 	}
 
@@ -103,7 +104,7 @@ func (h *Handler) GetLecturerPositions(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) GetStaffUnits(w http.ResponseWriter, r *http.Request) {
 	units, err := h.service.GetAllStaffUnits(r.Context())
 	if err != nil {
-		response.InternalServerError(w, "DATABASE_ERROR", "Failed to fetch staff units")
+		response.Catalog(w, staffUnitsFetchFailed)
 		return
 	}
 
@@ -115,7 +116,7 @@ func (h *Handler) GetStaffUnits(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) GetStaffPositions(w http.ResponseWriter, r *http.Request) {
 	positions, err := h.service.GetAllStaffPositions(r.Context())
 	if err != nil {
-		response.InternalServerError(w, "DATABASE_ERROR", "Failed to fetch staff positions")
+		response.Catalog(w, staffPositionsFetchFailed)
 		return
 	}
 
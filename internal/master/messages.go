@@ -0,0 +1,23 @@
+package master
+
+import (
+	"net/http"
+
+	"pemira-api/internal/errcatalog"
+)
+
+// Messages the master data handlers answer with, each under its own code in the
+// error catalog
+var (
+	facultyProgramsFetchFailed   = errcatalog.Define("FACULTY_PROGRAMS_FETCH_FAILED", http.StatusInternalServerError, "Gagal mengambil daftar fakultas dan program studi.", "Failed to fetch faculty programs.")
+	facultiesFetchFailed         = errcatalog.Define("FACULTIES_FETCH_FAILED", http.StatusInternalServerError, "Gagal mengambil daftar fakultas.", "Failed to fetch faculties.")
+	studyProgramsFetchFailed     = errcatalog.Define("STUDY_PROGRAMS_FETCH_FAILED", http.StatusInternalServerError, "Gagal mengambil daftar program studi.", "Failed to fetch study programs.")
+	lecturerUnitsFetchFailed     = errcatalog.Define("LECTURER_UNITS_FETCH_FAILED", http.StatusInternalServerError, "Gagal mengambil daftar unit dosen.", "Failed to fetch lecturer units.")
+	lecturerPositionsFetchFailed = errcatalog.Define("LECTURER_POSITIONS_FETCH_FAILED", http.StatusInternalServerError, "Gagal mengambil daftar jabatan dosen.", "Failed to fetch lecturer positions.")
+	staffUnitsFetchFailed        = errcatalog.Define("STAFF_UNITS_FETCH_FAILED", http.StatusInternalServerError, "Gagal mengambil daftar unit staf.", "Failed to fetch staff units.")
+	staffPositionsFetchFailed    = errcatalog.Define("STAFF_POSITIONS_FETCH_FAILED", http.StatusInternalServerError, "Gagal mengambil daftar jabatan staf.", "Failed to fetch staff positions.")
+	rosterFetchFailed            = errcatalog.Define("ROSTER_FETCH_FAILED", http.StatusInternalServerError, "Gagal mengambil roster.", "Failed to fetch the roster.")
+	rosterSyncsFetchFailed       = errcatalog.Define("ROSTER_SYNCS_FETCH_FAILED", http.StatusInternalServerError, "Gagal mengambil riwayat sinkronisasi roster.", "Failed to fetch roster syncs.")
+	rosterSyncFailed             = errcatalog.Define("ROSTER_SYNC_FAILED", http.StatusInternalServerError, "Gagal menyinkronkan roster.", "Failed to sync the roster.")
+	rosterFileInvalid            = errcatalog.Define("ROSTER_FILE_INVALID", http.StatusBadRequest, "File roster tidak valid.", "The roster file is not valid.")
+)
@@ -19,4 +19,11 @@ type Repository interface {
 	GetLecturerPositionByName(ctx context.Context, name string) (*LecturerPosition, error)
 	GetStaffUnitByName(ctx context.Context, name string) (*StaffUnit, error)
 	GetStaffPositionByName(ctx context.Context, name string) (*StaffPosition, error)
+
+	// Academic roster
+	SyncRoster(ctx context.Context, run *RosterSyncRun, records []RosterRecord, removeTypes []string) error
+	ListRoster(ctx context.Context, filter RosterFilter) ([]RosterEntry, int64, error)
+	ListRosterEntries(ctx context.Context) ([]RosterEntry, error)
+	GetRosterEntry(ctx context.Context, voterType, identifier string) (*RosterEntry, error)
+	ListRosterSyncRuns(ctx context.Context, limit int) ([]RosterSyncRun, error)
 }
@@ -0,0 +1,253 @@
+package master
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const rosterColumnsSelect = `
+	id, voter_type, identifier, name, email,
+	faculty_code, faculty_name, study_program_code, study_program_name,
+	cohort_year, semester, academic_status::text, raw_status,
+	source, synced_at, removed_at, created_at, updated_at
+`
+
+// SyncRoster upserts the records in one transaction and, when removeTypes is
+// set, marks roster entries of those types that the batch did not contain as
+// removed. Counts are written back to run.
+func (r *PgxRepository) SyncRoster(ctx context.Context, run *RosterSyncRun, records []RosterRecord, removeTypes []string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// updated_at only moves when a field changed, so it doubles as the
+	// change marker; synced_at always moves so untouched rows can be found.
+	const upsert = `
+		INSERT INTO academic_roster (
+			voter_type, identifier, name, email,
+			faculty_code, faculty_name, study_program_code, study_program_name,
+			cohort_year, semester, academic_status, raw_status, source
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		ON CONFLICT (voter_type, identifier) DO UPDATE SET
+			name = EXCLUDED.name,
+			email = EXCLUDED.email,
+			faculty_code = EXCLUDED.faculty_code,
+			faculty_name = EXCLUDED.faculty_name,
+			study_program_code = EXCLUDED.study_program_code,
+			study_program_name = EXCLUDED.study_program_name,
+			cohort_year = EXCLUDED.cohort_year,
+			semester = EXCLUDED.semester,
+			academic_status = EXCLUDED.academic_status,
+			raw_status = EXCLUDED.raw_status,
+			source = EXCLUDED.source,
+			synced_at = NOW(),
+			removed_at = NULL,
+			updated_at = CASE
+				WHEN academic_roster.removed_at IS NOT NULL
+				  OR (academic_roster.name, academic_roster.email,
+				      academic_roster.faculty_code, academic_roster.faculty_name,
+				      academic_roster.study_program_code, academic_roster.study_program_name,
+				      academic_roster.cohort_year, academic_roster.semester,
+				      academic_roster.academic_status)
+				     IS DISTINCT FROM
+				     (EXCLUDED.name, EXCLUDED.email,
+				      EXCLUDED.faculty_code, EXCLUDED.faculty_name,
+				      EXCLUDED.study_program_code, EXCLUDED.study_program_name,
+				      EXCLUDED.cohort_year, EXCLUDED.semester,
+				      EXCLUDED.academic_status)
+				THEN NOW()
+				ELSE academic_roster.updated_at
+			END
+		RETURNING (xmax = 0) AS inserted, updated_at = NOW() AS changed
+	`
+
+	batch := &pgx.Batch{}
+	for _, rec := range records {
+		batch.Queue(upsert,
+			rec.VoterType, rec.Identifier, rec.Name, rec.Email,
+			rec.FacultyCode, rec.FacultyName, rec.StudyProgramCode, rec.StudyProgramName,
+			rec.CohortYear, rec.Semester, rec.AcademicStatus, rec.RawStatus, run.Source,
+		)
+	}
+	results := tx.SendBatch(ctx, batch)
+	for _, rec := range records {
+		var inserted, changed bool
+		if err := results.QueryRow().Scan(&inserted, &changed); err != nil {
+			results.Close()
+			return fmt.Errorf("upsert roster %s %s: %w", rec.VoterType, rec.Identifier, err)
+		}
+		switch {
+		case inserted:
+			run.Inserted++
+		case changed:
+			run.Updated++
+		default:
+			run.Unchanged++
+		}
+	}
+	if err := results.Close(); err != nil {
+		return err
+	}
+
+	if len(removeTypes) > 0 {
+		tag, err := tx.Exec(ctx, `
+			UPDATE academic_roster
+			SET removed_at = NOW(), updated_at = NOW()
+			WHERE voter_type = ANY($1) AND removed_at IS NULL AND synced_at < NOW()
+		`, removeTypes)
+		if err != nil {
+			return fmt.Errorf("mark removed roster entries: %w", err)
+		}
+		run.Removed = int(tag.RowsAffected())
+	}
+
+	errs, err := json.Marshal(run.Errors)
+	if err != nil {
+		return err
+	}
+	if run.Errors == nil {
+		errs = []byte("[]")
+	}
+	err = tx.QueryRow(ctx, `
+		INSERT INTO roster_sync_runs (
+			source, full_sync, voter_types, total_rows,
+			inserted, updated, unchanged, removed, rejected, errors, created_by
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id, created_at
+	`, run.Source, run.Full, run.VoterTypes, run.TotalRows,
+		run.Inserted, run.Updated, run.Unchanged, run.Removed, run.Rejected, errs, run.CreatedBy,
+	).Scan(&run.ID, &run.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("record roster sync: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *PgxRepository) ListRoster(ctx context.Context, filter RosterFilter) ([]RosterEntry, int64, error) {
+	var (
+		where []string
+		args  []interface{}
+	)
+	add := func(cond string, arg interface{}) {
+		args = append(args, arg)
+		where = append(where, fmt.Sprintf(cond, len(args)))
+	}
+	if !filter.IncludeRemoved {
+		where = append(where, "removed_at IS NULL")
+	}
+	if filter.VoterType != "" {
+		add("voter_type = $%d", filter.VoterType)
+	}
+	if filter.AcademicStatus != "" {
+		add("academic_status::text = $%d", filter.AcademicStatus)
+	}
+	if filter.FacultyCode != "" {
+		add("faculty_code = $%d", filter.FacultyCode)
+	}
+	if filter.CohortYear != nil {
+		add("cohort_year = $%d", *filter.CohortYear)
+	}
+	if filter.Search != "" {
+		args = append(args, "%"+filter.Search+"%")
+		where = append(where, fmt.Sprintf("(identifier ILIKE $%d OR name ILIKE $%d)", len(args), len(args)))
+	}
+
+	whereSQL := ""
+	if len(where) > 0 {
+		whereSQL = "WHERE " + strings.Join(where, " AND ")
+	}
+
+	var total int64
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM academic_roster `+whereSQL, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	args = append(args, filter.Limit, filter.Offset)
+	query := fmt.Sprintf(`SELECT %s FROM academic_roster %s ORDER BY voter_type, identifier LIMIT $%d OFFSET $%d`,
+		rosterColumnsSelect, whereSQL, len(args)-1, len(args))
+	entries, err := r.queryRoster(ctx, query, args...)
+	return entries, total, err
+}
+
+// ListRosterEntries returns the whole roster, removed entries included
+func (r *PgxRepository) ListRosterEntries(ctx context.Context) ([]RosterEntry, error) {
+	return r.queryRoster(ctx, `SELECT `+rosterColumnsSelect+` FROM academic_roster ORDER BY voter_type, identifier`)
+}
+
+// GetRosterEntry returns the roster entry of an identifier; pgx.ErrNoRows
+// when there is none or it was removed
+func (r *PgxRepository) GetRosterEntry(ctx context.Context, voterType, identifier string) (*RosterEntry, error) {
+	entries, err := r.queryRoster(ctx, `SELECT `+rosterColumnsSelect+` FROM academic_roster
+		WHERE voter_type = $1 AND identifier = $2 AND removed_at IS NULL`, voterType, identifier)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, pgx.ErrNoRows
+	}
+	return &entries[0], nil
+}
+
+func (r *PgxRepository) queryRoster(ctx context.Context, query string, args ...interface{}) ([]RosterEntry, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []RosterEntry
+	for rows.Next() {
+		var e RosterEntry
+		if err := rows.Scan(
+			&e.ID, &e.VoterType, &e.Identifier, &e.Name, &e.Email,
+			&e.FacultyCode, &e.FacultyName, &e.StudyProgramCode, &e.StudyProgramName,
+			&e.CohortYear, &e.Semester, &e.AcademicStatus, &e.RawStatus,
+			&e.Source, &e.SyncedAt, &e.RemovedAt, &e.CreatedAt, &e.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+func (r *PgxRepository) ListRosterSyncRuns(ctx context.Context, limit int) ([]RosterSyncRun, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, source, full_sync, voter_types, total_rows,
+		       inserted, updated, unchanged, removed, rejected, errors, created_by, created_at
+		FROM roster_sync_runs
+		ORDER BY created_at DESC
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var runs []RosterSyncRun
+	for rows.Next() {
+		var (
+			run  RosterSyncRun
+			errs []byte
+		)
+		if err := rows.Scan(
+			&run.ID, &run.Source, &run.Full, &run.VoterTypes, &run.TotalRows,
+			&run.Inserted, &run.Updated, &run.Unchanged, &run.Removed, &run.Rejected,
+			&errs, &run.CreatedBy, &run.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(errs, &run.Errors); err != nil {
+			return nil, err
+		}
+		runs = append(runs, run)
+	}
+	return runs, rows.Err()
+}
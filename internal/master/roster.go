@@ -0,0 +1,327 @@
+package master
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrRosterEmpty             = errors.New("roster contains no valid rows")
+	ErrRosterRejectedRows      = errors.New("full roster sync refused: some rows were rejected")
+	ErrRosterSourceUnavailable = errors.New("roster source unavailable")
+	ErrRosterSourceDisabled    = errors.New("roster source not configured")
+	ErrRosterFormat            = errors.New("unsupported roster format")
+)
+
+// Roster voter types, matching voters.voter_type
+const (
+	RosterStudent  = "STUDENT"
+	RosterLecturer = "LECTURER"
+	RosterStaff    = "STAFF"
+)
+
+// maxStoredRowErrors caps how many rejected rows a sync run keeps
+const maxStoredRowErrors = 100
+
+// RosterEntry is one person in the academic roster. Identifier holds the
+// NIM for students, the NIDN for lecturers and the NIP for staff.
+type RosterEntry struct {
+	ID               int64      `json:"id"`
+	VoterType        string     `json:"voter_type"`
+	Identifier       string     `json:"identifier"`
+	Name             string     `json:"name"`
+	Email            *string    `json:"email,omitempty"`
+	FacultyCode      *string    `json:"faculty_code,omitempty"`
+	FacultyName      *string    `json:"faculty_name,omitempty"`
+	StudyProgramCode *string    `json:"study_program_code,omitempty"`
+	StudyProgramName *string    `json:"study_program_name,omitempty"`
+	CohortYear       *int       `json:"cohort_year,omitempty"`
+	Semester         *int       `json:"semester,omitempty"`
+	AcademicStatus   string     `json:"academic_status"`
+	RawStatus        *string    `json:"raw_status,omitempty"`
+	Source           string     `json:"source"`
+	SyncedAt         time.Time  `json:"synced_at"`
+	RemovedAt        *time.Time `json:"removed_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// RosterRecord is a validated row from a roster file or HTTP source.
+type RosterRecord struct {
+	VoterType        string
+	Identifier       string
+	Name             string
+	Email            *string
+	FacultyCode      *string
+	FacultyName      *string
+	StudyProgramCode *string
+	StudyProgramName *string
+	CohortYear       *int
+	Semester         *int
+	AcademicStatus   string
+	RawStatus        *string
+}
+
+// RosterRowError reports a rejected row. Row counts data rows from 1.
+type RosterRowError struct {
+	Row        int    `json:"row"`
+	Identifier string `json:"identifier,omitempty"`
+	Message    string `json:"message"`
+}
+
+type RosterSyncRun struct {
+	ID         int64            `json:"id"`
+	Source     string           `json:"source"`
+	Full       bool             `json:"full"`
+	VoterTypes []string         `json:"voter_types"`
+	TotalRows  int              `json:"total_rows"`
+	Inserted   int              `json:"inserted"`
+	Updated    int              `json:"updated"`
+	Unchanged  int              `json:"unchanged"`
+	Removed    int              `json:"removed"`
+	Rejected   int              `json:"rejected"`
+	Errors     []RosterRowError `json:"errors,omitempty"`
+	CreatedBy  *int64           `json:"created_by,omitempty"`
+	CreatedAt  time.Time        `json:"created_at"`
+}
+
+type RosterFilter struct {
+	VoterType      string
+	AcademicStatus string
+	FacultyCode    string
+	CohortYear     *int
+	Search         string
+	IncludeRemoved bool
+	Limit          int
+	Offset         int
+}
+
+// academicStatusAliases maps the spellings campus systems use to the
+// academic_status enum
+var academicStatusAliases = map[string]string{
+	"ACTIVE": "ACTIVE", "AKTIF": "ACTIVE", "A": "ACTIVE",
+	"ON_LEAVE": "ON_LEAVE", "CUTI": "ON_LEAVE", "C": "ON_LEAVE",
+	"GRADUATED": "GRADUATED", "LULUS": "GRADUATED", "L": "GRADUATED",
+	"DROPPED": "DROPPED", "DROP_OUT": "DROPPED", "DO": "DROPPED", "KELUAR": "DROPPED",
+	"MENGUNDURKAN_DIRI": "DROPPED", "D": "DROPPED", "K": "DROPPED",
+	"INACTIVE": "INACTIVE", "NON_AKTIF": "INACTIVE", "NONAKTIF": "INACTIVE",
+	"TIDAK_AKTIF": "INACTIVE", "N": "INACTIVE",
+}
+
+var voterTypeAliases = map[string]string{
+	"STUDENT": RosterStudent, "MAHASISWA": RosterStudent,
+	"LECTURER": RosterLecturer, "DOSEN": RosterLecturer,
+	"STAFF": RosterStaff, "TENDIK": RosterStaff, "PEGAWAI": RosterStaff,
+}
+
+// NormalizeAcademicStatus maps a free-text status ("Aktif", "cuti", "DO") to
+// the academic_status enum. An empty status counts as ACTIVE.
+func NormalizeAcademicStatus(raw string) (string, bool) {
+	key := normalizeKey(raw)
+	if key == "" {
+		return "ACTIVE", true
+	}
+	status, ok := academicStatusAliases[strings.ToUpper(key)]
+	return status, ok
+}
+
+// NormalizeVoterType maps "Mahasiswa", "dosen", "tendik" and the like to a
+// roster voter type.
+func NormalizeVoterType(raw string) (string, bool) {
+	t, ok := voterTypeAliases[strings.ToUpper(normalizeKey(raw))]
+	return t, ok
+}
+
+// rosterColumns maps accepted column names to record fields
+var rosterColumns = map[string]string{
+	"voter_type": "voter_type", "type": "voter_type", "jenis": "voter_type",
+	"identifier": "identifier", "nim": "identifier", "nidn": "identifier", "nip": "identifier",
+	"name": "name", "nama": "name", "email": "email",
+	"faculty_code": "faculty_code", "kode_fakultas": "faculty_code",
+	"faculty_name": "faculty_name", "faculty": "faculty_name", "fakultas": "faculty_name",
+	"study_program_code": "study_program_code", "kode_prodi": "study_program_code",
+	"study_program_name": "study_program_name", "study_program": "study_program_name", "prodi": "study_program_name",
+	"cohort_year": "cohort_year", "angkatan": "cohort_year", "semester": "semester",
+	"academic_status": "academic_status", "status": "academic_status", "status_akademik": "academic_status",
+}
+
+// ParseRoster reads a roster in "csv" or "json" format. Rows failing
+// validation are returned as row errors; defaultType applies to rows that
+// carry no voter type.
+func ParseRoster(r io.Reader, format, defaultType string) ([]RosterRecord, []RosterRowError, error) {
+	var (
+		rows []map[string]string
+		err  error
+	)
+	switch strings.ToLower(format) {
+	case "csv":
+		rows, err = readRosterCSV(r)
+	case "json":
+		rows, err = readRosterJSON(r)
+	default:
+		return nil, nil, ErrRosterFormat
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		records  []RosterRecord
+		rejected []RosterRowError
+	)
+	for i, row := range rows {
+		rec, err := rosterRecordFromRow(row, defaultType)
+		if err != nil {
+			rejected = append(rejected, RosterRowError{Row: i + 1, Identifier: row["identifier"], Message: err.Error()})
+			continue
+		}
+		records = append(records, rec)
+	}
+	return records, rejected, nil
+}
+
+func readRosterCSV(r io.Reader) ([]map[string]string, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read csv header: %w", err)
+	}
+	fields := make([]string, len(header))
+	for i, col := range header {
+		fields[i] = rosterColumns[strings.ToLower(normalizeKey(strings.TrimPrefix(col, "\ufeff")))]
+	}
+
+	var rows []map[string]string
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read csv: %w", err)
+		}
+		row := make(map[string]string, len(record))
+		for i, v := range record {
+			if i < len(fields) && fields[i] != "" {
+				row[fields[i]] = strings.TrimSpace(v)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// readRosterJSON accepts an array of objects or {"data": [...]}
+func readRosterJSON(r io.Reader) ([]map[string]string, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var items []map[string]any
+	if err := json.Unmarshal(raw, &items); err != nil {
+		var wrapped struct {
+			Data []map[string]any `json:"data"`
+		}
+		if err2 := json.Unmarshal(raw, &wrapped); err2 != nil {
+			return nil, fmt.Errorf("decode json roster: %w", err)
+		}
+		items = wrapped.Data
+	}
+
+	rows := make([]map[string]string, 0, len(items))
+	for _, item := range items {
+		row := make(map[string]string, len(item))
+		for k, v := range item {
+			field := rosterColumns[strings.ToLower(normalizeKey(k))]
+			if field == "" || v == nil {
+				continue
+			}
+			switch val := v.(type) {
+			case string:
+				row[field] = strings.TrimSpace(val)
+			case float64:
+				row[field] = strconv.FormatFloat(val, 'f', -1, 64)
+			default:
+				row[field] = fmt.Sprint(val)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func rosterRecordFromRow(row map[string]string, defaultType string) (RosterRecord, error) {
+	rec := RosterRecord{
+		Identifier: row["identifier"],
+		Name:       row["name"],
+	}
+	if rec.Identifier == "" {
+		return rec, errors.New("identifier (nim/nidn/nip) wajib diisi")
+	}
+	if rec.Name == "" {
+		return rec, errors.New("name wajib diisi")
+	}
+
+	rawType := row["voter_type"]
+	if rawType == "" {
+		rawType = defaultType
+	}
+	voterType, ok := NormalizeVoterType(rawType)
+	if !ok {
+		return rec, fmt.Errorf("voter_type %q tidak dikenal", rawType)
+	}
+	rec.VoterType = voterType
+
+	status, ok := NormalizeAcademicStatus(row["academic_status"])
+	if !ok {
+		return rec, fmt.Errorf("academic_status %q tidak dikenal", row["academic_status"])
+	}
+	rec.AcademicStatus = status
+	rec.RawStatus = optionalString(row["academic_status"])
+
+	if v := row["cohort_year"]; v != "" {
+		year, err := strconv.Atoi(v)
+		if err != nil || year < 1900 || year > 2200 {
+			return rec, fmt.Errorf("cohort_year %q tidak valid", v)
+		}
+		rec.CohortYear = &year
+	}
+	if v := row["semester"]; v != "" {
+		sem, err := strconv.Atoi(v)
+		if err != nil || sem < 1 || sem > 20 {
+			return rec, fmt.Errorf("semester %q tidak valid", v)
+		}
+		rec.Semester = &sem
+	}
+
+	rec.Email = optionalString(row["email"])
+	rec.FacultyCode = optionalString(strings.ToUpper(row["faculty_code"]))
+	rec.FacultyName = optionalString(row["faculty_name"])
+	rec.StudyProgramCode = optionalString(strings.ToUpper(row["study_program_code"]))
+	rec.StudyProgramName = optionalString(row["study_program_name"])
+	return rec, nil
+}
+
+// normalizeKey turns "Status Akademik" / "non-aktif" into "Status_Akademik" /
+// "non_aktif"
+func normalizeKey(s string) string {
+	s = strings.TrimSpace(s)
+	s = strings.ReplaceAll(s, "-", "_")
+	return strings.Join(strings.Fields(s), "_")
+}
+
+func optionalString(s string) *string {
+	if s = strings.TrimSpace(s); s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,156 @@
+package master
+
+import (
+	"log/slog"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"pemira-api/internal/errcatalog"
+	"pemira-api/internal/http/response"
+	"pemira-api/internal/shared/ctxkeys"
+)
+
+// maxRosterUploadBytes bounds roster file uploads
+const maxRosterUploadBytes = 32 << 20
+
+// ListRoster handles GET /admin/roster
+func (h *Handler) ListRoster(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	page := parsePositiveInt(q.Get("page"), 1)
+	limit := parsePositiveInt(q.Get("limit"), 50)
+
+	filter := RosterFilter{
+		VoterType:      q.Get("voter_type"),
+		AcademicStatus: q.Get("academic_status"),
+		FacultyCode:    q.Get("faculty_code"),
+		Search:         q.Get("search"),
+		IncludeRemoved: q.Get("include_removed") == "true",
+		Limit:          limit,
+		Offset:         (page - 1) * limit,
+	}
+	if cy := q.Get("cohort_year"); cy != "" {
+		year, err := strconv.Atoi(cy)
+		if err != nil {
+			response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("cohort_year"))
+			return
+		}
+		filter.CohortYear = &year
+	}
+
+	entries, total, err := h.service.ListRoster(r.Context(), filter)
+	if err != nil {
+		response.Catalog(w, rosterFetchFailed)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]interface{}{
+		"data":  entries,
+		"page":  page,
+		"limit": limit,
+		"total": total,
+	})
+}
+
+// SyncRoster handles POST /admin/roster/sync. A multipart upload ("file",
+// optional "voter_type" and "mode=full|partial") syncs from the file;
+// an empty body syncs from the configured HTTP source.
+func (h *Handler) SyncRoster(w http.ResponseWriter, r *http.Request) {
+	var createdBy *int64
+	if id, ok := ctxkeys.GetUserID(r.Context()); ok {
+		createdBy = &id
+	}
+
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		run, err := h.service.SyncRosterFromSource(r.Context(), createdBy)
+		if err != nil {
+			writeRosterError(w, run, err)
+			return
+		}
+		response.JSON(w, http.StatusOK, map[string]interface{}{"data": run})
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxRosterUploadBytes)
+	if err := r.ParseMultipartForm(maxRosterUploadBytes); err != nil {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.UploadFormError)
+		return
+	}
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.FileRequired)
+		return
+	}
+	defer file.Close()
+
+	mode := strings.ToLower(r.FormValue("mode"))
+	if mode != "" && mode != "full" && mode != "partial" {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.OneOf("mode", "full", "partial"))
+		return
+	}
+	defaultType := r.FormValue("voter_type")
+	if defaultType != "" {
+		if _, ok := NormalizeVoterType(defaultType); !ok {
+			response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("voter_type"))
+			return
+		}
+	}
+
+	format := strings.TrimPrefix(strings.ToLower(filepath.Ext(header.Filename)), ".")
+	records, rejected, err := ParseRoster(file, format, defaultType)
+	if err != nil {
+		if response.DomainError(w, err) {
+			return
+		}
+		response.Error(w, rosterFileInvalid.Status, rosterFileInvalid.Code, rosterFileInvalid.Message(),
+			map[string]interface{}{"reason": err.Error()})
+		return
+	}
+
+	run, err := h.service.SyncRoster(r.Context(), RosterSyncRequest{
+		Source:    "file:" + filepath.Base(header.Filename),
+		Records:   records,
+		Rejected:  rejected,
+		Full:      mode == "full",
+		CreatedBy: createdBy,
+	})
+	if err != nil {
+		writeRosterError(w, run, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]interface{}{"data": run})
+}
+
+// ListRosterSyncRuns handles GET /admin/roster/syncs
+func (h *Handler) ListRosterSyncRuns(w http.ResponseWriter, r *http.Request) {
+	runs, err := h.service.ListRosterSyncRuns(r.Context(), parsePositiveInt(r.URL.Query().Get("limit"), 20))
+	if err != nil {
+		response.Catalog(w, rosterSyncsFetchFailed)
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]interface{}{"data": runs})
+}
+
+// writeRosterError writes the catalog entry of err (see errors.go) with the
+// sync run, when there is one, as details
+func writeRosterError(w http.ResponseWriter, run *RosterSyncRun, err error) {
+	e, ok := errcatalog.Lookup(err)
+	if !ok {
+		slog.Error("roster sync error", "err", err)
+		response.Catalog(w, rosterSyncFailed)
+		return
+	}
+	var details interface{}
+	if run != nil {
+		details = run
+	}
+	response.Error(w, e.Status, e.Code, e.Message(), details)
+}
+
+func parsePositiveInt(raw string, def int) int {
+	if v, err := strconv.Atoi(raw); err == nil && v > 0 {
+		return v
+	}
+	return def
+}
@@ -0,0 +1,129 @@
+package master
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseRosterCSV(t *testing.T) {
+	input := "\ufeffNIM,Nama,Kode Fakultas,Angkatan,Status Akademik\n" +
+		"2101001,Ani,fteknik,2021,aktif\n" +
+		"2101002,Budi,FTEKNIK,2021,Cuti\n" +
+		",Tanpa NIM,FTEKNIK,2021,AKTIF\n" +
+		"2101004,Citra,FTEKNIK,abc,AKTIF\n"
+
+	records, rejected, err := ParseRoster(strings.NewReader(input), "csv", "mahasiswa")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+	if len(rejected) != 2 || rejected[0].Row != 3 || rejected[1].Row != 4 {
+		t.Fatalf("expected rows 3 and 4 rejected, got %+v", rejected)
+	}
+
+	first := records[0]
+	if first.VoterType != RosterStudent || first.Identifier != "2101001" || first.AcademicStatus != "ACTIVE" {
+		t.Fatalf("unexpected record: %+v", first)
+	}
+	if first.FacultyCode == nil || *first.FacultyCode != "FTEKNIK" {
+		t.Fatalf("expected faculty code upper-cased, got %v", first.FacultyCode)
+	}
+	if first.CohortYear == nil || *first.CohortYear != 2021 {
+		t.Fatalf("expected cohort 2021, got %v", first.CohortYear)
+	}
+	if records[1].AcademicStatus != "ON_LEAVE" {
+		t.Fatalf("expected CUTI mapped to ON_LEAVE, got %s", records[1].AcademicStatus)
+	}
+}
+
+func TestParseRosterJSON(t *testing.T) {
+	input := `{"data": [
+		{"nidn": "0011223344", "name": "Dr. Dewi", "voter_type": "dosen", "faculty_code": "fmipa"},
+		{"nip": "198001012005011001", "nama": "Eko", "jenis": "tendik", "status": "pensiun"}
+	]}`
+
+	records, rejected, err := ParseRoster(strings.NewReader(input), "json", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(records) != 1 || records[0].VoterType != RosterLecturer || records[0].AcademicStatus != "ACTIVE" {
+		t.Fatalf("unexpected records: %+v", records)
+	}
+	if len(rejected) != 1 || rejected[0].Row != 2 {
+		t.Fatalf("expected row 2 rejected, got %+v", rejected)
+	}
+}
+
+func TestParseRosterUnknownFormat(t *testing.T) {
+	if _, _, err := ParseRoster(strings.NewReader(""), "xlsx", ""); !errors.Is(err, ErrRosterFormat) {
+		t.Fatalf("expected ErrRosterFormat, got %v", err)
+	}
+}
+
+type rosterRepoStub struct {
+	Repository
+	synced      []RosterRecord
+	removeTypes []string
+}
+
+func (r *rosterRepoStub) SyncRoster(_ context.Context, run *RosterSyncRun, records []RosterRecord, removeTypes []string) error {
+	r.synced = records
+	r.removeTypes = removeTypes
+	run.Inserted = len(records)
+	return nil
+}
+
+func TestSyncRoster(t *testing.T) {
+	records := []RosterRecord{
+		{VoterType: RosterStudent, Identifier: "1", Name: "A", AcademicStatus: "ACTIVE"},
+		{VoterType: RosterStudent, Identifier: "1", Name: "A again", AcademicStatus: "ACTIVE"},
+		{VoterType: RosterStaff, Identifier: "9", Name: "S", AcademicStatus: "ACTIVE"},
+	}
+
+	t.Run("full sync refused with rejected rows", func(t *testing.T) {
+		repo := &rosterRepoStub{}
+		run, err := NewService(repo).SyncRoster(context.Background(), RosterSyncRequest{Records: records, Full: true})
+		if !errors.Is(err, ErrRosterRejectedRows) {
+			t.Fatalf("expected ErrRosterRejectedRows, got %v", err)
+		}
+		if run.Rejected != 1 || repo.synced != nil {
+			t.Fatalf("expected duplicate rejected and nothing synced, got run %+v", run)
+		}
+	})
+
+	t.Run("partial sync keeps first duplicate", func(t *testing.T) {
+		repo := &rosterRepoStub{}
+		run, err := NewService(repo).SyncRoster(context.Background(), RosterSyncRequest{Records: records})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(repo.synced) != 2 || repo.synced[0].Name != "A" || repo.removeTypes != nil {
+			t.Fatalf("unexpected sync: %+v removeTypes=%v", repo.synced, repo.removeTypes)
+		}
+		if run.TotalRows != 3 || run.Rejected != 1 {
+			t.Fatalf("unexpected run: %+v", run)
+		}
+	})
+
+	t.Run("full sync removes only batch voter types", func(t *testing.T) {
+		repo := &rosterRepoStub{}
+		_, err := NewService(repo).SyncRoster(context.Background(), RosterSyncRequest{Records: records[:1], Full: true})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(repo.removeTypes) != 1 || repo.removeTypes[0] != RosterStudent {
+			t.Fatalf("expected STUDENT removal scope, got %v", repo.removeTypes)
+		}
+	})
+
+	t.Run("empty roster", func(t *testing.T) {
+		_, err := NewService(&rosterRepoStub{}).SyncRoster(context.Background(), RosterSyncRequest{})
+		if !errors.Is(err, ErrRosterEmpty) {
+			t.Fatalf("expected ErrRosterEmpty, got %v", err)
+		}
+	})
+}
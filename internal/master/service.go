@@ -6,7 +6,8 @@ import (
 )
 
 type Service struct {
-	repo Repository
+	repo         Repository
+	rosterSource RosterSourceConfig
 }
 
 func NewService(repo Repository) *Service {
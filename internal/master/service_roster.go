@@ -0,0 +1,214 @@
+package master
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxRosterSourceBytes bounds the roster body read from the HTTP source
+const maxRosterSourceBytes = 64 << 20
+
+// RosterSourceConfig points at the campus system that serves the roster as
+// CSV or JSON. The source must return the complete roster of every voter
+// type it lists, because its syncs are full syncs.
+type RosterSourceConfig struct {
+	URL     string
+	Token   string
+	Timeout time.Duration
+}
+
+// RosterSyncRequest is one batch to merge into the roster. A full sync marks
+// entries of the batch's voter types that are missing from it as removed,
+// so it is refused when any row was rejected.
+type RosterSyncRequest struct {
+	Source    string
+	Records   []RosterRecord
+	Rejected  []RosterRowError
+	Full      bool
+	CreatedBy *int64
+}
+
+// SetRosterSource configures the HTTP roster source
+func (s *Service) SetRosterSource(cfg RosterSourceConfig) {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = time.Minute
+	}
+	s.rosterSource = cfg
+}
+
+// RosterSourceEnabled reports whether an HTTP roster source is configured
+func (s *Service) RosterSourceEnabled() bool {
+	return s.rosterSource.URL != ""
+}
+
+// SyncRoster merges a batch into the roster and records the run
+func (s *Service) SyncRoster(ctx context.Context, req RosterSyncRequest) (*RosterSyncRun, error) {
+	records, rejected := dedupeRoster(req.Records, req.Rejected)
+
+	run := &RosterSyncRun{
+		Source:     req.Source,
+		Full:       req.Full,
+		VoterTypes: rosterVoterTypes(records),
+		TotalRows:  len(records) + len(rejected),
+		Rejected:   len(rejected),
+		Errors:     rejected,
+		CreatedBy:  req.CreatedBy,
+	}
+	if len(run.Errors) > maxStoredRowErrors {
+		run.Errors = run.Errors[:maxStoredRowErrors]
+	}
+
+	if len(records) == 0 {
+		return run, ErrRosterEmpty
+	}
+	if req.Full && len(rejected) > 0 {
+		return run, ErrRosterRejectedRows
+	}
+
+	var removeTypes []string
+	if req.Full {
+		removeTypes = run.VoterTypes
+	}
+	if err := s.repo.SyncRoster(ctx, run, records, removeTypes); err != nil {
+		return nil, fmt.Errorf("sync roster: %w", err)
+	}
+	return run, nil
+}
+
+// SyncRosterFromSource fetches the roster from the HTTP source and runs a
+// full sync with it
+func (s *Service) SyncRosterFromSource(ctx context.Context, createdBy *int64) (*RosterSyncRun, error) {
+	records, rejected, err := s.FetchRoster(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.SyncRoster(ctx, RosterSyncRequest{
+		Source:    "http",
+		Records:   records,
+		Rejected:  rejected,
+		Full:      true,
+		CreatedBy: createdBy,
+	})
+}
+
+// FetchRoster downloads and parses the roster from the HTTP source. The
+// format follows the response Content-Type (text/csv, otherwise JSON).
+func (s *Service) FetchRoster(ctx context.Context) ([]RosterRecord, []RosterRowError, error) {
+	if !s.RosterSourceEnabled() {
+		return nil, nil, ErrRosterSourceDisabled
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.rosterSource.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.rosterSource.URL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Accept", "application/json, text/csv")
+	if s.rosterSource.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.rosterSource.Token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrRosterSourceUnavailable, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("%w: status %d", ErrRosterSourceUnavailable, resp.StatusCode)
+	}
+
+	format := "json"
+	if mt, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mt == "text/csv" {
+		format = "csv"
+	}
+	return ParseRoster(io.LimitReader(resp.Body, maxRosterSourceBytes), format, "")
+}
+
+// RunRosterSync syncs from the HTTP source every interval until ctx is done.
+// A zero interval or a missing source disables the job.
+func (s *Service) RunRosterSync(ctx context.Context, interval time.Duration) {
+	if interval <= 0 || !s.RosterSourceEnabled() {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			run, err := s.SyncRosterFromSource(ctx, nil)
+			if err != nil {
+				slog.Error("roster sync failed", "err", err)
+				continue
+			}
+			slog.Info("roster synced", "inserted", run.Inserted, "updated", run.Updated, "removed", run.Removed, "rejected", run.Rejected)
+		}
+	}
+}
+
+func (s *Service) ListRoster(ctx context.Context, filter RosterFilter) ([]RosterEntry, int64, error) {
+	if filter.Limit <= 0 || filter.Limit > 500 {
+		filter.Limit = 50
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+	filter.VoterType = strings.ToUpper(strings.TrimSpace(filter.VoterType))
+	filter.AcademicStatus = strings.ToUpper(strings.TrimSpace(filter.AcademicStatus))
+	filter.FacultyCode = strings.ToUpper(strings.TrimSpace(filter.FacultyCode))
+	filter.Search = strings.TrimSpace(filter.Search)
+	return s.repo.ListRoster(ctx, filter)
+}
+
+// RosterEntries returns the whole roster, removed entries included
+func (s *Service) RosterEntries(ctx context.Context) ([]RosterEntry, error) {
+	return s.repo.ListRosterEntries(ctx)
+}
+
+func (s *Service) ListRosterSyncRuns(ctx context.Context, limit int) ([]RosterSyncRun, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	return s.repo.ListRosterSyncRuns(ctx, limit)
+}
+
+// dedupeRoster keeps the first record per (voter type, identifier) and
+// rejects later duplicates
+func dedupeRoster(records []RosterRecord, rejected []RosterRowError) ([]RosterRecord, []RosterRowError) {
+	seen := make(map[string]bool, len(records))
+	out := make([]RosterRecord, 0, len(records))
+	for _, rec := range records {
+		key := rec.VoterType + ":" + rec.Identifier
+		if seen[key] {
+			rejected = append(rejected, RosterRowError{Identifier: rec.Identifier, Message: "duplikat dalam roster"})
+			continue
+		}
+		seen[key] = true
+		out = append(out, rec)
+	}
+	return out, rejected
+}
+
+func rosterVoterTypes(records []RosterRecord) []string {
+	seen := map[string]bool{}
+	types := []string{}
+	for _, rec := range records {
+		if !seen[rec.VoterType] {
+			seen[rec.VoterType] = true
+			types = append(types, rec.VoterType)
+		}
+	}
+	sort.Strings(types)
+	return types
+}
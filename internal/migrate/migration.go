@@ -0,0 +1,133 @@
+package migrate
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNoDown         = errors.New("migration has no down file")
+	ErrUnknownVersion = errors.New("unknown migration version")
+)
+
+// noTransaction is goose's marker for statements that cannot run inside a
+// transaction block.
+const noTransaction = "-- +goose NO TRANSACTION"
+
+var fileName = regexp.MustCompile(`^(\d+)_([A-Za-z0-9_]+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version  int64
+	Name     string
+	Up       string
+	Down     string
+	UpNoTx   bool
+	DownNoTx bool
+}
+
+// Status is one migration's state. Missing marks a version recorded in the
+// database that this binary does not ship.
+type Status struct {
+	Version   int64      `json:"version"`
+	Name      string     `json:"name"`
+	Applied   bool       `json:"applied"`
+	AppliedAt *time.Time `json:"applied_at,omitempty"`
+	Missing   bool       `json:"missing,omitempty"`
+}
+
+// Load reads NNN_name.up.sql / NNN_name.down.sql pairs from the root of
+// fsys, ordered by version. Other files are ignored.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		m := fileName.FindStringSubmatch(e.Name())
+		if m == nil {
+			if strings.HasSuffix(e.Name(), ".sql") {
+				return nil, fmt.Errorf("migration %s: name must be NNN_name.up.sql or NNN_name.down.sql", e.Name())
+			}
+			continue
+		}
+
+		version, err := strconv.ParseInt(m[1], 10, 64)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration %s: invalid version", e.Name())
+		}
+		data, err := fs.ReadFile(fsys, e.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		mig := byVersion[version]
+		if mig == nil {
+			mig = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = mig
+		} else if mig.Name != m[2] {
+			return nil, fmt.Errorf("migration %s: version %d is also used by %s", e.Name(), version, mig.Name)
+		}
+
+		sql := string(data)
+		if m[3] == "up" {
+			mig.Up, mig.UpNoTx = sql, strings.Contains(sql, noTransaction)
+		} else {
+			mig.Down, mig.DownNoTx = sql, strings.Contains(sql, noTransaction)
+		}
+	}
+
+	out := make([]Migration, 0, len(byVersion))
+	for _, mig := range byVersion {
+		if mig.Up == "" {
+			return nil, fmt.Errorf("migration %03d_%s has no up file", mig.Version, mig.Name)
+		}
+		out = append(out, *mig)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Version < out[j].Version })
+	return out, nil
+}
+
+// plan returns the migrations to apply and to roll back to end at target:
+// every pending one up to target in ascending order, then every applied one
+// above it in descending order.
+func plan(migrations []Migration, applied map[int64]bool, target int64) (up, down []Migration) {
+	for _, m := range migrations {
+		if m.Version <= target && !applied[m.Version] {
+			up = append(up, m)
+		}
+	}
+	for i := len(migrations) - 1; i >= 0; i-- {
+		m := migrations[i]
+		if m.Version > target && applied[m.Version] {
+			down = append(down, m)
+		}
+	}
+	return up, down
+}
+
+func latest(migrations []Migration) int64 {
+	if len(migrations) == 0 {
+		return 0
+	}
+	return migrations[len(migrations)-1].Version
+}
+
+func find(migrations []Migration, version int64) bool {
+	for _, m := range migrations {
+		if m.Version == version {
+			return true
+		}
+	}
+	return version == 0
+}
@@ -0,0 +1,84 @@
+package migrate
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"pemira-api/migrations"
+)
+
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"002_add_b.up.sql":   {Data: []byte("-- +goose Up\n-- +goose NO TRANSACTION\nALTER TYPE x ADD VALUE 'B';")},
+		"002_add_b.down.sql": {Data: []byte("-- +goose Down\n")},
+		"001_init.up.sql":    {Data: []byte("-- +goose Up\nCREATE TABLE a ();")},
+		"001_init.down.sql":  {Data: []byte("-- +goose Down\nDROP TABLE a;")},
+		"003_no_down.up.sql": {Data: []byte("SELECT 1;")},
+		"README.md":          {Data: []byte("ignored")},
+	}
+
+	got, err := Load(fsys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 3 || got[0].Version != 1 || got[1].Version != 2 || got[2].Version != 3 {
+		t.Fatalf("unexpected order: %+v", got)
+	}
+	if got[0].Name != "init" || got[0].Down == "" || got[0].UpNoTx {
+		t.Errorf("001: %+v", got[0])
+	}
+	if !got[1].UpNoTx || got[1].DownNoTx {
+		t.Errorf("002 transaction flags: up=%v down=%v", got[1].UpNoTx, got[1].DownNoTx)
+	}
+	if got[2].Down != "" {
+		t.Errorf("003 should have no down")
+	}
+}
+
+func TestLoad_Rejects(t *testing.T) {
+	cases := map[string]fstest.MapFS{
+		"bad name":  {"1-init.up.sql": {Data: []byte("x")}},
+		"down only": {"001_init.down.sql": {Data: []byte("x")}},
+		"version reuse": {
+			"001_a.up.sql": {Data: []byte("x")},
+			"001_b.up.sql": {Data: []byte("x")},
+		},
+	}
+	for name, fsys := range cases {
+		if _, err := Load(fsys); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestPlan(t *testing.T) {
+	migs := []Migration{{Version: 1}, {Version: 2}, {Version: 3}, {Version: 4}}
+
+	up, down := plan(migs, map[int64]bool{1: true, 3: true}, 4)
+	if len(down) != 0 || len(up) != 2 || up[0].Version != 2 || up[1].Version != 4 {
+		t.Fatalf("up to 4: up=%v down=%v", up, down)
+	}
+
+	up, down = plan(migs, map[int64]bool{1: true, 2: true, 3: true}, 1)
+	if len(up) != 0 || len(down) != 2 || down[0].Version != 3 || down[1].Version != 2 {
+		t.Fatalf("down to 1: up=%v down=%v", up, down)
+	}
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	migs, err := Load(migrations.FS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(migs) == 0 {
+		t.Fatal("no embedded migrations")
+	}
+	for i, m := range migs {
+		if m.Down == "" {
+			t.Errorf("%03d_%s has no down file", m.Version, m.Name)
+		}
+		if i > 0 && m.Version != migs[i-1].Version+1 {
+			t.Errorf("gap before %03d_%s", m.Version, m.Name)
+		}
+	}
+}
@@ -0,0 +1,244 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// lockKey serialises migrators across processes (pg_advisory_lock).
+const lockKey = 0x70656d697261 // "pemira"
+
+const createVersionTable = `
+CREATE TABLE IF NOT EXISTS schema_migrations (
+    version    BIGINT PRIMARY KEY,
+    name       TEXT NOT NULL,
+    applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+)`
+
+type Migrator struct {
+	db         *pgxpool.Pool
+	migrations []Migration
+	// Logf reports each migration as it runs. Optional.
+	Logf func(format string, args ...any)
+}
+
+func New(db *pgxpool.Pool, migrations []Migration) *Migrator {
+	return &Migrator{db: db, migrations: migrations}
+}
+
+func (m *Migrator) logf(format string, args ...any) {
+	if m.Logf != nil {
+		m.Logf(format, args...)
+	}
+}
+
+// withLock runs fn on one connection holding the migration lock, after
+// making sure the version table exists.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *pgxpool.Conn) error) error {
+	conn, err := m.db.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, `SELECT pg_advisory_lock($1)`, lockKey); err != nil {
+		return fmt.Errorf("acquire migration lock: %w", err)
+	}
+	defer conn.Exec(context.Background(), `SELECT pg_advisory_unlock($1)`, lockKey)
+
+	if _, err := conn.Exec(ctx, createVersionTable); err != nil {
+		return fmt.Errorf("create schema_migrations: %w", err)
+	}
+	return fn(conn)
+}
+
+func applied(ctx context.Context, conn *pgxpool.Conn) (map[int64]time.Time, error) {
+	rows, err := conn.Query(ctx, `SELECT version, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := map[int64]time.Time{}
+	for rows.Next() {
+		var v int64
+		var at time.Time
+		if err := rows.Scan(&v, &at); err != nil {
+			return nil, err
+		}
+		out[v] = at
+	}
+	return out, rows.Err()
+}
+
+func appliedSet(a map[int64]time.Time) map[int64]bool {
+	out := make(map[int64]bool, len(a))
+	for v := range a {
+		out[v] = true
+	}
+	return out
+}
+
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var out []Status
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		a, err := applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		known := map[int64]bool{}
+		for _, mig := range m.migrations {
+			known[mig.Version] = true
+			s := Status{Version: mig.Version, Name: mig.Name}
+			if at, ok := a[mig.Version]; ok {
+				s.Applied, s.AppliedAt = true, &at
+			}
+			out = append(out, s)
+		}
+		for v, at := range a {
+			if !known[v] {
+				at := at
+				out = append(out, Status{Version: v, Applied: true, AppliedAt: &at, Missing: true})
+			}
+		}
+		sort.Slice(out, func(i, j int) bool { return out[i].Version < out[j].Version })
+		return nil
+	})
+	return out, err
+}
+
+// Up applies every pending migration.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	return m.To(ctx, latest(m.migrations))
+}
+
+// To migrates up or down so that exactly the migrations up to version are
+// applied. Version 0 rolls everything back.
+func (m *Migrator) To(ctx context.Context, version int64) ([]Migration, error) {
+	if !find(m.migrations, version) {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+	}
+
+	var done []Migration
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		a, err := applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		up, down := plan(m.migrations, appliedSet(a), version)
+		for _, mig := range down {
+			if mig.Down == "" {
+				return fmt.Errorf("%03d_%s: %w", mig.Version, mig.Name, ErrNoDown)
+			}
+		}
+		for _, mig := range down {
+			if err := m.run(ctx, conn, mig, false); err != nil {
+				return err
+			}
+			done = append(done, mig)
+		}
+		for _, mig := range up {
+			if err := m.run(ctx, conn, mig, true); err != nil {
+				return err
+			}
+			done = append(done, mig)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Down rolls back the last steps applied migrations.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var done []Migration
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		a, err := applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		var targets []Migration
+		for i := len(m.migrations) - 1; i >= 0 && len(targets) < steps; i-- {
+			if _, ok := a[m.migrations[i].Version]; ok {
+				targets = append(targets, m.migrations[i])
+			}
+		}
+		for _, mig := range targets {
+			if mig.Down == "" {
+				return fmt.Errorf("%03d_%s: %w", mig.Version, mig.Name, ErrNoDown)
+			}
+		}
+		for _, mig := range targets {
+			if err := m.run(ctx, conn, mig, false); err != nil {
+				return err
+			}
+			done = append(done, mig)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Baseline records every migration up to version as applied without running
+// it, for databases whose schema was built by hand before the version table
+// existed.
+func (m *Migrator) Baseline(ctx context.Context, version int64) (int, error) {
+	if !find(m.migrations, version) {
+		return 0, fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+	}
+
+	var n int
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		for _, mig := range m.migrations {
+			if mig.Version > version {
+				break
+			}
+			tag, err := conn.Exec(ctx, `
+INSERT INTO schema_migrations (version, name) VALUES ($1, $2)
+ON CONFLICT (version) DO NOTHING`, mig.Version, mig.Name)
+			if err != nil {
+				return err
+			}
+			n += int(tag.RowsAffected())
+		}
+		return nil
+	})
+	return n, err
+}
+
+func (m *Migrator) run(ctx context.Context, conn *pgxpool.Conn, mig Migration, up bool) error {
+	sql, noTx, dir := mig.Down, mig.DownNoTx, "down"
+	if up {
+		sql, noTx, dir = mig.Up, mig.UpNoTx, "up"
+	}
+	m.logf("%s %03d_%s", dir, mig.Version, mig.Name)
+
+	record := func(q pgx.Tx) error {
+		if up {
+			_, err := q.Exec(ctx, `INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`, mig.Version, mig.Name)
+			return err
+		}
+		_, err := q.Exec(ctx, `DELETE FROM schema_migrations WHERE version = $1`, mig.Version)
+		return err
+	}
+
+	if noTx {
+		// Files run with the simple protocol so one Exec takes every
+		// statement in them.
+		if _, err := conn.Exec(ctx, sql, pgx.QueryExecModeSimpleProtocol); err != nil {
+			return fmt.Errorf("%s %03d_%s: %w", dir, mig.Version, mig.Name, err)
+		}
+		return pgx.BeginFunc(ctx, conn, record)
+	}
+
+	return pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, sql, pgx.QueryExecModeSimpleProtocol); err != nil {
+			return fmt.Errorf("%s %03d_%s: %w", dir, mig.Version, mig.Name, err)
+		}
+		return record(tx)
+	})
+}
@@ -36,4 +36,6 @@ type LiveCountSnapshot struct {
 	Participation  ParticipationStats `json:"participation"`
 	CandidateVotes map[int64]int64    `json:"candidate_votes"`
 	TPSStats       []TPSStats         `json:"tps_stats"`
+	// ResultsEmbargoed means CandidateVotes is withheld (see internal/embargo)
+	ResultsEmbargoed bool `json:"results_embargoed"`
 }
@@ -6,6 +6,7 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	
+	"pemira-api/internal/errcatalog"
 	"pemira-api/internal/http/response"
 )
 
@@ -28,7 +29,7 @@ func (h *Handler) GetSummary(w http.ResponseWriter, r *http.Request) {
 	
 	summary, err := h.service.GetDashboardSummary(r.Context(), electionID)
 	if err != nil {
-		response.InternalServerError(w, "INTERNAL_ERROR", "Failed to fetch summary")
+		response.Catalog(w, summaryFailed)
 		return
 	}
 
@@ -38,13 +39,13 @@ func (h *Handler) GetSummary(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) GetLiveCount(w http.ResponseWriter, r *http.Request) {
 	electionID, err := strconv.ParseInt(chi.URLParam(r, "electionID"), 10, 64)
 	if err != nil {
-		response.BadRequest(w, "INVALID_REQUEST", "Invalid election ID")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.InvalidElectionID)
 		return
 	}
 
 	snapshot, err := h.service.GetLiveCountSnapshot(r.Context(), electionID)
 	if err != nil {
-		response.InternalServerError(w, "INTERNAL_ERROR", "Failed to fetch live count")
+		response.Catalog(w, liveCountFailed)
 		return
 	}
 
@@ -54,13 +55,13 @@ func (h *Handler) GetLiveCount(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) GetParticipation(w http.ResponseWriter, r *http.Request) {
 	electionID, err := strconv.ParseInt(chi.URLParam(r, "electionID"), 10, 64)
 	if err != nil {
-		response.BadRequest(w, "INVALID_REQUEST", "Invalid election ID")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.InvalidElectionID)
 		return
 	}
 
 	participation, err := h.service.repo.GetParticipationStats(r.Context(), electionID)
 	if err != nil {
-		response.InternalServerError(w, "INTERNAL_ERROR", "Failed to fetch participation")
+		response.Catalog(w, participationFailed)
 		return
 	}
 
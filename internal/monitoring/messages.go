@@ -0,0 +1,15 @@
+package monitoring
+
+import (
+	"net/http"
+
+	"pemira-api/internal/errcatalog"
+)
+
+// Messages the monitoring handlers answer with, each under its own code in the
+// error catalog
+var (
+	summaryFailed       = errcatalog.Define("MONITORING_SUMMARY_FAILED", http.StatusInternalServerError, "Gagal mengambil ringkasan.", "Failed to fetch the summary.")
+	liveCountFailed     = errcatalog.Define("LIVE_COUNT_FAILED", http.StatusInternalServerError, "Gagal mengambil hitung cepat.", "Failed to fetch the live count.")
+	participationFailed = errcatalog.Define("PARTICIPATION_FAILED", http.StatusInternalServerError, "Gagal mengambil data partisipasi.", "Failed to fetch participation.")
+)
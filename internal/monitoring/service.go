@@ -3,16 +3,25 @@ package monitoring
 import (
 	"context"
 	"time"
+
+	"pemira-api/internal/embargo"
 )
 
 type Service struct {
-	repo Repository
+	repo    Repository
+	embargo embargo.Policy
 }
 
 func NewService(repo Repository) *Service {
 	return &Service{repo: repo}
 }
 
+// SetEmbargo hides candidate votes while an election's results are under
+// embargo. Totals and participation stay visible.
+func (s *Service) SetEmbargo(p embargo.Policy) {
+	s.embargo = p
+}
+
 func (s *Service) GetLiveCountSnapshot(ctx context.Context, electionID int64) (*LiveCountSnapshot, error) {
 	// Get all stats in parallel
 	voteStats, err := s.repo.GetVoteStats(ctx, electionID)
@@ -45,14 +54,19 @@ func (s *Service) GetLiveCountSnapshot(ctx context.Context, electionID int64) (*
 		totalVotes += stat.TotalVotes
 	}
 
-	return &LiveCountSnapshot{
+	snapshot := &LiveCountSnapshot{
 		ElectionID:     electionID,
 		Timestamp:      time.Now(),
 		TotalVotes:     totalVotes,
 		Participation:  *participation,
 		CandidateVotes: candidateVotes,
 		TPSStats:       tpsStatsVal,
-	}, nil
+	}
+	if s.embargo != nil && s.embargo.Sealed(ctx, electionID) {
+		snapshot.CandidateVotes = map[int64]int64{}
+		snapshot.ResultsEmbargoed = true
+	}
+	return snapshot, nil
 }
 
 func (s *Service) GetDashboardSummary(ctx context.Context, electionID int64) (map[string]interface{}, error) {
@@ -66,6 +80,7 @@ func (s *Service) GetDashboardSummary(ctx context.Context, electionID int64) (ma
 		"total_eligible":    snapshot.Participation.TotalEligible,
 		"participation_pct": snapshot.Participation.ParticipationPct,
 		"candidate_votes":   snapshot.CandidateVotes,
+		"results_embargoed": snapshot.ResultsEmbargoed,
 		"tps_count":         len(snapshot.TPSStats),
 		"last_updated":      snapshot.Timestamp,
 	}, nil
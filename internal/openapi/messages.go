@@ -0,0 +1,13 @@
+package openapi
+
+import (
+	"net/http"
+
+	"pemira-api/internal/errcatalog"
+)
+
+// Messages the OpenAPI handlers answer with, each under its own code in the
+// error catalog
+var (
+	specNotReady = errcatalog.Define("SPEC_NOT_READY", http.StatusServiceUnavailable, "Dokumen OpenAPI belum tersedia.", "The OpenAPI document is not available yet.")
+)
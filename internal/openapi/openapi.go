@@ -0,0 +1,268 @@
+// Package openapi generates the OpenAPI 3.1 document of the API from the
+// chi route table and the request DTOs bound to it, serves it and validates
+// request bodies against the same DTOs.
+//
+// Every registered route shows up in the document. Operations add what the
+// route table cannot tell: a summary, the request body type and whether the
+// route is public. Body schemas come from the DTOs' json and validate tags.
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+
+	"pemira-api/internal/http/response"
+)
+
+const Version = "3.1.0"
+
+// Operation describes one route; Method and Pattern must match it exactly
+type Operation struct {
+	Method  string
+	Pattern string
+	Summary string
+	// Request is a zero value of the JSON body, nil when the route takes none
+	Request any
+	// Public routes need no bearer token
+	Public bool
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+type Document struct {
+	OpenAPI    string                `json:"openapi"`
+	Info       Info                  `json:"info"`
+	Paths      map[string]PathItem   `json:"paths"`
+	Components Components            `json:"components"`
+	Security   []map[string][]string `json:"security"`
+}
+
+// PathItem maps a lower-case method to its operation
+type PathItem map[string]*OperationObject
+
+type OperationObject struct {
+	OperationID string                 `json:"operationId"`
+	Summary     string                 `json:"summary,omitempty"`
+	Tags        []string               `json:"tags,omitempty"`
+	Parameters  []Parameter            `json:"parameters,omitempty"`
+	RequestBody *RequestBody           `json:"requestBody,omitempty"`
+	Responses   map[string]Response    `json:"responses"`
+	Security    *[]map[string][]string `json:"security,omitempty"`
+}
+
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required"`
+	Schema   *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes"`
+}
+
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+}
+
+// Spec is the generated document plus what request validation needs
+type Spec struct {
+	info     Info
+	ops      map[string]Operation
+	validate *validator.Validate
+
+	mu     sync.RWMutex
+	doc    *Document
+	raw    []byte
+	routes chi.Routes
+	bodies map[string]reflect.Type
+}
+
+func New(info Info, ops ...Operation) *Spec {
+	v := validator.New()
+	// report fields by their JSON names
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+
+	s := &Spec{info: info, ops: make(map[string]Operation, len(ops)), validate: v}
+	for _, op := range ops {
+		s.ops[routeKey(op.Method, op.Pattern)] = op
+	}
+	return s
+}
+
+func routeKey(method, pattern string) string {
+	return strings.ToUpper(method) + " " + normalizePattern(pattern)
+}
+
+// normalizePattern turns a chi pattern into the path used in the document:
+// "/x/" from a subrouter's "/" becomes "/x", "/*" suffixes are dropped
+func normalizePattern(pattern string) string {
+	pattern = strings.TrimSuffix(pattern, "/*")
+	if len(pattern) > 1 {
+		pattern = strings.TrimSuffix(pattern, "/")
+	}
+	if pattern == "" {
+		return "/"
+	}
+	return pattern
+}
+
+var paramPattern = regexp.MustCompile(`\{([^}:]+)(:[^}]*)?\}`)
+
+// Build walks routes and generates the document. Operations that match no
+// route are an error so the table cannot drift from the router.
+func (s *Spec) Build(routes chi.Routes) error {
+	doc := &Document{
+		OpenAPI:  Version,
+		Info:     s.info,
+		Paths:    map[string]PathItem{},
+		Security: []map[string][]string{{"bearerAuth": {}}},
+		Components: Components{
+			SecuritySchemes: map[string]SecurityScheme{
+				"bearerAuth": {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+			},
+		},
+	}
+	reg := schemas{}
+	errorRef := reg.of(reflect.TypeOf(response.ErrorResponse{}))
+	bodies := map[string]reflect.Type{}
+	matched := map[string]bool{}
+
+	err := chi.Walk(routes, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		key := routeKey(method, route)
+		path := paramPattern.ReplaceAllString(normalizePattern(route), "{$1}")
+		op, ok := s.ops[key]
+		matched[key] = ok
+
+		o := &OperationObject{
+			OperationID: operationID(method, path),
+			Summary:     op.Summary,
+			Tags:        []string{tag(path)},
+			Responses: map[string]Response{
+				"2XX":     {Description: "Success"},
+				"default": {Description: "Error", Content: map[string]MediaType{"application/json": {Schema: errorRef}}},
+			},
+		}
+		for _, m := range paramPattern.FindAllStringSubmatch(path, -1) {
+			o.Parameters = append(o.Parameters, Parameter{Name: m[1], In: "path", Required: true, Schema: &Schema{Type: "string"}})
+		}
+		if op.Request != nil {
+			t := reflect.TypeOf(op.Request)
+			o.RequestBody = &RequestBody{
+				Required: true,
+				Content:  map[string]MediaType{"application/json": {Schema: reg.of(t)}},
+			}
+			bodies[key] = t
+		}
+		if op.Public {
+			o.Security = &[]map[string][]string{}
+		}
+
+		item := doc.Paths[path]
+		if item == nil {
+			item = PathItem{}
+			doc.Paths[path] = item
+		}
+		item[strings.ToLower(method)] = o
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	var stale []string
+	for key := range s.ops {
+		if _, ok := matched[key]; !ok {
+			stale = append(stale, key)
+		}
+	}
+	if len(stale) > 0 {
+		sort.Strings(stale)
+		return fmt.Errorf("openapi: operations without a route: %s", strings.Join(stale, ", "))
+	}
+
+	doc.Components.Schemas = reg
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.doc, s.raw, s.routes, s.bodies = doc, raw, routes, bodies
+	s.mu.Unlock()
+	return nil
+}
+
+// Document returns the generated document, nil before Build
+func (s *Spec) Document() *Document {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.doc
+}
+
+// ServeHTTP serves the document: GET /openapi.json
+func (s *Spec) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	raw := s.raw
+	s.mu.RUnlock()
+	if raw == nil {
+		response.Catalog(w, specNotReady)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(raw)
+}
+
+// operationID is e.g. "post_api_v1_voting_online_cast"
+func operationID(method, path string) string {
+	id := strings.ToLower(method) + strings.NewReplacer("/", "_", "{", "", "}", "", "-", "_", ".", "_").Replace(path)
+	return strings.TrimSuffix(id, "_")
+}
+
+// tag groups operations by area: "admin/elections", "voting", "health"
+func tag(path string) string {
+	parts := strings.Split(strings.TrimPrefix(strings.TrimPrefix(path, "/api/v1"), "/"), "/")
+	if parts[0] == "admin" && len(parts) > 1 {
+		return "admin/" + parts[1]
+	}
+	if parts[0] == "" {
+		return "root"
+	}
+	return parts[0]
+}
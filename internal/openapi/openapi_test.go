@@ -0,0 +1,171 @@
+package openapi
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type member struct {
+	Name string `json:"name" validate:"required"`
+}
+
+type createRequest struct {
+	Code     string   `json:"code" validate:"required,min=3,max=20"`
+	Status   string   `json:"status" validate:"required,oneof=DRAFT ACTIVE"`
+	Capacity int      `json:"capacity" validate:"min=0"`
+	Email    string   `json:"email,omitempty" validate:"omitempty,email"`
+	Members  []member `json:"members" validate:"dive"`
+	Secret   string   `json:"-"`
+}
+
+func newTestSpec(t *testing.T) (*Spec, chi.Router, *string) {
+	t.Helper()
+	seen := new(string)
+	s := New(Info{Title: "test", Version: "1"},
+		Operation{Method: http.MethodPost, Pattern: "/items", Summary: "Create", Request: createRequest{}},
+		Operation{Method: http.MethodGet, Pattern: "/items/{id}", Public: true},
+	)
+	r := chi.NewRouter()
+	r.Use(s.ValidateRequests)
+	r.Post("/items", func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		*seen = string(b)
+		w.WriteHeader(http.StatusCreated)
+	})
+	r.Get("/items/{id}", func(w http.ResponseWriter, r *http.Request) {})
+	r.Delete("/items/{id:[0-9]+}", func(w http.ResponseWriter, r *http.Request) {})
+	if err := s.Build(r); err != nil {
+		t.Fatal(err)
+	}
+	return s, r, seen
+}
+
+func TestBuild(t *testing.T) {
+	s, _, _ := newTestSpec(t)
+	doc := s.Document()
+
+	post := doc.Paths["/items"]["post"]
+	if post == nil || post.OperationID != "post_items" || post.RequestBody == nil {
+		t.Fatalf("post /items = %+v", post)
+	}
+	if post.Security != nil {
+		t.Fatal("protected operation overrides global security")
+	}
+	if get := doc.Paths["/items/{id}"]["get"]; get == nil || get.Security == nil || len(*get.Security) != 0 {
+		t.Fatalf("public get = %+v", get)
+	}
+	// regexp constraints are dropped from the path
+	del := doc.Paths["/items/{id}"]["delete"]
+	if del == nil || len(del.Parameters) != 1 || del.Parameters[0].Name != "id" {
+		t.Fatalf("delete = %+v", del)
+	}
+
+	schema := doc.Components.Schemas["openapi.createRequest"]
+	if schema == nil {
+		t.Fatalf("schemas = %v", doc.Components.Schemas)
+	}
+	if got := strings.Join(schema.Required, ","); got != "code,status" {
+		t.Fatalf("required = %s", got)
+	}
+	if code := schema.Properties["code"]; *code.MinLength != 3 || *code.MaxLength != 20 {
+		t.Fatalf("code = %+v", code)
+	}
+	if status := schema.Properties["status"]; len(status.Enum) != 2 {
+		t.Fatalf("status = %+v", status)
+	}
+	if c := schema.Properties["capacity"]; c.Type != "integer" || *c.Minimum != 0 {
+		t.Fatalf("capacity = %+v", c)
+	}
+	if e := schema.Properties["email"]; e.Format != "email" {
+		t.Fatalf("email = %+v", e)
+	}
+	if m := schema.Properties["members"]; m.Type != "array" || m.Items.Ref != "#/components/schemas/openapi.member" {
+		t.Fatalf("members = %+v", m)
+	}
+	if _, ok := schema.Properties["Secret"]; ok {
+		t.Fatal(`json:"-" field documented`)
+	}
+}
+
+func TestBuildRejectsStaleOperations(t *testing.T) {
+	s := New(Info{}, Operation{Method: http.MethodPost, Pattern: "/gone"})
+	r := chi.NewRouter()
+	r.Get("/here", func(w http.ResponseWriter, r *http.Request) {})
+
+	err := s.Build(r)
+	if err == nil || !strings.Contains(err.Error(), "POST /gone") {
+		t.Fatalf("err = %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("unbuilt spec served: code = %d", rec.Code)
+	}
+}
+
+func TestValidateRequests(t *testing.T) {
+	_, r, seen := newTestSpec(t)
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+		fields string
+	}{
+		{"valid", `{"code":"TPS01","status":"DRAFT","members":[{"name":"a"}]}`, http.StatusCreated, ""},
+		{"missing and bad", `{"code":"T","status":"OPEN","members":[{}]}`, http.StatusUnprocessableEntity, "code:min,status:oneof,members[0].name:required"},
+		{"malformed", `{"code":`, http.StatusBadRequest, ""},
+		{"empty", ``, http.StatusCreated, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			*seen = ""
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			r.ServeHTTP(rec, req)
+			if rec.Code != tt.status {
+				t.Fatalf("code = %d, body = %s", rec.Code, rec.Body.String())
+			}
+			if tt.status == http.StatusCreated && *seen != tt.body {
+				t.Fatalf("handler saw %q", *seen)
+			}
+			if tt.fields == "" {
+				return
+			}
+
+			var resp struct {
+				Code    string `json:"code"`
+				Details struct {
+					Fields []FieldError `json:"fields"`
+				} `json:"details"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, f := range resp.Details.Fields {
+				got = append(got, f.Field+":"+f.Rule)
+			}
+			if resp.Code != "VALIDATION_ERROR" || strings.Join(got, ",") != tt.fields {
+				t.Fatalf("code = %s, fields = %v", resp.Code, got)
+			}
+		})
+	}
+
+	// other content types are left to the handler
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader("code=x"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.ServeHTTP(rec, req)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("form body: code = %d", rec.Code)
+	}
+}
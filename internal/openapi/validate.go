@@ -0,0 +1,136 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+
+	"pemira-api/internal/errcatalog"
+	"pemira-api/internal/http/response"
+)
+
+// maxValidatedBody caps what the middleware reads; bound DTOs are small
+// and uploads go through multipart, which is not validated here
+const maxValidatedBody = 1 << 20
+
+// FieldError is one failed rule, reported under the JSON field name
+type FieldError = errcatalog.FieldError
+
+// ValidateRequests checks JSON bodies of routes with a bound Request type
+// against its validate tags before the handler runs. Invalid bodies get
+// 422 VALIDATION_ERROR listing the failed fields; the handler sees the body
+// unchanged otherwise. Routes are looked up in the router given to Build, so
+// the middleware can be installed before the routes are registered.
+func (s *Spec) ValidateRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !hasJSONBody(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		t := s.bodyType(r)
+		if t == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		raw, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxValidatedBody))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				response.Error(w, http.StatusRequestEntityTooLarge, "BODY_TOO_LARGE", errcatalog.BodyTooLarge, nil)
+				return
+			}
+			response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(raw))
+
+		if len(bytes.TrimSpace(raw)) == 0 {
+			// an empty body is the handler's to reject
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		v := reflect.New(t)
+		if err := json.Unmarshal(raw, v.Interface()); err != nil {
+			response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
+			return
+		}
+		if fields := s.check(v.Interface()); len(fields) > 0 {
+			response.Error(w, http.StatusUnprocessableEntity, "VALIDATION_ERROR", errcatalog.InvalidData,
+				map[string]interface{}{"fields": fields})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// bodyType returns the bound request type of the route r resolves to
+func (s *Spec) bodyType(r *http.Request) reflect.Type {
+	s.mu.RLock()
+	routes, bodies := s.routes, s.bodies
+	s.mu.RUnlock()
+	if routes == nil || len(bodies) == 0 {
+		return nil
+	}
+
+	pattern := routes.Find(chi.NewRouteContext(), r.Method, r.URL.Path)
+	if pattern == "" {
+		return nil
+	}
+	t := bodies[routeKey(r.Method, pattern)]
+	if t == nil {
+		return nil
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	return t
+}
+
+func hasJSONBody(r *http.Request) bool {
+	if r.Body == nil || r.Body == http.NoBody {
+		return false
+	}
+	ct := r.Header.Get("Content-Type")
+	if ct == "" {
+		return true
+	}
+	mt, _, err := mime.ParseMediaType(ct)
+	return err == nil && mt == "application/json"
+}
+
+func (s *Spec) check(v any) []FieldError {
+	err := s.validate.Struct(v)
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return nil
+	}
+	out := make([]FieldError, 0, len(verrs))
+	for _, fe := range verrs {
+		out = append(out, FieldError{Field: fieldPath(fe), Rule: fe.Tag(), Param: fe.Param()})
+	}
+	return out
+}
+
+// fieldPath drops the root type from the validator's namespace:
+// "CreateTPSRequest.code" becomes "code", nested fields keep their path
+func fieldPath(fe validator.FieldError) string {
+	ns := fe.Namespace()
+	for i := 0; i < len(ns); i++ {
+		if ns[i] == '.' {
+			return ns[i+1:]
+		}
+	}
+	return ns
+}
@@ -0,0 +1,51 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepEvery is how many hits pass between sweeps of expired windows
+const sweepEvery = 1024
+
+// MemoryStore keeps windows in process. Limits are per instance, so use
+// RedisStore when the API runs on more than one.
+type MemoryStore struct {
+	mu      sync.Mutex
+	windows map[string]*memoryWindow
+	hits    int
+	now     func() time.Time
+}
+
+type memoryWindow struct {
+	count   int64
+	expires time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{windows: make(map[string]*memoryWindow), now: time.Now}
+}
+
+func (s *MemoryStore) Hit(_ context.Context, key string, window time.Duration) (int64, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.hits++
+	if s.hits%sweepEvery == 0 {
+		for k, w := range s.windows {
+			if !now.Before(w.expires) {
+				delete(s.windows, k)
+			}
+		}
+	}
+
+	w, ok := s.windows[key]
+	if !ok || !now.Before(w.expires) {
+		w = &memoryWindow{expires: now.Add(window)}
+		s.windows[key] = w
+	}
+	w.count++
+	return w.count, w.expires.Sub(now), nil
+}
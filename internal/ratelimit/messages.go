@@ -0,0 +1,13 @@
+package ratelimit
+
+import (
+	"net/http"
+
+	"pemira-api/internal/errcatalog"
+)
+
+// Messages the rate limit handlers answer with, each under its own code in the
+// error catalog
+var (
+	rateLimitExceeded = errcatalog.Define("RATE_LIMIT_EXCEEDED", http.StatusTooManyRequests, "Terlalu banyak permintaan. Coba lagi dalam %d detik.", "Too many requests. Try again in %d seconds.")
+)
@@ -0,0 +1,141 @@
+package ratelimit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"pemira-api/internal/http/response"
+	"pemira-api/internal/shared/ctxkeys"
+)
+
+// maxKeyBodyBytes bounds how much of a request body ByJSONField reads
+const maxKeyBodyBytes = 64 << 10
+
+// KeyFunc groups requests for a policy. An empty key exempts the request.
+type KeyFunc func(r *http.Request) string
+
+// ByIP keys on the client address. It relies on middleware.RealIP having
+// resolved proxies into RemoteAddr.
+func ByIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+// ByUserID keys on the authenticated user, falling back to the IP for
+// anonymous requests
+func ByUserID(r *http.Request) string {
+	if id, ok := ctxkeys.GetUserID(r.Context()); ok {
+		return "user:" + strconv.FormatInt(id, 10)
+	}
+	return ByIP(r)
+}
+
+// ByJSONField keys on a string field of the JSON body, such as the
+// username of a login. The body is restored for the handler.
+func ByJSONField(field string) KeyFunc {
+	return func(r *http.Request) string {
+		if r.Body == nil {
+			return ""
+		}
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxKeyBodyBytes))
+		r.Body.Close()
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		if err != nil {
+			return ""
+		}
+
+		var fields map[string]json.RawMessage
+		if json.Unmarshal(body, &fields) != nil {
+			return ""
+		}
+		var v string
+		if json.Unmarshal(fields[field], &v) != nil {
+			return ""
+		}
+		v = strings.ToLower(strings.TrimSpace(v))
+		if v == "" {
+			return ""
+		}
+		return field + ":" + v
+	}
+}
+
+// Middleware counts every request against each enabled policy and rejects
+// it with 429 once any of them is exhausted. Responses carry the
+// RateLimit-* headers of the policy closest to its limit. Store errors are
+// logged and the request is let through.
+func (l *Limiter) Middleware(policies ...Policy) func(http.Handler) http.Handler {
+	var enabled []Policy
+	for _, p := range policies {
+		if p.Enabled() {
+			enabled = append(enabled, p)
+		}
+	}
+
+	return func(next http.Handler) http.Handler {
+		if l == nil || l.store == nil || len(enabled) == 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var tightest *Result
+			for _, p := range enabled {
+				key := p.Key(r)
+				if key == "" {
+					continue
+				}
+				res, err := l.Allow(r.Context(), p, key)
+				if err != nil {
+					slog.Warn("rate limit store unavailable", "policy", p.Name, "error", err)
+					continue
+				}
+				if tightest == nil || !res.Allowed || (tightest.Allowed && res.Remaining < tightest.Remaining) {
+					tightest = &res
+				}
+				if !res.Allowed {
+					break
+				}
+			}
+
+			if tightest != nil {
+				writeHeaders(w, *tightest)
+				if !tightest.Allowed {
+					retry := seconds(tightest.ResetIn)
+					w.Header().Set("Retry-After", strconv.Itoa(retry))
+					response.Error(w, rateLimitExceeded.Status, rateLimitExceeded.Code, rateLimitExceeded.Messagef(retry),
+						map[string]interface{}{"policy": tightest.Policy.Name, "retry_after": retry})
+					return
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func writeHeaders(w http.ResponseWriter, res Result) {
+	h := w.Header()
+	h.Set("RateLimit-Limit", strconv.Itoa(res.Policy.Limit))
+	h.Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+	h.Set("RateLimit-Reset", strconv.Itoa(seconds(res.ResetIn)))
+	h.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", res.Policy.Limit, seconds(res.Policy.Window)))
+}
+
+// seconds rounds up so clients never retry before the window closes
+func seconds(d time.Duration) int {
+	s := int(math.Ceil(d.Seconds()))
+	if s < 1 {
+		return 1
+	}
+	return s
+}
@@ -0,0 +1,93 @@
+// Package ratelimit counts requests per key in fixed windows, in process or
+// in a Redis-compatible server shared by every API instance.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Store counts hits per key. Hit adds one to the key's current window,
+// starting a new window of the given length when none is open, and returns
+// the count so far and the time left in the window.
+type Store interface {
+	Hit(ctx context.Context, key string, window time.Duration) (count int64, resetIn time.Duration, err error)
+}
+
+// Policy is a named limit applied to requests grouped by Key. A policy
+// with Limit <= 0 is disabled.
+type Policy struct {
+	Name   string
+	Limit  int
+	Window time.Duration
+	Key    KeyFunc
+}
+
+// NewPolicy builds a policy from a "LIMIT/WINDOW" spec such as "10/1m".
+// An empty spec, "0" or "off" disables it.
+func NewPolicy(name, spec string, key KeyFunc) (Policy, error) {
+	p := Policy{Name: name, Key: key}
+	spec = strings.TrimSpace(spec)
+	if spec == "" || spec == "0" || strings.EqualFold(spec, "off") {
+		return p, nil
+	}
+
+	limit, window, ok := strings.Cut(spec, "/")
+	if !ok {
+		return p, fmt.Errorf("rate limit %s: %q is not LIMIT/WINDOW", name, spec)
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(limit))
+	if err != nil || n < 0 {
+		return p, fmt.Errorf("rate limit %s: invalid limit %q", name, limit)
+	}
+	d, err := time.ParseDuration(strings.TrimSpace(window))
+	if err != nil || d < time.Second {
+		return p, fmt.Errorf("rate limit %s: invalid window %q", name, window)
+	}
+	p.Limit, p.Window = n, d
+	return p, nil
+}
+
+// Enabled reports whether the policy limits anything
+func (p Policy) Enabled() bool {
+	return p.Limit > 0 && p.Window > 0
+}
+
+// Result is the state of one policy after counting a request
+type Result struct {
+	Policy    Policy
+	Allowed   bool
+	Remaining int
+	ResetIn   time.Duration
+}
+
+// Limiter applies policies against a store. A nil store disables limiting.
+type Limiter struct {
+	store  Store
+	prefix string
+}
+
+func NewLimiter(store Store) *Limiter {
+	return &Limiter{store: store, prefix: "rl:"}
+}
+
+// Allow counts one request for key under p
+func (l *Limiter) Allow(ctx context.Context, p Policy, key string) (Result, error) {
+	count, resetIn, err := l.store.Hit(ctx, l.prefix+p.Name+":"+key, p.Window)
+	if err != nil {
+		return Result{Policy: p, Allowed: true}, err
+	}
+	remaining := p.Limit - int(count)
+	if remaining < 0 {
+		remaining = 0
+	}
+	return Result{
+		Policy:    p,
+		Allowed:   count <= int64(p.Limit),
+		Remaining: remaining,
+		ResetIn:   resetIn,
+	}, nil
+}
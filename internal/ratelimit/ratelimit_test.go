@@ -0,0 +1,244 @@
+package ratelimit
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNewPolicy(t *testing.T) {
+	tests := []struct {
+		spec    string
+		limit   int
+		window  time.Duration
+		wantErr bool
+	}{
+		{spec: "10/1m", limit: 10, window: time.Minute},
+		{spec: " 5 / 15m ", limit: 5, window: 15 * time.Minute},
+		{spec: "off"},
+		{spec: ""},
+		{spec: "0"},
+		{spec: "10", wantErr: true},
+		{spec: "x/1m", wantErr: true},
+		{spec: "10/100ms", wantErr: true},
+	}
+	for _, tt := range tests {
+		p, err := NewPolicy("login", tt.spec, ByIP)
+		if tt.wantErr {
+			if err == nil {
+				t.Fatalf("%q: expected error", tt.spec)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", tt.spec, err)
+		}
+		if p.Limit != tt.limit || p.Window != tt.window || p.Enabled() != (tt.limit > 0) {
+			t.Fatalf("%q: got %+v", tt.spec, p)
+		}
+	}
+}
+
+func TestMemoryStoreWindow(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	s := NewMemoryStore()
+	s.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	for i := int64(1); i <= 3; i++ {
+		n, reset, _ := s.Hit(ctx, "k", time.Minute)
+		if n != i || reset != time.Minute {
+			t.Fatalf("hit %d: count %d reset %v", i, n, reset)
+		}
+	}
+
+	now = now.Add(40 * time.Second)
+	if n, reset, _ := s.Hit(ctx, "k", time.Minute); n != 4 || reset != 20*time.Second {
+		t.Fatalf("same window: count %d reset %v", n, reset)
+	}
+
+	now = now.Add(20 * time.Second)
+	if n, _, _ := s.Hit(ctx, "k", time.Minute); n != 1 {
+		t.Fatalf("new window: count %d", n)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	limiter := NewLimiter(NewMemoryStore())
+	ipPolicy, _ := NewPolicy("login", "3/1m", ByIP)
+	userPolicy, _ := NewPolicy("login_username", "2/1m", ByJSONField("username"))
+
+	var gotBody string
+	handler := limiter.Middleware(ipPolicy, userPolicy)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		gotBody = string(b)
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	login := func(username, ip string) *httptest.ResponseRecorder {
+		body := `{"username":"` + username + `","password":"x"}`
+		req := httptest.NewRequest(http.MethodPost, "/auth/login", strings.NewReader(body))
+		req.RemoteAddr = ip + ":1234"
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := login("Ani", "10.0.0.1")
+	if rec.Code != http.StatusOK || !strings.Contains(gotBody, `"username":"Ani"`) {
+		t.Fatalf("first request: %d body %q", rec.Code, gotBody)
+	}
+	if rec.Header().Get("RateLimit-Remaining") != "1" || rec.Header().Get("RateLimit-Policy") != "2;w=60" {
+		t.Fatalf("expected username policy headers, got %v", rec.Header())
+	}
+
+	// Same username from another IP shares the username budget
+	if rec := login(" ani ", "10.0.0.2"); rec.Code != http.StatusOK {
+		t.Fatalf("second request: %d", rec.Code)
+	}
+	rec = login("ani", "10.0.0.3")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429, got %d", rec.Code)
+	}
+	if retry, err := strconv.Atoi(rec.Header().Get("Retry-After")); err != nil || retry < 1 || retry > 60 {
+		t.Fatalf("unexpected Retry-After %q", rec.Header().Get("Retry-After"))
+	}
+
+	// Another username from the first IP still counts against the IP policy
+	if rec := login("budi", "10.0.0.1"); rec.Code != http.StatusOK {
+		t.Fatalf("ip request 2: %d", rec.Code)
+	}
+	if rec := login("citra", "10.0.0.1"); rec.Code != http.StatusOK {
+		t.Fatalf("ip request 3: %d", rec.Code)
+	}
+	if rec := login("dewi", "10.0.0.1"); rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected IP limit, got %d", rec.Code)
+	}
+}
+
+func TestMiddlewareDisabled(t *testing.T) {
+	p, _ := NewPolicy("vote", "1/1m", ByUserID)
+	called := 0
+	handler := NewLimiter(nil).Middleware(p)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called++
+	}))
+	for i := 0; i < 3; i++ {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/", nil))
+	}
+	if called != 3 {
+		t.Fatalf("expected limiter without store to pass through, handler called %d times", called)
+	}
+}
+
+// fakeRedis answers AUTH, SELECT, PING and the hit script over RESP
+type fakeRedis struct {
+	mu       sync.Mutex
+	counts   map[string]int64
+	commands []string
+}
+
+func startFakeRedis(t *testing.T) (string, *fakeRedis) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	f := &fakeRedis{counts: map[string]int64{}}
+	go func() {
+		for {
+			c, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go f.serve(c)
+		}
+	}()
+	return ln.Addr().String(), f
+}
+
+func (f *fakeRedis) serve(c net.Conn) {
+	defer c.Close()
+	r := bufio.NewReader(c)
+	for {
+		reply, err := readReply(r)
+		if err != nil {
+			return
+		}
+		var args []string
+		for _, a := range reply.([]interface{}) {
+			args = append(args, string(a.([]byte)))
+		}
+
+		f.mu.Lock()
+		f.commands = append(f.commands, args[0])
+		switch args[0] {
+		case "AUTH", "SELECT":
+			io.WriteString(c, "+OK\r\n")
+		case "PING":
+			io.WriteString(c, "+PONG\r\n")
+		case "EVAL":
+			f.counts[args[3]]++
+			io.WriteString(c, "*2\r\n:"+strconv.FormatInt(f.counts[args[3]], 10)+"\r\n:"+args[4]+"\r\n")
+		default:
+			io.WriteString(c, "-ERR unknown command\r\n")
+		}
+		f.mu.Unlock()
+	}
+}
+
+func TestRedisStore(t *testing.T) {
+	addr, fake := startFakeRedis(t)
+	store, err := NewRedisStore("redis://:secret@" + addr + "/2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	ctx := context.Background()
+
+	if err := store.Ping(ctx); err != nil {
+		t.Fatalf("ping: %v", err)
+	}
+	for i := int64(1); i <= 2; i++ {
+		n, reset, err := store.Hit(ctx, "rl:login:ip:1", time.Minute)
+		if err != nil || n != i || reset != time.Minute {
+			t.Fatalf("hit %d: count %d reset %v err %v", i, n, reset, err)
+		}
+	}
+	if _, err := store.Do(ctx, "FLUSHALL"); err == nil || !strings.Contains(err.Error(), "unknown command") {
+		t.Fatalf("expected error reply, got %v", err)
+	}
+	// The connection survives an error reply
+	if err := store.Ping(ctx); err != nil {
+		t.Fatalf("ping after error: %v", err)
+	}
+
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	got := strings.Join(fake.commands, " ")
+	if got != "AUTH SELECT PING EVAL EVAL FLUSHALL PING" {
+		t.Fatalf("unexpected command sequence %q", got)
+	}
+}
+
+func TestNewRedisStoreURL(t *testing.T) {
+	s, err := NewRedisStore("rediss://user:pw@cache.internal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.addr != "cache.internal:6379" || s.username != "user" || s.password != "pw" || s.tls == nil {
+		t.Fatalf("unexpected store %+v", s)
+	}
+	if _, err := NewRedisStore("http://cache.internal"); err == nil {
+		t.Fatal("expected scheme error")
+	}
+}
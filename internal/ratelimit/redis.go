@@ -0,0 +1,265 @@
+package ratelimit
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	redisPoolSize    = 16
+	redisDialTimeout = 3 * time.Second
+	redisIOTimeout   = 2 * time.Second
+)
+
+// hitScript increments the key and starts its window on the first hit. The
+// PTTL check also repairs keys that lost their expiry.
+const hitScript = `
+local n = redis.call('INCR', KEYS[1])
+local ttl = redis.call('PTTL', KEYS[1])
+if ttl < 0 then
+  redis.call('PEXPIRE', KEYS[1], ARGV[1])
+  ttl = tonumber(ARGV[1])
+end
+return {n, ttl}
+`
+
+// RedisError is an error reply from the server
+type RedisError string
+
+func (e RedisError) Error() string { return string(e) }
+
+// RedisStore keeps windows in Redis or a server speaking its protocol
+// (Valkey, KeyDB, Dragonfly), so every API instance shares the counts.
+type RedisStore struct {
+	addr     string
+	username string
+	password string
+	db       int
+	tls      *tls.Config
+
+	pool chan *redisConn
+}
+
+type redisConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+// NewRedisStore parses redis://[user:password@]host:port[/db] or rediss://
+// for TLS. Connections are opened on first use.
+func NewRedisStore(rawURL string) (*RedisStore, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse redis url: %w", err)
+	}
+	s := &RedisStore{pool: make(chan *redisConn, redisPoolSize)}
+	switch u.Scheme {
+	case "redis":
+	case "rediss":
+		s.tls = &tls.Config{ServerName: u.Hostname(), MinVersion: tls.VersionTLS12}
+	default:
+		return nil, fmt.Errorf("redis url: unsupported scheme %q", u.Scheme)
+	}
+
+	s.addr = u.Host
+	if u.Port() == "" {
+		s.addr = net.JoinHostPort(u.Hostname(), "6379")
+	}
+	if u.User != nil {
+		s.password, _ = u.User.Password()
+		s.username = u.User.Username()
+		if s.password == "" {
+			// redis://:password@host and redis://password@host both mean
+			// a password for the default user
+			s.password, s.username = s.username, ""
+		}
+	}
+	if db := strings.Trim(u.Path, "/"); db != "" {
+		if s.db, err = strconv.Atoi(db); err != nil {
+			return nil, fmt.Errorf("redis url: invalid database %q", db)
+		}
+	}
+	return s, nil
+}
+
+func (s *RedisStore) Hit(ctx context.Context, key string, window time.Duration) (int64, time.Duration, error) {
+	reply, err := s.Do(ctx, "EVAL", hitScript, "1", key, strconv.FormatInt(window.Milliseconds(), 10))
+	if err != nil {
+		return 0, 0, err
+	}
+	vals, ok := reply.([]interface{})
+	if !ok || len(vals) != 2 {
+		return 0, 0, fmt.Errorf("redis: unexpected reply %v", reply)
+	}
+	count, ok1 := vals[0].(int64)
+	ttl, ok2 := vals[1].(int64)
+	if !ok1 || !ok2 {
+		return 0, 0, fmt.Errorf("redis: unexpected reply %v", reply)
+	}
+	return count, time.Duration(ttl) * time.Millisecond, nil
+}
+
+// Ping checks that the server is reachable
+func (s *RedisStore) Ping(ctx context.Context) error {
+	_, err := s.Do(ctx, "PING")
+	return err
+}
+
+// Close closes the idle connections
+func (s *RedisStore) Close() error {
+	for {
+		select {
+		case c := <-s.pool:
+			c.Close()
+		default:
+			return nil
+		}
+	}
+}
+
+// Do sends one command and returns its reply: string, int64, []byte, nil,
+// []interface{} or a RedisError.
+func (s *RedisStore) Do(ctx context.Context, args ...string) (interface{}, error) {
+	c, err := s.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(redisIOTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	c.SetDeadline(deadline)
+
+	reply, err := c.roundTrip(args)
+	var redisErr RedisError
+	if err != nil && !errors.As(err, &redisErr) {
+		// The stream may be out of sync; never reuse the connection
+		c.Close()
+		return nil, err
+	}
+	s.release(c)
+	return reply, err
+}
+
+func (s *RedisStore) conn(ctx context.Context) (*redisConn, error) {
+	select {
+	case c := <-s.pool:
+		return c, nil
+	default:
+	}
+
+	dialer := &net.Dialer{Timeout: redisDialTimeout}
+	var (
+		nc  net.Conn
+		err error
+	)
+	if s.tls != nil {
+		nc, err = (&tls.Dialer{NetDialer: dialer, Config: s.tls}).DialContext(ctx, "tcp", s.addr)
+	} else {
+		nc, err = dialer.DialContext(ctx, "tcp", s.addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("redis dial: %w", err)
+	}
+	c := &redisConn{Conn: nc, r: bufio.NewReader(nc)}
+	c.SetDeadline(time.Now().Add(redisIOTimeout))
+
+	if s.password != "" {
+		args := []string{"AUTH", s.password}
+		if s.username != "" {
+			args = []string{"AUTH", s.username, s.password}
+		}
+		if _, err := c.roundTrip(args); err != nil {
+			c.Close()
+			return nil, fmt.Errorf("redis auth: %w", err)
+		}
+	}
+	if s.db != 0 {
+		if _, err := c.roundTrip([]string{"SELECT", strconv.Itoa(s.db)}); err != nil {
+			c.Close()
+			return nil, fmt.Errorf("redis select: %w", err)
+		}
+	}
+	return c, nil
+}
+
+func (s *RedisStore) release(c *redisConn) {
+	select {
+	case s.pool <- c:
+	default:
+		c.Close()
+	}
+}
+
+func (c *redisConn) roundTrip(args []string) (interface{}, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, a := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(a), a)
+	}
+	if _, err := io.WriteString(c.Conn, b.String()); err != nil {
+		return nil, err
+	}
+	return readReply(c.r)
+}
+
+// readReply decodes one RESP2 reply
+func readReply(r *bufio.Reader) (interface{}, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return nil, errors.New("redis: empty reply")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, RedisError(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil || n < 0 {
+			return nil, err
+		}
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		return buf[:n], nil
+	case '*':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil || n < 0 {
+			return nil, err
+		}
+		vals := make([]interface{}, n)
+		for i := range vals {
+			v, err := readReply(r)
+			var redisErr RedisError
+			if err != nil && !errors.As(err, &redisErr) {
+				return nil, err
+			}
+			if err != nil {
+				v = redisErr
+			}
+			vals[i] = v
+		}
+		return vals, nil
+	default:
+		return nil, fmt.Errorf("redis: unexpected reply %q", line)
+	}
+}
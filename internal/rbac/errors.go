@@ -0,0 +1,23 @@
+package rbac
+
+import (
+	"net/http"
+
+	"pemira-api/internal/errcatalog"
+)
+
+// The API codes and messages of the role assignment errors; handleError
+// writes them
+func init() {
+	errcatalog.Register(
+		errcatalog.Entry{Err: ErrUserNotFound, Code: "USER_NOT_FOUND", Status: http.StatusNotFound, ID: "User tidak ditemukan.", EN: "User not found."},
+		errcatalog.Entry{Err: ErrAssignmentNotFound, Code: "ASSIGNMENT_NOT_FOUND", Status: http.StatusNotFound, ID: "Penugasan role tidak ditemukan.", EN: "Role assignment not found."},
+		errcatalog.Entry{Err: ErrScopeNotFound, Code: "SCOPE_NOT_FOUND", Status: http.StatusNotFound, ID: "Pemilu atau TPS tidak ditemukan.", EN: "Election or TPS not found."},
+		errcatalog.Entry{Err: ErrAssignmentExists, Code: "ASSIGNMENT_EXISTS", Status: http.StatusConflict, ID: "Penugasan role yang sama sudah ada.", EN: "The same role assignment already exists."},
+		errcatalog.Entry{Err: ErrUnknownRole, Code: "VALIDATION_ERROR", Status: http.StatusBadRequest, ID: "Role tidak dikenal atau tidak memiliki izin.", EN: "The role is unknown or has no permissions."},
+		errcatalog.Entry{Err: ErrScopeRequired, Code: "VALIDATION_ERROR", Status: http.StatusBadRequest, ID: "Role ini wajib dibatasi ke satu TPS.", EN: "This role must be scoped to one TPS."},
+		errcatalog.Entry{Err: ErrRoleEscalation, Code: "ROLE_ESCALATION", Status: http.StatusForbidden, ID: "Anda tidak dapat memberikan izin yang tidak Anda miliki pada cakupan tersebut.", EN: "You cannot grant permissions you do not hold in that scope."},
+		errcatalog.Entry{Err: ErrSuperAdminGrant, Code: "SUPER_ADMIN_GRANT_FORBIDDEN", Status: http.StatusForbidden, ID: "Hanya super admin yang dapat memberikan role SUPER_ADMIN.", EN: "Only a super admin can grant the SUPER_ADMIN role."},
+		errcatalog.Entry{Err: ErrScopeMismatch, Code: "VALIDATION_ERROR", Status: http.StatusBadRequest, ID: "TPS tidak termasuk dalam pemilu tersebut.", EN: "The TPS does not belong to that election."},
+	)
+}
@@ -0,0 +1,132 @@
+package rbac
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"pemira-api/internal/errcatalog"
+	"pemira-api/internal/http/response"
+	"pemira-api/internal/shared/constants"
+)
+
+type Handler struct {
+	svc *Service
+}
+
+func NewHandler(svc *Service) *Handler {
+	return &Handler{svc: svc}
+}
+
+// ListAssignments: GET /admin/users/{userID}/roles
+func (h *Handler) ListAssignments(w http.ResponseWriter, r *http.Request) {
+	userID, ok := parseID(w, r, "userID")
+	if !ok {
+		return
+	}
+
+	items, err := h.svc.ListAssignments(r.Context(), userID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.Success(w, http.StatusOK, map[string]interface{}{"items": items})
+}
+
+// Assign: POST /admin/users/{userID}/roles
+func (h *Handler) Assign(w http.ResponseWriter, r *http.Request) {
+	userID, ok := parseID(w, r, "userID")
+	if !ok {
+		return
+	}
+
+	var req AssignRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
+		return
+	}
+	req.Role = constants.Role(strings.ToUpper(strings.TrimSpace(string(req.Role))))
+
+	granter, ok := PrincipalFromContext(r.Context())
+	if !ok {
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.Unauthenticated)
+		return
+	}
+
+	a, err := h.svc.Assign(r.Context(), userID, req, granter)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.Success(w, http.StatusCreated, a)
+}
+
+// Revoke: DELETE /admin/users/{userID}/roles/{assignmentID}
+func (h *Handler) Revoke(w http.ResponseWriter, r *http.Request) {
+	userID, ok := parseID(w, r, "userID")
+	if !ok {
+		return
+	}
+	assignmentID, ok := parseID(w, r, "assignmentID")
+	if !ok {
+		return
+	}
+
+	if err := h.svc.Revoke(r.Context(), userID, assignmentID); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.Success(w, http.StatusOK, map[string]string{"message": "Role berhasil dicabut"})
+}
+
+// MyPermissions: GET /me/permissions
+func (h *Handler) MyPermissions(w http.ResponseWriter, r *http.Request) {
+	p, ok := PrincipalFromContext(r.Context())
+	if !ok {
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.Unauthenticated)
+		return
+	}
+
+	type grant struct {
+		Role        constants.Role `json:"role"`
+		ElectionID  *int64         `json:"election_id,omitempty"`
+		TPSID       *int64         `json:"tps_id,omitempty"`
+		Permissions []Permission   `json:"permissions"`
+	}
+	grants := make([]grant, 0, len(p.Grants))
+	for _, g := range p.Grants {
+		grants = append(grants, grant{Role: g.Role, ElectionID: g.ElectionID, TPSID: g.TPSID, Permissions: RolePermissions(g.Role)})
+	}
+
+	response.Success(w, http.StatusOK, map[string]interface{}{
+		"user_id": p.UserID,
+		"role":    p.Role,
+		"grants":  grants,
+	})
+}
+
+func parseID(w http.ResponseWriter, r *http.Request, key string) (int64, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, key), 10, 64)
+	if err != nil || id <= 0 {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid(key))
+		return 0, false
+	}
+	return id, true
+}
+
+// handleError maps service errors to HTTP responses through the error
+// catalog (see errors.go)
+func (h *Handler) handleError(w http.ResponseWriter, err error) {
+	if response.DomainError(w, err) {
+		return
+	}
+	slog.Error("rbac handler error", "err", err)
+	response.InternalServerError(w, "INTERNAL_ERROR", errcatalog.InternalError)
+}
@@ -0,0 +1,100 @@
+package rbac
+
+import (
+	"sort"
+
+	"pemira-api/internal/shared/constants"
+)
+
+// Permission is a single action a staff account may perform.
+type Permission string
+
+const (
+	PermElectionView   Permission = "election.view"
+	PermElectionManage Permission = "election.manage"
+
+	PermCandidateView   Permission = "candidate.view"
+	PermCandidateManage Permission = "candidate.manage"
+	PermCandidacyReview Permission = "candidacy.review"
+
+	PermDPTView Permission = "dpt.view"
+	PermDPTEdit Permission = "dpt.edit"
+
+	PermTPSView           Permission = "tps.view"
+	PermTPSManage         Permission = "tps.manage"
+	PermTPSApproveCheckin Permission = "tps.approve_checkin"
+
+	PermResultsView Permission = "results.view"
+
+	PermUsersManage    Permission = "users.manage"
+	PermSettingsManage Permission = "settings.manage"
+)
+
+// AllPermissions lists every permission known to the API.
+var AllPermissions = []Permission{
+	PermElectionView, PermElectionManage,
+	PermCandidateView, PermCandidateManage, PermCandidacyReview,
+	PermDPTView, PermDPTEdit,
+	PermTPSView, PermTPSManage, PermTPSApproveCheckin,
+	PermResultsView,
+	PermUsersManage, PermSettingsManage,
+}
+
+var rolePermissions = map[constants.Role][]Permission{
+	constants.RoleSuperAdmin: AllPermissions,
+	constants.RoleAdmin:      AllPermissions,
+	constants.RolePanitia: {
+		PermElectionView,
+		PermCandidateView, PermCandidateManage, PermCandidacyReview,
+		PermDPTView, PermDPTEdit,
+		PermTPSView, PermTPSApproveCheckin,
+		PermResultsView,
+	},
+	constants.RoleKetuaTPS: {
+		PermTPSView, PermTPSApproveCheckin, PermResultsView,
+	},
+	constants.RoleOperatorPanel: {
+		PermTPSView, PermTPSApproveCheckin,
+	},
+	constants.RoleTPSOperator: {
+		PermTPSView, PermTPSApproveCheckin,
+	},
+	// Observers: read-only, without access to the voter list.
+	constants.RoleViewer: {
+		PermElectionView, PermCandidateView, PermTPSView, PermResultsView,
+	},
+}
+
+// tpsBoundRoles only make sense attached to a TPS. Holding one as the account
+// role grants nothing until the account is tied to a TPS.
+var tpsBoundRoles = map[constants.Role]bool{
+	constants.RoleKetuaTPS:      true,
+	constants.RoleOperatorPanel: true,
+}
+
+// RoleHas reports whether role's permission set contains perm.
+func RoleHas(role constants.Role, perm Permission) bool {
+	for _, p := range rolePermissions[role] {
+		if p == perm {
+			return true
+		}
+	}
+	return false
+}
+
+// RolePermissions returns the permission set of role, sorted.
+func RolePermissions(role constants.Role) []Permission {
+	perms := append([]Permission(nil), rolePermissions[role]...)
+	sort.Slice(perms, func(i, j int) bool { return perms[i] < perms[j] })
+	return perms
+}
+
+// IsStaffRole reports whether role carries any permission.
+func IsStaffRole(role constants.Role) bool {
+	return len(rolePermissions[role]) > 0
+}
+
+// IsTPSBound reports whether role only applies when scoped to a TPS.
+func IsTPSBound(role constants.Role) bool {
+	return tpsBoundRoles[role]
+}
@@ -0,0 +1,91 @@
+package rbac
+
+import (
+	"context"
+	"testing"
+
+	"pemira-api/internal/shared/constants"
+)
+
+func int64Ptr(v int64) *int64 { return &v }
+
+type stubRepo struct {
+	Repository
+	assignments []Assignment
+}
+
+func (s stubRepo) ListByUser(ctx context.Context, userID int64) ([]Assignment, error) {
+	return s.assignments, nil
+}
+
+func TestRoleHas(t *testing.T) {
+	cases := []struct {
+		role constants.Role
+		perm Permission
+		want bool
+	}{
+		{constants.RoleAdmin, PermUsersManage, true},
+		{constants.RoleViewer, PermResultsView, true},
+		{constants.RoleViewer, PermElectionManage, false},
+		{constants.RoleViewer, PermDPTView, false},
+		{constants.RolePanitia, PermDPTEdit, true},
+		{constants.RolePanitia, PermElectionManage, false},
+		{constants.RoleTPSOperator, PermTPSApproveCheckin, true},
+		{constants.RoleStudent, PermElectionView, false},
+	}
+	for _, c := range cases {
+		if got := RoleHas(c.role, c.perm); got != c.want {
+			t.Errorf("RoleHas(%s, %s) = %v, want %v", c.role, c.perm, got, c.want)
+		}
+	}
+}
+
+func TestPrincipal_ScopedGrants(t *testing.T) {
+	svc := NewService(stubRepo{assignments: []Assignment{
+		{Role: constants.RolePanitia, ElectionID: int64Ptr(3)},
+		{Role: constants.RoleKetuaTPS, ElectionID: int64Ptr(3), TPSID: int64Ptr(7)},
+	}})
+
+	p, err := svc.Principal(context.Background(), 1, constants.RoleViewer, nil)
+	if err != nil {
+		t.Fatalf("principal: %v", err)
+	}
+
+	if !p.Can(PermDPTEdit, Scope{ElectionID: int64Ptr(3)}) {
+		t.Error("panitia grant should cover its election")
+	}
+	if p.Can(PermDPTEdit, Scope{ElectionID: int64Ptr(4)}) {
+		t.Error("panitia grant must not leak to another election")
+	}
+	if p.Can(PermDPTEdit, Scope{}) {
+		t.Error("election-scoped grant must not apply to global routes")
+	}
+	if !p.Can(PermResultsView, Scope{ElectionID: int64Ptr(4)}) {
+		t.Error("global viewer role should read results everywhere")
+	}
+	if !p.Can(PermTPSApproveCheckin, Scope{TPSID: int64Ptr(7)}) {
+		t.Error("tps grant should cover its tps without an election in the route")
+	}
+	if p.Can(PermTPSApproveCheckin, Scope{ElectionID: int64Ptr(5), TPSID: int64Ptr(7)}) {
+		t.Error("tps grant must not match under another election")
+	}
+}
+
+func TestPrincipal_TPSBoundAccountRole(t *testing.T) {
+	svc := NewService(stubRepo{})
+
+	p, _ := svc.Principal(context.Background(), 1, constants.RoleKetuaTPS, nil)
+	if p.IsStaff() {
+		t.Error("KETUA_TPS without a TPS should hold no permissions")
+	}
+
+	p, _ = svc.Principal(context.Background(), 1, constants.RoleTPSOperator, nil)
+	if p.IsStaff() {
+		t.Error("TPS_OPERATOR without a TPS should hold no permissions")
+	}
+
+	p, _ = svc.Principal(context.Background(), 1, constants.RoleTPSOperator, int64Ptr(9))
+	if !p.Can(PermTPSApproveCheckin, Scope{TPSID: int64Ptr(9)}) || p.Can(PermTPSApproveCheckin, Scope{TPSID: int64Ptr(10)}) {
+		t.Error("operator tied to a TPS should only approve check-ins there")
+	}
+}
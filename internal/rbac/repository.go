@@ -0,0 +1,12 @@
+package rbac
+
+import "context"
+
+type Repository interface {
+	// ListByUser returns every role assignment held by a user.
+	ListByUser(ctx context.Context, userID int64) ([]Assignment, error)
+	// Create stores an assignment. A TPS-scoped assignment is stored with the
+	// TPS's election so election listings include it.
+	Create(ctx context.Context, userID int64, req AssignRequest, grantedBy *int64) (*Assignment, error)
+	Delete(ctx context.Context, userID, assignmentID int64) error
+}
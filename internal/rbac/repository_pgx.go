@@ -0,0 +1,123 @@
+package rbac
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"pemira-api/internal/shared/constants"
+)
+
+type PgRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPgRepository(db *pgxpool.Pool) *PgRepository {
+	return &PgRepository{db: db}
+}
+
+const assignmentColumns = `id, user_id, role::text, election_id, tps_id, granted_by, created_at`
+
+func (r *PgRepository) ListByUser(ctx context.Context, userID int64) ([]Assignment, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+assignmentColumns+`
+		FROM role_assignments
+		WHERE user_id = $1
+		ORDER BY id`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []Assignment
+	for rows.Next() {
+		a, err := scanAssignment(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, *a)
+	}
+	return items, rows.Err()
+}
+
+func (r *PgRepository) Create(ctx context.Context, userID int64, req AssignRequest, grantedBy *int64) (*Assignment, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var exists bool
+	if err := tx.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM user_accounts WHERE id = $1)`, userID).Scan(&exists); err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrUserNotFound
+	}
+
+	electionID := req.ElectionID
+	if req.TPSID != nil {
+		var tpsElection int64
+		err := tx.QueryRow(ctx, `SELECT election_id FROM tps WHERE id = $1`, *req.TPSID).Scan(&tpsElection)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrScopeNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+		if electionID != nil && *electionID != tpsElection {
+			return nil, ErrScopeMismatch
+		}
+		electionID = &tpsElection
+	} else if electionID != nil {
+		if err := tx.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM elections WHERE id = $1)`, *electionID).Scan(&exists); err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, ErrScopeNotFound
+		}
+	}
+
+	row := tx.QueryRow(ctx, `
+		INSERT INTO role_assignments (user_id, role, election_id, tps_id, granted_by)
+		VALUES ($1, $2::user_role, $3, $4, $5)
+		RETURNING `+assignmentColumns,
+		userID, string(req.Role), electionID, req.TPSID, grantedBy)
+	a, err := scanAssignment(row)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, ErrAssignmentExists
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (r *PgRepository) Delete(ctx context.Context, userID, assignmentID int64) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM role_assignments WHERE id = $1 AND user_id = $2`, assignmentID, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrAssignmentNotFound
+	}
+	return nil
+}
+
+func scanAssignment(row pgx.Row) (*Assignment, error) {
+	var a Assignment
+	var role string
+	if err := row.Scan(&a.ID, &a.UserID, &role, &a.ElectionID, &a.TPSID, &a.GrantedBy, &a.CreatedAt); err != nil {
+		return nil, err
+	}
+	a.Role = constants.Role(role)
+	return &a, nil
+}
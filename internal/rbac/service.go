@@ -0,0 +1,76 @@
+package rbac
+
+import (
+	"context"
+
+	"pemira-api/internal/shared/constants"
+)
+
+type Service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// Principal builds the grants of an authenticated account: its account role
+// plus every stored assignment. TPS roles, TPS_OPERATOR included, only apply
+// to the TPS the account is tied to and grant nothing without one.
+func (s *Service) Principal(ctx context.Context, userID int64, role constants.Role, tpsID *int64) (*Principal, error) {
+	p := &Principal{UserID: userID, Role: role}
+
+	base := Assignment{UserID: userID, Role: role}
+	if IsTPSBound(role) || role == constants.RoleTPSOperator {
+		base.TPSID = tpsID
+		if tpsID != nil {
+			p.Grants = append(p.Grants, base)
+		}
+	} else {
+		p.Grants = append(p.Grants, base)
+	}
+
+	assignments, err := s.repo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	p.Grants = append(p.Grants, assignments...)
+	return p, nil
+}
+
+func (s *Service) ListAssignments(ctx context.Context, userID int64) ([]Assignment, error) {
+	items, err := s.repo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if items == nil {
+		items = []Assignment{}
+	}
+	return items, nil
+}
+
+// Assign grants req to userID on behalf of granter. A granter can only hand
+// out permissions they hold over the same scope, and SUPER_ADMIN only comes
+// from another SUPER_ADMIN.
+func (s *Service) Assign(ctx context.Context, userID int64, req AssignRequest, granter *Principal) (*Assignment, error) {
+	if !IsStaffRole(req.Role) {
+		return nil, ErrUnknownRole
+	}
+	if IsTPSBound(req.Role) && req.TPSID == nil {
+		return nil, ErrScopeRequired
+	}
+	if req.Role == constants.RoleSuperAdmin && !granter.isSuperAdmin() {
+		return nil, ErrSuperAdminGrant
+	}
+	scope := Scope{ElectionID: req.ElectionID, TPSID: req.TPSID}
+	for _, perm := range rolePermissions[req.Role] {
+		if !granter.Can(perm, scope) {
+			return nil, ErrRoleEscalation
+		}
+	}
+	return s.repo.Create(ctx, userID, req, &granter.UserID)
+}
+
+func (s *Service) Revoke(ctx context.Context, userID, assignmentID int64) error {
+	return s.repo.Delete(ctx, userID, assignmentID)
+}
@@ -0,0 +1,78 @@
+package rbac
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"pemira-api/internal/shared/constants"
+)
+
+type createRepo struct {
+	stubRepo
+	created []AssignRequest
+}
+
+func (r *createRepo) Create(ctx context.Context, userID int64, req AssignRequest, grantedBy *int64) (*Assignment, error) {
+	r.created = append(r.created, req)
+	return &Assignment{UserID: userID, Role: req.Role, ElectionID: req.ElectionID, TPSID: req.TPSID, GrantedBy: grantedBy}, nil
+}
+
+func granter(role constants.Role, grants ...Assignment) *Principal {
+	return &Principal{UserID: 1, Role: role, Grants: append([]Assignment{{UserID: 1, Role: role}}, grants...)}
+}
+
+func TestAssign_RejectsPermissionsTheGranterLacks(t *testing.T) {
+	repo := &createRepo{}
+	svc := NewService(repo)
+
+	// PANITIA cannot create an ADMIN, who holds election.manage and users.manage.
+	_, err := svc.Assign(context.Background(), 2, AssignRequest{Role: constants.RoleAdmin}, granter(constants.RolePanitia))
+	if !errors.Is(err, ErrRoleEscalation) {
+		t.Fatalf("expected ErrRoleEscalation, got %v", err)
+	}
+
+	// A PANITIA scoped to election 3 cannot hand out PANITIA everywhere.
+	scoped := &Principal{UserID: 1, Role: constants.RoleStudent, Grants: []Assignment{{Role: constants.RolePanitia, ElectionID: int64Ptr(3)}}}
+	if _, err := svc.Assign(context.Background(), 2, AssignRequest{Role: constants.RolePanitia}, scoped); !errors.Is(err, ErrRoleEscalation) {
+		t.Fatalf("expected ErrRoleEscalation for a wider scope, got %v", err)
+	}
+
+	// ...but can within election 3.
+	if _, err := svc.Assign(context.Background(), 2, AssignRequest{Role: constants.RoleViewer, ElectionID: int64Ptr(3)}, granter(constants.RoleViewer)); err != nil {
+		t.Fatalf("viewer granting viewer: %v", err)
+	}
+	if _, err := svc.Assign(context.Background(), 2, AssignRequest{Role: constants.RolePanitia, ElectionID: int64Ptr(3)}, scoped); err != nil {
+		t.Fatalf("panitia granting panitia in its election: %v", err)
+	}
+	if len(repo.created) != 2 {
+		t.Fatalf("expected 2 assignments, got %d", len(repo.created))
+	}
+}
+
+func TestAssign_SuperAdminOnlyFromSuperAdmin(t *testing.T) {
+	repo := &createRepo{}
+	svc := NewService(repo)
+	req := AssignRequest{Role: constants.RoleSuperAdmin}
+
+	// ADMIN holds every permission SUPER_ADMIN has, and still cannot grant it.
+	if _, err := svc.Assign(context.Background(), 2, req, granter(constants.RoleAdmin)); !errors.Is(err, ErrSuperAdminGrant) {
+		t.Fatalf("expected ErrSuperAdminGrant, got %v", err)
+	}
+	// Nor can a SUPER_ADMIN grant limited to one election.
+	scoped := granter(constants.RoleAdmin, Assignment{Role: constants.RoleSuperAdmin, ElectionID: int64Ptr(3)})
+	if _, err := svc.Assign(context.Background(), 2, req, scoped); !errors.Is(err, ErrSuperAdminGrant) {
+		t.Fatalf("expected ErrSuperAdminGrant for a scoped super admin, got %v", err)
+	}
+
+	a, err := svc.Assign(context.Background(), 2, req, granter(constants.RoleSuperAdmin))
+	if err != nil {
+		t.Fatalf("super admin granting super admin: %v", err)
+	}
+	if a.GrantedBy == nil || *a.GrantedBy != 1 {
+		t.Fatalf("granted_by not recorded: %+v", a)
+	}
+	if len(repo.created) != 1 {
+		t.Fatalf("expected 1 assignment, got %d", len(repo.created))
+	}
+}
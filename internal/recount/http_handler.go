@@ -0,0 +1,52 @@
+package recount
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"pemira-api/internal/errcatalog"
+	"pemira-api/internal/http/response"
+)
+
+type Handler struct {
+	svc *Service
+}
+
+func NewHandler(svc *Service) *Handler {
+	return &Handler{svc: svc}
+}
+
+// Check: GET /admin/elections/{electionID}/recount
+func (h *Handler) Check(w http.ResponseWriter, r *http.Request) {
+	h.check(w, r, false)
+}
+
+// RebuildStats: POST /admin/elections/{electionID}/recount/rebuild-stats
+func (h *Handler) RebuildStats(w http.ResponseWriter, r *http.Request) {
+	h.check(w, r, true)
+}
+
+func (h *Handler) check(w http.ResponseWriter, r *http.Request, rebuild bool) {
+	electionID, err := strconv.ParseInt(chi.URLParam(r, "electionID"), 10, 64)
+	if err != nil || electionID <= 0 {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("electionID"))
+		return
+	}
+
+	report, err := h.svc.Check(r.Context(), electionID, rebuild)
+	if err != nil {
+		if errors.Is(err, ErrElectionNotFound) {
+			response.NotFound(w, "ELECTION_NOT_FOUND", errcatalog.ElectionNotFound)
+			return
+		}
+		slog.Error("recount handler error", "err", err, "election_id", electionID)
+		response.InternalServerError(w, "INTERNAL_ERROR", errcatalog.InternalError)
+		return
+	}
+
+	response.Success(w, http.StatusOK, report)
+}
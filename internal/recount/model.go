@@ -0,0 +1,150 @@
+package recount
+
+import (
+	"errors"
+	"time"
+)
+
+type MismatchKind string
+
+const (
+	// MismatchStatsDrift: vote_stats disagrees with the released ballots
+	// counted for a candidate.
+	MismatchStatsDrift MismatchKind = "STATS_DRIFT"
+	// MismatchVotesWithoutTokens: a channel or TPS has more ballots than
+	// voter-side vote tokens.
+	MismatchVotesWithoutTokens MismatchKind = "VOTES_WITHOUT_TOKENS"
+	// MismatchOrphanTokens: a channel or TPS has more vote tokens than ballots.
+	MismatchOrphanTokens MismatchKind = "ORPHAN_TOKENS"
+	// MismatchVotedWithoutVote: voters flagged has_voted with no vote token.
+	// Ballots cannot be joined to voters, so the token is the voter-side
+	// record that a ballot was cast.
+	MismatchVotedWithoutVote MismatchKind = "VOTED_FLAG_WITHOUT_VOTE"
+	// MismatchTokenWithoutVoted: vote tokens whose voter is not flagged
+	// has_voted.
+	MismatchTokenWithoutVoted MismatchKind = "TOKEN_WITHOUT_VOTED_FLAG"
+)
+
+// maxVoterSample caps the voter IDs listed per voter-level mismatch.
+const maxVoterSample = 100
+
+var (
+	ErrElectionNotFound = errors.New("election not found")
+)
+
+// BallotCount is the number of released ballots for one candidate, channel
+// and TPS. Encrypted ballots have no candidate. Queued ballots are not
+// broken down at all; see Counts.Queued.
+type BallotCount struct {
+	CandidateID *int64
+	Channel     string
+	TPSID       *int64
+	Released    int64
+	Encrypted   int64
+}
+
+type TokenCount struct {
+	Channel string
+	TPSID   *int64
+	Tokens  int64
+}
+
+// VoterSample is a count of voters plus up to maxVoterSample of their IDs.
+type VoterSample struct {
+	Count    int64
+	VoterIDs []int64
+}
+
+// Counts is every source of truth for one election, read from one snapshot so
+// ballots cast while checking cannot show up as drift.
+type Counts struct {
+	Ballots []BallotCount
+	// Queued is the number of ballots waiting in ballot_queue, with no
+	// candidate, channel or TPS.
+	Queued            int64
+	Stats             map[int64]int64
+	Tokens            []TokenCount
+	VotedFlags        int64
+	VotedWithoutToken VoterSample
+	TokenWithoutVoted VoterSample
+}
+
+type Totals struct {
+	Ballots    int64 `json:"ballots"`
+	Released   int64 `json:"released"`
+	Queued     int64 `json:"queued"`
+	Encrypted  int64 `json:"encrypted"`
+	Stats      int64 `json:"vote_stats"`
+	Tokens     int64 `json:"vote_tokens"`
+	VotedFlags int64 `json:"voted_flags"`
+}
+
+type CandidateTotal struct {
+	CandidateID int64 `json:"candidate_id"`
+	Votes       int64 `json:"votes"`
+	Released    int64 `json:"released"`
+	Online      int64 `json:"online"`
+	TPS         int64 `json:"tps"`
+	Stats       int64 `json:"vote_stats"`
+}
+
+type ChannelTotal struct {
+	Channel string `json:"channel"`
+	Ballots int64  `json:"ballots"`
+	Tokens  int64  `json:"vote_tokens"`
+}
+
+type TPSTotal struct {
+	TPSID   *int64 `json:"tps_id"`
+	Ballots int64  `json:"ballots"`
+	Tokens  int64  `json:"vote_tokens"`
+}
+
+// Mismatch is one inconsistency. Expected is the count recomputed from
+// ballots (zero for voter-level kinds), Actual the count in the checked
+// source.
+type Mismatch struct {
+	Kind        MismatchKind `json:"kind"`
+	CandidateID *int64       `json:"candidate_id,omitempty"`
+	Channel     string       `json:"channel,omitempty"`
+	TPSID       *int64       `json:"tps_id,omitempty"`
+	Expected    int64        `json:"expected"`
+	Actual      int64        `json:"actual"`
+	VoterIDs    []int64      `json:"voter_ids,omitempty"`
+}
+
+type Report struct {
+	ElectionID   int64            `json:"election_id"`
+	CheckedAt    time.Time        `json:"checked_at"`
+	Consistent   bool             `json:"consistent"`
+	Totals       Totals           `json:"totals"`
+	Candidates   []CandidateTotal `json:"candidates"`
+	Channels     []ChannelTotal   `json:"channels"`
+	TPS          []TPSTotal       `json:"tps"`
+	Mismatches   []Mismatch       `json:"mismatches"`
+	StatsRebuilt bool             `json:"stats_rebuilt"`
+	// Repaired lists the STATS_DRIFT mismatches a rebuild fixed.
+	Repaired []Mismatch `json:"repaired,omitempty"`
+	// ResultsEmbargoed means per-candidate figures are withheld.
+	ResultsEmbargoed bool `json:"results_embargoed,omitempty"`
+}
+
+// withholdCandidates drops the per-candidate totals and the counts of
+// candidate mismatches. Consistency is still reported, so a drift can be
+// repaired without seeing the tally.
+func (r *Report) withholdCandidates() {
+	r.Candidates = []CandidateTotal{}
+	r.Mismatches = withholdMismatches(r.Mismatches)
+	r.Repaired = withholdMismatches(r.Repaired)
+	r.ResultsEmbargoed = true
+}
+
+func withholdMismatches(ms []Mismatch) []Mismatch {
+	for i := range ms {
+		if ms[i].CandidateID != nil {
+			ms[i].CandidateID = nil
+			ms[i].Expected, ms[i].Actual = 0, 0
+		}
+	}
+	return ms
+}
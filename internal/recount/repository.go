@@ -0,0 +1,12 @@
+package recount
+
+import "context"
+
+type Repository interface {
+	// LoadCounts returns ErrElectionNotFound for an unknown election.
+	LoadCounts(ctx context.Context, electionID int64) (*Counts, error)
+	// RebuildStats replaces the election's vote_stats with counts from votes;
+	// queued ballots are counted when their batch is released.
+	RebuildStats(ctx context.Context, electionID int64) error
+	ListOpenElections(ctx context.Context) ([]int64, error)
+}
@@ -0,0 +1,187 @@
+package recount
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PgRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPgRepository(db *pgxpool.Pool) *PgRepository {
+	return &PgRepository{db: db}
+}
+
+func (r *PgRepository) LoadCounts(ctx context.Context, electionID int64) (*Counts, error) {
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var exists bool
+	if err := tx.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM elections WHERE id = $1)`, electionID).Scan(&exists); err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrElectionNotFound
+	}
+
+	c := &Counts{Stats: map[int64]int64{}}
+
+	rows, err := tx.Query(ctx, `
+SELECT candidate_id, channel, tps_id,
+       COUNT(*) FILTER (WHERE src = 'votes'),
+       COUNT(*) FILTER (WHERE src = 'encrypted')
+FROM (
+    SELECT candidate_id, channel::text AS channel, tps_id, 'votes' AS src FROM votes WHERE election_id = $1
+    UNION ALL
+    SELECT NULL, channel::text, tps_id, 'encrypted' FROM encrypted_ballots WHERE election_id = $1
+) b
+GROUP BY candidate_id, channel, tps_id
+ORDER BY candidate_id NULLS LAST, channel, tps_id NULLS FIRST`, electionID)
+	if err != nil {
+		return nil, fmt.Errorf("count ballots: %w", err)
+	}
+	for rows.Next() {
+		var b BallotCount
+		if err := rows.Scan(&b.CandidateID, &b.Channel, &b.TPSID, &b.Released, &b.Encrypted); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		c.Ballots = append(c.Ballots, b)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Queued ballots are counted as one number: broken down by candidate or
+	// TPS they would tie a voter who just voted to a choice.
+	if err := tx.QueryRow(ctx, `SELECT COUNT(*) FROM ballot_queue WHERE election_id = $1`, electionID).Scan(&c.Queued); err != nil {
+		return nil, fmt.Errorf("count ballot_queue: %w", err)
+	}
+
+	rows, err = tx.Query(ctx, `SELECT candidate_id, total_votes FROM vote_stats WHERE election_id = $1`, electionID)
+	if err != nil {
+		return nil, fmt.Errorf("load vote_stats: %w", err)
+	}
+	for rows.Next() {
+		var candidateID, total int64
+		if err := rows.Scan(&candidateID, &total); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		c.Stats[candidateID] = total
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = tx.Query(ctx, `
+SELECT method::text, tps_id, COUNT(*)
+FROM vote_tokens
+WHERE election_id = $1
+GROUP BY method, tps_id
+ORDER BY method, tps_id NULLS FIRST`, electionID)
+	if err != nil {
+		return nil, fmt.Errorf("count vote_tokens: %w", err)
+	}
+	for rows.Next() {
+		var t TokenCount
+		if err := rows.Scan(&t.Channel, &t.TPSID, &t.Tokens); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		c.Tokens = append(c.Tokens, t)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := tx.QueryRow(ctx, `
+SELECT COUNT(*) FROM voter_status WHERE election_id = $1 AND has_voted`, electionID).Scan(&c.VotedFlags); err != nil {
+		return nil, fmt.Errorf("count voted flags: %w", err)
+	}
+
+	if err := scanSample(tx.QueryRow(ctx, `
+SELECT COUNT(*), COALESCE((array_agg(vs.voter_id ORDER BY vs.voter_id))[1:$2], '{}')
+FROM voter_status vs
+WHERE vs.election_id = $1
+  AND vs.has_voted
+  AND NOT EXISTS (
+      SELECT 1 FROM vote_tokens vt
+      WHERE vt.election_id = vs.election_id AND vt.voter_id = vs.voter_id
+  )`, electionID, maxVoterSample), &c.VotedWithoutToken); err != nil {
+		return nil, fmt.Errorf("find voted flags without tokens: %w", err)
+	}
+
+	if err := scanSample(tx.QueryRow(ctx, `
+SELECT COUNT(*), COALESCE((array_agg(vt.voter_id ORDER BY vt.voter_id))[1:$2], '{}')
+FROM vote_tokens vt
+LEFT JOIN voter_status vs ON vs.election_id = vt.election_id AND vs.voter_id = vt.voter_id
+WHERE vt.election_id = $1
+  AND COALESCE(vs.has_voted, FALSE) = FALSE`, electionID, maxVoterSample), &c.TokenWithoutVoted); err != nil {
+		return nil, fmt.Errorf("find tokens without voted flags: %w", err)
+	}
+
+	return c, nil
+}
+
+func scanSample(row pgx.Row, s *VoterSample) error {
+	return row.Scan(&s.Count, &s.VoterIDs)
+}
+
+func (r *PgRepository) RebuildStats(ctx context.Context, electionID int64) error {
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Blocks concurrent batch releases, and waits for releases that already
+	// counted, so the counts below include every released ballot and no batch
+	// can increment a row this rebuild is about to overwrite.
+	if _, err := tx.Exec(ctx, `LOCK TABLE vote_stats IN SHARE ROW EXCLUSIVE MODE`); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM vote_stats WHERE election_id = $1`, electionID); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `
+INSERT INTO vote_stats (election_id, candidate_id, total_votes, updated_at)
+SELECT $1, candidate_id, COUNT(*), NOW()
+FROM votes
+WHERE election_id = $1
+GROUP BY candidate_id`, electionID); err != nil {
+		return fmt.Errorf("rebuild vote_stats: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *PgRepository) ListOpenElections(ctx context.Context) ([]int64, error) {
+	rows, err := r.db.Query(ctx, `SELECT id FROM elections WHERE status = 'VOTING_OPEN' ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
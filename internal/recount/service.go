@@ -0,0 +1,287 @@
+package recount
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"time"
+
+	"pemira-api/internal/embargo"
+)
+
+// Service recomputes an election's totals from its ballots and checks them
+// against every counter kept alongside: vote_stats, vote_tokens and
+// voter_status.has_voted.
+type Service struct {
+	repo    Repository
+	now     func() time.Time
+	embargo embargo.Policy
+}
+
+func NewService(repo Repository) *Service {
+	return &Service{repo: repo, now: time.Now}
+}
+
+// SetEmbargo withholds per-candidate totals from reports while an
+// election's results are under embargo.
+func (s *Service) SetEmbargo(p embargo.Policy) {
+	s.embargo = p
+}
+
+// Check reports every mismatch in the election. With rebuild set, vote_stats
+// is rebuilt from the ballots when it drifted and the report reflects the
+// state after the rebuild.
+func (s *Service) Check(ctx context.Context, electionID int64, rebuild bool) (*Report, error) {
+	report, err := s.check(ctx, electionID, rebuild)
+	if err != nil {
+		return nil, err
+	}
+	if s.embargo != nil && s.embargo.Sealed(ctx, electionID) {
+		report.withholdCandidates()
+	}
+	return report, nil
+}
+
+func (s *Service) check(ctx context.Context, electionID int64, rebuild bool) (*Report, error) {
+	counts, err := s.repo.LoadCounts(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+	report := buildReport(electionID, counts, s.now().UTC())
+	if !rebuild {
+		return report, nil
+	}
+
+	var drift []Mismatch
+	for _, m := range report.Mismatches {
+		if m.Kind == MismatchStatsDrift {
+			drift = append(drift, m)
+		}
+	}
+	if len(drift) == 0 {
+		return report, nil
+	}
+
+	if err := s.repo.RebuildStats(ctx, electionID); err != nil {
+		return nil, err
+	}
+	if counts, err = s.repo.LoadCounts(ctx, electionID); err != nil {
+		return nil, err
+	}
+	report = buildReport(electionID, counts, s.now().UTC())
+	report.StatsRebuilt = true
+	report.Repaired = drift
+	return report, nil
+}
+
+// CheckOpenElections checks every election with voting open and logs the
+// ones that are inconsistent.
+func (s *Service) CheckOpenElections(ctx context.Context) ([]*Report, error) {
+	ids, err := s.repo.ListOpenElections(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reports := make([]*Report, 0, len(ids))
+	for _, id := range ids {
+		report, err := s.Check(ctx, id, false)
+		if err != nil {
+			slog.Error("recount failed", "election_id", id, "err", err)
+			continue
+		}
+		if !report.Consistent {
+			slog.Warn("recount found mismatches",
+				"election_id", id,
+				"mismatches", len(report.Mismatches),
+				"kinds", mismatchKinds(report.Mismatches),
+			)
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// Run checks open elections every interval until ctx is done. A
+// non-positive interval disables the job.
+func (s *Service) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.CheckOpenElections(ctx); err != nil {
+				slog.Error("recount job failed", "err", err)
+			}
+		}
+	}
+}
+
+func mismatchKinds(ms []Mismatch) []string {
+	seen := map[MismatchKind]bool{}
+	var kinds []string
+	for _, m := range ms {
+		if !seen[m.Kind] {
+			seen[m.Kind] = true
+			kinds = append(kinds, string(m.Kind))
+		}
+	}
+	return kinds
+}
+
+// tpsKey maps TPS ballots and tokens whose TPS was deleted to 0.
+func tpsKey(tpsID *int64) int64 {
+	if tpsID == nil {
+		return 0
+	}
+	return *tpsID
+}
+
+func buildReport(electionID int64, c *Counts, now time.Time) *Report {
+	r := &Report{
+		ElectionID: electionID,
+		CheckedAt:  now,
+		Candidates: []CandidateTotal{},
+		Channels:   []ChannelTotal{},
+		TPS:        []TPSTotal{},
+		Mismatches: []Mismatch{},
+	}
+
+	candidates := map[int64]*CandidateTotal{}
+	candidate := func(id int64) *CandidateTotal {
+		if ct, ok := candidates[id]; ok {
+			return ct
+		}
+		ct := &CandidateTotal{CandidateID: id}
+		candidates[id] = ct
+		return ct
+	}
+	channels := map[string]*ChannelTotal{}
+	channel := func(name string) *ChannelTotal {
+		if ch, ok := channels[name]; ok {
+			return ch
+		}
+		ch := &ChannelTotal{Channel: name}
+		channels[name] = ch
+		return ch
+	}
+	tpsTotals := map[int64]*TPSTotal{}
+	tps := func(tpsID *int64) *TPSTotal {
+		k := tpsKey(tpsID)
+		if t, ok := tpsTotals[k]; ok {
+			return t
+		}
+		t := &TPSTotal{TPSID: tpsID}
+		tpsTotals[k] = t
+		return t
+	}
+
+	// Candidate totals count released ballots only. The queue is the batch
+	// of ballots cast since the last release, so any per-candidate share of
+	// it would show how the most recent voters chose.
+	r.Totals.Queued = c.Queued
+	r.Totals.Ballots = c.Queued
+	for _, b := range c.Ballots {
+		n := b.Released + b.Encrypted
+		r.Totals.Released += b.Released
+		r.Totals.Encrypted += b.Encrypted
+		r.Totals.Ballots += n
+
+		if b.CandidateID != nil {
+			ct := candidate(*b.CandidateID)
+			ct.Votes += b.Released
+			ct.Released += b.Released
+			if b.Channel == "TPS" {
+				ct.TPS += b.Released
+			} else {
+				ct.Online += b.Released
+			}
+		}
+		channel(b.Channel).Ballots += n
+		if b.Channel == "TPS" {
+			tps(b.TPSID).Ballots += n
+		}
+	}
+
+	for id, total := range c.Stats {
+		candidate(id).Stats = total
+		r.Totals.Stats += total
+	}
+
+	for _, t := range c.Tokens {
+		r.Totals.Tokens += t.Tokens
+		channel(t.Channel).Tokens += t.Tokens
+		if t.Channel == "TPS" {
+			tps(t.TPSID).Tokens += t.Tokens
+		}
+	}
+	r.Totals.VotedFlags = c.VotedFlags
+
+	for _, ct := range candidates {
+		r.Candidates = append(r.Candidates, *ct)
+	}
+	sort.Slice(r.Candidates, func(i, j int) bool { return r.Candidates[i].CandidateID < r.Candidates[j].CandidateID })
+	for _, ch := range channels {
+		r.Channels = append(r.Channels, *ch)
+	}
+	sort.Slice(r.Channels, func(i, j int) bool { return r.Channels[i].Channel < r.Channels[j].Channel })
+	for _, t := range tpsTotals {
+		r.TPS = append(r.TPS, *t)
+	}
+	sort.Slice(r.TPS, func(i, j int) bool { return tpsKey(r.TPS[i].TPSID) < tpsKey(r.TPS[j].TPSID) })
+
+	for _, ct := range r.Candidates {
+		if ct.Released != ct.Stats {
+			id := ct.CandidateID
+			r.Mismatches = append(r.Mismatches, Mismatch{Kind: MismatchStatsDrift, CandidateID: &id, Expected: ct.Released, Actual: ct.Stats})
+		}
+	}
+	// Channel and TPS ballots leave out the queue, so up to Queued tokens
+	// there may still be waiting for their ballot. The election-wide totals
+	// include the queue and must match exactly.
+	tokenMismatches := len(r.Mismatches)
+	for _, ch := range r.Channels {
+		if m, ok := tokenMismatch(ch.Ballots, ch.Tokens, c.Queued); ok {
+			m.Channel = ch.Channel
+			r.Mismatches = append(r.Mismatches, m)
+		}
+	}
+	for _, t := range r.TPS {
+		if m, ok := tokenMismatch(t.Ballots, t.Tokens, c.Queued); ok {
+			m.Channel = "TPS"
+			m.TPSID = t.TPSID
+			r.Mismatches = append(r.Mismatches, m)
+		}
+	}
+	if len(r.Mismatches) == tokenMismatches {
+		if m, ok := tokenMismatch(r.Totals.Ballots, r.Totals.Tokens, 0); ok {
+			r.Mismatches = append(r.Mismatches, m)
+		}
+	}
+	if c.VotedWithoutToken.Count > 0 {
+		r.Mismatches = append(r.Mismatches, Mismatch{Kind: MismatchVotedWithoutVote, Actual: c.VotedWithoutToken.Count, VoterIDs: c.VotedWithoutToken.VoterIDs})
+	}
+	if c.TokenWithoutVoted.Count > 0 {
+		r.Mismatches = append(r.Mismatches, Mismatch{Kind: MismatchTokenWithoutVoted, Actual: c.TokenWithoutVoted.Count, VoterIDs: c.TokenWithoutVoted.VoterIDs})
+	}
+
+	r.Consistent = len(r.Mismatches) == 0
+	return r
+}
+
+// tokenMismatch compares ballots with tokens, allowing up to queued tokens
+// whose ballots are still in the queue.
+func tokenMismatch(ballots, tokens, queued int64) (Mismatch, bool) {
+	switch {
+	case ballots > tokens:
+		return Mismatch{Kind: MismatchVotesWithoutTokens, Expected: ballots, Actual: tokens}, true
+	case tokens > ballots+queued:
+		return Mismatch{Kind: MismatchOrphanTokens, Expected: ballots, Actual: tokens}, true
+	}
+	return Mismatch{}, false
+}
@@ -0,0 +1,208 @@
+package recount
+
+import (
+	"context"
+	"testing"
+)
+
+type stubRepo struct {
+	counts   *Counts
+	rebuilds int
+}
+
+func (r *stubRepo) LoadCounts(ctx context.Context, electionID int64) (*Counts, error) {
+	return r.counts, nil
+}
+
+func (r *stubRepo) RebuildStats(ctx context.Context, electionID int64) error {
+	r.rebuilds++
+	stats := map[int64]int64{}
+	for _, b := range r.counts.Ballots {
+		if b.CandidateID != nil {
+			stats[*b.CandidateID] += b.Released
+		}
+	}
+	r.counts.Stats = stats
+	return nil
+}
+
+func (r *stubRepo) ListOpenElections(ctx context.Context) ([]int64, error) {
+	return []int64{1}, nil
+}
+
+func ptr(v int64) *int64 { return &v }
+
+func consistentCounts() *Counts {
+	return &Counts{
+		Ballots: []BallotCount{
+			{CandidateID: ptr(10), Channel: "ONLINE", Released: 3},
+			{CandidateID: ptr(10), Channel: "TPS", TPSID: ptr(7), Released: 2},
+		},
+		Queued: 2, // one ONLINE, one TPS 7
+		Stats: map[int64]int64{10: 5},
+		Tokens: []TokenCount{
+			{Channel: "ONLINE", Tokens: 4},
+			{Channel: "TPS", TPSID: ptr(7), Tokens: 3},
+		},
+		VotedFlags: 7,
+	}
+}
+
+func kinds(r *Report) map[MismatchKind]Mismatch {
+	out := map[MismatchKind]Mismatch{}
+	for _, m := range r.Mismatches {
+		out[m.Kind] = m
+	}
+	return out
+}
+
+func TestCheck_Consistent(t *testing.T) {
+	svc := NewService(&stubRepo{counts: consistentCounts()})
+
+	report, err := svc.Check(context.Background(), 1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.Consistent || len(report.Mismatches) != 0 {
+		t.Fatalf("expected consistent report, got %+v", report.Mismatches)
+	}
+	if report.Totals.Ballots != 7 || report.Totals.Queued != 2 || report.Totals.Tokens != 7 {
+		t.Fatalf("unexpected totals %+v", report.Totals)
+	}
+	if len(report.Candidates) != 1 || report.Candidates[0].Online != 3 || report.Candidates[0].TPS != 2 {
+		t.Fatalf("unexpected candidate totals %+v", report.Candidates)
+	}
+}
+
+func TestCheck_ReportsMismatches(t *testing.T) {
+	c := consistentCounts()
+	c.Stats[10] = 4
+	c.Tokens[0].Tokens = 6 // more ONLINE tokens than ballots, even counting the whole queue
+	c.Tokens[1].Tokens = 1 // one TPS ballot without a token
+	c.VotedWithoutToken = VoterSample{Count: 1, VoterIDs: []int64{42}}
+
+	report, err := NewService(&stubRepo{counts: c}).Check(context.Background(), 1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Consistent {
+		t.Fatal("expected mismatches")
+	}
+
+	got := kinds(report)
+	if m := got[MismatchStatsDrift]; m.CandidateID == nil || *m.CandidateID != 10 || m.Expected != 5 || m.Actual != 4 {
+		t.Errorf("stats drift: %+v", m)
+	}
+	if m := got[MismatchOrphanTokens]; m.Channel != "ONLINE" || m.Expected != 3 || m.Actual != 6 {
+		t.Errorf("orphan tokens: %+v", m)
+	}
+	if m := got[MismatchVotesWithoutTokens]; m.Channel != "TPS" || m.Expected != 2 || m.Actual != 1 {
+		t.Errorf("votes without tokens: %+v", m)
+	}
+	if m := got[MismatchVotedWithoutVote]; m.Actual != 1 || len(m.VoterIDs) != 1 {
+		t.Errorf("voted without vote: %+v", m)
+	}
+}
+
+func TestCheck_QueuedBallotsNeverReachCandidateTotals(t *testing.T) {
+	before, err := NewService(&stubRepo{counts: consistentCounts()}).Check(context.Background(), 1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := consistentCounts()
+	c.Queued += 3
+	c.Tokens[0].Tokens += 3
+	after, err := NewService(&stubRepo{counts: c}).Check(context.Background(), 1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(after.Candidates) != len(before.Candidates) {
+		t.Fatalf("candidates changed: %+v -> %+v", before.Candidates, after.Candidates)
+	}
+	for i := range before.Candidates {
+		if after.Candidates[i] != before.Candidates[i] {
+			t.Fatalf("queued ballot changed candidate total: %+v -> %+v", before.Candidates[i], after.Candidates[i])
+		}
+	}
+	if after.Totals.Queued != 5 || after.Totals.Ballots != before.Totals.Ballots+3 {
+		t.Fatalf("queue not counted in totals: %+v", after.Totals)
+	}
+	if !after.Consistent {
+		t.Fatalf("tokens waiting on queued ballots reported as mismatches: %+v", after.Mismatches)
+	}
+}
+
+func TestCheck_OrphanTokensBeyondQueue(t *testing.T) {
+	c := consistentCounts()
+	c.Tokens = append(c.Tokens, TokenCount{Channel: "TPS", TPSID: ptr(8), Tokens: 1})
+
+	report, err := NewService(&stubRepo{counts: c}).Check(context.Background(), 1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// TPS 8 is within the queue's slack, but election-wide there is one
+	// token more than ballots.
+	m, ok := kinds(report)[MismatchOrphanTokens]
+	if !ok || m.Channel != "" || m.Expected != 7 || m.Actual != 8 {
+		t.Fatalf("expected election-wide orphan token, got %+v", report.Mismatches)
+	}
+}
+
+func TestCheck_RebuildsDriftedStats(t *testing.T) {
+	c := consistentCounts()
+	c.Stats = map[int64]int64{10: 2, 99: 4}
+	repo := &stubRepo{counts: c}
+
+	report, err := NewService(repo).Check(context.Background(), 1, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if repo.rebuilds != 1 || !report.StatsRebuilt {
+		t.Fatalf("expected one rebuild, got %d", repo.rebuilds)
+	}
+	if !report.Consistent {
+		t.Fatalf("expected consistent after rebuild, got %+v", report.Mismatches)
+	}
+	if len(report.Repaired) != 2 {
+		t.Fatalf("expected 2 repaired candidates, got %+v", report.Repaired)
+	}
+
+	// Nothing to repair: no rebuild.
+	if _, err := NewService(repo).Check(context.Background(), 1, true); err != nil {
+		t.Fatal(err)
+	}
+	if repo.rebuilds != 1 {
+		t.Fatalf("rebuilt consistent stats")
+	}
+}
+
+type sealed bool
+
+func (s sealed) Sealed(ctx context.Context, electionID int64) bool { return bool(s) }
+
+func TestCheck_WithholdsCandidatesUnderEmbargo(t *testing.T) {
+	c := consistentCounts()
+	c.Stats[10] = 4
+	svc := NewService(&stubRepo{counts: c})
+	svc.SetEmbargo(sealed(true))
+
+	report, err := svc.Check(context.Background(), 1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.ResultsEmbargoed || len(report.Candidates) != 0 {
+		t.Fatalf("candidates not withheld: %+v", report.Candidates)
+	}
+	drift, ok := kinds(report)[MismatchStatsDrift]
+	if !ok || report.Consistent {
+		t.Fatalf("drift should still be reported: %+v", report.Mismatches)
+	}
+	if drift.CandidateID != nil || drift.Expected != 0 || drift.Actual != 0 {
+		t.Fatalf("drift leaks candidate counts: %+v", drift)
+	}
+	if report.Totals.Ballots != 7 {
+		t.Fatalf("totals should stay visible: %+v", report.Totals)
+	}
+}
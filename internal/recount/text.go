@@ -0,0 +1,43 @@
+package recount
+
+import (
+	"fmt"
+	"io"
+)
+
+// WriteText prints the report for a terminal.
+func (r *Report) WriteText(w io.Writer) {
+	fmt.Fprintf(w, "Election %d (checked %s)\n", r.ElectionID, r.CheckedAt.Format("2006-01-02 15:04:05Z07:00"))
+	t := r.Totals
+	fmt.Fprintf(w, "  ballots %d (released %d, queued %d, encrypted %d)\n", t.Ballots, t.Released, t.Queued, t.Encrypted)
+	fmt.Fprintf(w, "  vote_stats %d, vote_tokens %d, voted flags %d\n", t.Stats, t.Tokens, t.VotedFlags)
+
+	for _, c := range r.Candidates {
+		fmt.Fprintf(w, "  candidate %-6d votes %-6d online %-6d tps %-6d vote_stats %d\n", c.CandidateID, c.Votes, c.Online, c.TPS, c.Stats)
+	}
+
+	for _, m := range r.Repaired {
+		fmt.Fprintf(w, "  REPAIRED %s candidate %d: vote_stats %d -> %d\n", m.Kind, *m.CandidateID, m.Actual, m.Expected)
+	}
+
+	if r.Consistent {
+		fmt.Fprintln(w, "  OK: all counts agree")
+		return
+	}
+	for _, m := range r.Mismatches {
+		scope := ""
+		switch {
+		case m.CandidateID != nil:
+			scope = fmt.Sprintf(" candidate %d", *m.CandidateID)
+		case m.TPSID != nil:
+			scope = fmt.Sprintf(" TPS %d", *m.TPSID)
+		case m.Channel != "":
+			scope = " " + m.Channel
+		}
+		fmt.Fprintf(w, "  MISMATCH %s%s: expected %d, found %d", m.Kind, scope, m.Expected, m.Actual)
+		if len(m.VoterIDs) > 0 {
+			fmt.Fprintf(w, " (voters %v)", m.VoterIDs)
+		}
+		fmt.Fprintln(w)
+	}
+}
@@ -0,0 +1,17 @@
+package results
+
+import (
+	"net/http"
+
+	"pemira-api/internal/errcatalog"
+)
+
+// The API codes and messages of the results errors; handleError writes them
+func init() {
+	errcatalog.Register(
+		errcatalog.Entry{Err: ErrElectionNotFound, Code: "ELECTION_NOT_FOUND", Status: http.StatusNotFound, ID: "Pemilu tidak ditemukan.", EN: "Election not found."},
+		errcatalog.Entry{Err: ErrNotPublished, Code: "RESULTS_NOT_PUBLISHED", Status: http.StatusForbidden, ID: "Hasil pemilu belum diumumkan.", EN: "The election results are not published yet."},
+		errcatalog.Entry{Err: ErrTurnoutNotOpen, Code: "TURNOUT_NOT_AVAILABLE", Status: http.StatusForbidden, ID: "Data partisipasi tersedia setelah voting dibuka.", EN: "Turnout is available once voting opens."},
+		errcatalog.Entry{Err: ErrNothingToGenerate, Code: "SNAPSHOT_NOT_AVAILABLE", Status: http.StatusConflict, ID: "Snapshot hasil baru dapat dibuat setelah voting dibuka.", EN: "A results snapshot can only be generated once voting opens."},
+	)
+}
@@ -0,0 +1,91 @@
+package results
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"pemira-api/internal/errcatalog"
+	"pemira-api/internal/http/response"
+)
+
+type Handler struct {
+	svc *Service
+}
+
+func NewHandler(svc *Service) *Handler {
+	return &Handler{svc: svc}
+}
+
+// Results: GET /elections/{electionID}/results
+func (h *Handler) Results(w http.ResponseWriter, r *http.Request) {
+	h.serve(w, r, KindResults)
+}
+
+// Turnout: GET /elections/{electionID}/turnout
+func (h *Handler) Turnout(w http.ResponseWriter, r *http.Request) {
+	h.serve(w, r, KindTurnout)
+}
+
+// serve writes the snapshot as {"data": ...}. ServeContent answers
+// If-None-Match and If-Modified-Since with 304.
+func (h *Handler) serve(w http.ResponseWriter, r *http.Request, kind Kind) {
+	electionID, ok := parseElectionID(w, r)
+	if !ok {
+		return
+	}
+
+	snap, err := h.svc.Get(r.Context(), electionID, kind)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	body := make([]byte, 0, len(snap.Payload)+10)
+	body = append(body, `{"data":`...)
+	body = append(body, snap.Payload...)
+	body = append(body, '}')
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", snap.ETag)
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(CacheTTL.Seconds())))
+	http.ServeContent(w, r, "", snap.GeneratedAt, bytes.NewReader(body))
+}
+
+// Regenerate: POST /admin/elections/{electionID}/results/snapshot
+func (h *Handler) Regenerate(w http.ResponseWriter, r *http.Request) {
+	electionID, ok := parseElectionID(w, r)
+	if !ok {
+		return
+	}
+
+	infos, err := h.svc.Regenerate(r.Context(), electionID)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	response.Success(w, http.StatusOK, map[string]interface{}{"snapshots": infos})
+}
+
+func parseElectionID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "electionID"), 10, 64)
+	if err != nil || id <= 0 {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("electionID"))
+		return 0, false
+	}
+	return id, true
+}
+
+// handleError maps service errors to HTTP responses through the error
+// catalog (see errors.go)
+func (h *Handler) handleError(w http.ResponseWriter, r *http.Request, err error) {
+	if response.DomainError(w, err) {
+		return
+	}
+	slog.ErrorContext(r.Context(), "results handler error", "error", err)
+	response.InternalServerError(w, "INTERNAL_ERROR", errcatalog.InternalError)
+}
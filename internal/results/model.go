@@ -0,0 +1,121 @@
+// Package results serves an election's public results and turnout from
+// precomputed snapshots. A background job refreshes the snapshots; public
+// requests only ever read them, so announcement-day traffic never reaches
+// votes or voter_status.
+package results
+
+import (
+	"errors"
+	"time"
+
+	"pemira-api/internal/election"
+)
+
+// Kind is what a snapshot holds
+type Kind string
+
+const (
+	KindResults Kind = "RESULTS"
+	KindTurnout Kind = "TURNOUT"
+)
+
+var (
+	ErrElectionNotFound  = errors.New("election not found")
+	ErrSnapshotNotFound  = errors.New("snapshot not found")
+	ErrNotPublished      = errors.New("results are not published yet")
+	ErrTurnoutNotOpen    = errors.New("turnout is not available before voting opens")
+	ErrNothingToGenerate = errors.New("election has no snapshot to generate yet")
+)
+
+// Election is what snapshot availability and freshness depend on
+type Election struct {
+	ID             int64
+	Name           string
+	Status         election.ElectionStatus
+	AnnouncementAt *time.Time
+	UpdatedAt      time.Time
+	// ResultsAt and TurnoutAt are when the stored snapshots were generated
+	ResultsAt *time.Time
+	TurnoutAt *time.Time
+}
+
+// votingClosed reports whether no more ballots can be cast
+func votingClosed(status election.ElectionStatus) bool {
+	switch status {
+	case election.ElectionStatusVotingClosed,
+		election.ElectionStatusClosed,
+		election.ElectionStatusRecap,
+		election.ElectionStatusArchived:
+		return true
+	}
+	return false
+}
+
+// TurnoutAvailable reports whether turnout is public: from the moment
+// voting opens
+func (e Election) TurnoutAvailable() bool {
+	return e.Status == election.ElectionStatusVotingOpen || votingClosed(e.Status)
+}
+
+// ResultsAvailable reports whether per-candidate results are public at now:
+// voting is closed and the announcement time has passed
+func (e Election) ResultsAvailable(now time.Time) bool {
+	return votingClosed(e.Status) && e.AnnouncementAt != nil && !now.Before(*e.AnnouncementAt)
+}
+
+// Snapshot is a stored response body
+type Snapshot struct {
+	ElectionID  int64
+	Kind        Kind
+	Payload     []byte
+	ETag        string
+	GeneratedAt time.Time
+}
+
+// SnapshotInfo describes a snapshot without its payload
+type SnapshotInfo struct {
+	Kind        Kind      `json:"kind"`
+	ETag        string    `json:"etag"`
+	GeneratedAt time.Time `json:"generated_at"`
+}
+
+type FacultyTurnout struct {
+	FacultyCode    string  `json:"faculty_code"`
+	FacultyName    string  `json:"faculty_name"`
+	TotalEligible  int64   `json:"total_eligible"`
+	TotalVoted     int64   `json:"total_voted"`
+	TurnoutPercent float64 `json:"turnout_percent"`
+}
+
+type Turnout struct {
+	ElectionID     int64                   `json:"election_id"`
+	ElectionName   string                  `json:"election_name"`
+	Status         election.ElectionStatus `json:"status"`
+	TotalEligible  int64                   `json:"total_eligible"`
+	TotalVoted     int64                   `json:"total_voted"`
+	TurnoutPercent float64                 `json:"turnout_percent"`
+	Faculties      []FacultyTurnout        `json:"faculties"`
+	GeneratedAt    time.Time               `json:"generated_at"`
+}
+
+type CandidateResult struct {
+	CandidateID int64   `json:"candidate_id"`
+	Number      int     `json:"number"`
+	Name        string  `json:"name"`
+	Votes       int64   `json:"votes"`
+	Percent     float64 `json:"percent"`
+}
+
+// Results is the public result of an election. Ballots cannot be joined to
+// voters, so the per-faculty breakdown is turnout only.
+type Results struct {
+	ElectionID     int64             `json:"election_id"`
+	ElectionName   string            `json:"election_name"`
+	AnnouncementAt *time.Time        `json:"announcement_at"`
+	TotalVotes     int64             `json:"total_votes"`
+	TotalEligible  int64             `json:"total_eligible"`
+	TurnoutPercent float64           `json:"turnout_percent"`
+	Candidates     []CandidateResult `json:"candidates"`
+	Faculties      []FacultyTurnout  `json:"faculties"`
+	GeneratedAt    time.Time         `json:"generated_at"`
+}
@@ -0,0 +1,19 @@
+package results
+
+import "context"
+
+type Repository interface {
+	// GetElection returns ErrElectionNotFound for an unknown election.
+	GetElection(ctx context.Context, electionID int64) (*Election, error)
+	// ListElections returns the elections whose snapshots may still change:
+	// voting open or closed, not archived.
+	ListElections(ctx context.Context) ([]Election, error)
+	// CandidateVotes returns every candidate with its released ballots;
+	// Percent is left for the caller.
+	CandidateVotes(ctx context.Context, electionID int64) ([]CandidateResult, error)
+	// FacultyTurnout returns eligible and voted voters per faculty.
+	FacultyTurnout(ctx context.Context, electionID int64) ([]FacultyTurnout, error)
+	// GetSnapshot returns ErrSnapshotNotFound when none was stored yet.
+	GetSnapshot(ctx context.Context, electionID int64, kind Kind) (*Snapshot, error)
+	SaveSnapshot(ctx context.Context, s *Snapshot) error
+}
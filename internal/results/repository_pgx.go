@@ -0,0 +1,138 @@
+package results
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PgRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPgRepository(db *pgxpool.Pool) *PgRepository {
+	return &PgRepository{db: db}
+}
+
+const selectElection = `
+SELECT e.id, e.name, e.status, e.announcement_at, e.updated_at,
+       (SELECT generated_at FROM election_result_snapshots WHERE election_id = e.id AND kind = 'RESULTS'),
+       (SELECT generated_at FROM election_result_snapshots WHERE election_id = e.id AND kind = 'TURNOUT')
+FROM elections e`
+
+func scanElection(row pgx.Row) (*Election, error) {
+	var e Election
+	if err := row.Scan(&e.ID, &e.Name, &e.Status, &e.AnnouncementAt, &e.UpdatedAt, &e.ResultsAt, &e.TurnoutAt); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+func (r *PgRepository) GetElection(ctx context.Context, electionID int64) (*Election, error) {
+	e, err := scanElection(r.db.QueryRow(ctx, selectElection+` WHERE e.id = $1`, electionID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrElectionNotFound
+	}
+	return e, err
+}
+
+func (r *PgRepository) ListElections(ctx context.Context) ([]Election, error) {
+	rows, err := r.db.Query(ctx, selectElection+`
+WHERE e.status IN ('VOTING_OPEN', 'VOTING_CLOSED', 'CLOSED', 'RECAP')
+ORDER BY e.id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []Election
+	for rows.Next() {
+		e, err := scanElection(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *e)
+	}
+	return out, rows.Err()
+}
+
+func (r *PgRepository) CandidateVotes(ctx context.Context, electionID int64) ([]CandidateResult, error) {
+	rows, err := r.db.Query(ctx, `
+SELECT c.id, c.number, c.name, COUNT(v.id)
+FROM candidates c
+LEFT JOIN votes v ON v.candidate_id = c.id AND v.election_id = c.election_id
+WHERE c.election_id = $1
+GROUP BY c.id, c.number, c.name
+ORDER BY c.number, c.id`, electionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []CandidateResult{}
+	for rows.Next() {
+		var c CandidateResult
+		if err := rows.Scan(&c.CandidateID, &c.Number, &c.Name, &c.Votes); err != nil {
+			return nil, err
+		}
+		out = append(out, c)
+	}
+	return out, rows.Err()
+}
+
+func (r *PgRepository) FacultyTurnout(ctx context.Context, electionID int64) ([]FacultyTurnout, error) {
+	rows, err := r.db.Query(ctx, `
+SELECT COALESCE(v.faculty_code, ''),
+       COALESCE(NULLIF(v.faculty_name, ''), 'Unknown'),
+       COUNT(*),
+       COUNT(*) FILTER (WHERE vs.has_voted)
+FROM voter_status vs
+JOIN voters v ON v.id = vs.voter_id
+WHERE vs.election_id = $1
+  AND vs.is_eligible
+GROUP BY 1, 2
+ORDER BY 2, 1`, electionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []FacultyTurnout{}
+	for rows.Next() {
+		var f FacultyTurnout
+		if err := rows.Scan(&f.FacultyCode, &f.FacultyName, &f.TotalEligible, &f.TotalVoted); err != nil {
+			return nil, err
+		}
+		out = append(out, f)
+	}
+	return out, rows.Err()
+}
+
+func (r *PgRepository) GetSnapshot(ctx context.Context, electionID int64, kind Kind) (*Snapshot, error) {
+	s := Snapshot{ElectionID: electionID, Kind: kind}
+	err := r.db.QueryRow(ctx, `
+SELECT payload::text, etag, generated_at
+FROM election_result_snapshots
+WHERE election_id = $1 AND kind = $2`, electionID, kind).Scan(&s.Payload, &s.ETag, &s.GeneratedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrSnapshotNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *PgRepository) SaveSnapshot(ctx context.Context, s *Snapshot) error {
+	_, err := r.db.Exec(ctx, `
+INSERT INTO election_result_snapshots (election_id, kind, payload, etag, generated_at)
+VALUES ($1, $2, $3::text::json, $4, $5)
+ON CONFLICT (election_id, kind) DO UPDATE
+SET payload = EXCLUDED.payload,
+    etag = EXCLUDED.etag,
+    generated_at = EXCLUDED.generated_at`,
+		s.ElectionID, s.Kind, string(s.Payload), s.ETag, s.GeneratedAt)
+	return err
+}
@@ -0,0 +1,188 @@
+package results
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"pemira-api/internal/election"
+)
+
+type fakeRepo struct {
+	election  Election
+	snapshots map[Kind]*Snapshot
+	computed  int
+	loads     int
+}
+
+func (f *fakeRepo) GetElection(ctx context.Context, electionID int64) (*Election, error) {
+	f.loads++
+	if electionID != f.election.ID {
+		return nil, ErrElectionNotFound
+	}
+	e := f.election
+	return &e, nil
+}
+
+func (f *fakeRepo) ListElections(ctx context.Context) ([]Election, error) {
+	return []Election{f.election}, nil
+}
+
+func (f *fakeRepo) CandidateVotes(ctx context.Context, electionID int64) ([]CandidateResult, error) {
+	f.computed++
+	return []CandidateResult{{CandidateID: 1, Number: 1, Votes: 2}, {CandidateID: 2, Number: 2, Votes: 1}}, nil
+}
+
+func (f *fakeRepo) FacultyTurnout(ctx context.Context, electionID int64) ([]FacultyTurnout, error) {
+	f.computed++
+	return []FacultyTurnout{
+		{FacultyCode: "FT", FacultyName: "Teknik", TotalEligible: 4, TotalVoted: 2},
+		{FacultyCode: "FE", FacultyName: "Ekonomi", TotalEligible: 2, TotalVoted: 1},
+	}, nil
+}
+
+func (f *fakeRepo) GetSnapshot(ctx context.Context, electionID int64, kind Kind) (*Snapshot, error) {
+	if s, ok := f.snapshots[kind]; ok {
+		return s, nil
+	}
+	return nil, ErrSnapshotNotFound
+}
+
+func (f *fakeRepo) SaveSnapshot(ctx context.Context, s *Snapshot) error {
+	f.snapshots[s.Kind] = s
+	return nil
+}
+
+func newTestService(e Election, now *time.Time) (*Service, *fakeRepo) {
+	repo := &fakeRepo{election: e, snapshots: map[Kind]*Snapshot{}}
+	svc := NewService(repo)
+	svc.now = func() time.Time { return *now }
+	return svc, repo
+}
+
+func TestGetWaitsForAnnouncement(t *testing.T) {
+	now := time.Date(2026, 11, 20, 8, 0, 0, 0, time.UTC)
+	announce := now.Add(CacheTTL / 2)
+	svc, repo := newTestService(Election{ID: 1, Status: election.ElectionStatusVotingClosed, AnnouncementAt: &announce}, &now)
+	ctx := context.Background()
+
+	if _, err := svc.Get(ctx, 1, KindResults); !errors.Is(err, ErrNotPublished) {
+		t.Fatalf("before announcement: err = %v", err)
+	}
+	if _, err := svc.Get(ctx, 1, KindResults); !errors.Is(err, ErrNotPublished) {
+		t.Fatalf("cached refusal: err = %v", err)
+	}
+	if repo.loads != 1 {
+		t.Fatalf("loads = %d, want 1 (refusal cached)", repo.loads)
+	}
+
+	// the cached election is enough to notice the announcement time passing
+	now = announce
+	snap, err := svc.Get(ctx, 1, KindResults)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if repo.loads != 1 || !strings.Contains(string(snap.Payload), `"percent":66.67`) {
+		t.Fatalf("loads = %d, payload = %s", repo.loads, snap.Payload)
+	}
+
+	computed := repo.computed
+	if _, err := svc.Get(ctx, 1, KindResults); err != nil || repo.computed != computed {
+		t.Fatalf("second read recomputed: err = %v, computed = %d", err, repo.computed)
+	}
+
+	if _, err := svc.Get(ctx, 2, KindResults); !errors.Is(err, ErrElectionNotFound) {
+		t.Fatalf("unknown election: err = %v", err)
+	}
+}
+
+func TestTurnoutOpensWithVoting(t *testing.T) {
+	now := time.Date(2026, 11, 19, 8, 0, 0, 0, time.UTC)
+	svc, repo := newTestService(Election{ID: 1, Status: election.ElectionStatusCampaign}, &now)
+	ctx := context.Background()
+
+	if _, err := svc.Get(ctx, 1, KindTurnout); !errors.Is(err, ErrTurnoutNotOpen) {
+		t.Fatalf("campaign: err = %v", err)
+	}
+
+	repo.election.Status = election.ElectionStatusVotingOpen
+	now = now.Add(CacheTTL)
+	snap, err := svc.Get(ctx, 1, KindTurnout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(snap.Payload), `"total_voted":3`) || !strings.Contains(string(snap.Payload), `"turnout_percent":50`) {
+		t.Fatalf("payload = %s", snap.Payload)
+	}
+	if _, err := svc.Get(ctx, 1, KindResults); !errors.Is(err, ErrNotPublished) {
+		t.Fatalf("results while voting: err = %v", err)
+	}
+}
+
+func TestDue(t *testing.T) {
+	updated := time.Date(2026, 11, 19, 17, 0, 0, 0, time.UTC)
+	before, after := updated.Add(-time.Minute), updated.Add(time.Minute)
+
+	tests := []struct {
+		name string
+		e    Election
+		want string
+	}{
+		{"campaign", Election{Status: election.ElectionStatusCampaign, UpdatedAt: updated}, ""},
+		{"voting open", Election{Status: election.ElectionStatusVotingOpen, UpdatedAt: updated, TurnoutAt: &after}, "TURNOUT"},
+		{"just closed", Election{Status: election.ElectionStatusVotingClosed, UpdatedAt: updated, TurnoutAt: &before}, "TURNOUT,RESULTS"},
+		{"closed and current", Election{Status: election.ElectionStatusClosed, UpdatedAt: updated, TurnoutAt: &after, ResultsAt: &after}, ""},
+		{"edited after results", Election{Status: election.ElectionStatusRecap, UpdatedAt: updated, TurnoutAt: &after, ResultsAt: &before}, "RESULTS"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, k := range due(tt.e, false) {
+				got = append(got, string(k))
+			}
+			if strings.Join(got, ",") != tt.want {
+				t.Fatalf("due = %v, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandlerConditionalGet(t *testing.T) {
+	now := time.Date(2026, 11, 20, 10, 0, 0, 0, time.UTC)
+	announce := now.Add(-time.Hour)
+	svc, _ := newTestService(Election{ID: 1, Status: election.ElectionStatusRecap, AnnouncementAt: &announce}, &now)
+	h := NewHandler(svc)
+
+	router := chi.NewRouter()
+	router.Get("/elections/{electionID}/results", h.Results)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/elections/1/results", nil))
+	etag := rec.Header().Get("ETag")
+	if rec.Code != http.StatusOK || etag == "" || !strings.HasPrefix(rec.Body.String(), `{"data":{"election_id":1`) {
+		t.Fatalf("code = %d, etag = %q, body = %s", rec.Code, etag, rec.Body.String())
+	}
+	if rec.Header().Get("Last-Modified") == "" || !strings.HasPrefix(rec.Header().Get("Cache-Control"), "public") {
+		t.Fatalf("headers = %v", rec.Header())
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/elections/1/results", nil)
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotModified || rec.Body.Len() != 0 {
+		t.Fatalf("conditional: code = %d, body = %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/elections/2/results", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("unknown election: code = %d", rec.Code)
+	}
+}
@@ -0,0 +1,314 @@
+package results
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"sync"
+	"time"
+
+	"pemira-api/internal/election"
+)
+
+// CacheTTL is how long an instance serves a snapshot (or a refusal) from
+// memory before reading it again; it is also the public max-age
+const CacheTTL = 10 * time.Second
+
+type cacheKey struct {
+	electionID int64
+	kind       Kind
+}
+
+type cached struct {
+	election Election
+	// snapshot is nil while the kind is not available
+	snapshot *Snapshot
+	loadedAt time.Time
+}
+
+type Service struct {
+	repo Repository
+	now  func() time.Time
+
+	mu    sync.Mutex
+	cache map[cacheKey]cached
+
+	// genMu keeps the job, admins and cache misses from computing the same
+	// snapshot side by side
+	genMu sync.Mutex
+}
+
+func NewService(repo Repository) *Service {
+	return &Service{
+		repo:  repo,
+		now:   time.Now,
+		cache: make(map[cacheKey]cached),
+	}
+}
+
+// Get returns the public snapshot of the election. Results are refused
+// until the announcement time, turnout until voting opens. A snapshot that
+// is available but was never generated is generated on the spot.
+func (s *Service) Get(ctx context.Context, electionID int64, kind Kind) (*Snapshot, error) {
+	key := cacheKey{electionID, kind}
+	now := s.now()
+
+	s.mu.Lock()
+	c, ok := s.cache[key]
+	s.mu.Unlock()
+	if !ok || now.Sub(c.loadedAt) >= CacheTTL {
+		e, err := s.repo.GetElection(ctx, electionID)
+		if err != nil {
+			return nil, err
+		}
+		c = cached{election: *e, loadedAt: now}
+	}
+
+	if err := availability(c.election, kind, now); err != nil {
+		s.store(key, c)
+		return nil, err
+	}
+	if c.snapshot != nil {
+		return c.snapshot, nil
+	}
+
+	snap, err := s.repo.GetSnapshot(ctx, electionID, kind)
+	if errors.Is(err, ErrSnapshotNotFound) {
+		snap, err = s.generateMissing(ctx, c.election, kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+	c.snapshot = snap
+	s.store(key, c)
+	return snap, nil
+}
+
+func availability(e Election, kind Kind, now time.Time) error {
+	switch kind {
+	case KindResults:
+		if !e.ResultsAvailable(now) {
+			return ErrNotPublished
+		}
+	case KindTurnout:
+		if !e.TurnoutAvailable() {
+			return ErrTurnoutNotOpen
+		}
+	}
+	return nil
+}
+
+func (s *Service) store(key cacheKey, c cached) {
+	s.mu.Lock()
+	s.cache[key] = c
+	s.mu.Unlock()
+}
+
+// generateMissing generates a snapshot unless a concurrent caller stored
+// one while this one waited
+func (s *Service) generateMissing(ctx context.Context, e Election, kind Kind) (*Snapshot, error) {
+	s.genMu.Lock()
+	defer s.genMu.Unlock()
+
+	snap, err := s.repo.GetSnapshot(ctx, e.ID, kind)
+	if !errors.Is(err, ErrSnapshotNotFound) {
+		return snap, err
+	}
+	return s.generate(ctx, e, kind)
+}
+
+// generate computes and stores a snapshot; genMu must be held
+func (s *Service) generate(ctx context.Context, e Election, kind Kind) (*Snapshot, error) {
+	now := s.now().UTC().Truncate(time.Second)
+
+	var data any
+	switch kind {
+	case KindResults:
+		r, err := s.computeResults(ctx, e, now)
+		if err != nil {
+			return nil, err
+		}
+		data = r
+	case KindTurnout:
+		t, err := s.computeTurnout(ctx, e, now)
+		if err != nil {
+			return nil, err
+		}
+		data = t
+	default:
+		return nil, fmt.Errorf("unknown snapshot kind %q", kind)
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(payload)
+	snap := &Snapshot{
+		ElectionID:  e.ID,
+		Kind:        kind,
+		Payload:     payload,
+		ETag:        `"` + hex.EncodeToString(sum[:16]) + `"`,
+		GeneratedAt: now,
+	}
+	if err := s.repo.SaveSnapshot(ctx, snap); err != nil {
+		return nil, err
+	}
+
+	// this instance serves the new snapshot at once, others after CacheTTL
+	s.mu.Lock()
+	delete(s.cache, cacheKey{e.ID, kind})
+	s.mu.Unlock()
+	return snap, nil
+}
+
+func (s *Service) computeTurnout(ctx context.Context, e Election, now time.Time) (*Turnout, error) {
+	faculties, err := s.repo.FacultyTurnout(ctx, e.ID)
+	if err != nil {
+		return nil, fmt.Errorf("faculty turnout: %w", err)
+	}
+	t := &Turnout{
+		ElectionID:   e.ID,
+		ElectionName: e.Name,
+		Status:       e.Status,
+		Faculties:    faculties,
+		GeneratedAt:  now,
+	}
+	for i := range faculties {
+		f := &faculties[i]
+		f.TurnoutPercent = percent(f.TotalVoted, f.TotalEligible)
+		t.TotalEligible += f.TotalEligible
+		t.TotalVoted += f.TotalVoted
+	}
+	t.TurnoutPercent = percent(t.TotalVoted, t.TotalEligible)
+	return t, nil
+}
+
+func (s *Service) computeResults(ctx context.Context, e Election, now time.Time) (*Results, error) {
+	candidates, err := s.repo.CandidateVotes(ctx, e.ID)
+	if err != nil {
+		return nil, fmt.Errorf("candidate votes: %w", err)
+	}
+	turnout, err := s.computeTurnout(ctx, e, now)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Results{
+		ElectionID:     e.ID,
+		ElectionName:   e.Name,
+		AnnouncementAt: e.AnnouncementAt,
+		TotalEligible:  turnout.TotalEligible,
+		TurnoutPercent: turnout.TurnoutPercent,
+		Candidates:     candidates,
+		Faculties:      turnout.Faculties,
+		GeneratedAt:    now,
+	}
+	for _, c := range candidates {
+		r.TotalVotes += c.Votes
+	}
+	for i := range candidates {
+		candidates[i].Percent = percent(candidates[i].Votes, r.TotalVotes)
+	}
+	return r, nil
+}
+
+// percent is part/whole as a percentage rounded to two decimals
+func percent(part, whole int64) float64 {
+	if whole == 0 {
+		return 0
+	}
+	return math.Round(float64(part)/float64(whole)*10000) / 100
+}
+
+// Regenerate recomputes every snapshot the election has so far, e.g. after
+// a correction. Results are computed once voting is closed, ahead of the
+// announcement, and stay unpublished until then.
+func (s *Service) Regenerate(ctx context.Context, electionID int64) ([]SnapshotInfo, error) {
+	e, err := s.repo.GetElection(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+	kinds := due(*e, true)
+	if len(kinds) == 0 {
+		return nil, ErrNothingToGenerate
+	}
+
+	s.genMu.Lock()
+	defer s.genMu.Unlock()
+
+	out := make([]SnapshotInfo, 0, len(kinds))
+	for _, kind := range kinds {
+		snap, err := s.generate(ctx, *e, kind)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, SnapshotInfo{Kind: kind, ETag: snap.ETag, GeneratedAt: snap.GeneratedAt})
+	}
+	return out, nil
+}
+
+// due lists the snapshots of e to generate. Turnout follows voting while it
+// is open; afterwards both are regenerated only when the election changed
+// since (closing it bumps updated_at).
+func due(e Election, force bool) []Kind {
+	stale := func(at *time.Time) bool {
+		return force || at == nil || at.Before(e.UpdatedAt)
+	}
+
+	var kinds []Kind
+	if e.TurnoutAvailable() && (e.Status == election.ElectionStatusVotingOpen || stale(e.TurnoutAt)) {
+		kinds = append(kinds, KindTurnout)
+	}
+	if votingClosed(e.Status) && stale(e.ResultsAt) {
+		kinds = append(kinds, KindResults)
+	}
+	return kinds
+}
+
+// Refresh generates every due snapshot.
+func (s *Service) Refresh(ctx context.Context) error {
+	elections, err := s.repo.ListElections(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.genMu.Lock()
+	defer s.genMu.Unlock()
+
+	for _, e := range elections {
+		for _, kind := range due(e, false) {
+			if _, err := s.generate(ctx, e, kind); err != nil {
+				slog.Error("results snapshot failed", "election_id", e.ID, "kind", kind, "err", err)
+			}
+		}
+	}
+	return nil
+}
+
+// Run refreshes snapshots every interval until ctx is done. A non-positive
+// interval disables the job; snapshots are then generated on first request
+// or by an admin.
+func (s *Service) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Refresh(ctx); err != nil {
+				slog.Error("results snapshot job failed", "err", err)
+			}
+		}
+	}
+}
@@ -2,8 +2,10 @@ package settings
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
+	"pemira-api/internal/errcatalog"
 	"pemira-api/internal/http/response"
 	"pemira-api/internal/shared/ctxkeys"
 )
@@ -22,7 +24,7 @@ func (h *Handler) GetSettings(w http.ResponseWriter, r *http.Request) {
 	
 	settings, err := h.svc.GetAll(ctx)
 	if err != nil {
-		response.InternalServerError(w, "INTERNAL_ERROR", "Gagal mengambil settings.")
+		response.Catalog(w, settingsFetchFailed)
 		return
 	}
 	
@@ -35,7 +37,7 @@ func (h *Handler) GetActiveElection(w http.ResponseWriter, r *http.Request) {
 	
 	electionID, err := h.svc.GetActiveElectionID(ctx)
 	if err != nil {
-		response.InternalServerError(w, "INTERNAL_ERROR", "Gagal mengambil active election.")
+		response.Catalog(w, activeElectionFetchFailed)
 		return
 	}
 	
@@ -51,27 +53,25 @@ func (h *Handler) UpdateActiveElection(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from context
 	userID, ok := ctxkeys.GetUserID(ctx)
 	if !ok {
-		response.Unauthorized(w, "UNAUTHORIZED", "Unauthorized.")
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.Unauthenticated)
 		return
 	}
 	
-	var req struct {
-		ElectionID int `json:"election_id"`
-	}
+	var req UpdateActiveElectionRequest
 	
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.BadRequest(w, "VALIDATION_ERROR", "Body tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
 		return
 	}
 	
 	if req.ElectionID <= 0 {
-		response.UnprocessableEntity(w, "VALIDATION_ERROR", "election_id harus lebih dari 0.")
+		response.Catalog(w, activeElectionInvalid)
 		return
 	}
 	
 	err := h.svc.UpdateActiveElectionID(ctx, req.ElectionID, userID)
 	if err != nil {
-		response.InternalServerError(w, "INTERNAL_ERROR", "Gagal mengupdate active election.")
+		response.Catalog(w, activeElectionUpdateFailed)
 		return
 	}
 	
@@ -88,7 +88,7 @@ func (h *Handler) GetDefaultElection(w http.ResponseWriter, r *http.Request) {
 	
 	electionID, err := h.svc.repo.GetDefaultElectionID(ctx)
 	if err != nil {
-		response.InternalServerError(w, "INTERNAL_ERROR", "Gagal mengambil default election.")
+		response.Catalog(w, defaultElectionFetchFailed)
 		return
 	}
 	
@@ -96,3 +96,49 @@ func (h *Handler) GetDefaultElection(w http.ResponseWriter, r *http.Request) {
 		"default_election_id": electionID,
 	})
 }
+
+// GET /api/v1/admin/settings/maintenance
+func (h *Handler) GetMaintenance(w http.ResponseWriter, r *http.Request) {
+	m, err := h.svc.GetMaintenance(r.Context())
+	if err != nil {
+		response.Catalog(w, maintenanceFetchFailed)
+		return
+	}
+	response.Success(w, http.StatusOK, m)
+}
+
+// PUT /api/v1/admin/settings/maintenance
+func (h *Handler) UpdateMaintenance(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := ctxkeys.GetUserID(ctx)
+	if !ok {
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.Unauthenticated)
+		return
+	}
+
+	var req UpdateMaintenanceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
+		return
+	}
+
+	m, err := h.svc.UpdateMaintenance(ctx, req, userID)
+	if errors.Is(err, ErrInvalidMaintenance) {
+		response.Catalog(w, maintenanceMessageTooLong)
+		return
+	}
+	if err != nil {
+		response.Catalog(w, maintenanceUpdateFailed)
+		return
+	}
+	response.Success(w, http.StatusOK, m)
+}
+
+// GET /api/v1/maintenance (Public)
+func (h *Handler) PublicMaintenance(w http.ResponseWriter, r *http.Request) {
+	m := h.svc.MaintenanceState(r.Context())
+	response.Success(w, http.StatusOK, map[string]interface{}{
+		"enabled": m.Enabled,
+		"message": m.Message,
+	})
+}
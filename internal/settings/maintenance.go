@@ -0,0 +1,115 @@
+package settings
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	KeyMaintenanceMode    = "maintenance_mode"
+	KeyMaintenanceMessage = "maintenance_message"
+
+	// DefaultMaintenanceMessage is shown when the admin leaves the message empty
+	DefaultMaintenanceMessage = "Sistem sedang dalam pemeliharaan. Untuk sementara data hanya dapat dilihat."
+
+	maxMaintenanceMessage = 500
+
+	// maintenanceCacheTTL bounds how long another instance keeps serving
+	// writes after the mode is switched on
+	maintenanceCacheTTL = 5 * time.Second
+	// maintenanceLoadTimeout keeps a slow database from stalling every
+	// request behind the cache refresh
+	maintenanceLoadTimeout = 2 * time.Second
+)
+
+var ErrInvalidMaintenance = errors.New("pesan maintenance maksimal 500 karakter")
+
+// Maintenance is the read-only maintenance mode. While it is enabled the API
+// rejects mutating requests and keeps serving reads.
+type Maintenance struct {
+	Enabled   bool       `json:"enabled"`
+	Message   string     `json:"message"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	UpdatedBy *int64     `json:"updated_by,omitempty"`
+}
+
+type UpdateMaintenanceRequest struct {
+	Enabled bool   `json:"enabled"`
+	Message string `json:"message"`
+}
+
+// GetMaintenance reads the maintenance mode from app_settings
+func (s *Service) GetMaintenance(ctx context.Context) (Maintenance, error) {
+	m := Maintenance{Message: DefaultMaintenanceMessage}
+
+	mode, err := s.repo.Get(ctx, KeyMaintenanceMode)
+	if err != nil {
+		return m, err
+	}
+	if mode != nil {
+		m.Enabled, _ = strconv.ParseBool(mode.Value)
+		m.UpdatedAt = &mode.UpdatedAt
+		m.UpdatedBy = mode.UpdatedBy
+	}
+
+	msg, err := s.repo.Get(ctx, KeyMaintenanceMessage)
+	if err != nil {
+		return m, err
+	}
+	if msg != nil && strings.TrimSpace(msg.Value) != "" {
+		m.Message = msg.Value
+	}
+	return m, nil
+}
+
+// UpdateMaintenance switches the maintenance mode on or off. The change is
+// seen at once by this instance and within a few seconds by the others.
+func (s *Service) UpdateMaintenance(ctx context.Context, req UpdateMaintenanceRequest, updatedBy int64) (Maintenance, error) {
+	message := strings.TrimSpace(req.Message)
+	if utf8.RuneCountInString(message) > maxMaintenanceMessage {
+		return Maintenance{}, ErrInvalidMaintenance
+	}
+
+	if err := s.repo.Upsert(ctx, KeyMaintenanceMessage, message, "Pesan yang ditampilkan selama mode maintenance", updatedBy); err != nil {
+		return Maintenance{}, err
+	}
+	if err := s.repo.Upsert(ctx, KeyMaintenanceMode, strconv.FormatBool(req.Enabled), "Mode maintenance read-only (true/false)", updatedBy); err != nil {
+		return Maintenance{}, err
+	}
+	slog.InfoContext(ctx, "maintenance mode updated", "enabled", req.Enabled, "updated_by", updatedBy)
+
+	m, err := s.GetMaintenance(ctx)
+	if err != nil {
+		return Maintenance{}, err
+	}
+	s.mu.Lock()
+	s.maintenance, s.maintenanceAt = m, time.Now()
+	s.mu.Unlock()
+	return m, nil
+}
+
+// MaintenanceState returns the cached maintenance mode for the request path.
+// When app_settings cannot be read the last known mode is kept.
+func (s *Service) MaintenanceState(ctx context.Context) Maintenance {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.maintenanceAt.IsZero() && time.Since(s.maintenanceAt) < maintenanceCacheTTL {
+		return s.maintenance
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, maintenanceLoadTimeout)
+	defer cancel()
+	m, err := s.GetMaintenance(ctx)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to load maintenance mode", "error", err)
+		s.maintenanceAt = time.Now()
+		return s.maintenance
+	}
+	s.maintenance, s.maintenanceAt = m, time.Now()
+	return m
+}
@@ -0,0 +1,20 @@
+package settings
+
+import (
+	"net/http"
+
+	"pemira-api/internal/errcatalog"
+)
+
+// Messages the settings handlers answer with, each under its own code in the
+// error catalog
+var (
+	settingsFetchFailed        = errcatalog.Define("SETTINGS_FETCH_FAILED", http.StatusInternalServerError, "Gagal mengambil settings.", "Failed to fetch the settings.")
+	activeElectionFetchFailed  = errcatalog.Define("ACTIVE_ELECTION_FETCH_FAILED", http.StatusInternalServerError, "Gagal mengambil active election.", "Failed to fetch the active election.")
+	activeElectionInvalid      = errcatalog.Define("ACTIVE_ELECTION_INVALID", http.StatusUnprocessableEntity, "election_id harus lebih dari 0.", "election_id must be greater than 0.")
+	activeElectionUpdateFailed = errcatalog.Define("ACTIVE_ELECTION_UPDATE_FAILED", http.StatusInternalServerError, "Gagal mengupdate active election.", "Failed to update the active election.")
+	defaultElectionFetchFailed = errcatalog.Define("DEFAULT_ELECTION_FETCH_FAILED", http.StatusInternalServerError, "Gagal mengambil default election.", "Failed to fetch the default election.")
+	maintenanceFetchFailed     = errcatalog.Define("MAINTENANCE_FETCH_FAILED", http.StatusInternalServerError, "Gagal mengambil mode maintenance.", "Failed to fetch the maintenance mode.")
+	maintenanceMessageTooLong  = errcatalog.Define("MAINTENANCE_MESSAGE_TOO_LONG", http.StatusUnprocessableEntity, "Pesan maintenance maksimal 500 karakter.", "The maintenance message can be at most 500 characters.")
+	maintenanceUpdateFailed    = errcatalog.Define("MAINTENANCE_UPDATE_FAILED", http.StatusInternalServerError, "Gagal mengupdate mode maintenance.", "Failed to update the maintenance mode.")
+)
@@ -14,6 +14,10 @@ type UpdateSettingRequest struct {
 	Value string `json:"value"`
 }
 
+type UpdateActiveElectionRequest struct {
+	ElectionID int `json:"election_id"`
+}
+
 type SettingsResponse struct {
 	ActiveElectionID  int `json:"active_election_id"`
 	DefaultElectionID int `json:"default_election_id"`
@@ -12,6 +12,7 @@ type Repository interface {
 	Get(ctx context.Context, key string) (*AppSetting, error)
 	GetAll(ctx context.Context) ([]AppSetting, error)
 	Update(ctx context.Context, key string, value string, updatedBy int64) error
+	Upsert(ctx context.Context, key, value, description string, updatedBy int64) error
 	GetActiveElectionID(ctx context.Context) (int, error)
 	GetDefaultElectionID(ctx context.Context) (int, error)
 }
@@ -88,6 +89,17 @@ func (r *repository) Update(ctx context.Context, key string, value string, updat
 	return err
 }
 
+// Upsert saves a setting, creating it when the row does not exist yet
+func (r *repository) Upsert(ctx context.Context, key, value, description string, updatedBy int64) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO app_settings (key, value, description, updated_at, updated_by)
+		VALUES ($1, $2, $3, NOW(), $4)
+		ON CONFLICT (key) DO UPDATE
+		SET value = EXCLUDED.value, updated_at = NOW(), updated_by = EXCLUDED.updated_by
+	`, key, value, description, updatedBy)
+	return err
+}
+
 func (r *repository) GetActiveElectionID(ctx context.Context) (int, error) {
 	setting, err := r.Get(ctx, "active_election_id")
 	if err != nil {
@@ -4,10 +4,17 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"sync"
+	"time"
 )
 
 type Service struct {
 	repo Repository
+
+	// maintenance caches the maintenance mode for the request middleware
+	mu            sync.Mutex
+	maintenance   Maintenance
+	maintenanceAt time.Time
 }
 
 func NewService(repo Repository) *Service {
@@ -3,12 +3,16 @@ package constants
 type Role string
 
 const (
-	RoleStudent      Role = "STUDENT"
-	RoleLecturer     Role = "LECTURER"
-	RoleStaff        Role = "STAFF"
-	RoleAdmin        Role = "ADMIN"
-	RoleTPSOperator  Role = "TPS_OPERATOR"
-	RoleSuperAdmin   Role = "SUPER_ADMIN"
+	RoleStudent       Role = "STUDENT"
+	RoleLecturer      Role = "LECTURER"
+	RoleStaff         Role = "STAFF"
+	RoleAdmin         Role = "ADMIN"
+	RoleTPSOperator   Role = "TPS_OPERATOR"
+	RoleSuperAdmin    Role = "SUPER_ADMIN"
+	RolePanitia       Role = "PANITIA"
+	RoleKetuaTPS      Role = "KETUA_TPS"
+	RoleOperatorPanel Role = "OPERATOR_PANEL"
+	RoleViewer        Role = "VIEWER"
 )
 
 type ElectionPhase string
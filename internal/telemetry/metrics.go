@@ -0,0 +1,70 @@
+// Package telemetry holds the Prometheus metrics, OpenTelemetry tracing and
+// request-scoped logging shared by the HTTP layer and the domain services.
+package telemetry
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "pemira"
+
+var (
+	votesCast = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "votes_cast_total",
+		Help:      "Votes cast, by channel (ONLINE or TPS).",
+	}, []string{"channel"})
+
+	checkins = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tps_checkins_total",
+		Help:      "TPS check-in transitions, by the status entered.",
+	}, []string{"status"})
+
+	logins = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "auth_logins_total",
+		Help:      "Login attempts, by method and result.",
+	}, []string{"method", "result"})
+
+	qrScanFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "qr_scan_failures_total",
+		Help:      "Rejected TPS QR scans, by error code.",
+	}, []string{"code"})
+
+	httpDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency, by method, route pattern and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+)
+
+// Login results
+const (
+	LoginSuccess     = "success"
+	LoginFailure     = "failure"
+	LoginMFARequired = "mfa_required"
+)
+
+// VoteCast counts a committed vote.
+func VoteCast(channel string) {
+	votesCast.WithLabelValues(channel).Inc()
+}
+
+// CheckinRecorded counts a check-in entering status.
+func CheckinRecorded(status string) {
+	checkins.WithLabelValues(status).Inc()
+}
+
+// LoginAttempt counts a login through method (password, totp, sso).
+func LoginAttempt(method, result string) {
+	logins.WithLabelValues(method, result).Inc()
+}
+
+// QRScanFailed counts a QR scan rejected with the API error code.
+func QRScanFailed(code string) {
+	qrScanFailures.WithLabelValues(code).Inc()
+}
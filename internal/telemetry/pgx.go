@@ -0,0 +1,65 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// maxStatementLength caps the SQL recorded on a span.
+const maxStatementLength = 2048
+
+// QueryTracer is a pgx.QueryTracer that opens a span per query. Query
+// arguments are never recorded, they can hold voter data.
+type QueryTracer struct{}
+
+func (QueryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	if !trace.SpanFromContext(ctx).SpanContext().IsValid() {
+		// Queries outside a request (workers, startup) are not traced.
+		return ctx
+	}
+	sql := strings.TrimSpace(data.SQL)
+	ctx, _ = tracer().Start(ctx, "db "+statementVerb(sql),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.statement", truncate(sql, maxStatementLength)),
+		),
+	)
+	return ctx
+}
+
+func (QueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	span := trace.SpanFromContext(ctx)
+	if data.Err != nil && !errors.Is(data.Err, pgx.ErrNoRows) {
+		span.RecordError(data.Err)
+		span.SetStatus(codes.Error, data.Err.Error())
+	} else {
+		span.SetAttributes(attribute.Int64("db.rows_affected", data.CommandTag.RowsAffected()))
+	}
+	span.End()
+}
+
+// statementVerb returns the leading keyword of sql, e.g. SELECT or WITH.
+func statementVerb(sql string) string {
+	verb, _, _ := strings.Cut(sql, " ")
+	if i := strings.IndexAny(verb, "\n\t("); i >= 0 {
+		verb = verb[:i]
+	}
+	if verb == "" {
+		return "QUERY"
+	}
+	return strings.ToUpper(verb)
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
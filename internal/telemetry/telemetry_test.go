@@ -0,0 +1,86 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestMiddleware_RecordsRoutePattern(t *testing.T) {
+	r := chi.NewRouter()
+	r.Use(middleware.RequestID)
+	r.Use(Middleware)
+	r.Get("/tps/{tpsID}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	for _, path := range []string{"/tps/1", "/tps/2", "/nope"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(httpDuration)
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]uint64{}
+	for _, m := range families[0].GetMetric() {
+		labels := map[string]string{}
+		for _, l := range m.GetLabel() {
+			labels[l.GetName()] = l.GetValue()
+		}
+		got[labels["route"]+" "+labels["status"]] = m.GetHistogram().GetSampleCount()
+	}
+	if len(got) != 2 || got["/tps/{tpsID} 418"] != 2 || got["unmatched 404"] != 1 {
+		t.Fatalf("series = %v", got)
+	}
+}
+
+func TestLogHandler_AddsRequestID(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewLogHandler(slog.NewJSONHandler(&buf, nil)))
+
+	ctx := context.WithValue(context.Background(), middleware.RequestIDKey, "host/abc-000001")
+	logger.InfoContext(ctx, "hello")
+	logger.Info("no context")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	var first, second map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &second); err != nil {
+		t.Fatal(err)
+	}
+	if first["request_id"] != "host/abc-000001" {
+		t.Fatalf("record = %v", first)
+	}
+	if _, ok := second["request_id"]; ok {
+		t.Fatalf("record = %v", second)
+	}
+}
+
+func TestStatementVerb(t *testing.T) {
+	tests := map[string]string{
+		"SELECT 1":                   "SELECT",
+		"insert into t values ($1)":  "INSERT",
+		"WITH\n x AS (SELECT 1) ...": "WITH",
+		"":                           "QUERY",
+		"UPDATE(x)":                  "UPDATE",
+	}
+	for sql, want := range tests {
+		if got := statementVerb(sql); got != want {
+			t.Errorf("statementVerb(%q) = %q, want %q", sql, got, want)
+		}
+	}
+}
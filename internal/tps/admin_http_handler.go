@@ -7,6 +7,7 @@ import (
 	"strconv"
 
 	"github.com/go-chi/chi/v5"
+	"pemira-api/internal/errcatalog"
 	"pemira-api/internal/http/response"
 )
 
@@ -36,7 +37,7 @@ func (h *AdminHandler) List(w http.ResponseWriter, r *http.Request) {
 
 	items, err := h.svc.List(ctx, electionID)
 	if err != nil {
-		response.InternalServerError(w, "INTERNAL_ERROR", "Gagal mengambil daftar TPS.")
+		response.Catalog(w, tpsListFailed)
 		return
 	}
 
@@ -51,18 +52,18 @@ func (h *AdminHandler) Create(w http.ResponseWriter, r *http.Request) {
 
 	var req TPSCreateRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.BadRequest(w, "VALIDATION_ERROR", "Body tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
 		return
 	}
 
 	if req.Code == "" || req.Name == "" || req.Location == "" {
-		response.UnprocessableEntity(w, "VALIDATION_ERROR", "code, name, dan location wajib diisi.")
+		response.UnprocessableEntity(w, "VALIDATION_ERROR", errcatalog.Required("code", "name", "location"))
 		return
 	}
 
 	dto, err := h.svc.Create(ctx, req)
 	if err != nil {
-		response.InternalServerError(w, "INTERNAL_ERROR", "Gagal membuat TPS.")
+		response.Catalog(w, tpsCreateFailed)
 		return
 	}
 
@@ -75,17 +76,17 @@ func (h *AdminHandler) Get(w http.ResponseWriter, r *http.Request) {
 
 	id, err := parseIDParam(r, "tpsID")
 	if err != nil || id <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "tpsID tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("tpsID"))
 		return
 	}
 
 	dto, err := h.svc.Get(ctx, id)
 	if err != nil {
 		if errors.Is(err, ErrTPSNotFound) {
-			response.NotFound(w, "TPS_NOT_FOUND", "TPS tidak ditemukan.")
+			response.NotFound(w, "TPS_NOT_FOUND", errcatalog.TPSNotFound)
 			return
 		}
-		response.InternalServerError(w, "INTERNAL_ERROR", "Gagal mengambil detail TPS.")
+		response.Catalog(w, tpsFetchFailed)
 		return
 	}
 
@@ -98,23 +99,23 @@ func (h *AdminHandler) Update(w http.ResponseWriter, r *http.Request) {
 
 	id, err := parseIDParam(r, "tpsID")
 	if err != nil || id <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "tpsID tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("tpsID"))
 		return
 	}
 
 	var req TPSUpdateRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.BadRequest(w, "VALIDATION_ERROR", "Body tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
 		return
 	}
 
 	dto, err := h.svc.Update(ctx, id, req)
 	if err != nil {
 		if errors.Is(err, ErrTPSNotFound) {
-			response.NotFound(w, "TPS_NOT_FOUND", "TPS tidak ditemukan.")
+			response.NotFound(w, "TPS_NOT_FOUND", errcatalog.TPSNotFound)
 			return
 		}
-		response.InternalServerError(w, "INTERNAL_ERROR", "Gagal mengubah TPS.")
+		response.Catalog(w, tpsUpdateFailed)
 		return
 	}
 
@@ -127,16 +128,16 @@ func (h *AdminHandler) Delete(w http.ResponseWriter, r *http.Request) {
 
 	id, err := parseIDParam(r, "tpsID")
 	if err != nil || id <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "tpsID tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("tpsID"))
 		return
 	}
 
 	if err := h.svc.Delete(ctx, id); err != nil {
 		if errors.Is(err, ErrTPSNotFound) {
-			response.NotFound(w, "TPS_NOT_FOUND", "TPS tidak ditemukan.")
+			response.NotFound(w, "TPS_NOT_FOUND", errcatalog.TPSNotFound)
 			return
 		}
-		response.InternalServerError(w, "INTERNAL_ERROR", "Gagal menghapus TPS.")
+		response.Catalog(w, tpsDeleteFailed)
 		return
 	}
 
@@ -149,13 +150,13 @@ func (h *AdminHandler) ListOperators(w http.ResponseWriter, r *http.Request) {
 
 	tpsID, err := parseIDParam(r, "tpsID")
 	if err != nil || tpsID <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "tpsID tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("tpsID"))
 		return
 	}
 
 	items, err := h.svc.ListOperators(ctx, tpsID)
 	if err != nil {
-		response.InternalServerError(w, "INTERNAL_ERROR", "Gagal mengambil operator TPS.")
+		response.Catalog(w, operatorsFetchFailed)
 		return
 	}
 
@@ -168,24 +169,24 @@ func (h *AdminHandler) CreateOperator(w http.ResponseWriter, r *http.Request) {
 
 	tpsID, err := parseIDParam(r, "tpsID")
 	if err != nil || tpsID <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "tpsID tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("tpsID"))
 		return
 	}
 
 	var req CreateOperatorRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.BadRequest(w, "VALIDATION_ERROR", "Body tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
 		return
 	}
 
 	if req.Username == "" || req.Password == "" {
-		response.UnprocessableEntity(w, "VALIDATION_ERROR", "username dan password wajib diisi.")
+		response.UnprocessableEntity(w, "VALIDATION_ERROR", errcatalog.Required("username", "password"))
 		return
 	}
 
 	op, err := h.svc.CreateOperator(ctx, tpsID, req.Username, req.Password, req.Name, req.Email)
 	if err != nil {
-		response.InternalServerError(w, "INTERNAL_ERROR", "Gagal membuat operator TPS.")
+		response.Catalog(w, operatorCreateFailed)
 		return
 	}
 
@@ -198,18 +199,18 @@ func (h *AdminHandler) RemoveOperator(w http.ResponseWriter, r *http.Request) {
 
 	tpsID, err := parseIDParam(r, "tpsID")
 	if err != nil || tpsID <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "tpsID tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("tpsID"))
 		return
 	}
 
 	userID, err := parseIDParam(r, "userID")
 	if err != nil || userID <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "userID tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("userID"))
 		return
 	}
 
 	if err := h.svc.RemoveOperator(ctx, tpsID, userID); err != nil {
-		response.InternalServerError(w, "INTERNAL_ERROR", "Gagal menghapus operator TPS.")
+		response.Catalog(w, operatorDeleteFailed)
 		return
 	}
 
@@ -221,17 +222,17 @@ func (h *AdminHandler) Allocation(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	tpsID, err := parseIDParam(r, "tpsID")
 	if err != nil || tpsID <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "tpsID tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("tpsID"))
 		return
 	}
 
 	data, err := h.svc.Allocation(ctx, tpsID)
 	if err != nil {
 		if errors.Is(err, ErrTPSNotFound) {
-			response.NotFound(w, "TPS_NOT_FOUND", "TPS tidak ditemukan.")
+			response.NotFound(w, "TPS_NOT_FOUND", errcatalog.TPSNotFound)
 			return
 		}
-		response.InternalServerError(w, "INTERNAL_ERROR", "Gagal mengambil alokasi TPS.")
+		response.Catalog(w, allocationFetchFailed)
 		return
 	}
 
@@ -243,17 +244,17 @@ func (h *AdminHandler) Activity(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	tpsID, err := parseIDParam(r, "tpsID")
 	if err != nil || tpsID <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "tpsID tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("tpsID"))
 		return
 	}
 
 	data, err := h.svc.Activity(ctx, tpsID)
 	if err != nil {
 		if errors.Is(err, ErrTPSNotFound) {
-			response.NotFound(w, "TPS_NOT_FOUND", "TPS tidak ditemukan.")
+			response.NotFound(w, "TPS_NOT_FOUND", errcatalog.TPSNotFound)
 			return
 		}
-		response.InternalServerError(w, "INTERNAL_ERROR", "Gagal mengambil aktivitas TPS.")
+		response.Catalog(w, activityFetchFailed)
 		return
 	}
 
@@ -266,13 +267,13 @@ func (h *AdminHandler) Monitor(w http.ResponseWriter, r *http.Request) {
 
 	electionID, err := parseIDParam(r, "electionID")
 	if err != nil || electionID <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "electionID tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("electionID"))
 		return
 	}
 
 	items, err := h.svc.Monitor(ctx, electionID)
 	if err != nil {
-		response.InternalServerError(w, "INTERNAL_ERROR", "Gagal mengambil monitoring TPS.")
+		response.Catalog(w, monitorFetchFailed)
 		return
 	}
 
@@ -285,17 +286,17 @@ func (h *AdminHandler) GetQRMetadata(w http.ResponseWriter, r *http.Request) {
 
 	tpsID, err := parseIDParam(r, "tpsID")
 	if err != nil {
-		response.BadRequest(w, "VALIDATION_ERROR", "ID TPS tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidTPSID)
 		return
 	}
 
 	metadata, err := h.svc.GetQRMetadata(ctx, tpsID)
 	if err != nil {
 		if errors.Is(err, ErrTPSNotFound) {
-			response.NotFound(w, "TPS_NOT_FOUND", "TPS tidak ditemukan.")
+			response.NotFound(w, "TPS_NOT_FOUND", errcatalog.TPSNotFound)
 			return
 		}
-		response.InternalServerError(w, "INTERNAL_ERROR", "Gagal mengambil metadata QR.")
+		response.Catalog(w, qrMetadataFailed)
 		return
 	}
 
@@ -308,17 +309,17 @@ func (h *AdminHandler) RotateQR(w http.ResponseWriter, r *http.Request) {
 
 	tpsID, err := parseIDParam(r, "tpsID")
 	if err != nil {
-		response.BadRequest(w, "VALIDATION_ERROR", "ID TPS tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidTPSID)
 		return
 	}
 
 	result, err := h.svc.RotateQR(ctx, tpsID)
 	if err != nil {
 		if errors.Is(err, ErrTPSNotFound) {
-			response.NotFound(w, "TPS_NOT_FOUND", "TPS tidak ditemukan.")
+			response.NotFound(w, "TPS_NOT_FOUND", errcatalog.TPSNotFound)
 			return
 		}
-		response.InternalServerError(w, "INTERNAL_ERROR", "Gagal rotate QR.")
+		response.Catalog(w, qrRotateFailed)
 		return
 	}
 
@@ -331,17 +332,17 @@ func (h *AdminHandler) GetQRForPrint(w http.ResponseWriter, r *http.Request) {
 
 	tpsID, err := parseIDParam(r, "tpsID")
 	if err != nil {
-		response.BadRequest(w, "VALIDATION_ERROR", "ID TPS tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidTPSID)
 		return
 	}
 
 	printData, err := h.svc.GetQRForPrint(ctx, tpsID)
 	if err != nil {
 		if errors.Is(err, ErrTPSNotFound) {
-			response.NotFound(w, "TPS_NOT_FOUND", "TPS tidak ditemukan.")
+			response.NotFound(w, "TPS_NOT_FOUND", errcatalog.TPSNotFound)
 			return
 		}
-		response.InternalServerError(w, "INTERNAL_ERROR", "Gagal mengambil data cetak QR.")
+		response.Catalog(w, qrPrintFailed)
 		return
 	}
 
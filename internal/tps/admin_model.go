@@ -3,47 +3,51 @@ package tps
 import "time"
 
 type TPSDTO struct {
-	ID          int64     `json:"id"`
-	Code        string    `json:"code"`
-	Name        string    `json:"name"`
-	Location    string    `json:"location"`
-	Capacity    int       `json:"capacity"`
-	IsActive    bool      `json:"is_active"`
-	OpenTime    *string   `json:"open_time,omitempty"`
-	CloseTime   *string   `json:"close_time,omitempty"`
-	PICName     *string   `json:"pic_name,omitempty"`
-	PICPhone    *string   `json:"pic_phone,omitempty"`
-	Notes       *string   `json:"notes,omitempty"`
-	HasActiveQR bool      `json:"has_active_qr"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID            int64     `json:"id"`
+	Code          string    `json:"code"`
+	Name          string    `json:"name"`
+	Location      string    `json:"location"`
+	Capacity      int       `json:"capacity"`
+	AreaFacultyID *int64    `json:"area_faculty_id"`
+	IsActive      bool      `json:"is_active"`
+	OpenTime      *string   `json:"open_time,omitempty"`
+	CloseTime     *string   `json:"close_time,omitempty"`
+	PICName       *string   `json:"pic_name,omitempty"`
+	PICPhone      *string   `json:"pic_phone,omitempty"`
+	Notes         *string   `json:"notes,omitempty"`
+	HasActiveQR   bool      `json:"has_active_qr"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
 }
 
 type TPSCreateRequest struct {
-	ElectionID *int64  `json:"election_id,omitempty"`
-	Code       string  `json:"code"`
-	Name       string  `json:"name"`
-	Location   string  `json:"location"`
-	Capacity   int     `json:"capacity"`
-	IsActive   *bool   `json:"is_active,omitempty"`
-	OpenTime   *string `json:"open_time,omitempty"`
-	CloseTime  *string `json:"close_time,omitempty"`
-	PICName    *string `json:"pic_name,omitempty"`
-	PICPhone   *string `json:"pic_phone,omitempty"`
-	Notes      *string `json:"notes,omitempty"`
+	ElectionID    *int64  `json:"election_id,omitempty"`
+	Code          string  `json:"code" validate:"required"`
+	Name          string  `json:"name" validate:"required"`
+	Location      string  `json:"location" validate:"required"`
+	Capacity      int     `json:"capacity" validate:"min=0"`
+	AreaFacultyID *int64  `json:"area_faculty_id,omitempty"`
+	IsActive      *bool   `json:"is_active,omitempty"`
+	OpenTime      *string `json:"open_time,omitempty"`
+	CloseTime     *string `json:"close_time,omitempty"`
+	PICName       *string `json:"pic_name,omitempty"`
+	PICPhone      *string `json:"pic_phone,omitempty"`
+	Notes         *string `json:"notes,omitempty"`
 }
 
 type TPSUpdateRequest struct {
-	Code      *string `json:"code,omitempty"`
-	Name      *string `json:"name,omitempty"`
-	Location  *string `json:"location,omitempty"`
-	Capacity  *int    `json:"capacity,omitempty"`
-	IsActive  *bool   `json:"is_active,omitempty"`
-	OpenTime  *string `json:"open_time,omitempty"`
-	CloseTime *string `json:"close_time,omitempty"`
-	PICName   *string `json:"pic_name,omitempty"`
-	PICPhone  *string `json:"pic_phone,omitempty"`
-	Notes     *string `json:"notes,omitempty"`
+	Code     *string `json:"code,omitempty"`
+	Name     *string `json:"name,omitempty"`
+	Location *string `json:"location,omitempty"`
+	Capacity *int    `json:"capacity,omitempty"`
+	// AreaFacultyID 0 clears the faculty
+	AreaFacultyID *int64  `json:"area_faculty_id,omitempty"`
+	IsActive      *bool   `json:"is_active,omitempty"`
+	OpenTime      *string `json:"open_time,omitempty"`
+	CloseTime     *string `json:"close_time,omitempty"`
+	PICName       *string `json:"pic_name,omitempty"`
+	PICPhone      *string `json:"pic_phone,omitempty"`
+	Notes         *string `json:"notes,omitempty"`
 }
 
 type TPSOperatorDTO struct {
@@ -66,11 +70,13 @@ type TPSMonitorDTO struct {
 	ApprovedCheckins int64      `json:"approved_checkins"`
 	TotalVotes       int64      `json:"total_votes"`
 	LastActivityAt   *time.Time `json:"last_activity_at,omitempty"`
+	// OpenIncidents lists the unresolved incidents of the TPS, most severe first
+	OpenIncidents []OpenIncidentSummary `json:"open_incidents"`
 }
 
 type CreateOperatorRequest struct {
-	Username string `json:"username"`
-	Password string `json:"password"`
+	Username string `json:"username" validate:"required"`
+	Password string `json:"password" validate:"required"`
 	Name     string `json:"name"`
 	Email    string `json:"email"`
 }
@@ -23,6 +23,15 @@ func NewPgAdminRepository(db *pgxpool.Pool) *PgAdminRepository {
 
 // ErrTPSNotFound is already defined in errors.go
 
+// areaFacultyID maps a requested faculty to the column value; 0 means no
+// faculty
+func areaFacultyID(id *int64) *int64 {
+	if id == nil || *id <= 0 {
+		return nil
+	}
+	return id
+}
+
 // List returns all TPS
 func (r *PgAdminRepository) List(ctx context.Context, electionID int64) ([]TPSDTO, error) {
 	const q = `
@@ -32,6 +41,7 @@ SELECT
     t.name,
     t.location,
     t.capacity_estimate,
+    t.area_faculty_id,
     CASE WHEN t.status = 'ACTIVE' THEN TRUE ELSE FALSE END as is_active,
     t.open_time::TEXT,
     t.close_time::TEXT,
@@ -60,6 +70,7 @@ ORDER BY t.code
 			&t.Name,
 			&t.Location,
 			&t.Capacity,
+			&t.AreaFacultyID,
 			&t.IsActive,
 			&t.OpenTime,
 			&t.CloseTime,
@@ -86,6 +97,7 @@ SELECT
     t.name,
     t.location,
     t.capacity_estimate,
+    t.area_faculty_id,
     CASE WHEN t.status = 'ACTIVE' THEN TRUE ELSE FALSE END as is_active,
     t.open_time::TEXT,
     t.close_time::TEXT,
@@ -105,6 +117,7 @@ WHERE t.id = $1
 		&t.Name,
 		&t.Location,
 		&t.Capacity,
+		&t.AreaFacultyID,
 		&t.IsActive,
 		&t.OpenTime,
 		&t.CloseTime,
@@ -151,6 +164,7 @@ INSERT INTO tps (
     name,
     location,
     capacity_estimate,
+    area_faculty_id,
     status,
     voting_date,
     open_time,
@@ -158,13 +172,14 @@ INSERT INTO tps (
     pic_name,
     pic_phone,
     notes
-) VALUES ($1, $2, $3, $4, $5, $6, CURRENT_DATE, $7::TIME, $8::TIME, $9, $10, $11)
+) VALUES ($1, $2, $3, $4, $5, $6, $7, CURRENT_DATE, $8::TIME, $9::TIME, $10, $11, $12)
 RETURNING 
     id,
     code,
     name,
     location,
     capacity_estimate,
+    area_faculty_id,
     CASE WHEN status = 'ACTIVE' THEN TRUE ELSE FALSE END as is_active,
     open_time::TEXT,
     close_time::TEXT,
@@ -182,6 +197,7 @@ RETURNING
 		req.Name,
 		req.Location,
 		req.Capacity,
+		areaFacultyID(req.AreaFacultyID),
 		status,
 		openTime,
 		closeTime,
@@ -194,6 +210,7 @@ RETURNING
 		&t.Name,
 		&t.Location,
 		&t.Capacity,
+		&t.AreaFacultyID,
 		&t.IsActive,
 		&t.OpenTime,
 		&t.CloseTime,
@@ -240,6 +257,12 @@ func (r *PgAdminRepository) Update(ctx context.Context, id int64, req TPSUpdateR
 		argPos++
 	}
 
+	if req.AreaFacultyID != nil {
+		updates = append(updates, fmt.Sprintf("area_faculty_id = $%d", argPos))
+		args = append(args, areaFacultyID(req.AreaFacultyID))
+		argPos++
+	}
+
 	if req.IsActive != nil {
 		status := "DRAFT"
 		if *req.IsActive {
@@ -294,6 +317,7 @@ RETURNING
     name,
     location,
     capacity_estimate,
+    area_faculty_id,
     CASE WHEN status = 'ACTIVE' THEN TRUE ELSE FALSE END as is_active,
     open_time::TEXT,
     close_time::TEXT,
@@ -314,6 +338,7 @@ RETURNING
 		&t.Name,
 		&t.Location,
 		&t.Capacity,
+		&t.AreaFacultyID,
 		&t.IsActive,
 		&t.OpenTime,
 		&t.CloseTime,
@@ -614,9 +639,40 @@ ORDER BY t.code
 		); err != nil {
 			return nil, err
 		}
+		m.OpenIncidents = []OpenIncidentSummary{}
 		items = append(items, m)
 	}
-	return items, rows.Err()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	byTPS := make(map[int64]int, len(items))
+	for i := range items {
+		byTPS[items[i].TPSID] = i
+	}
+	incidents, err := r.db.Query(ctx, `
+SELECT tps_id, id, category, severity, status, assigned_to, created_at
+FROM tps_incidents
+WHERE election_id = $1
+  AND status <> 'RESOLVED'
+ORDER BY array_position(ARRAY['CRITICAL', 'HIGH', 'MEDIUM', 'LOW'], severity), created_at
+`, electionID)
+	if err != nil {
+		return nil, err
+	}
+	defer incidents.Close()
+
+	for incidents.Next() {
+		var tpsID int64
+		var oi OpenIncidentSummary
+		if err := incidents.Scan(&tpsID, &oi.ID, &oi.Category, &oi.Severity, &oi.Status, &oi.AssignedTo, &oi.CreatedAt); err != nil {
+			return nil, err
+		}
+		if i, ok := byTPS[tpsID]; ok {
+			items[i].OpenIncidents = append(items[i].OpenIncidents, oi)
+		}
+	}
+	return items, incidents.Err()
 }
 
 // GetTPSQRMetadata returns QR metadata for TPS
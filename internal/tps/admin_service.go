@@ -3,13 +3,30 @@ package tps
 import "context"
 
 type AdminService struct {
-	repo AdminRepository
+	repo       AdminRepository
+	allocation *AllocationService
 }
 
 func NewAdminService(repo AdminRepository) *AdminService {
 	return &AdminService{repo: repo}
 }
 
+// SetAllocation rebalances voter assignments when a TPS is added, changed
+// or removed
+func (s *AdminService) SetAllocation(a *AllocationService) {
+	s.allocation = a
+}
+
+// tpsChanged rebalances the election a TPS belongs to, if allocation is on
+func (s *AdminService) tpsChanged(ctx context.Context, tpsID int64) {
+	if s.allocation == nil {
+		return
+	}
+	if electionID, err := s.allocation.ElectionOfTPS(ctx, tpsID); err == nil {
+		s.allocation.TPSChanged(ctx, electionID)
+	}
+}
+
 // CRUD TPS
 func (s *AdminService) List(ctx context.Context, electionID int64) ([]TPSDTO, error) {
 	return s.repo.List(ctx, electionID)
@@ -20,15 +37,36 @@ func (s *AdminService) Get(ctx context.Context, id int64) (*TPSDTO, error) {
 }
 
 func (s *AdminService) Create(ctx context.Context, req TPSCreateRequest) (*TPSDTO, error) {
-	return s.repo.Create(ctx, req)
+	t, err := s.repo.Create(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	s.tpsChanged(ctx, t.ID)
+	return t, nil
 }
 
 func (s *AdminService) Update(ctx context.Context, id int64, req TPSUpdateRequest) (*TPSDTO, error) {
-	return s.repo.Update(ctx, id, req)
+	t, err := s.repo.Update(ctx, id, req)
+	if err != nil {
+		return nil, err
+	}
+	s.tpsChanged(ctx, id)
+	return t, nil
 }
 
 func (s *AdminService) Delete(ctx context.Context, id int64) error {
-	return s.repo.Delete(ctx, id)
+	// The election has to be resolved before the row is gone
+	var electionID int64
+	if s.allocation != nil {
+		electionID, _ = s.allocation.ElectionOfTPS(ctx, id)
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	if s.allocation != nil {
+		s.allocation.TPSChanged(ctx, electionID)
+	}
+	return nil
 }
 
 // Operators
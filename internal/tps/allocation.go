@@ -0,0 +1,464 @@
+package tps
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Assignment sources
+const (
+	AssignmentAuto   = "AUTO"
+	AssignmentManual = "MANUAL"
+)
+
+// Allocation modes. Fill keeps current assignments as long as their TPS is
+// within its share; full redistributes every automatic assignment.
+const (
+	AllocationModeFill = "fill"
+	AllocationModeFull = "full"
+)
+
+// TimeSlot is a window within a TPS's opening hours, as "HH:MM"
+type TimeSlot struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// AllocationSite is a TPS as seen by the planner
+type AllocationSite struct {
+	TPSID     int64
+	Code      string
+	Name      string
+	Status    string
+	FacultyID *int64
+	Capacity  int
+	OpenTime  string
+	CloseTime string
+}
+
+// AllocationVoter is a TPS-mode voter who has not voted yet
+type AllocationVoter struct {
+	VoterID   int64
+	FacultyID *int64
+	TPSID     *int64
+	SlotStart *string
+	Source    string
+}
+
+type AllocationChange struct {
+	VoterID   int64     `json:"voter_id"`
+	FromTPSID *int64    `json:"from_tps_id"`
+	ToTPSID   *int64    `json:"to_tps_id"`
+	Slot      *TimeSlot `json:"slot,omitempty"`
+	Source    string    `json:"source,omitempty"`
+}
+
+type AllocationSlotLoad struct {
+	TimeSlot
+	Assigned int `json:"assigned"`
+}
+
+type AllocationSiteLoad struct {
+	TPSID     int64                `json:"tps_id"`
+	Code      string               `json:"code"`
+	Name      string               `json:"name"`
+	Status    string               `json:"status"`
+	FacultyID *int64               `json:"area_faculty_id,omitempty"`
+	Capacity  int                  `json:"capacity"`
+	Assigned  int                  `json:"assigned"`
+	Overflow  int                  `json:"overflow"`
+	Slots     []AllocationSlotLoad `json:"slots,omitempty"`
+}
+
+// AllocationPlan is the outcome of a planning pass. Changes lists only the
+// voters whose TPS or slot differs from their current assignment.
+type AllocationPlan struct {
+	ElectionID    int64                `json:"election_id"`
+	Mode          string               `json:"mode"`
+	TotalVoters   int                  `json:"total_voters"`
+	NewlyAssigned int                  `json:"newly_assigned"`
+	Moved         int                  `json:"moved"`
+	Unassigned    int                  `json:"unassigned"`
+	Overflow      int                  `json:"overflow"`
+	Sites         []AllocationSiteLoad `json:"sites"`
+	Changes       []AllocationChange   `json:"changes"`
+}
+
+// BuildTimeSlots splits opening hours into slots of the given length. The
+// last slot ends at closing time. Nothing is returned when slotMinutes is
+// zero or the hours cannot be parsed.
+func BuildTimeSlots(openTime, closeTime string, slotMinutes int) []TimeSlot {
+	if slotMinutes <= 0 {
+		return nil
+	}
+	openAt, ok1 := parseClock(openTime)
+	closeAt, ok2 := parseClock(closeTime)
+	if !ok1 || !ok2 || !openAt.Before(closeAt) {
+		return nil
+	}
+
+	step := time.Duration(slotMinutes) * time.Minute
+	var slots []TimeSlot
+	for start := openAt; start.Before(closeAt); start = start.Add(step) {
+		end := start.Add(step)
+		if end.After(closeAt) {
+			end = closeAt
+		}
+		slots = append(slots, TimeSlot{Start: start.Format("15:04"), End: end.Format("15:04")})
+	}
+	return slots
+}
+
+func parseClock(s string) (time.Time, bool) {
+	s = strings.TrimSpace(s)
+	for _, layout := range []string{"15:04:05", "15:04"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// NormalizeClock formats "8:00", "08:00" or "08:00:00" as "08:00"
+func NormalizeClock(s string) (string, error) {
+	t, ok := parseClock(s)
+	if !ok {
+		return "", fmt.Errorf("invalid time %q", s)
+	}
+	return t.Format("15:04"), nil
+}
+
+// PlanAllocation assigns voters to active TPS locations.
+//
+// A voter goes to a TPS of their own faculty when one is active, otherwise
+// to a TPS without a faculty, otherwise to any active TPS. Within those
+// candidates voters are spread in proportion to capacity; a TPS without a
+// capacity estimate counts as the average of the others. Manual assignments
+// to an active TPS are kept as they are. In fill mode, other current
+// assignments are kept up to each TPS's share, so adding or closing a TPS
+// moves as few voters as possible.
+func PlanAllocation(sites []AllocationSite, voters []AllocationVoter, mode string, slotMinutes int) *AllocationPlan {
+	if mode != AllocationModeFull {
+		mode = AllocationModeFill
+	}
+	plan := &AllocationPlan{Mode: mode, TotalVoters: len(voters), Changes: []AllocationChange{}}
+
+	sorted := append([]AllocationSite(nil), sites...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].TPSID < sorted[j].TPSID })
+
+	var open []*AllocationSite
+	index := make(map[int64]int)
+	for i := range sorted {
+		if sorted[i].Status == StatusActive {
+			index[sorted[i].TPSID] = len(open)
+			open = append(open, &sorted[i])
+		}
+	}
+	weights := capacityWeights(open)
+
+	candidates := make([][]int, len(voters))
+	for i, v := range voters {
+		candidates[i] = candidateSites(open, v.FacultyID)
+	}
+
+	// A voter is pinned when manually assigned to an active TPS, and placed
+	// when their current TPS is still a valid choice for them
+	pinned := make([]int, len(voters))
+	placed := make([]int, len(voters))
+	for i, v := range voters {
+		pinned[i], placed[i] = -1, -1
+		if v.TPSID == nil {
+			continue
+		}
+		site, ok := index[*v.TPSID]
+		if !ok {
+			continue
+		}
+		if v.Source == AssignmentManual {
+			pinned[i] = site
+		} else if containsInt(candidates[i], site) {
+			placed[i] = site
+		}
+	}
+
+	// Most constrained voters first, so general TPS are not filled by voters
+	// who could have gone to their faculty's TPS
+	order := make([]int, len(voters))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		ca, cb := len(candidates[order[a]]), len(candidates[order[b]])
+		if ca != cb {
+			return ca < cb
+		}
+		return voters[order[a]].VoterID < voters[order[b]].VoterID
+	})
+
+	// First pass: the share each TPS would get from a fresh distribution
+	targets := make([]int, len(open))
+	for i := range voters {
+		if pinned[i] >= 0 {
+			targets[pinned[i]]++
+		}
+	}
+	for _, i := range order {
+		if pinned[i] < 0 && len(candidates[i]) > 0 {
+			targets[leastLoaded(candidates[i], targets, weights)]++
+		}
+	}
+
+	// Second pass: keep what can stay, then place the rest
+	load := make([]int, len(open))
+	assigned := make([]int, len(voters))
+	for i := range voters {
+		assigned[i] = pinned[i]
+		if pinned[i] >= 0 {
+			load[pinned[i]]++
+		}
+	}
+	if mode == AllocationModeFill {
+		for i := range voters {
+			if s := placed[i]; s >= 0 && load[s] < targets[s] {
+				assigned[i] = s
+				load[s]++
+			}
+		}
+	}
+	for _, i := range order {
+		if assigned[i] < 0 && len(candidates[i]) > 0 {
+			s := leastLoaded(candidates[i], load, weights)
+			assigned[i] = s
+			load[s]++
+		}
+	}
+
+	slots := assignSlots(open, voters, assigned, load, slotMinutes)
+
+	plan.Sites = make([]AllocationSiteLoad, len(open))
+	for s, site := range open {
+		sl := AllocationSiteLoad{
+			TPSID:     site.TPSID,
+			Code:      site.Code,
+			Name:      site.Name,
+			Status:    site.Status,
+			FacultyID: site.FacultyID,
+			Capacity:  site.Capacity,
+			Assigned:  load[s],
+		}
+		if site.Capacity > 0 && load[s] > site.Capacity {
+			sl.Overflow = load[s] - site.Capacity
+			plan.Overflow += sl.Overflow
+		}
+		sl.Slots = slots.loads[s]
+		plan.Sites[s] = sl
+	}
+
+	for i, v := range voters {
+		change := AllocationChange{VoterID: v.VoterID, FromTPSID: v.TPSID, Source: AssignmentAuto}
+		if pinned[i] >= 0 {
+			change.Source = AssignmentManual
+		}
+		if assigned[i] >= 0 {
+			id := open[assigned[i]].TPSID
+			change.ToTPSID = &id
+			change.Slot = slots.byVoter[i]
+		} else {
+			plan.Unassigned++
+		}
+
+		if sameTPS(v.TPSID, change.ToTPSID) && sameSlot(v.SlotStart, change.Slot) {
+			continue
+		}
+		switch {
+		case v.TPSID == nil && change.ToTPSID != nil:
+			plan.NewlyAssigned++
+		case v.TPSID != nil && !sameTPS(v.TPSID, change.ToTPSID):
+			plan.Moved++
+		}
+		plan.Changes = append(plan.Changes, change)
+	}
+	return plan
+}
+
+// CurrentAllocation reports the loads of the current assignments without
+// planning anything
+func CurrentAllocation(sites []AllocationSite, voters []AllocationVoter) *AllocationPlan {
+	plan := &AllocationPlan{TotalVoters: len(voters), Sites: make([]AllocationSiteLoad, 0, len(sites)), Changes: []AllocationChange{}}
+	index := make(map[int64]int)
+	for _, site := range sites {
+		index[site.TPSID] = len(plan.Sites)
+		plan.Sites = append(plan.Sites, AllocationSiteLoad{
+			TPSID:     site.TPSID,
+			Code:      site.Code,
+			Name:      site.Name,
+			Status:    site.Status,
+			FacultyID: site.FacultyID,
+			Capacity:  site.Capacity,
+		})
+	}
+	for _, v := range voters {
+		if v.TPSID == nil {
+			plan.Unassigned++
+			continue
+		}
+		if s, ok := index[*v.TPSID]; ok {
+			plan.Sites[s].Assigned++
+		}
+	}
+	for i := range plan.Sites {
+		s := &plan.Sites[i]
+		if s.Capacity > 0 && s.Assigned > s.Capacity {
+			s.Overflow = s.Assigned - s.Capacity
+			plan.Overflow += s.Overflow
+		}
+	}
+	return plan
+}
+
+func candidateSites(open []*AllocationSite, facultyID *int64) []int {
+	var own, general, all []int
+	for s, site := range open {
+		all = append(all, s)
+		switch {
+		case site.FacultyID == nil:
+			general = append(general, s)
+		case facultyID != nil && *site.FacultyID == *facultyID:
+			own = append(own, s)
+		}
+	}
+	if len(own) > 0 {
+		return own
+	}
+	if len(general) > 0 {
+		return general
+	}
+	return all
+}
+
+func capacityWeights(open []*AllocationSite) []float64 {
+	var sum float64
+	var n int
+	for _, site := range open {
+		if site.Capacity > 0 {
+			sum += float64(site.Capacity)
+			n++
+		}
+	}
+	mean := 1.0
+	if n > 0 {
+		mean = sum / float64(n)
+	}
+
+	weights := make([]float64, len(open))
+	for s, site := range open {
+		weights[s] = mean
+		if site.Capacity > 0 {
+			weights[s] = float64(site.Capacity)
+		}
+	}
+	return weights
+}
+
+// leastLoaded picks the candidate whose load after one more voter is the
+// smallest share of its capacity. Ties go to the lowest TPS ID.
+func leastLoaded(candidates []int, load []int, weights []float64) int {
+	best, bestRatio := -1, math.Inf(1)
+	for _, s := range candidates {
+		ratio := float64(load[s]+1) / weights[s]
+		if ratio < bestRatio {
+			best, bestRatio = s, ratio
+		}
+	}
+	return best
+}
+
+type slotAssignment struct {
+	byVoter []*TimeSlot
+	loads   [][]AllocationSlotLoad
+}
+
+// assignSlots spreads each TPS's voters evenly over its slots, keeping a
+// voter's current slot while it is not over its share. Manual assignments
+// keep their slot regardless.
+func assignSlots(open []*AllocationSite, voters []AllocationVoter, assigned, load []int, slotMinutes int) slotAssignment {
+	out := slotAssignment{byVoter: make([]*TimeSlot, len(voters)), loads: make([][]AllocationSlotLoad, len(open))}
+	siteSlots := make([][]TimeSlot, len(open))
+	for s, site := range open {
+		siteSlots[s] = BuildTimeSlots(site.OpenTime, site.CloseTime, slotMinutes)
+		for _, slot := range siteSlots[s] {
+			out.loads[s] = append(out.loads[s], AllocationSlotLoad{TimeSlot: slot})
+		}
+	}
+
+	var pending []int
+	for i, v := range voters {
+		s := assigned[i]
+		if s < 0 || len(siteSlots[s]) == 0 {
+			continue
+		}
+		quota := (load[s] + len(siteSlots[s]) - 1) / len(siteSlots[s])
+		if v.SlotStart != nil && sameTPS(v.TPSID, &open[s].TPSID) {
+			k := slotIndex(siteSlots[s], *v.SlotStart)
+			if k >= 0 && (v.Source == AssignmentManual || out.loads[s][k].Assigned < quota) {
+				out.loads[s][k].Assigned++
+				out.byVoter[i] = &siteSlots[s][k]
+				continue
+			}
+		}
+		pending = append(pending, i)
+	}
+
+	for _, i := range pending {
+		s := assigned[i]
+		best := 0
+		for k := range out.loads[s] {
+			if out.loads[s][k].Assigned < out.loads[s][best].Assigned {
+				best = k
+			}
+		}
+		out.loads[s][best].Assigned++
+		out.byVoter[i] = &siteSlots[s][best]
+	}
+	return out
+}
+
+func slotIndex(slots []TimeSlot, start string) int {
+	start, err := NormalizeClock(start)
+	if err != nil {
+		return -1
+	}
+	for k, slot := range slots {
+		if slot.Start == start {
+			return k
+		}
+	}
+	return -1
+}
+
+func sameTPS(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func sameSlot(current *string, next *TimeSlot) bool {
+	if current == nil || next == nil {
+		return current == nil && next == nil
+	}
+	c, err := NormalizeClock(*current)
+	return err == nil && c == next.Start
+}
+
+func containsInt(list []int, v int) bool {
+	for _, x := range list {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
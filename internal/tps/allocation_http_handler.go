@@ -0,0 +1,254 @@
+package tps
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"pemira-api/internal/errcatalog"
+	"pemira-api/internal/http/response"
+	"pemira-api/internal/shared/ctxkeys"
+)
+
+type AllocationHandler struct {
+	svc *AllocationService
+}
+
+func NewAllocationHandler(svc *AllocationService) *AllocationHandler {
+	return &AllocationHandler{svc: svc}
+}
+
+// GET /admin/elections/{electionID}/tps/allocation
+func (h *AllocationHandler) Overview(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	electionID, ok := allocationElectionID(w, r)
+	if !ok {
+		return
+	}
+
+	settings, err := h.svc.GetSettings(ctx, electionID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	plan, err := h.svc.Overview(ctx, electionID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.Success(w, http.StatusOK, map[string]interface{}{
+		"settings":   settings,
+		"allocation": plan,
+	})
+}
+
+// PUT /admin/elections/{electionID}/tps/allocation/settings
+func (h *AllocationHandler) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	electionID, ok := allocationElectionID(w, r)
+	if !ok {
+		return
+	}
+
+	var req UpdateAllocationSettingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
+		return
+	}
+
+	settings, err := h.svc.UpdateSettings(ctx, electionID, req, actorID(ctx))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	response.Success(w, http.StatusOK, settings)
+}
+
+// GET /admin/elections/{electionID}/tps/allocation/preview?mode=fill|full
+func (h *AllocationHandler) Preview(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	electionID, ok := allocationElectionID(w, r)
+	if !ok {
+		return
+	}
+	mode, ok := allocationMode(w, r.URL.Query().Get("mode"))
+	if !ok {
+		return
+	}
+
+	plan, err := h.svc.Preview(ctx, electionID, mode)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	response.Success(w, http.StatusOK, plan)
+}
+
+// POST /admin/elections/{electionID}/tps/allocation/apply
+func (h *AllocationHandler) Apply(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	electionID, ok := allocationElectionID(w, r)
+	if !ok {
+		return
+	}
+
+	var req ApplyAllocationRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
+			return
+		}
+	}
+	mode, ok := allocationMode(w, req.Mode)
+	if !ok {
+		return
+	}
+
+	plan, err := h.svc.Apply(ctx, electionID, actorID(ctx), mode, AllocationTriggerManual)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	response.Success(w, http.StatusOK, plan)
+}
+
+// PUT /admin/elections/{electionID}/tps/allocation/voters/{voterID}
+func (h *AllocationHandler) AssignVoter(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	electionID, ok := allocationElectionID(w, r)
+	if !ok {
+		return
+	}
+	voterID, err := parseIDParam(r, "voterID")
+	if err != nil || voterID <= 0 {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("voterID"))
+		return
+	}
+
+	var req AssignVoterTPSRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
+		return
+	}
+	if req.TPSID <= 0 {
+		response.UnprocessableEntity(w, "VALIDATION_ERROR", errcatalog.Required("tps_id"))
+		return
+	}
+
+	assignment, err := h.svc.AssignVoter(ctx, electionID, voterID, req)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	response.Success(w, http.StatusOK, assignment)
+}
+
+// DELETE /admin/elections/{electionID}/tps/allocation/voters/{voterID}
+func (h *AllocationHandler) ReleaseVoter(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	electionID, ok := allocationElectionID(w, r)
+	if !ok {
+		return
+	}
+	voterID, err := parseIDParam(r, "voterID")
+	if err != nil || voterID <= 0 {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("voterID"))
+		return
+	}
+
+	if err := h.svc.ReleaseVoter(ctx, electionID, voterID); err != nil {
+		h.handleError(w, err)
+		return
+	}
+	response.Success(w, http.StatusOK, map[string]interface{}{
+		"voter_id": voterID,
+		"source":   AssignmentAuto,
+	})
+}
+
+// GET /tps/assignment?election_id=
+func (h *AllocationHandler) MyAssignment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	voterID, ok := ctxkeys.GetVoterID(ctx)
+	if !ok {
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.TokenNoAccess)
+		return
+	}
+	electionID, err := strconv.ParseInt(r.URL.Query().Get("election_id"), 10, 64)
+	if err != nil || electionID <= 0 {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Required("election_id"))
+		return
+	}
+
+	assignment, err := h.svc.VoterAssignment(ctx, electionID, voterID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	response.Success(w, http.StatusOK, assignment)
+}
+
+func (h *AllocationHandler) handleError(w http.ResponseWriter, err error) {
+	if response.DomainError(w, err) {
+		return
+	}
+	slog.Error("tps allocation handler error", "error", err)
+	response.InternalServerError(w, "INTERNAL_ERROR", errcatalog.InternalError)
+}
+
+func allocationElectionID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	electionID, err := strconv.ParseInt(chi.URLParam(r, "electionID"), 10, 64)
+	if err != nil || electionID <= 0 {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("electionID"))
+		return 0, false
+	}
+	return electionID, true
+}
+
+func allocationMode(w http.ResponseWriter, mode string) (string, bool) {
+	switch mode {
+	case "":
+		return AllocationModeFill, true
+	case AllocationModeFill, AllocationModeFull:
+		return mode, true
+	}
+	response.BadRequest(w, "VALIDATION_ERROR", errcatalog.OneOf("mode", "fill", "full"))
+	return "", false
+}
+
+func actorID(ctx context.Context) *int64 {
+	if id, ok := ctxkeys.GetUserID(ctx); ok && id > 0 {
+		return &id
+	}
+	return nil
+}
+
+// panelOverride builds the override request of a panel check-in
+func panelOverride(ctx context.Context, override bool, reason string) CheckinOverride {
+	return CheckinOverride{Override: override, Reason: reason, OperatorID: actorID(ctx)}
+}
+
+// notAssignedDetails tells the voter or operator where the voter should go
+func notAssignedDetails(e *NotAssignedError) map[string]interface{} {
+	details := map[string]interface{}{
+		"assigned_tps": e.Assigned.TPS,
+	}
+	if e.Assigned.Location != "" {
+		details["location"] = e.Assigned.Location
+	}
+	if e.Assigned.Slot != nil {
+		details["slot"] = e.Assigned.Slot
+	}
+	return details
+}
+
+func writeNotAssigned(w http.ResponseWriter, e *NotAssignedError) {
+	details := notAssignedDetails(e)
+	details["override_allowed"] = true
+	response.Error(w, http.StatusForbidden, "TPS_NOT_ASSIGNED", catalogMessage(e), details)
+}
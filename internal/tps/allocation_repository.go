@@ -0,0 +1,86 @@
+package tps
+
+import (
+	"context"
+	"time"
+)
+
+// AllocationSettings controls TPS allocation for one election. Elections
+// without saved settings are not managed by the allocator.
+type AllocationSettings struct {
+	ElectionID        int64      `json:"election_id"`
+	Enabled           bool       `json:"enabled"`
+	SlotMinutes       int        `json:"slot_minutes"`
+	AutoRebalance     bool       `json:"auto_rebalance"`
+	EnforceAssignment bool       `json:"enforce_assignment"`
+	NotifyVoters      bool       `json:"notify_voters"`
+	UpdatedAt         *time.Time `json:"updated_at,omitempty"`
+}
+
+// VoterAssignment is where and when a voter is expected to vote
+type VoterAssignment struct {
+	ElectionID int64      `json:"election_id"`
+	VoterID    int64      `json:"voter_id"`
+	TPS        *TPSInfo   `json:"tps"`
+	Location   string     `json:"location,omitempty"`
+	VotingDate *time.Time `json:"voting_date,omitempty"`
+	OpenTime   string     `json:"open_time,omitempty"`
+	CloseTime  string     `json:"close_time,omitempty"`
+	Slot       *TimeSlot  `json:"slot,omitempty"`
+	Source     string     `json:"source,omitempty"`
+	AssignedAt *time.Time `json:"assigned_at,omitempty"`
+	NotifiedAt *time.Time `json:"notified_at,omitempty"`
+}
+
+// AssignmentNotice is the data for telling a voter about their TPS
+type AssignmentNotice struct {
+	VoterAssignment
+	VoterName    string
+	Email        string
+	ElectionName string
+}
+
+// AssignmentOverride records an operator checking a voter in at a TPS other
+// than the assigned one
+type AssignmentOverride struct {
+	ElectionID    int64
+	VoterID       int64
+	AssignedTPSID *int64
+	CheckinTPSID  int64
+	OperatorID    *int64
+	Reason        string
+}
+
+// AllocationRun is the audit record of an applied plan
+type AllocationRun struct {
+	ElectionID int64
+	Trigger    string
+	Mode       string
+	CreatedBy  *int64
+}
+
+// Allocation triggers
+const (
+	AllocationTriggerManual     = "MANUAL"
+	AllocationTriggerTPSChanged = "TPS_CHANGED"
+)
+
+type AllocationRepository interface {
+	GetAllocationSettings(ctx context.Context, electionID int64) (*AllocationSettings, error)
+	SaveAllocationSettings(ctx context.Context, settings *AllocationSettings, updatedBy *int64) error
+	ElectionIDForTPS(ctx context.Context, tpsID int64) (int64, error)
+
+	ListAllocationSites(ctx context.Context, electionID int64) ([]AllocationSite, error)
+	ListAllocationVoters(ctx context.Context, electionID int64) ([]AllocationVoter, error)
+	// ApplyAllocation locks the election, plans against the current state
+	// and writes the changes, so concurrent runs cannot interleave
+	ApplyAllocation(ctx context.Context, run AllocationRun, plan func([]AllocationSite, []AllocationVoter) *AllocationPlan) (*AllocationPlan, error)
+
+	GetVoterAssignment(ctx context.Context, electionID, voterID int64) (*VoterAssignment, error)
+	AssignVoterTPS(ctx context.Context, electionID, voterID, tpsID int64, slot *TimeSlot) error
+	ReleaseVoterTPS(ctx context.Context, electionID, voterID int64) error
+
+	ListAssignmentNotices(ctx context.Context, electionID int64, voterIDs []int64) ([]AssignmentNotice, error)
+	MarkAssignmentsNotified(ctx context.Context, electionID int64, voterIDs []int64) error
+	RecordAssignmentOverride(ctx context.Context, o AssignmentOverride) error
+}
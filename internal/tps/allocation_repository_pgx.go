@@ -0,0 +1,351 @@
+package tps
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PgAllocationRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPgAllocationRepository(db *pgxpool.Pool) *PgAllocationRepository {
+	return &PgAllocationRepository{db: db}
+}
+
+// allocationQuerier is satisfied by both the pool and a transaction
+type allocationQuerier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+func (r *PgAllocationRepository) GetAllocationSettings(ctx context.Context, electionID int64) (*AllocationSettings, error) {
+	s := AllocationSettings{ElectionID: electionID, AutoRebalance: true, NotifyVoters: true}
+	err := r.db.QueryRow(ctx, `
+		SELECT enabled, slot_minutes, auto_rebalance, enforce_assignment, notify_voters, updated_at
+		FROM tps_allocation_settings
+		WHERE election_id = $1
+	`, electionID).Scan(&s.Enabled, &s.SlotMinutes, &s.AutoRebalance, &s.EnforceAssignment, &s.NotifyVoters, &s.UpdatedAt)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *PgAllocationRepository) SaveAllocationSettings(ctx context.Context, s *AllocationSettings, updatedBy *int64) error {
+	return r.db.QueryRow(ctx, `
+		INSERT INTO tps_allocation_settings (
+			election_id, enabled, slot_minutes, auto_rebalance, enforce_assignment, notify_voters, updated_by
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (election_id) DO UPDATE SET
+			enabled = EXCLUDED.enabled,
+			slot_minutes = EXCLUDED.slot_minutes,
+			auto_rebalance = EXCLUDED.auto_rebalance,
+			enforce_assignment = EXCLUDED.enforce_assignment,
+			notify_voters = EXCLUDED.notify_voters,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = NOW()
+		RETURNING updated_at
+	`, s.ElectionID, s.Enabled, s.SlotMinutes, s.AutoRebalance, s.EnforceAssignment, s.NotifyVoters, updatedBy).Scan(&s.UpdatedAt)
+}
+
+func (r *PgAllocationRepository) ElectionIDForTPS(ctx context.Context, tpsID int64) (int64, error) {
+	var electionID int64
+	err := r.db.QueryRow(ctx, `SELECT election_id FROM tps WHERE id = $1`, tpsID).Scan(&electionID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, ErrTPSNotFound
+	}
+	return electionID, err
+}
+
+func (r *PgAllocationRepository) ListAllocationSites(ctx context.Context, electionID int64) ([]AllocationSite, error) {
+	return listAllocationSites(ctx, r.db, electionID)
+}
+
+func (r *PgAllocationRepository) ListAllocationVoters(ctx context.Context, electionID int64) ([]AllocationVoter, error) {
+	return listAllocationVoters(ctx, r.db, electionID)
+}
+
+func listAllocationSites(ctx context.Context, q allocationQuerier, electionID int64) ([]AllocationSite, error) {
+	rows, err := q.Query(ctx, `
+		SELECT id, code, name, status, area_faculty_id, COALESCE(capacity_estimate, 0),
+		       COALESCE(to_char(open_time, 'HH24:MI'), ''), COALESCE(to_char(close_time, 'HH24:MI'), '')
+		FROM tps
+		WHERE election_id = $1
+		ORDER BY id
+	`, electionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sites []AllocationSite
+	for rows.Next() {
+		var s AllocationSite
+		if err := rows.Scan(&s.TPSID, &s.Code, &s.Name, &s.Status, &s.FacultyID, &s.Capacity, &s.OpenTime, &s.CloseTime); err != nil {
+			return nil, err
+		}
+		sites = append(sites, s)
+	}
+	return sites, rows.Err()
+}
+
+// listAllocationVoters returns TPS-mode enrollments that can still vote.
+// The faculty comes from the voter's faculty code.
+func listAllocationVoters(ctx context.Context, q allocationQuerier, electionID int64) ([]AllocationVoter, error) {
+	rows, err := q.Query(ctx, `
+		SELECT ev.voter_id, f.id, ev.tps_id, to_char(ev.tps_slot_start, 'HH24:MI'),
+		       COALESCE(ev.tps_assignment, 'AUTO')
+		FROM election_voters ev
+		JOIN voters v ON v.id = ev.voter_id
+		LEFT JOIN faculties f ON f.code = v.faculty_code
+		WHERE ev.election_id = $1
+		  AND ev.voting_method = 'TPS'
+		  AND ev.status IN ('PENDING', 'VERIFIED')
+		  AND NOT EXISTS (
+		      SELECT 1 FROM voter_status vs
+		      WHERE vs.election_id = ev.election_id AND vs.voter_id = ev.voter_id AND vs.has_voted
+		  )
+		ORDER BY ev.voter_id
+	`, electionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var voters []AllocationVoter
+	for rows.Next() {
+		var v AllocationVoter
+		if err := rows.Scan(&v.VoterID, &v.FacultyID, &v.TPSID, &v.SlotStart, &v.Source); err != nil {
+			return nil, err
+		}
+		voters = append(voters, v)
+	}
+	return voters, rows.Err()
+}
+
+func (r *PgAllocationRepository) ApplyAllocation(
+	ctx context.Context,
+	run AllocationRun,
+	planFn func([]AllocationSite, []AllocationVoter) *AllocationPlan,
+) (*AllocationPlan, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('tps_allocation'), $1::int)`, run.ElectionID); err != nil {
+		return nil, fmt.Errorf("lock election: %w", err)
+	}
+
+	sites, err := listAllocationSites(ctx, tx, run.ElectionID)
+	if err != nil {
+		return nil, fmt.Errorf("list sites: %w", err)
+	}
+	voters, err := listAllocationVoters(ctx, tx, run.ElectionID)
+	if err != nil {
+		return nil, fmt.Errorf("list voters: %w", err)
+	}
+	plan := planFn(sites, voters)
+	plan.ElectionID = run.ElectionID
+
+	if len(plan.Changes) > 0 {
+		n := len(plan.Changes)
+		voterIDs := make([]int64, n)
+		tpsIDs := make([]*int64, n)
+		starts := make([]*string, n)
+		ends := make([]*string, n)
+		sources := make([]*string, n)
+		for i, c := range plan.Changes {
+			voterIDs[i] = c.VoterID
+			tpsIDs[i] = c.ToTPSID
+			if c.Slot != nil {
+				starts[i], ends[i] = &c.Slot.Start, &c.Slot.End
+			}
+			if c.ToTPSID != nil {
+				source := c.Source
+				sources[i] = &source
+			}
+		}
+
+		if _, err := tx.Exec(ctx, `
+			UPDATE election_voters ev
+			SET tps_id = c.tps_id,
+			    tps_slot_start = c.slot_start::TIME,
+			    tps_slot_end = c.slot_end::TIME,
+			    tps_assignment = c.source,
+			    tps_assigned_at = NOW(),
+			    tps_notified_at = NULL,
+			    updated_at = NOW()
+			FROM unnest($2::BIGINT[], $3::BIGINT[], $4::TEXT[], $5::TEXT[], $6::TEXT[])
+			     AS c(voter_id, tps_id, slot_start, slot_end, source)
+			WHERE ev.election_id = $1 AND ev.voter_id = c.voter_id
+		`, run.ElectionID, voterIDs, tpsIDs, starts, ends, sources); err != nil {
+			return nil, fmt.Errorf("update assignments: %w", err)
+		}
+	}
+
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO tps_allocation_settings (election_id, enabled, updated_by)
+		VALUES ($1, TRUE, $2)
+		ON CONFLICT (election_id) DO UPDATE SET enabled = TRUE
+	`, run.ElectionID, run.CreatedBy); err != nil {
+		return nil, fmt.Errorf("enable allocation: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO tps_allocation_runs (
+			election_id, trigger, mode, total, assigned, moved, unassigned, overflow, created_by
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`, run.ElectionID, run.Trigger, plan.Mode, plan.TotalVoters, plan.NewlyAssigned,
+		plan.Moved, plan.Unassigned, plan.Overflow, run.CreatedBy); err != nil {
+		return nil, fmt.Errorf("record run: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+	return plan, nil
+}
+
+const voterAssignmentColumns = `
+	ev.election_id, ev.voter_id, t.id, t.code, t.name, t.location, t.voting_date,
+	COALESCE(to_char(t.open_time, 'HH24:MI'), ''), COALESCE(to_char(t.close_time, 'HH24:MI'), ''),
+	to_char(ev.tps_slot_start, 'HH24:MI'), to_char(ev.tps_slot_end, 'HH24:MI'),
+	COALESCE(ev.tps_assignment, ''), ev.tps_assigned_at, ev.tps_notified_at`
+
+func scanVoterAssignment(row pgx.Row, a *VoterAssignment, extra ...any) error {
+	var (
+		tpsID              *int64
+		code, name, loc    *string
+		slotStart, slotEnd *string
+	)
+	dest := []any{
+		&a.ElectionID, &a.VoterID, &tpsID, &code, &name, &loc, &a.VotingDate,
+		&a.OpenTime, &a.CloseTime, &slotStart, &slotEnd,
+		&a.Source, &a.AssignedAt, &a.NotifiedAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return err
+	}
+	if tpsID != nil {
+		a.TPS = &TPSInfo{ID: *tpsID, Code: deref(code), Name: deref(name)}
+		a.Location = deref(loc)
+	}
+	if slotStart != nil && slotEnd != nil {
+		a.Slot = &TimeSlot{Start: *slotStart, End: *slotEnd}
+	}
+	return nil
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func (r *PgAllocationRepository) GetVoterAssignment(ctx context.Context, electionID, voterID int64) (*VoterAssignment, error) {
+	var a VoterAssignment
+	err := scanVoterAssignment(r.db.QueryRow(ctx, `
+		SELECT `+voterAssignmentColumns+`
+		FROM election_voters ev
+		LEFT JOIN tps t ON t.id = ev.tps_id
+		WHERE ev.election_id = $1 AND ev.voter_id = $2 AND ev.voting_method = 'TPS'
+	`, electionID, voterID), &a)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotTPSVoter
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (r *PgAllocationRepository) AssignVoterTPS(ctx context.Context, electionID, voterID, tpsID int64, slot *TimeSlot) error {
+	var start, end *string
+	if slot != nil {
+		start, end = &slot.Start, &slot.End
+	}
+
+	tag, err := r.db.Exec(ctx, `
+		UPDATE election_voters
+		SET tps_id = $3,
+		    tps_slot_start = $4::TIME,
+		    tps_slot_end = $5::TIME,
+		    tps_assignment = 'MANUAL',
+		    tps_assigned_at = NOW(),
+		    tps_notified_at = NULL,
+		    updated_at = NOW()
+		WHERE election_id = $1 AND voter_id = $2 AND voting_method = 'TPS'
+	`, electionID, voterID, tpsID, start, end)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotTPSVoter
+	}
+	return nil
+}
+
+func (r *PgAllocationRepository) ReleaseVoterTPS(ctx context.Context, electionID, voterID int64) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE election_voters
+		SET tps_assignment = CASE WHEN tps_id IS NULL THEN NULL ELSE 'AUTO' END, updated_at = NOW()
+		WHERE election_id = $1 AND voter_id = $2 AND voting_method = 'TPS'
+	`, electionID, voterID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotTPSVoter
+	}
+	return nil
+}
+
+func (r *PgAllocationRepository) ListAssignmentNotices(ctx context.Context, electionID int64, voterIDs []int64) ([]AssignmentNotice, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+voterAssignmentColumns+`, v.name, COALESCE(v.email, ''), e.name
+		FROM election_voters ev
+		JOIN voters v ON v.id = ev.voter_id
+		JOIN elections e ON e.id = ev.election_id
+		JOIN tps t ON t.id = ev.tps_id
+		WHERE ev.election_id = $1 AND ev.voter_id = ANY($2)
+		ORDER BY ev.voter_id
+	`, electionID, voterIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var notices []AssignmentNotice
+	for rows.Next() {
+		var n AssignmentNotice
+		if err := scanVoterAssignment(rows, &n.VoterAssignment, &n.VoterName, &n.Email, &n.ElectionName); err != nil {
+			return nil, err
+		}
+		notices = append(notices, n)
+	}
+	return notices, rows.Err()
+}
+
+func (r *PgAllocationRepository) MarkAssignmentsNotified(ctx context.Context, electionID int64, voterIDs []int64) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE election_voters SET tps_notified_at = NOW()
+		WHERE election_id = $1 AND voter_id = ANY($2)
+	`, electionID, voterIDs)
+	return err
+}
+
+func (r *PgAllocationRepository) RecordAssignmentOverride(ctx context.Context, o AssignmentOverride) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO tps_assignment_overrides (
+			election_id, voter_id, assigned_tps_id, checkin_tps_id, operator_id, reason
+		) VALUES ($1, $2, $3, $4, $5, $6)
+	`, o.ElectionID, o.VoterID, o.AssignedTPSID, o.CheckinTPSID, o.OperatorID, o.Reason)
+	return err
+}
@@ -0,0 +1,314 @@
+package tps
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"pemira-api/internal/mail"
+)
+
+// maxSlotMinutes bounds slot_minutes to a single voting day
+const maxSlotMinutes = 24 * 60
+
+// AllocationService assigns TPS-mode voters to a TPS (and optionally a time
+// slot) per election, and enforces those assignments at check-in.
+type AllocationService struct {
+	repo   AllocationRepository
+	mailer mail.Sender
+}
+
+func NewAllocationService(repo AllocationRepository) *AllocationService {
+	return &AllocationService{repo: repo, mailer: mail.LogSender{}}
+}
+
+// SetMailer sets where assignment notices are sent. nil disables them.
+func (s *AllocationService) SetMailer(m mail.Sender) {
+	s.mailer = m
+}
+
+func (s *AllocationService) GetSettings(ctx context.Context, electionID int64) (*AllocationSettings, error) {
+	return s.repo.GetAllocationSettings(ctx, electionID)
+}
+
+func (s *AllocationService) UpdateSettings(ctx context.Context, electionID int64, req UpdateAllocationSettingsRequest, actorID *int64) (*AllocationSettings, error) {
+	settings, err := s.repo.GetAllocationSettings(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Enabled != nil {
+		settings.Enabled = *req.Enabled
+	}
+	if req.SlotMinutes != nil {
+		if *req.SlotMinutes < 0 || *req.SlotMinutes > maxSlotMinutes {
+			return nil, ErrInvalidAllocation
+		}
+		settings.SlotMinutes = *req.SlotMinutes
+	}
+	if req.AutoRebalance != nil {
+		settings.AutoRebalance = *req.AutoRebalance
+	}
+	if req.EnforceAssignment != nil {
+		settings.EnforceAssignment = *req.EnforceAssignment
+	}
+	if req.NotifyVoters != nil {
+		settings.NotifyVoters = *req.NotifyVoters
+	}
+	settings.ElectionID = electionID
+
+	if err := s.repo.SaveAllocationSettings(ctx, settings, actorID); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// Overview reports how the current assignments are spread
+func (s *AllocationService) Overview(ctx context.Context, electionID int64) (*AllocationPlan, error) {
+	sites, err := s.repo.ListAllocationSites(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+	voters, err := s.repo.ListAllocationVoters(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+	plan := CurrentAllocation(sites, voters)
+	plan.ElectionID = electionID
+	return plan, nil
+}
+
+// Preview plans an allocation without writing it
+func (s *AllocationService) Preview(ctx context.Context, electionID int64, mode string) (*AllocationPlan, error) {
+	settings, err := s.repo.GetAllocationSettings(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+	sites, err := s.repo.ListAllocationSites(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+	voters, err := s.repo.ListAllocationVoters(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+	plan := PlanAllocation(sites, voters, mode, settings.SlotMinutes)
+	plan.ElectionID = electionID
+	return plan, nil
+}
+
+// Apply plans and writes an allocation. Applying enables allocation for the
+// election. Voters whose TPS or slot changed are notified in the background.
+func (s *AllocationService) Apply(ctx context.Context, electionID int64, actorID *int64, mode, trigger string) (*AllocationPlan, error) {
+	settings, err := s.repo.GetAllocationSettings(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := s.repo.ApplyAllocation(ctx, AllocationRun{
+		ElectionID: electionID,
+		Trigger:    trigger,
+		Mode:       mode,
+		CreatedBy:  actorID,
+	}, func(sites []AllocationSite, voters []AllocationVoter) *AllocationPlan {
+		return PlanAllocation(sites, voters, mode, settings.SlotMinutes)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if settings.NotifyVoters {
+		var voterIDs []int64
+		for _, c := range plan.Changes {
+			if c.ToTPSID != nil {
+				voterIDs = append(voterIDs, c.VoterID)
+			}
+		}
+		if len(voterIDs) > 0 {
+			go s.notify(context.WithoutCancel(ctx), electionID, voterIDs)
+		}
+	}
+	return plan, nil
+}
+
+// TPSChanged rebalances an election after a TPS was added, edited, closed or
+// removed, when the election has automatic rebalancing on. Failures are
+// logged only: the TPS change itself has already been saved.
+func (s *AllocationService) TPSChanged(ctx context.Context, electionID int64) {
+	if s == nil || electionID <= 0 {
+		return
+	}
+	settings, err := s.repo.GetAllocationSettings(ctx, electionID)
+	if err != nil {
+		slog.Error("tps allocation: failed to load settings", "election_id", electionID, "error", err)
+		return
+	}
+	if !settings.Enabled || !settings.AutoRebalance {
+		return
+	}
+	plan, err := s.Apply(ctx, electionID, nil, AllocationModeFill, AllocationTriggerTPSChanged)
+	if err != nil {
+		slog.Error("tps allocation: rebalance failed", "election_id", electionID, "error", err)
+		return
+	}
+	slog.Info("tps allocation rebalanced",
+		"election_id", electionID,
+		"assigned", plan.NewlyAssigned,
+		"moved", plan.Moved,
+		"unassigned", plan.Unassigned,
+		"overflow", plan.Overflow,
+	)
+}
+
+// ElectionOfTPS returns the election a TPS belongs to
+func (s *AllocationService) ElectionOfTPS(ctx context.Context, tpsID int64) (int64, error) {
+	return s.repo.ElectionIDForTPS(ctx, tpsID)
+}
+
+// AssignVoter pins a voter to a TPS and optional slot. Pinned voters are not
+// moved by rebalancing while their TPS stays active.
+func (s *AllocationService) AssignVoter(ctx context.Context, electionID, voterID int64, req AssignVoterTPSRequest) (*VoterAssignment, error) {
+	settings, err := s.repo.GetAllocationSettings(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+	sites, err := s.repo.ListAllocationSites(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var site *AllocationSite
+	for i := range sites {
+		if sites[i].TPSID == req.TPSID {
+			site = &sites[i]
+			break
+		}
+	}
+	if site == nil {
+		return nil, ErrTPSNotFound
+	}
+	if site.Status != StatusActive {
+		return nil, ErrTPSInactive
+	}
+
+	var slot *TimeSlot
+	if start := strings.TrimSpace(req.SlotStart); start != "" {
+		slots := BuildTimeSlots(site.OpenTime, site.CloseTime, settings.SlotMinutes)
+		k := slotIndex(slots, start)
+		if k < 0 {
+			return nil, ErrInvalidTimeSlot
+		}
+		slot = &slots[k]
+	}
+
+	if err := s.repo.AssignVoterTPS(ctx, electionID, voterID, site.TPSID, slot); err != nil {
+		return nil, err
+	}
+	if settings.NotifyVoters {
+		go s.notify(context.WithoutCancel(ctx), electionID, []int64{voterID})
+	}
+	return s.repo.GetVoterAssignment(ctx, electionID, voterID)
+}
+
+// ReleaseVoter turns a manual assignment back into an automatic one, so the
+// next rebalance may move the voter
+func (s *AllocationService) ReleaseVoter(ctx context.Context, electionID, voterID int64) error {
+	return s.repo.ReleaseVoterTPS(ctx, electionID, voterID)
+}
+
+func (s *AllocationService) VoterAssignment(ctx context.Context, electionID, voterID int64) (*VoterAssignment, error) {
+	return s.repo.GetVoterAssignment(ctx, electionID, voterID)
+}
+
+// CheckAssignment refuses a check-in at a TPS other than the voter's
+// assigned one. It only applies when the election enforces assignments and
+// the voter has been assigned; the error is a *NotAssignedError.
+func (s *AllocationService) CheckAssignment(ctx context.Context, electionID, voterID, tpsID int64) error {
+	if s == nil {
+		return nil
+	}
+	settings, err := s.repo.GetAllocationSettings(ctx, electionID)
+	if err != nil {
+		return err
+	}
+	if !settings.Enabled || !settings.EnforceAssignment {
+		return nil
+	}
+
+	assignment, err := s.repo.GetVoterAssignment(ctx, electionID, voterID)
+	if err != nil {
+		if errors.Is(err, ErrNotTPSVoter) {
+			return nil
+		}
+		return err
+	}
+	if assignment.TPS == nil || assignment.TPS.ID == tpsID {
+		return nil
+	}
+	return &NotAssignedError{Assigned: *assignment}
+}
+
+// RecordOverride logs an operator letting a voter check in outside their
+// assigned TPS
+func (s *AllocationService) RecordOverride(ctx context.Context, o AssignmentOverride) error {
+	if strings.TrimSpace(o.Reason) == "" {
+		return ErrOverrideReasonRequired
+	}
+	return s.repo.RecordAssignmentOverride(ctx, o)
+}
+
+func (s *AllocationService) notify(ctx context.Context, electionID int64, voterIDs []int64) {
+	if s.mailer == nil {
+		return
+	}
+	notices, err := s.repo.ListAssignmentNotices(ctx, electionID, voterIDs)
+	if err != nil {
+		slog.Error("tps allocation: failed to load notices", "election_id", electionID, "error", err)
+		return
+	}
+
+	var sent []int64
+	for _, n := range notices {
+		if n.Email == "" || n.TPS == nil {
+			continue
+		}
+		if err := s.mailer.Send(ctx, assignmentMessage(n)); err != nil {
+			slog.Warn("tps allocation: failed to send notice", "voter_id", n.VoterID, "error", err)
+			continue
+		}
+		sent = append(sent, n.VoterID)
+	}
+	if len(sent) > 0 {
+		if err := s.repo.MarkAssignmentsNotified(ctx, electionID, sent); err != nil {
+			slog.Error("tps allocation: failed to mark notified", "election_id", electionID, "error", err)
+		}
+	}
+}
+
+func assignmentMessage(n AssignmentNotice) mail.Message {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Halo %s,\n\n", n.VoterName)
+	fmt.Fprintf(&b, "Lokasi pemungutan suara Anda untuk %s:\n\n", n.ElectionName)
+	fmt.Fprintf(&b, "TPS    : %s - %s\n", n.TPS.Code, n.TPS.Name)
+	if n.Location != "" {
+		fmt.Fprintf(&b, "Lokasi : %s\n", n.Location)
+	}
+	if n.VotingDate != nil {
+		fmt.Fprintf(&b, "Tanggal: %s\n", n.VotingDate.Format("02-01-2006"))
+	}
+	switch {
+	case n.Slot != nil:
+		fmt.Fprintf(&b, "Waktu  : %s - %s\n", n.Slot.Start, n.Slot.End)
+	case n.OpenTime != "" && n.CloseTime != "":
+		fmt.Fprintf(&b, "Waktu  : %s - %s\n", n.OpenTime, n.CloseTime)
+	}
+	b.WriteString("\nSilakan datang ke TPS tersebut dan scan QR TPS untuk check-in.\n")
+	b.WriteString("Jika lokasi berubah, Anda akan menerima email baru.\n")
+
+	return mail.Message{
+		To:      n.Email,
+		Subject: fmt.Sprintf("Lokasi TPS Anda - %s", n.ElectionName),
+		Body:    b.String(),
+	}
+}
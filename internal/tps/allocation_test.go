@@ -0,0 +1,312 @@
+package tps_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"pemira-api/internal/tps"
+)
+
+func int64p(v int64) *int64 { return &v }
+
+func strp(s string) *string { return &s }
+
+func site(id int64, faculty *int64, capacity int) tps.AllocationSite {
+	return tps.AllocationSite{
+		TPSID:     id,
+		Code:      fmt.Sprintf("TPS%02d", id),
+		Status:    tps.StatusActive,
+		FacultyID: faculty,
+		Capacity:  capacity,
+		OpenTime:  "08:00",
+		CloseTime: "12:00",
+	}
+}
+
+func votersOf(n int, faculty *int64) []tps.AllocationVoter {
+	voters := make([]tps.AllocationVoter, n)
+	for i := range voters {
+		voters[i] = tps.AllocationVoter{VoterID: int64(i + 1), FacultyID: faculty, Source: tps.AssignmentAuto}
+	}
+	return voters
+}
+
+// applyPlan returns the voters as they would be after the plan is written
+func applyPlan(voters []tps.AllocationVoter, plan *tps.AllocationPlan) []tps.AllocationVoter {
+	out := append([]tps.AllocationVoter(nil), voters...)
+	index := make(map[int64]int)
+	for i, v := range out {
+		index[v.VoterID] = i
+	}
+	for _, c := range plan.Changes {
+		v := &out[index[c.VoterID]]
+		v.TPSID = c.ToTPSID
+		v.Source = c.Source
+		v.SlotStart = nil
+		if c.Slot != nil {
+			v.SlotStart = strp(c.Slot.Start)
+		}
+	}
+	return out
+}
+
+func loads(plan *tps.AllocationPlan) map[int64]int {
+	out := make(map[int64]int)
+	for _, s := range plan.Sites {
+		out[s.TPSID] = s.Assigned
+	}
+	return out
+}
+
+func TestBuildTimeSlots(t *testing.T) {
+	slots := tps.BuildTimeSlots("08:00:00", "10:15", 60)
+	want := []tps.TimeSlot{{"08:00", "09:00"}, {"09:00", "10:00"}, {"10:00", "10:15"}}
+	if len(slots) != len(want) {
+		t.Fatalf("got %v", slots)
+	}
+	for i := range want {
+		if slots[i] != want[i] {
+			t.Errorf("slot %d = %v, want %v", i, slots[i], want[i])
+		}
+	}
+
+	if got := tps.BuildTimeSlots("08:00", "12:00", 0); got != nil {
+		t.Errorf("slots disabled, got %v", got)
+	}
+	if got := tps.BuildTimeSlots("12:00", "08:00", 30); got != nil {
+		t.Errorf("inverted hours, got %v", got)
+	}
+}
+
+func TestPlanAllocation_FacultyRule(t *testing.T) {
+	teknik, hukum, ekonomi := int64p(1), int64p(2), int64p(3)
+	sites := []tps.AllocationSite{site(1, teknik, 100), site(2, hukum, 100), site(3, nil, 100)}
+
+	voters := append(votersOf(4, teknik), votersOf(3, ekonomi)...)
+	for i := range voters {
+		voters[i].VoterID = int64(i + 1)
+	}
+
+	plan := tps.PlanAllocation(sites, voters, tps.AllocationModeFull, 0)
+	got := loads(plan)
+	if got[1] != 4 || got[2] != 0 || got[3] != 3 {
+		t.Fatalf("loads = %v", got)
+	}
+	if plan.NewlyAssigned != 7 || plan.Unassigned != 0 {
+		t.Fatalf("plan = %+v", plan)
+	}
+}
+
+func TestPlanAllocation_CapacityProportion(t *testing.T) {
+	sites := []tps.AllocationSite{site(1, nil, 300), site(2, nil, 100)}
+	plan := tps.PlanAllocation(sites, votersOf(40, nil), tps.AllocationModeFull, 0)
+
+	got := loads(plan)
+	if got[1] != 30 || got[2] != 10 {
+		t.Fatalf("loads = %v", got)
+	}
+	if plan.Overflow != 0 {
+		t.Fatalf("overflow = %d", plan.Overflow)
+	}
+
+	small := []tps.AllocationSite{site(1, nil, 5)}
+	if plan := tps.PlanAllocation(small, votersOf(8, nil), tps.AllocationModeFull, 0); plan.Overflow != 3 {
+		t.Fatalf("overflow = %d, want 3", plan.Overflow)
+	}
+}
+
+func TestPlanAllocation_AddTPSMovesFewVoters(t *testing.T) {
+	sites := []tps.AllocationSite{site(1, nil, 100), site(2, nil, 100)}
+	voters := votersOf(20, nil)
+	voters = applyPlan(voters, tps.PlanAllocation(sites, voters, tps.AllocationModeFill, 0))
+
+	// A third TPS of the same size takes a third of the voters
+	sites = append(sites, site(3, nil, 100))
+	plan := tps.PlanAllocation(sites, voters, tps.AllocationModeFill, 0)
+	got := loads(plan)
+	if got[1]+got[2]+got[3] != 20 || got[3] < 6 || got[3] > 7 {
+		t.Fatalf("loads = %v", got)
+	}
+	if plan.Moved != got[3] || plan.NewlyAssigned != 0 {
+		t.Fatalf("moved %d voters for a new TPS holding %d", plan.Moved, got[3])
+	}
+	for _, c := range plan.Changes {
+		if *c.ToTPSID != 3 {
+			t.Fatalf("voter %d moved to %d, only moves to the new TPS were expected", c.VoterID, *c.ToTPSID)
+		}
+	}
+
+	// Planning again changes nothing
+	voters = applyPlan(voters, plan)
+	if again := tps.PlanAllocation(sites, voters, tps.AllocationModeFill, 0); len(again.Changes) != 0 {
+		t.Fatalf("stable plan produced %d changes", len(again.Changes))
+	}
+}
+
+func TestPlanAllocation_CloseTPS(t *testing.T) {
+	sites := []tps.AllocationSite{site(1, nil, 100), site(2, nil, 100), site(3, nil, 100)}
+	voters := votersOf(30, nil)
+	voters = applyPlan(voters, tps.PlanAllocation(sites, voters, tps.AllocationModeFill, 0))
+
+	sites[1].Status = tps.StatusClosed
+	plan := tps.PlanAllocation(sites, voters, tps.AllocationModeFill, 0)
+	got := loads(plan)
+	if _, ok := got[2]; ok {
+		t.Fatal("closed TPS still receives voters")
+	}
+	if got[1] != 15 || got[3] != 15 {
+		t.Fatalf("loads = %v", got)
+	}
+	if plan.Moved != 10 {
+		t.Fatalf("moved = %d, want only the 10 voters of the closed TPS", plan.Moved)
+	}
+
+	// Closing every TPS leaves voters unassigned
+	for i := range sites {
+		sites[i].Status = tps.StatusClosed
+	}
+	plan = tps.PlanAllocation(sites, voters, tps.AllocationModeFill, 0)
+	if plan.Unassigned != 30 {
+		t.Fatalf("unassigned = %d", plan.Unassigned)
+	}
+}
+
+func TestPlanAllocation_ManualAssignmentIsKept(t *testing.T) {
+	sites := []tps.AllocationSite{site(1, nil, 100), site(2, nil, 100)}
+	voters := votersOf(10, nil)
+	for i := 0; i < 8; i++ {
+		voters[i].TPSID = int64p(1)
+		voters[i].Source = tps.AssignmentManual
+	}
+
+	plan := tps.PlanAllocation(sites, voters, tps.AllocationModeFull, 0)
+	got := loads(plan)
+	if got[1] != 8 || got[2] != 2 {
+		t.Fatalf("loads = %v", got)
+	}
+	for _, c := range plan.Changes {
+		if c.VoterID <= 8 {
+			t.Fatalf("manually assigned voter %d changed", c.VoterID)
+		}
+	}
+
+	// A manual assignment to a closed TPS is released
+	sites[0].Status = tps.StatusClosed
+	plan = tps.PlanAllocation(sites, voters, tps.AllocationModeFull, 0)
+	if got := loads(plan); got[2] != 10 {
+		t.Fatalf("loads = %v", got)
+	}
+	for _, c := range plan.Changes {
+		if c.Source != tps.AssignmentAuto {
+			t.Fatalf("voter %d kept source %s", c.VoterID, c.Source)
+		}
+	}
+}
+
+func TestPlanAllocation_TimeSlots(t *testing.T) {
+	sites := []tps.AllocationSite{site(1, nil, 100)}
+	voters := votersOf(10, nil)
+	voters[0].TPSID = int64p(1)
+	voters[0].SlotStart = strp("11:00:00")
+
+	plan := tps.PlanAllocation(sites, voters, tps.AllocationModeFill, 60)
+	slots := plan.Sites[0].Slots
+	if len(slots) != 4 {
+		t.Fatalf("slots = %v", slots)
+	}
+	for _, s := range slots {
+		if s.Assigned < 2 || s.Assigned > 3 {
+			t.Fatalf("uneven slots: %v", slots)
+		}
+	}
+	for _, c := range plan.Changes {
+		if c.VoterID == 1 {
+			t.Fatalf("voter with a valid slot was changed to %v", c.Slot)
+		}
+		if c.Slot == nil {
+			t.Fatalf("voter %d got no slot", c.VoterID)
+		}
+	}
+}
+
+// allocationRepo is an in-memory AllocationRepository for service tests
+type allocationRepo struct {
+	tps.AllocationRepository
+	settings    tps.AllocationSettings
+	assignments map[int64]*tps.VoterAssignment
+	overrides   []tps.AssignmentOverride
+}
+
+func (r *allocationRepo) GetAllocationSettings(ctx context.Context, electionID int64) (*tps.AllocationSettings, error) {
+	s := r.settings
+	return &s, nil
+}
+
+func (r *allocationRepo) GetVoterAssignment(ctx context.Context, electionID, voterID int64) (*tps.VoterAssignment, error) {
+	a, ok := r.assignments[voterID]
+	if !ok {
+		return nil, tps.ErrNotTPSVoter
+	}
+	return a, nil
+}
+
+func (r *allocationRepo) RecordAssignmentOverride(ctx context.Context, o tps.AssignmentOverride) error {
+	r.overrides = append(r.overrides, o)
+	return nil
+}
+
+func TestCheckAssignment(t *testing.T) {
+	ctx := context.Background()
+	repo := &allocationRepo{
+		assignments: map[int64]*tps.VoterAssignment{
+			1: {VoterID: 1, TPS: &tps.TPSInfo{ID: 10, Code: "TPS01", Name: "Gedung A"}},
+			2: {VoterID: 2},
+		},
+	}
+	svc := tps.NewAllocationService(repo)
+
+	// Not enforced yet
+	if err := svc.CheckAssignment(ctx, 1, 1, 11); err != nil {
+		t.Fatalf("unenforced: %v", err)
+	}
+
+	repo.settings = tps.AllocationSettings{Enabled: true, EnforceAssignment: true}
+	if err := svc.CheckAssignment(ctx, 1, 1, 10); err != nil {
+		t.Fatalf("assigned TPS: %v", err)
+	}
+
+	err := svc.CheckAssignment(ctx, 1, 1, 11)
+	var notAssigned *tps.NotAssignedError
+	if !errors.As(err, &notAssigned) || !errors.Is(err, tps.ErrTPSNotAssigned) {
+		t.Fatalf("other TPS: err = %v", err)
+	}
+	if notAssigned.Assigned.TPS.Code != "TPS01" {
+		t.Fatalf("assigned = %+v", notAssigned.Assigned)
+	}
+	if code, status := tps.GetErrorCode(err); code != "TPS_NOT_ASSIGNED" || status != 403 {
+		t.Fatalf("error code = %s %d", code, status)
+	}
+
+	// Unassigned and non-TPS voters may check in anywhere
+	if err := svc.CheckAssignment(ctx, 1, 2, 11); err != nil {
+		t.Fatalf("unassigned: %v", err)
+	}
+	if err := svc.CheckAssignment(ctx, 1, 3, 11); err != nil {
+		t.Fatalf("not a TPS voter: %v", err)
+	}
+
+	// A nil service never refuses
+	var none *tps.AllocationService
+	if err := none.CheckAssignment(ctx, 1, 1, 11); err != nil {
+		t.Fatalf("nil service: %v", err)
+	}
+
+	if err := svc.RecordOverride(ctx, tps.AssignmentOverride{ElectionID: 1, VoterID: 1, CheckinTPSID: 11}); !errors.Is(err, tps.ErrOverrideReasonRequired) {
+		t.Fatalf("override without reason: %v", err)
+	}
+	if len(repo.overrides) != 0 {
+		t.Fatal("override without reason was recorded")
+	}
+}
@@ -0,0 +1,209 @@
+package tps
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"pemira-api/internal/telemetry"
+)
+
+const (
+	// DefaultCheckinPendingTTL is how long a scanned check-in waits for
+	// approval when neither the election nor the server sets a TTL
+	DefaultCheckinPendingTTL = 30 * time.Minute
+	// DefaultCheckinApprovedTTL is how long an approved voter has to vote
+	DefaultCheckinApprovedTTL = 15 * time.Minute
+
+	// maxCheckinTTLMinutes bounds per-election TTLs to a single voting day
+	maxCheckinTTLMinutes = 24 * 60
+	// sweepBatchSize is how many check-ins are expired per transaction
+	sweepBatchSize = 500
+)
+
+// CheckinTTL is how long check-ins stay PENDING or APPROVED before they
+// expire
+type CheckinTTL struct {
+	Pending  time.Duration
+	Approved time.Duration
+}
+
+// CheckinTTLSettings are the TTLs one election sets. 0 uses the server
+// default.
+type CheckinTTLSettings struct {
+	ElectionID         int64      `json:"election_id"`
+	PendingTTLMinutes  int        `json:"pending_ttl_minutes"`
+	ApprovedTTLMinutes int        `json:"approved_ttl_minutes"`
+	UpdatedAt          *time.Time `json:"updated_at,omitempty"`
+}
+
+// Effective resolves the settings against the server defaults
+func (s CheckinTTLSettings) Effective(defaults CheckinTTL) CheckinTTL {
+	ttl := defaults
+	if s.PendingTTLMinutes > 0 {
+		ttl.Pending = time.Duration(s.PendingTTLMinutes) * time.Minute
+	}
+	if s.ApprovedTTLMinutes > 0 {
+		ttl.Approved = time.Duration(s.ApprovedTTLMinutes) * time.Minute
+	}
+	return ttl
+}
+
+// ExpiredCheckin is a check-in the sweeper moved to EXPIRED
+type ExpiredCheckin struct {
+	ID             int64
+	ElectionID     int64
+	TPSID          int64
+	VoterID        int64
+	PreviousStatus string
+	ExpiredAt      time.Time
+}
+
+// ExpiredCheckinCounts counts the expired check-ins of a TPS by the status
+// they expired from
+type ExpiredCheckinCounts struct {
+	Total    int `json:"total"`
+	Pending  int `json:"pending"`
+	Approved int `json:"approved"`
+}
+
+// SweepResult counts the check-ins expired by one sweep
+type SweepResult struct {
+	Pending  int `json:"pending"`
+	Approved int `json:"approved"`
+}
+
+type CheckinLifecycleRepository interface {
+	// GetCheckinTTLSettings returns zero TTLs when the election has none saved
+	GetCheckinTTLSettings(ctx context.Context, electionID int64) (*CheckinTTLSettings, error)
+	SaveCheckinTTLSettings(ctx context.Context, s *CheckinTTLSettings, updatedBy *int64) error
+	// ExpireStaleCheckins moves up to limit stale PENDING and APPROVED
+	// check-ins to EXPIRED and records each in the audit trail
+	ExpireStaleCheckins(ctx context.Context, defaults CheckinTTL, limit int) ([]ExpiredCheckin, error)
+}
+
+// CheckinNotifier receives check-in status changes, e.g. the TPS queue
+// websocket hub
+type CheckinNotifier interface {
+	BroadcastCheckinUpdated(tpsID, checkinID int64, status string)
+}
+
+// CheckinLifecycleService expires check-ins that were never approved or
+// never used to vote, so they stop blocking new scans.
+type CheckinLifecycleService struct {
+	repo     CheckinLifecycleRepository
+	defaults CheckinTTL
+	notifier CheckinNotifier
+}
+
+func NewCheckinLifecycleService(repo CheckinLifecycleRepository, defaults CheckinTTL) *CheckinLifecycleService {
+	if defaults.Pending <= 0 {
+		defaults.Pending = DefaultCheckinPendingTTL
+	}
+	if defaults.Approved <= 0 {
+		defaults.Approved = DefaultCheckinApprovedTTL
+	}
+	return &CheckinLifecycleService{repo: repo, defaults: defaults}
+}
+
+// SetNotifier sets where expiries are announced. nil disables it.
+func (s *CheckinLifecycleService) SetNotifier(n CheckinNotifier) {
+	s.notifier = n
+}
+
+func (s *CheckinLifecycleService) Defaults() CheckinTTL {
+	return s.defaults
+}
+
+func (s *CheckinLifecycleService) GetSettings(ctx context.Context, electionID int64) (*CheckinTTLSettings, error) {
+	return s.repo.GetCheckinTTLSettings(ctx, electionID)
+}
+
+func (s *CheckinLifecycleService) UpdateSettings(ctx context.Context, electionID int64, req UpdateCheckinTTLRequest, actorID *int64) (*CheckinTTLSettings, error) {
+	settings, err := s.repo.GetCheckinTTLSettings(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+	if req.PendingTTLMinutes != nil {
+		if *req.PendingTTLMinutes < 0 || *req.PendingTTLMinutes > maxCheckinTTLMinutes {
+			return nil, ErrInvalidCheckinTTL
+		}
+		settings.PendingTTLMinutes = *req.PendingTTLMinutes
+	}
+	if req.ApprovedTTLMinutes != nil {
+		if *req.ApprovedTTLMinutes < 0 || *req.ApprovedTTLMinutes > maxCheckinTTLMinutes {
+			return nil, ErrInvalidCheckinTTL
+		}
+		settings.ApprovedTTLMinutes = *req.ApprovedTTLMinutes
+	}
+	settings.ElectionID = electionID
+
+	if err := s.repo.SaveCheckinTTLSettings(ctx, settings, actorID); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// ApprovedTTL is how long a check-in approved now stays valid for voting.
+// A nil service or a failed lookup falls back to the default.
+func (s *CheckinLifecycleService) ApprovedTTL(ctx context.Context, electionID int64) time.Duration {
+	if s == nil {
+		return DefaultCheckinApprovedTTL
+	}
+	settings, err := s.repo.GetCheckinTTLSettings(ctx, electionID)
+	if err != nil {
+		slog.Error("tps checkin: failed to load ttl settings", "election_id", electionID, "error", err)
+		return s.defaults.Approved
+	}
+	return settings.Effective(s.defaults).Approved
+}
+
+// Sweep expires every stale check-in, in batches, and announces each one
+func (s *CheckinLifecycleService) Sweep(ctx context.Context) (*SweepResult, error) {
+	result := &SweepResult{}
+	for {
+		expired, err := s.repo.ExpireStaleCheckins(ctx, s.defaults, sweepBatchSize)
+		if err != nil {
+			return result, err
+		}
+		for _, c := range expired {
+			switch c.PreviousStatus {
+			case CheckinStatusPending:
+				result.Pending++
+			case CheckinStatusApproved:
+				result.Approved++
+			}
+			telemetry.CheckinRecorded(CheckinStatusExpired)
+			if s.notifier != nil {
+				s.notifier.BroadcastCheckinUpdated(c.TPSID, c.ID, CheckinStatusExpired)
+			}
+		}
+		if len(expired) < sweepBatchSize {
+			return result, nil
+		}
+	}
+}
+
+// Run sweeps every interval until ctx is done. interval <= 0 disables it.
+func (s *CheckinLifecycleService) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			result, err := s.Sweep(ctx)
+			if err != nil {
+				slog.Error("tps checkin sweep failed", "error", err)
+			}
+			if result.Pending+result.Approved > 0 {
+				slog.Info("tps checkins expired", "pending", result.Pending, "approved", result.Approved)
+			}
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package tps
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"pemira-api/internal/errcatalog"
+	"pemira-api/internal/http/response"
+)
+
+type CheckinLifecycleHandler struct {
+	svc *CheckinLifecycleService
+}
+
+func NewCheckinLifecycleHandler(svc *CheckinLifecycleService) *CheckinLifecycleHandler {
+	return &CheckinLifecycleHandler{svc: svc}
+}
+
+// GET /admin/elections/{electionID}/tps/checkin-settings
+func (h *CheckinLifecycleHandler) GetSettings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	electionID, ok := allocationElectionID(w, r)
+	if !ok {
+		return
+	}
+
+	settings, err := h.svc.GetSettings(ctx, electionID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	response.Success(w, http.StatusOK, h.settingsResponse(settings))
+}
+
+// PUT /admin/elections/{electionID}/tps/checkin-settings
+func (h *CheckinLifecycleHandler) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	electionID, ok := allocationElectionID(w, r)
+	if !ok {
+		return
+	}
+
+	var req UpdateCheckinTTLRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
+		return
+	}
+
+	settings, err := h.svc.UpdateSettings(ctx, electionID, req, actorID(ctx))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	response.Success(w, http.StatusOK, h.settingsResponse(settings))
+}
+
+// settingsResponse adds the TTLs in effect, after server defaults
+func (h *CheckinLifecycleHandler) settingsResponse(s *CheckinTTLSettings) map[string]interface{} {
+	ttl := s.Effective(h.svc.Defaults())
+	return map[string]interface{}{
+		"election_id":          s.ElectionID,
+		"pending_ttl_minutes":  s.PendingTTLMinutes,
+		"approved_ttl_minutes": s.ApprovedTTLMinutes,
+		"updated_at":           s.UpdatedAt,
+		"effective": map[string]int{
+			"pending_ttl_minutes":  int(ttl.Pending / time.Minute),
+			"approved_ttl_minutes": int(ttl.Approved / time.Minute),
+		},
+	}
+}
+
+func (h *CheckinLifecycleHandler) handleError(w http.ResponseWriter, err error) {
+	if response.DomainError(w, err) {
+		return
+	}
+	slog.Error("tps checkin settings handler error", "error", err)
+	response.InternalServerError(w, "INTERNAL_ERROR", errcatalog.InternalError)
+}
@@ -0,0 +1,126 @@
+package tps
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"pemira-api/internal/audit"
+)
+
+type PgCheckinLifecycleRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPgCheckinLifecycleRepository(db *pgxpool.Pool) *PgCheckinLifecycleRepository {
+	return &PgCheckinLifecycleRepository{db: db}
+}
+
+func (r *PgCheckinLifecycleRepository) GetCheckinTTLSettings(ctx context.Context, electionID int64) (*CheckinTTLSettings, error) {
+	s := CheckinTTLSettings{ElectionID: electionID}
+	err := r.db.QueryRow(ctx, `
+		SELECT pending_ttl_minutes, approved_ttl_minutes, updated_at
+		FROM tps_checkin_settings
+		WHERE election_id = $1
+	`, electionID).Scan(&s.PendingTTLMinutes, &s.ApprovedTTLMinutes, &s.UpdatedAt)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *PgCheckinLifecycleRepository) SaveCheckinTTLSettings(ctx context.Context, s *CheckinTTLSettings, updatedBy *int64) error {
+	return r.db.QueryRow(ctx, `
+		INSERT INTO tps_checkin_settings (election_id, pending_ttl_minutes, approved_ttl_minutes, updated_by)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (election_id) DO UPDATE SET
+			pending_ttl_minutes = EXCLUDED.pending_ttl_minutes,
+			approved_ttl_minutes = EXCLUDED.approved_ttl_minutes,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = NOW()
+		RETURNING updated_at
+	`, s.ElectionID, s.PendingTTLMinutes, s.ApprovedTTLMinutes, updatedBy).Scan(&s.UpdatedAt)
+}
+
+// ExpireStaleCheckins expires PENDING check-ins scanned longer ago than the
+// pending TTL and APPROVED ones past expires_at (or, for rows approved
+// without one, past approved_at plus the approved TTL). Rows locked by a
+// concurrent approval or vote are skipped until the next sweep.
+func (r *PgCheckinLifecycleRepository) ExpireStaleCheckins(ctx context.Context, defaults CheckinTTL, limit int) ([]ExpiredCheckin, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `
+		WITH stale AS (
+			SELECT c.id, c.status::text AS previous_status
+			FROM tps_checkins c
+			LEFT JOIN tps_checkin_settings s ON s.election_id = c.election_id
+			WHERE (
+				c.status = 'PENDING'
+				AND c.scan_at < NOW() - make_interval(secs => COALESCE(NULLIF(s.pending_ttl_minutes, 0) * 60, $1::int))
+			) OR (
+				c.status = 'APPROVED'
+				AND COALESCE(
+					c.expires_at,
+					c.approved_at + make_interval(secs => COALESCE(NULLIF(s.approved_ttl_minutes, 0) * 60, $2::int))
+				) < NOW()
+			)
+			ORDER BY c.id
+			LIMIT $3
+			FOR UPDATE OF c SKIP LOCKED
+		)
+		UPDATE tps_checkins c
+		SET status = 'EXPIRED', expired_at = NOW()
+		FROM stale
+		WHERE c.id = stale.id
+		RETURNING c.id, c.election_id, c.tps_id, c.voter_id, stale.previous_status, c.expired_at
+	`, int(defaults.Pending.Seconds()), int(defaults.Approved.Seconds()), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var expired []ExpiredCheckin
+	for rows.Next() {
+		var e ExpiredCheckin
+		if err := rows.Scan(&e.ID, &e.ElectionID, &e.TPSID, &e.VoterID, &e.PreviousStatus, &e.ExpiredAt); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		expired = append(expired, e)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(expired) == 0 {
+		return nil, nil
+	}
+
+	entries := make([]audit.Entry, len(expired))
+	for i, e := range expired {
+		entries[i] = audit.Entry{
+			Action:     "TPS_CHECKIN_EXPIRED",
+			EntityType: "TPS_CHECKIN",
+			EntityID:   e.ID,
+			Metadata: map[string]any{
+				"election_id":     e.ElectionID,
+				"tps_id":          e.TPSID,
+				"voter_id":        e.VoterID,
+				"previous_status": e.PreviousStatus,
+			},
+		}
+	}
+	if err := audit.Write(ctx, tx, entries...); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return expired, nil
+}
@@ -0,0 +1,130 @@
+package tps_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"pemira-api/internal/tps"
+)
+
+// lifecycleRepo is an in-memory CheckinLifecycleRepository. stale is handed
+// out limit check-ins at a time, like the database would.
+type lifecycleRepo struct {
+	settings tps.CheckinTTLSettings
+	stale    []tps.ExpiredCheckin
+	sweeps   int
+	defaults tps.CheckinTTL
+}
+
+func (r *lifecycleRepo) GetCheckinTTLSettings(ctx context.Context, electionID int64) (*tps.CheckinTTLSettings, error) {
+	s := r.settings
+	s.ElectionID = electionID
+	return &s, nil
+}
+
+func (r *lifecycleRepo) SaveCheckinTTLSettings(ctx context.Context, s *tps.CheckinTTLSettings, updatedBy *int64) error {
+	r.settings = *s
+	return nil
+}
+
+func (r *lifecycleRepo) ExpireStaleCheckins(ctx context.Context, defaults tps.CheckinTTL, limit int) ([]tps.ExpiredCheckin, error) {
+	r.sweeps++
+	r.defaults = defaults
+	n := min(limit, len(r.stale))
+	batch := r.stale[:n]
+	r.stale = r.stale[n:]
+	return batch, nil
+}
+
+type notifierFunc func(tpsID, checkinID int64, status string)
+
+func (f notifierFunc) BroadcastCheckinUpdated(tpsID, checkinID int64, status string) {
+	f(tpsID, checkinID, status)
+}
+
+func TestCheckinTTLSettings_Effective(t *testing.T) {
+	defaults := tps.CheckinTTL{Pending: 30 * time.Minute, Approved: 15 * time.Minute}
+
+	if got := (tps.CheckinTTLSettings{}).Effective(defaults); got != defaults {
+		t.Fatalf("unset settings = %+v", got)
+	}
+	got := tps.CheckinTTLSettings{PendingTTLMinutes: 5}.Effective(defaults)
+	if got.Pending != 5*time.Minute || got.Approved != 15*time.Minute {
+		t.Fatalf("pending override = %+v", got)
+	}
+}
+
+func TestCheckinLifecycle_Settings(t *testing.T) {
+	ctx := context.Background()
+	repo := &lifecycleRepo{}
+	svc := tps.NewCheckinLifecycleService(repo, tps.CheckinTTL{})
+
+	if got := svc.Defaults(); got.Pending != tps.DefaultCheckinPendingTTL || got.Approved != tps.DefaultCheckinApprovedTTL {
+		t.Fatalf("defaults = %+v", got)
+	}
+	if ttl := svc.ApprovedTTL(ctx, 1); ttl != tps.DefaultCheckinApprovedTTL {
+		t.Fatalf("approved ttl = %v", ttl)
+	}
+
+	ten := 10
+	if _, err := svc.UpdateSettings(ctx, 1, tps.UpdateCheckinTTLRequest{ApprovedTTLMinutes: &ten}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if ttl := svc.ApprovedTTL(ctx, 1); ttl != 10*time.Minute {
+		t.Fatalf("approved ttl = %v, want 10m", ttl)
+	}
+
+	for _, bad := range []int{-1, 24*60 + 1} {
+		if _, err := svc.UpdateSettings(ctx, 1, tps.UpdateCheckinTTLRequest{PendingTTLMinutes: &bad}, nil); !errors.Is(err, tps.ErrInvalidCheckinTTL) {
+			t.Fatalf("pending ttl %d: err = %v", bad, err)
+		}
+	}
+	if repo.settings.ApprovedTTLMinutes != 10 || repo.settings.PendingTTLMinutes != 0 {
+		t.Fatalf("saved settings = %+v", repo.settings)
+	}
+
+	// A nil service keeps the historical 15 minutes
+	var none *tps.CheckinLifecycleService
+	if ttl := none.ApprovedTTL(ctx, 1); ttl != 15*time.Minute {
+		t.Fatalf("nil service ttl = %v", ttl)
+	}
+}
+
+func TestCheckinLifecycle_Sweep(t *testing.T) {
+	repo := &lifecycleRepo{}
+	for i := 0; i < 501; i++ {
+		status := tps.CheckinStatusPending
+		if i%3 == 0 {
+			status = tps.CheckinStatusApproved
+		}
+		repo.stale = append(repo.stale, tps.ExpiredCheckin{ID: int64(i + 1), TPSID: 7, PreviousStatus: status})
+	}
+
+	var events int
+	svc := tps.NewCheckinLifecycleService(repo, tps.CheckinTTL{Pending: time.Hour})
+	svc.SetNotifier(notifierFunc(func(tpsID, checkinID int64, status string) {
+		if tpsID != 7 || status != tps.CheckinStatusExpired {
+			t.Fatalf("event for TPS %d with status %s", tpsID, status)
+		}
+		events++
+	}))
+
+	result, err := svc.Sweep(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Approved != 167 || result.Pending != 334 {
+		t.Fatalf("result = %+v", result)
+	}
+	if events != 501 {
+		t.Fatalf("events = %d", events)
+	}
+	if repo.sweeps != 2 {
+		t.Fatalf("sweeps = %d, want a second batch after a full one", repo.sweeps)
+	}
+	if repo.defaults.Pending != time.Hour || repo.defaults.Approved != tps.DefaultCheckinApprovedTTL {
+		t.Fatalf("defaults passed to the repository = %+v", repo.defaults)
+	}
+}
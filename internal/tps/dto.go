@@ -12,6 +12,7 @@ type CreateTPSRequest struct {
 	OpenTime         string  `json:"open_time" validate:"required"`
 	CloseTime        string  `json:"close_time" validate:"required"`
 	CapacityEstimate int     `json:"capacity_estimate" validate:"min=0"`
+	AreaFacultyID    *int64  `json:"area_faculty_id,omitempty"`
 	Status           string  `json:"status" validate:"required,oneof=DRAFT ACTIVE CLOSED"`
 	PICName          *string `json:"pic_name,omitempty"`
 	PICPhone         *string `json:"pic_phone,omitempty"`
@@ -19,16 +20,18 @@ type CreateTPSRequest struct {
 }
 
 type UpdateTPSRequest struct {
-	Name             string  `json:"name" validate:"required"`
-	Location         string  `json:"location" validate:"required"`
-	VotingDate       string  `json:"voting_date" validate:"required"`
-	OpenTime         string  `json:"open_time" validate:"required"`
-	CloseTime        string  `json:"close_time" validate:"required"`
-	CapacityEstimate int     `json:"capacity_estimate" validate:"min=0"`
-	Status           string  `json:"status" validate:"required,oneof=DRAFT ACTIVE CLOSED"`
-	PICName          *string `json:"pic_name,omitempty"`
-	PICPhone         *string `json:"pic_phone,omitempty"`
-	Notes            *string `json:"notes,omitempty"`
+	Name             string `json:"name" validate:"required"`
+	Location         string `json:"location" validate:"required"`
+	VotingDate       string `json:"voting_date" validate:"required"`
+	OpenTime         string `json:"open_time" validate:"required"`
+	CloseTime        string `json:"close_time" validate:"required"`
+	CapacityEstimate int    `json:"capacity_estimate" validate:"min=0"`
+	// AreaFacultyID is left unchanged when omitted; 0 clears it
+	AreaFacultyID *int64  `json:"area_faculty_id,omitempty"`
+	Status        string  `json:"status" validate:"required,oneof=DRAFT ACTIVE CLOSED"`
+	PICName       *string `json:"pic_name,omitempty"`
+	PICPhone      *string `json:"pic_phone,omitempty"`
+	Notes         *string `json:"notes,omitempty"`
 }
 
 type AssignPanitiaRequest struct {
@@ -136,6 +139,11 @@ type CheckinStatusResponse struct {
 	ScanAt           *time.Time `json:"scan_at,omitempty"`
 	ApprovedAt       *time.Time `json:"approved_at,omitempty"`
 	ExpiresAt        *time.Time `json:"expires_at,omitempty"`
+	// Queue: WAITING with a position and wait estimate, or CALLED to a booth
+	QueueState           *string `json:"queue_state,omitempty"`
+	QueuePosition        *int    `json:"queue_position,omitempty"`
+	BoothNumber          *int    `json:"booth_number,omitempty"`
+	EstimatedWaitMinutes *int    `json:"estimated_wait_minutes,omitempty"`
 }
 
 // TPS Panel DTOs
@@ -161,16 +169,37 @@ type VoterInfo struct {
 	AcademicStatus string `json:"academic_status"`
 }
 
+// PanelCheckinRequest is the body of a panel check-in. The scan route reads
+// registration_qr_payload, the manual route registration_code; both fall back
+// to qr_token and then nim.
+type PanelCheckinRequest struct {
+	RegistrationQRPayload string `json:"registration_qr_payload"`
+	RegistrationCode      string `json:"registration_code"`
+	QRToken               string `json:"qr_token"` // alias for registration code
+	NIM                   string `json:"nim"`      // manual identifier
+	Override              bool   `json:"override"` // check in outside the assigned TPS
+	OverrideReason        string `json:"override_reason"`
+}
+
+// CreateCheckinRequest is the body of POST /tps/{tpsID}/checkins
+type CreateCheckinRequest struct {
+	QRPayload        string `json:"qr_payload"`
+	RegistrationCode string `json:"registration_code"`
+	Override         bool   `json:"override"`
+	OverrideReason   string `json:"override_reason"`
+}
+
 type RejectCheckinRequest struct {
 	Reason string `json:"reason" validate:"required"`
 }
 
 type ApproveCheckinResponse struct {
-	CheckinID  int64     `json:"checkin_id"`
-	Status     string    `json:"status"`
-	Voter      VoterInfo `json:"voter"`
-	TPS        TPSInfo   `json:"tps"`
-	ApprovedAt time.Time `json:"approved_at"`
+	CheckinID   int64     `json:"checkin_id"`
+	Status      string    `json:"status"`
+	Voter       VoterInfo `json:"voter"`
+	TPS         TPSInfo   `json:"tps"`
+	ApprovedAt  time.Time `json:"approved_at"`
+	BoothNumber *int      `json:"booth_number,omitempty"`
 }
 
 type RejectCheckinResponse struct {
@@ -218,6 +247,9 @@ type PanelCheckinRow struct {
 	Status     string
 	ScanAt     time.Time
 	VotedAt    *time.Time
+	// Queue position of a PENDING check-in and booth of an approved one
+	QueuePosition *int
+	BoothNumber   *int
 }
 
 type PanelTimelineRow struct {
@@ -270,3 +302,81 @@ type OperatorCreate struct {
 	Name     string
 	Email    string
 }
+
+// TPS allocation DTOs
+type UpdateAllocationSettingsRequest struct {
+	Enabled           *bool `json:"enabled,omitempty"`
+	SlotMinutes       *int  `json:"slot_minutes,omitempty"`
+	AutoRebalance     *bool `json:"auto_rebalance,omitempty"`
+	EnforceAssignment *bool `json:"enforce_assignment,omitempty"`
+	NotifyVoters      *bool `json:"notify_voters,omitempty"`
+}
+
+type ApplyAllocationRequest struct {
+	Mode string `json:"mode"`
+}
+
+type AssignVoterTPSRequest struct {
+	TPSID     int64  `json:"tps_id"`
+	SlotStart string `json:"slot_start,omitempty"`
+}
+
+// Check-in lifecycle DTOs
+type UpdateCheckinTTLRequest struct {
+	PendingTTLMinutes  *int `json:"pending_ttl_minutes,omitempty"`
+	ApprovedTTLMinutes *int `json:"approved_ttl_minutes,omitempty"`
+}
+
+// TPS queue DTOs
+type UpdateQueueSettingsRequest struct {
+	BoothCount int `json:"booth_count"`
+}
+
+// TPS schedule DTOs
+type ScheduleSessionInput struct {
+	Date      string `json:"date"`
+	OpenTime  string `json:"open_time"`
+	CloseTime string `json:"close_time"`
+}
+
+type UpdateScheduleRequest struct {
+	Sessions []ScheduleSessionInput `json:"sessions"`
+}
+
+type UpdateScheduleSettingsRequest struct {
+	Timezone string `json:"timezone"`
+}
+
+// ExtendSessionRequest extends the session open now when SessionID is nil
+type ExtendSessionRequest struct {
+	SessionID *int64 `json:"session_id,omitempty"`
+	Minutes   int    `json:"minutes"`
+	Reason    string `json:"reason"`
+}
+
+// TPS incident DTOs
+type ReportIncidentRequest struct {
+	Category    string `json:"category"`
+	Severity    string `json:"severity"`
+	Description string `json:"description"`
+	VoterID     *int64 `json:"voter_id,omitempty"`
+	CheckinID   *int64 `json:"checkin_id,omitempty"`
+}
+
+type TriageIncidentRequest struct {
+	Severity string `json:"severity,omitempty"`
+	Status   string `json:"status,omitempty"`
+}
+
+// AssignIncidentRequest unassigns the incident when AssignedTo is null
+type AssignIncidentRequest struct {
+	AssignedTo *int64 `json:"assigned_to"`
+}
+
+type ResolveIncidentRequest struct {
+	Resolution string `json:"resolution"`
+}
+
+type IncidentCommentRequest struct {
+	Body string `json:"body"`
+}
@@ -2,61 +2,174 @@ package tps
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+
+	"pemira-api/internal/errcatalog"
+	"pemira-api/internal/http/response"
 )
 
 var (
-	ErrTPSNotFound          = errors.New("TPS tidak ditemukan")
-	ErrTPSInactive          = errors.New("TPS belum/tidak aktif")
-	ErrTPSClosed            = errors.New("TPS sudah ditutup")
-	ErrQRInvalid            = errors.New("Payload QR tidak valid")
-	ErrQRRevoked            = errors.New("QR sudah tidak berlaku")
-	ErrElectionNotOpen      = errors.New("Pemilu bukan di fase voting")
-	ErrNotEligible          = errors.New("Mahasiswa bukan DPT / tidak berhak")
-	ErrAlreadyVoted         = errors.New("Mahasiswa sudah pernah voting")
-	ErrCheckinNotFound      = errors.New("Data check-in tidak ada")
-	ErrCheckinNotPending    = errors.New("Check-in bukan status PENDING")
-	ErrCheckinExpired       = errors.New("Check-in sudah kadaluarsa")
-	ErrCheckinAlreadyExists = errors.New("Check-in sudah ada")
-	ErrTPSAccessDenied      = errors.New("Panitia TPS tidak di-assign ke TPS ini")
-	ErrTPSCodeDuplicate     = errors.New("Kode TPS sudah digunakan")
-	ErrInvalidTimeFormat    = errors.New("Format waktu tidak valid")
-	ErrNotTPSVoter          = errors.New("Pemilih bukan TPS")
-	ErrTPSMismatch          = errors.New("TPS tidak sesuai")
-	ErrOperatorExists       = errors.New("Operator sudah ada")
-	ErrOperatorNotFound     = errors.New("Operator tidak ditemukan")
+	ErrTPSNotFound            = errors.New("TPS tidak ditemukan")
+	ErrTPSInactive            = errors.New("TPS belum/tidak aktif")
+	ErrTPSClosed              = errors.New("TPS sudah ditutup")
+	ErrQRInvalid              = errors.New("Payload QR tidak valid")
+	ErrQRRevoked              = errors.New("QR sudah tidak berlaku")
+	ErrElectionNotOpen        = errors.New("Pemilu bukan di fase voting")
+	ErrElectionNotFound       = errors.New("Pemilu tidak ditemukan")
+	ErrNotEligible            = errors.New("Mahasiswa bukan DPT / tidak berhak")
+	ErrAlreadyVoted           = errors.New("Mahasiswa sudah pernah voting")
+	ErrCheckinNotFound        = errors.New("Data check-in tidak ada")
+	ErrCheckinNotPending      = errors.New("Check-in bukan status PENDING")
+	ErrCheckinExpired         = errors.New("Check-in sudah kadaluarsa")
+	ErrCheckinAlreadyExists   = errors.New("Check-in sudah ada")
+	ErrTPSAccessDenied        = errors.New("Panitia TPS tidak di-assign ke TPS ini")
+	ErrTPSCodeDuplicate       = errors.New("Kode TPS sudah digunakan")
+	ErrInvalidTimeFormat      = errors.New("Format waktu tidak valid")
+	ErrNotTPSVoter            = errors.New("Pemilih bukan TPS")
+	ErrTPSMismatch            = errors.New("TPS tidak sesuai")
+	ErrOperatorExists         = errors.New("Operator sudah ada")
+	ErrOperatorNotFound       = errors.New("Operator tidak ditemukan")
+	ErrTPSNotAssigned         = errors.New("Pemilih terdaftar di TPS lain")
+	ErrInvalidTimeSlot        = errors.New("Slot waktu tidak tersedia di TPS ini")
+	ErrInvalidAllocation      = errors.New("Pengaturan alokasi TPS tidak valid")
+	ErrOverrideReasonRequired = errors.New("Alasan wajib diisi untuk check-in di luar TPS yang ditetapkan")
+	ErrInvalidCheckinTTL      = errors.New("Batas waktu check-in harus 0-1440 menit")
+	ErrInvalidBoothCount      = errors.New("Jumlah bilik suara harus 1-50")
+	ErrQueueEmpty             = errors.New("Tidak ada pemilih dalam antrean")
+	ErrTPSOutsideSession      = errors.New("TPS sedang tidak dalam jam buka")
+	ErrInvalidSchedule        = errors.New("Jadwal sesi TPS tidak valid")
+	ErrInvalidTimezone        = errors.New("Zona waktu tidak dikenal")
+	ErrInvalidExtension       = errors.New("Perpanjangan sesi harus 1-240 menit dan tidak melewati sesi berikutnya")
+	ErrNoSessionToExtend      = errors.New("Tidak ada sesi TPS hari ini yang bisa diperpanjang")
+	ErrExtensionReasonMissing = errors.New("Alasan perpanjangan sesi wajib diisi")
+	ErrIncidentNotFound       = errors.New("Insiden TPS tidak ditemukan")
+	ErrInvalidCategory        = errors.New("Kategori insiden tidak dikenal")
+	ErrInvalidSeverity        = errors.New("Tingkat insiden harus LOW, MEDIUM, HIGH atau CRITICAL")
+	ErrInvalidIncidentStatus  = errors.New("Status insiden hanya bisa diubah ke OPEN atau IN_PROGRESS")
+	ErrInvalidIncidentText    = errors.New("Teks insiden wajib diisi, maksimal 4000 karakter")
+	ErrInvalidIncidentLink    = errors.New("Pemilih atau check-in yang ditautkan tidak valid untuk TPS ini")
+	ErrInvalidAssignee        = errors.New("Petugas yang ditugaskan tidak ditemukan")
+	ErrIncidentResolved       = errors.New("Insiden sudah diselesaikan")
+	ErrResolutionMissing      = errors.New("Penyelesaian insiden wajib diisi")
+	ErrInvalidIncidentPhoto   = errors.New("Foto insiden harus berupa PNG, JPEG atau WebP")
+	ErrIncidentPhotoTooLarge  = errors.New("Ukuran foto insiden maksimal 5MB")
+	ErrIncidentPhotoNotFound  = errors.New("Insiden tidak memiliki foto")
 )
 
-type ErrorCode struct {
-	Code       string
-	HTTPStatus int
-}
-
-var errorCodeMap = map[error]ErrorCode{
-	ErrTPSNotFound:          {Code: "TPS_NOT_FOUND", HTTPStatus: http.StatusNotFound},
-	ErrTPSInactive:          {Code: "TPS_INACTIVE", HTTPStatus: http.StatusBadRequest},
-	ErrTPSClosed:            {Code: "TPS_CLOSED", HTTPStatus: http.StatusBadRequest},
-	ErrQRInvalid:            {Code: "QR_INVALID", HTTPStatus: http.StatusBadRequest},
-	ErrQRRevoked:            {Code: "QR_REVOKED", HTTPStatus: http.StatusBadRequest},
-	ErrElectionNotOpen:      {Code: "ELECTION_NOT_OPEN", HTTPStatus: http.StatusBadRequest},
-	ErrNotEligible:          {Code: "NOT_ELIGIBLE", HTTPStatus: http.StatusBadRequest},
-	ErrAlreadyVoted:         {Code: "ALREADY_VOTED", HTTPStatus: http.StatusConflict},
-	ErrCheckinNotFound:      {Code: "CHECKIN_NOT_FOUND", HTTPStatus: http.StatusNotFound},
-	ErrCheckinNotPending:    {Code: "CHECKIN_NOT_PENDING", HTTPStatus: http.StatusBadRequest},
-	ErrCheckinExpired:       {Code: "CHECKIN_EXPIRED", HTTPStatus: http.StatusBadRequest},
-	ErrCheckinAlreadyExists: {Code: "CHECKIN_EXISTS", HTTPStatus: http.StatusBadRequest},
-	ErrTPSAccessDenied:      {Code: "TPS_ACCESS_DENIED", HTTPStatus: http.StatusForbidden},
-	ErrTPSCodeDuplicate:     {Code: "TPS_CODE_DUPLICATE", HTTPStatus: http.StatusConflict},
-	ErrInvalidTimeFormat:    {Code: "INVALID_TIME_FORMAT", HTTPStatus: http.StatusBadRequest},
-	ErrNotTPSVoter:          {Code: "NOT_TPS_VOTER", HTTPStatus: http.StatusBadRequest},
-	ErrTPSMismatch:          {Code: "TPS_MISMATCH", HTTPStatus: http.StatusBadRequest},
-	ErrOperatorExists:       {Code: "OPERATOR_EXISTS", HTTPStatus: http.StatusConflict},
-	ErrOperatorNotFound:     {Code: "OPERATOR_NOT_FOUND", HTTPStatus: http.StatusNotFound},
+// NotAssignedError is returned when a voter checks in at a TPS other than
+// the one they are assigned to. It matches ErrTPSNotAssigned.
+type NotAssignedError struct {
+	Assigned VoterAssignment
+}
+
+func (e *NotAssignedError) Error() string {
+	if e.Assigned.TPS == nil {
+		return ErrTPSNotAssigned.Error()
+	}
+	return fmt.Sprintf("%s (%s - %s)", ErrTPSNotAssigned.Error(), e.Assigned.TPS.Code, e.Assigned.TPS.Name)
+}
+
+func (e *NotAssignedError) Unwrap() error {
+	return ErrTPSNotAssigned
 }
 
+// SessionClosedError is returned when a TPS is checked in or voted at
+// outside its open sessions. It matches ErrTPSOutsideSession.
+type SessionClosedError struct {
+	// Next is the next session of the TPS, nil when none is left
+	Next *ScheduleSession
+}
+
+func (e *SessionClosedError) Error() string {
+	if e.Next == nil {
+		return ErrTPSOutsideSession.Error() + " (tidak ada sesi berikutnya)"
+	}
+	return fmt.Sprintf("%s (sesi berikutnya %s)", ErrTPSOutsideSession.Error(), e.Next.OpensAt.Format("02-01-2006 15:04 MST"))
+}
+
+func (e *SessionClosedError) Unwrap() error {
+	return ErrTPSOutsideSession
+}
+
+func init() {
+	errcatalog.Register(
+		errcatalog.Entry{Err: ErrTPSNotFound, Code: "TPS_NOT_FOUND", Status: http.StatusNotFound, EN: "TPS not found"},
+		errcatalog.Entry{Err: ErrTPSInactive, Code: "TPS_INACTIVE", Status: http.StatusBadRequest, EN: "TPS is not active"},
+		errcatalog.Entry{Err: ErrTPSClosed, Code: "TPS_CLOSED", Status: http.StatusBadRequest, EN: "TPS is closed"},
+		errcatalog.Entry{Err: ErrQRInvalid, Code: "QR_INVALID", Status: http.StatusBadRequest, EN: "Invalid QR payload"},
+		errcatalog.Entry{Err: ErrQRRevoked, Code: "QR_REVOKED", Status: http.StatusBadRequest, EN: "QR code is no longer valid"},
+		errcatalog.Entry{Err: ErrElectionNotOpen, Code: "ELECTION_NOT_OPEN", Status: http.StatusBadRequest, EN: "Election is not in its voting phase"},
+		errcatalog.Entry{Err: ErrElectionNotFound, Code: "ELECTION_NOT_FOUND", Status: http.StatusNotFound, EN: "Election not found"},
+		errcatalog.Entry{Err: ErrNotEligible, Code: "NOT_ELIGIBLE", Status: http.StatusForbidden, EN: "Voter is not in the DPT or not eligible"},
+		errcatalog.Entry{Err: ErrAlreadyVoted, Code: "ALREADY_VOTED", Status: http.StatusConflict, EN: "Voter has already voted"},
+		errcatalog.Entry{Err: ErrCheckinNotFound, Code: "CHECKIN_NOT_FOUND", Status: http.StatusNotFound, EN: "Check-in not found"},
+		errcatalog.Entry{Err: ErrCheckinNotPending, Code: "CHECKIN_NOT_PENDING", Status: http.StatusBadRequest, EN: "Check-in is not PENDING"},
+		errcatalog.Entry{Err: ErrCheckinExpired, Code: "CHECKIN_EXPIRED", Status: http.StatusBadRequest, EN: "Check-in has expired"},
+		errcatalog.Entry{Err: ErrCheckinAlreadyExists, Code: "CHECKIN_EXISTS", Status: http.StatusBadRequest, EN: "Check-in already exists"},
+		errcatalog.Entry{Err: ErrTPSAccessDenied, Code: "TPS_ACCESS_DENIED", Status: http.StatusForbidden, EN: "TPS staff is not assigned to this TPS"},
+		errcatalog.Entry{Err: ErrTPSCodeDuplicate, Code: "TPS_CODE_DUPLICATE", Status: http.StatusConflict, EN: "TPS code is already in use"},
+		errcatalog.Entry{Err: ErrInvalidTimeFormat, Code: "INVALID_TIME_FORMAT", Status: http.StatusBadRequest, EN: "Invalid time format"},
+		errcatalog.Entry{Err: ErrNotTPSVoter, Code: "NOT_TPS_VOTER", Status: http.StatusBadRequest, EN: "Voter does not vote at a TPS"},
+		errcatalog.Entry{Err: ErrTPSMismatch, Code: "TPS_MISMATCH", Status: http.StatusBadRequest, EN: "TPS does not match"},
+		errcatalog.Entry{Err: ErrOperatorExists, Code: "OPERATOR_EXISTS", Status: http.StatusConflict, EN: "Operator already exists"},
+		errcatalog.Entry{Err: ErrOperatorNotFound, Code: "OPERATOR_NOT_FOUND", Status: http.StatusNotFound, EN: "Operator not found"},
+		errcatalog.Entry{Err: ErrTPSNotAssigned, Code: "TPS_NOT_ASSIGNED", Status: http.StatusForbidden, EN: "Voter is assigned to another TPS"},
+		errcatalog.Entry{Err: ErrInvalidTimeSlot, Code: "INVALID_TIME_SLOT", Status: http.StatusBadRequest, EN: "Time slot is not available at this TPS"},
+		errcatalog.Entry{Err: ErrInvalidAllocation, Code: "INVALID_ALLOCATION_SETTINGS", Status: http.StatusBadRequest, EN: "Invalid TPS allocation settings"},
+		errcatalog.Entry{Err: ErrOverrideReasonRequired, Code: "OVERRIDE_REASON_REQUIRED", Status: http.StatusBadRequest, EN: "A reason is required to check in outside the assigned TPS"},
+		errcatalog.Entry{Err: ErrInvalidCheckinTTL, Code: "INVALID_CHECKIN_TTL", Status: http.StatusBadRequest, EN: "Check-in expiry must be 0-1440 minutes"},
+		errcatalog.Entry{Err: ErrInvalidBoothCount, Code: "INVALID_BOOTH_COUNT", Status: http.StatusBadRequest, EN: "Booth count must be 1-50"},
+		errcatalog.Entry{Err: ErrQueueEmpty, Code: "QUEUE_EMPTY", Status: http.StatusNotFound, EN: "No voters in the queue"},
+		errcatalog.Entry{Err: ErrTPSOutsideSession, Code: "TPS_OUTSIDE_SESSION", Status: http.StatusForbidden, EN: "TPS is outside its opening hours"},
+		errcatalog.Entry{Err: ErrInvalidSchedule, Code: "INVALID_SCHEDULE", Status: http.StatusBadRequest, EN: "Invalid TPS session schedule"},
+		errcatalog.Entry{Err: ErrInvalidTimezone, Code: "INVALID_TIMEZONE", Status: http.StatusBadRequest, EN: "Unknown time zone"},
+		errcatalog.Entry{Err: ErrInvalidExtension, Code: "INVALID_SESSION_EXTENSION", Status: http.StatusBadRequest, EN: "A session extension must be 1-240 minutes and must not overlap the next session"},
+		errcatalog.Entry{Err: ErrNoSessionToExtend, Code: "NO_SESSION_TO_EXTEND", Status: http.StatusNotFound, EN: "No TPS session today can be extended"},
+		errcatalog.Entry{Err: ErrExtensionReasonMissing, Code: "EXTENSION_REASON_REQUIRED", Status: http.StatusBadRequest, EN: "A reason for the extension is required"},
+		errcatalog.Entry{Err: ErrIncidentNotFound, Code: "INCIDENT_NOT_FOUND", Status: http.StatusNotFound, EN: "TPS incident not found"},
+		errcatalog.Entry{Err: ErrInvalidCategory, Code: "INVALID_INCIDENT_CATEGORY", Status: http.StatusBadRequest, EN: "Unknown incident category"},
+		errcatalog.Entry{Err: ErrInvalidSeverity, Code: "INVALID_INCIDENT_SEVERITY", Status: http.StatusBadRequest, EN: "Incident severity must be LOW, MEDIUM, HIGH or CRITICAL"},
+		errcatalog.Entry{Err: ErrInvalidIncidentStatus, Code: "INVALID_INCIDENT_STATUS", Status: http.StatusBadRequest, EN: "Incident status can only be set to OPEN or IN_PROGRESS"},
+		errcatalog.Entry{Err: ErrInvalidIncidentText, Code: "VALIDATION_ERROR", Status: http.StatusBadRequest, EN: "Incident text is required, at most 4000 characters"},
+		errcatalog.Entry{Err: ErrInvalidIncidentLink, Code: "INVALID_INCIDENT_LINK", Status: http.StatusBadRequest, EN: "The linked voter or check-in is not valid for this TPS"},
+		errcatalog.Entry{Err: ErrInvalidAssignee, Code: "INVALID_INCIDENT_ASSIGNEE", Status: http.StatusBadRequest, EN: "Assigned staff member not found"},
+		errcatalog.Entry{Err: ErrIncidentResolved, Code: "INCIDENT_RESOLVED", Status: http.StatusConflict, EN: "Incident is already resolved"},
+		errcatalog.Entry{Err: ErrResolutionMissing, Code: "RESOLUTION_REQUIRED", Status: http.StatusBadRequest, EN: "A resolution is required"},
+		errcatalog.Entry{Err: ErrInvalidIncidentPhoto, Code: "INVALID_FILE_TYPE", Status: http.StatusUnprocessableEntity, EN: "Incident photos must be PNG, JPEG or WebP"},
+		errcatalog.Entry{Err: ErrIncidentPhotoTooLarge, Code: "FILE_TOO_LARGE", Status: http.StatusUnprocessableEntity, EN: "Incident photos can be at most 5MB"},
+		errcatalog.Entry{Err: ErrIncidentPhotoNotFound, Code: "INCIDENT_PHOTO_NOT_FOUND", Status: http.StatusNotFound, EN: "Incident has no photo"},
+	)
+}
+
+// GetErrorCode returns the API code and status of err from the error
+// catalog, INTERNAL_ERROR for errors it does not know
 func GetErrorCode(err error) (string, int) {
-	if ec, ok := errorCodeMap[err]; ok {
-		return ec.Code, ec.HTTPStatus
+	if e, ok := errcatalog.Lookup(err); ok {
+		return e.Code, e.Status
 	}
 	return "INTERNAL_ERROR", http.StatusInternalServerError
 }
+
+// catalogEntry returns the catalog entry of err, INTERNAL_ERROR for errors
+// the catalog does not know
+func catalogEntry(err error) errcatalog.Entry {
+	if e, ok := errcatalog.Lookup(err); ok {
+		return e
+	}
+	return errcatalog.Internal
+}
+
+// catalogMessage returns the message of the catalog entry of err
+func catalogMessage(err error) errcatalog.Message {
+	e, _ := errcatalog.Lookup(err)
+	return e.Message()
+}
+
+// writeError writes the catalog entry of err, INTERNAL_ERROR for errors the
+// catalog does not know
+func writeError(w http.ResponseWriter, err error) {
+	if !response.DomainError(w, err) {
+		response.InternalServerError(w, "INTERNAL_ERROR", errcatalog.InternalError)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-playground/validator/v10"
 
+	"pemira-api/internal/errcatalog"
 	"pemira-api/internal/http/response"
 	"pemira-api/internal/shared/ctxkeys"
 )
@@ -109,7 +110,7 @@ func (h *Handler) AdminListTPS(w http.ResponseWriter, r *http.Request) {
 
 	result, err := h.service.List(r.Context(), filter)
 	if err != nil {
-		response.InternalServerError(w, "INTERNAL_ERROR", "Failed to fetch TPS list")
+		response.Catalog(w, tpsListFailed)
 		return
 	}
 
@@ -119,14 +120,13 @@ func (h *Handler) AdminListTPS(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) AdminGetTPS(w http.ResponseWriter, r *http.Request) {
 	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
 	if err != nil {
-		response.BadRequest(w, "INVALID_REQUEST", "Invalid TPS ID")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.InvalidTPSID)
 		return
 	}
 
 	result, err := h.service.GetByID(r.Context(), id)
 	if err != nil {
-		code, status := GetErrorCode(err)
-		response.Error(w, status, code, err.Error(), nil)
+		writeError(w, err)
 		return
 	}
 
@@ -136,19 +136,18 @@ func (h *Handler) AdminGetTPS(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) AdminCreateTPS(w http.ResponseWriter, r *http.Request) {
 	var req CreateTPSRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.BadRequest(w, "INVALID_REQUEST", "Invalid request body")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.InvalidBody)
 		return
 	}
 
 	if err := h.validate.Struct(req); err != nil {
-		response.Error(w, http.StatusUnprocessableEntity, "VALIDATION_ERROR", "Validation failed", err.Error())
+		response.Error(w, http.StatusUnprocessableEntity, "VALIDATION_ERROR", errcatalog.ValidationFailed, err.Error())
 		return
 	}
 
 	id, err := h.service.Create(r.Context(), &req)
 	if err != nil {
-		code, status := GetErrorCode(err)
-		response.Error(w, status, code, err.Error(), nil)
+		writeError(w, err)
 		return
 	}
 
@@ -162,24 +161,23 @@ func (h *Handler) AdminCreateTPS(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) AdminUpdateTPS(w http.ResponseWriter, r *http.Request) {
 	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
 	if err != nil {
-		response.BadRequest(w, "INVALID_REQUEST", "Invalid TPS ID")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.InvalidTPSID)
 		return
 	}
 
 	var req UpdateTPSRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.BadRequest(w, "INVALID_REQUEST", "Invalid request body")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.InvalidBody)
 		return
 	}
 
 	if err := h.validate.Struct(req); err != nil {
-		response.Error(w, http.StatusUnprocessableEntity, "VALIDATION_ERROR", "Validation failed", err.Error())
+		response.Error(w, http.StatusUnprocessableEntity, "VALIDATION_ERROR", errcatalog.ValidationFailed, err.Error())
 		return
 	}
 
 	if err := h.service.Update(r.Context(), id, &req); err != nil {
-		code, status := GetErrorCode(err)
-		response.Error(w, status, code, err.Error(), nil)
+		writeError(w, err)
 		return
 	}
 
@@ -192,24 +190,23 @@ func (h *Handler) AdminUpdateTPS(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) AdminAssignPanitia(w http.ResponseWriter, r *http.Request) {
 	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
 	if err != nil {
-		response.BadRequest(w, "INVALID_REQUEST", "Invalid TPS ID")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.InvalidTPSID)
 		return
 	}
 
 	var req AssignPanitiaRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.BadRequest(w, "INVALID_REQUEST", "Invalid request body")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.InvalidBody)
 		return
 	}
 
 	if err := h.validate.Struct(req); err != nil {
-		response.Error(w, http.StatusUnprocessableEntity, "VALIDATION_ERROR", "Validation failed", err.Error())
+		response.Error(w, http.StatusUnprocessableEntity, "VALIDATION_ERROR", errcatalog.ValidationFailed, err.Error())
 		return
 	}
 
 	if err := h.service.AssignPanitia(r.Context(), id, &req); err != nil {
-		code, status := GetErrorCode(err)
-		response.Error(w, status, code, err.Error(), nil)
+		writeError(w, err)
 		return
 	}
 
@@ -222,14 +219,13 @@ func (h *Handler) AdminAssignPanitia(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) AdminRegenerateQR(w http.ResponseWriter, r *http.Request) {
 	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
 	if err != nil {
-		response.BadRequest(w, "INVALID_REQUEST", "Invalid TPS ID")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.InvalidTPSID)
 		return
 	}
 
 	result, err := h.service.RegenerateQR(r.Context(), id)
 	if err != nil {
-		code, status := GetErrorCode(err)
-		response.Error(w, status, code, err.Error(), nil)
+		writeError(w, err)
 		return
 	}
 
@@ -240,7 +236,7 @@ func (h *Handler) AdminRegenerateQR(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) AdminListTPSElection(w http.ResponseWriter, r *http.Request) {
 	electionID, err := strconv.ParseInt(chi.URLParam(r, "electionID"), 10, 64)
 	if err != nil || electionID <= 0 {
-		response.BadRequest(w, "INVALID_REQUEST", "Invalid election ID")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.InvalidElectionID)
 		return
 	}
 	filter := ListFilter{
@@ -252,8 +248,7 @@ func (h *Handler) AdminListTPSElection(w http.ResponseWriter, r *http.Request) {
 
 	result, err := h.service.List(r.Context(), filter)
 	if err != nil {
-		code, status := GetErrorCode(err)
-		response.Error(w, status, code, err.Error(), nil)
+		writeError(w, err)
 		return
 	}
 
@@ -267,19 +262,18 @@ func (h *Handler) AdminListTPSElection(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) AdminGetTPSElection(w http.ResponseWriter, r *http.Request) {
 	electionID, err := strconv.ParseInt(chi.URLParam(r, "electionID"), 10, 64)
 	if err != nil || electionID <= 0 {
-		response.BadRequest(w, "INVALID_REQUEST", "Invalid election ID")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.InvalidElectionID)
 		return
 	}
 	tpsID, err := strconv.ParseInt(chi.URLParam(r, "tpsID"), 10, 64)
 	if err != nil || tpsID <= 0 {
-		response.BadRequest(w, "INVALID_REQUEST", "Invalid TPS ID")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.InvalidTPSID)
 		return
 	}
 
 	result, err := h.service.GetByIDElection(r.Context(), electionID, tpsID)
 	if err != nil {
-		code, status := GetErrorCode(err)
-		response.Error(w, status, code, err.Error(), nil)
+		writeError(w, err)
 		return
 	}
 
@@ -289,26 +283,25 @@ func (h *Handler) AdminGetTPSElection(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) AdminCreateTPSElection(w http.ResponseWriter, r *http.Request) {
 	electionID, err := strconv.ParseInt(chi.URLParam(r, "electionID"), 10, 64)
 	if err != nil || electionID <= 0 {
-		response.BadRequest(w, "INVALID_REQUEST", "Invalid election ID")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.InvalidElectionID)
 		return
 	}
 
 	var req CreateTPSRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.BadRequest(w, "INVALID_REQUEST", "Invalid request body")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.InvalidBody)
 		return
 	}
 	req.ElectionID = electionID
 
 	if err := h.validate.Struct(req); err != nil {
-		response.Error(w, http.StatusUnprocessableEntity, "VALIDATION_ERROR", "Validation failed", err.Error())
+		response.Error(w, http.StatusUnprocessableEntity, "VALIDATION_ERROR", errcatalog.ValidationFailed, err.Error())
 		return
 	}
 
 	id, err := h.service.Create(r.Context(), &req)
 	if err != nil {
-		code, status := GetErrorCode(err)
-		response.Error(w, status, code, err.Error(), nil)
+		writeError(w, err)
 		return
 	}
 
@@ -321,29 +314,28 @@ func (h *Handler) AdminCreateTPSElection(w http.ResponseWriter, r *http.Request)
 func (h *Handler) AdminUpdateTPSElection(w http.ResponseWriter, r *http.Request) {
 	electionID, err := strconv.ParseInt(chi.URLParam(r, "electionID"), 10, 64)
 	if err != nil || electionID <= 0 {
-		response.BadRequest(w, "INVALID_REQUEST", "Invalid election ID")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.InvalidElectionID)
 		return
 	}
 	tpsID, err := strconv.ParseInt(chi.URLParam(r, "tpsID"), 10, 64)
 	if err != nil || tpsID <= 0 {
-		response.BadRequest(w, "INVALID_REQUEST", "Invalid TPS ID")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.InvalidTPSID)
 		return
 	}
 
 	var req UpdateTPSRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.BadRequest(w, "INVALID_REQUEST", "Invalid request body")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.InvalidBody)
 		return
 	}
 
 	if err := h.validate.Struct(req); err != nil {
-		response.Error(w, http.StatusUnprocessableEntity, "VALIDATION_ERROR", "Validation failed", err.Error())
+		response.Error(w, http.StatusUnprocessableEntity, "VALIDATION_ERROR", errcatalog.ValidationFailed, err.Error())
 		return
 	}
 
 	if err := h.service.UpdateWithElection(r.Context(), electionID, tpsID, &req); err != nil {
-		code, status := GetErrorCode(err)
-		response.Error(w, status, code, err.Error(), nil)
+		writeError(w, err)
 		return
 	}
 
@@ -356,18 +348,17 @@ func (h *Handler) AdminUpdateTPSElection(w http.ResponseWriter, r *http.Request)
 func (h *Handler) AdminDeleteTPSElection(w http.ResponseWriter, r *http.Request) {
 	electionID, err := strconv.ParseInt(chi.URLParam(r, "electionID"), 10, 64)
 	if err != nil || electionID <= 0 {
-		response.BadRequest(w, "INVALID_REQUEST", "Invalid election ID")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.InvalidElectionID)
 		return
 	}
 	tpsID, err := strconv.ParseInt(chi.URLParam(r, "tpsID"), 10, 64)
 	if err != nil || tpsID <= 0 {
-		response.BadRequest(w, "INVALID_REQUEST", "Invalid TPS ID")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.InvalidTPSID)
 		return
 	}
 
 	if err := h.service.Delete(r.Context(), electionID, tpsID); err != nil {
-		code, status := GetErrorCode(err)
-		response.Error(w, status, code, err.Error(), nil)
+		writeError(w, err)
 		return
 	}
 
@@ -379,13 +370,12 @@ func (h *Handler) AdminDeleteTPSElection(w http.ResponseWriter, r *http.Request)
 func (h *Handler) AdminGetQRMetadata(w http.ResponseWriter, r *http.Request) {
 	electionID, tpsID, ok := parseElectionTPS(r)
 	if !ok {
-		response.BadRequest(w, "INVALID_REQUEST", "Invalid election_id atau tps_id")
+		response.Catalog(w, tpsScopeInvalid)
 		return
 	}
 	qr, err := h.service.GetQRMetadata(r.Context(), electionID, tpsID)
 	if err != nil {
-		code, status := GetErrorCode(err)
-		response.Error(w, status, code, err.Error(), nil)
+		writeError(w, err)
 		return
 	}
 	response.Success(w, http.StatusOK, qr)
@@ -394,13 +384,12 @@ func (h *Handler) AdminGetQRMetadata(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) AdminRotateQR(w http.ResponseWriter, r *http.Request) {
 	electionID, tpsID, ok := parseElectionTPS(r)
 	if !ok {
-		response.BadRequest(w, "INVALID_REQUEST", "Invalid election_id atau tps_id")
+		response.Catalog(w, tpsScopeInvalid)
 		return
 	}
 	qr, err := h.service.RotateQR(r.Context(), electionID, tpsID)
 	if err != nil {
-		code, status := GetErrorCode(err)
-		response.Error(w, status, code, err.Error(), nil)
+		writeError(w, err)
 		return
 	}
 	response.Success(w, http.StatusOK, qr)
@@ -409,13 +398,12 @@ func (h *Handler) AdminRotateQR(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) AdminGetQRPrint(w http.ResponseWriter, r *http.Request) {
 	electionID, tpsID, ok := parseElectionTPS(r)
 	if !ok {
-		response.BadRequest(w, "INVALID_REQUEST", "Invalid election_id atau tps_id")
+		response.Catalog(w, tpsScopeInvalid)
 		return
 	}
 	payload, err := h.service.GetQRPrintPayload(r.Context(), electionID, tpsID)
 	if err != nil {
-		code, status := GetErrorCode(err)
-		response.Error(w, status, code, err.Error(), nil)
+		writeError(w, err)
 		return
 	}
 	response.Success(w, http.StatusOK, map[string]interface{}{
@@ -427,13 +415,12 @@ func (h *Handler) AdminGetQRPrint(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) AdminListOperators(w http.ResponseWriter, r *http.Request) {
 	electionID, tpsID, ok := parseElectionTPS(r)
 	if !ok {
-		response.BadRequest(w, "INVALID_REQUEST", "Invalid election_id atau tps_id")
+		response.Catalog(w, tpsScopeInvalid)
 		return
 	}
 	ops, err := h.service.ListOperators(r.Context(), electionID, tpsID)
 	if err != nil {
-		code, status := GetErrorCode(err)
-		response.Error(w, status, code, err.Error(), nil)
+		writeError(w, err)
 		return
 	}
 	response.Success(w, http.StatusOK, map[string]interface{}{
@@ -444,18 +431,18 @@ func (h *Handler) AdminListOperators(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) AdminCreateOperator(w http.ResponseWriter, r *http.Request) {
 	electionID, tpsID, ok := parseElectionTPS(r)
 	if !ok {
-		response.BadRequest(w, "INVALID_REQUEST", "Invalid election_id atau tps_id")
+		response.Catalog(w, tpsScopeInvalid)
 		return
 	}
 	var req OperatorCreate
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.BadRequest(w, "INVALID_REQUEST", "Invalid request body")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.InvalidBody)
 		return
 	}
 	req.Username = strings.TrimSpace(req.Username)
 	req.Password = strings.TrimSpace(req.Password)
 	if req.Username == "" || req.Password == "" {
-		response.UnprocessableEntity(w, "VALIDATION_ERROR", "username dan password wajib diisi.")
+		response.UnprocessableEntity(w, "VALIDATION_ERROR", errcatalog.Required("username", "password"))
 		return
 	}
 	if req.Name == "" {
@@ -464,8 +451,7 @@ func (h *Handler) AdminCreateOperator(w http.ResponseWriter, r *http.Request) {
 
 	op, err := h.service.CreateOperator(r.Context(), electionID, tpsID, req)
 	if err != nil {
-		code, status := GetErrorCode(err)
-		response.Error(w, status, code, err.Error(), nil)
+		writeError(w, err)
 		return
 	}
 	response.Success(w, http.StatusCreated, op)
@@ -474,17 +460,16 @@ func (h *Handler) AdminCreateOperator(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) AdminDeleteOperator(w http.ResponseWriter, r *http.Request) {
 	electionID, tpsID, ok := parseElectionTPS(r)
 	if !ok {
-		response.BadRequest(w, "INVALID_REQUEST", "Invalid election_id atau tps_id")
+		response.Catalog(w, tpsScopeInvalid)
 		return
 	}
 	userID, err := strconv.ParseInt(chi.URLParam(r, "userID"), 10, 64)
 	if err != nil || userID <= 0 {
-		response.BadRequest(w, "INVALID_REQUEST", "Invalid operator user ID")
+		response.Catalog(w, operatorIDInvalid)
 		return
 	}
 	if err := h.service.DeleteOperator(r.Context(), electionID, tpsID, userID); err != nil {
-		code, status := GetErrorCode(err)
-		response.Error(w, status, code, err.Error(), nil)
+		writeError(w, err)
 		return
 	}
 	response.Success(w, http.StatusOK, map[string]interface{}{
@@ -497,25 +482,24 @@ func (h *Handler) AdminDeleteOperator(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) StudentScanQR(w http.ResponseWriter, r *http.Request) {
 	userID, ok := r.Context().Value(ctxkeys.UserIDKey).(int64)
 	if !ok {
-		response.Unauthorized(w, "UNAUTHORIZED", "Unauthorized")
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.Unauthenticated)
 		return
 	}
 
 	var req ScanQRRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.BadRequest(w, "INVALID_REQUEST", "Invalid request body")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.InvalidBody)
 		return
 	}
 
 	if err := h.validate.Struct(req); err != nil {
-		response.Error(w, http.StatusUnprocessableEntity, "VALIDATION_ERROR", "Validation failed", err.Error())
+		response.Error(w, http.StatusUnprocessableEntity, "VALIDATION_ERROR", errcatalog.ValidationFailed, err.Error())
 		return
 	}
 
 	result, err := h.service.ScanQR(r.Context(), userID, &req)
 	if err != nil {
-		code, status := GetErrorCode(err)
-		response.Error(w, status, code, err.Error(), nil)
+		writeError(w, err)
 		return
 	}
 
@@ -523,9 +507,9 @@ func (h *Handler) StudentScanQR(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *Handler) StudentCheckinStatus(w http.ResponseWriter, r *http.Request) {
-	userID, ok := r.Context().Value(ctxkeys.UserIDKey).(int64)
+	voterID, ok := ctxkeys.GetVoterID(r.Context())
 	if !ok {
-		response.Unauthorized(w, "UNAUTHORIZED", "Unauthorized")
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.Unauthenticated)
 		return
 	}
 
@@ -534,9 +518,9 @@ func (h *Handler) StudentCheckinStatus(w http.ResponseWriter, r *http.Request) {
 		electionID = 1 // Default to active election
 	}
 
-	result, err := h.service.GetCheckinStatus(r.Context(), userID, electionID)
+	result, err := h.service.GetCheckinStatus(r.Context(), voterID, electionID)
 	if err != nil {
-		response.InternalServerError(w, "INTERNAL_ERROR", "Failed to get check-in status")
+		response.Catalog(w, checkinStatusFailed)
 		return
 	}
 
@@ -548,21 +532,20 @@ func (h *Handler) StudentCheckinStatus(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) PanelGetSummary(w http.ResponseWriter, r *http.Request) {
 	tpsID, err := parsePathID(r, "tps_id", "tpsID")
 	if err != nil {
-		response.BadRequest(w, "INVALID_REQUEST", "Invalid TPS ID")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.InvalidTPSID)
 		return
 	}
 
 	userID, ok := ctxkeys.GetUserID(r.Context())
 	if !ok {
-		response.Unauthorized(w, "UNAUTHORIZED", "Unauthorized")
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.Unauthenticated)
 		return
 	}
 	role, _ := ctxkeys.GetUserRole(r.Context())
 
 	result, err := h.service.GetTPSSummary(r.Context(), tpsID, userID, role)
 	if err != nil {
-		code, status := GetErrorCode(err)
-		response.Error(w, status, code, err.Error(), nil)
+		writeError(w, err)
 		return
 	}
 
@@ -572,7 +555,7 @@ func (h *Handler) PanelGetSummary(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) PanelListCheckins(w http.ResponseWriter, r *http.Request) {
 	tpsID, err := parsePathID(r, "tps_id", "tpsID")
 	if err != nil {
-		response.BadRequest(w, "INVALID_REQUEST", "Invalid TPS ID")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.InvalidTPSID)
 		return
 	}
 
@@ -586,14 +569,14 @@ func (h *Handler) PanelListCheckins(w http.ResponseWriter, r *http.Request) {
 
 	userID, ok := ctxkeys.GetUserID(r.Context())
 	if !ok {
-		response.Unauthorized(w, "UNAUTHORIZED", "Unauthorized")
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.Unauthenticated)
 		return
 	}
 	role, _ := ctxkeys.GetUserRole(r.Context())
 
 	result, err := h.service.ListCheckinQueue(r.Context(), tpsID, status, page, limit, role, userID)
 	if err != nil {
-		response.InternalServerError(w, "INTERNAL_ERROR", "Failed to fetch check-in queue")
+		response.Catalog(w, checkinQueueFailed)
 		return
 	}
 
@@ -603,34 +586,33 @@ func (h *Handler) PanelListCheckins(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) PanelApproveCheckin(w http.ResponseWriter, r *http.Request) {
 	tpsID, err := parsePathID(r, "tps_id", "tpsID")
 	if err != nil {
-		response.BadRequest(w, "INVALID_REQUEST", "Invalid TPS ID")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.InvalidTPSID)
 		return
 	}
 
 	checkinID, err := parsePathID(r, "checkin_id", "checkinID")
 	if err != nil {
-		response.BadRequest(w, "INVALID_REQUEST", "Invalid check-in ID")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.InvalidCheckinID)
 		return
 	}
 
 	userID, ok := ctxkeys.GetUserID(r.Context())
 	if !ok {
-		response.Unauthorized(w, "UNAUTHORIZED", "Unauthorized")
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.Unauthenticated)
 		return
 	}
 	role, _ := ctxkeys.GetUserRole(r.Context())
 	if !hasPanelAccess(role) {
 		hasAccess, _ := h.service.repo.IsPanitiaAssigned(r.Context(), tpsID, userID)
 		if !hasAccess {
-			response.Forbidden(w, "TPS_ACCESS_DENIED", "Akses ditolak.")
+			response.Forbidden(w, "TPS_ACCESS_DENIED", errcatalog.AccessDenied)
 			return
 		}
 	}
 
 	result, err := h.service.ApproveCheckin(r.Context(), tpsID, checkinID, userID)
 	if err != nil {
-		code, status := GetErrorCode(err)
-		response.Error(w, status, code, err.Error(), nil)
+		writeError(w, err)
 		return
 	}
 
@@ -640,45 +622,44 @@ func (h *Handler) PanelApproveCheckin(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) PanelRejectCheckin(w http.ResponseWriter, r *http.Request) {
 	tpsID, err := parsePathID(r, "tps_id", "tpsID")
 	if err != nil {
-		response.BadRequest(w, "INVALID_REQUEST", "Invalid TPS ID")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.InvalidTPSID)
 		return
 	}
 
 	checkinID, err := parsePathID(r, "checkin_id", "checkinID")
 	if err != nil {
-		response.BadRequest(w, "INVALID_REQUEST", "Invalid check-in ID")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.InvalidCheckinID)
 		return
 	}
 
 	userID, ok := ctxkeys.GetUserID(r.Context())
 	if !ok {
-		response.Unauthorized(w, "UNAUTHORIZED", "Unauthorized")
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.Unauthenticated)
 		return
 	}
 	role, _ := ctxkeys.GetUserRole(r.Context())
 	if !hasPanelAccess(role) {
 		hasAccess, _ := h.service.repo.IsPanitiaAssigned(r.Context(), tpsID, userID)
 		if !hasAccess {
-			response.Forbidden(w, "TPS_ACCESS_DENIED", "Akses ditolak.")
+			response.Forbidden(w, "TPS_ACCESS_DENIED", errcatalog.AccessDenied)
 			return
 		}
 	}
 
 	var req RejectCheckinRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.BadRequest(w, "INVALID_REQUEST", "Invalid request body")
+		response.BadRequest(w, "INVALID_REQUEST", errcatalog.InvalidBody)
 		return
 	}
 
 	if err := h.validate.Struct(req); err != nil {
-		response.Error(w, http.StatusUnprocessableEntity, "VALIDATION_ERROR", "Validation failed", err.Error())
+		response.Error(w, http.StatusUnprocessableEntity, "VALIDATION_ERROR", errcatalog.ValidationFailed, err.Error())
 		return
 	}
 
 	result, err := h.service.RejectCheckin(r.Context(), tpsID, checkinID, userID, req.Reason)
 	if err != nil {
-		code, status := GetErrorCode(err)
-		response.Error(w, status, code, err.Error(), nil)
+		writeError(w, err)
 		return
 	}
 
@@ -6,6 +6,8 @@ import (
 	"strconv"
 
 	"github.com/gorilla/mux"
+
+	"pemira-api/internal/errcatalog"
 )
 
 type CheckinHandler struct {
@@ -40,20 +42,21 @@ func (h *CheckinHandler) ScanQR(w http.ResponseWriter, r *http.Request) {
 	// Get voter ID from context (set by auth middleware)
 	voterID, ok := ctx.Value("voter_id").(int64)
 	if !ok {
-		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Voter tidak terautentikasi")
+		respondCatalog(w, voterUnauthenticated)
 		return
 	}
 
 	// Parse request
 	var req ScanQRRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "Request tidak valid")
+		respondCatalog(w, checkinRequestInvalid)
 		return
 	}
 
 	// Call service
 	result, err := h.service.CheckinScan(ctx, voterID, req.QRPayload)
 	if err != nil {
+		recordQRScanFailure(err)
 		handleServiceError(w, err)
 		return
 	}
@@ -68,14 +71,14 @@ func (h *CheckinHandler) GetCheckinStatus(w http.ResponseWriter, r *http.Request
 
 	_, ok := ctx.Value("voter_id").(int64)
 	if !ok {
-		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Voter tidak terautentikasi")
+		respondCatalog(w, voterUnauthenticated)
 		return
 	}
 
 	electionIDStr := r.URL.Query().Get("election_id")
 	_, err := strconv.ParseInt(electionIDStr, 10, 64)
 	if err != nil || electionIDStr == "" {
-		respondError(w, http.StatusBadRequest, "INVALID_ELECTION_ID", "Election ID tidak valid")
+		respondError(w, http.StatusBadRequest, "INVALID_ELECTION_ID", errcatalog.InvalidElectionID)
 		return
 	}
 
@@ -95,7 +98,7 @@ func (h *CheckinHandler) ListCheckinQueue(w http.ResponseWriter, r *http.Request
 	// Get operator ID from context (set by auth middleware)
 	_, ok := ctx.Value("user_id").(int64)
 	if !ok {
-		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Operator tidak terautentikasi")
+		respondCatalog(w, operatorUnauthenticated)
 		return
 	}
 
@@ -103,7 +106,7 @@ func (h *CheckinHandler) ListCheckinQueue(w http.ResponseWriter, r *http.Request
 	vars := mux.Vars(r)
 	_, err := strconv.ParseInt(vars["tps_id"], 10, 64)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_TPS_ID", "TPS ID tidak valid")
+		respondError(w, http.StatusBadRequest, "INVALID_TPS_ID", errcatalog.InvalidTPSID)
 		return
 	}
 
@@ -129,7 +132,7 @@ func (h *CheckinHandler) ApproveCheckin(w http.ResponseWriter, r *http.Request)
 	// Get operator ID from context
 	operatorID, ok := ctx.Value("user_id").(int64)
 	if !ok {
-		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Operator tidak terautentikasi")
+		respondCatalog(w, operatorUnauthenticated)
 		return
 	}
 
@@ -137,13 +140,13 @@ func (h *CheckinHandler) ApproveCheckin(w http.ResponseWriter, r *http.Request)
 	vars := mux.Vars(r)
 	tpsID, err := strconv.ParseInt(vars["tps_id"], 10, 64)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_TPS_ID", "TPS ID tidak valid")
+		respondError(w, http.StatusBadRequest, "INVALID_TPS_ID", errcatalog.InvalidTPSID)
 		return
 	}
 
 	checkinID, err := strconv.ParseInt(vars["checkin_id"], 10, 64)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_CHECKIN_ID", "Check-in ID tidak valid")
+		respondError(w, http.StatusBadRequest, "INVALID_CHECKIN_ID", errcatalog.InvalidCheckinID)
 		return
 	}
 
@@ -165,7 +168,7 @@ func (h *CheckinHandler) RejectCheckin(w http.ResponseWriter, r *http.Request) {
 	// Get operator ID from context
 	operatorID, ok := ctx.Value("user_id").(int64)
 	if !ok {
-		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Operator tidak terautentikasi")
+		respondCatalog(w, operatorUnauthenticated)
 		return
 	}
 
@@ -173,26 +176,26 @@ func (h *CheckinHandler) RejectCheckin(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	tpsID, err := strconv.ParseInt(vars["tps_id"], 10, 64)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_TPS_ID", "TPS ID tidak valid")
+		respondError(w, http.StatusBadRequest, "INVALID_TPS_ID", errcatalog.InvalidTPSID)
 		return
 	}
 
 	checkinID, err := strconv.ParseInt(vars["checkin_id"], 10, 64)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_CHECKIN_ID", "Check-in ID tidak valid")
+		respondError(w, http.StatusBadRequest, "INVALID_CHECKIN_ID", errcatalog.InvalidCheckinID)
 		return
 	}
 
 	// Parse request body
 	var req RejectCheckinRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "Request tidak valid")
+		respondCatalog(w, checkinRequestInvalid)
 		return
 	}
 
 	// Validate reason
 	if req.Reason == "" {
-		respondError(w, http.StatusBadRequest, "REASON_REQUIRED", "Alasan penolakan harus diisi")
+		respondCatalog(w, rejectReasonRequired)
 		return
 	}
 
@@ -217,19 +220,28 @@ func respondJSON(w http.ResponseWriter, status int, data interface{}) {
 	})
 }
 
-func respondError(w http.ResponseWriter, status int, code string, message string) {
+func respondError(w http.ResponseWriter, status int, code string, message errcatalog.Message) {
+	w.Header().Add("Vary", "Accept-Language")
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
 	json.NewEncoder(w).Encode(map[string]interface{}{
 		"success": false,
 		"error": map[string]interface{}{
 			"code":    code,
-			"message": message,
+			"message": message.Text(errcatalog.Parse(w.Header().Get("Content-Language"))),
 		},
 	})
 }
 
+func respondCatalog(w http.ResponseWriter, e errcatalog.Entry) {
+	respondError(w, e.Status, e.Code, e.Message())
+}
+
 func handleServiceError(w http.ResponseWriter, err error) {
 	code, status := GetErrorCode(err)
-	respondError(w, status, code, err.Error())
+	message := errcatalog.InternalError
+	if status != http.StatusInternalServerError {
+		message = catalogMessage(err)
+	}
+	respondError(w, status, code, message)
 }
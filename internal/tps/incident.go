@@ -0,0 +1,356 @@
+package tps
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gabriel-vasile/mimetype"
+)
+
+const (
+	IncidentStatusOpen       = "OPEN"
+	IncidentStatusInProgress = "IN_PROGRESS"
+	IncidentStatusResolved   = "RESOLVED"
+
+	IncidentSeverityLow      = "LOW"
+	IncidentSeverityMedium   = "MEDIUM"
+	IncidentSeverityHigh     = "HIGH"
+	IncidentSeverityCritical = "CRITICAL"
+
+	// maxIncidentText bounds descriptions, comments and resolutions
+	maxIncidentText = 4000
+	// MaxIncidentPhotoSize bounds the photo attached to an incident
+	MaxIncidentPhotoSize = 5 << 20
+)
+
+// incidentCategories are the kinds of incident an operator can report
+var incidentCategories = map[string]bool{
+	"POWER_OUTAGE": true,
+	"EQUIPMENT":    true,
+	"NETWORK":      true,
+	"DPT_DISPUTE":  true,
+	"SECURITY":     true,
+	"OTHER":        true,
+}
+
+var incidentSeverities = map[string]bool{
+	IncidentSeverityLow:      true,
+	IncidentSeverityMedium:   true,
+	IncidentSeverityHigh:     true,
+	IncidentSeverityCritical: true,
+}
+
+// Incident is something that went wrong at a TPS, from the report of an
+// operator to its resolution by an admin
+type Incident struct {
+	ID          int64             `json:"id"`
+	ElectionID  int64             `json:"election_id"`
+	TPSID       int64             `json:"tps_id"`
+	TPSCode     string            `json:"tps_code,omitempty"`
+	TPSName     string            `json:"tps_name,omitempty"`
+	Category    string            `json:"category"`
+	Severity    string            `json:"severity"`
+	Status      string            `json:"status"`
+	Description string            `json:"description"`
+	VoterID     *int64            `json:"voter_id,omitempty"`
+	CheckinID   *int64            `json:"checkin_id,omitempty"`
+	HasPhoto    bool              `json:"has_photo"`
+	ReportedBy  *int64            `json:"reported_by,omitempty"`
+	AssignedTo  *int64            `json:"assigned_to,omitempty"`
+	Resolution  *string           `json:"resolution,omitempty"`
+	ResolvedBy  *int64            `json:"resolved_by,omitempty"`
+	ResolvedAt  *time.Time        `json:"resolved_at,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+	Comments    []IncidentComment `json:"comments,omitempty"`
+}
+
+// IsOpen reports whether the incident still needs attention
+func (i *Incident) IsOpen() bool {
+	return i.Status != IncidentStatusResolved
+}
+
+type IncidentComment struct {
+	ID         int64     `json:"id"`
+	IncidentID int64     `json:"incident_id"`
+	AuthorID   *int64    `json:"author_id,omitempty"`
+	AuthorName string    `json:"author_name,omitempty"`
+	Body       string    `json:"body"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// IncidentPhoto is the photo attached to an incident
+type IncidentPhoto struct {
+	ContentType string
+	Data        []byte
+}
+
+// OpenIncidentSummary is an unresolved incident as the TPS monitor lists it
+type OpenIncidentSummary struct {
+	ID         int64     `json:"id"`
+	Category   string    `json:"category"`
+	Severity   string    `json:"severity"`
+	Status     string    `json:"status"`
+	AssignedTo *int64    `json:"assigned_to,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// IncidentFilter narrows the incidents of an election. Empty fields match
+// everything.
+type IncidentFilter struct {
+	TPSID    int64
+	Status   string
+	Severity string
+	Category string
+	// OpenOnly leaves out resolved incidents
+	OpenOnly bool
+}
+
+type IncidentRepository interface {
+	// CreateIncident saves a new incident and sets its ID and timestamps
+	CreateIncident(ctx context.Context, inc *Incident) error
+	// GetIncident returns an incident of an election with its comments
+	GetIncident(ctx context.Context, electionID, incidentID int64) (*Incident, error)
+	// ListIncidents returns incidents without comments, newest first
+	ListIncidents(ctx context.Context, electionID int64, f IncidentFilter) ([]Incident, error)
+	// UpdateIncident saves the severity, status, assignee and resolution of
+	// an incident and records action in the audit log
+	UpdateIncident(ctx context.Context, inc *Incident, action string, actorID *int64) error
+	AddIncidentComment(ctx context.Context, c *IncidentComment) error
+	SetIncidentPhoto(ctx context.Context, incidentID int64, photo *IncidentPhoto) error
+	// GetIncidentPhoto returns ErrIncidentPhotoNotFound when none is attached
+	GetIncidentPhoto(ctx context.Context, incidentID int64) (*IncidentPhoto, error)
+	// CheckinVoter returns the voter of a check-in made at a TPS, or
+	// ErrCheckinNotFound
+	CheckinVoter(ctx context.Context, tpsID, checkinID int64) (int64, error)
+	VoterExists(ctx context.Context, voterID int64) (bool, error)
+	UserExists(ctx context.Context, userID int64) (bool, error)
+}
+
+// IncidentService keeps the incident log of each TPS: operators report
+// incidents, admins triage, assign and resolve them.
+type IncidentService struct {
+	repo IncidentRepository
+}
+
+func NewIncidentService(repo IncidentRepository) *IncidentService {
+	return &IncidentService{repo: repo}
+}
+
+// Report files an incident at a TPS. A linked check-in must have been made
+// at that TPS and sets the voter when none is given.
+func (s *IncidentService) Report(ctx context.Context, electionID, tpsID int64, req ReportIncidentRequest, reporterID *int64) (*Incident, error) {
+	category := strings.ToUpper(strings.TrimSpace(req.Category))
+	if !incidentCategories[category] {
+		return nil, ErrInvalidCategory
+	}
+	severity := strings.ToUpper(strings.TrimSpace(req.Severity))
+	if severity == "" {
+		severity = IncidentSeverityMedium
+	}
+	if !incidentSeverities[severity] {
+		return nil, ErrInvalidSeverity
+	}
+	description := strings.TrimSpace(req.Description)
+	if !validIncidentText(description) {
+		return nil, ErrInvalidIncidentText
+	}
+
+	voterID := req.VoterID
+	if req.CheckinID != nil {
+		checkinVoter, err := s.repo.CheckinVoter(ctx, tpsID, *req.CheckinID)
+		if errors.Is(err, ErrCheckinNotFound) {
+			return nil, ErrInvalidIncidentLink
+		}
+		if err != nil {
+			return nil, err
+		}
+		if voterID != nil && *voterID != checkinVoter {
+			return nil, ErrInvalidIncidentLink
+		}
+		voterID = &checkinVoter
+	} else if voterID != nil {
+		ok, err := s.repo.VoterExists(ctx, *voterID)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, ErrInvalidIncidentLink
+		}
+	}
+
+	inc := &Incident{
+		ElectionID:  electionID,
+		TPSID:       tpsID,
+		Category:    category,
+		Severity:    severity,
+		Status:      IncidentStatusOpen,
+		Description: description,
+		VoterID:     voterID,
+		CheckinID:   req.CheckinID,
+		ReportedBy:  reporterID,
+	}
+	if err := s.repo.CreateIncident(ctx, inc); err != nil {
+		return nil, err
+	}
+	return inc, nil
+}
+
+func (s *IncidentService) List(ctx context.Context, electionID int64, f IncidentFilter) ([]Incident, error) {
+	f.Status = strings.ToUpper(f.Status)
+	f.Severity = strings.ToUpper(f.Severity)
+	f.Category = strings.ToUpper(f.Category)
+	incidents, err := s.repo.ListIncidents(ctx, electionID, f)
+	if err != nil {
+		return nil, err
+	}
+	if incidents == nil {
+		incidents = []Incident{}
+	}
+	return incidents, nil
+}
+
+func (s *IncidentService) Get(ctx context.Context, electionID, incidentID int64) (*Incident, error) {
+	return s.repo.GetIncident(ctx, electionID, incidentID)
+}
+
+// GetForTPS returns an incident only when it was reported at tpsID, so a
+// panel cannot read the incidents of another TPS
+func (s *IncidentService) GetForTPS(ctx context.Context, electionID, tpsID, incidentID int64) (*Incident, error) {
+	inc, err := s.repo.GetIncident(ctx, electionID, incidentID)
+	if err != nil {
+		return nil, err
+	}
+	if inc.TPSID != tpsID {
+		return nil, ErrIncidentNotFound
+	}
+	return inc, nil
+}
+
+// Triage changes the severity or status of an incident. Moving a resolved
+// incident back to OPEN or IN_PROGRESS reopens it; resolving goes through
+// Resolve so a resolution is always recorded.
+func (s *IncidentService) Triage(ctx context.Context, electionID, incidentID int64, req TriageIncidentRequest, actorID *int64) (*Incident, error) {
+	inc, err := s.repo.GetIncident(ctx, electionID, incidentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Severity != "" {
+		severity := strings.ToUpper(req.Severity)
+		if !incidentSeverities[severity] {
+			return nil, ErrInvalidSeverity
+		}
+		inc.Severity = severity
+	}
+	if req.Status != "" {
+		status := strings.ToUpper(req.Status)
+		if status != IncidentStatusOpen && status != IncidentStatusInProgress {
+			return nil, ErrInvalidIncidentStatus
+		}
+		if !inc.IsOpen() {
+			inc.Resolution, inc.ResolvedBy, inc.ResolvedAt = nil, nil, nil
+		}
+		inc.Status = status
+	}
+
+	if err := s.repo.UpdateIncident(ctx, inc, "TPS_INCIDENT_TRIAGED", actorID); err != nil {
+		return nil, err
+	}
+	return inc, nil
+}
+
+// Assign hands an incident to a staff account, or unassigns it when
+// assignee is nil. An open incident that gets an assignee is in progress.
+func (s *IncidentService) Assign(ctx context.Context, electionID, incidentID int64, assignee *int64, actorID *int64) (*Incident, error) {
+	inc, err := s.repo.GetIncident(ctx, electionID, incidentID)
+	if err != nil {
+		return nil, err
+	}
+	if !inc.IsOpen() {
+		return nil, ErrIncidentResolved
+	}
+	if assignee != nil {
+		ok, err := s.repo.UserExists(ctx, *assignee)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, ErrInvalidAssignee
+		}
+		if inc.Status == IncidentStatusOpen {
+			inc.Status = IncidentStatusInProgress
+		}
+	}
+	inc.AssignedTo = assignee
+
+	if err := s.repo.UpdateIncident(ctx, inc, "TPS_INCIDENT_ASSIGNED", actorID); err != nil {
+		return nil, err
+	}
+	return inc, nil
+}
+
+// Resolve closes an incident with a resolution
+func (s *IncidentService) Resolve(ctx context.Context, electionID, incidentID int64, req ResolveIncidentRequest, actorID *int64, now time.Time) (*Incident, error) {
+	resolution := strings.TrimSpace(req.Resolution)
+	if !validIncidentText(resolution) {
+		return nil, ErrResolutionMissing
+	}
+	inc, err := s.repo.GetIncident(ctx, electionID, incidentID)
+	if err != nil {
+		return nil, err
+	}
+	if !inc.IsOpen() {
+		return nil, ErrIncidentResolved
+	}
+
+	inc.Status = IncidentStatusResolved
+	inc.Resolution = &resolution
+	inc.ResolvedBy = actorID
+	inc.ResolvedAt = &now
+	if err := s.repo.UpdateIncident(ctx, inc, "TPS_INCIDENT_RESOLVED", actorID); err != nil {
+		return nil, err
+	}
+	return inc, nil
+}
+
+// Comment adds a comment to an incident. Callers scope the incident first.
+func (s *IncidentService) Comment(ctx context.Context, inc *Incident, req IncidentCommentRequest, authorID *int64) (*IncidentComment, error) {
+	body := strings.TrimSpace(req.Body)
+	if !validIncidentText(body) {
+		return nil, ErrInvalidIncidentText
+	}
+	c := &IncidentComment{IncidentID: inc.ID, AuthorID: authorID, Body: body}
+	if err := s.repo.AddIncidentComment(ctx, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// AttachPhoto attaches a PNG, JPEG or WebP photo to an incident, replacing
+// the previous one
+func (s *IncidentService) AttachPhoto(ctx context.Context, inc *Incident, data []byte) error {
+	if len(data) > MaxIncidentPhotoSize {
+		return ErrIncidentPhotoTooLarge
+	}
+	mime := mimetype.Detect(data)
+	if !mime.Is("image/png") && !mime.Is("image/jpeg") && !mime.Is("image/webp") {
+		return ErrInvalidIncidentPhoto
+	}
+	if err := s.repo.SetIncidentPhoto(ctx, inc.ID, &IncidentPhoto{ContentType: mime.String(), Data: data}); err != nil {
+		return err
+	}
+	inc.HasPhoto = true
+	return nil
+}
+
+func (s *IncidentService) Photo(ctx context.Context, inc *Incident) (*IncidentPhoto, error) {
+	return s.repo.GetIncidentPhoto(ctx, inc.ID)
+}
+
+func validIncidentText(s string) bool {
+	return s != "" && utf8.RuneCountInString(s) <= maxIncidentText
+}
@@ -0,0 +1,343 @@
+package tps
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"pemira-api/internal/errcatalog"
+	"pemira-api/internal/http/response"
+)
+
+// IncidentHandler serves the incident log: operators report incidents on
+// the panel of their TPS, admins triage them for the whole election
+type IncidentHandler struct {
+	panel *PanelService
+	svc   *IncidentService
+}
+
+func NewIncidentHandler(panel *PanelService, svc *IncidentService) *IncidentHandler {
+	return &IncidentHandler{panel: panel, svc: svc}
+}
+
+// POST /admin/elections/{electionID}/tps/{tpsID}/incidents
+func (h *IncidentHandler) Report(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	electionID, tpsID, ok := panelScope(w, r, h.panel)
+	if !ok {
+		return
+	}
+
+	var req ReportIncidentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
+		return
+	}
+
+	inc, err := h.svc.Report(ctx, electionID, tpsID, req, actorID(ctx))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	response.Success(w, http.StatusCreated, inc)
+}
+
+// GET /admin/elections/{electionID}/tps/{tpsID}/incidents
+func (h *IncidentHandler) PanelList(w http.ResponseWriter, r *http.Request) {
+	electionID, tpsID, ok := panelScope(w, r, h.panel)
+	if !ok {
+		return
+	}
+
+	f := incidentFilter(r)
+	f.TPSID = tpsID
+	items, err := h.svc.List(r.Context(), electionID, f)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	response.Success(w, http.StatusOK, items)
+}
+
+// GET /admin/elections/{electionID}/tps/{tpsID}/incidents/{incidentID}
+func (h *IncidentHandler) PanelGet(w http.ResponseWriter, r *http.Request) {
+	inc, ok := h.panelIncident(w, r)
+	if !ok {
+		return
+	}
+	response.Success(w, http.StatusOK, inc)
+}
+
+// POST /admin/elections/{electionID}/tps/{tpsID}/incidents/{incidentID}/comments
+func (h *IncidentHandler) PanelComment(w http.ResponseWriter, r *http.Request) {
+	inc, ok := h.panelIncident(w, r)
+	if !ok {
+		return
+	}
+	h.comment(w, r, inc)
+}
+
+// PUT /admin/elections/{electionID}/tps/{tpsID}/incidents/{incidentID}/photo
+func (h *IncidentHandler) UploadPhoto(w http.ResponseWriter, r *http.Request) {
+	inc, ok := h.panelIncident(w, r)
+	if !ok {
+		return
+	}
+
+	if err := r.ParseMultipartForm(MaxIncidentPhotoSize + (512 << 10)); err != nil {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.UploadFormError)
+		return
+	}
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.FileRequired)
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, MaxIncidentPhotoSize+1))
+	if err != nil {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.UploadFileError)
+		return
+	}
+	if err := h.svc.AttachPhoto(r.Context(), inc, data); err != nil {
+		h.handleError(w, err)
+		return
+	}
+	response.Success(w, http.StatusOK, inc)
+}
+
+// GET /admin/elections/{electionID}/tps/{tpsID}/incidents/{incidentID}/photo
+func (h *IncidentHandler) PanelPhoto(w http.ResponseWriter, r *http.Request) {
+	inc, ok := h.panelIncident(w, r)
+	if !ok {
+		return
+	}
+	h.photo(w, r, inc)
+}
+
+// GET /admin/elections/{electionID}/tps/incidents
+func (h *IncidentHandler) List(w http.ResponseWriter, r *http.Request) {
+	electionID, ok := allocationElectionID(w, r)
+	if !ok {
+		return
+	}
+
+	f := incidentFilter(r)
+	if raw := r.URL.Query().Get("tps_id"); raw != "" {
+		tpsID, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || tpsID <= 0 {
+			response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("tps_id"))
+			return
+		}
+		f.TPSID = tpsID
+	}
+
+	items, err := h.svc.List(r.Context(), electionID, f)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	response.Success(w, http.StatusOK, items)
+}
+
+// GET /admin/elections/{electionID}/tps/incidents/{incidentID}
+func (h *IncidentHandler) Get(w http.ResponseWriter, r *http.Request) {
+	inc, ok := h.adminIncident(w, r)
+	if !ok {
+		return
+	}
+	response.Success(w, http.StatusOK, inc)
+}
+
+// PUT /admin/elections/{electionID}/tps/incidents/{incidentID}
+func (h *IncidentHandler) Triage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	electionID, incidentID, ok := h.adminParams(w, r)
+	if !ok {
+		return
+	}
+
+	var req TriageIncidentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
+		return
+	}
+
+	inc, err := h.svc.Triage(ctx, electionID, incidentID, req, actorID(ctx))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	response.Success(w, http.StatusOK, inc)
+}
+
+// PUT /admin/elections/{electionID}/tps/incidents/{incidentID}/assignee
+func (h *IncidentHandler) Assign(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	electionID, incidentID, ok := h.adminParams(w, r)
+	if !ok {
+		return
+	}
+
+	var req AssignIncidentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
+		return
+	}
+
+	inc, err := h.svc.Assign(ctx, electionID, incidentID, req.AssignedTo, actorID(ctx))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	response.Success(w, http.StatusOK, inc)
+}
+
+// POST /admin/elections/{electionID}/tps/incidents/{incidentID}/resolve
+func (h *IncidentHandler) Resolve(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	electionID, incidentID, ok := h.adminParams(w, r)
+	if !ok {
+		return
+	}
+
+	var req ResolveIncidentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
+		return
+	}
+
+	inc, err := h.svc.Resolve(ctx, electionID, incidentID, req, actorID(ctx), time.Now())
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	response.Success(w, http.StatusOK, inc)
+}
+
+// POST /admin/elections/{electionID}/tps/incidents/{incidentID}/comments
+func (h *IncidentHandler) Comment(w http.ResponseWriter, r *http.Request) {
+	inc, ok := h.adminIncident(w, r)
+	if !ok {
+		return
+	}
+	h.comment(w, r, inc)
+}
+
+// GET /admin/elections/{electionID}/tps/incidents/{incidentID}/photo
+func (h *IncidentHandler) Photo(w http.ResponseWriter, r *http.Request) {
+	inc, ok := h.adminIncident(w, r)
+	if !ok {
+		return
+	}
+	h.photo(w, r, inc)
+}
+
+func (h *IncidentHandler) comment(w http.ResponseWriter, r *http.Request, inc *Incident) {
+	ctx := r.Context()
+	var req IncidentCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
+		return
+	}
+
+	c, err := h.svc.Comment(ctx, inc, req, actorID(ctx))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	response.Success(w, http.StatusCreated, c)
+}
+
+func (h *IncidentHandler) photo(w http.ResponseWriter, r *http.Request, inc *Incident) {
+	photo, err := h.svc.Photo(r.Context(), inc)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", photo.ContentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(photo.Data)))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(photo.Data)
+}
+
+// panelIncident loads an incident of the TPS in the panel route
+func (h *IncidentHandler) panelIncident(w http.ResponseWriter, r *http.Request) (*Incident, bool) {
+	electionID, tpsID, ok := panelScope(w, r, h.panel)
+	if !ok {
+		return nil, false
+	}
+	incidentID, ok := incidentIDParam(w, r)
+	if !ok {
+		return nil, false
+	}
+
+	inc, err := h.svc.GetForTPS(r.Context(), electionID, tpsID, incidentID)
+	if err != nil {
+		h.handleError(w, err)
+		return nil, false
+	}
+	return inc, true
+}
+
+// adminIncident loads an incident of the election in the admin route
+func (h *IncidentHandler) adminIncident(w http.ResponseWriter, r *http.Request) (*Incident, bool) {
+	electionID, incidentID, ok := h.adminParams(w, r)
+	if !ok {
+		return nil, false
+	}
+
+	inc, err := h.svc.Get(r.Context(), electionID, incidentID)
+	if err != nil {
+		h.handleError(w, err)
+		return nil, false
+	}
+	return inc, true
+}
+
+func (h *IncidentHandler) adminParams(w http.ResponseWriter, r *http.Request) (int64, int64, bool) {
+	electionID, ok := allocationElectionID(w, r)
+	if !ok {
+		return 0, 0, false
+	}
+	incidentID, ok := incidentIDParam(w, r)
+	if !ok {
+		return 0, 0, false
+	}
+	return electionID, incidentID, true
+}
+
+func incidentIDParam(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	incidentID, err := strconv.ParseInt(chi.URLParam(r, "incidentID"), 10, 64)
+	if err != nil || incidentID <= 0 {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("incidentID"))
+		return 0, false
+	}
+	return incidentID, true
+}
+
+// incidentFilter reads status, severity, category and open=true from the
+// query string
+func incidentFilter(r *http.Request) IncidentFilter {
+	q := r.URL.Query()
+	return IncidentFilter{
+		Status:   q.Get("status"),
+		Severity: q.Get("severity"),
+		Category: q.Get("category"),
+		OpenOnly: q.Get("open") == "true",
+	}
+}
+
+func (h *IncidentHandler) handleError(w http.ResponseWriter, err error) {
+	if response.DomainError(w, err) {
+		return
+	}
+	slog.Error("tps incident handler error", "error", err)
+	response.InternalServerError(w, "INTERNAL_ERROR", errcatalog.InternalError)
+}
@@ -0,0 +1,264 @@
+package tps
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"pemira-api/internal/audit"
+)
+
+type PgIncidentRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPgIncidentRepository(db *pgxpool.Pool) *PgIncidentRepository {
+	return &PgIncidentRepository{db: db}
+}
+
+const incidentColumns = `
+	i.id, i.election_id, i.tps_id, t.code, t.name,
+	i.category, i.severity, i.status, i.description,
+	i.voter_id, i.checkin_id, i.photo_data IS NOT NULL,
+	i.reported_by, i.assigned_to, i.resolution, i.resolved_by, i.resolved_at,
+	i.created_at, i.updated_at
+`
+
+func scanIncident(row pgx.Row, inc *Incident) error {
+	return row.Scan(
+		&inc.ID, &inc.ElectionID, &inc.TPSID, &inc.TPSCode, &inc.TPSName,
+		&inc.Category, &inc.Severity, &inc.Status, &inc.Description,
+		&inc.VoterID, &inc.CheckinID, &inc.HasPhoto,
+		&inc.ReportedBy, &inc.AssignedTo, &inc.Resolution, &inc.ResolvedBy, &inc.ResolvedAt,
+		&inc.CreatedAt, &inc.UpdatedAt,
+	)
+}
+
+func (r *PgIncidentRepository) CreateIncident(ctx context.Context, inc *Incident) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := tx.QueryRow(ctx, `
+		INSERT INTO tps_incidents
+			(election_id, tps_id, category, severity, status, description, voter_id, checkin_id, reported_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, created_at, updated_at
+	`, inc.ElectionID, inc.TPSID, inc.Category, inc.Severity, inc.Status, inc.Description,
+		inc.VoterID, inc.CheckinID, inc.ReportedBy,
+	).Scan(&inc.ID, &inc.CreatedAt, &inc.UpdatedAt); err != nil {
+		return err
+	}
+
+	if err := auditIncident(ctx, tx, inc, "TPS_INCIDENT_REPORTED", inc.ReportedBy); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *PgIncidentRepository) GetIncident(ctx context.Context, electionID, incidentID int64) (*Incident, error) {
+	var inc Incident
+	err := scanIncident(r.db.QueryRow(ctx, `
+		SELECT `+incidentColumns+`
+		FROM tps_incidents i
+		JOIN tps t ON t.id = i.tps_id
+		WHERE i.id = $1 AND i.election_id = $2
+	`, incidentID, electionID), &inc)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrIncidentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT c.id, c.incident_id, c.author_id, COALESCE(u.full_name, u.username, ''), c.body, c.created_at
+		FROM tps_incident_comments c
+		LEFT JOIN user_accounts u ON u.id = c.author_id
+		WHERE c.incident_id = $1
+		ORDER BY c.created_at, c.id
+	`, incidentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var c IncidentComment
+		if err := rows.Scan(&c.ID, &c.IncidentID, &c.AuthorID, &c.AuthorName, &c.Body, &c.CreatedAt); err != nil {
+			return nil, err
+		}
+		inc.Comments = append(inc.Comments, c)
+	}
+	return &inc, rows.Err()
+}
+
+func (r *PgIncidentRepository) ListIncidents(ctx context.Context, electionID int64, f IncidentFilter) ([]Incident, error) {
+	where := []string{"i.election_id = $1"}
+	args := []any{electionID}
+	add := func(cond string, v any) {
+		args = append(args, v)
+		where = append(where, fmt.Sprintf(cond, len(args)))
+	}
+	if f.TPSID > 0 {
+		add("i.tps_id = $%d", f.TPSID)
+	}
+	if f.Status != "" {
+		add("i.status = $%d", f.Status)
+	}
+	if f.Severity != "" {
+		add("i.severity = $%d", f.Severity)
+	}
+	if f.Category != "" {
+		add("i.category = $%d", f.Category)
+	}
+	if f.OpenOnly {
+		where = append(where, "i.status <> 'RESOLVED'")
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT `+incidentColumns+`
+		FROM tps_incidents i
+		JOIN tps t ON t.id = i.tps_id
+		WHERE `+strings.Join(where, " AND ")+`
+		ORDER BY i.created_at DESC, i.id DESC
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []Incident
+	for rows.Next() {
+		var inc Incident
+		if err := scanIncident(rows, &inc); err != nil {
+			return nil, err
+		}
+		items = append(items, inc)
+	}
+	return items, rows.Err()
+}
+
+func (r *PgIncidentRepository) UpdateIncident(ctx context.Context, inc *Incident, action string, actorID *int64) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `
+		UPDATE tps_incidents
+		SET severity = $3, status = $4, assigned_to = $5,
+		    resolution = $6, resolved_by = $7, resolved_at = $8,
+		    updated_at = NOW()
+		WHERE id = $1 AND election_id = $2
+		RETURNING updated_at
+	`, inc.ID, inc.ElectionID, inc.Severity, inc.Status, inc.AssignedTo,
+		inc.Resolution, inc.ResolvedBy, inc.ResolvedAt,
+	).Scan(&inc.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrIncidentNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := auditIncident(ctx, tx, inc, action, actorID); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *PgIncidentRepository) AddIncidentComment(ctx context.Context, c *IncidentComment) error {
+	return r.db.QueryRow(ctx, `
+		WITH inserted AS (
+			INSERT INTO tps_incident_comments (incident_id, author_id, body)
+			VALUES ($1, $2, $3)
+			RETURNING id, created_at
+		)
+		SELECT i.id, i.created_at, COALESCE(u.full_name, u.username, '')
+		FROM inserted i
+		LEFT JOIN user_accounts u ON u.id = $2
+	`, c.IncidentID, c.AuthorID, c.Body).Scan(&c.ID, &c.CreatedAt, &c.AuthorName)
+}
+
+func (r *PgIncidentRepository) SetIncidentPhoto(ctx context.Context, incidentID int64, photo *IncidentPhoto) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE tps_incidents
+		SET photo_data = $2, photo_content_type = $3, photo_size = $4, updated_at = NOW()
+		WHERE id = $1
+	`, incidentID, photo.Data, photo.ContentType, len(photo.Data))
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrIncidentNotFound
+	}
+	return nil
+}
+
+func (r *PgIncidentRepository) GetIncidentPhoto(ctx context.Context, incidentID int64) (*IncidentPhoto, error) {
+	var photo IncidentPhoto
+	var contentType *string
+	err := r.db.QueryRow(ctx, `
+		SELECT photo_content_type, photo_data FROM tps_incidents WHERE id = $1
+	`, incidentID).Scan(&contentType, &photo.Data)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrIncidentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if photo.Data == nil || contentType == nil {
+		return nil, ErrIncidentPhotoNotFound
+	}
+	photo.ContentType = *contentType
+	return &photo, nil
+}
+
+func (r *PgIncidentRepository) CheckinVoter(ctx context.Context, tpsID, checkinID int64) (int64, error) {
+	var voterID int64
+	err := r.db.QueryRow(ctx, `
+		SELECT voter_id FROM tps_checkins WHERE id = $1 AND tps_id = $2
+	`, checkinID, tpsID).Scan(&voterID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, ErrCheckinNotFound
+	}
+	return voterID, err
+}
+
+func (r *PgIncidentRepository) VoterExists(ctx context.Context, voterID int64) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM voters WHERE id = $1)`, voterID).Scan(&exists)
+	return exists, err
+}
+
+func (r *PgIncidentRepository) UserExists(ctx context.Context, userID int64) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM user_accounts WHERE id = $1)`, userID).Scan(&exists)
+	return exists, err
+}
+
+// auditIncident records a change to an incident in audit_logs
+func auditIncident(ctx context.Context, tx pgx.Tx, inc *Incident, action string, actorID *int64) error {
+	return audit.Write(ctx, tx, audit.Entry{
+		ActorUserID: actorID,
+		Action:      action,
+		EntityType:  "TPS_INCIDENT",
+		EntityID:    inc.ID,
+		Metadata: map[string]any{
+			"election_id": inc.ElectionID,
+			"tps_id":      inc.TPSID,
+			"category":    inc.Category,
+			"severity":    inc.Severity,
+			"status":      inc.Status,
+			"assigned_to": inc.AssignedTo,
+		},
+	})
+}
@@ -0,0 +1,255 @@
+package tps_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"pemira-api/internal/tps"
+)
+
+// incidentRepo is an in-memory IncidentRepository. checkins maps check-in
+// IDs made at TPS 7 to their voter.
+type incidentRepo struct {
+	incidents map[int64]*tps.Incident
+	photos    map[int64]*tps.IncidentPhoto
+	checkins  map[int64]int64
+	voters    map[int64]bool
+	users     map[int64]bool
+	actions   []string
+	nextID    int64
+}
+
+func newIncidentRepo() *incidentRepo {
+	return &incidentRepo{
+		incidents: map[int64]*tps.Incident{},
+		photos:    map[int64]*tps.IncidentPhoto{},
+		checkins:  map[int64]int64{31: 501},
+		voters:    map[int64]bool{501: true, 502: true},
+		users:     map[int64]bool{42: true, 43: true},
+	}
+}
+
+func (r *incidentRepo) CreateIncident(ctx context.Context, inc *tps.Incident) error {
+	r.nextID++
+	inc.ID = r.nextID
+	saved := *inc
+	r.incidents[inc.ID] = &saved
+	r.actions = append(r.actions, "TPS_INCIDENT_REPORTED")
+	return nil
+}
+
+func (r *incidentRepo) GetIncident(ctx context.Context, electionID, incidentID int64) (*tps.Incident, error) {
+	inc, ok := r.incidents[incidentID]
+	if !ok || inc.ElectionID != electionID {
+		return nil, tps.ErrIncidentNotFound
+	}
+	out := *inc
+	return &out, nil
+}
+
+func (r *incidentRepo) ListIncidents(ctx context.Context, electionID int64, f tps.IncidentFilter) ([]tps.Incident, error) {
+	var out []tps.Incident
+	for id := int64(1); id <= r.nextID; id++ {
+		inc, ok := r.incidents[id]
+		if !ok || inc.ElectionID != electionID || (f.TPSID > 0 && inc.TPSID != f.TPSID) || (f.OpenOnly && !inc.IsOpen()) {
+			continue
+		}
+		out = append(out, *inc)
+	}
+	return out, nil
+}
+
+func (r *incidentRepo) UpdateIncident(ctx context.Context, inc *tps.Incident, action string, actorID *int64) error {
+	saved := *inc
+	r.incidents[inc.ID] = &saved
+	r.actions = append(r.actions, action)
+	return nil
+}
+
+func (r *incidentRepo) AddIncidentComment(ctx context.Context, c *tps.IncidentComment) error {
+	inc := r.incidents[c.IncidentID]
+	c.ID = int64(len(inc.Comments) + 1)
+	inc.Comments = append(inc.Comments, *c)
+	return nil
+}
+
+func (r *incidentRepo) SetIncidentPhoto(ctx context.Context, incidentID int64, photo *tps.IncidentPhoto) error {
+	r.photos[incidentID] = photo
+	return nil
+}
+
+func (r *incidentRepo) GetIncidentPhoto(ctx context.Context, incidentID int64) (*tps.IncidentPhoto, error) {
+	photo, ok := r.photos[incidentID]
+	if !ok {
+		return nil, tps.ErrIncidentPhotoNotFound
+	}
+	return photo, nil
+}
+
+func (r *incidentRepo) CheckinVoter(ctx context.Context, tpsID, checkinID int64) (int64, error) {
+	voterID, ok := r.checkins[checkinID]
+	if !ok || tpsID != 7 {
+		return 0, tps.ErrCheckinNotFound
+	}
+	return voterID, nil
+}
+
+func (r *incidentRepo) VoterExists(ctx context.Context, voterID int64) (bool, error) {
+	return r.voters[voterID], nil
+}
+
+func (r *incidentRepo) UserExists(ctx context.Context, userID int64) (bool, error) {
+	return r.users[userID], nil
+}
+
+func TestIncident_Report(t *testing.T) {
+	ctx := context.Background()
+	operator := int64(43)
+	svc := tps.NewIncidentService(newIncidentRepo())
+
+	inc, err := svc.Report(ctx, 1, 7, tps.ReportIncidentRequest{
+		Category:    "dpt_dispute",
+		Description: "  Pemilih mengaku terdaftar di DPT tetapi ditolak  ",
+		CheckinID:   ptrInt64(31),
+	}, &operator)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inc.Category != "DPT_DISPUTE" || inc.Severity != tps.IncidentSeverityMedium || inc.Status != tps.IncidentStatusOpen {
+		t.Fatalf("incident = %+v", inc)
+	}
+	if inc.VoterID == nil || *inc.VoterID != 501 || inc.Description != "Pemilih mengaku terdaftar di DPT tetapi ditolak" {
+		t.Fatalf("voter %v, description %q", inc.VoterID, inc.Description)
+	}
+
+	tests := []struct {
+		name string
+		req  tps.ReportIncidentRequest
+		want error
+	}{
+		{"unknown category", tps.ReportIncidentRequest{Category: "FLOOD", Description: "banjir"}, tps.ErrInvalidCategory},
+		{"unknown severity", tps.ReportIncidentRequest{Category: "OTHER", Severity: "URGENT", Description: "x"}, tps.ErrInvalidSeverity},
+		{"no description", tps.ReportIncidentRequest{Category: "OTHER", Description: "   "}, tps.ErrInvalidIncidentText},
+		{"check-in of another TPS", tps.ReportIncidentRequest{Category: "OTHER", Description: "x", CheckinID: ptrInt64(99)}, tps.ErrInvalidIncidentLink},
+		{"voter of another check-in", tps.ReportIncidentRequest{Category: "OTHER", Description: "x", CheckinID: ptrInt64(31), VoterID: ptrInt64(502)}, tps.ErrInvalidIncidentLink},
+		{"unknown voter", tps.ReportIncidentRequest{Category: "OTHER", Description: "x", VoterID: ptrInt64(999)}, tps.ErrInvalidIncidentLink},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := svc.Report(ctx, 1, 7, tt.req, &operator); !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestIncident_TriageAssignResolve(t *testing.T) {
+	ctx := context.Background()
+	admin := int64(42)
+	repo := newIncidentRepo()
+	svc := tps.NewIncidentService(repo)
+
+	inc, err := svc.Report(ctx, 1, 7, tps.ReportIncidentRequest{Category: "POWER_OUTAGE", Severity: "high", Description: "Listrik padam"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := svc.Triage(ctx, 1, inc.ID, tps.TriageIncidentRequest{Status: "RESOLVED"}, &admin); !errors.Is(err, tps.ErrInvalidIncidentStatus) {
+		t.Fatalf("resolve through triage: err = %v", err)
+	}
+	inc, err = svc.Triage(ctx, 1, inc.ID, tps.TriageIncidentRequest{Severity: "critical"}, &admin)
+	if err != nil || inc.Severity != tps.IncidentSeverityCritical {
+		t.Fatalf("triage = %+v, err = %v", inc, err)
+	}
+
+	if _, err := svc.Assign(ctx, 1, inc.ID, ptrInt64(999), &admin); !errors.Is(err, tps.ErrInvalidAssignee) {
+		t.Fatalf("unknown assignee: err = %v", err)
+	}
+	inc, err = svc.Assign(ctx, 1, inc.ID, ptrInt64(43), &admin)
+	if err != nil || inc.Status != tps.IncidentStatusInProgress || *inc.AssignedTo != 43 {
+		t.Fatalf("assign = %+v, err = %v", inc, err)
+	}
+
+	if _, err := svc.Resolve(ctx, 1, inc.ID, tps.ResolveIncidentRequest{Resolution: " "}, &admin, time.Now()); !errors.Is(err, tps.ErrResolutionMissing) {
+		t.Fatalf("no resolution: err = %v", err)
+	}
+	inc, err = svc.Resolve(ctx, 1, inc.ID, tps.ResolveIncidentRequest{Resolution: "Genset dinyalakan"}, &admin, time.Now())
+	if err != nil || inc.IsOpen() || *inc.ResolvedBy != admin || inc.ResolvedAt == nil {
+		t.Fatalf("resolve = %+v, err = %v", inc, err)
+	}
+	if _, err := svc.Resolve(ctx, 1, inc.ID, tps.ResolveIncidentRequest{Resolution: "lagi"}, &admin, time.Now()); !errors.Is(err, tps.ErrIncidentResolved) {
+		t.Fatalf("resolve twice: err = %v", err)
+	}
+	if _, err := svc.Assign(ctx, 1, inc.ID, nil, &admin); !errors.Is(err, tps.ErrIncidentResolved) {
+		t.Fatalf("assign resolved: err = %v", err)
+	}
+
+	// Reopening clears the resolution
+	inc, err = svc.Triage(ctx, 1, inc.ID, tps.TriageIncidentRequest{Status: "open"}, &admin)
+	if err != nil || !inc.IsOpen() || inc.Resolution != nil || inc.ResolvedAt != nil {
+		t.Fatalf("reopen = %+v, err = %v", inc, err)
+	}
+
+	want := []string{"TPS_INCIDENT_REPORTED", "TPS_INCIDENT_TRIAGED", "TPS_INCIDENT_ASSIGNED", "TPS_INCIDENT_RESOLVED", "TPS_INCIDENT_TRIAGED"}
+	if len(repo.actions) != len(want) {
+		t.Fatalf("actions = %v", repo.actions)
+	}
+	for i := range want {
+		if repo.actions[i] != want[i] {
+			t.Fatalf("actions = %v", repo.actions)
+		}
+	}
+}
+
+func TestIncident_ScopedToTPS(t *testing.T) {
+	ctx := context.Background()
+	svc := tps.NewIncidentService(newIncidentRepo())
+
+	inc, err := svc.Report(ctx, 1, 7, tps.ReportIncidentRequest{Category: "NETWORK", Description: "Sinyal hilang"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.GetForTPS(ctx, 1, 8, inc.ID); !errors.Is(err, tps.ErrIncidentNotFound) {
+		t.Fatalf("other TPS: err = %v", err)
+	}
+	if _, err := svc.Get(ctx, 2, inc.ID); !errors.Is(err, tps.ErrIncidentNotFound) {
+		t.Fatalf("other election: err = %v", err)
+	}
+
+	items, err := svc.List(ctx, 1, tps.IncidentFilter{TPSID: 8})
+	if err != nil || items == nil || len(items) != 0 {
+		t.Fatalf("items = %v, err = %v", items, err)
+	}
+}
+
+func TestIncident_AttachPhoto(t *testing.T) {
+	ctx := context.Background()
+	repo := newIncidentRepo()
+	svc := tps.NewIncidentService(repo)
+	inc, err := svc.Report(ctx, 1, 7, tps.ReportIncidentRequest{Category: "EQUIPMENT", Description: "Scanner rusak"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := svc.AttachPhoto(ctx, inc, []byte("not an image")); !errors.Is(err, tps.ErrInvalidIncidentPhoto) {
+		t.Fatalf("text file: err = %v", err)
+	}
+	if err := svc.AttachPhoto(ctx, inc, make([]byte, tps.MaxIncidentPhotoSize+1)); !errors.Is(err, tps.ErrIncidentPhotoTooLarge) {
+		t.Fatalf("oversized: err = %v", err)
+	}
+	if _, err := svc.Photo(ctx, inc); !errors.Is(err, tps.ErrIncidentPhotoNotFound) {
+		t.Fatalf("no photo yet: err = %v", err)
+	}
+
+	png := append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte{0}, 16)...)
+	if err := svc.AttachPhoto(ctx, inc, png); err != nil {
+		t.Fatal(err)
+	}
+	photo, err := svc.Photo(ctx, inc)
+	if err != nil || photo.ContentType != "image/png" || !inc.HasPhoto {
+		t.Fatalf("photo = %+v, err = %v", photo, err)
+	}
+}
@@ -0,0 +1,42 @@
+package tps
+
+import (
+	"net/http"
+
+	"pemira-api/internal/errcatalog"
+)
+
+// Messages the TPS handlers answer with, each under its own code in the
+// error catalog
+var (
+	tpsListFailed            = errcatalog.Define("TPS_LIST_FAILED", http.StatusInternalServerError, "Gagal mengambil daftar TPS.", "Failed to fetch the TPS list.")
+	tpsCreateFailed          = errcatalog.Define("TPS_CREATE_FAILED", http.StatusInternalServerError, "Gagal membuat TPS.", "Failed to create the TPS.")
+	tpsFetchFailed           = errcatalog.Define("TPS_FETCH_FAILED", http.StatusInternalServerError, "Gagal mengambil detail TPS.", "Failed to fetch the TPS.")
+	tpsUpdateFailed          = errcatalog.Define("TPS_UPDATE_FAILED", http.StatusInternalServerError, "Gagal mengubah TPS.", "Failed to update the TPS.")
+	tpsDeleteFailed          = errcatalog.Define("TPS_DELETE_FAILED", http.StatusInternalServerError, "Gagal menghapus TPS.", "Failed to delete the TPS.")
+	operatorsFetchFailed     = errcatalog.Define("OPERATORS_FETCH_FAILED", http.StatusInternalServerError, "Gagal mengambil operator TPS.", "Failed to fetch TPS operators.")
+	operatorCreateFailed     = errcatalog.Define("OPERATOR_CREATE_FAILED", http.StatusInternalServerError, "Gagal membuat operator TPS.", "Failed to create the TPS operator.")
+	operatorDeleteFailed     = errcatalog.Define("OPERATOR_DELETE_FAILED", http.StatusInternalServerError, "Gagal menghapus operator TPS.", "Failed to delete the TPS operator.")
+	allocationFetchFailed    = errcatalog.Define("ALLOCATION_FETCH_FAILED", http.StatusInternalServerError, "Gagal mengambil alokasi TPS.", "Failed to fetch the TPS allocation.")
+	activityFetchFailed      = errcatalog.Define("TPS_ACTIVITY_FAILED", http.StatusInternalServerError, "Gagal mengambil aktivitas TPS.", "Failed to fetch TPS activity.")
+	monitorFetchFailed       = errcatalog.Define("TPS_MONITOR_FAILED", http.StatusInternalServerError, "Gagal mengambil monitoring TPS.", "Failed to fetch TPS monitoring.")
+	qrMetadataFailed         = errcatalog.Define("QR_METADATA_FAILED", http.StatusInternalServerError, "Gagal mengambil metadata QR.", "Failed to fetch the QR metadata.")
+	qrRotateFailed           = errcatalog.Define("QR_ROTATE_FAILED", http.StatusInternalServerError, "Gagal rotate QR.", "Failed to rotate the QR code.")
+	qrPrintFailed            = errcatalog.Define("QR_PRINT_FAILED", http.StatusInternalServerError, "Gagal mengambil data cetak QR.", "Failed to fetch the QR print data.")
+	tpsScopeInvalid          = errcatalog.Define("TPS_SCOPE_INVALID", http.StatusBadRequest, "election_id atau tps_id tidak valid.", "Invalid election_id or tps_id.")
+	operatorIDInvalid        = errcatalog.Define("OPERATOR_ID_INVALID", http.StatusBadRequest, "ID user operator tidak valid.", "Invalid operator user ID.")
+	checkinStatusFailed      = errcatalog.Define("CHECKIN_STATUS_FAILED", http.StatusInternalServerError, "Gagal mengambil status check-in.", "Failed to fetch the check-in status.")
+	checkinQueueFailed       = errcatalog.Define("CHECKIN_QUEUE_FAILED", http.StatusInternalServerError, "Gagal mengambil antrean check-in.", "Failed to fetch the check-in queue.")
+	voterUnauthenticated     = errcatalog.Define("VOTER_UNAUTHENTICATED", http.StatusUnauthorized, "Voter tidak terautentikasi", "The voter is not authenticated")
+	checkinRequestInvalid    = errcatalog.Define("CHECKIN_REQUEST_INVALID", http.StatusBadRequest, "Request tidak valid", "Invalid request")
+	operatorUnauthenticated  = errcatalog.Define("OPERATOR_UNAUTHENTICATED", http.StatusUnauthorized, "Operator tidak terautentikasi", "The operator is not authenticated")
+	rejectReasonRequired     = errcatalog.Define("REASON_REQUIRED", http.StatusBadRequest, "Alasan penolakan harus diisi", "A rejection reason is required")
+	registrationCodeRequired = errcatalog.Define("REGISTRATION_CODE_REQUIRED", http.StatusUnprocessableEntity, "Kode registrasi wajib diisi.", "The registration code is required.")
+	scanPayloadRequired      = errcatalog.Define("SCAN_PAYLOAD_REQUIRED", http.StatusUnprocessableEntity, "qr_payload atau registration_code wajib diisi.", "qr_payload or registration_code is required.")
+	tpsListAdminsOnly        = errcatalog.Define("TPS_LIST_ADMINS_ONLY", http.StatusForbidden, "Hanya admin yang dapat mengakses daftar TPS.", "Only admins can access the TPS list.")
+	notTPSOperator           = errcatalog.Define("NOT_TPS_OPERATOR", http.StatusForbidden, "Akun ini bukan operator TPS.", "This account is not a TPS operator.")
+	operatorTPSMissing       = errcatalog.Define("OPERATOR_TPS_NOT_ASSIGNED", http.StatusForbidden, "Akun operator belum terhubung ke TPS.", "The operator account is not linked to a TPS.")
+	panelLoginFailed         = errcatalog.Define("PANEL_LOGIN_FAILED", http.StatusInternalServerError, "Gagal login.", "Login failed.")
+	registrationQRUnknown    = errcatalog.Define("INVALID_REGISTRATION_QR", http.StatusBadRequest, "Kode QR pendaftaran tidak dikenali.", "The registration QR code is not recognized.")
+	registrationQRElection   = errcatalog.Define("REGISTRATION_QR_ELECTION_MISMATCH", http.StatusBadRequest, "Kode QR tidak sesuai dengan pemilu ini.", "The QR code does not match this election.")
+)
@@ -1,9 +1,13 @@
 package tps
 
-import "pemira-api/internal/shared/constants"
+import (
+	"pemira-api/internal/rbac"
+	"pemira-api/internal/shared/constants"
+)
 
+// hasPanelAccess reports whether role may run any TPS panel on its own.
+// TPS-bound roles (KETUA_TPS, OPERATOR_PANEL) still need a panitia assignment.
 func hasPanelAccess(role string) bool {
-	return role == string(constants.RoleAdmin) ||
-		role == string(constants.RoleSuperAdmin) ||
-		role == string(constants.RoleTPSOperator)
+	r := constants.Role(role)
+	return rbac.RoleHas(r, rbac.PermTPSApproveCheckin) && !rbac.IsTPSBound(r)
 }
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"pemira-api/internal/auth"
+	"pemira-api/internal/errcatalog"
 	"pemira-api/internal/http/response"
 	"pemira-api/internal/shared/constants"
 )
@@ -28,52 +29,69 @@ func NewPanelAuthHandler(authSvc *auth.AuthService, repo Repository) *PanelAuthH
 func (h *PanelAuthHandler) PanelLogin(w http.ResponseWriter, r *http.Request) {
 	var req auth.LoginRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.BadRequest(w, "VALIDATION_ERROR", "Body tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
 		return
 	}
 
 	req.Username = strings.TrimSpace(req.Username)
 	if req.Username == "" || strings.TrimSpace(req.Password) == "" {
-		response.UnprocessableEntity(w, "VALIDATION_ERROR", "username dan password wajib diisi.")
+		response.UnprocessableEntity(w, "VALIDATION_ERROR", errcatalog.Required("username", "password"))
 		return
 	}
 
-	userAgent := r.Header.Get("User-Agent")
-	ipAddress := r.Header.Get("X-Real-IP")
-	if ipAddress == "" {
-		ipAddress = r.Header.Get("X-Forwarded-For")
-		if ipAddress != "" {
-			ipAddress = strings.Split(ipAddress, ",")[0]
+	userAgent, ipAddress := auth.ClientInfo(r)
+	loginResp, err := h.authService.Login(r.Context(), req, userAgent, ipAddress)
+	if err != nil {
+		var mfa *auth.MFARequiredError
+		if errors.As(err, &mfa) {
+			response.JSON(w, http.StatusOK, mfa.Challenge)
+			return
 		}
+		h.handleError(w, err)
+		return
 	}
-	if ipAddress == "" {
-		ipAddress = r.RemoteAddr
+
+	h.respondLogin(w, r, loginResp)
+}
+
+// PanelLoginMFA handles POST /tps-panel/auth/login/2fa, the second step for
+// operators with 2FA. Enrollment uses POST /auth/login/2fa/setup first.
+func (h *PanelAuthHandler) PanelLoginMFA(w http.ResponseWriter, r *http.Request) {
+	req, ok := auth.DecodeLoginMFA(w, r)
+	if !ok {
+		return
 	}
 
-	loginResp, err := h.authService.Login(r.Context(), req, userAgent, ipAddress)
+	userAgent, ipAddress := auth.ClientInfo(r)
+	loginResp, err := h.authService.CompleteLoginMFA(r.Context(), req, userAgent, ipAddress)
 	if err != nil {
 		h.handleError(w, err)
 		return
 	}
 
+	h.respondLogin(w, r, loginResp)
+}
+
+// respondLogin checks that the account operates an active TPS and writes the
+// panel login payload
+func (h *PanelAuthHandler) respondLogin(w http.ResponseWriter, r *http.Request, loginResp *auth.LoginResponse) {
 	if loginResp.User.Role != constants.RoleTPSOperator {
-		response.Error(w, http.StatusForbidden, "NOT_TPS_OPERATOR", "Akun ini bukan operator TPS.", nil)
+		response.Catalog(w, notTPSOperator)
 		return
 	}
 
 	if loginResp.User.TPSID == nil {
-		response.Error(w, http.StatusForbidden, "TPS_NOT_ASSIGNED", "Akun operator belum terhubung ke TPS.", nil)
+		response.Catalog(w, operatorTPSMissing)
 		return
 	}
 
 	tpsRow, err := h.tpsRepo.GetByID(r.Context(), *loginResp.User.TPSID)
 	if err != nil {
-		code, status := GetErrorCode(err)
-		response.Error(w, status, code, "TPS tidak ditemukan.", nil)
+		writeError(w, err)
 		return
 	}
 	if tpsRow.Status != StatusActive {
-		response.Error(w, http.StatusBadRequest, "TPS_INACTIVE", "TPS belum aktif.", nil)
+		writeError(w, ErrTPSInactive)
 		return
 	}
 
@@ -94,17 +112,18 @@ func (h *PanelAuthHandler) PanelLogin(w http.ResponseWriter, r *http.Request) {
 			},
 		},
 	}
+	if len(loginResp.RecoveryCodes) > 0 {
+		resp["recovery_codes"] = loginResp.RecoveryCodes
+	}
 
 	response.JSON(w, http.StatusOK, resp)
 }
 
 func (h *PanelAuthHandler) handleError(w http.ResponseWriter, err error) {
-	switch {
-	case errors.Is(err, auth.ErrInvalidCredentials):
-		response.Error(w, http.StatusUnauthorized, "INVALID_CREDENTIALS", "Username atau password salah.", nil)
-	case errors.Is(err, auth.ErrInactiveUser):
-		response.Error(w, http.StatusForbidden, "USER_INACTIVE", "Akun tidak aktif.", nil)
-	default:
-		response.InternalServerError(w, "INTERNAL_ERROR", "Gagal login.")
+	if auth.WriteAccountLocked(w, err) {
+		return
+	}
+	if !response.DomainError(w, err) {
+		response.Catalog(w, panelLoginFailed)
 	}
 }
@@ -2,14 +2,17 @@ package tps
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
 
 	"github.com/go-chi/chi/v5"
 
+	"pemira-api/internal/errcatalog"
 	"pemira-api/internal/http/response"
 	"pemira-api/internal/shared/ctxkeys"
+	"pemira-api/internal/telemetry"
 )
 
 type PanelHandler struct {
@@ -26,29 +29,28 @@ func (h *PanelHandler) Me(w http.ResponseWriter, r *http.Request) {
 
 	userID, ok := ctxkeys.GetUserID(ctx)
 	if !ok {
-		response.Unauthorized(w, "UNAUTHORIZED", "User tidak valid.")
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.InvalidUser)
 		return
 	}
 	tpsID, ok := ctxkeys.GetTPSID(ctx)
 	if !ok {
-		response.Forbidden(w, "TPS_ACCESS_DENIED", "Token tidak memiliki TPS.")
+		writeError(w, ErrTPSAccessDenied)
 		return
 	}
 
 	operator, err := h.svc.repo.GetOperatorInfo(ctx, userID)
 	if err != nil {
-		response.Error(w, http.StatusForbidden, "TPS_ACCESS_DENIED", "Akses ditolak.", nil)
+		response.Error(w, http.StatusForbidden, "TPS_ACCESS_DENIED", errcatalog.AccessDenied, nil)
 		return
 	}
 	if operator.TPSID == nil || *operator.TPSID != tpsID {
-		response.Error(w, http.StatusForbidden, "TPS_ACCESS_DENIED", "Akses ditolak.", nil)
+		response.Error(w, http.StatusForbidden, "TPS_ACCESS_DENIED", errcatalog.AccessDenied, nil)
 		return
 	}
 
 	tpsRow, err := h.svc.repo.GetByID(ctx, tpsID)
 	if err != nil {
-		code, status := GetErrorCode(err)
-		response.Error(w, status, code, "TPS tidak ditemukan.", nil)
+		writeError(w, err)
 		return
 	}
 
@@ -63,7 +65,7 @@ func (h *PanelHandler) Me(w http.ResponseWriter, r *http.Request) {
 			"location":   tpsRow.Location,
 			"open_time":  tpsRow.OpenTime,
 			"close_time": tpsRow.CloseTime,
-			"status":     h.svc.derivePanelStatus(tpsRow),
+			"status":     h.svc.derivePanelStatus(ctx, tpsRow),
 		},
 	}
 
@@ -75,32 +77,30 @@ func (h *PanelHandler) Dashboard(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	electionID, err := strconv.ParseInt(chi.URLParam(r, "electionID"), 10, 64)
 	if err != nil || electionID <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "electionId tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("electionId"))
 		return
 	}
 	tpsID, err := strconv.ParseInt(chi.URLParam(r, "tpsID"), 10, 64)
 	if err != nil || tpsID <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "tpsId tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("tpsId"))
 		return
 	}
 
 	role, _ := ctxkeys.GetUserRole(ctx)
 	tokenTPS, _ := ctxkeys.GetTPSID(ctx)
 	if role == "" {
-		response.Forbidden(w, "TPS_ACCESS_DENIED", "Akses ditolak.")
+		response.Forbidden(w, "TPS_ACCESS_DENIED", errcatalog.AccessDenied)
 		return
 	}
 
 	if _, err := h.svc.EnsureAccess(ctx, electionID, tpsID, role, &tokenTPS); err != nil {
-		code, status := GetErrorCode(err)
-		response.Error(w, status, code, err.Error(), nil)
+		writeError(w, err)
 		return
 	}
 
 	data, err := h.svc.Dashboard(ctx, tpsID)
 	if err != nil {
-		code, status := GetErrorCode(err)
-		response.Error(w, status, code, err.Error(), nil)
+		writeError(w, err)
 		return
 	}
 	response.JSON(w, http.StatusOK, data)
@@ -111,24 +111,23 @@ func (h *PanelHandler) ListCheckins(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	electionID, err := strconv.ParseInt(chi.URLParam(r, "electionID"), 10, 64)
 	if err != nil || electionID <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "electionId tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("electionId"))
 		return
 	}
 	tpsID, err := strconv.ParseInt(chi.URLParam(r, "tpsID"), 10, 64)
 	if err != nil || tpsID <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "tpsId tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("tpsId"))
 		return
 	}
 	role, _ := ctxkeys.GetUserRole(ctx)
 	tokenTPS, _ := ctxkeys.GetTPSID(ctx)
 	if role == "" {
-		response.Forbidden(w, "TPS_ACCESS_DENIED", "Akses ditolak.")
+		response.Forbidden(w, "TPS_ACCESS_DENIED", errcatalog.AccessDenied)
 		return
 	}
 
 	if _, err := h.svc.EnsureAccess(ctx, electionID, tpsID, role, &tokenTPS); err != nil {
-		code, status := GetErrorCode(err)
-		response.Error(w, status, code, err.Error(), nil)
+		writeError(w, err)
 		return
 	}
 
@@ -151,8 +150,7 @@ func (h *PanelHandler) ListCheckins(w http.ResponseWriter, r *http.Request) {
 
 	items, total, err := h.svc.ListCheckins(ctx, tpsID, status, search, limit, offset)
 	if err != nil {
-		code, statusCode := GetErrorCode(err)
-		response.Error(w, statusCode, code, err.Error(), nil)
+		writeError(w, err)
 		return
 	}
 
@@ -167,46 +165,40 @@ func (h *PanelHandler) GetCheckin(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	electionID, err := strconv.ParseInt(chi.URLParam(r, "electionID"), 10, 64)
 	if err != nil || electionID <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "electionId tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("electionId"))
 		return
 	}
 	tpsID, err := strconv.ParseInt(chi.URLParam(r, "tpsID"), 10, 64)
 	if err != nil || tpsID <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "tpsId tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("tpsId"))
 		return
 	}
 	role, _ := ctxkeys.GetUserRole(ctx)
 	tokenTPS, _ := ctxkeys.GetTPSID(ctx)
 	if role == "" {
-		response.Forbidden(w, "TPS_ACCESS_DENIED", "Akses ditolak.")
+		response.Forbidden(w, "TPS_ACCESS_DENIED", errcatalog.AccessDenied)
 		return
 	}
 
 	if _, err := h.svc.EnsureAccess(ctx, electionID, tpsID, role, &tokenTPS); err != nil {
-		code, status := GetErrorCode(err)
-		response.Error(w, status, code, err.Error(), nil)
+		writeError(w, err)
 		return
 	}
 
 	checkinIDStr := chi.URLParam(r, "checkinId")
 	checkinID, err := strconv.ParseInt(checkinIDStr, 10, 64)
 	if err != nil || checkinID <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "checkinId tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("checkinId"))
 		return
 	}
 
 	row, err := h.svc.GetCheckin(ctx, checkinID)
 	if err != nil {
-		if err == ErrCheckinNotFound {
-			response.NotFound(w, "CHECKIN_NOT_FOUND", "Check-in tidak ditemukan.")
-			return
-		}
-		code, status := GetErrorCode(err)
-		response.Error(w, status, code, err.Error(), nil)
+		writeError(w, err)
 		return
 	}
 	if row.TPSID != tpsID {
-		response.Forbidden(w, "TPS_ACCESS_DENIED", "Tidak bisa mengakses check-in TPS lain.")
+		writeError(w, ErrTPSAccessDenied)
 		return
 	}
 
@@ -227,35 +219,29 @@ func (h *PanelHandler) handleCheckin(w http.ResponseWriter, r *http.Request, use
 	ctx := r.Context()
 	electionID, err := strconv.ParseInt(chi.URLParam(r, "electionID"), 10, 64)
 	if err != nil || electionID <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "electionId tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("electionId"))
 		return
 	}
 	tpsID, err := strconv.ParseInt(chi.URLParam(r, "tpsID"), 10, 64)
 	if err != nil || tpsID <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "tpsId tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("tpsId"))
 		return
 	}
 	role, _ := ctxkeys.GetUserRole(ctx)
 	tokenTPS, _ := ctxkeys.GetTPSID(ctx)
 	if role == "" {
-		response.Forbidden(w, "TPS_ACCESS_DENIED", "Akses ditolak.")
+		response.Forbidden(w, "TPS_ACCESS_DENIED", errcatalog.AccessDenied)
 		return
 	}
 
 	if _, err := h.svc.EnsureAccess(ctx, electionID, tpsID, role, &tokenTPS); err != nil {
-		code, status := GetErrorCode(err)
-		response.Error(w, status, code, err.Error(), nil)
+		writeError(w, err)
 		return
 	}
 
-	var payload struct {
-		RegistrationQRPayload string `json:"registration_qr_payload"`
-		RegistrationCode      string `json:"registration_code"`
-		QRToken               string `json:"qr_token"` // alias for registration code
-		NIM                   string `json:"nim"`      // manual identifier
-	}
+	var payload PanelCheckinRequest
 	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
-		response.BadRequest(w, "VALIDATION_ERROR", "Body tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
 		return
 	}
 
@@ -271,7 +257,7 @@ func (h *PanelHandler) handleCheckin(w http.ResponseWriter, r *http.Request, use
 	}
 	raw = strings.TrimSpace(raw)
 	if raw == "" {
-		response.UnprocessableEntity(w, "VALIDATION_ERROR", "Kode registrasi wajib diisi.")
+		response.Catalog(w, registrationCodeRequired)
 		return
 	}
 
@@ -285,47 +271,48 @@ func (h *PanelHandler) handleCheckin(w http.ResponseWriter, r *http.Request, use
 		if fallback, ferr := h.svc.repo.FindVoterByIdentifier(ctx, electionID, raw); ferr == nil {
 			result = fallback
 		} else {
-			response.Error(w, http.StatusBadRequest, "INVALID_REGISTRATION_QR", "Kode QR pendaftaran tidak dikenali.", nil)
+			scanError(w, useQR, registrationQRUnknown)
 			return
 		}
 	}
 
 	if result.TPSID != nil && *result.TPSID != tpsID {
-		response.Error(w, http.StatusBadRequest, "TPS_MISMATCH", "Kode QR tidak sesuai dengan TPS ini.", nil)
+		scanError(w, useQR, catalogEntry(ErrTPSMismatch))
 		return
 	}
 
 	if result.ElectionID != electionID {
-		response.Error(w, http.StatusBadRequest, "INVALID_REGISTRATION_QR", "Kode QR tidak sesuai dengan pemilu ini.", nil)
+		scanError(w, useQR, registrationQRElection)
 		return
 	}
 
 	// Ensure TPS matches token
 	result.TPSID = &tpsID
 
-	checkin, err := h.svc.repo.CreatePanelCheckin(ctx, *result)
+	checkin, err := h.svc.CreatePanelCheckin(ctx, *result, panelOverride(ctx, payload.Override, payload.OverrideReason))
 	if err != nil {
-		switch err {
-		case ErrNotEligible:
-			response.Error(w, http.StatusBadRequest, "NOT_TPS_VOTER", "Pemilih ini terdaftar sebagai pemilih online, bukan TPS.", nil)
-			return
-		case ErrNotTPSVoter:
-			response.Error(w, http.StatusBadRequest, "NOT_TPS_VOTER", "Pemilih ini terdaftar sebagai pemilih online, bukan TPS.", nil)
-			return
-		case ErrAlreadyVoted:
-			response.Error(w, http.StatusConflict, "ALREADY_VOTED", "Pemilih ini sudah memberikan suara pada pemilu ini.", nil)
+		var notAssigned *NotAssignedError
+		if errors.As(err, &notAssigned) {
+			if useQR {
+				telemetry.QRScanFailed("TPS_NOT_ASSIGNED")
+			}
+			writeNotAssigned(w, notAssigned)
 			return
-		case ErrCheckinAlreadyExists:
-			response.Error(w, http.StatusBadRequest, "CHECKIN_EXISTS", "Pemilih sudah memiliki check-in aktif.", nil)
-			return
-		case ErrTPSMismatch:
-			response.Error(w, http.StatusBadRequest, "TPS_MISMATCH", "Kode QR tidak sesuai dengan TPS ini.", nil)
-			return
-		default:
-			code, status := GetErrorCode(err)
-			response.Error(w, status, code, err.Error(), nil)
+		}
+		var closed *SessionClosedError
+		if errors.As(err, &closed) {
+			if useQR {
+				telemetry.QRScanFailed("TPS_OUTSIDE_SESSION")
+			}
+			writeSessionClosed(w, closed)
 			return
 		}
+		if errors.Is(err, ErrNotEligible) {
+			// the voter is in the DPT of the election, just not at a TPS
+			err = ErrNotTPSVoter
+		}
+		scanError(w, useQR, catalogEntry(err))
+		return
 	}
 
 	resp := map[string]interface{}{
@@ -343,6 +330,7 @@ func (h *PanelHandler) handleCheckin(w http.ResponseWriter, r *http.Request, use
 			},
 			"status":       mapCheckinStatus(checkin.Status),
 			"checkin_time": checkin.ScanAt,
+			"booth_number": checkin.BoothNumber,
 		},
 	}
 
@@ -354,31 +342,29 @@ func (h *PanelHandler) Status(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	electionID, err := strconv.ParseInt(chi.URLParam(r, "electionID"), 10, 64)
 	if err != nil || electionID <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "electionId tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("electionId"))
 		return
 	}
 	tpsID, err := strconv.ParseInt(chi.URLParam(r, "tpsID"), 10, 64)
 	if err != nil || tpsID <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "tpsId tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("tpsId"))
 		return
 	}
 	role, _ := ctxkeys.GetUserRole(ctx)
 	tokenTPS, _ := ctxkeys.GetTPSID(ctx)
 	if role == "" {
-		response.Forbidden(w, "TPS_ACCESS_DENIED", "Akses ditolak.")
+		response.Forbidden(w, "TPS_ACCESS_DENIED", errcatalog.AccessDenied)
 		return
 	}
 
 	if _, err := h.svc.EnsureAccess(ctx, electionID, tpsID, role, &tokenTPS); err != nil {
-		code, status := GetErrorCode(err)
-		response.Error(w, status, code, err.Error(), nil)
+		writeError(w, err)
 		return
 	}
 
 	resp, err := h.svc.Status(ctx, tpsID)
 	if err != nil {
-		code, status := GetErrorCode(err)
-		response.Error(w, status, code, err.Error(), nil)
+		writeError(w, err)
 		return
 	}
 	response.JSON(w, http.StatusOK, resp)
@@ -389,32 +375,30 @@ func (h *PanelHandler) Stats(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	electionID, err := strconv.ParseInt(chi.URLParam(r, "electionID"), 10, 64)
 	if err != nil || electionID <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "electionId tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("electionId"))
 		return
 	}
 	tpsID, err := strconv.ParseInt(chi.URLParam(r, "tpsID"), 10, 64)
 	if err != nil || tpsID <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "tpsId tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("tpsId"))
 		return
 	}
 	role, _ := ctxkeys.GetUserRole(ctx)
 	tokenTPS, _ := ctxkeys.GetTPSID(ctx)
 	if role == "" {
-		response.Forbidden(w, "TPS_ACCESS_DENIED", "Akses ditolak.")
+		response.Forbidden(w, "TPS_ACCESS_DENIED", errcatalog.AccessDenied)
 		return
 	}
 
 	tpsRow, err := h.svc.EnsureAccess(ctx, electionID, tpsID, role, &tokenTPS)
 	if err != nil {
-		code, status := GetErrorCode(err)
-		response.Error(w, status, code, err.Error(), nil)
+		writeError(w, err)
 		return
 	}
 
 	data, err := h.svc.Stats(ctx, tpsID)
 	if err != nil {
-		code, status := GetErrorCode(err)
-		response.Error(w, status, code, err.Error(), nil)
+		writeError(w, err)
 		return
 	}
 
@@ -432,32 +416,30 @@ func (h *PanelHandler) Timeline(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	electionID, err := strconv.ParseInt(chi.URLParam(r, "electionID"), 10, 64)
 	if err != nil || electionID <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "electionId tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("electionId"))
 		return
 	}
 	tpsID, err := strconv.ParseInt(chi.URLParam(r, "tpsID"), 10, 64)
 	if err != nil || tpsID <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "tpsId tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("tpsId"))
 		return
 	}
 	role, _ := ctxkeys.GetUserRole(ctx)
 	tokenTPS, _ := ctxkeys.GetTPSID(ctx)
 	if role == "" {
-		response.Forbidden(w, "TPS_ACCESS_DENIED", "Akses ditolak.")
+		response.Forbidden(w, "TPS_ACCESS_DENIED", errcatalog.AccessDenied)
 		return
 	}
 
 	tpsRow, err := h.svc.EnsureAccess(ctx, electionID, tpsID, role, &tokenTPS)
 	if err != nil {
-		code, status := GetErrorCode(err)
-		response.Error(w, status, code, err.Error(), nil)
+		writeError(w, err)
 		return
 	}
 
 	points, err := h.svc.Timeline(ctx, tpsID)
 	if err != nil {
-		code, status := GetErrorCode(err)
-		response.Error(w, status, code, err.Error(), nil)
+		writeError(w, err)
 		return
 	}
 	response.JSON(w, http.StatusOK, map[string]interface{}{
@@ -472,37 +454,41 @@ func (h *PanelHandler) Logs(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	electionID, err := strconv.ParseInt(chi.URLParam(r, "electionID"), 10, 64)
 	if err != nil || electionID <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "electionId tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("electionId"))
 		return
 	}
 	tpsID, err := strconv.ParseInt(chi.URLParam(r, "tpsID"), 10, 64)
 	if err != nil || tpsID <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "tpsId tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("tpsId"))
 		return
 	}
 	role, _ := ctxkeys.GetUserRole(ctx)
 	tokenTPS, _ := ctxkeys.GetTPSID(ctx)
 	if role == "" {
-		response.Forbidden(w, "TPS_ACCESS_DENIED", "Akses ditolak.")
+		response.Forbidden(w, "TPS_ACCESS_DENIED", errcatalog.AccessDenied)
 		return
 	}
 
 	if _, err := h.svc.EnsureAccess(ctx, electionID, tpsID, role, &tokenTPS); err != nil {
-		code, status := GetErrorCode(err)
-		response.Error(w, status, code, err.Error(), nil)
+		writeError(w, err)
 		return
 	}
 
 	limit := parseIntDefault(r.URL.Query().Get("limit"), 50)
 	logs, err := h.svc.Logs(ctx, tpsID, limit)
 	if err != nil {
-		code, status := GetErrorCode(err)
-		response.Error(w, status, code, err.Error(), nil)
+		writeError(w, err)
+		return
+	}
+	expired, err := h.svc.ExpiredCounts(ctx, tpsID)
+	if err != nil {
+		writeError(w, err)
 		return
 	}
 
 	response.Success(w, http.StatusOK, map[string]interface{}{
-		"items": logs,
+		"items":   logs,
+		"expired": expired,
 	})
 }
 
@@ -511,26 +497,23 @@ func (h *PanelHandler) CreateCheckinSimple(w http.ResponseWriter, r *http.Reques
 	ctx := r.Context()
 	tpsID, err := strconv.ParseInt(chi.URLParam(r, "tpsID"), 10, 64)
 	if err != nil || tpsID <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "tpsId tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("tpsId"))
 		return
 	}
 	role, _ := ctxkeys.GetUserRole(ctx)
 	tokenTPS, _ := ctxkeys.GetTPSID(ctx)
 	if role != "TPS_OPERATOR" {
-		response.Forbidden(w, "TPS_ACCESS_DENIED", "Akses ditolak.")
+		response.Forbidden(w, "TPS_ACCESS_DENIED", errcatalog.AccessDenied)
 		return
 	}
 	if tokenTPS != tpsID {
-		response.Forbidden(w, "TPS_ACCESS_DENIED", "Token tidak sesuai TPS.")
+		writeError(w, ErrTPSAccessDenied)
 		return
 	}
 
-	var payload struct {
-		QRPayload        string `json:"qr_payload"`
-		RegistrationCode string `json:"registration_code"`
-	}
+	var payload CreateCheckinRequest
 	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
-		response.BadRequest(w, "VALIDATION_ERROR", "Body tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
 		return
 	}
 	raw := strings.TrimSpace(payload.QRPayload)
@@ -538,33 +521,28 @@ func (h *PanelHandler) CreateCheckinSimple(w http.ResponseWriter, r *http.Reques
 		raw = strings.TrimSpace(payload.RegistrationCode)
 	}
 	if raw == "" {
-		response.UnprocessableEntity(w, "VALIDATION_ERROR", "qr_payload atau registration_code wajib diisi.")
+		response.Catalog(w, scanPayloadRequired)
 		return
 	}
 
-	checkin, err := h.svc.CreateCheckinViaQR(ctx, tpsID, raw)
+	checkin, err := h.svc.CreateCheckinViaQR(ctx, tpsID, raw, panelOverride(ctx, payload.Override, payload.OverrideReason))
 	if err != nil {
-		switch err {
-		case ErrQRInvalid:
-			response.Error(w, http.StatusBadRequest, "INVALID_REGISTRATION_QR", "Kode QR pendaftaran tidak dikenali.", nil)
-			return
-		case ErrNotTPSVoter:
-			response.Error(w, http.StatusBadRequest, "NOT_TPS_VOTER", "Pemilih ini terdaftar sebagai pemilih online, bukan TPS.", nil)
+		var notAssigned *NotAssignedError
+		if errors.As(err, &notAssigned) {
+			writeNotAssigned(w, notAssigned)
 			return
-		case ErrAlreadyVoted:
-			response.Error(w, http.StatusConflict, "ALREADY_VOTED", "Pemilih ini sudah memberikan suara pada pemilu ini.", nil)
-			return
-		case ErrCheckinAlreadyExists:
-			response.Error(w, http.StatusBadRequest, "CHECKIN_EXISTS", "Pemilih sudah memiliki check-in aktif.", nil)
-			return
-		case ErrTPSMismatch:
-			response.Error(w, http.StatusBadRequest, "TPS_MISMATCH", "Kode QR tidak sesuai dengan TPS ini.", nil)
+		}
+		var closed *SessionClosedError
+		if errors.As(err, &closed) {
+			writeSessionClosed(w, closed)
 			return
-		default:
-			code, status := GetErrorCode(err)
-			response.Error(w, status, code, err.Error(), nil)
+		}
+		if errors.Is(err, ErrQRInvalid) {
+			response.Catalog(w, registrationQRUnknown)
 			return
 		}
+		writeError(w, err)
+		return
 	}
 
 	resp := map[string]interface{}{
@@ -582,6 +560,7 @@ func (h *PanelHandler) CreateCheckinSimple(w http.ResponseWriter, r *http.Reques
 			},
 			"status":       mapCheckinStatus(checkin.Status),
 			"checkin_time": checkin.ScanAt,
+			"booth_number": checkin.BoothNumber,
 		},
 	}
 	response.JSON(w, http.StatusOK, resp)
@@ -592,20 +571,19 @@ func (h *PanelHandler) ListTPSByElection(w http.ResponseWriter, r *http.Request)
 	ctx := r.Context()
 	electionID, err := strconv.ParseInt(chi.URLParam(r, "electionID"), 10, 64)
 	if err != nil || electionID <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "electionId tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("electionId"))
 		return
 	}
 
 	role, _ := ctxkeys.GetUserRole(ctx)
 	if role != "ADMIN" && role != "SUPER_ADMIN" {
-		response.Forbidden(w, "FORBIDDEN", "Hanya admin yang dapat mengakses daftar TPS.")
+		response.Catalog(w, tpsListAdminsOnly)
 		return
 	}
 
 	items, err := h.svc.ListTPSByElection(ctx, electionID)
 	if err != nil {
-		code, status := GetErrorCode(err)
-		response.Error(w, status, code, err.Error(), nil)
+		writeError(w, err)
 		return
 	}
 
@@ -625,3 +603,39 @@ func parseIntDefault(s string, def int) int {
 	}
 	return val
 }
+
+// panelScope parses the election and TPS of a panel path and checks the
+// caller may operate that TPS
+func panelScope(w http.ResponseWriter, r *http.Request, svc *PanelService) (int64, int64, bool) {
+	ctx := r.Context()
+	electionID, err := strconv.ParseInt(chi.URLParam(r, "electionID"), 10, 64)
+	if err != nil || electionID <= 0 {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("electionId"))
+		return 0, 0, false
+	}
+	tpsID, err := strconv.ParseInt(chi.URLParam(r, "tpsID"), 10, 64)
+	if err != nil || tpsID <= 0 {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("tpsId"))
+		return 0, 0, false
+	}
+
+	role, _ := ctxkeys.GetUserRole(ctx)
+	tokenTPS, _ := ctxkeys.GetTPSID(ctx)
+	if role == "" {
+		response.Forbidden(w, "TPS_ACCESS_DENIED", errcatalog.AccessDenied)
+		return 0, 0, false
+	}
+	if _, err := svc.EnsureAccess(ctx, electionID, tpsID, role, &tokenTPS); err != nil {
+		writeError(w, err)
+		return 0, 0, false
+	}
+	return electionID, tpsID, true
+}
+
+// scanError writes a failed check-in; failures of QR scans are counted
+func scanError(w http.ResponseWriter, useQR bool, e errcatalog.Entry) {
+	if useQR {
+		telemetry.QRScanFailed(e.Code)
+	}
+	response.Catalog(w, e)
+}
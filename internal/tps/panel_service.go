@@ -2,18 +2,49 @@ package tps
 
 import (
 	"context"
+	"errors"
+	"log/slog"
 	"strings"
 	"time"
+
+	"pemira-api/internal/telemetry"
 )
 
 type PanelService struct {
-	repo Repository
+	repo       Repository
+	allocation *AllocationService
+	queue      *QueueService
+	schedule   *ScheduleService
 }
 
 func NewPanelService(repo Repository) *PanelService {
 	return &PanelService{repo: repo}
 }
 
+// SetAllocation enables TPS assignment enforcement for panel check-ins
+func (s *PanelService) SetAllocation(a *AllocationService) {
+	s.allocation = a
+}
+
+// SetQueue sends voters checked in by an operator to a booth
+func (s *PanelService) SetQueue(q *QueueService) {
+	s.queue = q
+}
+
+// SetSchedule rejects panel check-ins outside the sessions of the TPS and
+// derives the panel status from them
+func (s *PanelService) SetSchedule(sch *ScheduleService) {
+	s.schedule = sch
+}
+
+// CheckinOverride lets an operator check a voter in at a TPS other than the
+// one they are assigned to. A reason is required.
+type CheckinOverride struct {
+	Override   bool
+	Reason     string
+	OperatorID *int64
+}
+
 type PanelDashboard struct {
 	ElectionID int64        `json:"election_id"`
 	TPS        PanelTPSInfo `json:"tps"`
@@ -33,6 +64,8 @@ type PanelStats struct {
 	TotalCheckedIn  int `json:"total_checked_in"`
 	TotalVoted      int `json:"total_voted"`
 	TotalNotVoted   int `json:"total_not_voted"`
+	// Expired counts check-ins the sweeper expired at this TPS
+	Expired ExpiredCheckinCounts `json:"expired"`
 }
 
 type PanelCheckinItem struct {
@@ -45,6 +78,9 @@ type PanelCheckinItem struct {
 	Status    string     `json:"status"`
 	CheckinAt time.Time  `json:"checkin_time"`
 	VotedAt   *time.Time `json:"voted_time,omitempty"`
+	// Queue position while waiting, booth once called
+	QueuePosition *int `json:"queue_position,omitempty"`
+	BoothNumber   *int `json:"booth_number,omitempty"`
 }
 
 type PanelCheckinDetail struct {
@@ -79,7 +115,7 @@ type PanelLog struct {
 	At        time.Time `json:"at"`
 }
 
-func (s *PanelService) derivePanelStatus(tpsRow *TPS) string {
+func (s *PanelService) derivePanelStatus(ctx context.Context, tpsRow *TPS) string {
 	now := time.Now()
 	// Default by status field
 	if tpsRow.Status == StatusClosed {
@@ -89,6 +125,9 @@ func (s *PanelService) derivePanelStatus(tpsRow *TPS) string {
 		return "NOT_STARTED"
 	}
 
+	if sch := s.loadSchedule(ctx, tpsRow.ID); sch != nil {
+		return sch.Status(now)
+	}
 	if tpsRow.VotingDate != nil {
 		start := parseDailyTime(*tpsRow.VotingDate, tpsRow.OpenTime)
 		end := parseDailyTime(*tpsRow.VotingDate, tpsRow.CloseTime)
@@ -102,6 +141,20 @@ func (s *PanelService) derivePanelStatus(tpsRow *TPS) string {
 	return "OPEN"
 }
 
+// loadSchedule returns the sessions of the TPS, nil without a schedule
+// service or when they cannot be loaded
+func (s *PanelService) loadSchedule(ctx context.Context, tpsID int64) *TPSSchedule {
+	if s.schedule == nil {
+		return nil
+	}
+	sch, err := s.schedule.GetSchedule(ctx, tpsID)
+	if err != nil {
+		slog.Error("failed to load tps schedule", "tps_id", tpsID, "error", err)
+		return nil
+	}
+	return sch
+}
+
 func parseDailyTime(date time.Time, hhmm string) *time.Time {
 	if strings.TrimSpace(hhmm) == "" {
 		return nil
@@ -137,6 +190,11 @@ func (s *PanelService) Dashboard(ctx context.Context, tpsID int64) (*PanelDashbo
 		return nil, err
 	}
 
+	expired, err := s.repo.PanelExpiredCounts(ctx, tpsID)
+	if err != nil {
+		return nil, err
+	}
+
 	totalNotVoted := stats.TotalRegistered - stats.TotalVoted
 	if totalNotVoted < 0 {
 		totalNotVoted = 0
@@ -149,12 +207,13 @@ func (s *PanelService) Dashboard(ctx context.Context, tpsID int64) (*PanelDashbo
 			Code: tpsRow.Code,
 			Name: tpsRow.Name,
 		},
-		Status: s.derivePanelStatus(tpsRow),
+		Status: s.derivePanelStatus(ctx, tpsRow),
 		Stats: PanelStats{
 			TotalRegistered: stats.TotalRegistered,
 			TotalCheckedIn:  stats.TotalCheckedIn,
 			TotalVoted:      stats.TotalVoted,
 			TotalNotVoted:   totalNotVoted,
+			Expired:         *expired,
 		},
 		LastActive: stats.LastActivity,
 	}, nil
@@ -169,15 +228,17 @@ func (s *PanelService) ListCheckins(ctx context.Context, tpsID int64, status, se
 	resp := make([]PanelCheckinItem, 0, len(items))
 	for _, row := range items {
 		resp = append(resp, PanelCheckinItem{
-			CheckinID: row.ID,
-			VoterID:   row.VoterID,
-			Name:      row.VoterName,
-			NIM:       row.VoterNIM,
-			Faculty:   row.Faculty,
-			Program:   row.Program,
-			Status:    mapCheckinStatus(row.Status),
-			CheckinAt: row.ScanAt,
-			VotedAt:   row.VotedAt,
+			CheckinID:     row.ID,
+			VoterID:       row.VoterID,
+			Name:          row.VoterName,
+			NIM:           row.VoterNIM,
+			Faculty:       row.Faculty,
+			Program:       row.Program,
+			Status:        mapCheckinStatus(row.Status),
+			CheckinAt:     row.ScanAt,
+			VotedAt:       row.VotedAt,
+			QueuePosition: row.QueuePosition,
+			BoothNumber:   row.BoothNumber,
 		})
 	}
 	return resp, total, nil
@@ -211,7 +272,7 @@ func (s *PanelService) Status(ctx context.Context, tpsID int64) (*TPSStatusRespo
 	if err != nil {
 		return nil, err
 	}
-	status := s.derivePanelStatus(tpsRow)
+	status := s.derivePanelStatus(ctx, tpsRow)
 
 	resp := &TPSStatusResponse{
 		ElectionID: tpsRow.ElectionID,
@@ -224,7 +285,18 @@ func (s *PanelService) Status(ctx context.Context, tpsID int64) (*TPSStatusRespo
 		},
 	}
 
-	if tpsRow.VotingDate != nil {
+	if sch := s.loadSchedule(ctx, tpsRow.ID); sch != nil {
+		resp.Timezone = sch.Timezone
+		resp.CurrentSession, resp.NextSession = sch.At(resp.Now)
+		window := resp.CurrentSession
+		if window == nil {
+			window = resp.NextSession
+		}
+		if window != nil {
+			resp.VotingWindow.StartAt = &window.OpensAt
+			resp.VotingWindow.EndAt = &window.ClosesAt
+		}
+	} else if tpsRow.VotingDate != nil {
 		start := parseDailyTime(*tpsRow.VotingDate, tpsRow.OpenTime)
 		end := parseDailyTime(*tpsRow.VotingDate, tpsRow.CloseTime)
 		resp.VotingWindow.StartAt = start
@@ -240,6 +312,11 @@ type TPSStatusResponse struct {
 	Status       string       `json:"status"`
 	Now          time.Time    `json:"now"`
 	VotingWindow VotingWindow `json:"voting_window"`
+	// Timezone and the sessions around now are set when the TPS has a
+	// schedule
+	Timezone       string           `json:"timezone,omitempty"`
+	CurrentSession *ScheduleSession `json:"current_session,omitempty"`
+	NextSession    *ScheduleSession `json:"next_session,omitempty"`
 }
 
 func (s *PanelService) Timeline(ctx context.Context, tpsID int64) ([]TimelinePoint, error) {
@@ -280,6 +357,11 @@ func (s *PanelService) Logs(ctx context.Context, tpsID int64, limit int) ([]Pane
 	return logs, nil
 }
 
+// ExpiredCounts counts the check-ins expired at a TPS
+func (s *PanelService) ExpiredCounts(ctx context.Context, tpsID int64) (*ExpiredCheckinCounts, error) {
+	return s.repo.PanelExpiredCounts(ctx, tpsID)
+}
+
 func (s *PanelService) ListTPSByElection(ctx context.Context, electionID int64) ([]PanelTPSListItem, error) {
 	return s.repo.PanelListTPSByElection(ctx, electionID)
 }
@@ -301,7 +383,7 @@ func (s *PanelService) EnsureAccess(ctx context.Context, electionID, tpsID int64
 }
 
 // CreateCheckinViaQR creates a check-in using registration QR payload, deriving election from TPS.
-func (s *PanelService) CreateCheckinViaQR(ctx context.Context, tpsID int64, raw string) (*PanelCheckinRow, error) {
+func (s *PanelService) CreateCheckinViaQR(ctx context.Context, tpsID int64, raw string, override CheckinOverride) (*PanelCheckinRow, error) {
 	tpsRow, err := s.repo.GetByID(ctx, tpsID)
 	if err != nil {
 		return nil, err
@@ -317,5 +399,50 @@ func (s *PanelService) CreateCheckinViaQR(ctx context.Context, tpsID int64, raw
 	}
 
 	reg.TPSID = &tpsID
-	return s.repo.CreatePanelCheckin(ctx, *reg)
+	return s.CreatePanelCheckin(ctx, *reg, override)
+}
+
+// CreatePanelCheckin checks the voter's TPS assignment and creates an
+// approved check-in. An override is recorded only once the check-in exists.
+func (s *PanelService) CreatePanelCheckin(ctx context.Context, reg PanelRegistrationCode, override CheckinOverride) (*PanelCheckinRow, error) {
+	if reg.TPSID == nil {
+		return nil, ErrTPSMismatch
+	}
+	if err := s.schedule.EnsureOpen(ctx, *reg.TPSID, time.Now()); err != nil {
+		return nil, err
+	}
+
+	var notAssigned *NotAssignedError
+	if err := s.allocation.CheckAssignment(ctx, reg.ElectionID, reg.VoterID, *reg.TPSID); err != nil {
+		if !errors.As(err, &notAssigned) || !override.Override {
+			return nil, err
+		}
+		if strings.TrimSpace(override.Reason) == "" {
+			return nil, ErrOverrideReasonRequired
+		}
+	}
+
+	checkin, err := s.repo.CreatePanelCheckin(ctx, reg)
+	if err != nil {
+		return nil, err
+	}
+	telemetry.CheckinRecorded(checkin.Status)
+	checkin.BoothNumber = s.queue.AssignBooth(ctx, checkin.TPSID, checkin.ID)
+
+	if notAssigned != nil {
+		o := AssignmentOverride{
+			ElectionID:   reg.ElectionID,
+			VoterID:      reg.VoterID,
+			CheckinTPSID: *reg.TPSID,
+			OperatorID:   override.OperatorID,
+			Reason:       strings.TrimSpace(override.Reason),
+		}
+		if notAssigned.Assigned.TPS != nil {
+			o.AssignedTPSID = &notAssigned.Assigned.TPS.ID
+		}
+		if err := s.allocation.RecordOverride(ctx, o); err != nil {
+			slog.Error("failed to record tps assignment override", "voter_id", reg.VoterID, "tps_id", *reg.TPSID, "error", err)
+		}
+	}
+	return checkin, nil
 }
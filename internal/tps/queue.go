@@ -0,0 +1,285 @@
+package tps
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"pemira-api/internal/telemetry"
+)
+
+const (
+	// QueueStateWaiting is a check-in waiting to be called to a booth
+	QueueStateWaiting = "WAITING"
+	// QueueStateCalled is an approved check-in sent to a booth
+	QueueStateCalled = "CALLED"
+
+	// maxBoothCount bounds the booths of one TPS
+	maxBoothCount = 50
+	// queueThroughputWindow is how far back voting throughput is measured
+	queueThroughputWindow = 30 * time.Minute
+	// defaultBoothServiceTime is assumed per voter and booth until the TPS
+	// has recent throughput to go by
+	defaultBoothServiceTime = 3 * time.Minute
+)
+
+// QueueSettings configures the queue of one TPS
+type QueueSettings struct {
+	TPSID      int64      `json:"tps_id"`
+	BoothCount int        `json:"booth_count"`
+	UpdatedAt  *time.Time `json:"updated_at,omitempty"`
+}
+
+// QueueEntry is a check-in in the queue of a TPS. Position is set for
+// waiting check-ins only, 1 being the next to be called.
+type QueueEntry struct {
+	CheckinID            int64      `json:"checkin_id"`
+	ElectionID           int64      `json:"election_id"`
+	TPSID                int64      `json:"tps_id"`
+	VoterID              int64      `json:"voter_id"`
+	Name                 string     `json:"name"`
+	NIM                  string     `json:"nim"`
+	State                string     `json:"state"`
+	Position             int        `json:"position,omitempty"`
+	BoothNumber          *int       `json:"booth_number,omitempty"`
+	EstimatedWaitMinutes *int       `json:"estimated_wait_minutes,omitempty"`
+	CheckinAt            time.Time  `json:"checkin_time"`
+	CalledAt             *time.Time `json:"called_at,omitempty"`
+	ExpiresAt            *time.Time `json:"expires_at,omitempty"`
+}
+
+// Booth is a voting booth and how many called voters it currently holds
+type Booth struct {
+	Number   int `json:"number"`
+	Occupied int `json:"occupied"`
+}
+
+// QueueSnapshot is the queue of a TPS as the panel shows it
+type QueueSnapshot struct {
+	TPSID      int64 `json:"tps_id"`
+	BoothCount int   `json:"booth_count"`
+	Waiting    int   `json:"waiting"`
+	Called     int   `json:"called"`
+	// VotedRecently counts voters who voted within the throughput window
+	VotedRecently int `json:"voted_last_30m"`
+	// EstimatedWaitMinutes is the wait of a voter joining the queue now
+	EstimatedWaitMinutes int          `json:"estimated_wait_minutes"`
+	Booths               []Booth      `json:"booths"`
+	Entries              []QueueEntry `json:"entries"`
+}
+
+// VoterQueueStatus is where a voter stands in the queue of their TPS
+type VoterQueueStatus struct {
+	State         string
+	Position      int
+	BoothNumber   *int
+	EstimatedWait time.Duration
+}
+
+// EstimateWait estimates how long the voter at position waits to be called.
+// The time per voter comes from the voters who voted within window, or from
+// defaultBoothServiceTime spread over the booths when nobody has yet.
+func EstimateWait(position, booths, votedInWindow int, window time.Duration) time.Duration {
+	if position <= 0 {
+		return 0
+	}
+	if booths < 1 {
+		booths = 1
+	}
+	perVoter := defaultBoothServiceTime / time.Duration(booths)
+	if votedInWindow > 0 && window > 0 {
+		perVoter = window / time.Duration(votedInWindow)
+	}
+	return perVoter * time.Duration(position)
+}
+
+// PickBooth returns the booth holding the fewest called voters, the lowest
+// number among equals. Booths above boothCount are ignored.
+func PickBooth(boothCount int, occupied map[int]int) int {
+	best := 1
+	for n := 2; n <= boothCount; n++ {
+		if occupied[n] < occupied[best] {
+			best = n
+		}
+	}
+	return best
+}
+
+// waitMinutes rounds a wait up to whole minutes
+func waitMinutes(d time.Duration) int {
+	return int((d + time.Minute - 1) / time.Minute)
+}
+
+type QueueRepository interface {
+	// GetQueueSettings returns one booth when the TPS has no settings saved
+	GetQueueSettings(ctx context.Context, tpsID int64) (*QueueSettings, error)
+	SaveQueueSettings(ctx context.Context, s *QueueSettings, updatedBy *int64) error
+	// ListQueue returns the PENDING and unexpired APPROVED check-ins of a
+	// TPS, oldest first
+	ListQueue(ctx context.Context, tpsID int64) ([]QueueEntry, error)
+	// QueuePlace returns the position of a PENDING check-in (0 otherwise)
+	// and the booth of an approved one
+	QueuePlace(ctx context.Context, checkinID int64) (position int, booth *int, err error)
+	// CountVotedSince counts the check-ins of a TPS used to vote since t
+	CountVotedSince(ctx context.Context, tpsID int64, since time.Time) (int, error)
+	// CallNext approves the oldest PENDING check-in of a TPS whose voter has
+	// not voted and sends it to the booth pick chooses. It returns
+	// ErrQueueEmpty when nobody is waiting.
+	CallNext(ctx context.Context, tpsID, operatorID int64, ttl time.Duration, pick func(occupied map[int]int) int) (*QueueEntry, error)
+	// AssignBooth sends an approved check-in to the booth pick chooses
+	AssignBooth(ctx context.Context, tpsID, checkinID int64, pick func(occupied map[int]int) int) (int, error)
+}
+
+// QueueService runs the check-in queue of each TPS: queue positions, booth
+// assignment on approval and wait estimates.
+type QueueService struct {
+	repo      QueueRepository
+	lifecycle *CheckinLifecycleService
+	notifier  CheckinNotifier
+}
+
+func NewQueueService(repo QueueRepository) *QueueService {
+	return &QueueService{repo: repo}
+}
+
+// SetCheckinLifecycle applies the election's approved check-in TTL to
+// voters called from the queue
+func (s *QueueService) SetCheckinLifecycle(l *CheckinLifecycleService) {
+	s.lifecycle = l
+}
+
+// SetNotifier sets where called voters are announced. nil disables it.
+func (s *QueueService) SetNotifier(n CheckinNotifier) {
+	s.notifier = n
+}
+
+func (s *QueueService) GetSettings(ctx context.Context, tpsID int64) (*QueueSettings, error) {
+	return s.repo.GetQueueSettings(ctx, tpsID)
+}
+
+func (s *QueueService) UpdateSettings(ctx context.Context, tpsID int64, req UpdateQueueSettingsRequest, actorID *int64) (*QueueSettings, error) {
+	if req.BoothCount < 1 || req.BoothCount > maxBoothCount {
+		return nil, ErrInvalidBoothCount
+	}
+	settings := &QueueSettings{TPSID: tpsID, BoothCount: req.BoothCount}
+	if err := s.repo.SaveQueueSettings(ctx, settings, actorID); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// Snapshot returns the queue of a TPS with positions and wait estimates
+func (s *QueueService) Snapshot(ctx context.Context, tpsID int64) (*QueueSnapshot, error) {
+	settings, err := s.repo.GetQueueSettings(ctx, tpsID)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := s.repo.ListQueue(ctx, tpsID)
+	if err != nil {
+		return nil, err
+	}
+	voted, err := s.repo.CountVotedSince(ctx, tpsID, time.Now().Add(-queueThroughputWindow))
+	if err != nil {
+		return nil, err
+	}
+
+	snap := &QueueSnapshot{
+		TPSID:         tpsID,
+		BoothCount:    settings.BoothCount,
+		VotedRecently: voted,
+		Entries:       entries,
+	}
+	occupied := make(map[int]int)
+	for i := range snap.Entries {
+		e := &snap.Entries[i]
+		if e.State == QueueStateWaiting {
+			snap.Waiting++
+			e.Position = snap.Waiting
+			wait := waitMinutes(EstimateWait(e.Position, settings.BoothCount, voted, queueThroughputWindow))
+			e.EstimatedWaitMinutes = &wait
+			continue
+		}
+		snap.Called++
+		if e.BoothNumber != nil {
+			occupied[*e.BoothNumber]++
+		}
+	}
+	snap.EstimatedWaitMinutes = waitMinutes(EstimateWait(snap.Waiting+1, settings.BoothCount, voted, queueThroughputWindow))
+
+	snap.Booths = make([]Booth, settings.BoothCount)
+	for i := range snap.Booths {
+		snap.Booths[i] = Booth{Number: i + 1, Occupied: occupied[i+1]}
+	}
+	return snap, nil
+}
+
+// CallNext approves the next waiting voter and sends them to a booth
+func (s *QueueService) CallNext(ctx context.Context, electionID, tpsID, operatorID int64) (*QueueEntry, error) {
+	settings, err := s.repo.GetQueueSettings(ctx, tpsID)
+	if err != nil {
+		return nil, err
+	}
+	ttl := s.lifecycle.ApprovedTTL(ctx, electionID)
+
+	entry, err := s.repo.CallNext(ctx, tpsID, operatorID, ttl, func(occupied map[int]int) int {
+		return PickBooth(settings.BoothCount, occupied)
+	})
+	if err != nil {
+		return nil, err
+	}
+	telemetry.CheckinRecorded(CheckinStatusApproved)
+	if s.notifier != nil {
+		s.notifier.BroadcastCheckinUpdated(tpsID, entry.CheckinID, CheckinStatusApproved)
+	}
+	return entry, nil
+}
+
+// AssignBooth sends a check-in approved outside the queue to a booth.
+// Failures are logged only: the approval itself has already been saved.
+func (s *QueueService) AssignBooth(ctx context.Context, tpsID, checkinID int64) *int {
+	if s == nil {
+		return nil
+	}
+	settings, err := s.repo.GetQueueSettings(ctx, tpsID)
+	if err != nil {
+		slog.Error("tps queue: failed to load settings", "tps_id", tpsID, "error", err)
+		return nil
+	}
+	booth, err := s.repo.AssignBooth(ctx, tpsID, checkinID, func(occupied map[int]int) int {
+		return PickBooth(settings.BoothCount, occupied)
+	})
+	if err != nil {
+		slog.Error("tps queue: failed to assign booth", "tps_id", tpsID, "checkin_id", checkinID, "error", err)
+		return nil
+	}
+	return &booth
+}
+
+// VoterStatus tells a voter where they stand. It returns nil for a nil
+// service and for check-ins no longer in the queue.
+func (s *QueueService) VoterStatus(ctx context.Context, checkin *TPSCheckin) (*VoterQueueStatus, error) {
+	if s == nil || (checkin.Status != CheckinStatusPending && checkin.Status != CheckinStatusApproved) {
+		return nil, nil
+	}
+	position, booth, err := s.repo.QueuePlace(ctx, checkin.ID)
+	if err != nil {
+		return nil, err
+	}
+	if checkin.Status == CheckinStatusApproved {
+		return &VoterQueueStatus{State: QueueStateCalled, BoothNumber: booth}, nil
+	}
+
+	settings, err := s.repo.GetQueueSettings(ctx, checkin.TPSID)
+	if err != nil {
+		return nil, err
+	}
+	voted, err := s.repo.CountVotedSince(ctx, checkin.TPSID, time.Now().Add(-queueThroughputWindow))
+	if err != nil {
+		return nil, err
+	}
+	return &VoterQueueStatus{
+		State:         QueueStateWaiting,
+		Position:      position,
+		EstimatedWait: EstimateWait(position, settings.BoothCount, voted, queueThroughputWindow),
+	}, nil
+}
@@ -0,0 +1,102 @@
+package tps
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"pemira-api/internal/errcatalog"
+	"pemira-api/internal/http/response"
+	"pemira-api/internal/shared/ctxkeys"
+)
+
+// QueueHandler serves the check-in queue of a TPS to its panel
+type QueueHandler struct {
+	panel *PanelService
+	svc   *QueueService
+}
+
+func NewQueueHandler(panel *PanelService, svc *QueueService) *QueueHandler {
+	return &QueueHandler{panel: panel, svc: svc}
+}
+
+// GET /admin/elections/{electionID}/tps/{tpsID}/queue
+func (h *QueueHandler) Queue(w http.ResponseWriter, r *http.Request) {
+	_, tpsID, ok := panelScope(w, r, h.panel)
+	if !ok {
+		return
+	}
+
+	snap, err := h.svc.Snapshot(r.Context(), tpsID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	response.Success(w, http.StatusOK, snap)
+}
+
+// POST /admin/elections/{electionID}/tps/{tpsID}/queue/call-next
+func (h *QueueHandler) CallNext(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	electionID, tpsID, ok := panelScope(w, r, h.panel)
+	if !ok {
+		return
+	}
+	operatorID, ok := ctxkeys.GetUserID(ctx)
+	if !ok {
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.TokenNoAccess)
+		return
+	}
+
+	entry, err := h.svc.CallNext(ctx, electionID, tpsID, operatorID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	response.Success(w, http.StatusOK, entry)
+}
+
+// GET /admin/elections/{electionID}/tps/{tpsID}/queue/settings
+func (h *QueueHandler) GetSettings(w http.ResponseWriter, r *http.Request) {
+	_, tpsID, ok := panelScope(w, r, h.panel)
+	if !ok {
+		return
+	}
+
+	settings, err := h.svc.GetSettings(r.Context(), tpsID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	response.Success(w, http.StatusOK, settings)
+}
+
+// PUT /admin/elections/{electionID}/tps/{tpsID}/queue/settings
+func (h *QueueHandler) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	_, tpsID, ok := panelScope(w, r, h.panel)
+	if !ok {
+		return
+	}
+
+	var req UpdateQueueSettingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
+		return
+	}
+
+	settings, err := h.svc.UpdateSettings(ctx, tpsID, req, actorID(ctx))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	response.Success(w, http.StatusOK, settings)
+}
+
+func (h *QueueHandler) handleError(w http.ResponseWriter, err error) {
+	if response.DomainError(w, err) {
+		return
+	}
+	slog.Error("tps queue handler error", "error", err)
+	response.InternalServerError(w, "INTERNAL_ERROR", errcatalog.InternalError)
+}
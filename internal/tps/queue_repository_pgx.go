@@ -0,0 +1,240 @@
+package tps
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PgQueueRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPgQueueRepository(db *pgxpool.Pool) *PgQueueRepository {
+	return &PgQueueRepository{db: db}
+}
+
+func (r *PgQueueRepository) GetQueueSettings(ctx context.Context, tpsID int64) (*QueueSettings, error) {
+	s := QueueSettings{TPSID: tpsID, BoothCount: 1}
+	err := r.db.QueryRow(ctx, `
+		SELECT booth_count, updated_at
+		FROM tps_queue_settings
+		WHERE tps_id = $1
+	`, tpsID).Scan(&s.BoothCount, &s.UpdatedAt)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *PgQueueRepository) SaveQueueSettings(ctx context.Context, s *QueueSettings, updatedBy *int64) error {
+	return r.db.QueryRow(ctx, `
+		INSERT INTO tps_queue_settings (tps_id, booth_count, updated_by)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (tps_id) DO UPDATE SET
+			booth_count = EXCLUDED.booth_count,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = NOW()
+		RETURNING updated_at
+	`, s.TPSID, s.BoothCount, updatedBy).Scan(&s.UpdatedAt)
+}
+
+func (r *PgQueueRepository) ListQueue(ctx context.Context, tpsID int64) ([]QueueEntry, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT c.id, c.election_id, c.tps_id, c.voter_id,
+		       COALESCE(v.name, ''), COALESCE(v.nim, ''),
+		       c.status::text, c.booth_number, c.scan_at, c.called_at, c.expires_at
+		FROM tps_checkins c
+		JOIN voters v ON v.id = c.voter_id
+		WHERE c.tps_id = $1
+		  AND (
+			c.status = 'PENDING'
+			OR (c.status = 'APPROVED' AND (c.expires_at IS NULL OR c.expires_at > NOW()))
+		  )
+		ORDER BY c.scan_at, c.id
+	`, tpsID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []QueueEntry{}
+	for rows.Next() {
+		var e QueueEntry
+		var status string
+		if err := rows.Scan(
+			&e.CheckinID, &e.ElectionID, &e.TPSID, &e.VoterID, &e.Name, &e.NIM,
+			&status, &e.BoothNumber, &e.CheckinAt, &e.CalledAt, &e.ExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		e.State = QueueStateCalled
+		if status == CheckinStatusPending {
+			e.State = QueueStateWaiting
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+func (r *PgQueueRepository) QueuePlace(ctx context.Context, checkinID int64) (int, *int, error) {
+	var position int
+	var booth *int
+	err := r.db.QueryRow(ctx, `
+		SELECT
+			CASE WHEN c.status = 'PENDING' THEN (
+				SELECT COUNT(*)
+				FROM tps_checkins q
+				WHERE q.tps_id = c.tps_id
+				  AND q.status = 'PENDING'
+				  AND (q.scan_at, q.id) <= (c.scan_at, c.id)
+			) ELSE 0 END,
+			c.booth_number
+		FROM tps_checkins c
+		WHERE c.id = $1
+	`, checkinID).Scan(&position, &booth)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, nil, ErrCheckinNotFound
+	}
+	return position, booth, err
+}
+
+func (r *PgQueueRepository) CountVotedSince(ctx context.Context, tpsID int64, since time.Time) (int, error) {
+	var n int
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*) FROM tps_checkins WHERE tps_id = $1 AND voted_at >= $2
+	`, tpsID, since).Scan(&n)
+	return n, err
+}
+
+// CallNext locks the queue of the TPS so two operators calling at once get
+// different voters and booths
+func (r *PgQueueRepository) CallNext(ctx context.Context, tpsID, operatorID int64, ttl time.Duration, pick func(occupied map[int]int) int) (*QueueEntry, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockQueue(ctx, tx, tpsID); err != nil {
+		return nil, err
+	}
+
+	var checkinID int64
+	err = tx.QueryRow(ctx, `
+		SELECT c.id
+		FROM tps_checkins c
+		WHERE c.tps_id = $1
+		  AND c.status = 'PENDING'
+		  AND NOT EXISTS (
+			SELECT 1 FROM voter_status vs
+			WHERE vs.election_id = c.election_id AND vs.voter_id = c.voter_id AND vs.has_voted
+		  )
+		ORDER BY c.scan_at, c.id
+		LIMIT 1
+		FOR UPDATE OF c SKIP LOCKED
+	`, tpsID).Scan(&checkinID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrQueueEmpty
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	occupied, err := boothLoad(ctx, tx, tpsID)
+	if err != nil {
+		return nil, err
+	}
+
+	e := QueueEntry{State: QueueStateCalled}
+	err = tx.QueryRow(ctx, `
+		UPDATE tps_checkins c
+		SET status = 'APPROVED',
+		    approved_at = NOW(),
+		    approved_by_id = $2,
+		    expires_at = NOW() + make_interval(secs => $3),
+		    booth_number = $4,
+		    called_at = NOW()
+		FROM voters v
+		WHERE c.id = $1 AND v.id = c.voter_id
+		RETURNING c.id, c.election_id, c.tps_id, c.voter_id,
+		          COALESCE(v.name, ''), COALESCE(v.nim, ''),
+		          c.booth_number, c.scan_at, c.called_at, c.expires_at
+	`, checkinID, operatorID, ttl.Seconds(), pick(occupied)).Scan(
+		&e.CheckinID, &e.ElectionID, &e.TPSID, &e.VoterID, &e.Name, &e.NIM,
+		&e.BoothNumber, &e.CheckinAt, &e.CalledAt, &e.ExpiresAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+func (r *PgQueueRepository) AssignBooth(ctx context.Context, tpsID, checkinID int64, pick func(occupied map[int]int) int) (int, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockQueue(ctx, tx, tpsID); err != nil {
+		return 0, err
+	}
+	occupied, err := boothLoad(ctx, tx, tpsID)
+	if err != nil {
+		return 0, err
+	}
+
+	booth := pick(occupied)
+	tag, err := tx.Exec(ctx, `
+		UPDATE tps_checkins
+		SET booth_number = $3, called_at = COALESCE(called_at, NOW())
+		WHERE id = $1 AND tps_id = $2 AND status = 'APPROVED'
+	`, checkinID, tpsID, booth)
+	if err != nil {
+		return 0, err
+	}
+	if tag.RowsAffected() == 0 {
+		return 0, ErrCheckinNotFound
+	}
+	return booth, tx.Commit(ctx)
+}
+
+func lockQueue(ctx context.Context, tx pgx.Tx, tpsID int64) error {
+	_, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('tps_queue'), $1::int)`, tpsID)
+	return err
+}
+
+// boothLoad counts the called voters still holding each booth
+func boothLoad(ctx context.Context, tx pgx.Tx, tpsID int64) (map[int]int, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT booth_number, COUNT(*)
+		FROM tps_checkins
+		WHERE tps_id = $1
+		  AND status = 'APPROVED'
+		  AND booth_number IS NOT NULL
+		  AND (expires_at IS NULL OR expires_at > NOW())
+		GROUP BY booth_number
+	`, tpsID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	occupied := make(map[int]int)
+	for rows.Next() {
+		var booth, n int
+		if err := rows.Scan(&booth, &n); err != nil {
+			return nil, err
+		}
+		occupied[booth] = n
+	}
+	return occupied, rows.Err()
+}
@@ -0,0 +1,226 @@
+package tps_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"pemira-api/internal/tps"
+)
+
+// queueRepo is an in-memory QueueRepository over the queue of one TPS
+type queueRepo struct {
+	settings tps.QueueSettings
+	entries  []tps.QueueEntry
+	voted    int
+	ttl      time.Duration
+}
+
+func (r *queueRepo) GetQueueSettings(ctx context.Context, tpsID int64) (*tps.QueueSettings, error) {
+	s := r.settings
+	s.TPSID = tpsID
+	if s.BoothCount == 0 {
+		s.BoothCount = 1
+	}
+	return &s, nil
+}
+
+func (r *queueRepo) SaveQueueSettings(ctx context.Context, s *tps.QueueSettings, updatedBy *int64) error {
+	r.settings = *s
+	return nil
+}
+
+func (r *queueRepo) ListQueue(ctx context.Context, tpsID int64) ([]tps.QueueEntry, error) {
+	return append([]tps.QueueEntry(nil), r.entries...), nil
+}
+
+func (r *queueRepo) QueuePlace(ctx context.Context, checkinID int64) (int, *int, error) {
+	position := 0
+	for _, e := range r.entries {
+		if e.State == tps.QueueStateWaiting {
+			position++
+		}
+		if e.CheckinID == checkinID {
+			if e.State != tps.QueueStateWaiting {
+				position = 0
+			}
+			return position, e.BoothNumber, nil
+		}
+	}
+	return 0, nil, tps.ErrCheckinNotFound
+}
+
+func (r *queueRepo) CountVotedSince(ctx context.Context, tpsID int64, since time.Time) (int, error) {
+	return r.voted, nil
+}
+
+func (r *queueRepo) CallNext(ctx context.Context, tpsID, operatorID int64, ttl time.Duration, pick func(occupied map[int]int) int) (*tps.QueueEntry, error) {
+	r.ttl = ttl
+	for i := range r.entries {
+		e := &r.entries[i]
+		if e.State != tps.QueueStateWaiting {
+			continue
+		}
+		booth := pick(r.occupied())
+		e.State = tps.QueueStateCalled
+		e.BoothNumber = &booth
+		called := *e
+		return &called, nil
+	}
+	return nil, tps.ErrQueueEmpty
+}
+
+func (r *queueRepo) AssignBooth(ctx context.Context, tpsID, checkinID int64, pick func(occupied map[int]int) int) (int, error) {
+	for i := range r.entries {
+		if e := &r.entries[i]; e.CheckinID == checkinID {
+			booth := pick(r.occupied())
+			e.BoothNumber = &booth
+			return booth, nil
+		}
+	}
+	return 0, tps.ErrCheckinNotFound
+}
+
+func (r *queueRepo) occupied() map[int]int {
+	occupied := make(map[int]int)
+	for _, e := range r.entries {
+		if e.State == tps.QueueStateCalled && e.BoothNumber != nil {
+			occupied[*e.BoothNumber]++
+		}
+	}
+	return occupied
+}
+
+func TestEstimateWait(t *testing.T) {
+	tests := []struct {
+		name            string
+		position, booth int
+		voted           int
+		want            time.Duration
+	}{
+		{"not waiting", 0, 2, 10, 0},
+		{"no throughput yet", 4, 2, 0, 6 * time.Minute},
+		{"no booths configured", 1, 0, 0, 3 * time.Minute},
+		{"recent throughput", 3, 2, 15, 6 * time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tps.EstimateWait(tt.position, tt.booth, tt.voted, 30*time.Minute); got != tt.want {
+				t.Fatalf("EstimateWait = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPickBooth(t *testing.T) {
+	if got := tps.PickBooth(3, nil); got != 1 {
+		t.Fatalf("empty booths: got %d, want 1", got)
+	}
+	if got := tps.PickBooth(3, map[int]int{1: 1, 2: 0, 3: 0}); got != 2 {
+		t.Fatalf("got %d, want lowest free booth 2", got)
+	}
+	if got := tps.PickBooth(2, map[int]int{1: 2, 2: 2, 3: 0}); got != 1 {
+		t.Fatalf("got %d, want booth beyond the count ignored", got)
+	}
+}
+
+func TestQueue_SnapshotAndCallNext(t *testing.T) {
+	ctx := context.Background()
+	one := 1
+	repo := &queueRepo{
+		settings: tps.QueueSettings{BoothCount: 2},
+		voted:    10,
+		entries: []tps.QueueEntry{
+			{CheckinID: 1, State: tps.QueueStateCalled, BoothNumber: &one},
+			{CheckinID: 2, State: tps.QueueStateWaiting},
+			{CheckinID: 3, State: tps.QueueStateWaiting},
+		},
+	}
+	svc := tps.NewQueueService(repo)
+
+	snap, err := svc.Snapshot(ctx, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snap.Waiting != 2 || snap.Called != 1 {
+		t.Fatalf("waiting %d, called %d", snap.Waiting, snap.Called)
+	}
+	if e := snap.Entries[2]; e.Position != 2 || e.EstimatedWaitMinutes == nil || *e.EstimatedWaitMinutes != 6 {
+		t.Fatalf("last entry = %+v", e)
+	}
+	if snap.EstimatedWaitMinutes != 9 {
+		t.Fatalf("wait for a new voter = %d, want 9", snap.EstimatedWaitMinutes)
+	}
+	if len(snap.Booths) != 2 || snap.Booths[0].Occupied != 1 || snap.Booths[1].Occupied != 0 {
+		t.Fatalf("booths = %+v", snap.Booths)
+	}
+
+	var events []int64
+	svc.SetNotifier(notifierFunc(func(tpsID, checkinID int64, status string) {
+		if status != tps.CheckinStatusApproved {
+			t.Fatalf("event status %s", status)
+		}
+		events = append(events, checkinID)
+	}))
+
+	called, err := svc.CallNext(ctx, 1, 7, 99)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if called.CheckinID != 2 || called.BoothNumber == nil || *called.BoothNumber != 2 {
+		t.Fatalf("called = %+v, want check-in 2 at the free booth 2", called)
+	}
+	if repo.ttl != tps.DefaultCheckinApprovedTTL {
+		t.Fatalf("ttl = %v", repo.ttl)
+	}
+	if _, err := svc.CallNext(ctx, 1, 7, 99); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.CallNext(ctx, 1, 7, 99); !errors.Is(err, tps.ErrQueueEmpty) {
+		t.Fatalf("err = %v, want ErrQueueEmpty", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("events = %v", events)
+	}
+}
+
+func TestQueue_UpdateSettings(t *testing.T) {
+	svc := tps.NewQueueService(&queueRepo{})
+	for _, bad := range []int{0, 51} {
+		if _, err := svc.UpdateSettings(context.Background(), 7, tps.UpdateQueueSettingsRequest{BoothCount: bad}, nil); !errors.Is(err, tps.ErrInvalidBoothCount) {
+			t.Fatalf("booth count %d: err = %v", bad, err)
+		}
+	}
+	s, err := svc.UpdateSettings(context.Background(), 7, tps.UpdateQueueSettingsRequest{BoothCount: 4}, nil)
+	if err != nil || s.BoothCount != 4 {
+		t.Fatalf("settings = %+v, err = %v", s, err)
+	}
+}
+
+func TestQueue_VoterStatus(t *testing.T) {
+	ctx := context.Background()
+	repo := &queueRepo{entries: []tps.QueueEntry{
+		{CheckinID: 1, State: tps.QueueStateWaiting},
+		{CheckinID: 2, State: tps.QueueStateWaiting},
+	}}
+	svc := tps.NewQueueService(repo)
+
+	st, err := svc.VoterStatus(ctx, &tps.TPSCheckin{ID: 2, TPSID: 7, Status: tps.CheckinStatusPending})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.State != tps.QueueStateWaiting || st.Position != 2 || st.EstimatedWait != 6*time.Minute {
+		t.Fatalf("status = %+v", st)
+	}
+
+	st, err = svc.VoterStatus(ctx, &tps.TPSCheckin{ID: 2, TPSID: 7, Status: tps.CheckinStatusUsed})
+	if err != nil || st != nil {
+		t.Fatalf("used check-in: status = %+v, err = %v", st, err)
+	}
+
+	var none *tps.QueueService
+	if st, err := none.VoterStatus(ctx, &tps.TPSCheckin{Status: tps.CheckinStatusPending}); st != nil || err != nil {
+		t.Fatalf("nil service: status = %+v, err = %v", st, err)
+	}
+}
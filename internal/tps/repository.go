@@ -51,6 +51,7 @@ type Repository interface {
 	PanelTimeline(ctx context.Context, tpsID int64) ([]PanelTimelineRow, error)
 	PanelListTPSByElection(ctx context.Context, electionID int64) ([]PanelTPSListItem, error)
 	PanelLogs(ctx context.Context, tpsID int64, limit int) ([]PanelLogRow, error)
+	PanelExpiredCounts(ctx context.Context, tpsID int64) (*ExpiredCheckinCounts, error)
 	GetOperatorInfo(ctx context.Context, userID int64) (*OperatorInfo, error)
 	ParseRegistrationCode(ctx context.Context, raw string) (*PanelRegistrationCode, error)
 	CreatePanelCheckin(ctx context.Context, reg PanelRegistrationCode) (*PanelCheckinRow, error)
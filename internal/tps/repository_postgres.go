@@ -187,14 +187,14 @@ func (r *PostgresRepository) Update(ctx context.Context, tps *TPS) error {
 		UPDATE tps
 		SET name = $1, location = $2, status = $3, voting_date = $4,
 		    open_time = $5, close_time = $6, capacity_estimate = $7,
-		    pic_name = $8, pic_phone = $9, notes = $10
-		WHERE id = $11
+		    pic_name = $8, pic_phone = $9, notes = $10, area_faculty_id = $11
+		WHERE id = $12
 	`
 
 	result, err := r.db.ExecContext(ctx, query,
 		tps.Name, tps.Location, tps.Status, tps.VotingDate,
 		tps.OpenTime, tps.CloseTime, tps.CapacityEstimate,
-		tps.PICName, tps.PICPhone, tps.Notes, tps.ID,
+		tps.PICName, tps.PICPhone, tps.Notes, tps.AreaFacultyID, tps.ID,
 	)
 
 	if err != nil {
@@ -772,7 +772,13 @@ func (r *PostgresRepository) PanelListCheckins(ctx context.Context, tpsID int64,
 	query := `
 		SELECT c.id, c.tps_id, c.election_id, c.voter_id, v.name, v.nim, 
 		       COALESCE(v.faculty_name,''), COALESCE(v.study_program_name,''),
-		       c.status, c.scan_at, c.voted_at
+		       c.status, c.scan_at, c.voted_at,
+		       CASE WHEN c.status = 'PENDING' THEN (
+		           SELECT COUNT(*) FROM tps_checkins q
+		           WHERE q.tps_id = c.tps_id AND q.status = 'PENDING'
+		             AND (q.scan_at, q.id) <= (c.scan_at, c.id)
+		       ) END AS queue_position,
+		       c.booth_number
 		FROM tps_checkins c
 		JOIN voters v ON v.id = c.voter_id
 	` + where + `
@@ -794,6 +800,7 @@ func (r *PostgresRepository) PanelListCheckins(ctx context.Context, tpsID int64,
 			&row.ID, &row.TPSID, &row.ElectionID, &row.VoterID,
 			&row.VoterName, &row.VoterNIM, &row.Faculty, &row.Program,
 			&row.Status, &row.ScanAt, &row.VotedAt,
+			&row.QueuePosition, &row.BoothNumber,
 		); err != nil {
 			return nil, 0, err
 		}
@@ -883,7 +890,10 @@ func (r *PostgresRepository) PanelLogs(ctx context.Context, tpsID int64, limit i
 				c.status AS status,
 				COALESCE(v.name, '') AS voter_name,
 				COALESCE(v.nim, '') AS voter_nim,
-				COALESCE(c.approved_at, c.scan_at) AS at_ts
+				CASE
+					WHEN c.status = 'EXPIRED' THEN COALESCE(c.expired_at, c.expires_at, c.scan_at)
+					ELSE COALESCE(c.approved_at, c.scan_at)
+				END AS at_ts
 			FROM tps_checkins c
 			JOIN voters v ON v.id = c.voter_id
 			WHERE c.tps_id = $1
@@ -920,6 +930,24 @@ func (r *PostgresRepository) PanelLogs(ctx context.Context, tpsID int64, limit i
 	return list, rows.Err()
 }
 
+// PanelExpiredCounts counts the expired check-ins of a TPS by whether they
+// expired waiting for approval or after approval
+func (r *PostgresRepository) PanelExpiredCounts(ctx context.Context, tpsID int64) (*ExpiredCheckinCounts, error) {
+	var counts ExpiredCheckinCounts
+	err := r.db.QueryRowContext(ctx, `
+		SELECT
+			COUNT(*),
+			COUNT(*) FILTER (WHERE approved_at IS NULL),
+			COUNT(*) FILTER (WHERE approved_at IS NOT NULL)
+		FROM tps_checkins
+		WHERE tps_id = $1 AND status = 'EXPIRED'
+	`, tpsID).Scan(&counts.Total, &counts.Pending, &counts.Approved)
+	if err != nil {
+		return nil, err
+	}
+	return &counts, nil
+}
+
 func (r *PostgresRepository) PanelListTPSByElection(ctx context.Context, electionID int64) ([]PanelTPSListItem, error) {
 	query := `
 		SELECT t.id, t.code, t.name, t.location, t.status, t.open_time, t.close_time, t.capacity_estimate
@@ -0,0 +1,354 @@
+package tps
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+	_ "time/tzdata" // election timezones must resolve on hosts without zoneinfo
+)
+
+const (
+	// DefaultElectionTimezone is the timezone of elections that have not
+	// set one
+	DefaultElectionTimezone = "Asia/Jakarta"
+
+	// PanelStatusBreak is a TPS between two of its sessions
+	PanelStatusBreak = "BREAK"
+
+	// maxScheduleSessions bounds the sessions of one TPS
+	maxScheduleSessions = 50
+	// maxSessionExtension bounds, in minutes, how far one session can be
+	// extended in total
+	maxSessionExtension = 240
+)
+
+// ScheduleSession is one opening period of a TPS. Date and hours are local
+// to the election's timezone; OpensAt and ClosesAt are resolved from them,
+// ClosesAt including any extension.
+type ScheduleSession struct {
+	ID              int64     `json:"id,omitempty"`
+	Date            string    `json:"date"`
+	OpenTime        string    `json:"open_time"`
+	CloseTime       string    `json:"close_time"`
+	ExtendedMinutes int       `json:"extended_minutes"`
+	OpensAt         time.Time `json:"opens_at"`
+	ClosesAt        time.Time `json:"closes_at"`
+}
+
+// TPSSchedule is the sessions of a TPS, oldest first once resolved
+type TPSSchedule struct {
+	TPSID      int64  `json:"tps_id"`
+	ElectionID int64  `json:"election_id"`
+	Timezone   string `json:"timezone"`
+	// Legacy is set when the TPS has no sessions of its own and its voting
+	// date and hours stand in as one session
+	Legacy   bool              `json:"legacy"`
+	Sessions []ScheduleSession `json:"sessions"`
+}
+
+// ScheduleSettings holds the timezone TPS schedules of an election use
+type ScheduleSettings struct {
+	ElectionID int64  `json:"election_id"`
+	Timezone   string `json:"timezone"`
+}
+
+// SessionExtension is an operator pushing back the close of a session
+type SessionExtension struct {
+	SessionID     int64
+	TPSID         int64
+	ElectionID    int64
+	SessionDate   string
+	Minutes       int
+	PreviousClose time.Time
+	NewClose      time.Time
+	Reason        string
+	ExtendedBy    *int64
+}
+
+// Resolve places the sessions in the election's timezone and sorts them
+func (sch *TPSSchedule) Resolve() error {
+	loc, err := time.LoadLocation(sch.Timezone)
+	if err != nil {
+		return ErrInvalidTimezone
+	}
+	for i := range sch.Sessions {
+		ss := &sch.Sessions[i]
+		opens, closes, err := sessionBounds(ss.Date, ss.OpenTime, ss.CloseTime, loc)
+		if err != nil {
+			return err
+		}
+		ss.OpenTime, ss.CloseTime = opens.Format("15:04"), closes.Format("15:04")
+		ss.OpensAt = opens
+		ss.ClosesAt = closes.Add(time.Duration(ss.ExtendedMinutes) * time.Minute)
+	}
+	sort.SliceStable(sch.Sessions, func(i, j int) bool {
+		return sch.Sessions[i].OpensAt.Before(sch.Sessions[j].OpensAt)
+	})
+	return nil
+}
+
+// At returns the session open at t, if any, and the next session to open
+// after t
+func (sch *TPSSchedule) At(t time.Time) (current, next *ScheduleSession) {
+	for i := range sch.Sessions {
+		ss := &sch.Sessions[i]
+		if current == nil && !t.Before(ss.OpensAt) && t.Before(ss.ClosesAt) {
+			current = ss
+		}
+		if next == nil && ss.OpensAt.After(t) {
+			next = ss
+		}
+	}
+	return current, next
+}
+
+// Status is the panel status of the schedule at t: OPEN within a session,
+// NOT_STARTED before the first one, CLOSED after the last one and BREAK in
+// between. A schedule without sessions is always OPEN.
+func (sch *TPSSchedule) Status(t time.Time) string {
+	current, next := sch.At(t)
+	switch {
+	case len(sch.Sessions) == 0 || current != nil:
+		return "OPEN"
+	case next == nil:
+		return "CLOSED"
+	case next == &sch.Sessions[0]:
+		return "NOT_STARTED"
+	}
+	return PanelStatusBreak
+}
+
+// ValidateSessions checks the sessions an admin sets: valid dates and
+// hours, closing after opening, and no two sessions overlapping
+func ValidateSessions(sessions []ScheduleSessionInput, loc *time.Location) error {
+	if len(sessions) == 0 || len(sessions) > maxScheduleSessions {
+		return ErrInvalidSchedule
+	}
+	type bounds struct{ opens, closes time.Time }
+	resolved := make([]bounds, len(sessions))
+	for i, in := range sessions {
+		opens, closes, err := sessionBounds(in.Date, in.OpenTime, in.CloseTime, loc)
+		if err != nil {
+			return err
+		}
+		resolved[i] = bounds{opens, closes}
+	}
+	sort.Slice(resolved, func(i, j int) bool { return resolved[i].opens.Before(resolved[j].opens) })
+	for i := 1; i < len(resolved); i++ {
+		if resolved[i].opens.Before(resolved[i-1].closes) {
+			return ErrInvalidSchedule
+		}
+	}
+	return nil
+}
+
+// sessionBounds resolves the date and hours of a session in loc
+func sessionBounds(date, open, close string, loc *time.Location) (time.Time, time.Time, error) {
+	day, err := time.ParseInLocation("2006-01-02", strings.TrimSpace(date), loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, ErrInvalidSchedule
+	}
+	o, ok := parseClock(open)
+	if !ok {
+		return time.Time{}, time.Time{}, ErrInvalidSchedule
+	}
+	c, ok := parseClock(close)
+	if !ok || !c.After(o) {
+		return time.Time{}, time.Time{}, ErrInvalidSchedule
+	}
+	y, m, d := day.Date()
+	return time.Date(y, m, d, o.Hour(), o.Minute(), 0, 0, loc), time.Date(y, m, d, c.Hour(), c.Minute(), 0, 0, loc), nil
+}
+
+type ScheduleRepository interface {
+	// GetSchedule returns the sessions of a TPS, unresolved, with its
+	// election's timezone. A TPS without sessions gets its voting date and
+	// hours as a single legacy session.
+	GetSchedule(ctx context.Context, tpsID int64) (*TPSSchedule, error)
+	// ReplaceSessions replaces every session of a TPS
+	ReplaceSessions(ctx context.Context, tpsID int64, sessions []ScheduleSessionInput) error
+	// ExtendSession adds ext.Minutes to a session and records who did so
+	// and why. It returns ErrInvalidExtension when the session would exceed
+	// maxMinutes of extension in total.
+	ExtendSession(ctx context.Context, ext *SessionExtension, maxMinutes int) error
+	GetElectionTimezone(ctx context.Context, electionID int64) (string, error)
+	SetElectionTimezone(ctx context.Context, electionID int64, timezone string) error
+}
+
+// ScheduleService keeps the opening sessions of each TPS and enforces them
+// at check-in and TPS voting
+type ScheduleService struct {
+	repo ScheduleRepository
+}
+
+func NewScheduleService(repo ScheduleRepository) *ScheduleService {
+	return &ScheduleService{repo: repo}
+}
+
+func (s *ScheduleService) GetSettings(ctx context.Context, electionID int64) (*ScheduleSettings, error) {
+	tz, err := s.repo.GetElectionTimezone(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+	return &ScheduleSettings{ElectionID: electionID, Timezone: tz}, nil
+}
+
+func (s *ScheduleService) UpdateSettings(ctx context.Context, electionID int64, req UpdateScheduleSettingsRequest) (*ScheduleSettings, error) {
+	tz := strings.TrimSpace(req.Timezone)
+	if tz == "" {
+		return nil, ErrInvalidTimezone
+	}
+	if _, err := time.LoadLocation(tz); err != nil {
+		return nil, ErrInvalidTimezone
+	}
+	if err := s.repo.SetElectionTimezone(ctx, electionID, tz); err != nil {
+		return nil, err
+	}
+	return &ScheduleSettings{ElectionID: electionID, Timezone: tz}, nil
+}
+
+// GetSchedule returns the resolved sessions of a TPS
+func (s *ScheduleService) GetSchedule(ctx context.Context, tpsID int64) (*TPSSchedule, error) {
+	sch, err := s.repo.GetSchedule(ctx, tpsID)
+	if err != nil {
+		return nil, err
+	}
+	if err := sch.Resolve(); err != nil {
+		return nil, err
+	}
+	return sch, nil
+}
+
+// ReplaceSessions sets the sessions of a TPS. Extensions of the previous
+// sessions are dropped along with them.
+func (s *ScheduleService) ReplaceSessions(ctx context.Context, tpsID int64, req UpdateScheduleRequest) (*TPSSchedule, error) {
+	sch, err := s.repo.GetSchedule(ctx, tpsID)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := time.LoadLocation(sch.Timezone)
+	if err != nil {
+		return nil, ErrInvalidTimezone
+	}
+	if err := ValidateSessions(req.Sessions, loc); err != nil {
+		return nil, err
+	}
+	if err := s.repo.ReplaceSessions(ctx, tpsID, req.Sessions); err != nil {
+		return nil, err
+	}
+	return s.GetSchedule(ctx, tpsID)
+}
+
+// EnsureOpen returns a *SessionClosedError when t falls outside every
+// session of the TPS. A nil service or a TPS without sessions is always
+// open.
+func (s *ScheduleService) EnsureOpen(ctx context.Context, tpsID int64, t time.Time) error {
+	if s == nil {
+		return nil
+	}
+	sch, err := s.GetSchedule(ctx, tpsID)
+	if err != nil {
+		return err
+	}
+	if len(sch.Sessions) == 0 {
+		return nil
+	}
+	if current, next := sch.At(t); current == nil {
+		return &SessionClosedError{Next: next}
+	}
+	return nil
+}
+
+// ExtendSession pushes back the close of a session of the TPS. Without a
+// session ID it extends the session open at now or, failing that, the last
+// session of the day that has already closed. A legacy schedule is saved as
+// a session first so the extension has something to attach to.
+func (s *ScheduleService) ExtendSession(ctx context.Context, tpsID int64, req ExtendSessionRequest, operatorID *int64, now time.Time) (*ScheduleSession, error) {
+	if req.Minutes < 1 || req.Minutes > maxSessionExtension {
+		return nil, ErrInvalidExtension
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, ErrExtensionReasonMissing
+	}
+
+	sch, err := s.GetSchedule(ctx, tpsID)
+	if err != nil {
+		return nil, err
+	}
+	if sch.Legacy {
+		inputs := make([]ScheduleSessionInput, len(sch.Sessions))
+		for i, ss := range sch.Sessions {
+			inputs[i] = ScheduleSessionInput{Date: ss.Date, OpenTime: ss.OpenTime, CloseTime: ss.CloseTime}
+		}
+		if err := s.repo.ReplaceSessions(ctx, tpsID, inputs); err != nil {
+			return nil, err
+		}
+		if sch, err = s.GetSchedule(ctx, tpsID); err != nil {
+			return nil, err
+		}
+	}
+
+	i := extendTarget(sch, req.SessionID, now)
+	if i < 0 {
+		return nil, ErrNoSessionToExtend
+	}
+	ss := sch.Sessions[i]
+	newClose := ss.ClosesAt.Add(time.Duration(req.Minutes) * time.Minute)
+	if ss.ExtendedMinutes+req.Minutes > maxSessionExtension {
+		return nil, ErrInvalidExtension
+	}
+	if i+1 < len(sch.Sessions) && newClose.After(sch.Sessions[i+1].OpensAt) {
+		return nil, ErrInvalidExtension
+	}
+
+	ext := &SessionExtension{
+		SessionID:     ss.ID,
+		TPSID:         tpsID,
+		ElectionID:    sch.ElectionID,
+		SessionDate:   ss.Date,
+		Minutes:       req.Minutes,
+		PreviousClose: ss.ClosesAt,
+		NewClose:      newClose,
+		Reason:        reason,
+		ExtendedBy:    operatorID,
+	}
+	if err := s.repo.ExtendSession(ctx, ext, maxSessionExtension); err != nil {
+		return nil, err
+	}
+
+	ss.ExtendedMinutes += req.Minutes
+	ss.ClosesAt = newClose
+	return &ss, nil
+}
+
+// extendTarget returns the index of the session to extend, -1 if none
+func extendTarget(sch *TPSSchedule, sessionID *int64, now time.Time) int {
+	if sessionID != nil {
+		for i, ss := range sch.Sessions {
+			if ss.ID == *sessionID {
+				return i
+			}
+		}
+		return -1
+	}
+
+	target := -1
+	for i, ss := range sch.Sessions {
+		if !now.Before(ss.OpensAt) && now.Before(ss.ClosesAt) {
+			return i
+		}
+		local := now.In(ss.OpensAt.Location())
+		if !ss.ClosesAt.After(now) && sameDay(local, ss.OpensAt) {
+			target = i
+		}
+	}
+	return target
+}
+
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
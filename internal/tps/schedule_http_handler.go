@@ -0,0 +1,137 @@
+package tps
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"pemira-api/internal/errcatalog"
+	"pemira-api/internal/http/response"
+)
+
+// ScheduleHandler serves TPS sessions to admins and the extension of a
+// session to TPS operators
+type ScheduleHandler struct {
+	panel *PanelService
+	svc   *ScheduleService
+}
+
+func NewScheduleHandler(panel *PanelService, svc *ScheduleService) *ScheduleHandler {
+	return &ScheduleHandler{panel: panel, svc: svc}
+}
+
+// GET /admin/elections/{electionID}/tps/schedule-settings
+func (h *ScheduleHandler) GetSettings(w http.ResponseWriter, r *http.Request) {
+	electionID, ok := allocationElectionID(w, r)
+	if !ok {
+		return
+	}
+
+	settings, err := h.svc.GetSettings(r.Context(), electionID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	response.Success(w, http.StatusOK, settings)
+}
+
+// PUT /admin/elections/{electionID}/tps/schedule-settings
+func (h *ScheduleHandler) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	electionID, ok := allocationElectionID(w, r)
+	if !ok {
+		return
+	}
+
+	var req UpdateScheduleSettingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
+		return
+	}
+
+	settings, err := h.svc.UpdateSettings(r.Context(), electionID, req)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	response.Success(w, http.StatusOK, settings)
+}
+
+// GET /admin/elections/{electionID}/tps/{tpsID}/schedule
+func (h *ScheduleHandler) Get(w http.ResponseWriter, r *http.Request) {
+	_, tpsID, ok := panelScope(w, r, h.panel)
+	if !ok {
+		return
+	}
+
+	sch, err := h.svc.GetSchedule(r.Context(), tpsID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	response.Success(w, http.StatusOK, sch)
+}
+
+// PUT /admin/elections/{electionID}/tps/{tpsID}/schedule
+func (h *ScheduleHandler) Update(w http.ResponseWriter, r *http.Request) {
+	_, tpsID, ok := panelScope(w, r, h.panel)
+	if !ok {
+		return
+	}
+
+	var req UpdateScheduleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
+		return
+	}
+
+	sch, err := h.svc.ReplaceSessions(r.Context(), tpsID, req)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	response.Success(w, http.StatusOK, sch)
+}
+
+// POST /admin/elections/{electionID}/tps/{tpsID}/schedule/extend
+func (h *ScheduleHandler) Extend(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	_, tpsID, ok := panelScope(w, r, h.panel)
+	if !ok {
+		return
+	}
+
+	var req ExtendSessionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
+		return
+	}
+
+	session, err := h.svc.ExtendSession(ctx, tpsID, req, actorID(ctx), time.Now())
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	response.Success(w, http.StatusOK, session)
+}
+
+func (h *ScheduleHandler) handleError(w http.ResponseWriter, err error) {
+	var closed *SessionClosedError
+	if errors.As(err, &closed) {
+		writeSessionClosed(w, closed)
+		return
+	}
+	if response.DomainError(w, err) {
+		return
+	}
+	slog.Error("tps schedule handler error", "error", err)
+	response.InternalServerError(w, "INTERNAL_ERROR", errcatalog.InternalError)
+}
+
+// writeSessionClosed tells the voter or operator when the TPS opens next
+func writeSessionClosed(w http.ResponseWriter, e *SessionClosedError) {
+	response.Error(w, http.StatusForbidden, "TPS_OUTSIDE_SESSION", catalogMessage(e), map[string]interface{}{
+		"next_session": e.Next,
+	})
+}
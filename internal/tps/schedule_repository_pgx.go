@@ -0,0 +1,169 @@
+package tps
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"pemira-api/internal/audit"
+)
+
+type PgScheduleRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPgScheduleRepository(db *pgxpool.Pool) *PgScheduleRepository {
+	return &PgScheduleRepository{db: db}
+}
+
+func (r *PgScheduleRepository) GetSchedule(ctx context.Context, tpsID int64) (*TPSSchedule, error) {
+	sch := TPSSchedule{TPSID: tpsID, Sessions: []ScheduleSession{}}
+	var votingDate, openTime, closeTime *string
+	err := r.db.QueryRow(ctx, `
+		SELECT t.election_id, COALESCE(NULLIF(e.timezone, ''), $2),
+		       to_char(t.voting_date, 'YYYY-MM-DD'),
+		       to_char(t.open_time, 'HH24:MI'), to_char(t.close_time, 'HH24:MI')
+		FROM tps t
+		JOIN elections e ON e.id = t.election_id
+		WHERE t.id = $1
+	`, tpsID, DefaultElectionTimezone).Scan(&sch.ElectionID, &sch.Timezone, &votingDate, &openTime, &closeTime)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrTPSNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT id, to_char(session_date, 'YYYY-MM-DD'),
+		       to_char(open_time, 'HH24:MI'), to_char(close_time, 'HH24:MI'),
+		       extended_minutes
+		FROM tps_sessions
+		WHERE tps_id = $1
+		ORDER BY session_date, open_time
+	`, tpsID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var ss ScheduleSession
+		if err := rows.Scan(&ss.ID, &ss.Date, &ss.OpenTime, &ss.CloseTime, &ss.ExtendedMinutes); err != nil {
+			return nil, err
+		}
+		sch.Sessions = append(sch.Sessions, ss)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(sch.Sessions) == 0 && votingDate != nil && openTime != nil && closeTime != nil {
+		sch.Legacy = true
+		sch.Sessions = append(sch.Sessions, ScheduleSession{Date: *votingDate, OpenTime: *openTime, CloseTime: *closeTime})
+	}
+	return &sch, nil
+}
+
+func (r *PgScheduleRepository) ReplaceSessions(ctx context.Context, tpsID int64, sessions []ScheduleSessionInput) error {
+	dates := make([]string, len(sessions))
+	opens := make([]string, len(sessions))
+	closes := make([]string, len(sessions))
+	for i, s := range sessions {
+		dates[i], opens[i], closes[i] = s.Date, s.OpenTime, s.CloseTime
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM tps_sessions WHERE tps_id = $1`, tpsID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO tps_sessions (tps_id, session_date, open_time, close_time)
+		SELECT $1, s.d::date, s.o::time, s.c::time
+		FROM unnest($2::text[], $3::text[], $4::text[]) AS s(d, o, c)
+	`, tpsID, dates, opens, closes); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *PgScheduleRepository) ExtendSession(ctx context.Context, ext *SessionExtension, maxMinutes int) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE tps_sessions
+		SET extended_minutes = extended_minutes + $3, updated_at = NOW()
+		WHERE id = $1 AND tps_id = $2 AND extended_minutes + $3 <= $4
+	`, ext.SessionID, ext.TPSID, ext.Minutes, maxMinutes)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrInvalidExtension
+	}
+
+	var extensionID int64
+	if err := tx.QueryRow(ctx, `
+		INSERT INTO tps_session_extensions
+			(session_id, tps_id, session_date, minutes, previous_close, new_close, reason, extended_by)
+		VALUES ($1, $2, $3::date, $4, $5, $6, $7, $8)
+		RETURNING id
+	`, ext.SessionID, ext.TPSID, ext.SessionDate, ext.Minutes, ext.PreviousClose, ext.NewClose, ext.Reason, ext.ExtendedBy).Scan(&extensionID); err != nil {
+		return err
+	}
+
+	if err := audit.Write(ctx, tx, audit.Entry{
+		ActorUserID: ext.ExtendedBy,
+		Action:      "TPS_SESSION_EXTENDED",
+		EntityType:  "TPS_SESSION",
+		EntityID:    ext.SessionID,
+		Metadata: map[string]any{
+			"election_id":    ext.ElectionID,
+			"tps_id":         ext.TPSID,
+			"extension_id":   extensionID,
+			"minutes":        ext.Minutes,
+			"previous_close": ext.PreviousClose,
+			"new_close":      ext.NewClose,
+			"reason":         ext.Reason,
+		},
+	}); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *PgScheduleRepository) GetElectionTimezone(ctx context.Context, electionID int64) (string, error) {
+	var tz string
+	err := r.db.QueryRow(ctx, `
+		SELECT COALESCE(NULLIF(timezone, ''), $2) FROM elections WHERE id = $1
+	`, electionID, DefaultElectionTimezone).Scan(&tz)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", ErrElectionNotFound
+	}
+	return tz, err
+}
+
+func (r *PgScheduleRepository) SetElectionTimezone(ctx context.Context, electionID int64, timezone string) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE elections SET timezone = $2, updated_at = NOW() WHERE id = $1
+	`, electionID, timezone)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrElectionNotFound
+	}
+	return nil
+}
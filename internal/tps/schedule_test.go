@@ -0,0 +1,253 @@
+package tps_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"pemira-api/internal/tps"
+)
+
+// scheduleRepo is an in-memory ScheduleRepository over one TPS. legacy is
+// served while no sessions are saved.
+type scheduleRepo struct {
+	timezone   string
+	legacy     *tps.ScheduleSession
+	sessions   []tps.ScheduleSession
+	extensions []tps.SessionExtension
+	nextID     int64
+}
+
+func (r *scheduleRepo) GetSchedule(ctx context.Context, tpsID int64) (*tps.TPSSchedule, error) {
+	sch := &tps.TPSSchedule{TPSID: tpsID, ElectionID: 1, Timezone: r.timezone}
+	sch.Sessions = append(sch.Sessions, r.sessions...)
+	if len(sch.Sessions) == 0 && r.legacy != nil {
+		sch.Legacy = true
+		sch.Sessions = append(sch.Sessions, *r.legacy)
+	}
+	return sch, nil
+}
+
+func (r *scheduleRepo) ReplaceSessions(ctx context.Context, tpsID int64, sessions []tps.ScheduleSessionInput) error {
+	r.sessions = nil
+	for _, in := range sessions {
+		r.nextID++
+		r.sessions = append(r.sessions, tps.ScheduleSession{ID: r.nextID, Date: in.Date, OpenTime: in.OpenTime, CloseTime: in.CloseTime})
+	}
+	return nil
+}
+
+func (r *scheduleRepo) ExtendSession(ctx context.Context, ext *tps.SessionExtension, maxMinutes int) error {
+	for i := range r.sessions {
+		if r.sessions[i].ID == ext.SessionID {
+			r.sessions[i].ExtendedMinutes += ext.Minutes
+			r.extensions = append(r.extensions, *ext)
+			return nil
+		}
+	}
+	return tps.ErrInvalidExtension
+}
+
+func (r *scheduleRepo) GetElectionTimezone(ctx context.Context, electionID int64) (string, error) {
+	return r.timezone, nil
+}
+
+func (r *scheduleRepo) SetElectionTimezone(ctx context.Context, electionID int64, timezone string) error {
+	r.timezone = timezone
+	return nil
+}
+
+// twoDays is a schedule over two days with a lunch break on the first
+func twoDays() []tps.ScheduleSessionInput {
+	return []tps.ScheduleSessionInput{
+		{Date: "2026-11-03", OpenTime: "08:00", CloseTime: "12:00"},
+		{Date: "2026-11-03", OpenTime: "13:00", CloseTime: "16:00"},
+		{Date: "2026-11-04", OpenTime: "08:00", CloseTime: "12:00"},
+	}
+}
+
+func wib(t *testing.T, value string) time.Time {
+	t.Helper()
+	loc, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Fatal(err)
+	}
+	at, err := time.ParseInLocation("2006-01-02 15:04", value, loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return at
+}
+
+func TestSchedule_Status(t *testing.T) {
+	repo := &scheduleRepo{timezone: "Asia/Jakarta"}
+	svc := tps.NewScheduleService(repo)
+	sch, err := svc.ReplaceSessions(context.Background(), 7, tps.UpdateScheduleRequest{Sessions: twoDays()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		at   string
+		want string
+	}{
+		{"2026-11-03 07:59", "NOT_STARTED"},
+		{"2026-11-03 08:00", "OPEN"},
+		{"2026-11-03 12:00", tps.PanelStatusBreak},
+		{"2026-11-03 22:00", tps.PanelStatusBreak},
+		{"2026-11-04 11:59", "OPEN"},
+		{"2026-11-04 12:00", "CLOSED"},
+	}
+	for _, tt := range tests {
+		if got := sch.Status(wib(t, tt.at)); got != tt.want {
+			t.Errorf("status at %s = %s, want %s", tt.at, got, tt.want)
+		}
+	}
+
+	// 01:00 UTC is 08:00 in Jakarta
+	if got := sch.Status(time.Date(2026, 11, 3, 1, 0, 0, 0, time.UTC)); got != "OPEN" {
+		t.Fatalf("status at 01:00 UTC = %s", got)
+	}
+}
+
+func TestValidateSessions(t *testing.T) {
+	tests := []struct {
+		name     string
+		sessions []tps.ScheduleSessionInput
+		valid    bool
+	}{
+		{"two days", twoDays(), true},
+		{"empty", nil, false},
+		{"closes before opening", []tps.ScheduleSessionInput{{Date: "2026-11-03", OpenTime: "12:00", CloseTime: "08:00"}}, false},
+		{"bad date", []tps.ScheduleSessionInput{{Date: "03-11-2026", OpenTime: "08:00", CloseTime: "12:00"}}, false},
+		{"overlap", []tps.ScheduleSessionInput{
+			{Date: "2026-11-03", OpenTime: "08:00", CloseTime: "12:00"},
+			{Date: "2026-11-03", OpenTime: "11:00", CloseTime: "14:00"},
+		}, false},
+		{"back to back", []tps.ScheduleSessionInput{
+			{Date: "2026-11-03", OpenTime: "08:00", CloseTime: "12:00"},
+			{Date: "2026-11-03", OpenTime: "12:00", CloseTime: "14:00"},
+		}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tps.ValidateSessions(tt.sessions, time.UTC)
+			if tt.valid && err != nil {
+				t.Fatalf("err = %v", err)
+			}
+			if !tt.valid && !errors.Is(err, tps.ErrInvalidSchedule) {
+				t.Fatalf("err = %v, want ErrInvalidSchedule", err)
+			}
+		})
+	}
+}
+
+func TestSchedule_EnsureOpen(t *testing.T) {
+	ctx := context.Background()
+	repo := &scheduleRepo{timezone: "Asia/Jakarta"}
+	svc := tps.NewScheduleService(repo)
+	if _, err := svc.ReplaceSessions(ctx, 7, tps.UpdateScheduleRequest{Sessions: twoDays()}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := svc.EnsureOpen(ctx, 7, wib(t, "2026-11-03 09:00")); err != nil {
+		t.Fatalf("in session: %v", err)
+	}
+
+	err := svc.EnsureOpen(ctx, 7, wib(t, "2026-11-03 12:30"))
+	var closed *tps.SessionClosedError
+	if !errors.As(err, &closed) || closed.Next == nil || closed.Next.OpenTime != "13:00" {
+		t.Fatalf("lunch break: err = %v", err)
+	}
+	if code, status := tps.GetErrorCode(err); code != "TPS_OUTSIDE_SESSION" || status != 403 {
+		t.Fatalf("error code = %s %d", code, status)
+	}
+
+	err = svc.EnsureOpen(ctx, 7, wib(t, "2026-11-05 09:00"))
+	if !errors.As(err, &closed) || closed.Next != nil {
+		t.Fatalf("after the last session: err = %v", err)
+	}
+
+	var none *tps.ScheduleService
+	if err := none.EnsureOpen(ctx, 7, time.Now()); err != nil {
+		t.Fatalf("nil service: %v", err)
+	}
+}
+
+func TestSchedule_ExtendSession(t *testing.T) {
+	ctx := context.Background()
+	operator := int64(42)
+	repo := &scheduleRepo{
+		timezone: "Asia/Jakarta",
+		legacy:   &tps.ScheduleSession{Date: "2026-11-03", OpenTime: "08:00", CloseTime: "12:00"},
+	}
+	svc := tps.NewScheduleService(repo)
+
+	if _, err := svc.ExtendSession(ctx, 7, tps.ExtendSessionRequest{Minutes: 30}, &operator, wib(t, "2026-11-03 11:50")); !errors.Is(err, tps.ErrExtensionReasonMissing) {
+		t.Fatalf("no reason: err = %v", err)
+	}
+	if _, err := svc.ExtendSession(ctx, 7, tps.ExtendSessionRequest{Minutes: 0, Reason: "antrean"}, &operator, wib(t, "2026-11-03 11:50")); !errors.Is(err, tps.ErrInvalidExtension) {
+		t.Fatalf("zero minutes: err = %v", err)
+	}
+
+	// The legacy voting hours become a session that can be extended
+	ss, err := svc.ExtendSession(ctx, 7, tps.ExtendSessionRequest{Minutes: 30, Reason: "antrean panjang"}, &operator, wib(t, "2026-11-03 11:50"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ss.ClosesAt.Equal(wib(t, "2026-11-03 12:30")) || ss.ExtendedMinutes != 30 {
+		t.Fatalf("extended session = %+v", ss)
+	}
+	if len(repo.sessions) != 1 || len(repo.extensions) != 1 {
+		t.Fatalf("sessions %d, extensions %d", len(repo.sessions), len(repo.extensions))
+	}
+	if ext := repo.extensions[0]; *ext.ExtendedBy != operator || !ext.PreviousClose.Equal(wib(t, "2026-11-03 12:00")) {
+		t.Fatalf("extension = %+v", ext)
+	}
+	if err := svc.EnsureOpen(ctx, 7, wib(t, "2026-11-03 12:15")); err != nil {
+		t.Fatalf("after extension: %v", err)
+	}
+
+	// A session that has closed can be reopened later the same day
+	if _, err := svc.ExtendSession(ctx, 7, tps.ExtendSessionRequest{Minutes: 15, Reason: "listrik padam"}, &operator, wib(t, "2026-11-03 12:40")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.ExtendSession(ctx, 7, tps.ExtendSessionRequest{Minutes: 15, Reason: "lupa"}, &operator, wib(t, "2026-11-04 08:00")); !errors.Is(err, tps.ErrNoSessionToExtend) {
+		t.Fatalf("next day: err = %v", err)
+	}
+	if _, err := svc.ExtendSession(ctx, 7, tps.ExtendSessionRequest{Minutes: 200, Reason: "antrean"}, &operator, wib(t, "2026-11-03 12:50")); !errors.Is(err, tps.ErrInvalidExtension) {
+		t.Fatalf("over 240 minutes in total: err = %v", err)
+	}
+}
+
+func TestSchedule_ExtendSessionStopsAtNextSession(t *testing.T) {
+	ctx := context.Background()
+	repo := &scheduleRepo{timezone: "Asia/Jakarta"}
+	svc := tps.NewScheduleService(repo)
+	if _, err := svc.ReplaceSessions(ctx, 7, tps.UpdateScheduleRequest{Sessions: twoDays()}); err != nil {
+		t.Fatal(err)
+	}
+
+	req := tps.ExtendSessionRequest{Minutes: 61, Reason: "antrean"}
+	if _, err := svc.ExtendSession(ctx, 7, req, nil, wib(t, "2026-11-03 11:00")); !errors.Is(err, tps.ErrInvalidExtension) {
+		t.Fatalf("into the afternoon session: err = %v", err)
+	}
+	req.Minutes = 60
+	if _, err := svc.ExtendSession(ctx, 7, req, nil, wib(t, "2026-11-03 11:00")); err != nil {
+		t.Fatalf("up to the afternoon session: %v", err)
+	}
+}
+
+func TestSchedule_UpdateSettings(t *testing.T) {
+	repo := &scheduleRepo{timezone: "Asia/Jakarta"}
+	svc := tps.NewScheduleService(repo)
+
+	if _, err := svc.UpdateSettings(context.Background(), 1, tps.UpdateScheduleSettingsRequest{Timezone: "Mars/Olympus"}); !errors.Is(err, tps.ErrInvalidTimezone) {
+		t.Fatalf("err = %v", err)
+	}
+	settings, err := svc.UpdateSettings(context.Background(), 1, tps.UpdateScheduleSettingsRequest{Timezone: "Asia/Makassar"})
+	if err != nil || settings.Timezone != "Asia/Makassar" || repo.timezone != "Asia/Makassar" {
+		t.Fatalf("settings = %+v, err = %v", settings, err)
+	}
+}
@@ -5,18 +5,46 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"fmt"
+	"log/slog"
 	"strings"
 	"time"
+
+	"pemira-api/internal/telemetry"
 )
 
 type Service struct {
-	repo Repository
+	repo       Repository
+	allocation *AllocationService
+	lifecycle  *CheckinLifecycleService
+	queue      *QueueService
+	schedule   *ScheduleService
 }
 
 func NewService(repo Repository) *Service {
 	return &Service{repo: repo}
 }
 
+// SetAllocation enables TPS assignment enforcement at check-in and
+// rebalancing when a TPS is added, changed or removed
+func (s *Service) SetAllocation(a *AllocationService) {
+	s.allocation = a
+}
+
+// SetCheckinLifecycle applies the election's approved check-in TTL
+func (s *Service) SetCheckinLifecycle(l *CheckinLifecycleService) {
+	s.lifecycle = l
+}
+
+// SetQueue sends approved voters to a booth and reports queue positions
+func (s *Service) SetQueue(q *QueueService) {
+	s.queue = q
+}
+
+// SetSchedule rejects check-ins outside the sessions of the TPS
+func (s *Service) SetSchedule(sch *ScheduleService) {
+	s.schedule = sch
+}
+
 func (s *Service) ensureTPSElection(ctx context.Context, electionID, tpsID int64) (*TPS, error) {
 	if electionID > 0 {
 		return s.repo.GetByIDElection(ctx, electionID, tpsID)
@@ -163,6 +191,7 @@ func (s *Service) Create(ctx context.Context, req *CreateTPSRequest) (int64, err
 		OpenTime:         req.OpenTime,
 		CloseTime:        req.CloseTime,
 		CapacityEstimate: req.CapacityEstimate,
+		AreaFacultyID:    areaFacultyID(req.AreaFacultyID),
 	}
 
 	if err := s.repo.Create(ctx, tps); err != nil {
@@ -183,6 +212,8 @@ func (s *Service) Create(ctx context.Context, req *CreateTPSRequest) (int64, err
 		_ = s.repo.CreateQR(ctx, qr)
 	}
 
+	s.allocation.TPSChanged(ctx, tps.ElectionID)
+
 	return tps.ID, nil
 }
 
@@ -204,11 +235,18 @@ func (s *Service) Update(ctx context.Context, id int64, req *UpdateTPSRequest) e
 	tps.OpenTime = req.OpenTime
 	tps.CloseTime = req.CloseTime
 	tps.CapacityEstimate = req.CapacityEstimate
+	if req.AreaFacultyID != nil {
+		tps.AreaFacultyID = areaFacultyID(req.AreaFacultyID)
+	}
 	tps.PICName = req.PICName
 	tps.PICPhone = req.PICPhone
 	tps.Notes = req.Notes
 
-	return s.repo.Update(ctx, tps)
+	if err := s.repo.Update(ctx, tps); err != nil {
+		return err
+	}
+	s.allocation.TPSChanged(ctx, tps.ElectionID)
+	return nil
 }
 
 func (s *Service) UpdateWithElection(ctx context.Context, electionID, id int64, req *UpdateTPSRequest) error {
@@ -226,7 +264,11 @@ func (s *Service) Delete(ctx context.Context, electionID, id int64) error {
 	if _, err := s.repo.GetByIDElection(ctx, electionID, id); err != nil {
 		return ErrTPSNotFound
 	}
-	return s.repo.Delete(ctx, electionID, id)
+	if err := s.repo.Delete(ctx, electionID, id); err != nil {
+		return err
+	}
+	s.allocation.TPSChanged(ctx, electionID)
+	return nil
 }
 
 func (s *Service) GetQRMetadata(ctx context.Context, electionID, tpsID int64) (*QRInfo, error) {
@@ -359,6 +401,11 @@ func (s *Service) ScanQR(ctx context.Context, voterID int64, req *ScanQRRequest)
 		return nil, ErrTPSInactive
 	}
 
+	// Check the TPS is within one of its sessions
+	if err := s.schedule.EnsureOpen(ctx, tps.ID, time.Now()); err != nil {
+		return nil, err
+	}
+
 	// Check voter eligibility
 	eligible, err := s.repo.IsVoterEligible(ctx, voterID, tps.ElectionID)
 	if err != nil || !eligible {
@@ -371,6 +418,11 @@ func (s *Service) ScanQR(ctx context.Context, voterID int64, req *ScanQRRequest)
 		return nil, ErrAlreadyVoted
 	}
 
+	// Check the voter is assigned to this TPS
+	if err := s.allocation.CheckAssignment(ctx, tps.ElectionID, voterID, tps.ID); err != nil {
+		return nil, err
+	}
+
 	// Check existing pending checkin
 	existingCheckin, _ := s.repo.GetCheckinByVoter(ctx, voterID, tps.ElectionID)
 	if existingCheckin != nil && existingCheckin.Status == CheckinStatusPending {
@@ -398,6 +450,7 @@ func (s *Service) ScanQR(ctx context.Context, voterID int64, req *ScanQRRequest)
 	if err := s.repo.CreateCheckin(ctx, checkin); err != nil {
 		return nil, err
 	}
+	telemetry.CheckinRecorded(CheckinStatusPending)
 
 	return &ScanQRResponse{
 		CheckinID: checkin.ID,
@@ -434,14 +487,29 @@ func (s *Service) GetCheckinStatus(ctx context.Context, voterID, electionID int6
 		tpsInfo.Name = tps.Name
 	}
 
-	return &CheckinStatusResponse{
+	resp := &CheckinStatusResponse{
 		HasActiveCheckin: true,
 		Status:           &checkin.Status,
 		TPS:              tpsInfo,
 		ScanAt:           &checkin.ScanAt,
 		ApprovedAt:       checkin.ApprovedAt,
 		ExpiresAt:        checkin.ExpiresAt,
-	}, nil
+	}
+
+	queue, err := s.queue.VoterStatus(ctx, checkin)
+	if err != nil {
+		slog.Error("tps queue: failed to load voter status", "checkin_id", checkin.ID, "error", err)
+	}
+	if queue != nil {
+		resp.QueueState = &queue.State
+		resp.BoothNumber = queue.BoothNumber
+		if queue.State == QueueStateWaiting {
+			wait := waitMinutes(queue.EstimatedWait)
+			resp.QueuePosition = &queue.Position
+			resp.EstimatedWaitMinutes = &wait
+		}
+	}
+	return resp, nil
 }
 
 // TPS Panel Operations
@@ -511,7 +579,7 @@ func (s *Service) ApproveCheckin(ctx context.Context, tpsID, checkinID, approver
 	}
 
 	now := time.Now()
-	expiresAt := now.Add(15 * time.Minute)
+	expiresAt := now.Add(s.lifecycle.ApprovedTTL(ctx, checkin.ElectionID))
 
 	checkin.Status = CheckinStatusApproved
 	checkin.ApprovedByID = &approverID
@@ -521,6 +589,8 @@ func (s *Service) ApproveCheckin(ctx context.Context, tpsID, checkinID, approver
 	if err := s.repo.UpdateCheckin(ctx, checkin); err != nil {
 		return nil, err
 	}
+	telemetry.CheckinRecorded(CheckinStatusApproved)
+	booth := s.queue.AssignBooth(ctx, tpsID, checkin.ID)
 
 	voterInfo, _ := s.repo.GetVoterInfo(ctx, checkin.VoterID)
 	if voterInfo == nil {
@@ -536,11 +606,12 @@ func (s *Service) ApproveCheckin(ctx context.Context, tpsID, checkinID, approver
 	}
 
 	return &ApproveCheckinResponse{
-		CheckinID:  checkin.ID,
-		Status:     checkin.Status,
-		Voter:      *voterInfo,
-		TPS:        tpsInfo,
-		ApprovedAt: now,
+		CheckinID:   checkin.ID,
+		Status:      checkin.Status,
+		Voter:       *voterInfo,
+		TPS:         tpsInfo,
+		ApprovedAt:  now,
+		BoothNumber: booth,
 	}, nil
 }
 
@@ -566,6 +637,7 @@ func (s *Service) RejectCheckin(ctx context.Context, tpsID, checkinID, approverI
 	if err := s.repo.UpdateCheckin(ctx, checkin); err != nil {
 		return nil, err
 	}
+	telemetry.CheckinRecorded(CheckinStatusRejected)
 
 	return &RejectCheckinResponse{
 		CheckinID: checkin.ID,
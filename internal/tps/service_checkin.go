@@ -8,10 +8,15 @@ import (
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+
+	"pemira-api/internal/telemetry"
 )
 
 type CheckinService struct {
-	db *pgxpool.Pool
+	db         *pgxpool.Pool
+	allocation *AllocationService
+	lifecycle  *CheckinLifecycleService
+	schedule   *ScheduleService
 }
 
 func NewCheckinService(db *pgxpool.Pool) *CheckinService {
@@ -20,6 +25,21 @@ func NewCheckinService(db *pgxpool.Pool) *CheckinService {
 	}
 }
 
+// SetAllocation enables TPS assignment enforcement at check-in
+func (s *CheckinService) SetAllocation(a *AllocationService) {
+	s.allocation = a
+}
+
+// SetCheckinLifecycle applies the election's approved check-in TTL
+func (s *CheckinService) SetCheckinLifecycle(l *CheckinLifecycleService) {
+	s.lifecycle = l
+}
+
+// SetSchedule rejects check-ins outside the sessions of the TPS
+func (s *CheckinService) SetSchedule(sch *ScheduleService) {
+	s.schedule = sch
+}
+
 func (s *CheckinService) withTx(ctx context.Context, fn func(tx pgx.Tx) error) error {
 	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{})
 	if err != nil {
@@ -51,6 +71,7 @@ func (s *CheckinService) CheckinScan(
 	}
 
 	var result *ScanQRResponse
+	created := false
 
 	err = s.withTx(ctx, func(tx pgx.Tx) error {
 		// 1. Load QR entry & TPS
@@ -68,6 +89,11 @@ func (s *CheckinService) CheckinScan(
 			return ErrTPSInactive
 		}
 
+		// Cek jam buka sesi TPS
+		if err := s.schedule.EnsureOpen(ctx, tpsEntry.ID, time.Now()); err != nil {
+			return err
+		}
+
 		// 2. Cek election & fase
 		election, err := s.getElectionByID(ctx, tx, tpsEntry.ElectionID)
 		if err != nil {
@@ -97,6 +123,11 @@ func (s *CheckinService) CheckinScan(
 			return ErrAlreadyVoted
 		}
 
+		// Cek TPS yang ditetapkan untuk pemilih
+		if err := s.allocation.CheckAssignment(ctx, election.ID, voterID, tpsEntry.ID); err != nil {
+			return err
+		}
+
 		// 4. Cek apakah sudah ada checkin pending
 		existingCheckin, _ := s.getPendingCheckin(ctx, tx, voterID, election.ID)
 		if existingCheckin != nil {
@@ -151,6 +182,7 @@ func (s *CheckinService) CheckinScan(
 			Message: "Check-in berhasil. Silakan menunggu verifikasi panitia TPS.",
 			ScanAt:  now,
 		}
+		created = true
 
 		return nil
 	})
@@ -158,6 +190,9 @@ func (s *CheckinService) CheckinScan(
 	if err != nil {
 		return nil, err
 	}
+	if created {
+		telemetry.CheckinRecorded(CheckinStatusPending)
+	}
 
 	return result, nil
 }
@@ -215,7 +250,7 @@ func (s *CheckinService) ApproveCheckin(
 
 		// 4. Update check-in -> APPROVED
 		now := time.Now().UTC()
-		expiresAt := now.Add(15 * time.Minute)
+		expiresAt := now.Add(s.lifecycle.ApprovedTTL(ctx, checkin.ElectionID))
 
 		err = s.updateCheckinToApproved(ctx, tx, checkinID, operatorUserID, now, expiresAt)
 		if err != nil {
@@ -269,6 +304,7 @@ func (s *CheckinService) ApproveCheckin(
 	if err != nil {
 		return nil, err
 	}
+	telemetry.CheckinRecorded(CheckinStatusApproved)
 
 	return result, nil
 }
@@ -337,6 +373,7 @@ func (s *CheckinService) RejectCheckin(
 	if err != nil {
 		return nil, err
 	}
+	telemetry.CheckinRecorded(CheckinStatusRejected)
 
 	return result, nil
 }
@@ -146,6 +146,9 @@ func (m *mockRepository) PanelListTPSByElection(ctx context.Context, electionID
 func (m *mockRepository) PanelLogs(ctx context.Context, tpsID int64, limit int) ([]tps.PanelLogRow, error) {
 	return []tps.PanelLogRow{}, nil
 }
+func (m *mockRepository) PanelExpiredCounts(ctx context.Context, tpsID int64) (*tps.ExpiredCheckinCounts, error) {
+	return &tps.ExpiredCheckinCounts{}, nil
+}
 func (m *mockRepository) FindVoterByIdentifier(ctx context.Context, electionID int64, identifier string) (*tps.PanelRegistrationCode, error) {
 	return &tps.PanelRegistrationCode{ElectionID: electionID, VoterID: 1, TPSID: ptrInt64(1), Raw: identifier}, nil
 }
@@ -2,6 +2,8 @@ package tps
 
 import (
 	"context"
+
+	"pemira-api/internal/telemetry"
 )
 
 // ServiceWithWebSocket extends Service with WebSocket broadcasting capabilities
@@ -82,6 +84,7 @@ func (s *ServiceWithWebSocket) MarkCheckinAsUsed(ctx context.Context, checkinID
 	if err != nil {
 		return err
 	}
+	telemetry.CheckinRecorded(CheckinStatusUsed)
 
 	// Broadcast status update
 	if s.wsHub != nil {
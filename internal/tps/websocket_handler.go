@@ -141,11 +141,11 @@ func NewWSHandler(hub *WSHub, service *Service) *WSHandler {
 }
 
 func (h *WSHandler) RegisterRoutes(r chi.Router) {
-	r.Get("/ws/tps/{tps_id}/queue", h.HandleTPSQueue)
+	r.Get("/ws/tps/{tpsID}/queue", h.HandleTPSQueue)
 }
 
 func (h *WSHandler) HandleTPSQueue(w http.ResponseWriter, r *http.Request) {
-	tpsID, err := strconv.ParseInt(chi.URLParam(r, "tps_id"), 10, 64)
+	tpsID, err := strconv.ParseInt(chi.URLParam(r, "tpsID"), 10, 64)
 	if err != nil {
 		http.Error(w, "Invalid TPS ID", http.StatusBadRequest)
 		return
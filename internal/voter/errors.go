@@ -0,0 +1,22 @@
+package voter
+
+import (
+	"net/http"
+
+	"pemira-api/internal/errcatalog"
+)
+
+func init() {
+	errcatalog.Register(
+		errcatalog.Entry{Err: ErrVoterNotFound, Code: "VOTER_NOT_FOUND", Status: http.StatusNotFound, ID: errcatalog.VoterNotFound.ID, EN: errcatalog.VoterNotFound.EN},
+		errcatalog.Entry{Err: ErrInvalidEmail, Code: "INVALID_EMAIL", Status: http.StatusBadRequest, ID: "Format email tidak valid.", EN: "Invalid email format."},
+		errcatalog.Entry{Err: ErrInvalidPhone, Code: "INVALID_PHONE", Status: http.StatusBadRequest, ID: "Format nomor telepon tidak valid. Gunakan format 08xxx atau +62xxx.", EN: "Invalid phone number. Use 08xxx or +62xxx."},
+		errcatalog.Entry{Err: ErrInvalidVotingMethod, Code: "INVALID_METHOD", Status: http.StatusBadRequest, ID: "Metode voting tidak valid. Gunakan ONLINE atau TPS.", EN: "Invalid voting method. Use ONLINE or TPS."},
+		errcatalog.Entry{Err: ErrPasswordMismatch, Code: "PASSWORD_MISMATCH", Status: http.StatusBadRequest, ID: "Konfirmasi password tidak cocok.", EN: "The password confirmation does not match."},
+		errcatalog.Entry{Err: ErrPasswordTooShort, Code: "PASSWORD_TOO_SHORT", Status: http.StatusBadRequest, ID: "Password minimal 8 karakter.", EN: "Passwords need at least 8 characters."},
+		errcatalog.Entry{Err: ErrPasswordSameAsCurrent, Code: "PASSWORD_SAME", Status: http.StatusBadRequest, ID: "Password baru tidak boleh sama dengan password lama.", EN: "The new password must differ from the old one."},
+		errcatalog.Entry{Err: ErrInvalidCurrentPassword, Code: "INVALID_PASSWORD", Status: http.StatusUnauthorized, ID: "Password saat ini salah.", EN: "The current password is incorrect."},
+		errcatalog.Entry{Err: ErrAlreadyVoted, Code: "ALREADY_VOTED", Status: http.StatusBadRequest, ID: "Tidak dapat mengubah metode voting karena sudah voting.", EN: "Cannot change the voting method after voting."},
+		errcatalog.Entry{Err: ErrAlreadyCheckedIn, Code: "ALREADY_CHECKED_IN", Status: http.StatusBadRequest, ID: "Tidak dapat mengubah ke ONLINE karena sudah check-in di TPS.", EN: "Cannot switch to ONLINE after checking in at a TPS."},
+	)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	
+	"pemira-api/internal/errcatalog"
 	"pemira-api/internal/http/response"
 	"pemira-api/internal/shared"
 )
@@ -31,7 +32,7 @@ func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
 	params := shared.NewPaginationParams(page, perPage)
 	voters, total, err := h.service.List(r.Context(), params)
 	if err != nil {
-		response.InternalServerError(w, "INTERNAL_ERROR", "Failed to fetch voters")
+		response.Catalog(w, voterListFailed)
 		return
 	}
 
@@ -43,7 +44,7 @@ func (h *Handler) GetByNIM(w http.ResponseWriter, r *http.Request) {
 	
 	voter, err := h.service.GetByNIM(r.Context(), nim)
 	if err != nil {
-		response.NotFound(w, "NOT_FOUND", "Voter not found")
+		response.NotFound(w, "NOT_FOUND", errcatalog.VoterNotFound)
 		return
 	}
 
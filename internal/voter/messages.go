@@ -0,0 +1,13 @@
+package voter
+
+import (
+	"net/http"
+
+	"pemira-api/internal/errcatalog"
+)
+
+// Messages the voter handlers answer with, each under its own code in the
+// error catalog
+var (
+	voterListFailed = errcatalog.Define("VOTER_LIST_FAILED", http.StatusInternalServerError, "Gagal mengambil daftar pemilih.", "Failed to fetch voters.")
+)
@@ -3,12 +3,12 @@ package voter
 import (
 	"encoding/json"
 "strconv"
-	"errors"
 	"log/slog"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
 
+	"pemira-api/internal/errcatalog"
 	"pemira-api/internal/http/response"
 	"pemira-api/internal/shared/ctxkeys"
 )
@@ -34,13 +34,13 @@ func (h *ProfileHandler) RegisterRoutes(r chi.Router) {
 func (h *ProfileHandler) GetCompleteProfile(w http.ResponseWriter, r *http.Request) {
 	userID, ok := ctxkeys.GetUserID(r.Context())
 	if !ok {
-		response.Unauthorized(w, "UNAUTHORIZED", "Token tidak valid atau tidak ditemukan.")
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.TokenMissing)
 		return
 	}
 
 	voterID, ok := ctxkeys.GetVoterID(r.Context())
 	if !ok {
-		response.Forbidden(w, "FORBIDDEN", "Hanya voter yang dapat mengakses profil.")
+		response.Forbidden(w, "FORBIDDEN", errcatalog.VotersOnly)
 		return
 	}
 
@@ -65,13 +65,13 @@ func (h *ProfileHandler) GetCompleteProfile(w http.ResponseWriter, r *http.Reque
 func (h *ProfileHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
 	voterID, ok := ctxkeys.GetVoterID(r.Context())
 	if !ok {
-		response.Forbidden(w, "FORBIDDEN", "Hanya voter yang dapat mengakses profil.")
+		response.Forbidden(w, "FORBIDDEN", errcatalog.VotersOnly)
 		return
 	}
 
 	var req UpdateProfileRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.BadRequest(w, "INVALID_JSON", "Format JSON tidak valid.")
+		response.BadRequest(w, "INVALID_JSON", errcatalog.InvalidJSON)
 		return
 	}
 
@@ -104,24 +104,24 @@ func (h *ProfileHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
 func (h *ProfileHandler) UpdateVotingMethod(w http.ResponseWriter, r *http.Request) {
 	voterID, ok := ctxkeys.GetVoterID(r.Context())
 	if !ok {
-		response.Forbidden(w, "FORBIDDEN", "Hanya voter yang dapat mengakses profil.")
+		response.Forbidden(w, "FORBIDDEN", errcatalog.VotersOnly)
 		return
 	}
 
 	var req UpdateVotingMethodRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.BadRequest(w, "INVALID_JSON", "Format JSON tidak valid.")
+		response.BadRequest(w, "INVALID_JSON", errcatalog.InvalidJSON)
 		return
 	}
 
 	// Validation
 	if req.ElectionID == 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "election_id wajib diisi")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Required("election_id"))
 		return
 	}
 
 	if req.PreferredMethod != "ONLINE" && req.PreferredMethod != "TPS" {
-		response.BadRequest(w, "VALIDATION_ERROR", "preferred_method harus ONLINE atau TPS")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.OneOf("preferred_method", "ONLINE", "TPS"))
 		return
 	}
 
@@ -148,29 +148,29 @@ func (h *ProfileHandler) UpdateVotingMethod(w http.ResponseWriter, r *http.Reque
 func (h *ProfileHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
 	userID, ok := ctxkeys.GetUserID(r.Context())
 	if !ok {
-		response.Unauthorized(w, "UNAUTHORIZED", "Token tidak valid atau tidak ditemukan.")
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.TokenMissing)
 		return
 	}
 
 	var req ChangePasswordRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.BadRequest(w, "INVALID_JSON", "Format JSON tidak valid.")
+		response.BadRequest(w, "INVALID_JSON", errcatalog.InvalidJSON)
 		return
 	}
 
 	// Validation
 	if req.CurrentPassword == "" {
-		response.BadRequest(w, "VALIDATION_ERROR", "current_password wajib diisi")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Required("current_password"))
 		return
 	}
 
 	if req.NewPassword == "" {
-		response.BadRequest(w, "VALIDATION_ERROR", "new_password wajib diisi")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Required("new_password"))
 		return
 	}
 
 	if req.ConfirmPassword == "" {
-		response.BadRequest(w, "VALIDATION_ERROR", "confirm_password wajib diisi")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Required("confirm_password"))
 		return
 	}
 
@@ -190,7 +190,7 @@ func (h *ProfileHandler) ChangePassword(w http.ResponseWriter, r *http.Request)
 func (h *ProfileHandler) GetParticipationStats(w http.ResponseWriter, r *http.Request) {
 	voterID, ok := ctxkeys.GetVoterID(r.Context())
 	if !ok {
-		response.Forbidden(w, "FORBIDDEN", "Hanya voter yang dapat mengakses profil.")
+		response.Forbidden(w, "FORBIDDEN", errcatalog.VotersOnly)
 		return
 	}
 
@@ -207,7 +207,7 @@ func (h *ProfileHandler) GetParticipationStats(w http.ResponseWriter, r *http.Re
 func (h *ProfileHandler) DeletePhoto(w http.ResponseWriter, r *http.Request) {
 	voterID, ok := ctxkeys.GetVoterID(r.Context())
 	if !ok {
-		response.Forbidden(w, "FORBIDDEN", "Hanya voter yang dapat mengakses profil.")
+		response.Forbidden(w, "FORBIDDEN", errcatalog.VotersOnly)
 		return
 	}
 
@@ -224,39 +224,9 @@ func (h *ProfileHandler) DeletePhoto(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *ProfileHandler) handleError(w http.ResponseWriter, err error) {
-	switch {
-	case errors.Is(err, ErrVoterNotFound):
-		response.NotFound(w, "VOTER_NOT_FOUND", "Voter tidak ditemukan.")
-
-	case errors.Is(err, ErrInvalidEmail):
-		response.BadRequest(w, "INVALID_EMAIL", "Format email tidak valid.")
-
-	case errors.Is(err, ErrInvalidPhone):
-		response.BadRequest(w, "INVALID_PHONE", "Format nomor telepon tidak valid. Gunakan format 08xxx atau +62xxx.")
-
-	case errors.Is(err, ErrInvalidVotingMethod):
-		response.BadRequest(w, "INVALID_METHOD", "Metode voting tidak valid. Gunakan ONLINE atau TPS.")
-
-	case errors.Is(err, ErrPasswordMismatch):
-		response.BadRequest(w, "PASSWORD_MISMATCH", "Konfirmasi password tidak cocok.")
-
-	case errors.Is(err, ErrPasswordTooShort):
-		response.BadRequest(w, "PASSWORD_TOO_SHORT", "Password minimal 8 karakter.")
-
-	case errors.Is(err, ErrPasswordSameAsCurrent):
-		response.BadRequest(w, "PASSWORD_SAME", "Password baru tidak boleh sama dengan password lama.")
-
-	case errors.Is(err, ErrInvalidCurrentPassword):
-		response.Unauthorized(w, "INVALID_PASSWORD", "Password saat ini salah.")
-
-	case errors.Is(err, ErrAlreadyVoted):
-		response.BadRequest(w, "ALREADY_VOTED", "Tidak dapat mengubah metode voting karena sudah voting.")
-
-	case errors.Is(err, ErrAlreadyCheckedIn):
-		response.BadRequest(w, "ALREADY_CHECKED_IN", "Tidak dapat mengubah ke ONLINE karena sudah check-in di TPS.")
-
-	default:
-		slog.Error("profile handler error", "error", err)
-		response.InternalServerError(w, "INTERNAL_ERROR", "Terjadi kesalahan pada sistem.")
+	if response.DomainError(w, err) {
+		return
 	}
+	slog.Error("profile handler error", "error", err)
+	response.InternalServerError(w, "INTERNAL_ERROR", errcatalog.InternalError)
 }
@@ -0,0 +1,77 @@
+package voting
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// txRunner runs fn in a transaction of its own, committing when fn succeeds.
+type txRunner func(ctx context.Context, fn func(tx pgx.Tx) error) error
+
+// queueFunc queues a ballot outside the voter's transaction.
+type queueFunc func(vote *Vote) error
+
+// castWithBallot runs cast in the voter's transaction and gives it a queue
+// function that writes the ballot in a second transaction, committed before
+// the voter's. The ballot row therefore never carries the voter's
+// transaction id, and its random key says nothing about cast order. The
+// voter's row stays locked while the ballot is written, so a voter cannot
+// queue two ballots; if the voter's transaction fails after the ballot was
+// committed, the ballot is discarded again.
+//
+// What remains: until its batch is released (ballotBatchSize ballots, or
+// voting closes) a queued ballot sits in ballot_queue with its TPS and an
+// hour-truncated cast time, and its transaction id is close to the voter's.
+// Only the recount's single queued total reads the table.
+func castWithBallot(ctx context.Context, run txRunner, repo VoteRepository, cast func(tx pgx.Tx, queue queueFunc) error) error {
+	var queued []string
+	err := run(ctx, func(tx pgx.Tx) error {
+		return cast(tx, func(vote *Vote) error {
+			if err := run(ctx, func(btx pgx.Tx) error {
+				return repo.QueueBallot(ctx, btx, vote)
+			}); err != nil {
+				return err
+			}
+			queued = append(queued, vote.QueueID)
+			return nil
+		})
+	})
+	if err != nil {
+		dctx := context.WithoutCancel(ctx)
+		for _, id := range queued {
+			if derr := run(dctx, func(tx pgx.Tx) error {
+				return repo.DiscardQueuedBallot(dctx, tx, id)
+			}); derr != nil {
+				slog.Error("discard queued ballot failed", "err", derr)
+			}
+		}
+	}
+	return err
+}
+
+// withBallotTx is castWithBallot on the service's pool. A cast holds two
+// connections at once, so concurrent casts are capped at half the pool;
+// otherwise every connection could be held by a voter transaction waiting
+// for a second one.
+func (s *Service) withBallotTx(ctx context.Context, cast func(tx pgx.Tx, queue queueFunc) error) error {
+	if s.ballotSlots != nil {
+		select {
+		case s.ballotSlots <- struct{}{}:
+			defer func() { <-s.ballotSlots }()
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return castWithBallot(ctx, s.withTx, s.voteRepo, cast)
+}
+
+func ballotSlots(db *pgxpool.Pool) chan struct{} {
+	n := 1
+	if db != nil {
+		n = max(int(db.Config().MaxConns)/2, 1)
+	}
+	return make(chan struct{}, n)
+}
@@ -0,0 +1,89 @@
+package voting
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type fakeTx struct {
+	pgx.Tx
+	id int
+}
+
+type fakeRunner struct {
+	next      int
+	committed []int
+}
+
+func (r *fakeRunner) run(ctx context.Context, fn func(tx pgx.Tx) error) error {
+	r.next++
+	tx := &fakeTx{id: r.next}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	r.committed = append(r.committed, tx.id)
+	return nil
+}
+
+type queueRepo struct {
+	VoteRepository
+	queuedIn  pgx.Tx
+	discarded []string
+}
+
+func (r *queueRepo) QueueBallot(ctx context.Context, tx pgx.Tx, vote *Vote) error {
+	r.queuedIn = tx
+	vote.QueueID = "q-1"
+	return nil
+}
+
+func (r *queueRepo) DiscardQueuedBallot(ctx context.Context, tx pgx.Tx, queueID string) error {
+	r.discarded = append(r.discarded, queueID)
+	return nil
+}
+
+func TestCastWithBallot_QueuesOutsideVoterTx(t *testing.T) {
+	runner := &fakeRunner{}
+	repo := &queueRepo{}
+
+	var voterTx pgx.Tx
+	err := castWithBallot(context.Background(), runner.run, repo, func(tx pgx.Tx, queue queueFunc) error {
+		voterTx = tx
+		return queue(&Vote{ElectionID: 1, CandidateID: 10})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if repo.queuedIn == nil || repo.queuedIn == voterTx {
+		t.Fatal("ballot was queued in the voter's transaction")
+	}
+	// The ballot commits first, while the voter's row is still locked.
+	if len(runner.committed) != 2 || runner.committed[0] != repo.queuedIn.(*fakeTx).id || runner.committed[1] != voterTx.(*fakeTx).id {
+		t.Fatalf("unexpected commit order %v", runner.committed)
+	}
+	if len(repo.discarded) != 0 {
+		t.Fatalf("discarded a counted ballot: %v", repo.discarded)
+	}
+}
+
+func TestCastWithBallot_DiscardsBallotWhenVoterTxFails(t *testing.T) {
+	runner := &fakeRunner{}
+	repo := &queueRepo{}
+	errLate := errors.New("mark checkin used")
+
+	err := castWithBallot(context.Background(), runner.run, repo, func(tx pgx.Tx, queue queueFunc) error {
+		if err := queue(&Vote{ElectionID: 1, CandidateID: 10}); err != nil {
+			return err
+		}
+		return errLate
+	})
+	if !errors.Is(err, errLate) {
+		t.Fatalf("expected voter tx error, got %v", err)
+	}
+	if len(repo.discarded) != 1 || repo.discarded[0] != "q-1" {
+		t.Fatalf("queued ballot not discarded: %v", repo.discarded)
+	}
+}
@@ -1,6 +1,10 @@
 package voting
 
-import "time"
+import (
+	"time"
+
+	"pemira-api/internal/crypto"
+)
 
 // Request DTOs
 type CastVoteRequest struct {
@@ -8,14 +12,14 @@ type CastVoteRequest struct {
 }
 
 type CastOnlineVoteRequest struct {
-	ElectionID  int64 `json:"election_id"`
-	CandidateID int64 `json:"candidate_id"`
+	ElectionID  int64 `json:"election_id" validate:"required,min=1"`
+	CandidateID int64 `json:"candidate_id" validate:"required,min=1"`
 }
 
 type CastTPSVoteRequest struct {
-	ElectionID  int64 `json:"election_id"`
-	CandidateID int64 `json:"candidate_id"`
-	TPSID       int64 `json:"tps_id"`
+	ElectionID  int64 `json:"election_id" validate:"required,min=1"`
+	CandidateID int64 `json:"candidate_id" validate:"required,min=1"`
+	TPSID       int64 `json:"tps_id" validate:"required,min=1"`
 }
 
 // QR-based TPS voting (offline device)
@@ -83,6 +87,38 @@ type ReceiptDetail struct {
 	Note      string `json:"note"`
 }
 
+type VerifyReceiptRequest struct {
+	ElectionID int64  `json:"election_id"`
+	Receipt    string `json:"receipt"`
+}
+
+// ReceiptVerification: Queued ballots are recorded but wait for their shuffled
+// batch before they show up in the tally.
+type ReceiptVerification struct {
+	ElectionID int64 `json:"election_id"`
+	Recorded   bool  `json:"recorded"`
+	Counted    bool  `json:"counted"`
+	Queued     bool  `json:"queued"`
+}
+
+// CastEncryptedVoteRequest carries a ballot encrypted by the voter's client
+// under the election key. TPSID is set when casting at a TPS after check-in.
+type CastEncryptedVoteRequest struct {
+	ElectionID int64                   `json:"election_id"`
+	TPSID      *int64                  `json:"tps_id,omitempty"`
+	Ballot     *crypto.EncryptedBallot `json:"ballot"`
+}
+
+// EncryptedVoteResult: Tracker is the ballot's public fingerprint, listed on
+// the election's bulletin board.
+type EncryptedVoteResult struct {
+	ElectionID int64     `json:"election_id"`
+	Method     string    `json:"method"`
+	VotedAt    time.Time `json:"voted_at"`
+	Tracker    string    `json:"tracker"`
+	Note       string    `json:"note"`
+}
+
 type TPSInfo struct {
 	ID   int64  `json:"id"`
 	Code string `json:"code"`
@@ -117,6 +153,7 @@ type CastFromBallotQRResponse struct {
 	TPS        TPSInfo           `json:"tps"`
 	Status     string            `json:"status"`
 	Candidate  *CandidateSummary `json:"candidate,omitempty"`
+	Receipt    *ReceiptDetail    `json:"receipt,omitempty"`
 }
 
 type CandidateSummary struct {
@@ -1,6 +1,10 @@
 package voting
 
-import "time"
+import (
+	"time"
+
+	"pemira-api/internal/crypto"
+)
 
 type Vote struct {
 	ID            int64     `json:"id"`
@@ -10,8 +14,21 @@ type Vote struct {
 	Channel       string    `json:"channel"` // "ONLINE" | "TPS"
 	TPSID         *int64    `json:"tps_id"`
 	CandidateQRID *int64    `json:"candidate_qr_id,omitempty"`
-	BallotScanID  *int64    `json:"ballot_scan_id,omitempty"`
 	CastAt        time.Time `json:"cast_at"`
+	// QueueID is the random key of the ballot while it waits in ballot_queue.
+	QueueID string `json:"-"`
+}
+
+// EncryptedVote is a client-encrypted ballot. Like Vote it has no voter
+// reference.
+type EncryptedVote struct {
+	ID         int64                   `json:"id"`
+	ElectionID int64                   `json:"election_id"`
+	Tracker    string                  `json:"tracker"`
+	Ballot     *crypto.EncryptedBallot `json:"ballot"`
+	Channel    string                  `json:"channel"`
+	TPSID      *int64                  `json:"tps_id"`
+	CastAt     time.Time               `json:"cast_at"`
 }
 
 type VoteToken struct {
@@ -41,6 +58,9 @@ type VoterStatusEntity struct {
 	DigitalSignatureURL *string    `json:"digital_signature_url"`
 	CreatedAt       time.Time  `json:"created_at"`
 	UpdatedAt       time.Time  `json:"updated_at"`
+	// EmailUnverified is set by GetStatusForUpdate when the election
+	// requires a verified email the voter does not have
+	EmailUnverified bool `json:"-"`
 }
 
 type VoteResultEntity struct {
@@ -1,6 +1,11 @@
 package voting
 
-import "errors"
+import (
+	"errors"
+	"net/http"
+
+	"pemira-api/internal/errcatalog"
+)
 
 var (
 	ErrElectionNotFound      = errors.New("election not found")
@@ -23,8 +28,49 @@ var (
 	ErrModeNotAllowed        = errors.New("voting mode not available")
 	ErrVoteRequired          = errors.New("must vote before signing")
 	ErrSignatureAlreadyExists = errors.New("digital signature already submitted")
+	ErrInvalidReceipt        = errors.New("invalid receipt")
+	ErrEmailNotVerified      = errors.New("election requires a verified email")
+
+	ErrEncryptedBallotRequired = errors.New("election only accepts encrypted ballots")
+	ErrEncryptionNotEnabled    = errors.New("election does not accept encrypted ballots")
+	ErrEncryptionKeyNotReady   = errors.New("election key ceremony has not completed")
+	ErrInvalidBallot           = errors.New("encrypted ballot does not verify")
+	ErrDuplicateBallot         = errors.New("encrypted ballot already submitted")
 )
 
+// The API codes and messages of the errors above; handleError writes them
+func init() {
+	errcatalog.Register(
+		errcatalog.Entry{Err: ErrElectionNotFound, Code: "ELECTION_NOT_FOUND", Status: http.StatusNotFound, ID: "Pemilu aktif tidak ditemukan.", EN: "Active election not found."},
+		errcatalog.Entry{Err: ErrElectionNotOpen, Code: "ELECTION_NOT_OPEN", Status: http.StatusBadRequest, ID: "Pemilu belum dibuka atau sudah ditutup untuk voting.", EN: "The election is not open for voting yet or has closed."},
+		errcatalog.Entry{Err: ErrMethodNotAllowed, Code: "CHANNEL_NOT_ALLOWED", Status: http.StatusBadRequest, ID: "Metode voting ini tidak diizinkan untuk pemilu ini.", EN: "This voting method is not allowed in this election."},
+		errcatalog.Entry{Err: ErrNotEligible, Code: "VOTER_NOT_ELIGIBLE", Status: http.StatusForbidden, ID: "Anda tidak terdaftar sebagai pemilih pada pemilu ini.", EN: "You are not registered as a voter in this election."},
+		errcatalog.Entry{Err: ErrEmailNotVerified, Code: "EMAIL_NOT_VERIFIED", Status: http.StatusForbidden, ID: "Pemilu ini mewajibkan email terverifikasi sebelum memilih.", EN: "This election requires a verified email before voting."},
+		errcatalog.Entry{Err: ErrAlreadyVoted, Code: "ALREADY_VOTED", Status: http.StatusConflict, ID: "Anda sudah memberikan suara pada pemilu ini.", EN: "You have already voted in this election."},
+		errcatalog.Entry{Err: ErrCandidateNotFound, Code: "CANDIDATE_NOT_FOUND", Status: http.StatusNotFound, ID: "Kandidat tidak ditemukan untuk pemilu ini.", EN: "Candidate not found in this election."},
+		errcatalog.Entry{Err: ErrCandidateInactive, Code: "CANDIDATE_INACTIVE", Status: http.StatusBadRequest, ID: "Kandidat tidak aktif.", EN: "The candidate is not active."},
+		errcatalog.Entry{Err: ErrTPSCheckinNotFound, Code: "TPS_CHECKIN_NOT_FOUND", Status: http.StatusBadRequest, ID: "Anda belum melakukan check-in TPS yang valid.", EN: "You have no valid TPS check-in."},
+		errcatalog.Entry{Err: ErrTPSCheckinNotApproved, Code: "TPS_CHECKIN_NOT_APPROVED", Status: http.StatusBadRequest, ID: "Check-in Anda belum disetujui panitia TPS.", EN: "Your check-in has not been approved by the TPS staff."},
+		errcatalog.Entry{Err: ErrCheckinExpired, Code: "CHECKIN_EXPIRED", Status: http.StatusBadRequest, ID: "Waktu validasi check-in Anda sudah habis, silakan ulangi di TPS.", EN: "Your check-in has expired, please check in again at the TPS."},
+		errcatalog.Entry{Err: ErrTPSNotFound, Code: "TPS_NOT_FOUND", Status: http.StatusNotFound, ID: "TPS tidak ditemukan.", EN: "TPS not found."},
+		errcatalog.Entry{Err: ErrVoterMappingMissing, Code: "VOTER_MAPPING_MISSING", Status: http.StatusForbidden, ID: "Akun ini belum terhubung dengan data pemilih.", EN: "This account is not linked to a voter record."},
+		errcatalog.Entry{Err: ErrInvalidBallotQR, Code: "INVALID_BALLOT_QR", Status: http.StatusBadRequest, ID: "Kode QR surat suara tidak dikenali.", EN: "The ballot QR code is not recognized."},
+		errcatalog.Entry{Err: ErrElectionMismatch, Code: "ELECTION_MISMATCH", Status: http.StatusBadRequest, ID: "Kode QR tidak sesuai dengan pemilu yang sedang Anda ikuti.", EN: "The QR code does not belong to your election."},
+		errcatalog.Entry{Err: ErrNotTPSVoter, Code: "NOT_TPS_VOTER", Status: http.StatusBadRequest, ID: "Mode pemilihan Anda bukan TPS.", EN: "Your voting method is not TPS."},
+		errcatalog.Entry{Err: ErrNoActiveCheckin, Code: "NO_ACTIVE_CHECKIN", Status: http.StatusBadRequest, ID: "Anda belum tercatat melakukan check-in di TPS.", EN: "You have not checked in at a TPS."},
+		errcatalog.Entry{Err: ErrModeNotAllowed, Code: "MODE_NOT_AVAILABLE", Status: http.StatusUnprocessableEntity, ID: "Mode voting tidak tersedia.", EN: "This voting mode is not available."},
+		errcatalog.Entry{Err: ErrDuplicateVoteAttempt, Code: "DUPLICATE_VOTE_ATTEMPT", Status: http.StatusConflict, ID: "Permintaan ini tidak dapat diproses karena suara Anda sudah tercatat.", EN: "This request cannot be processed because your vote is already recorded."},
+		errcatalog.Entry{Err: ErrVoteRequired, Code: "VOTE_REQUIRED", Status: http.StatusBadRequest, ID: "Anda harus melakukan pemilihan terlebih dahulu.", EN: "You must vote first."},
+		errcatalog.Entry{Err: ErrSignatureAlreadyExists, Code: "SIGNATURE_EXISTS", Status: http.StatusConflict, ID: "Tanda tangan digital sudah ada.", EN: "A digital signature has already been submitted."},
+		errcatalog.Entry{Err: ErrInvalidReceipt, Code: "INVALID_RECEIPT", Status: http.StatusBadRequest, ID: "Kode tanda terima wajib diisi.", EN: "The receipt code is required."},
+		errcatalog.Entry{Err: ErrEncryptedBallotRequired, Code: "ENCRYPTED_BALLOT_REQUIRED", Status: http.StatusConflict, ID: "Pemilu ini hanya menerima surat suara terenkripsi.", EN: "This election only accepts encrypted ballots."},
+		errcatalog.Entry{Err: ErrEncryptionNotEnabled, Code: "ENCRYPTION_NOT_ENABLED", Status: http.StatusBadRequest, ID: "Pemilu ini tidak menggunakan surat suara terenkripsi.", EN: "This election does not use encrypted ballots."},
+		errcatalog.Entry{Err: ErrEncryptionKeyNotReady, Code: "KEY_NOT_READY", Status: http.StatusConflict, ID: "Kunci pemilu belum siap. Hubungi panitia.", EN: "The election key is not ready yet. Contact the committee."},
+		errcatalog.Entry{Err: ErrInvalidBallot, Code: "INVALID_BALLOT", Status: http.StatusUnprocessableEntity, ID: "Surat suara terenkripsi tidak valid.", EN: "The encrypted ballot is invalid."},
+		errcatalog.Entry{Err: ErrDuplicateBallot, Code: "DUPLICATE_BALLOT", Status: http.StatusConflict, ID: "Surat suara ini sudah pernah dikirim.", EN: "This ballot has already been submitted."},
+	)
+}
+
 func translateNotFound(err error, customErr error) error {
 	if err != nil && err.Error() == "no rows in result set" {
 		return customErr
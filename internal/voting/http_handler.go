@@ -11,9 +11,15 @@ import (
 	"github.com/go-chi/chi/v5"
 
 	"pemira-api/internal/auth"
+	"pemira-api/internal/errcatalog"
 	"pemira-api/internal/http/response"
+	"pemira-api/internal/tps"
 )
 
+// maxEncryptedBallotSize caps an encrypted ballot upload, roughly 6 KB per
+// candidate.
+const maxEncryptedBallotSize = 256 << 10
+
 type Handler struct {
 	service *Service
 }
@@ -50,11 +56,11 @@ type setMethodRequest struct {
 	TPSID      *int64 `json:"tps_id,omitempty"` // required if method=TPS
 }
 
-type scanCandidateRequest struct {
+type ScanTPSCandidateRequest struct {
 	BallotQRPayload string `json:"ballot_qr_payload"`
 }
 
-type voterQRRequest struct {
+type VoterTPSQRRequest struct {
 	ElectionID int64 `json:"election_id"`
 }
 
@@ -64,6 +70,8 @@ func (h *Handler) RegisterRoutes(r chi.Router) {
 	r.Post("/voting/tps/cast", h.CastTPSVote)
 	r.Get("/voting/tps/status", h.GetTPSVotingStatus)
 	r.Get("/voting/receipt", h.GetVotingReceipt)
+	r.Post("/voting/receipt/verify", h.VerifyReceipt)
+	r.Post("/voting/encrypted/cast", h.CastEncryptedVote)
 	r.Post("/voting/method", h.SetVoterMethod)
 	r.Post("/tps/{tpsID}/checkins/{checkinID}/scan-candidate", h.ScanTPSCandidate)
 	r.Post("/tps/ballots/parse-qr", h.ParseBallotQR)
@@ -81,20 +89,20 @@ func (h *Handler) CastOnlineVote(w http.ResponseWriter, r *http.Request) {
 	// Get auth user from context
 	authUser, ok := auth.FromContext(ctx)
 	if !ok {
-		response.Unauthorized(w, "UNAUTHORIZED", "Token tidak valid.")
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.InvalidToken)
 		return
 	}
 
 	// Parse request body
 	var reqBody onlineVoteRequest
 	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
-		response.BadRequest(w, "VALIDATION_ERROR", "Body tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
 		return
 	}
 
 	// Validate required fields
 	if reqBody.ElectionID <= 0 || reqBody.CandidateID <= 0 {
-		response.UnprocessableEntity(w, "VALIDATION_ERROR", "election_id dan candidate_id wajib diisi.")
+		response.UnprocessableEntity(w, "VALIDATION_ERROR", errcatalog.Required("election_id", "candidate_id"))
 		return
 	}
 
@@ -121,18 +129,18 @@ func (h *Handler) SubmitDigitalSignature(w http.ResponseWriter, r *http.Request)
 	ctx := r.Context()
 	authUser, ok := auth.FromContext(ctx)
 	if !ok {
-		response.Unauthorized(w, "UNAUTHORIZED", "Token tidak valid.")
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.InvalidToken)
 		return
 	}
 
 	var reqBody SubmitSignatureRequest
 	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
-		response.BadRequest(w, "VALIDATION_ERROR", "Body tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
 		return
 	}
 
 	if reqBody.ElectionID <= 0 || strings.TrimSpace(reqBody.Signature) == "" {
-		response.UnprocessableEntity(w, "VALIDATION_ERROR", "election_id dan signature wajib diisi.")
+		response.UnprocessableEntity(w, "VALIDATION_ERROR", errcatalog.Required("election_id", "signature"))
 		return
 	}
 
@@ -153,20 +161,20 @@ func (h *Handler) CastTPSVote(w http.ResponseWriter, r *http.Request) {
 	// Get auth user from context
 	authUser, ok := auth.FromContext(ctx)
 	if !ok {
-		response.Unauthorized(w, "UNAUTHORIZED", "Token tidak valid.")
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.InvalidToken)
 		return
 	}
 
 	// Parse request body
 	var reqBody tpsVoteRequest
 	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
-		response.BadRequest(w, "VALIDATION_ERROR", "Body tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
 		return
 	}
 
 	// Validate required fields
 	if reqBody.ElectionID <= 0 || reqBody.CandidateID <= 0 || reqBody.TPSID <= 0 {
-		response.UnprocessableEntity(w, "VALIDATION_ERROR", "election_id, candidate_id, dan tps_id wajib diisi.")
+		response.UnprocessableEntity(w, "VALIDATION_ERROR", errcatalog.Required("election_id", "candidate_id", "tps_id"))
 		return
 	}
 
@@ -194,12 +202,12 @@ func (h *Handler) GetTPSVotingStatus(w http.ResponseWriter, r *http.Request) {
 
 	authUser, ok := auth.FromContext(ctx)
 	if !ok {
-		response.Unauthorized(w, "UNAUTHORIZED", "Token tidak valid.")
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.InvalidToken)
 		return
 	}
 
 	if authUser.VoterID == nil {
-		response.Forbidden(w, "VOTER_MAPPING_MISSING", "Akun ini belum terhubung dengan data pemilih.")
+		response.Forbidden(w, "VOTER_MAPPING_MISSING", errcatalog.VoterNotLinked)
 		return
 	}
 
@@ -218,12 +226,12 @@ func (h *Handler) GetVotingReceipt(w http.ResponseWriter, r *http.Request) {
 
 	authUser, ok := auth.FromContext(ctx)
 	if !ok {
-		response.Unauthorized(w, "UNAUTHORIZED", "Token tidak valid.")
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.InvalidToken)
 		return
 	}
 
 	if authUser.VoterID == nil {
-		response.Forbidden(w, "VOTER_MAPPING_MISSING", "Akun ini belum terhubung dengan data pemilih.")
+		response.Forbidden(w, "VOTER_MAPPING_MISSING", errcatalog.VoterNotLinked)
 		return
 	}
 
@@ -236,24 +244,73 @@ func (h *Handler) GetVotingReceipt(w http.ResponseWriter, r *http.Request) {
 	response.Success(w, http.StatusOK, receipt)
 }
 
+// POST /voting/receipt/verify
+func (h *Handler) VerifyReceipt(w http.ResponseWriter, r *http.Request) {
+	var req VerifyReceiptRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
+		return
+	}
+	if req.ElectionID <= 0 {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Required("election_id"))
+		return
+	}
+
+	result, err := h.service.VerifyReceipt(r.Context(), req.ElectionID, req.Receipt)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.Success(w, http.StatusOK, result)
+}
+
+// POST /voting/encrypted/cast
+func (h *Handler) CastEncryptedVote(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authUser, ok := auth.FromContext(ctx)
+	if !ok {
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.InvalidToken)
+		return
+	}
+
+	var req CastEncryptedVoteRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxEncryptedBallotSize)).Decode(&req); err != nil {
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
+		return
+	}
+	if req.ElectionID <= 0 || req.Ballot == nil {
+		response.UnprocessableEntity(w, "VALIDATION_ERROR", errcatalog.Required("election_id", "ballot"))
+		return
+	}
+
+	result, err := h.service.CastEncryptedVote(ctx, authUser, req)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.Success(w, http.StatusOK, result)
+}
+
 // POST /voting/method
 func (h *Handler) SetVoterMethod(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	authUser, ok := auth.FromContext(ctx)
 	if !ok {
-		response.Unauthorized(w, "UNAUTHORIZED", "Token tidak valid.")
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.InvalidToken)
 		return
 	}
 
 	var reqBody setMethodRequest
 	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
-		response.BadRequest(w, "VALIDATION_ERROR", "Body tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
 		return
 	}
 
 	if reqBody.ElectionID <= 0 || strings.TrimSpace(reqBody.Method) == "" {
-		response.UnprocessableEntity(w, "VALIDATION_ERROR", "election_id dan method wajib diisi.")
+		response.UnprocessableEntity(w, "VALIDATION_ERROR", errcatalog.Required("election_id", "method"))
 		return
 	}
 
@@ -278,28 +335,28 @@ func (h *Handler) ScanTPSCandidate(w http.ResponseWriter, r *http.Request) {
 
 	authUser, ok := auth.FromContext(ctx)
 	if !ok {
-		response.Unauthorized(w, "UNAUTHORIZED", "Token tidak valid.")
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.InvalidToken)
 		return
 	}
 
 	tpsID, err := strconv.ParseInt(chi.URLParam(r, "tpsID"), 10, 64)
 	if err != nil || tpsID <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "tpsID tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("tpsID"))
 		return
 	}
 	checkinID, err := strconv.ParseInt(chi.URLParam(r, "checkinID"), 10, 64)
 	if err != nil || checkinID <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "checkinID tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("checkinID"))
 		return
 	}
 
-	var reqBody scanCandidateRequest
+	var reqBody ScanTPSCandidateRequest
 	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
-		response.BadRequest(w, "VALIDATION_ERROR", "Body tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
 		return
 	}
 	if strings.TrimSpace(reqBody.BallotQRPayload) == "" {
-		response.UnprocessableEntity(w, "VALIDATION_ERROR", "ballot_qr_payload wajib diisi.")
+		response.UnprocessableEntity(w, "VALIDATION_ERROR", errcatalog.Required("ballot_qr_payload"))
 		return
 	}
 
@@ -325,18 +382,18 @@ func (h *Handler) ParseBallotQR(w http.ResponseWriter, r *http.Request) {
 
 	authUser, ok := auth.FromContext(ctx)
 	if !ok {
-		response.Unauthorized(w, "UNAUTHORIZED", "Token tidak valid.")
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.InvalidToken)
 		return
 	}
 
 	var reqBody castBallotQRRequest
 	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
-		response.BadRequest(w, "VALIDATION_ERROR", "Body tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
 		return
 	}
 
 	if strings.TrimSpace(reqBody.BallotQRPayload) == "" {
-		response.UnprocessableEntity(w, "VALIDATION_ERROR", "ballot_qr_payload wajib diisi.")
+		response.UnprocessableEntity(w, "VALIDATION_ERROR", errcatalog.Required("ballot_qr_payload"))
 		return
 	}
 
@@ -360,17 +417,17 @@ func (h *Handler) CastBallotFromQR(w http.ResponseWriter, r *http.Request) {
 
 	authUser, ok := auth.FromContext(ctx)
 	if !ok {
-		response.Unauthorized(w, "UNAUTHORIZED", "Token tidak valid.")
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.InvalidToken)
 		return
 	}
 
 	var reqBody castBallotQRRequest
 	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
-		response.BadRequest(w, "VALIDATION_ERROR", "Body tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
 		return
 	}
 	if strings.TrimSpace(reqBody.BallotQRPayload) == "" {
-		response.UnprocessableEntity(w, "VALIDATION_ERROR", "ballot_qr_payload wajib diisi.")
+		response.UnprocessableEntity(w, "VALIDATION_ERROR", errcatalog.Required("ballot_qr_payload"))
 		return
 	}
 
@@ -394,18 +451,18 @@ func (h *Handler) GetVoterTPSQR(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	authUser, ok := auth.FromContext(ctx)
 	if !ok {
-		response.Unauthorized(w, "UNAUTHORIZED", "Token tidak valid.")
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.InvalidToken)
 		return
 	}
 
 	voterID, err := strconv.ParseInt(chi.URLParam(r, "voterID"), 10, 64)
 	if err != nil || voterID <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "voterID tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("voterID"))
 		return
 	}
 	electionID, _ := strconv.ParseInt(r.URL.Query().Get("election_id"), 10, 64)
 	if electionID <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "election_id wajib diisi.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Required("election_id"))
 		return
 	}
 
@@ -422,23 +479,23 @@ func (h *Handler) GenerateVoterTPSQR(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	authUser, ok := auth.FromContext(ctx)
 	if !ok {
-		response.Unauthorized(w, "UNAUTHORIZED", "Token tidak valid.")
+		response.Unauthorized(w, "UNAUTHORIZED", errcatalog.InvalidToken)
 		return
 	}
 
 	voterID, err := strconv.ParseInt(chi.URLParam(r, "voterID"), 10, 64)
 	if err != nil || voterID <= 0 {
-		response.BadRequest(w, "VALIDATION_ERROR", "voterID tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.Invalid("voterID"))
 		return
 	}
 
-	var req voterQRRequest
+	var req VoterTPSQRRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.BadRequest(w, "VALIDATION_ERROR", "Body tidak valid.")
+		response.BadRequest(w, "VALIDATION_ERROR", errcatalog.InvalidBody)
 		return
 	}
 	if req.ElectionID <= 0 {
-		response.UnprocessableEntity(w, "VALIDATION_ERROR", "election_id wajib diisi.")
+		response.UnprocessableEntity(w, "VALIDATION_ERROR", errcatalog.Required("election_id"))
 		return
 	}
 
@@ -450,71 +507,20 @@ func (h *Handler) GenerateVoterTPSQR(w http.ResponseWriter, r *http.Request) {
 	response.JSON(w, http.StatusOK, qr)
 }
 
-// handleError maps domain errors to HTTP responses
+// handleError maps domain errors to HTTP responses through the error
+// catalog (see errors.go)
 func (h *Handler) handleError(w http.ResponseWriter, err error) {
-	switch {
-	case errors.Is(err, ErrElectionNotFound):
-		response.NotFound(w, "ELECTION_NOT_FOUND", "Pemilu aktif tidak ditemukan.")
-
-	case errors.Is(err, ErrElectionNotOpen):
-		response.BadRequest(w, "ELECTION_NOT_OPEN", "Pemilu belum dibuka atau sudah ditutup untuk voting.")
-
-	case errors.Is(err, ErrMethodNotAllowed):
-		response.BadRequest(w, "CHANNEL_NOT_ALLOWED", "Metode voting ini tidak diizinkan untuk pemilu ini.")
-
-	case errors.Is(err, ErrNotEligible):
-		response.Forbidden(w, "VOTER_NOT_ELIGIBLE", "Anda tidak terdaftar sebagai pemilih pada pemilu ini.")
-
-	case errors.Is(err, ErrAlreadyVoted):
-		response.Conflict(w, "ALREADY_VOTED", "Anda sudah memberikan suara pada pemilu ini.")
-
-	case errors.Is(err, ErrCandidateNotFound):
-		response.NotFound(w, "CANDIDATE_NOT_FOUND", "Kandidat tidak ditemukan untuk pemilu ini.")
-
-	case errors.Is(err, ErrCandidateInactive):
-		response.BadRequest(w, "CANDIDATE_INACTIVE", "Kandidat tidak aktif.")
-
-	case errors.Is(err, ErrTPSCheckinNotFound):
-		response.BadRequest(w, "TPS_CHECKIN_NOT_FOUND", "Anda belum melakukan check-in TPS yang valid.")
-
-	case errors.Is(err, ErrTPSCheckinNotApproved):
-		response.BadRequest(w, "TPS_CHECKIN_NOT_APPROVED", "Check-in Anda belum disetujui panitia TPS.")
-
-	case errors.Is(err, ErrCheckinExpired):
-		response.BadRequest(w, "CHECKIN_EXPIRED", "Waktu validasi check-in Anda sudah habis, silakan ulangi di TPS.")
-
-	case errors.Is(err, ErrTPSNotFound):
-		response.NotFound(w, "TPS_NOT_FOUND", "TPS tidak ditemukan.")
-
-	case errors.Is(err, ErrVoterMappingMissing):
-		response.Forbidden(w, "VOTER_MAPPING_MISSING", "Akun ini belum terhubung dengan data pemilih.")
-
-	case errors.Is(err, ErrInvalidBallotQR):
-		response.BadRequest(w, "INVALID_BALLOT_QR", "Kode QR surat suara tidak dikenali.")
-
-	case errors.Is(err, ErrElectionMismatch):
-		response.BadRequest(w, "ELECTION_MISMATCH", "Kode QR tidak sesuai dengan pemilu yang sedang Anda ikuti.")
-
-	case errors.Is(err, ErrNotTPSVoter):
-		response.BadRequest(w, "NOT_TPS_VOTER", "Mode pemilihan Anda bukan TPS.")
-
-	case errors.Is(err, ErrNoActiveCheckin):
-		response.BadRequest(w, "NO_ACTIVE_CHECKIN", "Anda belum tercatat melakukan check-in di TPS.")
-
-	case errors.Is(err, ErrModeNotAllowed):
-		response.UnprocessableEntity(w, "MODE_NOT_AVAILABLE", "Mode voting tidak tersedia.")
-
-	case errors.Is(err, ErrDuplicateVoteAttempt):
-		response.Conflict(w, "DUPLICATE_VOTE_ATTEMPT", "Permintaan ini tidak dapat diproses karena suara Anda sudah tercatat.")
-
-	case errors.Is(err, ErrVoteRequired):
-		response.BadRequest(w, "VOTE_REQUIRED", "Anda harus melakukan pemilihan terlebih dahulu.")
-
-	case errors.Is(err, ErrSignatureAlreadyExists):
-		response.Conflict(w, "SIGNATURE_EXISTS", "Tanda tangan digital sudah ada.")
-
-	default:
-		fmt.Printf("[ErrorHandler] Internal Error: %v\n", err)
-		response.InternalServerError(w, "INTERNAL_ERROR", "Terjadi kesalahan pada sistem.")
+	var closed *tps.SessionClosedError
+	if errors.As(err, &closed) {
+		e, _ := errcatalog.Lookup(err)
+		response.Error(w, e.Status, e.Code, e.Message(), map[string]interface{}{
+			"next_session": closed.Next,
+		})
+		return
+	}
+	if response.DomainError(w, err) {
+		return
 	}
+	fmt.Printf("[ErrorHandler] Internal Error: %v\n", err)
+	response.InternalServerError(w, "INTERNAL_ERROR", errcatalog.InternalError)
 }
@@ -32,8 +32,18 @@ type VoteRepository interface {
 	// InsertToken inserts a new vote token
 	InsertToken(ctx context.Context, tx pgx.Tx, token *VoteToken) error
 
-	// InsertVote inserts a new vote
-	InsertVote(ctx context.Context, tx pgx.Tx, vote *Vote) error
+	// QueueBallot queues a ballot, setting vote.QueueID, and releases the
+	// queue into votes in a shuffled batch once enough ballots are waiting.
+	// tx must not be the voter's transaction.
+	QueueBallot(ctx context.Context, tx pgx.Tx, vote *Vote) error
+
+	// DiscardQueuedBallot removes a queued ballot whose voter transaction
+	// failed; a no-op once the ballot was released
+	DiscardQueuedBallot(ctx context.Context, tx pgx.Tx, queueID string) error
+
+	// InsertEncryptedBallot stores a client-encrypted ballot; ErrDuplicateBallot
+	// if its tracker is already on the bulletin board
+	InsertEncryptedBallot(ctx context.Context, tx pgx.Tx, vote *EncryptedVote) error
 
 	// MarkTokenUsed marks a token as used
 	MarkTokenUsed(ctx context.Context, tx pgx.Tx, electionID int64, tokenHash string, usedAt time.Time) error
@@ -64,12 +74,6 @@ type VoteRepository interface {
 	InsertVoterQR(ctx context.Context, tx pgx.Tx, qr *VoterTPSQR) error
 }
 
-// VoteStatsRepository handles vote statistics (optional, for read-model)
-type VoteStatsRepository interface {
-	// IncrementCandidateCount increments vote count for a candidate
-	IncrementCandidateCount(ctx context.Context, tx pgx.Tx, electionID, candidateID int64, channel string, tpsID *int64) error
-}
-
 // Repository is the legacy interface (kept for backward compatibility)
 type Repository interface {
 	CreateVote(ctx context.Context, vote *Vote) error
@@ -17,7 +17,7 @@ func NewCandidateRepository() CandidateRepository {
 
 func (r *candidateRepository) GetByIDWithTx(ctx context.Context, tx pgx.Tx, candidateID int64) (*candidate.Candidate, error) {
 	query := `
-		SELECT id, election_id, number, name, vision, photo_url, status, created_at, updated_at
+		SELECT id, election_id, COALESCE(number, 0), name, vision, photo_url, status, created_at, updated_at
 		FROM candidates
 		WHERE id = $1
 	`
@@ -2,10 +2,12 @@ package voting
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"pemira-api/internal/shared"
 	"pemira-api/internal/tps"
 )
@@ -39,11 +41,11 @@ func (r *voteRepository) InsertToken(ctx context.Context, tx pgx.Tx, token *Vote
 	return nil
 }
 
-func (r *voteRepository) InsertVote(ctx context.Context, tx pgx.Tx, vote *Vote) error {
+func (r *voteRepository) QueueBallot(ctx context.Context, tx pgx.Tx, vote *Vote) error {
 	query := `
-		INSERT INTO votes (election_id, candidate_id, token_hash, channel, tps_id, candidate_qr_id, ballot_scan_id, cast_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-		RETURNING id
+		INSERT INTO ballot_queue (election_id, candidate_id, token_hash, channel, tps_id, candidate_qr_id, cast_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id::text
 	`
 
 	err := tx.QueryRow(ctx, query,
@@ -53,12 +55,47 @@ func (r *voteRepository) InsertVote(ctx context.Context, tx pgx.Tx, vote *Vote)
 		vote.Channel,
 		vote.TPSID,
 		vote.CandidateQRID,
-		vote.BallotScanID,
 		vote.CastAt,
-	).Scan(&vote.ID)
+	).Scan(&vote.QueueID)
+	if err != nil {
+		return fmt.Errorf("queue ballot: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `SELECT flush_ballot_queue($1, $2)`, vote.ElectionID, ballotBatchSize); err != nil {
+		return fmt.Errorf("flush ballot queue: %w", err)
+	}
+
+	return nil
+}
+
+func (r *voteRepository) DiscardQueuedBallot(ctx context.Context, tx pgx.Tx, queueID string) error {
+	if _, err := tx.Exec(ctx, `DELETE FROM ballot_queue WHERE id = $1::uuid`, queueID); err != nil {
+		return fmt.Errorf("discard queued ballot: %w", err)
+	}
+	return nil
+}
 
+func (r *voteRepository) InsertEncryptedBallot(ctx context.Context, tx pgx.Tx, vote *EncryptedVote) error {
+	query := `
+		INSERT INTO encrypted_ballots (election_id, tracker, ballot, channel, tps_id, cast_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`
+
+	err := tx.QueryRow(ctx, query,
+		vote.ElectionID,
+		vote.Tracker,
+		vote.Ballot,
+		vote.Channel,
+		vote.TPSID,
+		vote.CastAt,
+	).Scan(&vote.ID)
 	if err != nil {
-		return fmt.Errorf("insert vote: %w", err)
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return ErrDuplicateBallot
+		}
+		return fmt.Errorf("insert encrypted ballot: %w", err)
 	}
 
 	return nil
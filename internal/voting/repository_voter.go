@@ -16,12 +16,15 @@ func NewVoterRepository() VoterRepository {
 
 func (r *voterRepository) GetStatusForUpdate(ctx context.Context, tx pgx.Tx, electionID, voterID int64) (*VoterStatusEntity, error) {
 	query := `
-		SELECT id, election_id, voter_id, is_eligible, has_voted, 
-		       voting_method, tps_id, voted_at, vote_token_hash,
-		       preferred_method, online_allowed, tps_allowed, digital_signature_url
-		FROM voter_status
-		WHERE election_id = $1 AND voter_id = $2
-		FOR UPDATE
+		SELECT vs.id, vs.election_id, vs.voter_id, vs.is_eligible, vs.has_voted, 
+		       vs.voting_method, vs.tps_id, vs.voted_at, vs.vote_token_hash,
+		       vs.preferred_method, vs.online_allowed, vs.tps_allowed, vs.digital_signature_url,
+		       (e.require_email_verification AND v.email_verified_at IS NULL) AS email_unverified
+		FROM voter_status vs
+		JOIN elections e ON e.id = vs.election_id
+		JOIN voters v ON v.id = vs.voter_id
+		WHERE vs.election_id = $1 AND vs.voter_id = $2
+		FOR UPDATE OF vs
 	`
 
 	var vs VoterStatusEntity
@@ -40,6 +43,7 @@ func (r *voterRepository) GetStatusForUpdate(ctx context.Context, tx pgx.Tx, ele
 		&vs.OnlineAllowed,
 		&vs.TPSAllowed,
 		&vs.DigitalSignatureURL,
+		&vs.EmailUnverified,
 	)
 
 	if err != nil {
@@ -15,9 +15,12 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"pemira-api/internal/auth"
+	"pemira-api/internal/crypto"
 	"pemira-api/internal/election"
+	"pemira-api/internal/embargo"
 	"pemira-api/internal/shared"
 	"pemira-api/internal/shared/constants"
+	"pemira-api/internal/telemetry"
 	"pemira-api/internal/tps"
 )
 
@@ -28,8 +31,29 @@ type Service struct {
 	voterRepo     VoterRepository
 	candidateRepo CandidateRepository
 	voteRepo      VoteRepository
-	statsRepo     VoteStatsRepository
 	auditSvc      AuditService
+	ballots       BallotVerifier
+	schedule      TPSSchedule
+	embargo       embargo.Policy
+	ballotSlots   chan struct{}
+}
+
+// BallotVerifier checks end-to-end encrypted ballots against an election's
+// threshold key (see internal/electionkey).
+type BallotVerifier interface {
+	// EncryptionMode reports whether the election takes encrypted ballots
+	// only, and whether its key ceremony has completed.
+	EncryptionMode(ctx context.Context, electionID int64) (required, ready bool, err error)
+	// VerifyBallot checks the ballot's proofs against the election key.
+	VerifyBallot(ctx context.Context, electionID int64, ballot *crypto.EncryptedBallot) error
+}
+
+// TPSSchedule enforces the opening sessions of TPS (see
+// tps.ScheduleService).
+type TPSSchedule interface {
+	// EnsureOpen returns a *tps.SessionClosedError when t falls outside
+	// every session of the TPS.
+	EnsureOpen(ctx context.Context, tpsID int64, t time.Time) error
 }
 
 type SetMethodRequest struct {
@@ -70,7 +94,6 @@ func NewVotingService(
 	voterRepo VoterRepository,
 	candidateRepo CandidateRepository,
 	voteRepo VoteRepository,
-	statsRepo VoteStatsRepository,
 	auditSvc AuditService,
 ) *Service {
 	return &Service{
@@ -79,11 +102,37 @@ func NewVotingService(
 		voterRepo:     voterRepo,
 		candidateRepo: candidateRepo,
 		voteRepo:      voteRepo,
-		statsRepo:     statsRepo,
 		auditSvc:      auditSvc,
+		ballotSlots:   ballotSlots(db),
 	}
 }
 
+// SetBallotVerifier enables encrypted ballots. Without it every election
+// takes plaintext ballots only.
+func (s *Service) SetBallotVerifier(v BallotVerifier) {
+	s.ballots = v
+}
+
+// SetTPSSchedule rejects TPS votes outside the sessions of the TPS. Without
+// it TPS votes are accepted whenever the election is open.
+func (s *Service) SetTPSSchedule(sch TPSSchedule) {
+	s.schedule = sch
+}
+
+// SetEmbargo makes GetLiveCount fail with embargo.ErrResultsEmbargoed while
+// the election's results are under embargo.
+func (s *Service) SetEmbargo(p embargo.Policy) {
+	s.embargo = p
+}
+
+// ensureTPSOpen rejects a TPS vote outside the sessions of the TPS
+func (s *Service) ensureTPSOpen(ctx context.Context, tpsID int64) error {
+	if s.schedule == nil {
+		return nil
+	}
+	return s.schedule.EnsureOpen(ctx, tpsID, time.Now())
+}
+
 // withTx executes a function within a transaction
 func (s *Service) withTx(ctx context.Context, fn func(tx pgx.Tx) error) error {
 	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{})
@@ -177,11 +226,35 @@ func (s *Service) CastTPSVote(ctx context.Context, authUser auth.AuthUser, req C
 	}
 
 	// 4. Get & validate latest approved check-in
+	checkin, err := s.approvedCheckin(ctx, req.ElectionID, voterID, req.TPSID)
+	if err != nil {
+		return err
+	}
+
+	// 5. Cast vote with TPS info
+	_, err = s.castVote(ctx, req.ElectionID, voterID, req.CandidateID, "TPS", &req.TPSID)
+	if err != nil {
+		return err
+	}
+
+	// 6. Mark check-in as used
+	if err := s.withTx(ctx, func(tx pgx.Tx) error {
+		return s.voteRepo.MarkCheckinUsed(ctx, tx, checkin.ID, time.Now().UTC())
+	}); err == nil {
+		telemetry.CheckinRecorded(tps.CheckinStatusUsed)
+	}
+
+	return nil
+}
+
+// approvedCheckin returns the voter's latest approved, unexpired check-in at
+// the given TPS, provided the TPS is within one of its sessions.
+func (s *Service) approvedCheckin(ctx context.Context, electionID, voterID, tpsID int64) (*tps.TPSCheckin, error) {
 	var checkin *tps.TPSCheckin
 
-	err = s.withTx(ctx, func(tx pgx.Tx) error {
+	err := s.withTx(ctx, func(tx pgx.Tx) error {
 		var err error
-		checkin, err = s.voteRepo.GetLatestApprovedCheckin(ctx, tx, req.ElectionID, voterID)
+		checkin, err = s.voteRepo.GetLatestApprovedCheckin(ctx, tx, electionID, voterID)
 		if err != nil {
 			return translateNotFound(err, ErrTPSCheckinNotFound)
 		}
@@ -197,7 +270,7 @@ func (s *Service) CastTPSVote(ctx context.Context, authUser auth.AuthUser, req C
 		}
 
 		// Validate TPS ID matches request
-		if checkin.TPSID != req.TPSID {
+		if checkin.TPSID != tpsID {
 			return ErrTPSNotFound
 		}
 
@@ -205,20 +278,27 @@ func (s *Service) CastTPSVote(ctx context.Context, authUser auth.AuthUser, req C
 	})
 
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if err := s.ensureTPSOpen(ctx, tpsID); err != nil {
+		return nil, err
 	}
+	return checkin, nil
+}
 
-	// 5. Cast vote with TPS info
-	_, err = s.castVote(ctx, req.ElectionID, voterID, req.CandidateID, "TPS", &req.TPSID)
+// ensurePlaintextBallots rejects plaintext ballots for elections that take
+// encrypted ballots only, so the two never mix in one tally.
+func (s *Service) ensurePlaintextBallots(ctx context.Context, electionID int64) error {
+	if s.ballots == nil {
+		return nil
+	}
+	required, _, err := s.ballots.EncryptionMode(ctx, electionID)
 	if err != nil {
 		return err
 	}
-
-	// 6. Mark check-in as used
-	_ = s.withTx(ctx, func(tx pgx.Tx) error {
-		return s.voteRepo.MarkCheckinUsed(ctx, tx, checkin.ID, time.Now().UTC())
-	})
-
+	if required {
+		return ErrEncryptedBallotRequired
+	}
 	return nil
 }
 
@@ -229,27 +309,17 @@ func (s *Service) castVote(
 	channel string,
 	tpsID *int64,
 ) (*VoteResultEntity, error) {
+	if err := s.ensurePlaintextBallots(ctx, electionID); err != nil {
+		return nil, err
+	}
+
 	var result *VoteResultEntity
 
-	err := s.withTx(ctx, func(tx pgx.Tx) error {
-		// 1. Lock voter_status with FOR UPDATE
-		vs, err := s.voterRepo.GetStatusForUpdate(ctx, tx, electionID, voterID)
+	err := s.withBallotTx(ctx, func(tx pgx.Tx, queue queueFunc) error {
+		// 1-2. Lock voter_status and check eligibility
+		vs, err := s.lockVoterForCast(ctx, tx, electionID, voterID, channel)
 		if err != nil {
-			return translateNotFound(err, ErrNotEligible)
-		}
-
-		// 2. Check eligibility
-		if !vs.IsEligible {
-			return ErrNotEligible
-		}
-		if vs.HasVoted {
-			return ErrAlreadyVoted
-		}
-		if channel == "ONLINE" && !vs.OnlineAllowed {
-			return ErrMethodNotAllowed
-		}
-		if channel == "TPS" && !vs.TPSAllowed {
-			return ErrMethodNotAllowed
+			return err
 		}
 
 		// 3. Get and validate candidate
@@ -265,72 +335,38 @@ func (s *Service) castVote(
 		// 	return ErrCandidateInactive
 		// }
 
-		// 4. Generate token hash
+		// 4. Generate receipt. The voter side and the ballot get different
+		// hashes of it so the two cannot be joined.
 		now := time.Now().UTC()
-		tokenHash := generateTokenHash(electionID, voterID)
-
-		// 5. Insert vote token
-		token := &VoteToken{
-			ElectionID: electionID,
-			VoterID:    voterID,
-			TokenHash:  tokenHash,
-			IssuedAt:   now,
-			Method:     channel,
-			TPSID:      tpsID,
+		receipt, err := newBallotReceipt()
+		if err != nil {
+			return err
 		}
-		if err := s.voteRepo.InsertToken(ctx, tx, token); err != nil {
+
+		// 5-6. Insert vote token and mark the voter as voted
+		if err := s.recordVoterCast(ctx, tx, vs, channel, tpsID, receipt.VoterHash, now); err != nil {
 			return err
 		}
 
-		// 6. Insert vote
+		// 7. Queue ballot in its own transaction
 		vote := &Vote{
 			ElectionID:  electionID,
 			CandidateID: cand.ID,
-			TokenHash:   tokenHash,
+			TokenHash:   receipt.BallotHash,
 			Channel:     channel,
 			TPSID:       tpsID,
-			CastAt:      now,
+			CastAt:      ballotCastTime(now),
 		}
-		if err := s.voteRepo.InsertVote(ctx, tx, vote); err != nil {
-			return err
-		}
-
-		// 7. Update voter_status
-		vs.HasVoted = true
-		method := channel
-		vs.VotingMethod = &method
-		vs.TPSID = tpsID
-		vs.VotedAt = &now
-		vs.TokenHash = &tokenHash
-
-		if err := s.voterRepo.UpdateStatus(ctx, tx, vs); err != nil {
+		if err := queue(vote); err != nil {
 			return err
 		}
 
-		// 7.5. Sync election_voters.updated_at so voted voters appear at top of list
-		if _, err := tx.Exec(ctx, `
-			UPDATE election_voters 
-			SET updated_at = $1 
-			WHERE election_id = $2 AND voter_id = $3
-		`, now, electionID, voterID); err != nil {
-			// Non-critical, log but don't fail
-			fmt.Printf("[WARN] Failed to sync election_voters.updated_at: %v\n", err)
-		}
-
-		// 8. Update stats (optional)
-		if s.statsRepo != nil {
-			if err := s.statsRepo.IncrementCandidateCount(ctx, tx, electionID, cand.ID, channel, tpsID); err != nil {
-				return err
-			}
-		}
-
-		// 9. Audit log (async-safe, errors ignored)
+		// 8. Audit log (async-safe, errors ignored)
 		if s.auditSvc != nil {
 			_ = s.auditSvc.Log(ctx, AuditEntry{
 				ActorVoterID: &voterID,
 				Action:       "CAST_VOTE_" + channel,
 				EntityType:   "VOTE",
-				EntityID:     vote.ID,
 				Metadata: map[string]any{
 					"election_id": electionID,
 					"channel":     channel,
@@ -339,7 +375,7 @@ func (s *Service) castVote(
 			})
 		}
 
-		// 10. Build result
+		// 9. Build result
 		var tpsInfo *TPSInfo
 		if tpsID != nil && channel == "TPS" {
 			tpsEntry, err := s.voteRepo.GetTPSByID(ctx, tx, *tpsID)
@@ -359,8 +395,8 @@ func (s *Service) castVote(
 			VotedAt:    now,
 			TPS:        tpsInfo,
 			Receipt: ReceiptDetail{
-				TokenHash: tokenHash,
-				Note:      "",
+				TokenHash: receipt.Code,
+				Note:      receiptNote,
 			},
 		}
 
@@ -370,10 +406,202 @@ func (s *Service) castVote(
 	if err != nil {
 		return nil, err
 	}
+	telemetry.VoteCast(channel)
+
+	return result, nil
+}
+
+// CastEncryptedVote records a ballot the voter's client encrypted under the
+// election key. The server checks the ballot's proofs but never learns the
+// choice; the tally is decrypted only in aggregate by the trustees. With
+// TPSID set the voter casts at a TPS and needs an approved check-in.
+func (s *Service) CastEncryptedVote(ctx context.Context, authUser auth.AuthUser, req CastEncryptedVoteRequest) (*EncryptedVoteResult, error) {
+	if s.db == nil || s.electionRepo == nil {
+		return nil, errors.New("not implemented")
+	}
+	if s.ballots == nil {
+		return nil, ErrEncryptionNotEnabled
+	}
+	if authUser.VoterID == nil {
+		return nil, ErrVoterMappingMissing
+	}
+	if req.Ballot == nil {
+		return nil, ErrInvalidBallot
+	}
+	voterID := *authUser.VoterID
+
+	election, err := s.electionRepo.GetByID(ctx, req.ElectionID)
+	if err != nil {
+		return nil, translateNotFound(err, ErrElectionNotFound)
+	}
+	s.ensureElectionStatus(ctx, election)
+	if election.Status != "VOTING_OPEN" {
+		return nil, ErrElectionNotOpen
+	}
+
+	channel := "ONLINE"
+	var checkin *tps.TPSCheckin
+	if req.TPSID != nil {
+		if !election.TPSEnabled {
+			return nil, ErrMethodNotAllowed
+		}
+		channel = "TPS"
+		if checkin, err = s.approvedCheckin(ctx, req.ElectionID, voterID, *req.TPSID); err != nil {
+			return nil, err
+		}
+	} else if !election.OnlineEnabled {
+		return nil, ErrMethodNotAllowed
+	}
+
+	required, ready, err := s.ballots.EncryptionMode(ctx, req.ElectionID)
+	if err != nil {
+		return nil, err
+	}
+	if !required {
+		return nil, ErrEncryptionNotEnabled
+	}
+	if !ready {
+		return nil, ErrEncryptionKeyNotReady
+	}
+	if err := s.ballots.VerifyBallot(ctx, req.ElectionID, req.Ballot); err != nil {
+		if errors.Is(err, crypto.ErrInvalidProof) || errors.Is(err, crypto.ErrInvalidElement) || errors.Is(err, crypto.ErrBallotCandidates) {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidBallot, err)
+		}
+		return nil, err
+	}
+
+	var result *EncryptedVoteResult
+	err = s.withTx(ctx, func(tx pgx.Tx) error {
+		vs, err := s.lockVoterForCast(ctx, tx, req.ElectionID, voterID, channel)
+		if err != nil {
+			return err
+		}
+
+		// The tracker is public, so the voter side keeps an unrelated hash.
+		now := time.Now().UTC()
+		receipt, err := newBallotReceipt()
+		if err != nil {
+			return err
+		}
+
+		vote := &EncryptedVote{
+			ElectionID: req.ElectionID,
+			Tracker:    req.Ballot.Tracker(),
+			Ballot:     req.Ballot,
+			Channel:    channel,
+			TPSID:      req.TPSID,
+			CastAt:     ballotCastTime(now),
+		}
+		if err := s.voteRepo.InsertEncryptedBallot(ctx, tx, vote); err != nil {
+			return err
+		}
+
+		if err := s.recordVoterCast(ctx, tx, vs, channel, req.TPSID, receipt.VoterHash, now); err != nil {
+			return err
+		}
+
+		if s.auditSvc != nil {
+			_ = s.auditSvc.Log(ctx, AuditEntry{
+				ActorVoterID: &voterID,
+				Action:       "CAST_ENCRYPTED_VOTE_" + channel,
+				EntityType:   "VOTE",
+				Metadata: map[string]any{
+					"election_id": req.ElectionID,
+					"channel":     channel,
+					"tps_id":      req.TPSID,
+				},
+			})
+		}
+
+		result = &EncryptedVoteResult{
+			ElectionID: req.ElectionID,
+			Method:     channel,
+			VotedAt:    now,
+			Tracker:    vote.Tracker,
+			Note:       encryptedReceiptNote,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	telemetry.VoteCast(channel)
+
+	if checkin != nil {
+		if err := s.withTx(ctx, func(tx pgx.Tx) error {
+			return s.voteRepo.MarkCheckinUsed(ctx, tx, checkin.ID, time.Now().UTC())
+		}); err == nil {
+			telemetry.CheckinRecorded(tps.CheckinStatusUsed)
+		}
+	}
 
 	return result, nil
 }
 
+// lockVoterForCast locks the voter's status row and checks they may still
+// vote through channel.
+func (s *Service) lockVoterForCast(ctx context.Context, tx pgx.Tx, electionID, voterID int64, channel string) (*VoterStatusEntity, error) {
+	vs, err := s.voterRepo.GetStatusForUpdate(ctx, tx, electionID, voterID)
+	if err != nil {
+		return nil, translateNotFound(err, ErrNotEligible)
+	}
+
+	if !vs.IsEligible {
+		return nil, ErrNotEligible
+	}
+	if vs.EmailUnverified {
+		return nil, ErrEmailNotVerified
+	}
+	if vs.HasVoted {
+		return nil, ErrAlreadyVoted
+	}
+	if channel == "ONLINE" && !vs.OnlineAllowed {
+		return nil, ErrMethodNotAllowed
+	}
+	if channel == "TPS" && !vs.TPSAllowed {
+		return nil, ErrMethodNotAllowed
+	}
+	return vs, nil
+}
+
+// recordVoterCast stores the voter-side receipt hash and marks the voter as
+// voted. Nothing written here references the ballot.
+func (s *Service) recordVoterCast(ctx context.Context, tx pgx.Tx, vs *VoterStatusEntity, channel string, tpsID *int64, voterHash string, now time.Time) error {
+	token := &VoteToken{
+		ElectionID: vs.ElectionID,
+		VoterID:    vs.VoterID,
+		TokenHash:  voterHash,
+		IssuedAt:   now,
+		Method:     channel,
+		TPSID:      tpsID,
+	}
+	if err := s.voteRepo.InsertToken(ctx, tx, token); err != nil {
+		return err
+	}
+
+	vs.HasVoted = true
+	method := channel
+	vs.VotingMethod = &method
+	vs.TPSID = tpsID
+	vs.VotedAt = &now
+	vs.TokenHash = &voterHash
+
+	if err := s.voterRepo.UpdateStatus(ctx, tx, vs); err != nil {
+		return err
+	}
+
+	// Sync election_voters.updated_at so voted voters appear at top of list
+	if _, err := tx.Exec(ctx, `
+		UPDATE election_voters 
+		SET updated_at = $1 
+		WHERE election_id = $2 AND voter_id = $3
+	`, now, vs.ElectionID, vs.VoterID); err != nil {
+		// Non-critical, log but don't fail
+		fmt.Printf("[WARN] Failed to sync election_voters.updated_at: %v\n", err)
+	}
+	return nil
+}
+
 // GetTPSVotingStatus checks if voter is eligible for TPS voting
 func (s *Service) GetTPSVotingStatus(ctx context.Context, voterID int64) (*TPSVotingStatus, error) {
 	// TODO: Implement
@@ -395,10 +623,40 @@ func (s *Service) GetVotingReceipt(ctx context.Context, voterID int64) (*Receipt
 	}, nil
 }
 
+// VerifyReceipt reports whether the ballot cast with a receipt code is
+// recorded. It never reveals the choice, so a receipt cannot prove to anyone
+// how its holder voted.
+func (s *Service) VerifyReceipt(ctx context.Context, electionID int64, code string) (*ReceiptVerification, error) {
+	if s.db == nil {
+		return nil, errors.New("service not initialized")
+	}
+
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return nil, ErrInvalidReceipt
+	}
+	hash := BallotReceiptHash(code)
+
+	result := &ReceiptVerification{ElectionID: electionID}
+	err := s.db.QueryRow(ctx, `
+		SELECT
+		    EXISTS(SELECT 1 FROM votes WHERE election_id = $1 AND token_hash = $2),
+		    EXISTS(SELECT 1 FROM ballot_queue WHERE election_id = $1 AND token_hash = $2)
+	`, electionID, hash).Scan(&result.Counted, &result.Queued)
+	if err != nil {
+		return nil, err
+	}
+	result.Recorded = result.Counted || result.Queued
+	return result, nil
+}
+
 func (s *Service) GetLiveCount(ctx context.Context, electionID int64) (map[int64]int64, error) {
 	if s.repo == nil {
 		return nil, errors.New("repository not initialized")
 	}
+	if s.embargo != nil && s.embargo.Sealed(ctx, electionID) {
+		return nil, embargo.ErrResultsEmbargoed
+	}
 	return s.repo.GetVoteCount(ctx, electionID)
 }
 
@@ -707,11 +965,14 @@ func (s *Service) CastVoteFromBallotQR(ctx context.Context, authUser auth.AuthUs
 	if req.ElectionID != nil {
 		electionID = *req.ElectionID
 	}
+	if err := s.ensurePlaintextBallots(ctx, electionID); err != nil {
+		return nil, err
+	}
 
 	voterID := *authUser.VoterID
 	var result *CastFromBallotQRResponse
 
-	err = s.withTx(ctx, func(tx pgx.Tx) error {
+	err = s.withBallotTx(ctx, func(tx pgx.Tx, queue queueFunc) error {
 		electionRow, err := s.electionRepo.GetByID(ctx, electionID)
 		if err != nil {
 			return translateNotFound(err, ErrElectionNotFound)
@@ -733,6 +994,9 @@ func (s *Service) CastVoteFromBallotQR(ctx context.Context, authUser auth.AuthUs
 		if !status.IsEligible {
 			return ErrNotEligible
 		}
+		if status.EmailUnverified {
+			return ErrEmailNotVerified
+		}
 		if status.HasVoted {
 			return ErrAlreadyVoted
 		}
@@ -756,6 +1020,9 @@ func (s *Service) CastVoteFromBallotQR(ctx context.Context, authUser auth.AuthUs
 		if checkin.ExpiresAt != nil && checkin.ExpiresAt.Before(time.Now().UTC()) {
 			return ErrCheckinExpired
 		}
+		if err := s.ensureTPSOpen(ctx, checkin.TPSID); err != nil {
+			return err
+		}
 
 		// QR validation against active candidate QR code
 		qrRecord, err := s.voteRepo.FindActiveCandidateQRWithVersion(ctx, tx, electionID, qr.CandidateID, qr.Version)
@@ -776,17 +1043,19 @@ func (s *Service) CastVoteFromBallotQR(ctx context.Context, authUser auth.AuthUs
 		}
 
 		now := time.Now().UTC()
-		tokenHash := generateTokenHash(electionID, voterID)
+		receipt, err := newBallotReceipt()
+		if err != nil {
+			return err
+		}
 		candidateNumber := fmt.Sprintf("%02d", cand.Number)
 
+		// The scan log names the voter, so an applied scan keeps no trace of
+		// the choice.
 		scan := &BallotScan{
 			ElectionID:      electionID,
 			TPSID:           checkin.TPSID,
 			CheckinID:       checkin.ID,
 			VoterID:         voterID,
-			CandidateID:     &qr.CandidateID,
-			CandidateQRID:   &qrRecord.ID,
-			RawPayload:      payload,
 			PayloadValid:    true,
 			Status:          "APPLIED",
 			RejectedReason:  nil,
@@ -800,31 +1069,25 @@ func (s *Service) CastVoteFromBallotQR(ctx context.Context, authUser auth.AuthUs
 		vote := &Vote{
 			ElectionID:    electionID,
 			CandidateID:   qr.CandidateID,
-			TokenHash:     tokenHash,
+			TokenHash:     receipt.BallotHash,
 			Channel:       "TPS",
 			TPSID:         &checkin.TPSID,
 			CandidateQRID: &qrRecord.ID,
-			BallotScanID:  &scan.ID,
-			CastAt:        now,
+			CastAt:        ballotCastTime(now),
 		}
-		if err := s.voteRepo.InsertVote(ctx, tx, vote); err != nil {
-			var pgErr *pgconn.PgError
-			if errors.As(err, &pgErr) && pgErr.Code == "23505" {
-				return ErrDuplicateVoteAttempt
-			}
+		if err := s.recordVoterCast(ctx, tx, status, "TPS", &checkin.TPSID, receipt.VoterHash, now); err != nil {
 			return err
 		}
 
-		status.HasVoted = true
-		status.VotingMethod = stringPtr("TPS")
-		status.TPSID = &checkin.TPSID
-		status.VotedAt = &now
-		status.TokenHash = &tokenHash
-		if err := s.voterRepo.UpdateStatus(ctx, tx, status); err != nil {
+		if err := s.voteRepo.MarkCheckinUsed(ctx, tx, checkin.ID, now); err != nil {
 			return err
 		}
 
-		if err := s.voteRepo.MarkCheckinUsed(ctx, tx, checkin.ID, now); err != nil {
+		if err := queue(vote); err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+				return ErrDuplicateVoteAttempt
+			}
 			return err
 		}
 
@@ -845,12 +1108,21 @@ func (s *Service) CastVoteFromBallotQR(ctx context.Context, authUser auth.AuthUs
 				Number: candidateNumber,
 				Name:   cand.Name,
 			},
+			Receipt: &ReceiptDetail{
+				TokenHash: receipt.Code,
+				Note:      receiptNote,
+			},
 		}
 
 		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
+	telemetry.VoteCast("TPS")
+	telemetry.CheckinRecorded(tps.CheckinStatusUsed)
 
-	return result, err
+	return result, nil
 }
 
 // ScanCandidateAtTPS handles QR ballot scan after check-in approval.
@@ -869,7 +1141,7 @@ func (s *Service) ScanCandidateAtTPS(ctx context.Context, authUser auth.AuthUser
 
 	var result *VoteResultEntity
 	var err error
-	err = s.withTx(ctx, func(tx pgx.Tx) error {
+	err = s.withBallotTx(ctx, func(tx pgx.Tx, queue queueFunc) error {
 		var (
 			qr       *BallotQR
 			qrRecord *CandidateQR
@@ -896,6 +1168,10 @@ func (s *Service) ScanCandidateAtTPS(ctx context.Context, authUser auth.AuthUser
 			}
 		}
 
+		if err := s.ensurePlaintextBallots(ctx, qr.ElectionID); err != nil {
+			return err
+		}
+
 		checkin, err := s.voteRepo.GetCheckinByID(ctx, tx, req.CheckinID)
 		if err != nil {
 			return translateNotFound(err, ErrTPSCheckinNotFound)
@@ -909,6 +1185,9 @@ func (s *Service) ScanCandidateAtTPS(ctx context.Context, authUser auth.AuthUser
 		if checkin.ExpiresAt != nil && checkin.ExpiresAt.Before(time.Now().UTC()) {
 			return ErrCheckinExpired
 		}
+		if err := s.ensureTPSOpen(ctx, checkin.TPSID); err != nil {
+			return err
+		}
 
 		// Election match
 		if checkin.ElectionID != qr.ElectionID {
@@ -929,6 +1208,9 @@ func (s *Service) ScanCandidateAtTPS(ctx context.Context, authUser auth.AuthUser
 		if err != nil {
 			return translateNotFound(err, ErrNotEligible)
 		}
+		if status.EmailUnverified {
+			return ErrEmailNotVerified
+		}
 		if status.HasVoted {
 			return ErrAlreadyVoted
 		}
@@ -943,7 +1225,10 @@ func (s *Service) ScanCandidateAtTPS(ctx context.Context, authUser auth.AuthUser
 		}
 
 		now := time.Now().UTC()
-		tokenHash := generateTokenHash(qr.ElectionID, checkin.VoterID)
+		receipt, err := newBallotReceipt()
+		if err != nil {
+			return err
+		}
 
 		// Insert ballot scan log with status APPLIED
 		scanStatus := "APPLIED"
@@ -958,9 +1243,6 @@ func (s *Service) ScanCandidateAtTPS(ctx context.Context, authUser auth.AuthUser
 			TPSID:           req.TPSID,
 			CheckinID:       checkin.ID,
 			VoterID:         checkin.VoterID,
-			CandidateID:     &qr.CandidateID,
-			CandidateQRID:   candidateQRID,
-			RawPayload:      req.Payload,
 			PayloadValid:    payloadValid,
 			Status:          scanStatus,
 			RejectedReason:  rejectedReason,
@@ -976,29 +1258,24 @@ func (s *Service) ScanCandidateAtTPS(ctx context.Context, authUser auth.AuthUser
 		vote := &Vote{
 			ElectionID:    qr.ElectionID,
 			CandidateID:   qr.CandidateID,
-			TokenHash:     tokenHash,
+			TokenHash:     receipt.BallotHash,
 			Channel:       "TPS",
 			TPSID:         &req.TPSID,
 			CandidateQRID: candidateQRID,
-			BallotScanID:  &scan.ID,
-			CastAt:        now,
+			CastAt:        ballotCastTime(now),
 		}
-		if err := s.voteRepo.InsertVote(ctx, tx, vote); err != nil {
+		// Insert vote token and update voter_status
+		if err := s.recordVoterCast(ctx, tx, status, "TPS", &req.TPSID, receipt.VoterHash, now); err != nil {
 			return err
 		}
 
-		// Update voter_status
-		status.HasVoted = true
-		status.VotingMethod = stringPtr("TPS")
-		status.TPSID = &req.TPSID
-		status.VotedAt = &now
-		status.TokenHash = &tokenHash
-		if err := s.voterRepo.UpdateStatus(ctx, tx, status); err != nil {
+		// Update checkin status to USED
+		if err := s.voteRepo.MarkCheckinUsed(ctx, tx, checkin.ID, now); err != nil {
 			return err
 		}
 
-		// Update checkin status to USED
-		if err := s.voteRepo.MarkCheckinUsed(ctx, tx, checkin.ID, now); err != nil {
+		// Queue ballot in its own transaction
+		if err := queue(vote); err != nil {
 			return err
 		}
 
@@ -1008,15 +1285,20 @@ func (s *Service) ScanCandidateAtTPS(ctx context.Context, authUser auth.AuthUser
 			Method:     "TPS",
 			VotedAt:    now,
 			TPS:        &TPSInfo{ID: checkin.TPSID},
+			// The receipt code stays off the operator's screen.
 			Receipt: ReceiptDetail{
-				TokenHash: tokenHash,
-				Note:      "Vote dicatat melalui scan QR surat suara.",
+				Note: "Vote dicatat melalui scan QR surat suara.",
 			},
 		}
 		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
+	telemetry.VoteCast("TPS")
+	telemetry.CheckinRecorded(tps.CheckinStatusUsed)
 
-	return result, err
+	return result, nil
 }
 
 func (s *Service) SubmitDigitalSignature(ctx context.Context, authUser auth.AuthUser, req SubmitSignatureRequest) error {
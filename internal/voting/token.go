@@ -4,33 +4,62 @@ import (
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/hex"
-	"fmt"
+	"time"
 )
 
-// generateTokenHash generates a secure random token hash
-func generateTokenHash(electionID, voterID int64) string {
-	randomBytes := make([]byte, 32)
-	if _, err := rand.Read(randomBytes); err != nil {
-		// Fallback to timestamp-based if random fails
-		return fmt.Sprintf("vt_%d_%d_%d", electionID, voterID, generateFallbackRandom())
-	}
-	
-	// Combine random bytes with election and voter IDs for uniqueness
-	data := append(randomBytes, []byte(fmt.Sprintf("%d:%d", electionID, voterID))...)
-	hash := sha256.Sum256(data)
-	
-	return "vt_" + hex.EncodeToString(hash[:12])
+const (
+	// ballotTimeBucket is the resolution of the cast time stored on a ballot.
+	ballotTimeBucket = time.Hour
+
+	// ballotBatchSize is how many queued ballots are released into votes at
+	// once, in random order. It is the smallest group a ballot hides in while
+	// voting is open.
+	ballotBatchSize = 10
+
+	receiptNote = "Simpan kode ini untuk memeriksa bahwa suara Anda tercatat. Kode tidak dapat ditampilkan ulang."
+
+	encryptedReceiptNote = "Simpan tracker ini dan cocokkan dengan bulletin board pemilu untuk memastikan surat suara terenkripsi Anda ikut dihitung."
+)
+
+// ballotReceipt is the secret handed to the voter after casting. The voter's
+// records store VoterHash and the ballot stores BallotHash; both are derived
+// from Code, which the server does not keep, so neither can be computed from
+// the other.
+type ballotReceipt struct {
+	Code       string
+	VoterHash  string
+	BallotHash string
 }
 
-func generateFallbackRandom() int64 {
-	b := make([]byte, 8)
-	rand.Read(b)
-	var n int64
-	for i := 0; i < 8; i++ {
-		n = n<<8 | int64(b[i])
+func newBallotReceipt() (ballotReceipt, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return ballotReceipt{}, err
 	}
-	if n < 0 {
-		n = -n
-	}
-	return n
+	code := "rc_" + hex.EncodeToString(b)
+	return ballotReceipt{
+		Code:       code,
+		VoterHash:  voterReceiptHash(code),
+		BallotHash: BallotReceiptHash(code),
+	}, nil
+}
+
+func voterReceiptHash(code string) string {
+	return "vt_" + receiptDigest("voter", code)
+}
+
+// BallotReceiptHash returns the token_hash of the ballot cast with a receipt
+// code, letting a voter check that their ballot is in the tally.
+func BallotReceiptHash(code string) string {
+	return "bl_" + receiptDigest("ballot", code)
+}
+
+func receiptDigest(domain, code string) string {
+	sum := sha256.Sum256([]byte("pemira:" + domain + ":" + code))
+	return hex.EncodeToString(sum[:16])
+}
+
+// ballotCastTime coarsens the time stored on a ballot.
+func ballotCastTime(t time.Time) time.Time {
+	return t.UTC().Truncate(ballotTimeBucket)
 }
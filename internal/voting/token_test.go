@@ -0,0 +1,33 @@
+package voting
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewBallotReceipt(t *testing.T) {
+	a, err := newBallotReceipt()
+	if err != nil {
+		t.Fatalf("receipt: %v", err)
+	}
+	b, _ := newBallotReceipt()
+
+	if a.Code == b.Code || a.BallotHash == b.BallotHash {
+		t.Fatal("receipts must be random")
+	}
+	if a.VoterHash == a.BallotHash {
+		t.Fatal("voter and ballot hashes must differ")
+	}
+	if BallotReceiptHash(a.Code) != a.BallotHash || voterReceiptHash(a.Code) != a.VoterHash {
+		t.Fatal("hashes must be reproducible from the code")
+	}
+}
+
+func TestBallotCastTime(t *testing.T) {
+	at := time.Date(2025, 2, 15, 9, 41, 17, 0, time.FixedZone("WIB", 7*3600))
+	got := ballotCastTime(at)
+	want := time.Date(2025, 2, 15, 2, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Fatalf("ballotCastTime = %v, want %v", got, want)
+	}
+}
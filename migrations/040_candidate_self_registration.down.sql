@@ -0,0 +1,16 @@
+-- +goose Down
+
+DROP TABLE IF EXISTS candidate_notifications;
+DROP TABLE IF EXISTS candidate_reviews;
+DROP TABLE IF EXISTS candidate_applications;
+
+ALTER TABLE candidate_media DROP CONSTRAINT IF EXISTS candidate_media_slot_check;
+ALTER TABLE candidate_media ADD CONSTRAINT candidate_media_slot_check CHECK (slot IN (
+    'profile', 'poster', 'photo_extra', 'pdf_program', 'pdf_visimisi'
+));
+
+UPDATE candidates SET status = 'PENDING' WHERE status = 'NEEDS_REVISION';
+DELETE FROM candidates WHERE number IS NULL;
+ALTER TABLE candidates ALTER COLUMN number SET NOT NULL;
+
+-- Enum values cannot be dropped; NEEDS_REVISION stays in candidate_status.
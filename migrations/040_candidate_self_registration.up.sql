@@ -0,0 +1,57 @@
+-- +goose Up
+-- Candidate self-registration: applications submitted by students, committee
+-- reviews with per-requirement checklists, and applicant notifications.
+
+ALTER TYPE candidate_status ADD VALUE IF NOT EXISTS 'NEEDS_REVISION';
+
+-- Submitted applications do not have a ballot number until they are approved.
+ALTER TABLE candidates ALTER COLUMN number DROP NOT NULL;
+
+ALTER TABLE candidate_media DROP CONSTRAINT IF EXISTS candidate_media_slot_check;
+ALTER TABLE candidate_media ADD CONSTRAINT candidate_media_slot_check CHECK (slot IN (
+    'profile', 'poster', 'photo_extra', 'pdf_program', 'pdf_visimisi',
+    'doc_ktm', 'doc_transcript', 'doc_statement', 'doc_recommendation'
+));
+
+CREATE TABLE IF NOT EXISTS candidate_applications (
+    candidate_id       BIGINT PRIMARY KEY REFERENCES candidates(id) ON DELETE CASCADE,
+    election_id        BIGINT NOT NULL REFERENCES elections(id) ON DELETE CASCADE,
+    applicant_user_id  BIGINT NOT NULL REFERENCES user_accounts(id) ON DELETE CASCADE,
+    submitted_at       TIMESTAMPTZ NULL,
+    decided_at         TIMESTAMPTZ NULL,
+    created_at         TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at         TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT ux_candidate_applications_applicant UNIQUE (election_id, applicant_user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_candidate_applications_election ON candidate_applications (election_id);
+
+CREATE TABLE IF NOT EXISTS candidate_reviews (
+    id           BIGSERIAL PRIMARY KEY,
+    candidate_id BIGINT NOT NULL REFERENCES candidates(id) ON DELETE CASCADE,
+    election_id  BIGINT NOT NULL REFERENCES elections(id) ON DELETE CASCADE,
+    reviewer_id  BIGINT NULL REFERENCES user_accounts(id) ON DELETE SET NULL,
+    decision     TEXT NOT NULL CHECK (decision IN ('APPROVE', 'REQUEST_REVISION', 'REJECT')),
+    checklist    JSONB NOT NULL DEFAULT '[]'::jsonb,
+    comment      TEXT NOT NULL DEFAULT '',
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_candidate_reviews_candidate ON candidate_reviews (candidate_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS candidate_notifications (
+    id           BIGSERIAL PRIMARY KEY,
+    candidate_id BIGINT NOT NULL REFERENCES candidates(id) ON DELETE CASCADE,
+    user_id      BIGINT NOT NULL REFERENCES user_accounts(id) ON DELETE CASCADE,
+    type         TEXT NOT NULL,
+    title        TEXT NOT NULL,
+    message      TEXT NOT NULL DEFAULT '',
+    read_at      TIMESTAMPTZ NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_candidate_notifications_user ON candidate_notifications (user_id, created_at DESC);
+
+COMMENT ON TABLE candidate_applications IS 'Candidacy submitted by a student through self-registration';
+COMMENT ON TABLE candidate_reviews IS 'Committee verification decisions with per-requirement checklist';
+COMMENT ON TABLE candidate_notifications IS 'Status notifications delivered to candidacy applicants';
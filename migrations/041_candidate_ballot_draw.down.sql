@@ -0,0 +1,3 @@
+-- +goose Down
+DROP TABLE IF EXISTS candidate_ballot_draw_seeds;
+DROP TABLE IF EXISTS candidate_ballot_draws;
@@ -0,0 +1,30 @@
+-- +goose Up
+-- Public ballot number draw (pengundian nomor urut) using commit-reveal.
+-- The server seed hash is published at commit time; the seed itself is only
+-- exposed after reveal together with the full transcript.
+
+CREATE TABLE IF NOT EXISTS candidate_ballot_draws (
+    id                BIGSERIAL PRIMARY KEY,
+    election_id       BIGINT NOT NULL UNIQUE REFERENCES elections(id) ON DELETE CASCADE,
+    status            TEXT NOT NULL DEFAULT 'COMMITTED' CHECK (status IN ('COMMITTED', 'REVEALED')),
+    server_seed_hash  TEXT NOT NULL,
+    server_seed       TEXT NOT NULL,
+    combined_seed     TEXT NULL,
+    transcript        JSONB NULL,
+    committed_by      BIGINT NULL REFERENCES user_accounts(id) ON DELETE SET NULL,
+    committed_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    revealed_by       BIGINT NULL REFERENCES user_accounts(id) ON DELETE SET NULL,
+    revealed_at       TIMESTAMPTZ NULL
+);
+
+CREATE TABLE IF NOT EXISTS candidate_ballot_draw_seeds (
+    election_id   BIGINT NOT NULL REFERENCES elections(id) ON DELETE CASCADE,
+    candidate_id  BIGINT NOT NULL REFERENCES candidates(id) ON DELETE CASCADE,
+    seed          TEXT NOT NULL,
+    submitted_by  BIGINT NULL REFERENCES user_accounts(id) ON DELETE SET NULL,
+    submitted_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (election_id, candidate_id)
+);
+
+COMMENT ON TABLE candidate_ballot_draws IS 'Commit-reveal ballot number draw per election; numbers are locked once revealed';
+COMMENT ON TABLE candidate_ballot_draw_seeds IS 'Seeds contributed by each candidate pair to the ballot number draw';
@@ -0,0 +1,18 @@
+-- +goose Up
+-- Reusable election templates: phase schedule (as offsets), mode settings and
+-- TPS definitions captured from an existing election.
+
+CREATE TABLE IF NOT EXISTS election_templates (
+    id                  BIGSERIAL PRIMARY KEY,
+    name                TEXT NOT NULL,
+    description         TEXT NULL,
+    config              JSONB NOT NULL DEFAULT '{}'::jsonb,
+    source_election_id  BIGINT NULL REFERENCES elections(id) ON DELETE SET NULL,
+    created_by          BIGINT NULL REFERENCES user_accounts(id) ON DELETE SET NULL,
+    created_at          TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at          TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_election_templates_name ON election_templates (LOWER(name));
+
+COMMENT ON TABLE election_templates IS 'Saved election blueprints used to create new elections';
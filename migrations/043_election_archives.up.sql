@@ -0,0 +1,22 @@
+-- +goose Up
+-- Elections restored from an export bundle. Data that cannot live in the
+-- operational tables of another instance (vote tokens without their voters,
+-- the audit trail and the ballot draw transcript) is kept here as JSON.
+
+CREATE TABLE IF NOT EXISTS election_archives (
+    id                  BIGSERIAL PRIMARY KEY,
+    election_id         BIGINT NOT NULL UNIQUE REFERENCES elections(id) ON DELETE CASCADE,
+    format_version      INTEGER NOT NULL,
+    source_election_id  BIGINT NOT NULL,
+    bundle_sha256       TEXT NOT NULL,
+    manifest            JSONB NOT NULL,
+    vote_tokens         JSONB NOT NULL DEFAULT '[]'::jsonb,
+    audit_log           JSONB NOT NULL DEFAULT '[]'::jsonb,
+    ballot_draw         JSONB NULL,
+    imported_by         BIGINT NULL REFERENCES user_accounts(id) ON DELETE SET NULL,
+    imported_at         TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_election_archives_bundle ON election_archives (bundle_sha256);
+
+COMMENT ON TABLE election_archives IS 'Elections imported from a pemira election bundle, with archive-only records';
@@ -0,0 +1,22 @@
+-- +goose Up
+-- Role grants on top of user_accounts.role. A grant may be global or scoped
+-- to one election or one TPS; the role's permission set only applies inside
+-- that scope.
+
+CREATE TABLE IF NOT EXISTS role_assignments (
+    id          BIGSERIAL PRIMARY KEY,
+    user_id     BIGINT NOT NULL REFERENCES user_accounts(id) ON DELETE CASCADE,
+    role        user_role NOT NULL,
+    election_id BIGINT NULL REFERENCES elections(id) ON DELETE CASCADE,
+    tps_id      BIGINT NULL REFERENCES tps(id) ON DELETE CASCADE,
+    granted_by  BIGINT NULL REFERENCES user_accounts(id) ON DELETE SET NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_role_assignments_scope
+    ON role_assignments (user_id, role, COALESCE(election_id, 0), COALESCE(tps_id, 0));
+
+CREATE INDEX IF NOT EXISTS idx_role_assignments_user ON role_assignments (user_id);
+
+COMMENT ON TABLE role_assignments IS 'Role grants per user, optionally scoped to an election or TPS';
+
@@ -0,0 +1,9 @@
+-- +goose Down
+-- Rewritten ballots and redacted scans cannot be restored. Queued ballots are
+-- released first so no vote is lost.
+SELECT flush_ballot_queue(election_id, 0) FROM (SELECT DISTINCT election_id FROM ballot_queue) q;
+
+DROP TRIGGER IF EXISTS flush_ballot_queue_on_close ON elections;
+DROP FUNCTION IF EXISTS flush_ballot_queue_on_close();
+DROP FUNCTION IF EXISTS flush_ballot_queue(BIGINT, INTEGER);
+DROP TABLE IF EXISTS ballot_queue;
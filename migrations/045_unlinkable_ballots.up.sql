@@ -0,0 +1,100 @@
+-- +goose Up
+-- Secret ballot storage.
+--
+-- A ballot no longer shares anything with the voter's records: its receipt
+-- hash is derived from a code only the voter holds, cast_at is truncated to
+-- the hour, and ballots are queued inside the voter's transaction and moved
+-- into votes later in shuffled batches, so a votes row never carries the
+-- voter's transaction id, insertion order or exact timestamp. vote_stats is
+-- only counted when a batch is released, never in the voter's transaction.
+
+CREATE TABLE IF NOT EXISTS ballot_queue (
+    id              BIGSERIAL PRIMARY KEY,
+    election_id     BIGINT NOT NULL REFERENCES elections(id) ON DELETE CASCADE,
+    candidate_id    BIGINT NOT NULL REFERENCES candidates(id) ON DELETE CASCADE,
+    token_hash      TEXT NOT NULL,
+    channel         vote_channel NOT NULL,
+    tps_id          BIGINT NULL REFERENCES tps(id) ON DELETE SET NULL,
+    candidate_qr_id BIGINT NULL REFERENCES candidate_qr_codes(id) ON DELETE SET NULL,
+    cast_at         TIMESTAMPTZ NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_ballot_queue_token_hash ON ballot_queue (token_hash);
+CREATE INDEX IF NOT EXISTS idx_ballot_queue_election ON ballot_queue (election_id);
+
+COMMENT ON TABLE ballot_queue IS 'Ballots waiting to be released into votes in a shuffled batch';
+
+-- Moves every queued ballot of an election into votes in random order once at
+-- least p_min_batch are waiting, adding the batch to vote_stats. Returns the
+-- number of ballots moved.
+CREATE OR REPLACE FUNCTION flush_ballot_queue(p_election_id BIGINT, p_min_batch INTEGER)
+RETURNS INTEGER AS $$
+DECLARE
+    moved INTEGER;
+BEGIN
+    IF (SELECT COUNT(*) FROM ballot_queue WHERE election_id = p_election_id) < GREATEST(p_min_batch, 1) THEN
+        RETURN 0;
+    END IF;
+
+    WITH taken AS (
+        DELETE FROM ballot_queue
+        WHERE election_id = p_election_id
+        RETURNING election_id, candidate_id, token_hash, channel, tps_id, candidate_qr_id, cast_at
+    ), counted AS (
+        INSERT INTO vote_stats (election_id, candidate_id, total_votes, updated_at)
+        SELECT election_id, candidate_id, COUNT(*), NOW()
+        FROM taken
+        GROUP BY election_id, candidate_id
+        ON CONFLICT (election_id, candidate_id)
+        DO UPDATE SET
+            total_votes = vote_stats.total_votes + EXCLUDED.total_votes,
+            updated_at = EXCLUDED.updated_at
+    )
+    INSERT INTO votes (election_id, candidate_id, token_hash, channel, tps_id, candidate_qr_id, cast_at)
+    SELECT election_id, candidate_id, token_hash, channel, tps_id, candidate_qr_id, cast_at
+    FROM taken
+    ORDER BY random();
+
+    GET DIAGNOSTICS moved = ROW_COUNT;
+    RETURN moved;
+END;
+$$ language 'plpgsql';
+
+-- Whatever is still queued is released when voting closes, however it closes.
+CREATE OR REPLACE FUNCTION flush_ballot_queue_on_close()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF OLD.status = 'VOTING_OPEN' AND NEW.status <> 'VOTING_OPEN' THEN
+        PERFORM flush_ballot_queue(NEW.id, 0);
+    END IF;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+DROP TRIGGER IF EXISTS flush_ballot_queue_on_close ON elections;
+CREATE TRIGGER flush_ballot_queue_on_close
+    AFTER UPDATE OF status ON elections
+    FOR EACH ROW
+    EXECUTE FUNCTION flush_ballot_queue_on_close();
+
+-- Existing ballots: new receipt hashes unrelated to the voter-side token,
+-- coarse cast times, no pointer to the TPS scan row, rewritten in random order.
+CREATE TEMP TABLE votes_shuffle ON COMMIT DROP AS
+SELECT election_id, candidate_id, channel, tps_id, candidate_qr_id,
+       date_trunc('hour', cast_at) AS cast_at
+FROM votes;
+
+DELETE FROM votes;
+
+INSERT INTO votes (election_id, candidate_id, token_hash, channel, tps_id, candidate_qr_id, cast_at)
+SELECT election_id, candidate_id, 'bl_' || replace(gen_random_uuid()::text, '-', ''),
+       channel, tps_id, candidate_qr_id, cast_at
+FROM votes_shuffle
+ORDER BY random();
+
+-- Applied TPS scans keep who scanned when, but not what was on the ballot.
+UPDATE tps_ballot_scans
+SET candidate_id = NULL,
+    candidate_qr_id = NULL,
+    raw_payload = ''
+WHERE status = 'APPLIED';
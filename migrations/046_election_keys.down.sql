@@ -0,0 +1,6 @@
+-- +goose Down
+DROP TABLE IF EXISTS trustee_partial_decryptions;
+DROP TABLE IF EXISTS encrypted_tallies;
+DROP TABLE IF EXISTS encrypted_ballots;
+DROP TABLE IF EXISTS election_trustees;
+DROP TABLE IF EXISTS election_key_ceremonies;
@@ -0,0 +1,69 @@
+-- +goose Up
+-- End-to-end verifiable elections: a threshold ElGamal key generated by
+-- committee trustees, client-encrypted ballots and a homomorphic tally that
+-- only k of the N trustees together can decrypt. All crypto values are hex.
+
+CREATE TABLE IF NOT EXISTS election_key_ceremonies (
+    election_id   BIGINT PRIMARY KEY REFERENCES elections(id) ON DELETE CASCADE,
+    threshold     INTEGER NOT NULL CHECK (threshold >= 1),
+    trustee_count INTEGER NOT NULL CHECK (trustee_count >= threshold),
+    status        TEXT NOT NULL DEFAULT 'TRANSPORT_KEYS'
+                  CHECK (status IN ('TRANSPORT_KEYS', 'DEALING', 'CONFIRMING', 'READY', 'FAILED')),
+    failure       TEXT NULL,
+    public_key    TEXT NULL,
+    candidate_ids BIGINT[] NOT NULL DEFAULT '{}',
+    created_by    BIGINT NULL REFERENCES user_accounts(id) ON DELETE SET NULL,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at  TIMESTAMPTZ NULL
+);
+
+CREATE TABLE IF NOT EXISTS election_trustees (
+    election_id      BIGINT NOT NULL REFERENCES election_key_ceremonies(election_id) ON DELETE CASCADE,
+    trustee_index    INTEGER NOT NULL CHECK (trustee_index >= 1),
+    user_id          BIGINT NOT NULL REFERENCES user_accounts(id) ON DELETE RESTRICT,
+    name             TEXT NOT NULL,
+    transport_key    TEXT NULL,
+    transport_proof  JSONB NULL,
+    dealing          JSONB NULL,
+    confirmed_at     TIMESTAMPTZ NULL,
+    verification_key TEXT NULL,
+    PRIMARY KEY (election_id, trustee_index),
+    UNIQUE (election_id, user_id)
+);
+
+-- Ballots carry no voter reference; tracker is the ballot's public hash.
+CREATE TABLE IF NOT EXISTS encrypted_ballots (
+    id          BIGSERIAL PRIMARY KEY,
+    election_id BIGINT NOT NULL REFERENCES elections(id) ON DELETE CASCADE,
+    tracker     TEXT NOT NULL UNIQUE,
+    ballot      JSONB NOT NULL,
+    channel     vote_channel NOT NULL,
+    tps_id      BIGINT NULL REFERENCES tps(id) ON DELETE SET NULL,
+    cast_at     TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_encrypted_ballots_election ON encrypted_ballots (election_id, id);
+
+CREATE TABLE IF NOT EXISTS encrypted_tallies (
+    election_id  BIGINT PRIMARY KEY REFERENCES election_key_ceremonies(election_id) ON DELETE CASCADE,
+    ballot_count INTEGER NOT NULL,
+    tally        JSONB NOT NULL,
+    status       TEXT NOT NULL DEFAULT 'DECRYPTING' CHECK (status IN ('DECRYPTING', 'PUBLISHED')),
+    result       JSONB NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    published_at TIMESTAMPTZ NULL
+);
+
+CREATE TABLE IF NOT EXISTS trustee_partial_decryptions (
+    election_id   BIGINT NOT NULL REFERENCES encrypted_tallies(election_id) ON DELETE CASCADE,
+    trustee_index INTEGER NOT NULL,
+    partials      JSONB NOT NULL,
+    submitted_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (election_id, trustee_index),
+    FOREIGN KEY (election_id, trustee_index) REFERENCES election_trustees(election_id, trustee_index) ON DELETE CASCADE
+);
+
+COMMENT ON TABLE election_key_ceremonies IS 'Threshold ElGamal key ceremony per election';
+COMMENT ON TABLE encrypted_ballots IS 'Client-encrypted ballots (public bulletin board)';
+COMMENT ON TABLE encrypted_tallies IS 'Homomorphic tally and its verifiable decryption';
@@ -0,0 +1,37 @@
+-- +goose Up
+-- TOTP second factor for user accounts. Secrets are AES-GCM encrypted by the
+-- API; recovery codes and login challenge tokens are stored as SHA-256 hashes.
+
+CREATE TABLE IF NOT EXISTS user_totp (
+    user_id        BIGINT PRIMARY KEY REFERENCES user_accounts(id) ON DELETE CASCADE,
+    secret_enc     TEXT NOT NULL,
+    confirmed_at   TIMESTAMPTZ NULL,
+    -- Last accepted 30-second step; a code is never accepted twice.
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at     TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS user_recovery_codes (
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    BIGINT NOT NULL REFERENCES user_accounts(id) ON DELETE CASCADE,
+    code_hash  TEXT NOT NULL,
+    used_at    TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, code_hash)
+);
+
+-- Pending second step of a login: VERIFY for enrolled users, ENROLL for
+-- users whose role requires 2FA but who have not set it up yet.
+CREATE TABLE IF NOT EXISTS auth_mfa_challenges (
+    id          BIGSERIAL PRIMARY KEY,
+    token_hash  TEXT NOT NULL UNIQUE,
+    user_id     BIGINT NOT NULL REFERENCES user_accounts(id) ON DELETE CASCADE,
+    purpose     TEXT NOT NULL CHECK (purpose IN ('VERIFY', 'ENROLL')),
+    attempts    INTEGER NOT NULL DEFAULT 0,
+    expires_at  TIMESTAMPTZ NOT NULL,
+    consumed_at TIMESTAMPTZ NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_auth_mfa_challenges_user ON auth_mfa_challenges (user_id);
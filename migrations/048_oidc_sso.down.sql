@@ -0,0 +1,3 @@
+-- +goose Down
+DROP TABLE IF EXISTS sso_login_states;
+DROP TABLE IF EXISTS user_identities;
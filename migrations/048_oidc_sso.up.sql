@@ -0,0 +1,32 @@
+-- +goose Up
+-- Institutional SSO (OpenID Connect). An identity ties an IdP subject to a
+-- user account; login states carry one authorization-code + PKCE round trip
+-- and the one-time code the frontend exchanges for tokens.
+
+CREATE TABLE IF NOT EXISTS user_identities (
+    id            BIGSERIAL PRIMARY KEY,
+    user_id       BIGINT NOT NULL REFERENCES user_accounts(id) ON DELETE CASCADE,
+    issuer        TEXT NOT NULL,
+    subject       TEXT NOT NULL,
+    email         TEXT NULL,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_login_at TIMESTAMPTZ NULL,
+    UNIQUE (issuer, subject),
+    UNIQUE (user_id, issuer)
+);
+
+CREATE TABLE IF NOT EXISTS sso_login_states (
+    id              BIGSERIAL PRIMARY KEY,
+    state_hash      TEXT NOT NULL UNIQUE,
+    nonce           TEXT NOT NULL,
+    code_verifier   TEXT NOT NULL,
+    expires_at      TIMESTAMPTZ NOT NULL,
+    state_used_at   TIMESTAMPTZ NULL,
+    user_id         BIGINT NULL REFERENCES user_accounts(id) ON DELETE CASCADE,
+    login_code_hash TEXT NULL UNIQUE,
+    code_expires_at TIMESTAMPTZ NULL,
+    code_used_at    TIMESTAMPTZ NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_sso_login_states_expires ON sso_login_states (expires_at);
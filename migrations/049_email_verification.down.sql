@@ -0,0 +1,5 @@
+-- +goose Down
+DROP TABLE IF EXISTS email_verifications;
+ALTER TABLE elections DROP COLUMN IF EXISTS require_email_verification;
+ALTER TABLE voters DROP COLUMN IF EXISTS email_verified_at;
+ALTER TABLE user_accounts DROP COLUMN IF EXISTS email_verification_pending;
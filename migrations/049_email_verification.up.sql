@@ -0,0 +1,30 @@
+-- +goose Up
+-- Self-registered accounts stay pending until the voter proves control of
+-- their email with a 6-digit code or the link sent with it.
+
+ALTER TABLE user_accounts
+    ADD COLUMN IF NOT EXISTS email_verification_pending BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE voters
+    ADD COLUMN IF NOT EXISTS email_verified_at TIMESTAMPTZ NULL;
+
+ALTER TABLE elections
+    ADD COLUMN IF NOT EXISTS require_email_verification BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- One row per email sent; only the newest open row of a user is usable.
+CREATE TABLE IF NOT EXISTS email_verifications (
+    id              BIGSERIAL PRIMARY KEY,
+    user_id         BIGINT NOT NULL REFERENCES user_accounts(id) ON DELETE CASCADE,
+    email           TEXT NOT NULL,
+    code_hash       TEXT NOT NULL,
+    link_token_hash TEXT NOT NULL UNIQUE,
+    attempts        INTEGER NOT NULL DEFAULT 0,
+    expires_at      TIMESTAMPTZ NOT NULL,
+    verified_at     TIMESTAMPTZ NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_verifications_user ON email_verifications (user_id, created_at DESC);
+
+COMMENT ON COLUMN voters.email_verified_at IS 'When the voter proved control of voters.email';
+COMMENT ON COLUMN elections.require_email_verification IS 'Only voters with a verified email may vote';
@@ -0,0 +1,5 @@
+-- +goose Down
+DROP TABLE IF EXISTS eligibility_apply_runs;
+DROP TABLE IF EXISTS election_eligibility_rules;
+DROP TABLE IF EXISTS roster_sync_runs;
+DROP TABLE IF EXISTS academic_roster;
@@ -0,0 +1,72 @@
+-- +goose Up
+-- Academic roster synced from the campus information system, and per-election
+-- eligibility rules evaluated against it to propose DPT changes.
+
+CREATE TABLE IF NOT EXISTS academic_roster (
+    id                 BIGSERIAL PRIMARY KEY,
+    voter_type         TEXT NOT NULL CHECK (voter_type IN ('STUDENT', 'LECTURER', 'STAFF')),
+    identifier         TEXT NOT NULL,      -- NIM, NIDN atau NIP
+    name               TEXT NOT NULL,
+    email              TEXT NULL,
+    faculty_code       TEXT NULL,
+    faculty_name       TEXT NULL,
+    study_program_code TEXT NULL,
+    study_program_name TEXT NULL,
+    cohort_year        INTEGER NULL,
+    semester           INTEGER NULL,
+    academic_status    academic_status NOT NULL DEFAULT 'ACTIVE',
+    raw_status         TEXT NULL,          -- status as written by the source
+    source             TEXT NOT NULL,
+    synced_at          TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    removed_at         TIMESTAMPTZ NULL,   -- missing from the latest full sync
+    created_at         TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at         TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (voter_type, identifier)
+);
+
+CREATE INDEX IF NOT EXISTS idx_academic_roster_identifier ON academic_roster (identifier);
+CREATE INDEX IF NOT EXISTS idx_academic_roster_faculty ON academic_roster (faculty_code);
+
+CREATE TABLE IF NOT EXISTS roster_sync_runs (
+    id          BIGSERIAL PRIMARY KEY,
+    source      TEXT NOT NULL,
+    full_sync   BOOLEAN NOT NULL DEFAULT FALSE,
+    voter_types TEXT[] NOT NULL DEFAULT '{}',
+    total_rows  INTEGER NOT NULL DEFAULT 0,
+    inserted    INTEGER NOT NULL DEFAULT 0,
+    updated     INTEGER NOT NULL DEFAULT 0,
+    unchanged   INTEGER NOT NULL DEFAULT 0,
+    removed     INTEGER NOT NULL DEFAULT 0,
+    rejected    INTEGER NOT NULL DEFAULT 0,
+    errors      JSONB NOT NULL DEFAULT '[]',
+    created_by  BIGINT NULL REFERENCES user_accounts(id) ON DELETE SET NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS election_eligibility_rules (
+    election_id       BIGINT PRIMARY KEY REFERENCES elections(id) ON DELETE CASCADE,
+    voter_types       TEXT[] NOT NULL DEFAULT '{}',
+    academic_statuses TEXT[] NOT NULL DEFAULT '{ACTIVE}',
+    cohort_year_min   INTEGER NULL,
+    cohort_year_max   INTEGER NULL,
+    semester_min      INTEGER NULL,
+    semester_max      INTEGER NULL,
+    faculty_codes     TEXT[] NOT NULL DEFAULT '{}',
+    updated_by        BIGINT NULL REFERENCES user_accounts(id) ON DELETE SET NULL,
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at        TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS eligibility_apply_runs (
+    id          BIGSERIAL PRIMARY KEY,
+    election_id BIGINT NOT NULL REFERENCES elections(id) ON DELETE CASCADE,
+    diff_hash   TEXT NOT NULL,
+    rules       JSONB NOT NULL,
+    added       INTEGER NOT NULL DEFAULT 0,
+    removed     INTEGER NOT NULL DEFAULT 0,
+    flagged     INTEGER NOT NULL DEFAULT 0,
+    applied_by  BIGINT NULL REFERENCES user_accounts(id) ON DELETE SET NULL,
+    applied_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_eligibility_apply_runs_election ON eligibility_apply_runs (election_id, applied_at DESC);
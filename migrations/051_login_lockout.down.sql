@@ -0,0 +1,5 @@
+-- +goose Down
+ALTER TABLE user_accounts
+    DROP COLUMN IF EXISTS locked_until,
+    DROP COLUMN IF EXISTS last_failed_login_at,
+    DROP COLUMN IF EXISTS failed_login_count;
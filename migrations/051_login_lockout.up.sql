@@ -0,0 +1,10 @@
+-- +goose Up
+-- Failed password logins per account, for progressive lockout. The counter
+-- resets on a successful login, an admin password reset or activation.
+
+ALTER TABLE user_accounts
+    ADD COLUMN IF NOT EXISTS failed_login_count INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS last_failed_login_at TIMESTAMPTZ NULL,
+    ADD COLUMN IF NOT EXISTS locked_until TIMESTAMPTZ NULL;
+
+COMMENT ON COLUMN user_accounts.locked_until IS 'Password login is refused until this time';
@@ -0,0 +1,13 @@
+-- +goose Down
+DROP TABLE IF EXISTS tps_assignment_overrides;
+DROP TABLE IF EXISTS tps_allocation_runs;
+DROP INDEX IF EXISTS idx_election_voters_tps;
+
+ALTER TABLE election_voters
+    DROP COLUMN IF EXISTS tps_notified_at,
+    DROP COLUMN IF EXISTS tps_assigned_at,
+    DROP COLUMN IF EXISTS tps_assignment,
+    DROP COLUMN IF EXISTS tps_slot_end,
+    DROP COLUMN IF EXISTS tps_slot_start;
+
+DROP TABLE IF EXISTS tps_allocation_settings;
@@ -0,0 +1,55 @@
+-- +goose Up
+-- Rule-based assignment of TPS-mode voters to TPS locations, with optional
+-- time slots, automatic rebalancing and operator overrides at check-in.
+
+CREATE TABLE IF NOT EXISTS tps_allocation_settings (
+    election_id        BIGINT PRIMARY KEY REFERENCES elections(id) ON DELETE CASCADE,
+    enabled            BOOLEAN NOT NULL DEFAULT FALSE,
+    slot_minutes       INTEGER NOT NULL DEFAULT 0 CHECK (slot_minutes >= 0),
+    auto_rebalance     BOOLEAN NOT NULL DEFAULT TRUE,
+    enforce_assignment BOOLEAN NOT NULL DEFAULT FALSE,
+    notify_voters      BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_by         BIGINT NULL REFERENCES user_accounts(id) ON DELETE SET NULL,
+    created_at         TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at         TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE election_voters
+    ADD COLUMN IF NOT EXISTS tps_slot_start  TIME NULL,
+    ADD COLUMN IF NOT EXISTS tps_slot_end    TIME NULL,
+    ADD COLUMN IF NOT EXISTS tps_assignment  TEXT NULL CHECK (tps_assignment IN ('AUTO', 'MANUAL')),
+    ADD COLUMN IF NOT EXISTS tps_assigned_at TIMESTAMPTZ NULL,
+    ADD COLUMN IF NOT EXISTS tps_notified_at TIMESTAMPTZ NULL;
+
+COMMENT ON COLUMN election_voters.tps_assignment IS 'MANUAL assignments are kept by rebalancing while their TPS stays active';
+
+CREATE INDEX IF NOT EXISTS idx_election_voters_tps ON election_voters (election_id, tps_id);
+
+CREATE TABLE IF NOT EXISTS tps_allocation_runs (
+    id          BIGSERIAL PRIMARY KEY,
+    election_id BIGINT NOT NULL REFERENCES elections(id) ON DELETE CASCADE,
+    trigger     TEXT NOT NULL,      -- MANUAL, TPS_CHANGED
+    mode        TEXT NOT NULL,      -- fill, full
+    total       INTEGER NOT NULL DEFAULT 0,
+    assigned    INTEGER NOT NULL DEFAULT 0,
+    moved       INTEGER NOT NULL DEFAULT 0,
+    unassigned  INTEGER NOT NULL DEFAULT 0,
+    overflow    INTEGER NOT NULL DEFAULT 0,
+    created_by  BIGINT NULL REFERENCES user_accounts(id) ON DELETE SET NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_tps_allocation_runs_election ON tps_allocation_runs (election_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS tps_assignment_overrides (
+    id              BIGSERIAL PRIMARY KEY,
+    election_id     BIGINT NOT NULL REFERENCES elections(id) ON DELETE CASCADE,
+    voter_id        BIGINT NOT NULL REFERENCES voters(id) ON DELETE CASCADE,
+    assigned_tps_id BIGINT NULL REFERENCES tps(id) ON DELETE SET NULL,
+    checkin_tps_id  BIGINT NOT NULL REFERENCES tps(id) ON DELETE CASCADE,
+    operator_id     BIGINT NULL REFERENCES user_accounts(id) ON DELETE SET NULL,
+    reason          TEXT NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_tps_assignment_overrides_tps ON tps_assignment_overrides (checkin_tps_id, created_at DESC);
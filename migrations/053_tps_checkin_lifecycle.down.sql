@@ -0,0 +1,7 @@
+-- +goose Down
+DROP INDEX IF EXISTS idx_tps_checkins_open;
+
+ALTER TABLE tps_checkins
+    DROP COLUMN IF EXISTS expired_at;
+
+DROP TABLE IF EXISTS tps_checkin_settings;
@@ -0,0 +1,23 @@
+-- +goose Up
+-- Expiry of stale TPS check-ins. PENDING check-ins expire a while after the
+-- scan, APPROVED ones at expires_at; both TTLs can be set per election.
+
+CREATE TABLE IF NOT EXISTS tps_checkin_settings (
+    election_id          BIGINT PRIMARY KEY REFERENCES elections(id) ON DELETE CASCADE,
+    pending_ttl_minutes  INTEGER NOT NULL DEFAULT 0 CHECK (pending_ttl_minutes >= 0),
+    approved_ttl_minutes INTEGER NOT NULL DEFAULT 0 CHECK (approved_ttl_minutes >= 0),
+    updated_by           BIGINT NULL REFERENCES user_accounts(id) ON DELETE SET NULL,
+    created_at           TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at           TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON COLUMN tps_checkin_settings.pending_ttl_minutes IS '0 uses the server default (CHECKIN_PENDING_TTL)';
+COMMENT ON COLUMN tps_checkin_settings.approved_ttl_minutes IS '0 uses the server default (CHECKIN_APPROVED_TTL)';
+
+ALTER TABLE tps_checkins
+    ADD COLUMN IF NOT EXISTS expired_at TIMESTAMPTZ NULL;
+
+-- The sweeper only looks at check-ins that can still expire
+CREATE INDEX IF NOT EXISTS idx_tps_checkins_open
+    ON tps_checkins (status, scan_at)
+    WHERE status IN ('PENDING', 'APPROVED');
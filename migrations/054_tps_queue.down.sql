@@ -0,0 +1,8 @@
+-- +goose Down
+DROP INDEX IF EXISTS idx_tps_checkins_tps_voted;
+
+ALTER TABLE tps_checkins
+    DROP COLUMN IF EXISTS called_at,
+    DROP COLUMN IF EXISTS booth_number;
+
+DROP TABLE IF EXISTS tps_queue_settings;
@@ -0,0 +1,20 @@
+-- +goose Up
+-- Check-in queue per TPS: booth count, and the booth each approved
+-- check-in was sent to.
+
+CREATE TABLE IF NOT EXISTS tps_queue_settings (
+    tps_id      BIGINT PRIMARY KEY REFERENCES tps(id) ON DELETE CASCADE,
+    booth_count INTEGER NOT NULL DEFAULT 1 CHECK (booth_count BETWEEN 1 AND 50),
+    updated_by  BIGINT NULL REFERENCES user_accounts(id) ON DELETE SET NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE tps_checkins
+    ADD COLUMN IF NOT EXISTS booth_number INTEGER NULL,
+    ADD COLUMN IF NOT EXISTS called_at    TIMESTAMPTZ NULL;
+
+-- Throughput for wait estimates
+CREATE INDEX IF NOT EXISTS idx_tps_checkins_tps_voted
+    ON tps_checkins (tps_id, voted_at)
+    WHERE voted_at IS NOT NULL;
@@ -0,0 +1,6 @@
+-- +goose Down
+DROP TABLE IF EXISTS tps_session_extensions;
+DROP TABLE IF EXISTS tps_sessions;
+
+ALTER TABLE elections
+    DROP COLUMN IF EXISTS timezone;
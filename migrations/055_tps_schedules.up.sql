@@ -0,0 +1,37 @@
+-- +goose Up
+-- Multi-session TPS schedules. Session dates and hours are local to the
+-- election's timezone; a TPS without sessions keeps using its voting_date,
+-- open_time and close_time as a single session.
+
+ALTER TABLE elections
+    ADD COLUMN IF NOT EXISTS timezone TEXT NOT NULL DEFAULT 'Asia/Jakarta';
+
+CREATE TABLE IF NOT EXISTS tps_sessions (
+    id               BIGSERIAL PRIMARY KEY,
+    tps_id           BIGINT NOT NULL REFERENCES tps(id) ON DELETE CASCADE,
+    session_date     DATE NOT NULL,
+    open_time        TIME NOT NULL,
+    close_time       TIME NOT NULL,
+    extended_minutes INTEGER NOT NULL DEFAULT 0 CHECK (extended_minutes >= 0),
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_tps_sessions_hours CHECK (close_time > open_time)
+);
+
+CREATE INDEX IF NOT EXISTS idx_tps_sessions_tps ON tps_sessions (tps_id, session_date, open_time);
+
+CREATE TABLE IF NOT EXISTS tps_session_extensions (
+    id             BIGSERIAL PRIMARY KEY,
+    session_id     BIGINT NULL REFERENCES tps_sessions(id) ON DELETE SET NULL,
+    tps_id         BIGINT NOT NULL REFERENCES tps(id) ON DELETE CASCADE,
+    session_date   DATE NOT NULL,
+    minutes        INTEGER NOT NULL CHECK (minutes > 0),
+    previous_close TIMESTAMPTZ NOT NULL,
+    new_close      TIMESTAMPTZ NOT NULL,
+    reason         TEXT NOT NULL,
+    extended_by    BIGINT NULL REFERENCES user_accounts(id) ON DELETE SET NULL,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_tps_session_extensions_tps ON tps_session_extensions (tps_id, created_at DESC);
@@ -0,0 +1,6 @@
+-- +goose Down
+ALTER TABLE election_archives
+    DROP COLUMN IF EXISTS tps_incidents;
+
+DROP TABLE IF EXISTS tps_incident_comments;
+DROP TABLE IF EXISTS tps_incidents;
@@ -0,0 +1,46 @@
+-- +goose Up
+-- Incidents reported by TPS operators (power outage, broken scanner, a
+-- disputed DPT status, ...) and the comments admins triage them with.
+-- Imported election bundles keep their incidents as an archive-only record.
+
+CREATE TABLE IF NOT EXISTS tps_incidents (
+    id                 BIGSERIAL PRIMARY KEY,
+    election_id        BIGINT NOT NULL REFERENCES elections(id) ON DELETE CASCADE,
+    tps_id             BIGINT NOT NULL REFERENCES tps(id) ON DELETE CASCADE,
+    category           TEXT NOT NULL,
+    severity           TEXT NOT NULL,
+    status             TEXT NOT NULL DEFAULT 'OPEN',
+    description        TEXT NOT NULL,
+    voter_id           BIGINT NULL REFERENCES voters(id) ON DELETE SET NULL,
+    checkin_id         BIGINT NULL REFERENCES tps_checkins(id) ON DELETE SET NULL,
+    photo_data         BYTEA NULL,
+    photo_content_type TEXT NULL,
+    photo_size         BIGINT NULL,
+    reported_by        BIGINT NULL REFERENCES user_accounts(id) ON DELETE SET NULL,
+    assigned_to        BIGINT NULL REFERENCES user_accounts(id) ON DELETE SET NULL,
+    resolution         TEXT NULL,
+    resolved_by        BIGINT NULL REFERENCES user_accounts(id) ON DELETE SET NULL,
+    resolved_at        TIMESTAMPTZ NULL,
+    created_at         TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at         TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_tps_incidents_category CHECK (category IN ('POWER_OUTAGE', 'EQUIPMENT', 'NETWORK', 'DPT_DISPUTE', 'SECURITY', 'OTHER')),
+    CONSTRAINT chk_tps_incidents_severity CHECK (severity IN ('LOW', 'MEDIUM', 'HIGH', 'CRITICAL')),
+    CONSTRAINT chk_tps_incidents_status CHECK (status IN ('OPEN', 'IN_PROGRESS', 'RESOLVED'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_tps_incidents_election ON tps_incidents (election_id, status, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_tps_incidents_tps ON tps_incidents (tps_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS tps_incident_comments (
+    id          BIGSERIAL PRIMARY KEY,
+    incident_id BIGINT NOT NULL REFERENCES tps_incidents(id) ON DELETE CASCADE,
+    author_id   BIGINT NULL REFERENCES user_accounts(id) ON DELETE SET NULL,
+    body        TEXT NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_tps_incident_comments_incident ON tps_incident_comments (incident_id, created_at);
+
+ALTER TABLE election_archives
+    ADD COLUMN IF NOT EXISTS tps_incidents JSONB NOT NULL DEFAULT '[]'::jsonb;
@@ -0,0 +1,3 @@
+-- +goose Down
+
+DELETE FROM app_settings WHERE key IN ('maintenance_mode', 'maintenance_message');
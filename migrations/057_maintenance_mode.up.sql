@@ -0,0 +1,8 @@
+-- +goose Up
+-- Read-only maintenance mode, switched from the admin settings
+
+INSERT INTO app_settings (key, value, description)
+VALUES
+    ('maintenance_mode', 'false', 'Mode maintenance read-only (true/false)'),
+    ('maintenance_message', '', 'Pesan yang ditampilkan selama mode maintenance')
+ON CONFLICT (key) DO NOTHING;
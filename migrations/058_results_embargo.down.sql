@@ -0,0 +1,7 @@
+-- +goose Down
+DROP TABLE IF EXISTS results_embargo_breaks;
+
+ALTER TABLE elections
+    DROP CONSTRAINT IF EXISTS chk_elections_results_embargo;
+ALTER TABLE elections
+    DROP COLUMN IF EXISTS results_embargo;
@@ -0,0 +1,23 @@
+-- +goose Up
+-- Results embargo: hides candidate-level tallies until voting closes (or the
+-- announcement time passes). Break-glass accesses by super admins are kept
+-- here next to their audit_logs entries.
+
+ALTER TABLE elections
+    ADD COLUMN IF NOT EXISTS results_embargo TEXT NOT NULL DEFAULT 'OFF';
+
+ALTER TABLE elections
+    DROP CONSTRAINT IF EXISTS chk_elections_results_embargo;
+ALTER TABLE elections
+    ADD CONSTRAINT chk_elections_results_embargo
+    CHECK (results_embargo IN ('OFF', 'UNTIL_CLOSE', 'UNTIL_ANNOUNCEMENT'));
+
+CREATE TABLE IF NOT EXISTS results_embargo_breaks (
+    id          BIGSERIAL PRIMARY KEY,
+    election_id BIGINT NOT NULL REFERENCES elections(id) ON DELETE CASCADE,
+    user_id     BIGINT NULL REFERENCES user_accounts(id) ON DELETE SET NULL,
+    reason      TEXT NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_results_embargo_breaks_election ON results_embargo_breaks (election_id, created_at DESC);
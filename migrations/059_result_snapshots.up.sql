@@ -0,0 +1,16 @@
+-- +goose Up
+-- Public results and turnout are served from snapshots computed by a
+-- background job, so announcement-day traffic never reaches votes or
+-- voter_status. payload is kept as JSON (not JSONB) so the bytes served match
+-- the etag hashed from them.
+
+CREATE TABLE IF NOT EXISTS election_result_snapshots (
+    election_id  BIGINT NOT NULL REFERENCES elections(id) ON DELETE CASCADE,
+    kind         TEXT NOT NULL CHECK (kind IN ('RESULTS', 'TURNOUT')),
+    payload      JSON NOT NULL,
+    etag         TEXT NOT NULL,
+    generated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (election_id, kind)
+);
+
+COMMENT ON TABLE election_result_snapshots IS 'Precomputed public results and turnout per election';
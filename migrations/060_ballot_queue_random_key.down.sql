@@ -0,0 +1,3 @@
+-- +goose Down
+ALTER TABLE ballot_queue DROP COLUMN IF EXISTS id;
+ALTER TABLE ballot_queue ADD COLUMN id BIGSERIAL PRIMARY KEY;
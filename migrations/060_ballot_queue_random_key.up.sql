@@ -0,0 +1,8 @@
+-- +goose Up
+-- Queued ballots are keyed by a random UUID instead of a sequence, so the key
+-- does not give away the order ballots were cast in. The ballot is also
+-- written in a transaction of its own (see voting.Service.withBallotTx), so
+-- its xmin is not the voter's transaction id.
+
+ALTER TABLE ballot_queue DROP COLUMN IF EXISTS id;
+ALTER TABLE ballot_queue ADD COLUMN id UUID NOT NULL DEFAULT gen_random_uuid() PRIMARY KEY;
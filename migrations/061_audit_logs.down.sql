@@ -0,0 +1,4 @@
+-- +goose Down
+-- audit_logs may predate this migration and holds the audit trail, so it is
+-- kept.
+SELECT 1;
@@ -0,0 +1,22 @@
+-- +goose Up
+-- audit_logs used to be created outside the migrations on some deployments,
+-- and writers skipped the audit where it was missing. It is now part of the
+-- schema and every audit write fails loudly (see audit.Write).
+
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id             BIGSERIAL PRIMARY KEY,
+    actor_voter_id BIGINT NULL,
+    actor_user_id  BIGINT NULL,
+    action         TEXT NOT NULL,
+    entity_type    TEXT NOT NULL,
+    entity_id      BIGINT NULL,
+    metadata       JSONB NOT NULL DEFAULT '{}'::jsonb,
+    ip_address     TEXT NULL,
+    user_agent     TEXT NULL,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_entity ON audit_logs (entity_type, entity_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_created_at ON audit_logs (created_at);
+
+COMMENT ON TABLE audit_logs IS 'Append-only log of administrative and voting actions';
@@ -8,7 +8,17 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-func NewPostgresPool(ctx context.Context, databaseURL string) (*pgxpool.Pool, error) {
+// Option adjusts the pool configuration before the pool is created.
+type Option func(*pgxpool.Config)
+
+// WithTracer traces every query of the pool's connections.
+func WithTracer(tracer pgx.QueryTracer) Option {
+	return func(config *pgxpool.Config) {
+		config.ConnConfig.Tracer = tracer
+	}
+}
+
+func NewPostgresPool(ctx context.Context, databaseURL string, opts ...Option) (*pgxpool.Pool, error) {
 	config, err := pgxpool.ParseConfig(databaseURL)
 	if err != nil {
 		return nil, err
@@ -26,6 +36,10 @@ func NewPostgresPool(ctx context.Context, databaseURL string) (*pgxpool.Pool, er
 	// This fixes: ERROR: prepared statement "stmtcache_*" already exists (SQLSTATE 42P05)
 	config.ConnConfig.DefaultQueryExecMode = pgx.QueryExecModeSimpleProtocol
 
+	for _, opt := range opts {
+		opt(config)
+	}
+
 	pool, err := pgxpool.NewWithConfig(ctx, config)
 	if err != nil {
 		return nil, err